@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/load"
+)
+
+func main() {
+	var cfg load.Config
+	flag.StringVar(&cfg.URL, "url", "http://localhost:8080", "base URL of the server")
+	flag.DurationVar(&cfg.Duration, "duration", 30*time.Second, "how long the traffic runs")
+	flag.IntVar(&cfg.Workers, "workers", 10, "concurrent clients sending queries and mutations")
+	flag.Float64Var(&cfg.Rate, "rate", 0, "operations per second of all workers, 0 means as fast as they go")
+	flag.Float64Var(&cfg.MutationShare, "mutations", 0.2, "share of the operations that are mutations")
+	flag.IntVar(&cfg.Subscribers, "subscribers", 10, "websocket clients subscribed to the hot posts")
+	flag.IntVar(&cfg.HotPosts, "hot-posts", 10, "number of the newest posts the traffic goes to")
+	flag.IntVar(&cfg.Authors, "authors", 10000, "authors of the mutations are picked from 1 to this")
+	flag.DurationVar(&cfg.Drain, "drain", 5*time.Second, "how long to wait for the last subscription deliveries")
+	flag.Int64Var(&cfg.Seed, "seed", 1, "seed of the traffic mix")
+	flag.Parse()
+	if flag.NArg() != 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err := cfg.Validate(); err != nil {
+		slog.Error("Invalid config", "error", err)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	report, err := load.Run(ctx, cfg)
+	if err != nil {
+		slog.Error("Load test failed", "error", err)
+		os.Exit(1)
+	}
+	if err := report.Print(os.Stdout); err != nil {
+		slog.Error("Failed to print report", "error", err)
+		os.Exit(1)
+	}
+}
@@ -1,19 +1,65 @@
 package main
 
 import (
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
 
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/admin"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/app"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/pkg/config"
 )
 
+const usage = `usage: posts [serve | migrate <command> | admin <command>]
+
+serve runs the server, it is the default.`
+
 func main() {
 	cfg, err := config.LoadConfig()
 	if err != nil {
-		log.Fatal("Failed to load config: ", err)
+		slog.Error("Failed to load config", "error", err)
+		os.Exit(1)
+	}
+
+	args := os.Args[1:]
+	if len(args) == 0 {
+		args = []string{"serve"}
 	}
 
-	a := app.New(cfg)
+	switch args[0] {
+	case "serve":
+		if len(args) != 1 {
+			exitUsage(usage)
+		}
+		app.New(cfg).Run()
+	case "migrate":
+		err := app.Migrate(cfg, args[1:], os.Stdout)
+		if errors.Is(err, app.ErrUsage) {
+			fmt.Fprintln(os.Stderr, err)
+			exitUsage(app.MigrateUsage)
+		}
+		if err != nil {
+			slog.Error("Failed to migrate", "error", err)
+			os.Exit(1)
+		}
+	case "admin":
+		err := app.Admin(cfg, args[1:], os.Stdout)
+		if errors.Is(err, admin.ErrUsage) {
+			fmt.Fprintln(os.Stderr, err)
+			exitUsage(admin.Usage)
+		}
+		if err != nil {
+			slog.Error("Admin command failed", "error", err)
+			os.Exit(1)
+		}
+	default:
+		exitUsage(usage)
+	}
+}
 
-	a.Run()
+// exitUsage prints the usage and exits with the status of a command line error
+func exitUsage(text string) {
+	fmt.Fprintln(os.Stderr, text)
+	os.Exit(2)
 }
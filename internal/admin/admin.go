@@ -0,0 +1,323 @@
+// Package admin implements the operator commands, they work on the data through the repository,
+// so they run against any of the backends
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+)
+
+// Usage describes the admin subcommands
+const Usage = `usage: admin <command>
+
+commands:
+  posts                     list the posts
+  post ID                   print the post with its comments
+  delete-post ID            delete the post with its comments
+  comments POST_ID          list the comments of the post, newest first
+  comment ID                print the comment with its replies
+  delete-comment ID         delete the comment with its replies
+  disable-comments POST_ID  stop accepting comments on the post
+  enable-comments POST_ID   accept comments on the post again
+  export FILE               write the posts with their comment trees as JSON Lines, - is the standard output
+  import [flags] FILE       create the posts and comments of an export, - is the standard input
+
+  generate [flags]          create synthetic posts with comment trees
+
+import flags:
+  -batch N                  posts per unit of work, 100 by default
+  -checkpoint FILE          where the progress is saved to resume a failed import, FILE.checkpoint by default
+
+generate flags:
+  -posts N                  number of posts, 100 by default
+  -fanout F                 mean number of root comments of a post and of replies to a comment, 3 by default
+  -depth N                  levels of comments, 4 by default
+  -max-comments N           comments per post at most, the deepest are cut, 1000 by default
+  -span D                   the posts are created within D before now, 720h by default
+  -spread uniform|recent    spread of the posts over the span, recent puts more of them close to now, recent by default
+  -reply-delay D            mean delay of a comment after its parent, 2h by default
+  -authors N                number of authors, a few of them write most of the content, 1000 by default
+  -seed N                   the same seed generates the same data, 1 by default
+  -batch N                  posts per unit of work, 100 by default`
+
+// ErrUsage is returned for a command line that doesn't match the usage
+var ErrUsage = errors.New("invalid arguments")
+
+// pageSize is how many comments are read at once
+const pageSize = 100
+
+// Admin runs the commands against the repository
+type Admin struct {
+	repo repository.Repository
+	in   io.Reader
+	out  io.Writer
+}
+
+// New creates Admin that reads the standard input of the commands from in and reports to out
+func New(repo repository.Repository, in io.Reader, out io.Writer) *Admin {
+	return &Admin{repo: repo, in: in, out: out}
+}
+
+// Run runs the command with its arguments
+func (a *Admin) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+
+	switch args[0] {
+	case "posts":
+		if len(args) != 1 {
+			return ErrUsage
+		}
+		return a.listPosts(ctx)
+	case "import":
+		return a.importFile(ctx, args[1:])
+	case "generate":
+		return a.generateData(ctx, args[1:])
+	}
+
+	if len(args) != 2 {
+		return ErrUsage
+	}
+	if args[0] == "export" {
+		return a.exportFile(ctx, args[1])
+	}
+
+	id, err := strconv.Atoi(args[1])
+	if err != nil || id < 1 {
+		return fmt.Errorf("%w: %s must be a positive id", ErrUsage, args[1])
+	}
+	switch args[0] {
+	case "post":
+		return a.showPost(ctx, id)
+	case "delete-post":
+		return a.deletePost(ctx, id)
+	case "comments":
+		return a.listComments(ctx, id)
+	case "comment":
+		return a.showComment(ctx, id)
+	case "delete-comment":
+		return a.deleteComment(ctx, id)
+	case "disable-comments":
+		return a.setCommentsDisabled(ctx, id, true)
+	case "enable-comments":
+		return a.setCommentsDisabled(ctx, id, false)
+	default:
+		return ErrUsage
+	}
+}
+
+func (a *Admin) listPosts(ctx context.Context) error {
+	posts, err := a.repo.GetPosts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tAUTHOR\tCREATED\tFLAGS\tTITLE")
+	for _, post := range posts {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\n",
+			post.ID, post.AuthorID, post.CreatedAt.Format(time.RFC3339), postFlags(post), preview(post.Title))
+	}
+	return w.Flush()
+}
+
+func (a *Admin) showPost(ctx context.Context, id int) error {
+	post, err := a.repo.GetPost(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	comments, err := postComments(ctx, a.repo, id)
+	if err != nil {
+		return err
+	}
+	return a.printJSON(record{Post: post, Comments: comments})
+}
+
+func (a *Admin) listComments(ctx context.Context, postID int) error {
+	comments, err := readAll(func(limit, offset int) ([]*domain.Comment, error) {
+		return a.repo.GetCommentsByPost(ctx, postID, limit, offset, true)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	w := tabwriter.NewWriter(a.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tPARENT\tAUTHOR\tCREATED\tFLAGS\tCONTENT")
+	for _, comment := range comments {
+		parent := "-"
+		if comment.ParentID != nil {
+			parent = strconv.Itoa(*comment.ParentID)
+		}
+		flags := "-"
+		if comment.Hidden {
+			flags = "hidden"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%s\n",
+			comment.ID, parent, comment.AuthorID, comment.CreatedAt.Format(time.RFC3339), flags, preview(comment.Content))
+	}
+	return w.Flush()
+}
+
+func (a *Admin) showComment(ctx context.Context, id int) error {
+	comment, err := a.repo.GetComment(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+	replies, err := readAll(func(limit, offset int) ([]*domain.Comment, error) {
+		return a.repo.GetCommentsByParent(ctx, id, limit, offset, true)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get replies: %w", err)
+	}
+	return a.printJSON(struct {
+		Comment *domain.Comment   `json:"comment"`
+		Replies []*domain.Comment `json:"replies"`
+	}{comment, replies})
+}
+
+// deletePost deletes the post and records the deletion in the outbox like a moderator's deletion does,
+// so that subscribers and webhooks learn about it
+func (a *Admin) deletePost(ctx context.Context, id int) error {
+	err := a.repo.WithTx(ctx, func(tx repository.Repository) error {
+		post, err := tx.GetPost(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.DeletePost(ctx, id); err != nil {
+			return err
+		}
+		return addEvent(ctx, tx, domain.EventPostDeleted, post.ID, post.ID, post)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete post: %w", err)
+	}
+
+	fmt.Fprintf(a.out, "deleted post %d\n", id)
+	return nil
+}
+
+// deleteComment deletes the comment thread, the replies deleted with it get no events of their own
+func (a *Admin) deleteComment(ctx context.Context, id int) error {
+	err := a.repo.WithTx(ctx, func(tx repository.Repository) error {
+		comment, err := tx.GetComment(ctx, id)
+		if err != nil {
+			return err
+		}
+		if err := tx.DeleteComment(ctx, id); err != nil {
+			return err
+		}
+		return addEvent(ctx, tx, domain.EventCommentDeleted, comment.PostID, comment.ID, comment)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	fmt.Fprintf(a.out, "deleted comment %d\n", id)
+	return nil
+}
+
+func (a *Admin) setCommentsDisabled(ctx context.Context, postID int, disabled bool) error {
+	err := a.repo.WithTx(ctx, func(tx repository.Repository) error {
+		var err error
+		if disabled {
+			err = tx.DisableComments(ctx, postID)
+		} else {
+			err = tx.EnableComments(ctx, postID)
+		}
+		if err != nil {
+			return err
+		}
+
+		post, err := tx.GetPost(ctx, postID)
+		if err != nil {
+			return err
+		}
+		return addEvent(ctx, tx, domain.EventPostUpdated, post.ID, post.ID, post)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update post: %w", err)
+	}
+
+	if disabled {
+		fmt.Fprintf(a.out, "disabled comments on post %d\n", postID)
+	} else {
+		fmt.Fprintf(a.out, "enabled comments on post %d\n", postID)
+	}
+	return nil
+}
+
+func (a *Admin) printJSON(v any) error {
+	enc := json.NewEncoder(a.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func addEvent(ctx context.Context, repo repository.Repository, eventType domain.EventType, postID, entityID int, entity any) error {
+	event, err := domain.NewEvent(eventType, postID, entityID, entity)
+	if err != nil {
+		return err
+	}
+	if _, err := repo.CreateEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to add event to the outbox: %w", err)
+	}
+	return nil
+}
+
+// readAll reads the pages until a short one
+func readAll(page func(limit, offset int) ([]*domain.Comment, error)) ([]*domain.Comment, error) {
+	all := []*domain.Comment{}
+	for offset := 0; ; offset += pageSize {
+		comments, err := page(pageSize, offset)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, comments...)
+		if len(comments) < pageSize {
+			return all, nil
+		}
+	}
+}
+
+func postFlags(post *domain.Post) string {
+	var flags []string
+	if post.Hidden {
+		flags = append(flags, "hidden")
+	}
+	if post.CommentsDisabled {
+		flags = append(flags, "comments-disabled")
+	}
+	if len(flags) == 0 {
+		return "-"
+	}
+	return strings.Join(flags, ",")
+}
+
+// preview shortens the text to a line of the table
+func preview(text string) string {
+	const maxLen = 60
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > maxLen {
+		return string(runes[:maxLen-3]) + "..."
+	}
+	return text
+}
+
+// openFile opens the file for reading, - is the standard input
+func (a *Admin) openFile(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(a.in), nil
+	}
+	return os.Open(path)
+}
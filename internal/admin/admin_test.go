@@ -0,0 +1,316 @@
+package admin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/in_memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var created = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+// seed creates a post with a thread of a root comment, a hidden reply and a reply to it
+func seed(t *testing.T, repo repository.Repository, title string) *domain.Post {
+	ctx := context.Background()
+
+	post, err := repo.CreatePost(ctx, &domain.Post{Title: title, Content: "content", AuthorID: 1, CreatedAt: created})
+	require.NoError(t, err)
+
+	parentID := 0
+	for i := range 3 {
+		comment := &domain.Comment{PostID: post.ID, AuthorID: 2, Content: "comment", CreatedAt: created.Add(time.Duration(i) * time.Minute)}
+		if parentID != 0 {
+			id := parentID
+			comment.ParentID = &id
+		}
+		comment, err = repo.CreateComment(ctx, comment)
+		require.NoError(t, err)
+		if i == 1 {
+			require.NoError(t, repo.HideComment(ctx, comment.ID))
+		}
+		parentID = comment.ID
+	}
+
+	return post
+}
+
+func run(t *testing.T, repo repository.Repository, args ...string) (string, error) {
+	var out bytes.Buffer
+	err := New(repo, strings.NewReader(""), &out).Run(context.Background(), args)
+	return out.String(), err
+}
+
+func events(t *testing.T, repo repository.Repository) []domain.EventType {
+	claimed, err := repo.ClaimEvents(context.Background(), 100, time.Minute)
+	require.NoError(t, err)
+	var types []domain.EventType
+	for _, event := range claimed {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestAdmin_Usage(t *testing.T) {
+	repo := in_memory.New()
+
+	for _, args := range [][]string{nil, {"posts", "1"}, {"post"}, {"post", "abc"}, {"post", "0"}, {"unknown", "1"}} {
+		_, err := run(t, repo, args...)
+		assert.ErrorIs(t, err, ErrUsage, args)
+	}
+}
+
+func TestAdmin_List(t *testing.T) {
+	repo := in_memory.New()
+	post := seed(t, repo, "first post")
+	require.NoError(t, repo.DisableComments(context.Background(), post.ID))
+
+	out, err := run(t, repo, "posts")
+	require.NoError(t, err)
+	assert.Contains(t, out, "first post")
+	assert.Contains(t, out, "comments-disabled")
+
+	out, err = run(t, repo, "comments", "1")
+	require.NoError(t, err)
+	// the header and the three comments
+	assert.Len(t, strings.Split(strings.TrimSpace(out), "\n"), 4)
+	assert.Contains(t, out, "hidden")
+
+	_, err = run(t, repo, "comments", "2")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestAdmin_Show(t *testing.T) {
+	repo := in_memory.New()
+	seed(t, repo, "post")
+
+	out, err := run(t, repo, "post", "1")
+	require.NoError(t, err)
+	var rec record
+	require.NoError(t, json.Unmarshal([]byte(out), &rec))
+	assert.Equal(t, "post", rec.Post.Title)
+	assert.Len(t, rec.Comments, 3)
+
+	out, err = run(t, repo, "comment", "1")
+	require.NoError(t, err)
+	assert.Contains(t, out, `"replies"`)
+
+	_, err = run(t, repo, "post", "2")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestAdmin_Writes(t *testing.T) {
+	ctx := context.Background()
+	repo := in_memory.New()
+	seed(t, repo, "first")
+	seed(t, repo, "second")
+
+	_, err := run(t, repo, "disable-comments", "1")
+	require.NoError(t, err)
+	post, err := repo.GetPost(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, post.CommentsDisabled)
+
+	_, err = run(t, repo, "enable-comments", "1")
+	require.NoError(t, err)
+	post, err = repo.GetPost(ctx, 1)
+	require.NoError(t, err)
+	assert.False(t, post.CommentsDisabled)
+
+	_, err = run(t, repo, "delete-comment", "2")
+	require.NoError(t, err)
+	comments, err := repo.GetCommentsByPost(ctx, 1, 10, 0, true)
+	require.NoError(t, err)
+	assert.Len(t, comments, 1, "the replies are deleted with the comment")
+
+	_, err = run(t, repo, "delete-post", "2")
+	require.NoError(t, err)
+	_, err = repo.GetPost(ctx, 2)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	assert.Equal(t, []domain.EventType{
+		domain.EventPostUpdated, domain.EventPostUpdated, domain.EventCommentDeleted, domain.EventPostDeleted,
+	}, events(t, repo))
+
+	_, err = run(t, repo, "delete-post", "2")
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestAdmin_ExportImport(t *testing.T) {
+	ctx := context.Background()
+	src := in_memory.New()
+	seed(t, src, "first")
+	seed(t, src, "second")
+	require.NoError(t, src.HidePost(ctx, 2))
+
+	path := filepath.Join(t.TempDir(), "export.jsonl")
+	out, err := run(t, src, "export", path)
+	require.NoError(t, err)
+	assert.Equal(t, "exported 2 posts to "+path+"\n", out)
+
+	// the ids in the destination are shifted by the data that is already there
+	dst := in_memory.New()
+	seed(t, dst, "existing")
+	out, err = run(t, dst, "import", path)
+	require.NoError(t, err)
+	assert.Equal(t, "imported 2 posts and 6 comments\n", out)
+	assert.Empty(t, events(t, dst), "imports record no events")
+
+	posts, err := dst.GetPosts(ctx)
+	require.NoError(t, err)
+	require.Len(t, posts, 3)
+	assert.Equal(t, "second", posts[2].Title)
+	assert.True(t, posts[2].Hidden)
+	assert.Equal(t, created, posts[2].CreatedAt)
+
+	comments, err := postComments(ctx, dst, posts[2].ID)
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+	assert.Nil(t, comments[0].ParentID)
+	assert.Equal(t, comments[0].ID, *comments[1].ParentID)
+	assert.Equal(t, comments[1].ID, *comments[2].ParentID)
+	assert.True(t, comments[1].Hidden)
+	assert.Equal(t, created.Add(2*time.Minute), comments[2].CreatedAt)
+}
+
+func TestAdmin_ImportStdin(t *testing.T) {
+	repo := in_memory.New()
+	input := `{"post":{"id":7,"title":"post","author_id":1},"comments":[{"id":9,"post_id":7,"parent_id":8,"author_id":1}]}`
+
+	var out bytes.Buffer
+	err := New(repo, strings.NewReader(input), &out).Run(context.Background(), []string{"import", "-"})
+	assert.ErrorContains(t, err, "parent 8 of comment 9")
+
+	posts, err := repo.GetPosts(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, posts, "the post is imported with its comments or not at all")
+}
+
+func TestAdmin_ExportPages(t *testing.T) {
+	ctx := context.Background()
+	repo := in_memory.New()
+	for range pageSize + 1 {
+		_, err := repo.CreatePost(ctx, &domain.Post{Title: "post", AuthorID: 1, CreatedAt: created})
+		require.NoError(t, err)
+	}
+	require.NoError(t, repo.DeletePost(ctx, 1))
+
+	out, err := run(t, repo, "export", "-")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, pageSize)
+	assert.True(t, strings.HasPrefix(lines[0], `{"post":{"id":2,`))
+	assert.True(t, strings.HasPrefix(lines[pageSize-1], `{"post":{"id":101,`))
+}
+
+func TestTreeOrder(t *testing.T) {
+	comment := func(id, parentID int) *domain.Comment {
+		c := &domain.Comment{ID: id}
+		if parentID != 0 {
+			c.ParentID = &parentID
+		}
+		return c
+	}
+
+	// 1 -> 4 -> 5, 2 -> 3, 6 replies to a comment that is not there
+	ordered := treeOrder([]*domain.Comment{comment(5, 4), comment(3, 2), comment(6, 9), comment(1, 0), comment(4, 1), comment(2, 0)})
+	var got []int
+	for _, c := range ordered {
+		got = append(got, c.ID)
+	}
+	assert.Equal(t, []int{1, 4, 5, 2, 3, 6}, got)
+}
+
+func TestAdmin_ImportResume(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "export.jsonl")
+	valid := `{"post":{"id":3,"title":"third","author_id":1},"comments":[{"id":1,"post_id":3,"author_id":2},{"id":2,"post_id":3,"parent_id":1,"author_id":2}]}`
+	lines := []string{
+		`{"post":{"id":1,"title":"first","author_id":1},"comments":[]}`,
+		`{"post":{"id":2,"title":"second","author_id":1},"comments":[]}`,
+		`{"post":{"id":3,"title":"third","author_id":1},"comments":[{"id":2,"post_id":3,"parent_id":1,"author_id":2}]}`,
+	}
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644))
+
+	repo := in_memory.New()
+	_, err := run(t, repo, "import", "-batch", "1", path)
+	assert.ErrorContains(t, err, "invalid record 3")
+	checkpoint, err := os.ReadFile(path + ".checkpoint")
+	require.NoError(t, err)
+	assert.Equal(t, "2\n", string(checkpoint))
+
+	// the broken record is fixed and the import resumes after the imported ones
+	lines[2] = valid
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644))
+	out, err := run(t, repo, "import", "-batch", "1", path)
+	require.NoError(t, err)
+	assert.Equal(t, "imported 1 posts and 2 comments, skipped 2 records imported before\n", out)
+
+	posts, err := repo.GetPosts(ctx)
+	require.NoError(t, err)
+	require.Len(t, posts, 3)
+	assert.Equal(t, "third", posts[2].Title)
+	comments, err := postComments(ctx, repo, posts[2].ID)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, comments[0].ID, *comments[1].ParentID)
+
+	// a finished import leaves no checkpoint behind
+	_, err = os.Stat(path + ".checkpoint")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	for _, args := range [][]string{{"import"}, {"import", "-batch", "0", path}, {"import", "-unknown", path}, {"import", path, path}} {
+		_, err := run(t, repo, args...)
+		assert.ErrorIs(t, err, ErrUsage, args)
+	}
+}
+
+func TestAdmin_ImportTwice(t *testing.T) {
+	src := in_memory.New()
+	seed(t, src, "first")
+	seed(t, src, "second")
+	path := filepath.Join(t.TempDir(), "export.jsonl")
+	_, err := run(t, src, "export", path)
+	require.NoError(t, err)
+
+	// the same file is imported into two environments
+	for range 2 {
+		dst := in_memory.New()
+		out, err := run(t, dst, "import", "-batch", "1", path)
+		require.NoError(t, err)
+		assert.Equal(t, "imported 2 posts and 6 comments\n", out)
+
+		posts, err := dst.GetPosts(context.Background())
+		require.NoError(t, err)
+		assert.Len(t, posts, 2)
+	}
+}
+
+func TestAdmin_Generate(t *testing.T) {
+	ctx := context.Background()
+	repo := in_memory.New()
+
+	out, err := run(t, repo, "generate", "-posts", "25", "-fanout", "2", "-depth", "2", "-batch", "10")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out, "generated 25 posts and "), out)
+
+	posts, err := repo.GetPosts(ctx)
+	require.NoError(t, err)
+	assert.Len(t, posts, 25)
+	assert.Empty(t, events(t, repo))
+
+	_, err = run(t, repo, "generate", "-spread", "normal")
+	assert.ErrorIs(t, err, ErrUsage)
+	_, err = run(t, repo, "generate", "extra")
+	assert.ErrorIs(t, err, ErrUsage)
+}
@@ -0,0 +1,325 @@
+package admin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+)
+
+// defaultBatchSize is how many posts an import writes in a unit of work
+const defaultBatchSize = 100
+
+// record is a line of an export: the post with its whole comment tree, every reply comes after its parent
+type record struct {
+	Post     *domain.Post      `json:"post"`
+	Comments []*domain.Comment `json:"comments"`
+}
+
+// exportFile writes a record per post, hidden posts and comments included
+func (a *Admin) exportFile(ctx context.Context, path string) (err error) {
+	out := a.out
+	if path != "-" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create export file: %w", err)
+		}
+		defer func() {
+			if closeErr := f.Close(); closeErr != nil && err == nil {
+				err = fmt.Errorf("failed to write export file: %w", closeErr)
+			}
+		}()
+		out = f
+	}
+
+	w := bufio.NewWriter(out)
+	n, err := export(ctx, a.repo, w)
+	if err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	if path != "-" {
+		fmt.Fprintf(a.out, "exported %d posts to %s\n", n, path)
+	}
+	return nil
+}
+
+// export streams the posts page by page, only a page of posts and the comments of one post are held at once
+func export(ctx context.Context, repo repository.Repository, w io.Writer) (int, error) {
+	enc := json.NewEncoder(w)
+	n := 0
+	for afterID := 0; ; {
+		posts, err := repo.GetPostsAfter(ctx, pageSize, afterID)
+		if err != nil {
+			return n, fmt.Errorf("failed to get posts: %w", err)
+		}
+
+		for _, post := range posts {
+			comments, err := postComments(ctx, repo, post.ID)
+			if errors.Is(err, repository.ErrNotFound) {
+				// deleted since the page was read
+				continue
+			}
+			if err != nil {
+				return n, err
+			}
+			if err := enc.Encode(record{Post: post, Comments: comments}); err != nil {
+				return n, fmt.Errorf("failed to write export: %w", err)
+			}
+			n++
+		}
+
+		if len(posts) < pageSize {
+			return n, nil
+		}
+		afterID = posts[len(posts)-1].ID
+	}
+}
+
+// postComments returns all comments of the post in tree order
+func postComments(ctx context.Context, repo repository.Repository, postID int) ([]*domain.Comment, error) {
+	comments, err := readAll(func(limit, offset int) ([]*domain.Comment, error) {
+		return repo.GetCommentsByPost(ctx, postID, limit, offset, true)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	return treeOrder(comments), nil
+}
+
+// treeOrder orders the comments depth first, every comment is followed by its replies, siblings are ordered by id.
+// A comment whose parent is not among them is treated as a root.
+func treeOrder(comments []*domain.Comment) []*domain.Comment {
+	sort.Slice(comments, func(i, j int) bool { return comments[i].ID < comments[j].ID })
+
+	present := make(map[int]bool, len(comments))
+	for _, c := range comments {
+		present[c.ID] = true
+	}
+	var roots []*domain.Comment
+	replies := make(map[int][]*domain.Comment)
+	for _, c := range comments {
+		if c.ParentID != nil && present[*c.ParentID] {
+			replies[*c.ParentID] = append(replies[*c.ParentID], c)
+		} else {
+			roots = append(roots, c)
+		}
+	}
+
+	ordered := make([]*domain.Comment, 0, len(comments))
+	var visit func(c *domain.Comment)
+	visit = func(c *domain.Comment) {
+		ordered = append(ordered, c)
+		for _, reply := range replies[c.ID] {
+			visit(reply)
+		}
+	}
+	for _, root := range roots {
+		visit(root)
+	}
+	return ordered
+}
+
+// importFile creates the posts and comments of an export in batches, each batch in a unit of work.
+// The repository assigns new ids, the parent links are remapped to them.
+// Imports record no events, the data is not new to anyone.
+//
+// After every batch the number of imported records is saved to the checkpoint file,
+// an import that failed resumes after them when it runs again.
+// The checkpoint is removed once the import is done, so the file can be imported anew.
+func (a *Admin) importFile(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	batchSize := flags.Int("batch", defaultBatchSize, "posts per unit of work")
+	checkpoint := flags.String("checkpoint", "", "file to save the progress to")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	if flags.NArg() != 1 {
+		return ErrUsage
+	}
+	if *batchSize < 1 {
+		return fmt.Errorf("%w: batch must be positive", ErrUsage)
+	}
+	path := flags.Arg(0)
+	if *checkpoint == "" && path != "-" {
+		*checkpoint = path + ".checkpoint"
+	}
+
+	done, err := readCheckpoint(*checkpoint)
+	if err != nil {
+		return err
+	}
+
+	f, err := a.openFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer f.Close()
+
+	im := importer{repo: a.repo, checkpoint: *checkpoint, done: done}
+	dec := json.NewDecoder(bufio.NewReader(f))
+	for n := 1; ; n++ {
+		var rec record
+		err := dec.Decode(&rec)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read record %d: %w", n, err)
+		}
+		if n <= done {
+			continue
+		}
+		if err := validateRecord(&rec); err != nil {
+			return fmt.Errorf("invalid record %d: %w", n, err)
+		}
+
+		im.add(&rec)
+		if len(im.posts) == *batchSize {
+			if err := im.flush(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	if err := im.flush(ctx); err != nil {
+		return err
+	}
+	if err := removeCheckpoint(*checkpoint); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.out, "imported %d posts and %d comments", im.postCount, im.commentCount)
+	if done > 0 {
+		fmt.Fprintf(a.out, ", skipped %d records imported before", done)
+	}
+	fmt.Fprintln(a.out)
+	return nil
+}
+
+// importer collects the records of a batch
+type importer struct {
+	repo       repository.Repository
+	checkpoint string // no checkpoint is kept if empty
+	done       int    // records imported so far, skipped ones included
+
+	posts    []*domain.Post
+	comments []*domain.Comment
+
+	postCount, commentCount int
+}
+
+func (im *importer) add(rec *record) {
+	im.posts = append(im.posts, rec.Post)
+	im.comments = append(im.comments, rec.Comments...)
+}
+
+// flush imports the batch and saves the progress
+func (im *importer) flush(ctx context.Context) error {
+	if len(im.posts) == 0 {
+		return nil
+	}
+
+	if err := im.repo.ImportPosts(ctx, im.posts, im.comments); err != nil {
+		return fmt.Errorf("failed to import records %d to %d: %w", im.done+1, im.done+len(im.posts), err)
+	}
+	im.done += len(im.posts)
+	im.postCount += len(im.posts)
+	im.commentCount += len(im.comments)
+	im.posts, im.comments = nil, nil
+
+	// a failure between the import and the checkpoint means that the batch is imported again on resume
+	return writeCheckpoint(im.checkpoint, im.done)
+}
+
+// validateRecord checks the references within the record, so that a broken record is reported by its number
+func validateRecord(rec *record) error {
+	if rec.Post == nil {
+		return errors.New("no post")
+	}
+	seen := make(map[int]bool, len(rec.Comments))
+	for _, c := range rec.Comments {
+		if c == nil {
+			return errors.New("null comment")
+		}
+		if c.PostID != rec.Post.ID {
+			return fmt.Errorf("comment %d belongs to post %d, not %d", c.ID, c.PostID, rec.Post.ID)
+		}
+		if c.ParentID != nil && !seen[*c.ParentID] {
+			return fmt.Errorf("parent %d of comment %d is not in the post before it", *c.ParentID, c.ID)
+		}
+		if seen[c.ID] {
+			return fmt.Errorf("duplicate comment %d", c.ID)
+		}
+		seen[c.ID] = true
+	}
+	return nil
+}
+
+// readCheckpoint returns the number of records imported before, zero if there is no checkpoint
+func readCheckpoint(path string) (int, error) {
+	if path == "" {
+		return 0, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	done, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || done < 0 {
+		return 0, fmt.Errorf("invalid checkpoint %s: %q", path, data)
+	}
+	return done, nil
+}
+
+// removeCheckpoint removes the checkpoint of a finished import
+func removeCheckpoint(path string) error {
+	if path == "" {
+		return nil
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove checkpoint: %w", err)
+	}
+	return nil
+}
+
+// writeCheckpoint replaces the checkpoint through a rename, so it is never left half written
+func writeCheckpoint(path string, done int) error {
+	if path == "" {
+		return nil
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := fmt.Fprintln(tmp, done); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	return nil
+}
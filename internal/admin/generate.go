@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/generate"
+)
+
+// generateData fills the repository with synthetic posts and comment trees, written in batches like an import
+func (a *Admin) generateData(ctx context.Context, args []string) error {
+	cfg := generate.Config{End: time.Now().UTC()}
+	var spread string
+	flags := flag.NewFlagSet("generate", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	flags.IntVar(&cfg.Posts, "posts", 100, "")
+	flags.Float64Var(&cfg.Fanout, "fanout", 3, "")
+	flags.IntVar(&cfg.Depth, "depth", 4, "")
+	flags.IntVar(&cfg.MaxComments, "max-comments", 1000, "")
+	flags.DurationVar(&cfg.Span, "span", 30*24*time.Hour, "")
+	flags.StringVar(&spread, "spread", string(generate.SpreadRecent), "")
+	flags.DurationVar(&cfg.ReplyDelay, "reply-delay", 2*time.Hour, "")
+	flags.IntVar(&cfg.Authors, "authors", 1000, "")
+	flags.Int64Var(&cfg.Seed, "seed", 1, "")
+	batchSize := flags.Int("batch", defaultBatchSize, "")
+	if err := flags.Parse(args); err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+	if flags.NArg() != 0 {
+		return ErrUsage
+	}
+	if *batchSize < 1 {
+		return fmt.Errorf("%w: batch must be positive", ErrUsage)
+	}
+	cfg.Spread = generate.Spread(spread)
+
+	gen, err := generate.New(cfg)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrUsage, err)
+	}
+
+	im := importer{repo: a.repo}
+	for {
+		post, comments, ok := gen.Next()
+		if !ok {
+			break
+		}
+		im.add(&record{Post: post, Comments: comments})
+		if len(im.posts) == *batchSize {
+			if err := im.flush(ctx); err != nil {
+				return err
+			}
+		}
+	}
+	if err := im.flush(ctx); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.out, "generated %d posts and %d comments\n", im.postCount, im.commentCount)
+	return nil
+}
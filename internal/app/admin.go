@@ -0,0 +1,38 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/admin"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/logging"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/pkg/config"
+)
+
+// Admin runs an admin subcommand against the configured repository and reports the result to out,
+// the logs go to the standard error so that an export to the standard output stays clean
+func Admin(cfg *config.Config, args []string, out io.Writer) (err error) {
+	ctx := context.Background()
+
+	logger, err := logging.New(cfg.Log, os.Stderr)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	repo, db, err := createRepository(ctx, cfg, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create repository: %w", err)
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.Shutdown.DatabaseTimeout)
+		defer cancel()
+		if closeErr := db.close(ctx); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("failed to close database: %w", closeErr))
+		}
+	}()
+
+	return admin.New(repo, os.Stdin, out).Run(ctx, args)
+}
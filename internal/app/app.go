@@ -2,102 +2,466 @@ package app
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"time"
 
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/complexity"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/filter"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/health"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/logging"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/metrics"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/outbox"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/persisted"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/ratelimit"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/cached"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/in_memory"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/observed"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/postgres"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/sqlite"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/resolvers"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/schema"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/server"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/tracing"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/webhook"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/pkg/config"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/pkg/signal"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 const (
 	inMemoryStorage = "IN_MEMORY"
 	postgresStorage = "POSTGRES"
+	sqliteStorage   = "SQLITE"
+
+	memoryRateLimit   = "MEMORY"
+	postgresRateLimit = "POSTGRES"
+
+	logSink  = "LOG"
+	fileSink = "FILE"
+
+	outboxDedupSize = 10000 // dedup ids remembered by the subscriptions sink
 )
 
 // App is the main application structure
 type App struct {
-	config  *config.Config
-	sigQuit chan os.Signal // signal channel for graceful shutdown
-	srv     *server.Server // GraphQL server
+	config   *config.Config
+	sigQuit  chan os.Signal // signal channel for graceful shutdown
+	srv      *server.Server // GraphQL server
+	resolver *resolvers.Resolver
+	db       databases
+	logger   *slog.Logger
+
+	background []func(context.Context) error // stop functions of background jobs, in start order
 }
 
 // New creates a new instance of the application with created repository, resolver, and schema
 func New(cfg *config.Config) *App {
 	ctx := context.Background()
 
-	repo, err := createRepository(ctx, cfg)
+	logger, err := logging.New(cfg.Log, os.Stdout)
+	if err != nil {
+		fatal(slog.Default(), "Failed to create logger", err)
+	}
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing)
+	if err != nil {
+		fatal(logger, "Failed to set up tracing", err)
+	}
+
+	repo, db, err := createRepository(ctx, cfg, logger)
+	if err != nil {
+		fatal(logger, "Failed to create repository", err)
+	}
+
+	var m *metrics.Metrics
+	observers := []observed.Observer{logging.RepositoryObserver(logger)}
+	if cfg.Metrics {
+		m = metrics.New()
+		observers = append(observers, m.RepositoryObserver)
+		addPoolMetrics(m, db)
+	}
+	if tracing.Enabled(cfg.Tracing) {
+		observers = append(observers, tracing.RepositoryObserver)
+	}
+	repo = observed.New(repo, observers...)
+	repo = cached.New(repo, cfg.Cache.Size,
+		cached.WithTTL(cfg.Cache.TTL),
+		cached.WithObserver(m.ObserveCache),
+	)
+
+	relay, closeSinks, err := createOutboxRelay(cfg.Outbox, repo, logger)
 	if err != nil {
-		log.Fatal("Failed to create repository: ", err)
+		fatal(logger, "Failed to create outbox relay", err)
 	}
+
+	limiter, err := createRateLimiter(cfg.RateLimit, db.pool)
+	if err != nil {
+		fatal(logger, "Failed to create rate limiter", err)
+	}
+	filters, err := filter.NewChainFromConfig(cfg.Filter)
+	if err != nil {
+		fatal(logger, "Failed to create content filters", err)
+	}
+
 	// GraphQL resolver
-	resolver := resolvers.NewResolver(repo)
+	resolverOpts := []resolvers.Option{
+		resolvers.WithModerators(cfg.ModeratorIDs...),
+		resolvers.WithFilters(filters),
+		resolvers.WithRateLimiter(limiter),
+		resolvers.WithMetrics(m),
+		resolvers.WithLogger(logger),
+		resolvers.WithEventNotify(relay.Notify),
+	}
+	webhookOpts := []webhook.Option{
+		webhook.WithInterval(cfg.Webhook.PollInterval),
+		webhook.WithBatchSize(cfg.Webhook.BatchSize),
+		webhook.WithTimeout(cfg.Webhook.Timeout),
+		webhook.WithLease(cfg.Webhook.Lease),
+		webhook.WithRetries(cfg.Webhook.MaxAttempts, cfg.Webhook.MinBackoff, cfg.Webhook.MaxBackoff),
+	}
+	if cfg.Webhook.AllowPrivate {
+		webhookOpts = append(webhookOpts, webhook.WithPrivateAddresses())
+	} else {
+		resolverOpts = append(resolverOpts, resolvers.WithWebhookURLCheck(webhook.CheckURL))
+	}
+	resolver := resolvers.NewResolver(repo, resolverOpts...)
+	// the resolver is the in-process event bus, it fans new comments out to the subscribers;
+	// it only gets the events this instance's relay claims, see the outbox package doc
+	relay.AddSink("subscriptions", outbox.Deduplicate(resolver, outboxDedupSize))
+
+	dispatcher := webhook.NewDispatcher(repo, logger, webhookOpts...)
+	relay.AddSink("webhooks", dispatcher)
+	dispatcher.Start()
+	relay.Start()
+	background := []func(context.Context) error{shutdownTracing, closeSinks, dispatcher.Stop, relay.Stop}
+	if db.replicas != nil {
+		db.replicas.Start()
+		background = append(background, db.replicas.Stop)
+	}
 
 	// GraphQL schema
 	sch, err := schema.NewSchema(resolver)
 	if err != nil {
-		log.Fatal("Failed to create new GraphQL schema: ", err)
+		fatal(logger, "Failed to create new GraphQL schema", err)
+	}
+	if tracing.Enabled(cfg.Tracing) {
+		tracing.InstrumentSchema(&sch)
 	}
 
-	srv := server.NewServer(&sch)
+	persistedQueries, err := createPersistedQueries(cfg.PersistedQueries, logger)
+	if err != nil {
+		fatal(logger, "Failed to set up persisted queries", err)
+	}
+
+	checks, err := createHealthChecks(cfg, db)
+	if err != nil {
+		fatal(logger, "Failed to set up health checks", err)
+	}
+
+	serverOpts := []server.Option{
+		server.WithLogger(logger),
+		server.WithHealthChecks(checks...),
+		server.WithMetrics(m),
+		server.WithPersistedQueries(persistedQueries),
+		server.WithCacheControl(cfg.Cache.MaxAge),
+		server.WithReadYourWrites(cfg.Replica.ReadYourWrites),
+		server.WithQueryLimits(complexity.Limits{
+			MaxDepth:   cfg.Query.MaxDepth,
+			MaxAliases: cfg.Query.MaxAliases,
+			MaxCost:    cfg.Query.MaxCost,
+		}, cfg.Query.DefaultListSize),
+	}
+	if cfg.RateLimit.TrustProxyHeaders {
+		serverOpts = append(serverOpts, server.WithTrustedProxies(cfg.RateLimit.TrustedProxies))
+	}
+	if tracing.Enabled(cfg.Tracing) {
+		serverOpts = append(serverOpts, server.WithTracing())
+	}
+	srv := server.NewServer(&sch, serverOpts...)
 
 	return &App{
-		config:  cfg,
-		srv:     srv,
-		sigQuit: signal.GetShutdownChannel(),
+		config:   cfg,
+		srv:      srv,
+		resolver: resolver,
+		db:       db,
+		logger:   logger,
+		sigQuit:  signal.GetShutdownChannel(),
+
+		background: background,
 	}
 }
 
 // Run starts the server and waits for a signal to shut down
 func (a *App) Run() {
 	go func() {
-		log.Println("Starting server on port", a.config.HTTPPort)
+		a.logger.Info("Starting server", "port", a.config.HTTPPort)
 		if err := a.srv.Run(a.config.HTTPPort); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Fatalln("Failed to start server: ", err)
+			fatal(a.logger, "Failed to start server", err)
 		}
 	}()
 
 	<-a.sigQuit
-	log.Println("Gracefully shutting down server")
+	a.logger.Info("Gracefully shutting down server")
 
-	if err := a.srv.Shutdown(context.Background()); err != nil {
-		log.Fatalln("Failed to shutdown the server gracefully: ", err)
+	if err := a.shutdown(); err != nil {
+		fatal(a.logger, "Failed to shutdown the server gracefully", err)
 	}
 
-	log.Println("Server shutdown is successful")
+	a.logger.Info("Server shutdown is successful")
+}
+
+// fatal logs the error and exits
+func fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// databases holds the connections behind the repository, all are nil for in-memory storage without persistence
+type databases struct {
+	pool     *pgxpool.Pool      // postgres
+	replicas *postgres.Replicas // read replicas of the postgres pool, nil if there are none
+	sqlite   *sql.DB
+	memory   *in_memory.Persistent
 }
 
 // createRepository creates a repository based type from the configuration
-func createRepository(ctx context.Context, cfg *config.Config) (repository.Repository, error) {
+// along with the database connections behind it
+func createRepository(ctx context.Context, cfg *config.Config, logger *slog.Logger) (repository.Repository, databases, error) {
 	switch cfg.Repository {
 	case inMemoryStorage:
-		return in_memory.New(), nil
+		if cfg.Memory.Dir == "" {
+			return in_memory.New(), databases{}, nil
+		}
+
+		repo, err := in_memory.Open(cfg.Memory.Dir, logger,
+			in_memory.WithSnapshotInterval(cfg.Memory.SnapshotInterval),
+			in_memory.WithSnapshotAfter(cfg.Memory.SnapshotAfter),
+		)
+		if err != nil {
+			return nil, databases{}, fmt.Errorf("failed to load in-memory repository: %w", err)
+		}
+		logger.Info("In-memory repository is loaded", "dir", cfg.Memory.Dir)
+
+		return repo, databases{memory: repo}, nil
 	case postgresStorage:
-		log.Println("Processing migration...")
-		if err := postgres.ProcessMigration(cfg.MigrationPath, cfg.DbURL); err != nil {
-			return nil, fmt.Errorf("failed to process migration: %w", err)
+		isolation, err := postgres.ParseIsolation(cfg.TxIsolation)
+		if err != nil {
+			return nil, databases{}, err
+		}
+
+		if cfg.AutoMigrate {
+			logger.Info("Processing migration...")
+			if err := postgres.ProcessMigration(cfg.MigrationPath, cfg.DbURL); err != nil {
+				return nil, databases{}, fmt.Errorf("failed to process migration: %w", err)
+			}
+			logger.Info("Migration is successful")
+		}
+
+		logger.Info("Setting up pgx pool...")
+		var tracer pgx.QueryTracer
+		if tracing.Enabled(cfg.Tracing) {
+			tracer = tracing.PgxTracer{}
 		}
-		log.Println("Migration is successful")
+		pool, err := postgres.SetupPgxPool(ctx, cfg.DbURL, cfg.Pool, tracer)
+		if err != nil {
+			return nil, databases{}, fmt.Errorf("failed to setup pgx pool: %w", err)
+		}
+		logger.Info("Pgx pool is set up successfully")
+
+		replicas, err := createReplicas(ctx, cfg.Replica, cfg.Pool, tracer, logger)
+		if err != nil {
+			pool.Close()
+			return nil, databases{}, err
+		}
+		opts := []postgres.Option{postgres.WithIsolation(isolation), postgres.WithQueryTimeout(cfg.Pool.QueryTimeout)}
+		if replicas != nil {
+			opts = append(opts, postgres.WithReplicas(replicas))
+		}
+
+		return postgres.New(pool, opts...), databases{pool: pool, replicas: replicas}, nil
+	case sqliteStorage:
+		if cfg.AutoMigrate {
+			logger.Info("Processing migration...")
+			if err := sqlite.ProcessMigration(cfg.SQLite.MigrationPath, cfg.SQLite.Path); err != nil {
+				return nil, databases{}, fmt.Errorf("failed to process migration: %w", err)
+			}
+			logger.Info("Migration is successful")
+		}
+
+		db, err := sqlite.Open(ctx, cfg.SQLite.Path)
+		if err != nil {
+			return nil, databases{}, err
+		}
+		logger.Info("SQLite database is open", "path", cfg.SQLite.Path)
+
+		return sqlite.New(db), databases{sqlite: db}, nil
+	default:
+		return nil, databases{}, fmt.Errorf("unknown repository type: %s", cfg.Repository)
+	}
+}
+
+// createReplicas creates the read replicas of the Postgres repository, nil if there are none
+func createReplicas(
+	ctx context.Context, cfg config.ReplicaConfig, poolCfg config.PoolConfig, tracer pgx.QueryTracer, logger *slog.Logger,
+) (*postgres.Replicas, error) {
+	if len(cfg.URLs) == 0 {
+		return nil, nil
+	}
+
+	pools := make([]*pgxpool.Pool, 0, len(cfg.URLs))
+	for i, url := range cfg.URLs {
+		pool, err := postgres.SetupPgxPool(ctx, url, poolCfg, tracer)
+		if err != nil {
+			for _, p := range pools {
+				p.Close()
+			}
+			return nil, fmt.Errorf("failed to setup pgx pool of replica %d: %w", i+1, err)
+		}
+		pools = append(pools, pool)
+	}
+
+	replicas := postgres.NewReplicas(pools, logger,
+		postgres.WithCheckInterval(cfg.CheckInterval),
+		postgres.WithMaxLag(cfg.MaxLag),
+		postgres.WithReplicaQueryTimeout(poolCfg.QueryTimeout),
+	)
+	replicas.CheckAll(ctx)
+	logger.Info("Read replicas are set up", "replicas", len(pools), "healthy", replicas.Healthy())
+
+	return replicas, nil
+}
+
+// addPoolMetrics exports the statistics of the Postgres pools
+func addPoolMetrics(m *metrics.Metrics, db databases) {
+	if db.pool == nil {
+		return
+	}
+	m.AddPool("primary", db.pool)
+	if db.replicas != nil {
+		for i, pool := range db.replicas.Pools() {
+			m.AddPool(fmt.Sprintf("replica%d", i+1), pool)
+		}
+	}
+}
+
+// createHealthChecks creates the readiness checks of the repository
+func createHealthChecks(cfg *config.Config, db databases) ([]health.Check, error) {
+	switch {
+	case db.pool != nil:
+		version, err := postgres.LatestMigrationVersion(cfg.MigrationPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return []health.Check{
+			{Name: "repository", Check: db.pool.Ping},
+			{Name: "migrations", Check: func(ctx context.Context) error {
+				return postgres.CheckMigrations(ctx, db.pool, version)
+			}},
+		}, nil
+	case db.sqlite != nil:
+		version, err := postgres.LatestMigrationVersion(cfg.SQLite.MigrationPath)
+		if err != nil {
+			return nil, err
+		}
+
+		return []health.Check{
+			{Name: "repository", Check: db.sqlite.PingContext},
+			{Name: "migrations", Check: func(ctx context.Context) error {
+				return sqlite.CheckMigrations(ctx, db.sqlite, version)
+			}},
+		}, nil
+	default:
+		return []health.Check{{
+			Name:  "repository",
+			Check: func(context.Context) error { return nil }, // in-memory storage is always available
+		}}, nil
+	}
+}
 
-		log.Println("Setting up pgx pool...")
-		pool, err := postgres.SetupPgxPool(ctx, cfg.DbURL)
+// createPersistedQueries creates the persisted query store, loading the manifest if there is one
+func createPersistedQueries(cfg config.PersistedQueriesConfig, logger *slog.Logger) (*persisted.Store, error) {
+	var opts []persisted.Option
+	if cfg.Manifest != "" {
+		manifest, err := persisted.LoadManifest(cfg.Manifest)
 		if err != nil {
-			return nil, fmt.Errorf("failed to setup pgx pool: %w", err)
+			return nil, err
 		}
-		log.Println("Pgx pool is set up successfully")
+		logger.Info("Loaded persisted queries", "count", len(manifest), "manifest", cfg.Manifest)
+		opts = append(opts, persisted.WithManifest(manifest))
+	}
+	if cfg.AllowlistOnly {
+		if cfg.Manifest == "" {
+			return nil, fmt.Errorf("allowlist mode requires a manifest")
+		}
+		opts = append(opts, persisted.AllowlistOnly())
+	}
 
-		return postgres.New(pool), nil
+	return persisted.New(cfg.CacheSize, opts...), nil
+}
+
+// createOutboxRelay creates the outbox relay with the sinks from the configuration,
+// the returned function closes the sinks once the relay is stopped
+func createOutboxRelay(cfg config.OutboxConfig, repo repository.Repository, logger *slog.Logger) (*outbox.Relay, func(context.Context) error, error) {
+	opts := []outbox.Option{
+		outbox.WithInterval(cfg.PollInterval),
+		outbox.WithBatchSize(cfg.BatchSize),
+		outbox.WithLease(cfg.Lease),
+		outbox.WithRetention(cfg.Retention),
+	}
+	closeSinks := func(context.Context) error { return nil }
+	for _, sink := range cfg.Sinks {
+		switch sink {
+		case logSink:
+			opts = append(opts, outbox.WithSink("log", outbox.NewLogSink(logger)))
+		case fileSink:
+			f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to open outbox file: %w", err)
+			}
+			opts = append(opts, outbox.WithSink("file", outbox.NewWriterSink(f)))
+			closeSinks = func(context.Context) error { return f.Close() }
+		default:
+			return nil, nil, fmt.Errorf("unknown outbox sink: %s", sink)
+		}
+	}
+
+	return outbox.NewRelay(repo, logger, opts...), closeSinks, nil
+}
+
+// createRateLimiter creates a rate limiter with the store from the configuration
+func createRateLimiter(cfg config.RateLimitConfig, pool *pgxpool.Pool) (*ratelimit.Limiter, error) {
+	var store ratelimit.Store
+	switch cfg.Store {
+	case memoryRateLimit:
+		store = ratelimit.NewMemoryStore()
+	case postgresRateLimit:
+		if pool == nil {
+			return nil, fmt.Errorf("rate limit store %s requires %s repository", postgresRateLimit, postgresStorage)
+		}
+		store = ratelimit.NewPostgresStore(pool)
 	default:
-		return nil, fmt.Errorf("unknown repository type: %s", cfg.Repository)
+		return nil, fmt.Errorf("unknown rate limit store: %s", cfg.Store)
 	}
+
+	return ratelimit.New(store, map[ratelimit.Operation]ratelimit.Limit{
+		ratelimit.OpCreatePost:    {Burst: cfg.PostsPerHour, Period: time.Hour},
+		ratelimit.OpCreateComment: {Burst: cfg.CommentsPerMinute, Period: time.Minute},
+		ratelimit.OpReport:        {Burst: cfg.ReportsPerHour, Period: time.Hour},
+	}, map[ratelimit.Operation]ratelimit.Limit{
+		ratelimit.OpCreatePost:    {Burst: cfg.PostsPerHourIP, Period: time.Hour},
+		ratelimit.OpCreateComment: {Burst: cfg.CommentsPerMinuteIP, Period: time.Minute},
+		ratelimit.OpReport:        {Burst: cfg.ReportsPerHourIP, Period: time.Hour},
+	}), nil
 }
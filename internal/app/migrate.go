@@ -0,0 +1,116 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/migration"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/pkg/config"
+)
+
+// MigrateUsage describes the migrate subcommands
+const MigrateUsage = `usage: migrate <command>
+
+commands:
+  up          apply all pending migrations
+  down N      roll back the last N migrations
+  goto V      migrate up or down to version V
+  version     print the current version
+  force V     set the version to V without migrating and clear the dirty flag, -1 means none applied
+  create NAME add empty up and down files for a new migration`
+
+// ErrUsage is returned for a command line that doesn't match the usage
+var ErrUsage = errors.New("invalid arguments")
+
+// Migrate runs a migrate subcommand against the database of the configured repository
+// and reports the result to out
+func Migrate(cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+
+	sourceURL, databaseURL, err := migrationURLs(cfg)
+	if err != nil {
+		return err
+	}
+
+	if args[0] == "create" {
+		if len(args) != 2 {
+			return ErrUsage
+		}
+		dir, err := migration.Dir(sourceURL)
+		if err != nil {
+			return err
+		}
+		up, down, err := migration.Create(dir, args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "created %s\ncreated %s\n", up, down)
+		return nil
+	}
+
+	m, err := migration.New(sourceURL, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		err = m.Up()
+	case args[0] == "down" && len(args) == 2:
+		var n int
+		if n, err = strconv.Atoi(args[1]); err != nil {
+			return fmt.Errorf("%w: N must be a number", ErrUsage)
+		}
+		err = m.Down(n)
+	case args[0] == "goto" && len(args) == 2:
+		var version uint64
+		if version, err = strconv.ParseUint(args[1], 10, 0); err != nil {
+			return fmt.Errorf("%w: V must be a version number", ErrUsage)
+		}
+		err = m.Goto(uint(version))
+	case args[0] == "force" && len(args) == 2:
+		var version int
+		if version, err = strconv.Atoi(args[1]); err != nil {
+			return fmt.Errorf("%w: V must be a version number", ErrUsage)
+		}
+		err = m.Force(version)
+	case args[0] == "version" && len(args) == 1:
+	default:
+		return ErrUsage
+	}
+	if err != nil {
+		return err
+	}
+
+	version, dirty, err := m.Version()
+	if err != nil {
+		return err
+	}
+	switch {
+	case version == 0:
+		fmt.Fprintln(out, "no migrations applied")
+	case dirty:
+		fmt.Fprintf(out, "version %d (dirty)\n", version)
+	default:
+		fmt.Fprintf(out, "version %d\n", version)
+	}
+
+	return nil
+}
+
+// migrationURLs returns the migration source and the database of the configured repository
+func migrationURLs(cfg *config.Config) (sourceURL, databaseURL string, err error) {
+	switch cfg.Repository {
+	case postgresStorage:
+		return cfg.MigrationPath, cfg.DbURL, nil
+	case sqliteStorage:
+		return cfg.SQLite.MigrationPath, "sqlite://" + cfg.SQLite.Path, nil
+	default:
+		return "", "", fmt.Errorf("repository %s has no migrations", cfg.Repository)
+	}
+}
@@ -0,0 +1,116 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// shutdownStage is a step of the graceful shutdown, stages run in order and each one has its own timeout
+type shutdownStage struct {
+	name    string
+	timeout time.Duration
+	run     func(ctx context.Context) error
+}
+
+// shutdownStages returns the stages in the order they run:
+// stop accepting and report not ready, drain HTTP requests, close subscriptions,
+// stop background jobs, close the database
+func (a *App) shutdownStages() []shutdownStage {
+	cfg := a.config.Shutdown
+	return []shutdownStage{
+		{name: "readiness", timeout: cfg.ReadinessDelay + time.Second, run: a.markNotReady},
+		{name: "http", timeout: cfg.HTTPTimeout, run: a.srv.Shutdown},
+		{name: "subscriptions", timeout: cfg.SubscriptionsTimeout, run: a.closeSubscriptions},
+		{name: "background jobs", timeout: cfg.BackgroundTimeout, run: a.stopBackground},
+		{name: "database", timeout: cfg.DatabaseTimeout, run: a.closeDatabase},
+	}
+}
+
+// shutdown runs every stage even if an earlier one failed, and returns all errors
+func (a *App) shutdown() error {
+	var errs []error
+	for _, stage := range a.shutdownStages() {
+		start := time.Now()
+		a.logger.Info("Shutdown stage started", "stage", stage.name, "timeout", stage.timeout)
+
+		ctx, cancel := context.WithTimeout(context.Background(), stage.timeout)
+		err := stage.run(ctx)
+		cancel()
+
+		if err != nil {
+			a.logger.Error("Shutdown stage failed", "stage", stage.name, "error", err, "duration", time.Since(start))
+			errs = append(errs, fmt.Errorf("%s: %w", stage.name, err))
+			continue
+		}
+		a.logger.Info("Shutdown stage done", "stage", stage.name, "duration", time.Since(start))
+	}
+
+	return errors.Join(errs...)
+}
+
+// markNotReady reports the server as not ready and gives load balancers the readiness delay to notice
+func (a *App) markNotReady(ctx context.Context) error {
+	a.srv.MarkNotReady()
+
+	select {
+	case <-time.After(a.config.Shutdown.ReadinessDelay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *App) closeSubscriptions(ctx context.Context) error {
+	return errors.Join(
+		a.srv.CloseSubscriptions(ctx),
+		a.resolver.Close(ctx),
+	)
+}
+
+// stopBackground stops the background jobs in the reverse order of their start
+func (a *App) stopBackground(ctx context.Context) error {
+	var errs []error
+	for i := len(a.background) - 1; i >= 0; i-- {
+		errs = append(errs, a.background[i](ctx))
+	}
+	return errors.Join(errs...)
+}
+
+func (a *App) closeDatabase(ctx context.Context) error {
+	return a.db.close(ctx)
+}
+
+// close closes the database connections of the repository
+func (db databases) close(ctx context.Context) error {
+	switch {
+	case db.pool != nil:
+		if db.replicas == nil {
+			return closePool(ctx, db.pool)
+		}
+		return errors.Join(closePool(ctx, db.replicas), closePool(ctx, db.pool))
+	case db.sqlite != nil:
+		return db.sqlite.Close()
+	case db.memory != nil:
+		return db.memory.Close(ctx)
+	default:
+		return nil
+	}
+}
+
+// closePool closes the pool, waiting until the connections in use are released or the context is done
+func closePool(ctx context.Context, pool interface{ Close() }) error {
+	done := make(chan struct{})
+	go func() {
+		pool.Close()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("connections are still in use: %w", ctx.Err())
+	}
+}
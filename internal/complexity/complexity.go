@@ -0,0 +1,251 @@
+package complexity
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// listSizeArgs are arguments that bound the size of a list field
+var listSizeArgs = []string{"first", "limit"}
+
+// Limits are the maximum values allowed for a query, zero disables a limit
+type Limits struct {
+	MaxDepth   int
+	MaxAliases int
+	MaxCost    int
+}
+
+// Result is the static analysis of a query
+type Result struct {
+	Depth   int `json:"depth"`
+	Aliases int `json:"aliases"`
+	Cost    int `json:"cost"`
+}
+
+// LimitError is returned when a query exceeds a limit.
+// It implements gqlerrors.ExtendedError, so the code ends up in the GraphQL response.
+type LimitError struct {
+	Limit string
+	Value int
+	Max   int
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("query %s is %d, the maximum allowed is %d", e.Limit, e.Value, e.Max)
+}
+
+func (e *LimitError) Extensions() map[string]any {
+	return map[string]any{
+		"code":  "QUERY_TOO_COMPLEX",
+		"limit": e.Limit,
+		"value": e.Value,
+		"max":   e.Max,
+	}
+}
+
+// Check returns a LimitError for the first exceeded limit
+func (l Limits) Check(res Result) error {
+	switch {
+	case l.MaxDepth > 0 && res.Depth > l.MaxDepth:
+		return &LimitError{Limit: "depth", Value: res.Depth, Max: l.MaxDepth}
+	case l.MaxAliases > 0 && res.Aliases > l.MaxAliases:
+		return &LimitError{Limit: "aliases", Value: res.Aliases, Max: l.MaxAliases}
+	case l.MaxCost > 0 && res.Cost > l.MaxCost:
+		return &LimitError{Limit: "cost", Value: res.Cost, Max: l.MaxCost}
+	}
+	return nil
+}
+
+// Analyzer computes depth, alias count and cost of validated documents.
+// Every field costs 1, the cost of the selection of a list field is multiplied
+// by its first/limit argument, or by DefaultListSize when the list is unbounded.
+type Analyzer struct {
+	Schema          *graphql.Schema
+	DefaultListSize int
+	Limits          Limits
+}
+
+// Check analyzes the document and checks the result against the limits
+func (a *Analyzer) Check(doc *ast.Document, operationName string, variables map[string]any) (Result, error) {
+	res, err := a.Analyze(doc, operationName, variables)
+	if err != nil {
+		return res, err
+	}
+
+	return res, a.Limits.Check(res)
+}
+
+// analysis is the state of a single document walk
+type analysis struct {
+	*Analyzer
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]any
+	aliases   int
+}
+
+// Analyze walks the operation selected by operationName, the document must be already validated
+func (a *Analyzer) Analyze(doc *ast.Document, operationName string, variables map[string]any) (Result, error) {
+	an := &analysis{
+		Analyzer:  a,
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: variables,
+	}
+
+	var op *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		switch def := def.(type) {
+		case *ast.FragmentDefinition:
+			an.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operationName == "" || (def.Name != nil && def.Name.Value == operationName) {
+				op = def
+			}
+		}
+	}
+	if op == nil {
+		return Result{}, fmt.Errorf("operation %q not found", operationName)
+	}
+
+	var root *graphql.Object
+	switch op.Operation {
+	case ast.OperationTypeQuery:
+		root = a.Schema.QueryType()
+	case ast.OperationTypeMutation:
+		root = a.Schema.MutationType()
+	case ast.OperationTypeSubscription:
+		root = a.Schema.SubscriptionType()
+	}
+	if root == nil {
+		return Result{}, fmt.Errorf("schema doesn't support %s operations", op.Operation)
+	}
+
+	depth, cost := an.selectionSet(op.SelectionSet, root, map[string]bool{})
+
+	return Result{Depth: depth, Aliases: an.aliases, Cost: cost}, nil
+}
+
+// selectionSet returns the depth and the cost of the selection set on the parent type,
+// visiting guards fragment spreads against cycles
+func (an *analysis) selectionSet(set *ast.SelectionSet, parent graphql.Type, visiting map[string]bool) (int, int) {
+	if set == nil {
+		return 0, 0
+	}
+
+	maxDepth, cost := 0, 0
+	for _, sel := range set.Selections {
+		var depth, c int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			depth, c = an.field(sel, parent, visiting)
+		case *ast.InlineFragment:
+			typ := parent
+			if sel.TypeCondition != nil {
+				if t := an.Schema.Type(sel.TypeCondition.Name.Value); t != nil {
+					typ = t
+				}
+			}
+			depth, c = an.selectionSet(sel.SelectionSet, typ, visiting)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			fragment, ok := an.fragments[name]
+			if !ok || visiting[name] {
+				continue
+			}
+			typ := parent
+			if t := an.Schema.Type(fragment.TypeCondition.Name.Value); t != nil {
+				typ = t
+			}
+			visiting[name] = true
+			depth, c = an.selectionSet(fragment.SelectionSet, typ, visiting)
+			delete(visiting, name)
+		}
+		maxDepth = max(maxDepth, depth)
+		cost += c
+	}
+
+	return maxDepth, cost
+}
+
+func (an *analysis) field(f *ast.Field, parent graphql.Type, visiting map[string]bool) (int, int) {
+	// introspection is answered from the schema and is free
+	if strings.HasPrefix(f.Name.Value, "__") {
+		return 0, 0
+	}
+	if f.Alias != nil && f.Alias.Value != f.Name.Value {
+		an.aliases++
+	}
+
+	def := fieldDefinition(parent, f.Name.Value)
+	if def == nil {
+		return 1, 1
+	}
+
+	fieldType, isList := unwrap(def.Type)
+	depth, cost := an.selectionSet(f.SelectionSet, fieldType, visiting)
+	if isList {
+		cost *= an.listSize(f)
+	}
+
+	return depth + 1, cost + 1
+}
+
+// listSize returns the first/limit argument of the field or the default list size
+func (an *analysis) listSize(f *ast.Field) int {
+	for _, arg := range f.Arguments {
+		for _, name := range listSizeArgs {
+			if arg.Name.Value != name {
+				continue
+			}
+			if size, ok := an.intValue(arg.Value); ok && size >= 0 {
+				return size
+			}
+		}
+	}
+	return an.DefaultListSize
+}
+
+func (an *analysis) intValue(v ast.Value) (int, bool) {
+	switch v := v.(type) {
+	case *ast.IntValue:
+		n, err := strconv.Atoi(v.Value)
+		return n, err == nil
+	case *ast.Variable:
+		switch n := an.variables[v.Name.Value].(type) {
+		case int:
+			return n, true
+		case float64: // JSON numbers
+			return int(n), true
+		}
+	}
+	return 0, false
+}
+
+func fieldDefinition(parent graphql.Type, name string) *graphql.FieldDefinition {
+	switch t := parent.(type) {
+	case *graphql.Object:
+		return t.Fields()[name]
+	case *graphql.Interface:
+		return t.Fields()[name]
+	}
+	return nil
+}
+
+// unwrap strips non-null and list wrappers, reporting if the type is a list
+func unwrap(t graphql.Type) (graphql.Type, bool) {
+	isList := false
+	for {
+		switch wrapped := t.(type) {
+		case *graphql.NonNull:
+			t = wrapped.OfType
+		case *graphql.List:
+			isList = true
+			t = wrapped.OfType
+		default:
+			return t, isList
+		}
+	}
+}
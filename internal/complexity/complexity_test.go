@@ -0,0 +1,101 @@
+package complexity
+
+import (
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSchema(t *testing.T) *graphql.Schema {
+	comment := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Comment",
+		Fields: graphql.Fields{
+			"id":      &graphql.Field{Type: graphql.Int},
+			"content": &graphql.Field{Type: graphql.String},
+		},
+	})
+	comment.AddFieldConfig("replies", &graphql.Field{
+		Type: graphql.NewList(comment),
+		Args: graphql.FieldConfigArgument{"first": &graphql.ArgumentConfig{Type: graphql.Int}},
+	})
+
+	post := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Post",
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.Int},
+			"title": &graphql.Field{Type: graphql.String},
+			"comments": &graphql.Field{
+				Type: graphql.NewList(comment),
+				Args: graphql.FieldConfigArgument{"limit": &graphql.ArgumentConfig{Type: graphql.Int}},
+			},
+		},
+	})
+
+	s, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"posts": &graphql.Field{Type: graphql.NewList(post)},
+				"post":  &graphql.Field{Type: post},
+			},
+		}),
+	})
+	require.NoError(t, err)
+
+	return &s
+}
+
+func analyze(t *testing.T, a *Analyzer, query string, variables map[string]any) (Result, error) {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	require.NoError(t, err)
+	require.True(t, graphql.ValidateDocument(a.Schema, doc, nil).IsValid)
+
+	return a.Check(doc, "", variables)
+}
+
+func TestAnalyzer_Cost(t *testing.T) {
+	a := &Analyzer{Schema: testSchema(t), DefaultListSize: 10}
+
+	// post: 1 + id 1 + comments(1 + 5 * (id 1 + replies(1 + 2 * content 1)))
+	res, err := analyze(t, a, `query($n: Int) {
+		post {
+			id
+			comments(limit: 5) { id replies(first: $n) { content } }
+		}
+	}`, map[string]any{"n": float64(2)})
+	assert.NoError(t, err)
+
+	assert.Equal(t, Result{Depth: 4, Aliases: 0, Cost: 1 + 1 + 1 + 5*(1+1+2*1)}, res)
+}
+
+func TestAnalyzer_DefaultListSizeAndFragments(t *testing.T) {
+	a := &Analyzer{Schema: testSchema(t), DefaultListSize: 10}
+
+	res, err := analyze(t, a, `
+		query { first: posts { ...postFields } second: posts { ...postFields } __schema { types { name } } }
+		fragment postFields on Post { id title }
+	`, nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, Result{Depth: 2, Aliases: 2, Cost: 2 * (1 + 10*2)}, res)
+}
+
+func TestAnalyzer_Limits(t *testing.T) {
+	a := &Analyzer{Schema: testSchema(t), DefaultListSize: 10, Limits: Limits{MaxDepth: 3}}
+
+	_, err := analyze(t, a, `{ post { comments { replies { replies { id } } } } }`, nil)
+
+	var limitErr *LimitError
+	assert.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, "depth", limitErr.Limit)
+	assert.Equal(t, 5, limitErr.Value)
+	assert.Equal(t, "QUERY_TOO_COMPLEX", limitErr.Extensions()["code"])
+
+	a.Limits = Limits{MaxCost: 100}
+	_, err = analyze(t, a, `{ posts { comments(limit: 50) { id } } }`, nil)
+	assert.ErrorAs(t, err, &limitErr)
+	assert.Equal(t, "cost", limitErr.Limit)
+}
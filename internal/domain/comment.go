@@ -9,4 +9,5 @@ type Comment struct {
 	AuthorID  int       `json:"author_id"`
 	Content   string    `json:"content"`
 	CreatedAt time.Time `json:"created_at"`
+	Hidden    bool      `json:"hidden"` // hidden by a moderator
 }
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EventType is the kind of change an outbox event describes
+type EventType string
+
+const (
+	EventPostCreated    EventType = "POST_CREATED"
+	EventPostUpdated    EventType = "POST_UPDATED" // comments disabled or hidden by a moderator
+	EventPostDeleted    EventType = "POST_DELETED"
+	EventCommentCreated EventType = "COMMENT_CREATED"
+	EventCommentUpdated EventType = "COMMENT_UPDATED" // hidden by a moderator
+	EventCommentDeleted EventType = "COMMENT_DELETED"
+)
+
+// Event is a change recorded in the outbox in the same unit of work as the change itself
+type Event struct {
+	ID          int             `json:"id"`
+	DedupID     string          `json:"dedup_id"` // stable across redeliveries, receivers use it to drop duplicates
+	Type        EventType       `json:"type"`
+	PostID      int             `json:"post_id"`
+	Payload     json.RawMessage `json:"payload"` // the changed entity
+	CreatedAt   time.Time       `json:"created_at"`
+	PublishedAt *time.Time      `json:"published_at,omitempty"` // nil until every sink got the event
+}
+
+// NewEvent records the change of the entity, the payload is the entity encoded as JSON
+func NewEvent(eventType EventType, postID, entityID int, entity any) (*Event, error) {
+	payload, err := json.Marshal(entity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	// an entity can change several times, so the dedup id is made unique with a random suffix
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, fmt.Errorf("failed to generate event id: %w", err)
+	}
+
+	return &Event{
+		DedupID:   fmt.Sprintf("%s:%d:%s", eventType, entityID, hex.EncodeToString(suffix)),
+		Type:      eventType,
+		PostID:    postID,
+		Payload:   payload,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
@@ -9,4 +9,5 @@ type Post struct {
 	AuthorID         int       `json:"author_id"`
 	CreatedAt        time.Time `json:"created_at"`
 	CommentsDisabled bool      `json:"comments_disabled"`
+	Hidden           bool      `json:"hidden"` // hidden by a moderator
 }
@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+// TargetType is the kind of content a report points to
+type TargetType string
+
+const (
+	TargetPost    TargetType = "POST"
+	TargetComment TargetType = "COMMENT"
+)
+
+// ReportStatus is the state of a report in the moderation queue
+type ReportStatus string
+
+const (
+	ReportOpen      ReportStatus = "OPEN"
+	ReportDismissed ReportStatus = "DISMISSED"
+	ReportActioned  ReportStatus = "ACTIONED"
+)
+
+// ModerationAction is a decision a moderator can take on a report
+type ModerationAction string
+
+const (
+	ActionDismiss   ModerationAction = "DISMISS"
+	ActionHide      ModerationAction = "HIDE"
+	ActionDelete    ModerationAction = "DELETE"
+	ActionBanAuthor ModerationAction = "BAN_AUTHOR"
+)
+
+// SystemReporterID is the reporter id of reports filed automatically by content filters
+const SystemReporterID = 0
+
+type Report struct {
+	ID         int          `json:"id"`
+	TargetType TargetType   `json:"target_type"`
+	TargetID   int          `json:"target_id"`
+	ReporterID int          `json:"reporter_id"`
+	Reason     string       `json:"reason"`
+	Status     ReportStatus `json:"status"`
+	CreatedAt  time.Time    `json:"created_at"`
+	ResolvedAt *time.Time   `json:"resolved_at,omitempty"` // nil while the report is open
+}
+
+// ModerationRecord is an audit trail entry for a moderator decision
+type ModerationRecord struct {
+	ID          int              `json:"id"`
+	ReportID    int              `json:"report_id"`
+	ModeratorID int              `json:"moderator_id"`
+	Action      ModerationAction `json:"action"`
+	TargetType  TargetType       `json:"target_type"`
+	TargetID    int              `json:"target_id"`
+	AuthorID    int              `json:"author_id"` // author of the reported content
+	CreatedAt   time.Time        `json:"created_at"`
+}
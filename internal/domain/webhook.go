@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook is an HTTP callback registered for events on watched posts
+type Webhook struct {
+	ID        int         `json:"id"`
+	OwnerID   int         `json:"owner_id"`
+	URL       string      `json:"url"`
+	Events    []EventType `json:"events"`
+	PostIDs   []int       `json:"post_ids"` // watched posts, empty watches every post
+	Secret    string      `json:"-"`        // key of the HMAC-SHA256 payload signature
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Matches reports if the webhook wants the event
+func (w *Webhook) Matches(event *Event) bool {
+	eventMatches := false
+	for _, t := range w.Events {
+		if t == event.Type {
+			eventMatches = true
+			break
+		}
+	}
+	if !eventMatches {
+		return false
+	}
+
+	if len(w.PostIDs) == 0 {
+		return true
+	}
+	for _, id := range w.PostIDs {
+		if id == event.PostID {
+			return true
+		}
+	}
+	return false
+}
+
+// DeliveryStatus is the state of a webhook delivery
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "PENDING"
+	DeliveryDelivered DeliveryStatus = "DELIVERED"
+	DeliveryDead      DeliveryStatus = "DEAD" // out of attempts, kept for debugging
+)
+
+// WebhookDelivery is an event sent, or to be sent, to a webhook
+type WebhookDelivery struct {
+	ID             int             `json:"id"`
+	WebhookID      int             `json:"webhook_id"`
+	EventID        string          `json:"event_id"` // dedup id of the event, a webhook gets each event once
+	EventType      EventType       `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"` // request body as signed
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status"` // HTTP status of the last attempt, 0 if there was no response
+	LastError      string          `json:"last_error"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+}
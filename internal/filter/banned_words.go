@@ -0,0 +1,108 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BannedWords matches whole words from a list, case-insensitively.
+// On Rewrite the words are masked with asterisks.
+type BannedWords struct {
+	action  Verdict
+	pattern *regexp.Regexp // any of the words, matches are kept only at word boundaries
+}
+
+func NewBannedWords(words []string, action Verdict) *BannedWords {
+	quoted := make([]string, 0, len(words))
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w != "" {
+			quoted = append(quoted, regexp.QuoteMeta(w))
+		}
+	}
+	// the longest word is tried first, so a phrase wins over a word it starts with
+	sort.Slice(quoted, func(i, j int) bool { return len(quoted[i]) > len(quoted[j]) })
+
+	f := &BannedWords{action: action}
+	if len(quoted) > 0 {
+		f.pattern = regexp.MustCompile(`(?i)(?:` + strings.Join(quoted, "|") + `)`)
+	}
+
+	return f
+}
+
+// find returns the byte ranges of the banned words that stand as whole words in the text.
+// The word boundaries are checked here, since \b of regexp only knows ASCII letters.
+func (f *BannedWords) find(text string) [][2]int {
+	var found [][2]int
+	for start := 0; start < len(text); {
+		loc := f.pattern.FindStringIndex(text[start:])
+		if loc == nil {
+			break
+		}
+		i, j := start+loc[0], start+loc[1]
+		before, _ := utf8.DecodeLastRuneInString(text[:i])
+		after, _ := utf8.DecodeRuneInString(text[j:])
+		if !isWordRune(before) && !isWordRune(after) {
+			found = append(found, [2]int{i, j})
+			start = j
+			continue
+		}
+		// the word is part of a longer one, look again from the next character
+		_, size := utf8.DecodeRuneInString(text[i:])
+		start = i + max(size, 1)
+	}
+	return found
+}
+
+// isWordRune reports if the rune belongs to a word, utf8.RuneError stands for the start or the end of the text
+func isWordRune(r rune) bool {
+	if r == utf8.RuneError {
+		return false
+	}
+	return r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r)
+}
+
+func (f *BannedWords) Name() string {
+	return "banned_words"
+}
+
+func (f *BannedWords) Apply(_ context.Context, c *Content) (Result, error) {
+	if f.pattern == nil {
+		return Result{Verdict: Allow}, nil
+	}
+
+	var found string
+	for _, text := range c.texts() {
+		matches := f.find(*text)
+		if len(matches) == 0 {
+			continue
+		}
+		found = (*text)[matches[0][0]:matches[0][1]]
+		if f.action == Rewrite {
+			*text = mask(*text, matches)
+		}
+	}
+	if found == "" {
+		return Result{Verdict: Allow}, nil
+	}
+
+	return Result{Verdict: f.action, Reason: fmt.Sprintf("banned word %q", found)}, nil
+}
+
+// mask replaces every rune of the matches with an asterisk
+func mask(text string, matches [][2]int) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		b.WriteString(text[last:m[0]])
+		b.WriteString(strings.Repeat("*", utf8.RuneCountInString(text[m[0]:m[1]])))
+		last = m[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
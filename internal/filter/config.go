@@ -0,0 +1,62 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/pkg/config"
+)
+
+// NewChainFromConfig builds the filter chain in the order listed in cfg.Chain
+func NewChainFromConfig(cfg config.FilterConfig) (*Chain, error) {
+	filters := make([]Filter, 0, len(cfg.Chain))
+	for _, name := range cfg.Chain {
+		f, err := newFilter(strings.TrimSpace(name), cfg)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+
+	return NewChain(filters...), nil
+}
+
+func newFilter(name string, cfg config.FilterConfig) (Filter, error) {
+	switch name {
+	case "banned_words":
+		action, err := ParseVerdict(cfg.BannedWordsAction)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		return NewBannedWords(cfg.BannedWords, action), nil
+	case "link_limit":
+		action, err := ParseVerdict(cfg.MaxLinksAction)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if cfg.MaxLinks < 0 {
+			return nil, fmt.Errorf("%s: max links must not be negative", name)
+		}
+		return NewLinkLimit(cfg.MaxLinks, action), nil
+	case "repeated_chars":
+		action, err := ParseVerdict(cfg.RepeatedCharsAction)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if cfg.MaxRepeatedChars < 1 {
+			return nil, fmt.Errorf("%s: max repeated chars must be positive", name)
+		}
+		return NewRepeatedChars(cfg.MaxRepeatedChars, action), nil
+	case "duplicate":
+		action, err := ParseVerdict(cfg.DuplicateAction)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if cfg.DuplicateWindow <= 0 {
+			return nil, fmt.Errorf("%s: duplicate window must be positive", name)
+		}
+		return NewDuplicate(cfg.DuplicateWindow, action), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFilter, name)
+	}
+}
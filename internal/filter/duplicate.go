@@ -0,0 +1,95 @@
+package filter
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Duplicate matches content an author has already saved within the window, on the same post and parent.
+// It can't rewrite content, so a Rewrite action is treated as Reject.
+type Duplicate struct {
+	action Verdict
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	seen      map[int]map[[sha256.Size]byte]time.Time // author id -> content hash -> last seen
+	lastSweep time.Time
+}
+
+func NewDuplicate(window time.Duration, action Verdict) *Duplicate {
+	if action == Rewrite {
+		action = Reject
+	}
+
+	return &Duplicate{
+		action: action,
+		window: window,
+		now:    time.Now,
+		seen:   make(map[int]map[[sha256.Size]byte]time.Time),
+	}
+}
+
+func (f *Duplicate) Name() string {
+	return "duplicate"
+}
+
+// Apply rejects content the author has saved within the window, the content is remembered once it is saved too
+func (f *Duplicate) Apply(_ context.Context, c *Content) (Result, error) {
+	key := fmt.Sprintf("%s\x00%d\x00%d\x00%s\x00%s", c.Kind, c.PostID, c.ParentID, normalize(c.Title), normalize(c.Body))
+	hash := sha256.Sum256([]byte(key))
+	authorID := c.AuthorID
+	now := f.now()
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if now.Sub(f.lastSweep) > f.window {
+		f.sweep(now)
+	}
+
+	seenAt, duplicate := f.seen[authorID][hash]
+	if duplicate && now.Sub(seenAt) <= f.window {
+		return Result{Verdict: f.action, Reason: "duplicate content"}, nil
+	}
+
+	c.onSaved = append(c.onSaved, func() { f.record(authorID, hash) })
+	return Result{Verdict: Allow}, nil
+}
+
+// record remembers saved content of the author
+func (f *Duplicate) record(authorID int, hash [sha256.Size]byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	hashes, ok := f.seen[authorID]
+	if !ok {
+		hashes = make(map[[sha256.Size]byte]time.Time)
+		f.seen[authorID] = hashes
+	}
+	hashes[hash] = f.now()
+}
+
+// sweep drops expired entries, so memory is bounded by the activity within the window
+func (f *Duplicate) sweep(now time.Time) {
+	for authorID, hashes := range f.seen {
+		for h, seenAt := range hashes {
+			if now.Sub(seenAt) > f.window {
+				delete(hashes, h)
+			}
+		}
+		if len(hashes) == 0 {
+			delete(f.seen, authorID)
+		}
+	}
+	f.lastSweep = now
+}
+
+// normalize makes trivial edits (case, extra spaces) count as the same content
+func normalize(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
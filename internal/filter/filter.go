@@ -0,0 +1,121 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+)
+
+// Verdict is the decision of a filter about content
+type Verdict string
+
+const (
+	Allow   Verdict = "ALLOW"   // content passes unchanged
+	Reject  Verdict = "REJECT"  // content is not saved
+	Flag    Verdict = "FLAG"    // content is saved and sent to the moderation queue
+	Rewrite Verdict = "REWRITE" // content is saved after the filter changed it
+)
+
+var (
+	ErrRejected       = fmt.Errorf("content rejected")
+	ErrUnknownVerdict = fmt.Errorf("unknown filter action")
+	ErrUnknownFilter  = fmt.Errorf("unknown filter")
+)
+
+// Content is a new post or comment passed through the filters
+type Content struct {
+	Kind     domain.TargetType
+	AuthorID int
+	PostID   int    // post of a comment, zero for posts
+	ParentID int    // parent of a reply, zero for posts and root comments
+	Title    string // empty for comments
+	Body     string
+
+	onSaved []func() // registered by filters that remember the content they let through
+}
+
+// Saved tells the filters that the content was saved. Filters remember content only once it is,
+// so content that failed to save doesn't count against its retry.
+func (c *Content) Saved() {
+	for _, fn := range c.onSaved {
+		fn()
+	}
+	c.onSaved = nil
+}
+
+// texts returns pointers to the text fields of the content, so filters can rewrite them
+func (c *Content) texts() []*string {
+	if c.Kind == domain.TargetPost {
+		return []*string{&c.Title, &c.Body}
+	}
+	return []*string{&c.Body}
+}
+
+// Result is what a single filter decided
+type Result struct {
+	Verdict Verdict
+	Reason  string // why the filter matched, empty for Allow
+}
+
+// Filter checks new content before it is saved.
+// A filter returning Rewrite must have already changed the content.
+type Filter interface {
+	Name() string
+	Apply(ctx context.Context, c *Content) (Result, error)
+}
+
+// Flagged is a filter match that requires moderator review
+type Flagged struct {
+	Filter string
+	Reason string
+}
+
+// Chain runs filters one by one, each filter sees the content rewritten by the previous ones
+type Chain struct {
+	filters []Filter
+}
+
+func NewChain(filters ...Filter) *Chain {
+	return &Chain{filters: filters}
+}
+
+// Run applies the filters to the content, rewriting it in place.
+// It stops on the first rejection and returns an error wrapping ErrRejected.
+func (ch *Chain) Run(ctx context.Context, c *Content) ([]Flagged, error) {
+	if ch == nil {
+		return nil, nil
+	}
+
+	var flags []Flagged
+	for _, f := range ch.filters {
+		res, err := f.Apply(ctx, c)
+		if err != nil {
+			return nil, fmt.Errorf("filter %s failed: %w", f.Name(), err)
+		}
+
+		switch res.Verdict {
+		case Allow, Rewrite:
+		case Reject:
+			return nil, fmt.Errorf("%w by %s: %s", ErrRejected, f.Name(), res.Reason)
+		case Flag:
+			flags = append(flags, Flagged{Filter: f.Name(), Reason: res.Reason})
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrUnknownVerdict, res.Verdict)
+		}
+	}
+
+	return flags, nil
+}
+
+// ParseVerdict converts configured action names to a Verdict
+func ParseVerdict(s string) (Verdict, error) {
+	v := Verdict(strings.ToUpper(strings.TrimSpace(s)))
+	switch v {
+	case Reject, Flag, Rewrite:
+		return v, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrUnknownVerdict, s)
+	}
+}
@@ -0,0 +1,108 @@
+package filter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChain_RewriteBannedWords(t *testing.T) {
+	chain := NewChain(NewBannedWords([]string{"darn"}, Rewrite))
+
+	c := &Content{Kind: domain.TargetPost, AuthorID: 1, Title: "Darn title", Body: "this is darn good, not darned"}
+	flags, err := chain.Run(context.Background(), c)
+	assert.NoError(t, err)
+	assert.Empty(t, flags)
+
+	assert.Equal(t, "**** title", c.Title)
+	assert.Equal(t, "this is **** good, not darned", c.Body)
+}
+
+func TestBannedWords_Unicode(t *testing.T) {
+	f := NewBannedWords([]string{"дурак", "über"}, Rewrite)
+
+	c := &Content{Kind: domain.TargetComment, AuthorID: 1, Body: "Дурак, дураки и ДУРАК! über-cool, überall, kiểm über"}
+	res, err := f.Apply(context.Background(), c)
+	assert.NoError(t, err)
+	assert.Equal(t, Rewrite, res.Verdict)
+	assert.Equal(t, "*****, дураки и *****! ****-cool, überall, kiểm ****", c.Body)
+
+	// a word inside a longer one is not a match, whatever its script
+	res, err = f.Apply(context.Background(), &Content{Kind: domain.TargetComment, AuthorID: 1, Body: "дуракам, одурак"})
+	assert.NoError(t, err)
+	assert.Equal(t, Allow, res.Verdict)
+}
+
+func TestChain_RejectStopsChain(t *testing.T) {
+	chain := NewChain(
+		NewLinkLimit(1, Reject),
+		NewRepeatedChars(3, Flag),
+	)
+
+	c := &Content{Kind: domain.TargetComment, AuthorID: 1, Body: "https://a.com www.b.com !!!!!!"}
+	flags, err := chain.Run(context.Background(), c)
+	assert.ErrorIs(t, err, ErrRejected)
+	assert.Nil(t, flags)
+}
+
+func TestChain_Flag(t *testing.T) {
+	chain := NewChain(NewRepeatedChars(3, Flag), NewLinkLimit(0, Flag))
+
+	c := &Content{Kind: domain.TargetComment, AuthorID: 1, Body: "wooooooow"}
+	flags, err := chain.Run(context.Background(), c)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []Flagged{{Filter: "repeated_chars", Reason: `'o' repeated more than 3 times`}}, flags)
+	assert.Equal(t, "wooooooow", c.Body)
+}
+
+func TestRepeatedChars_Rewrite(t *testing.T) {
+	c := &Content{Kind: domain.TargetComment, Body: "nooooo way!!!!!!"}
+	res, err := NewRepeatedChars(2, Rewrite).Apply(context.Background(), c)
+	assert.NoError(t, err)
+
+	assert.Equal(t, Rewrite, res.Verdict)
+	assert.Equal(t, "noo way!!", c.Body)
+}
+
+func TestLinkLimit_Rewrite(t *testing.T) {
+	c := &Content{Kind: domain.TargetComment, Body: "see https://a.com and http://b.com"}
+	res, err := NewLinkLimit(1, Rewrite).Apply(context.Background(), c)
+	assert.NoError(t, err)
+
+	assert.Equal(t, Rewrite, res.Verdict)
+	assert.Equal(t, "see https://a.com and [link removed]", c.Body)
+}
+
+func TestDuplicate(t *testing.T) {
+	f := NewDuplicate(time.Minute, Reject)
+	now := time.Now()
+	f.now = func() time.Time { return now }
+	apply := func(c *Content) Verdict {
+		res, err := f.Apply(context.Background(), c)
+		assert.NoError(t, err)
+		return res.Verdict
+	}
+
+	first := &Content{Kind: domain.TargetComment, AuthorID: 1, PostID: 1, Body: "Hello  there"}
+	assert.Equal(t, Allow, apply(first))
+
+	// content that wasn't saved can be sent again
+	assert.Equal(t, Allow, apply(&Content{Kind: domain.TargetComment, AuthorID: 1, PostID: 1, Body: "Hello  there"}))
+	first.Saved()
+
+	// same text with different case and spacing from the same author
+	assert.Equal(t, Reject, apply(&Content{Kind: domain.TargetComment, AuthorID: 1, PostID: 1, Body: "hello there"}))
+
+	// another author can say the same, and so can the author on another post or in reply to a comment
+	assert.Equal(t, Allow, apply(&Content{Kind: domain.TargetComment, AuthorID: 2, PostID: 1, Body: "hello there"}))
+	assert.Equal(t, Allow, apply(&Content{Kind: domain.TargetComment, AuthorID: 1, PostID: 2, Body: "hello there"}))
+	assert.Equal(t, Allow, apply(&Content{Kind: domain.TargetComment, AuthorID: 1, PostID: 1, ParentID: 3, Body: "hello there"}))
+
+	// outside the window the content is not a duplicate
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, Allow, apply(&Content{Kind: domain.TargetComment, AuthorID: 1, PostID: 1, Body: "Hello  there"}))
+}
@@ -0,0 +1,51 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+const removedLink = "[link removed]"
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+
+// LinkLimit matches content with more than max links.
+// On Rewrite the links above the limit are removed.
+type LinkLimit struct {
+	action Verdict
+	max    int
+}
+
+func NewLinkLimit(max int, action Verdict) *LinkLimit {
+	return &LinkLimit{max: max, action: action}
+}
+
+func (f *LinkLimit) Name() string {
+	return "link_limit"
+}
+
+func (f *LinkLimit) Apply(_ context.Context, c *Content) (Result, error) {
+	count := 0
+	for _, text := range c.texts() {
+		count += len(linkPattern.FindAllStringIndex(*text, -1))
+	}
+	if count <= f.max {
+		return Result{Verdict: Allow}, nil
+	}
+
+	if f.action == Rewrite {
+		kept := 0
+		for _, text := range c.texts() {
+			*text = linkPattern.ReplaceAllStringFunc(*text, func(link string) string {
+				if kept < f.max {
+					kept++
+					return link
+				}
+				return removedLink
+			})
+		}
+	}
+
+	return Result{Verdict: f.action, Reason: fmt.Sprintf("%d links, at most %d allowed", count, f.max)}, nil
+}
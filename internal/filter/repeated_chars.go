@@ -0,0 +1,68 @@
+package filter
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// RepeatedChars matches spam like "!!!!!!!!!!!!" or "aaaaaaaaaaaa",
+// where one character is repeated more than max times in a row.
+// On Rewrite such runs are shortened to max characters.
+type RepeatedChars struct {
+	action Verdict
+	max    int
+}
+
+func NewRepeatedChars(max int, action Verdict) *RepeatedChars {
+	return &RepeatedChars{max: max, action: action}
+}
+
+func (f *RepeatedChars) Name() string {
+	return "repeated_chars"
+}
+
+func (f *RepeatedChars) Apply(_ context.Context, c *Content) (Result, error) {
+	var found rune
+	for _, text := range c.texts() {
+		collapsed, r := f.collapse(*text)
+		if r == 0 {
+			continue
+		}
+		found = r
+		if f.action == Rewrite {
+			*text = collapsed
+		}
+	}
+	if found == 0 {
+		return Result{Verdict: Allow}, nil
+	}
+
+	return Result{Verdict: f.action, Reason: fmt.Sprintf("%q repeated more than %d times", found, f.max)}, nil
+}
+
+// collapse shortens runs longer than max and returns the first over-long rune, or 0 if there is none
+func (f *RepeatedChars) collapse(text string) (string, rune) {
+	var (
+		sb    strings.Builder
+		prev  rune
+		run   int
+		found rune
+	)
+	for _, r := range text {
+		if r == prev {
+			run++
+		} else {
+			prev, run = r, 1
+		}
+		if run > f.max {
+			if found == 0 {
+				found = r
+			}
+			continue
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String(), found
+}
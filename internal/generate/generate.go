@@ -0,0 +1,201 @@
+// Package generate makes synthetic posts with comment trees, to fill a repository for load tests
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+)
+
+// Spread is how the creation times of the posts fall in the span
+type Spread string
+
+const (
+	SpreadUniform Spread = "uniform" // evenly over the span
+	SpreadRecent  Spread = "recent"  // exponentially more posts close to the end of the span
+)
+
+// maxFanout keeps the number of children sampling cheap and the trees within reason
+const maxFanout = 50
+
+// Config shapes the generated data
+type Config struct {
+	Posts       int
+	Fanout      float64       // mean number of root comments of a post and of replies to a comment, Poisson distributed
+	Depth       int           // levels of comments, 1 means root comments only
+	MaxComments int           // comments of a post, the deepest levels are cut to fit
+	Span        time.Duration // the posts are created within the span before End
+	Spread      Spread
+	ReplyDelay  time.Duration // mean delay of a comment after its parent, exponentially distributed
+	Authors     int           // authors are picked from 1 to Authors, a few of them write most of the content
+	End         time.Time     // no post or comment is created after it
+	Seed        int64         // the same seed makes the same data
+}
+
+// Validate checks that the config makes sense
+func (c Config) Validate() error {
+	var errs []error
+	if c.Posts < 0 {
+		errs = append(errs, fmt.Errorf("number of posts must not be negative, got %d", c.Posts))
+	}
+	if c.Fanout < 0 || c.Fanout > maxFanout {
+		errs = append(errs, fmt.Errorf("fan-out must be from 0 to %d, got %g", maxFanout, c.Fanout))
+	}
+	if c.Depth < 0 {
+		errs = append(errs, fmt.Errorf("depth must not be negative, got %d", c.Depth))
+	}
+	if c.MaxComments < 0 {
+		errs = append(errs, fmt.Errorf("comments per post must not be negative, got %d", c.MaxComments))
+	}
+	if c.Span < 0 || c.ReplyDelay < 0 {
+		errs = append(errs, errors.New("span and reply delay must not be negative"))
+	}
+	if c.Spread != SpreadUniform && c.Spread != SpreadRecent {
+		errs = append(errs, fmt.Errorf("unknown spread %q, use %s or %s", c.Spread, SpreadUniform, SpreadRecent))
+	}
+	if c.Authors < 2 {
+		errs = append(errs, fmt.Errorf("number of authors must be at least 2, got %d", c.Authors))
+	}
+	return errors.Join(errs...)
+}
+
+// Generator makes the posts one at a time. The ids are its own, counting from 1,
+// so the data is meant to be imported with repository.Repository.ImportPosts.
+type Generator struct {
+	cfg     Config
+	rng     *rand.Rand
+	authors *rand.Zipf
+
+	posts     int // posts made so far
+	commentID int
+}
+
+// New creates Generator
+func New(cfg Config) (*Generator, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	rng := rand.New(rand.NewSource(cfg.Seed))
+	return &Generator{
+		cfg:     cfg,
+		rng:     rng,
+		authors: rand.NewZipf(rng, 1.1, 1, uint64(cfg.Authors-1)),
+	}, nil
+}
+
+// Next returns the next post with its comments, every reply comes after its parent.
+// It returns false after the last post.
+func (g *Generator) Next() (*domain.Post, []*domain.Comment, bool) {
+	if g.posts == g.cfg.Posts {
+		return nil, nil, false
+	}
+	g.posts++
+
+	post := &domain.Post{
+		ID:        g.posts,
+		Title:     g.text(3, 8),
+		Content:   g.text(10, 60),
+		AuthorID:  g.author(),
+		CreatedAt: g.postTime(),
+	}
+
+	// breadth first, so the comments cut by MaxComments are the deepest ones
+	type node struct {
+		id, depth int
+		createdAt time.Time
+	}
+	comments := make([]*domain.Comment, 0)
+	queue := []node{{id: 0, depth: 0, createdAt: post.CreatedAt}}
+	for len(queue) > 0 && len(comments) < g.cfg.MaxComments {
+		parent := queue[0]
+		queue = queue[1:]
+		if parent.depth == g.cfg.Depth {
+			continue
+		}
+
+		for range g.children() {
+			if len(comments) == g.cfg.MaxComments {
+				break
+			}
+			g.commentID++
+			comment := &domain.Comment{
+				ID:        g.commentID,
+				PostID:    post.ID,
+				AuthorID:  g.author(),
+				Content:   g.text(3, 40),
+				CreatedAt: g.replyTime(parent.createdAt),
+			}
+			if parent.id != 0 {
+				parentID := parent.id
+				comment.ParentID = &parentID
+			}
+			comments = append(comments, comment)
+			queue = append(queue, node{id: comment.ID, depth: parent.depth + 1, createdAt: comment.CreatedAt})
+		}
+	}
+
+	return post, comments, true
+}
+
+// children samples the number of children of a post or a comment from the Poisson distribution
+func (g *Generator) children() int {
+	// Knuth's method, fine for a small mean
+	limit := math.Exp(-g.cfg.Fanout)
+	n := 0
+	for p := g.rng.Float64(); p > limit; p *= g.rng.Float64() {
+		n++
+	}
+	return n
+}
+
+func (g *Generator) author() int {
+	return int(g.authors.Uint64()) + 1
+}
+
+func (g *Generator) postTime() time.Time {
+	span := float64(g.cfg.Span)
+	var before float64
+	switch g.cfg.Spread {
+	case SpreadRecent:
+		// the mean is a fifth of the span, the tail that falls out of it wraps around
+		before = math.Mod(g.rng.ExpFloat64()*span/5, span)
+	default:
+		before = g.rng.Float64() * span
+	}
+	if math.IsNaN(before) {
+		before = 0
+	}
+	return g.cfg.End.Add(-time.Duration(before)).UTC().Truncate(time.Millisecond)
+}
+
+func (g *Generator) replyTime(parent time.Time) time.Time {
+	delay := time.Duration(g.rng.ExpFloat64() * float64(g.cfg.ReplyDelay))
+	t := parent.Add(delay)
+	if t.After(g.cfg.End) {
+		t = g.cfg.End
+	}
+	return t.UTC().Truncate(time.Millisecond)
+}
+
+var words = strings.Fields(`
+	the a post comment thread reply news today people think really good bad idea agree disagree
+	why how what when about this that with from market release update version service fast slow
+	city weather game team music movie book price change problem question answer time year week
+	new old first last great small big interesting strange funny again still never always maybe`)
+
+// text makes a sentence of min to max words
+func (g *Generator) text(min, max int) string {
+	n := min + g.rng.Intn(max-min+1)
+	b := make([]string, n)
+	for i := range b {
+		b[i] = words[g.rng.Intn(len(words))]
+	}
+	b[0] = strings.ToUpper(b[0][:1]) + b[0][1:]
+	return strings.Join(b, " ") + "."
+}
@@ -0,0 +1,131 @@
+package generate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var end = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func config() Config {
+	return Config{
+		Posts:       50,
+		Fanout:      2,
+		Depth:       3,
+		MaxComments: 1000,
+		Span:        7 * 24 * time.Hour,
+		Spread:      SpreadRecent,
+		ReplyDelay:  time.Hour,
+		Authors:     100,
+		End:         end,
+		Seed:        42,
+	}
+}
+
+func all(t *testing.T, cfg Config) ([]*domain.Post, []*domain.Comment) {
+	g, err := New(cfg)
+	require.NoError(t, err)
+
+	var posts []*domain.Post
+	var comments []*domain.Comment
+	for {
+		post, postComments, ok := g.Next()
+		if !ok {
+			return posts, comments
+		}
+		posts = append(posts, post)
+		comments = append(comments, postComments...)
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	cfg := config()
+	posts, comments := all(t, cfg)
+	require.Len(t, posts, cfg.Posts)
+	assert.NotEmpty(t, comments)
+
+	postTimes := make(map[int]time.Time)
+	for i, post := range posts {
+		assert.Equal(t, i+1, post.ID)
+		assert.False(t, post.CreatedAt.After(end))
+		assert.False(t, post.CreatedAt.Before(end.Add(-cfg.Span)))
+		assert.NotEmpty(t, post.Title)
+		assert.True(t, post.AuthorID >= 1 && post.AuthorID <= cfg.Authors)
+		postTimes[post.ID] = post.CreatedAt
+	}
+
+	// every reply comes after its parent, in the order and in time, and is not deeper than allowed
+	type seen struct {
+		postID, depth int
+		createdAt     time.Time
+	}
+	byID := make(map[int]seen)
+	for i, c := range comments {
+		assert.Equal(t, i+1, c.ID)
+		assert.False(t, c.CreatedAt.After(end))
+		depth := 1
+		parentTime := postTimes[c.PostID]
+		if c.ParentID != nil {
+			parent, ok := byID[*c.ParentID]
+			require.True(t, ok, "parent of comment %d comes before it", c.ID)
+			assert.Equal(t, parent.postID, c.PostID)
+			depth = parent.depth + 1
+			parentTime = parent.createdAt
+		}
+		assert.LessOrEqual(t, depth, cfg.Depth)
+		assert.False(t, c.CreatedAt.Before(parentTime))
+		byID[c.ID] = seen{postID: c.PostID, depth: depth, createdAt: c.CreatedAt}
+	}
+
+	// the same seed makes the same data
+	again, againComments := all(t, cfg)
+	assert.Equal(t, posts, again)
+	assert.Equal(t, comments, againComments)
+}
+
+func TestGenerator_MaxComments(t *testing.T) {
+	cfg := config()
+	cfg.Fanout = 10
+	cfg.MaxComments = 25
+
+	g, err := New(cfg)
+	require.NoError(t, err)
+	for {
+		_, comments, ok := g.Next()
+		if !ok {
+			break
+		}
+		assert.LessOrEqual(t, len(comments), cfg.MaxComments)
+	}
+}
+
+func TestGenerator_Fanout(t *testing.T) {
+	cfg := config()
+	cfg.Posts = 2000
+	cfg.Depth = 1
+
+	posts, comments := all(t, cfg)
+	mean := float64(len(comments)) / float64(len(posts))
+	assert.InDelta(t, cfg.Fanout, mean, 0.2)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, config().Validate())
+
+	for name, change := range map[string]func(c *Config){
+		"posts":   func(c *Config) { c.Posts = -1 },
+		"fanout":  func(c *Config) { c.Fanout = 100 },
+		"depth":   func(c *Config) { c.Depth = -1 },
+		"spread":  func(c *Config) { c.Spread = "normal" },
+		"authors": func(c *Config) { c.Authors = 1 },
+		"span":    func(c *Config) { c.Span = -time.Hour },
+	} {
+		cfg := config()
+		change(&cfg)
+		assert.Error(t, cfg.Validate(), name)
+	}
+}
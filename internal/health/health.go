@@ -0,0 +1,124 @@
+// Package health serves the liveness and readiness endpoints
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusOK           = "ok"
+	StatusDown         = "down"
+	StatusShuttingDown = "shutting_down"
+)
+
+// checkTimeout bounds every readiness check
+const checkTimeout = 2 * time.Second
+
+// Check is a readiness check of one component, it returns an error when the component is not ready
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// ComponentStatus is the readiness of one component
+type ComponentStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the body of the health endpoints
+type Report struct {
+	Status     string                     `json:"status"`
+	Components map[string]ComponentStatus `json:"components,omitempty"`
+}
+
+// Health runs the readiness checks, it reports not ready once shutdown has begun
+type Health struct {
+	checks       []Check
+	shuttingDown atomic.Bool
+}
+
+func New(checks ...Check) *Health {
+	return &Health{checks: checks}
+}
+
+// Add adds readiness checks, it must be called before the endpoints are served
+func (h *Health) Add(checks ...Check) {
+	h.checks = append(h.checks, checks...)
+}
+
+// Shutdown makes the readiness endpoint report not ready
+func (h *Health) Shutdown() {
+	h.shuttingDown.Store(true)
+}
+
+// Ready runs all checks concurrently
+func (h *Health) Ready(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	report := Report{
+		Status:     StatusOK,
+		Components: make(map[string]ComponentStatus, len(h.checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, check := range h.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+			status := ComponentStatus{Status: StatusOK}
+			if err := check.Check(ctx); err != nil {
+				status = ComponentStatus{Status: StatusDown, Error: err.Error()}
+			}
+
+			mu.Lock()
+			report.Components[check.Name] = status
+			if status.Status != StatusOK {
+				report.Status = StatusDown
+			}
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	if h.shuttingDown.Load() {
+		report.Status = StatusShuttingDown
+	}
+
+	return report
+}
+
+// LivenessHandler reports that the process is alive
+func (h *Health) LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, Report{Status: StatusOK})
+	})
+}
+
+// ReadinessHandler reports if the service can serve requests, with the status of every component
+func (h *Health) ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := h.Ready(r.Context())
+
+		status := http.StatusOK
+		if report.Status != StatusOK {
+			status = http.StatusServiceUnavailable
+		}
+		writeReport(w, status, report)
+	})
+}
+
+func writeReport(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,59 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ready(t *testing.T, h *Health) (int, Report) {
+	w := httptest.NewRecorder()
+	h.ReadinessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var report Report
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &report))
+	return w.Code, report
+}
+
+func TestReadiness(t *testing.T) {
+	var dbErr error
+	h := New(
+		Check{Name: "repository", Check: func(context.Context) error { return dbErr }},
+		Check{Name: "subscriptions", Check: func(context.Context) error { return nil }},
+	)
+
+	code, report := ready(t, h)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, Report{Status: StatusOK, Components: map[string]ComponentStatus{
+		"repository":    {Status: StatusOK},
+		"subscriptions": {Status: StatusOK},
+	}}, report)
+
+	dbErr = errors.New("connection refused")
+	code, report = ready(t, h)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusDown, report.Status)
+	assert.Equal(t, ComponentStatus{Status: StatusDown, Error: "connection refused"}, report.Components["repository"])
+
+	dbErr = nil
+	h.Shutdown()
+	code, report = ready(t, h)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StatusShuttingDown, report.Status)
+}
+
+func TestLiveness(t *testing.T) {
+	h := New(Check{Name: "repository", Check: func(context.Context) error { return errors.New("down") }})
+	h.Shutdown()
+
+	w := httptest.NewRecorder()
+	h.LivenessHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "ok"}`, w.Body.String())
+}
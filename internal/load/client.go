@@ -0,0 +1,145 @@
+package load
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// httpTimeout bounds a GraphQL request
+const httpTimeout = 10 * time.Second
+
+// client sends GraphQL requests to /root
+type client struct {
+	url  string
+	http *http.Client
+}
+
+func newClient(baseURL string, workers int) *client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = workers
+	return &client{
+		url:  strings.TrimSuffix(baseURL, "/") + "/root",
+		http: &http.Client{Transport: transport, Timeout: httpTimeout},
+	}
+}
+
+// do runs the query and decodes its data into data, unless it is nil.
+// A response with errors is an error, the first one is returned.
+func (c *client) do(ctx context.Context, query string, data any) error {
+	body, err := json.Marshal(map[string]string{"query": query})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 10<<20)).Decode(&res); err != nil {
+		return fmt.Errorf("failed to decode response with status %d: %w", resp.StatusCode, err)
+	}
+	if len(res.Errors) > 0 {
+		return errors.New(res.Errors[0].Message)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	if data != nil {
+		return json.Unmarshal(res.Data, data)
+	}
+	return nil
+}
+
+// subscriptionQuery is what the subscribers ask for, the content identifies the comment
+const subscriptionQuery = "subscription { comment { id postId content } }"
+
+// subscriber is a websocket client subscribed to the comments of the hot posts
+type subscriber struct {
+	conn *websocket.Conn
+	done chan struct{}
+}
+
+// subscribe connects the subscribers, they report the comments they get to the driver
+func (d *driver) subscribe(ctx context.Context) ([]*subscriber, error) {
+	url := strings.TrimSuffix(d.cfg.URL, "/") + "/subscriptions"
+	url = "ws" + strings.TrimPrefix(url, "http")
+	dialer := websocket.Dialer{
+		HandshakeTimeout: httpTimeout,
+		Subprotocols:     []string{"graphql-ws"},
+	}
+	message, err := json.Marshal(map[string]any{"posts": d.hot, "query": subscriptionQuery})
+	if err != nil {
+		return nil, err
+	}
+
+	subscribers := make([]*subscriber, 0, d.cfg.Subscribers)
+	for range d.cfg.Subscribers {
+		conn, _, err := dialer.DialContext(ctx, url, nil)
+		if err == nil {
+			err = conn.WriteMessage(websocket.TextMessage, message)
+		}
+		if err != nil {
+			for _, s := range subscribers {
+				s.close()
+			}
+			return nil, fmt.Errorf("failed to subscribe: %w", err)
+		}
+
+		s := &subscriber{conn: conn, done: make(chan struct{})}
+		go s.read(d)
+		subscribers = append(subscribers, s)
+	}
+	return subscribers, nil
+}
+
+// read reports the received comments until the connection is closed
+func (s *subscriber) read(d *driver) {
+	defer close(s.done)
+	for {
+		_, p, err := s.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		at := time.Now()
+
+		var msg struct {
+			Payload struct {
+				Comment struct {
+					Content string `json:"content"`
+				} `json:"comment"`
+			} `json:"payload"`
+		}
+		if json.Unmarshal(p, &msg) == nil {
+			d.delivered(msg.Payload.Comment.Content, at)
+		}
+	}
+}
+
+func (s *subscriber) close() {
+	s.conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(time.Second))
+	s.conn.Close()
+	<-s.done
+}
@@ -0,0 +1,316 @@
+// Package load drives mixed query, mutation and subscription traffic against a running server
+// and measures the latencies and the fan-out delay of new comments to the subscribers
+package load
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config of a run
+type Config struct {
+	URL           string        // base URL of the server, like http://localhost:8080
+	Duration      time.Duration // how long the traffic runs
+	Workers       int           // concurrent clients sending queries and mutations
+	Rate          float64       // operations per second of all workers together, 0 means as fast as they go
+	MutationShare float64       // share of the operations that are mutations
+	Subscribers   int           // websocket clients subscribed to the comments of the hot posts
+	HotPosts      int           // the traffic goes to the newest posts, created if there are none
+	Authors       int           // authors of the mutations are picked from 1 to Authors, spread to stay under the rate limits
+	Drain         time.Duration // how long the subscribers wait for the last comments after the traffic stops
+	Seed          int64
+}
+
+// Validate checks that the config makes sense
+func (c Config) Validate() error {
+	var errs []error
+	if c.URL == "" {
+		errs = append(errs, errors.New("URL is required"))
+	}
+	if c.Duration <= 0 {
+		errs = append(errs, fmt.Errorf("duration must be positive, got %s", c.Duration))
+	}
+	if c.Workers < 1 {
+		errs = append(errs, fmt.Errorf("number of workers must be positive, got %d", c.Workers))
+	}
+	if c.Rate < 0 {
+		errs = append(errs, fmt.Errorf("rate must not be negative, got %g", c.Rate))
+	}
+	if c.MutationShare < 0 || c.MutationShare > 1 {
+		errs = append(errs, fmt.Errorf("mutation share must be from 0 to 1, got %g", c.MutationShare))
+	}
+	if c.Subscribers < 0 {
+		errs = append(errs, fmt.Errorf("number of subscribers must not be negative, got %d", c.Subscribers))
+	}
+	if c.HotPosts < 1 {
+		errs = append(errs, fmt.Errorf("number of hot posts must be positive, got %d", c.HotPosts))
+	}
+	if c.Authors < 1 {
+		errs = append(errs, fmt.Errorf("number of authors must be positive, got %d", c.Authors))
+	}
+	if c.Drain < 0 {
+		errs = append(errs, fmt.Errorf("drain must not be negative, got %s", c.Drain))
+	}
+	return errors.Join(errs...)
+}
+
+// subscribeSettle gives the server time to register the subscriptions before the traffic starts
+const subscribeSettle = 500 * time.Millisecond
+
+// maxKnownComments bounds the comments of a post remembered to reply to
+const maxKnownComments = 100
+
+// driver is the state of a run shared by the workers and the subscribers
+type driver struct {
+	cfg     Config
+	client  *client
+	rec     *recorder
+	fanout  *recorder
+	hot     []int
+	runID   string
+	seq     atomic.Int64
+	created atomic.Int64 // comments created while there are subscribers
+
+	mu    sync.Mutex
+	known map[int][]int // comments of the hot posts, to reply to
+
+	sent sync.Map // token of a comment -> time it was sent
+}
+
+// Run drives the traffic for the duration and returns the measurements
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	d := &driver{
+		cfg:    cfg,
+		client: newClient(cfg.URL, cfg.Workers),
+		rec:    newRecorder(),
+		fanout: newRecorder(),
+		runID:  fmt.Sprintf("%x", rand.New(rand.NewSource(time.Now().UnixNano())).Uint32()),
+		known:  make(map[int][]int),
+	}
+
+	hot, err := d.hotPosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.hot = hot
+
+	subscribers, err := d.subscribe(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(subscribers) > 0 {
+		time.Sleep(subscribeSettle)
+	}
+
+	start := time.Now()
+	d.drive(ctx)
+	elapsed := time.Since(start)
+
+	if len(subscribers) > 0 {
+		d.drain(ctx)
+	}
+	for _, s := range subscribers {
+		s.close()
+	}
+
+	fanout := d.fanout.stats()["fanout"]
+	return &Report{
+		Duration:   elapsed,
+		Operations: d.rec.stats(),
+		Fanout:     fanout,
+		Expected:   int(d.created.Load()) * len(subscribers),
+	}, nil
+}
+
+// hotPosts returns the newest posts, creating them if there are none
+func (d *driver) hotPosts(ctx context.Context) ([]int, error) {
+	var res struct {
+		Posts []struct {
+			ID int `json:"id"`
+		} `json:"posts"`
+	}
+	if err := d.client.do(ctx, "{ posts { id } }", &res); err != nil {
+		return nil, fmt.Errorf("failed to get posts: %w", err)
+	}
+
+	ids := make([]int, 0, d.cfg.HotPosts)
+	for i := max(len(res.Posts)-d.cfg.HotPosts, 0); i < len(res.Posts); i++ {
+		ids = append(ids, res.Posts[i].ID)
+	}
+	if len(ids) > 0 {
+		return ids, nil
+	}
+
+	rng := rand.New(rand.NewSource(d.cfg.Seed))
+	for range d.cfg.HotPosts {
+		id, err := d.createPost(ctx, rng)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create post: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// drive runs the workers until the duration is over or the context is done.
+// The operations in flight at the end are finished, so that every created comment is known.
+func (d *driver) drive(ctx context.Context) {
+	stop, cancel := context.WithTimeout(ctx, d.cfg.Duration)
+	defer cancel()
+
+	var tokens <-chan time.Time
+	if d.cfg.Rate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / d.cfg.Rate))
+		defer ticker.Stop()
+		tokens = ticker.C
+	}
+
+	var wg sync.WaitGroup
+	for i := range d.cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rng := rand.New(rand.NewSource(d.cfg.Seed + int64(i)))
+			for {
+				if tokens != nil {
+					select {
+					case <-tokens:
+					case <-stop.Done():
+						return
+					}
+				}
+				if stop.Err() != nil {
+					return
+				}
+				d.operation(ctx, rng)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// operation runs an operation picked by the traffic mix and records its latency
+func (d *driver) operation(ctx context.Context, rng *rand.Rand) {
+	postID := d.hot[rng.Intn(len(d.hot))]
+	start := time.Now()
+
+	var name string
+	var err error
+	if rng.Float64() < d.cfg.MutationShare {
+		// mostly comments, they are what the subscribers get
+		if rng.Intn(10) == 0 {
+			name = "createPost"
+			_, err = d.createPost(ctx, rng)
+		} else {
+			name = "createComment"
+			err = d.createComment(ctx, rng, postID, start)
+		}
+	} else {
+		switch n := rng.Intn(20); {
+		case n == 0:
+			name = "posts"
+			err = d.client.do(ctx, "{ posts { id title } }", nil)
+		case n < 10:
+			name = "post"
+			err = d.client.do(ctx, fmt.Sprintf("{ post(id: %d) { id title content authorId } }", postID), nil)
+		default:
+			name = "commentsByPost"
+			err = d.client.do(ctx,
+				fmt.Sprintf("{ commentsByPost(postId: %d, limit: 20) { id parentId authorId content createdAt } }", postID), nil)
+		}
+	}
+
+	// requests cut by an interrupted run are not failures of the server
+	if ctx.Err() != nil {
+		return
+	}
+	d.rec.record(name, time.Since(start), err)
+}
+
+func (d *driver) createPost(ctx context.Context, rng *rand.Rand) (int, error) {
+	var res struct {
+		CreatePost struct {
+			ID int `json:"id"`
+		} `json:"createPost"`
+	}
+	query := fmt.Sprintf(`mutation { createPost(title: "load test", content: %q, authorId: %d) { id } }`,
+		d.content(), rng.Intn(d.cfg.Authors)+1)
+	if err := d.client.do(ctx, query, &res); err != nil {
+		return 0, err
+	}
+	return res.CreatePost.ID, nil
+}
+
+// createComment comments on the post or replies to a comment of it, the content carries a token
+// by which the subscribers find when the comment was sent
+func (d *driver) createComment(ctx context.Context, rng *rand.Rand, postID int, start time.Time) error {
+	parentID := 0
+	d.mu.Lock()
+	if known := d.known[postID]; len(known) > 0 && rng.Intn(2) == 0 {
+		parentID = known[rng.Intn(len(known))]
+	}
+	d.mu.Unlock()
+
+	content := d.content()
+	d.sent.Store(content, start)
+
+	var res struct {
+		CreateComment struct {
+			ID int `json:"id"`
+		} `json:"createComment"`
+	}
+	query := fmt.Sprintf(`mutation { createComment(postId: %d, parentId: %d, authorId: %d, content: %q) { id } }`,
+		postID, parentID, rng.Intn(d.cfg.Authors)+1, content)
+	if err := d.client.do(ctx, query, &res); err != nil {
+		d.sent.Delete(content)
+		return err
+	}
+	d.created.Add(1)
+
+	d.mu.Lock()
+	known := append(d.known[postID], res.CreateComment.ID)
+	if len(known) > maxKnownComments {
+		known = known[1:]
+	}
+	d.known[postID] = known
+	d.mu.Unlock()
+	return nil
+}
+
+// content makes a unique text, the duplicate content filter rejects repeated ones
+func (d *driver) content() string {
+	return fmt.Sprintf("load test %s-%d", d.runID, d.seq.Add(1))
+}
+
+// delivered records the fan-out delay of a comment received by a subscriber
+func (d *driver) delivered(content string, at time.Time) {
+	if !strings.HasPrefix(content, "load test "+d.runID+"-") {
+		return
+	}
+	sent, ok := d.sent.Load(content)
+	if !ok {
+		return
+	}
+	d.fanout.record("fanout", at.Sub(sent.(time.Time)), nil)
+}
+
+// drain waits until every expected delivery arrives or the drain time is over
+func (d *driver) drain(ctx context.Context) {
+	deadline := time.Now().Add(d.cfg.Drain)
+	for time.Now().Before(deadline) && ctx.Err() == nil {
+		if d.fanout.stats()["fanout"].Count >= int(d.created.Load())*d.cfg.Subscribers {
+			return
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
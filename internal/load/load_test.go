@@ -0,0 +1,185 @@
+package load
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSummarize(t *testing.T) {
+	latencies := make([]time.Duration, 0, 100)
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	s := summarize(latencies, 3)
+	assert.Equal(t, Stats{
+		Count:  100,
+		Errors: 3,
+		P50:    50 * time.Millisecond,
+		P90:    90 * time.Millisecond,
+		P99:    99 * time.Millisecond,
+		Max:    100 * time.Millisecond,
+	}, s)
+
+	assert.Equal(t, Stats{Errors: 1}, summarize(nil, 1))
+	one := summarize([]time.Duration{time.Second}, 0)
+	assert.Equal(t, time.Second, one.P50)
+	assert.Equal(t, time.Second, one.P99)
+}
+
+func TestReport_Print(t *testing.T) {
+	r := &Report{
+		Duration: 2 * time.Second,
+		Operations: map[string]Stats{
+			"post":          {Count: 30, P50: time.Millisecond},
+			"createComment": {Count: 10, Errors: 2},
+		},
+		Fanout:   Stats{Count: 15},
+		Expected: 20,
+	}
+	var out bytes.Buffer
+	require.NoError(t, r.Print(&out))
+
+	lines := strings.Split(out.String(), "\n")
+	assert.Contains(t, lines[0], "OPERATION")
+	assert.Contains(t, lines[1], "createComment")
+	assert.Contains(t, lines[2], "post")
+	assert.Contains(t, lines[3], "fan-out")
+	assert.Contains(t, out.String(), "40 operations in 2s, 20.0 per second")
+	assert.Contains(t, out.String(), "15 of 20 subscription deliveries received")
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, config("http://localhost:8080").Validate())
+
+	cfg := config("")
+	cfg.Workers = 0
+	cfg.MutationShare = 2
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "URL")
+	assert.Contains(t, err.Error(), "workers")
+	assert.Contains(t, err.Error(), "mutation share")
+}
+
+func TestRun(t *testing.T) {
+	srv := httptest.NewServer(newFakeServer())
+	defer srv.Close()
+
+	report, err := Run(context.Background(), config(srv.URL))
+	require.NoError(t, err)
+
+	total := 0
+	for name, s := range report.Operations {
+		assert.Zero(t, s.Errors, name)
+		total += s.Count
+	}
+	assert.Positive(t, total)
+	assert.Positive(t, report.Operations["createComment"].Count)
+
+	// every comment reaches every subscriber
+	assert.Equal(t, report.Operations["createComment"].Count*2, report.Expected)
+	assert.Equal(t, report.Expected, report.Fanout.Count)
+	assert.Positive(t, report.Fanout.Max)
+}
+
+func config(url string) Config {
+	return Config{
+		URL:           url,
+		Duration:      300 * time.Millisecond,
+		Workers:       4,
+		MutationShare: 0.5,
+		Subscribers:   2,
+		HotPosts:      3,
+		Authors:       10,
+		Drain:         2 * time.Second,
+		Seed:          1,
+	}
+}
+
+// fakeServer answers the queries of the driver and sends the created comments to the subscribers
+type fakeServer struct {
+	mu          sync.Mutex
+	posts       int
+	comments    int
+	subscribers []*websocket.Conn
+}
+
+func newFakeServer() http.Handler {
+	s := &fakeServer{}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/root", s.graphql)
+	mux.HandleFunc("/subscriptions", s.subscribe)
+	return mux
+}
+
+var contentArg = regexp.MustCompile(`content: ("(?:[^"\\]|\\.)*")`)
+
+func (s *fakeServer) graphql(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var data any
+	switch {
+	case strings.Contains(req.Query, "createPost"):
+		s.posts++
+		data = map[string]any{"createPost": map[string]any{"id": s.posts}}
+	case strings.Contains(req.Query, "createComment"):
+		s.comments++
+		var content string
+		if m := contentArg.FindStringSubmatch(req.Query); m != nil {
+			_ = json.Unmarshal([]byte(m[1]), &content)
+		}
+		comment := map[string]any{"id": s.comments, "content": content}
+		for _, conn := range s.subscribers {
+			_ = conn.WriteJSON(map[string]any{"payload": map[string]any{"comment": comment}})
+		}
+		data = map[string]any{"createComment": comment}
+	default:
+		data = map[string]any{"posts": []any{}}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func (s *fakeServer) subscribe(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{Subprotocols: []string{"graphql-ws"}}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	if _, _, err := conn.ReadMessage(); err != nil {
+		conn.Close()
+		return
+	}
+
+	s.mu.Lock()
+	s.subscribers = append(s.subscribers, conn)
+	s.mu.Unlock()
+
+	// hold the connection until the client closes it
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package load
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Stats summarizes the latencies of an operation
+type Stats struct {
+	Count  int
+	Errors int
+	P50    time.Duration
+	P90    time.Duration
+	P99    time.Duration
+	Max    time.Duration
+}
+
+// Report is the result of a run
+type Report struct {
+	Duration   time.Duration
+	Operations map[string]Stats
+	// Fanout is the delay from sending a comment to receiving it on a subscription, a sample per delivery
+	Fanout Stats
+	// Expected is how many deliveries the created comments should make, Fanout.Count of them arrived
+	Expected int
+}
+
+// Print writes the report as a table
+func (r *Report) Print(w io.Writer) error {
+	names := make([]string, 0, len(r.Operations))
+	total := 0
+	for name, stats := range r.Operations {
+		names = append(names, name)
+		total += stats.Count
+	}
+	sort.Strings(names)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "OPERATION\tCOUNT\tERRORS\tP50\tP90\tP99\tMAX\t")
+	for _, name := range names {
+		printStats(tw, name, r.Operations[name])
+	}
+	if r.Expected > 0 {
+		printStats(tw, "fan-out", r.Fanout)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	seconds := r.Duration.Seconds()
+	if seconds > 0 {
+		fmt.Fprintf(w, "\n%d operations in %s, %.1f per second\n", total, r.Duration.Round(time.Millisecond), float64(total)/seconds)
+	}
+	if r.Expected > 0 {
+		fmt.Fprintf(w, "%d of %d subscription deliveries received\n", r.Fanout.Count, r.Expected)
+	}
+	return nil
+}
+
+func printStats(w io.Writer, name string, s Stats) {
+	fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t\n", name, s.Count, s.Errors,
+		round(s.P50), round(s.P90), round(s.P99), round(s.Max))
+}
+
+func round(d time.Duration) time.Duration {
+	return d.Round(10 * time.Microsecond)
+}
+
+// recorder collects the latencies of the operations from many goroutines
+type recorder struct {
+	mu     sync.Mutex
+	series map[string]*series
+}
+
+type series struct {
+	latencies []time.Duration
+	errors    int
+}
+
+func newRecorder() *recorder {
+	return &recorder{series: make(map[string]*series)}
+}
+
+// record adds a latency, the latencies of failed operations are not counted
+func (r *recorder) record(name string, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s, ok := r.series[name]
+	if !ok {
+		s = &series{}
+		r.series[name] = s
+	}
+	if err != nil {
+		s.errors++
+		return
+	}
+	s.latencies = append(s.latencies, latency)
+}
+
+func (r *recorder) stats() map[string]Stats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stats := make(map[string]Stats, len(r.series))
+	for name, s := range r.series {
+		stats[name] = summarize(s.latencies, s.errors)
+	}
+	return stats
+}
+
+// summarize sorts the latencies and picks the percentiles by the nearest rank
+func summarize(latencies []time.Duration, errors int) Stats {
+	stats := Stats{Count: len(latencies), Errors: errors}
+	if len(latencies) == 0 {
+		return stats
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	percentile := func(p float64) time.Duration {
+		rank := int(math.Ceil(p * float64(len(latencies))))
+		return latencies[max(rank, 1)-1]
+	}
+	stats.P50 = percentile(0.50)
+	stats.P90 = percentile(0.90)
+	stats.P99 = percentile(0.99)
+	stats.Max = latencies[len(latencies)-1]
+	return stats
+}
@@ -0,0 +1,144 @@
+// Package logging builds the service logger and carries request scoped log fields in the context
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/pkg/config"
+)
+
+const (
+	TextFormat = "TEXT"
+	JSONFormat = "JSON"
+)
+
+// New creates a logger with the level and format from the configuration.
+// Records logged with a request context get the request id and the request fields.
+func New(cfg config.LogConfig, w io.Writer) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", cfg.Level, err)
+	}
+	opts := &slog.HandlerOptions{Level: level}
+
+	var h slog.Handler
+	switch strings.ToUpper(cfg.Format) {
+	case TextFormat:
+		h = slog.NewTextHandler(w, opts)
+	case JSONFormat:
+		h = slog.NewJSONHandler(w, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format: %s", cfg.Format)
+	}
+
+	return slog.New(contextHandler{h}), nil
+}
+
+// contextHandler adds the request id and the request fields from the context to every record
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := RequestID(ctx); id != "" {
+		r.AddAttrs(slog.String("request_id", id))
+	}
+	if f, ok := ctx.Value(fieldsKey{}).(*fields); ok {
+		r.AddAttrs(f.user()...)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
+
+type requestIDKey struct{}
+
+// NewRequestID returns a random request id
+func NewRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// WithRequestID returns a context carrying the request id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestID returns the request id from the context, or an empty string
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+type fieldsKey struct{}
+
+// fields are log fields collected while a request is handled
+type fields struct {
+	mu     sync.Mutex
+	userID *int // added to every record of the request once known
+	attrs  []slog.Attr
+}
+
+func (f *fields) user() []slog.Attr {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.userID == nil {
+		return nil
+	}
+	return []slog.Attr{slog.Int("user_id", *f.userID)}
+}
+
+// WithFields returns a context that collects request fields, see AddFields and SetUser
+func WithFields(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, &fields{})
+}
+
+// AddFields adds fields to the request log line, it does nothing if the context does not collect fields
+func AddFields(ctx context.Context, attrs ...slog.Attr) {
+	f, ok := ctx.Value(fieldsKey{}).(*fields)
+	if !ok {
+		return
+	}
+	f.mu.Lock()
+	f.attrs = append(f.attrs, attrs...)
+	f.mu.Unlock()
+}
+
+// SetUser records the user acting in the request, every later record of the request includes it.
+// The first user set wins, ids that are not positive are ignored.
+func SetUser(ctx context.Context, userID int) {
+	f, ok := ctx.Value(fieldsKey{}).(*fields)
+	if !ok || userID <= 0 {
+		return
+	}
+	f.mu.Lock()
+	if f.userID == nil {
+		f.userID = &userID
+	}
+	f.mu.Unlock()
+}
+
+// Fields returns the fields added to the request with AddFields
+func Fields(ctx context.Context) []slog.Attr {
+	f, ok := ctx.Value(fieldsKey{}).(*fields)
+	if !ok {
+		return nil
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]slog.Attr(nil), f.attrs...)
+}
@@ -0,0 +1,50 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew_AddsRequestFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(config.LogConfig{Level: "INFO", Format: "json"}, &buf)
+	require.NoError(t, err)
+
+	ctx := WithFields(WithRequestID(context.Background(), "abc"))
+	SetUser(ctx, 7)
+	SetUser(ctx, 8)
+	AddFields(ctx, slog.String("operation_type", "query"))
+	logger.InfoContext(ctx, "hello")
+	logger.DebugContext(ctx, "hidden")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "hello", record["msg"])
+	assert.Equal(t, "abc", record["request_id"])
+	assert.Equal(t, float64(7), record["user_id"])
+	assert.NotContains(t, record, "operation_type", "added fields are only for the request line")
+	assert.Equal(t, []slog.Attr{slog.String("operation_type", "query")}, Fields(ctx))
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	_, err := New(config.LogConfig{Level: "LOUD", Format: "TEXT"}, &bytes.Buffer{})
+	assert.Error(t, err)
+
+	_, err = New(config.LogConfig{Level: "INFO", Format: "XML"}, &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestFields_WithoutRequest(t *testing.T) {
+	ctx := context.Background()
+	SetUser(ctx, 1)
+	AddFields(ctx, slog.Int("n", 1))
+	assert.Empty(t, Fields(ctx))
+	assert.Empty(t, RequestID(ctx))
+}
@@ -0,0 +1,31 @@
+package logging
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+)
+
+// RepositoryObserver logs repository calls at debug level and failed calls at warn level,
+// a missing entity is an expected outcome and is logged at debug level too.
+// It is meant for the observed repository decorator.
+func RepositoryObserver(logger *slog.Logger) func(ctx context.Context, method string) (context.Context, func(error)) {
+	return func(ctx context.Context, method string) (context.Context, func(error)) {
+		start := time.Now()
+		return ctx, func(err error) {
+			if err != nil && !errors.Is(err, repository.ErrNotFound) {
+				logger.LogAttrs(ctx, slog.LevelWarn, "repository call failed",
+					slog.String("method", method), slog.Duration("latency", time.Since(start)), slog.Any("error", err))
+				return
+			}
+			attrs := []slog.Attr{slog.String("method", method), slog.Duration("latency", time.Since(start))}
+			if err != nil {
+				attrs = append(attrs, slog.Any("error", err))
+			}
+			logger.LogAttrs(ctx, slog.LevelDebug, "repository call", attrs...)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "posts"
+
+// Metrics holds the service collectors.
+// All methods are safe to call on a nil *Metrics, which records nothing.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	operations     *prometheus.CounterVec
+	operationTime  *prometheus.HistogramVec
+	errors         *prometheus.CounterVec
+	repositoryTime *prometheus.HistogramVec
+	cacheRequests  *prometheus.CounterVec
+	subscribers    prometheus.Gauge
+	fanoutPending  prometheus.Gauge
+	dropped        prometheus.Counter
+	pools          *poolCollector
+}
+
+// New creates the collectors and registers them in a new registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "graphql_operations_total",
+			Help:      "GraphQL root fields executed, by operation type and field.",
+		}, []string{"type", "field"}),
+		operationTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "graphql_operation_duration_seconds",
+			Help:      "GraphQL request latency, by operation type and root field.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"type", "field"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "graphql_errors_total",
+			Help:      "GraphQL errors returned to clients, by error code.",
+		}, []string{"code"}),
+		repositoryTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_call_duration_seconds",
+			Help:      "Repository call latency, by method and outcome: ok, not_found or error.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"method", "status"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "repository_cache_requests_total",
+			Help:      "Cacheable repository reads, by method and result: hit or miss.",
+		}, []string{"method", "result"}),
+		subscribers: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "subscriptions_active",
+			Help:      "Active WebSocket subscriptions.",
+		}),
+		fanoutPending: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "comment_fanout_pending",
+			Help:      "New comments waiting to be delivered to subscribers.",
+		}),
+		dropped: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "subscriptions_dropped_total",
+			Help:      "Subscriptions ended because the subscriber fell behind the new comments.",
+		}),
+		pools: newPoolCollector(),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.operations,
+		m.operationTime,
+		m.errors,
+		m.repositoryTime,
+		m.cacheRequests,
+		m.subscribers,
+		m.fanoutPending,
+		m.dropped,
+		m.pools,
+	)
+
+	return m
+}
+
+// Handler serves the metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	if m == nil {
+		return http.NotFoundHandler()
+	}
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// ObserveOperation records an executed GraphQL root field
+func (m *Metrics) ObserveOperation(operationType, field string, d time.Duration) {
+	if m == nil {
+		return
+	}
+	m.operations.WithLabelValues(operationType, field).Inc()
+	m.operationTime.WithLabelValues(operationType, field).Observe(d.Seconds())
+}
+
+// ObserveError records an error returned to a client
+func (m *Metrics) ObserveError(code string) {
+	if m == nil {
+		return
+	}
+	m.errors.WithLabelValues(code).Inc()
+}
+
+// ObserveRepositoryCall records the latency of a repository method
+func (m *Metrics) ObserveRepositoryCall(method string, d time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	status := "ok"
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		status = "not_found"
+	case err != nil:
+		status = "error"
+	}
+	m.repositoryTime.WithLabelValues(method, status).Observe(d.Seconds())
+}
+
+// ObserveCache records a cacheable repository read
+func (m *Metrics) ObserveCache(method string, hit bool) {
+	if m == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheRequests.WithLabelValues(method, result).Inc()
+}
+
+// SubscriptionStarted and SubscriptionEnded track active subscriptions
+func (m *Metrics) SubscriptionStarted() {
+	if m == nil {
+		return
+	}
+	m.subscribers.Inc()
+}
+
+func (m *Metrics) SubscriptionEnded() {
+	if m == nil {
+		return
+	}
+	m.subscribers.Dec()
+}
+
+// FanoutQueued and FanoutDelivered track comments waiting for delivery to subscribers
+func (m *Metrics) FanoutQueued(n int) {
+	if m == nil {
+		return
+	}
+	m.fanoutPending.Add(float64(n))
+}
+
+func (m *Metrics) FanoutDelivered() {
+	if m == nil {
+		return
+	}
+	m.fanoutPending.Dec()
+}
+
+// SubscriberDropped records a subscription ended because the subscriber fell behind
+func (m *Metrics) SubscriberDropped() {
+	if m == nil {
+		return
+	}
+	m.dropped.Inc()
+}
+
+// AddPool exports the statistics of a database connection pool under the name
+func (m *Metrics) AddPool(name string, pool *pgxpool.Pool) {
+	if m == nil {
+		return
+	}
+	m.pools.add(name, pool)
+}
+
+// RepositoryObserver measures repository calls, it is meant for the observed repository decorator
+func (m *Metrics) RepositoryObserver(ctx context.Context, method string) (context.Context, func(error)) {
+	start := time.Now()
+	return ctx, func(err error) {
+		m.ObserveRepositoryCall(method, time.Since(start), err)
+	}
+}
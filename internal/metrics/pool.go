@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// poolCollector reads the statistics of the database connection pools when the metrics are scraped
+type poolCollector struct {
+	mu    sync.Mutex
+	pools []namedPool
+
+	maxConns, minConns, totalConns, idleConns, acquiredConns, constructingConns *prometheus.Desc
+	acquires, acquireTime, emptyAcquires, canceledAcquires                      *prometheus.Desc
+	newConns, lifetimeDestroys, idleDestroys                                    *prometheus.Desc
+}
+
+type namedPool struct {
+	name string
+	pool *pgxpool.Pool
+}
+
+func newPoolCollector() *poolCollector {
+	desc := func(name, help string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(namespace, "db_pool", name), help, []string{"pool"}, nil)
+	}
+	return &poolCollector{
+		maxConns:          desc("max_conns", "Maximum size of the pool."),
+		minConns:          desc("min_conns", "Connections kept open even when idle."),
+		totalConns:        desc("conns", "Open connections, idle, acquired and being constructed."),
+		idleConns:         desc("idle_conns", "Idle connections."),
+		acquiredConns:     desc("acquired_conns", "Connections in use."),
+		constructingConns: desc("constructing_conns", "Connections being opened."),
+		acquires:          desc("acquires_total", "Connections acquired from the pool."),
+		acquireTime:       desc("acquire_duration_seconds_total", "Time spent acquiring connections."),
+		emptyAcquires:     desc("empty_acquires_total", "Acquires that waited for a connection because none was idle."),
+		canceledAcquires:  desc("canceled_acquires_total", "Acquires canceled by their context."),
+		newConns:          desc("new_conns_total", "Connections opened."),
+		lifetimeDestroys:  desc("max_lifetime_destroys_total", "Connections closed at the end of their lifetime."),
+		idleDestroys:      desc("max_idle_destroys_total", "Connections closed after staying idle too long."),
+	}
+}
+
+func (c *poolCollector) add(name string, pool *pgxpool.Pool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pools = append(c.pools, namedPool{name: name, pool: pool})
+}
+
+func (c *poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		c.maxConns, c.minConns, c.totalConns, c.idleConns, c.acquiredConns, c.constructingConns,
+		c.acquires, c.acquireTime, c.emptyAcquires, c.canceledAcquires,
+		c.newConns, c.lifetimeDestroys, c.idleDestroys,
+	} {
+		ch <- d
+	}
+}
+
+func (c *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	pools := c.pools
+	c.mu.Unlock()
+
+	for _, p := range pools {
+		s := p.pool.Stat()
+		gauge := func(d *prometheus.Desc, v float64) {
+			ch <- prometheus.MustNewConstMetric(d, prometheus.GaugeValue, v, p.name)
+		}
+		counter := func(d *prometheus.Desc, v float64) {
+			ch <- prometheus.MustNewConstMetric(d, prometheus.CounterValue, v, p.name)
+		}
+
+		gauge(c.maxConns, float64(s.MaxConns()))
+		gauge(c.minConns, float64(p.pool.Config().MinConns))
+		gauge(c.totalConns, float64(s.TotalConns()))
+		gauge(c.idleConns, float64(s.IdleConns()))
+		gauge(c.acquiredConns, float64(s.AcquiredConns()))
+		gauge(c.constructingConns, float64(s.ConstructingConns()))
+		counter(c.acquires, float64(s.AcquireCount()))
+		counter(c.acquireTime, s.AcquireDuration().Seconds())
+		counter(c.emptyAcquires, float64(s.EmptyAcquireCount()))
+		counter(c.canceledAcquires, float64(s.CanceledAcquireCount()))
+		counter(c.newConns, float64(s.NewConnsCount()))
+		counter(c.lifetimeDestroys, float64(s.MaxLifetimeDestroyCount()))
+		counter(c.idleDestroys, float64(s.MaxIdleDestroyCount()))
+	}
+}
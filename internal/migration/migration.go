@@ -0,0 +1,141 @@
+// Package migration manages the schema migrations of the SQL repositories
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+)
+
+// Migrator moves the database between the versions of the migration source
+type Migrator struct {
+	m *migrate.Migrate
+}
+
+// New connects to the database, the source and database drivers must be registered by the caller
+func New(sourceURL, databaseURL string) (*Migrator, error) {
+	m, err := migrate.New(sourceURL, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new migration: %w", err)
+	}
+	return &Migrator{m: m}, nil
+}
+
+// Up applies all migrations that are not applied yet
+func (m *Migrator) Up() error {
+	if err := m.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to migrate up: %w", err)
+	}
+	return nil
+}
+
+// Down rolls back the last n migrations
+func (m *Migrator) Down(n int) error {
+	if n < 1 {
+		return fmt.Errorf("number of migrations to roll back must be positive, got %d", n)
+	}
+	if err := m.m.Steps(-n); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to migrate down: %w", err)
+	}
+	return nil
+}
+
+// Goto migrates up or down to the version
+func (m *Migrator) Goto(version uint) error {
+	if err := m.m.Migrate(version); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to migrate to version %d: %w", version, err)
+	}
+	return nil
+}
+
+// Version returns the current version, zero if no migration is applied.
+// A dirty version is one whose migration failed halfway and has to be fixed by hand and forced.
+func (m *Migrator) Version() (version uint, dirty bool, err error) {
+	version, dirty, err = m.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// Force sets the version without running any migration and clears the dirty flag,
+// -1 means no migration is applied
+func (m *Migrator) Force(version int) error {
+	if version < -1 {
+		return fmt.Errorf("invalid version %d", version)
+	}
+	if err := m.m.Force(version); err != nil {
+		return fmt.Errorf("failed to force version %d: %w", version, err)
+	}
+	return nil
+}
+
+// Close closes the source and the database connection
+func (m *Migrator) Close() error {
+	srcErr, dbErr := m.m.Close()
+	return errors.Join(srcErr, dbErr)
+}
+
+// namePattern is what a migration name may consist of, it becomes part of the file names
+var namePattern = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// filePattern matches migration files and captures their version
+var filePattern = regexp.MustCompile(`^(\d+)_.*\.(up|down)\.sql$`)
+
+// Create writes empty up and down files for a new migration in the directory,
+// numbered after the newest migration there, and returns their paths
+func Create(dir, name string) (up, down string, err error) {
+	if !namePattern.MatchString(name) {
+		return "", "", fmt.Errorf("invalid migration name %q: use lowercase letters, digits and underscores", name)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read migration directory: %w", err)
+	}
+	var last uint64
+	for _, e := range entries {
+		match := filePattern.FindStringSubmatch(e.Name())
+		if e.IsDir() || match == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid migration version in %s: %w", e.Name(), err)
+		}
+		last = max(last, version)
+	}
+
+	prefix := filepath.Join(dir, fmt.Sprintf("%06d_%s", last+1, name))
+	up, down = prefix+".up.sql", prefix+".down.sql"
+	for _, path := range []string{up, down} {
+		// O_EXCL so that a file written meanwhile is never overwritten
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to create migration file: %w", err)
+		}
+		if err := f.Close(); err != nil {
+			return "", "", fmt.Errorf("failed to create migration file: %w", err)
+		}
+	}
+
+	return up, down, nil
+}
+
+// Dir returns the directory of a file:// migration source
+func Dir(sourceURL string) (string, error) {
+	dir, ok := strings.CutPrefix(sourceURL, "file://")
+	if !ok {
+		return "", fmt.Errorf("migrations can only be created in a file:// source, got %s", sourceURL)
+	}
+	return dir, nil
+}
@@ -0,0 +1,89 @@
+package migration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sourceURL = "file://../../migrations/sqlite"
+
+// latest counts the up migrations of the source, they are numbered from 1 without gaps
+func latest(t *testing.T) uint {
+	matches, err := filepath.Glob("../../migrations/sqlite/*.up.sql")
+	require.NoError(t, err)
+	return uint(len(matches))
+}
+
+func newMigrator(t *testing.T) *Migrator {
+	m, err := New(sourceURL, "sqlite://"+filepath.Join(t.TempDir(), "posts.db"))
+	require.NoError(t, err)
+	t.Cleanup(func() { m.Close() })
+	return m
+}
+
+func requireVersion(t *testing.T, m *Migrator, want uint) {
+	t.Helper()
+	version, dirty, err := m.Version()
+	require.NoError(t, err)
+	assert.Equal(t, want, version)
+	assert.False(t, dirty)
+}
+
+func TestMigrator(t *testing.T) {
+	m := newMigrator(t)
+	last := latest(t)
+
+	requireVersion(t, m, 0)
+
+	require.NoError(t, m.Up())
+	requireVersion(t, m, last)
+	require.NoError(t, m.Up(), "nothing to apply is not an error")
+
+	require.NoError(t, m.Down(2))
+	requireVersion(t, m, last-2)
+
+	require.NoError(t, m.Goto(1))
+	requireVersion(t, m, 1)
+	require.NoError(t, m.Goto(last))
+	requireVersion(t, m, last)
+
+	require.NoError(t, m.Force(1))
+	requireVersion(t, m, 1)
+	require.NoError(t, m.Force(-1))
+	requireVersion(t, m, 0)
+
+	assert.Error(t, m.Down(0))
+	assert.Error(t, m.Force(-2))
+}
+
+func TestCreate(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"000001_init.up.sql", "000001_init.down.sql", "000009_webhooks.up.sql", "README.md"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), nil, 0o644))
+	}
+
+	up, down, err := Create(dir, "add_likes")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "000010_add_likes.up.sql"), up)
+	assert.Equal(t, filepath.Join(dir, "000010_add_likes.down.sql"), down)
+	assert.FileExists(t, up)
+	assert.FileExists(t, down)
+
+	_, _, err = Create(dir, "Add likes")
+	assert.ErrorContains(t, err, "invalid migration name")
+}
+
+func TestDir(t *testing.T) {
+	dir, err := Dir("file://migrations/sqlite")
+	require.NoError(t, err)
+	assert.Equal(t, "migrations/sqlite", dir)
+
+	_, err = Dir("github://owner/repo/migrations")
+	assert.Error(t, err)
+}
@@ -0,0 +1,173 @@
+// Package outbox publishes the events recorded in the repository outbox to sinks.
+//
+// An event is claimed and published by a single relay. Sinks that deliver to the outside,
+// like webhooks, get it once whatever the number of instances, but a sink that serves
+// the local subscribers of an instance, like the resolver, only sees the events its own relay claimed:
+// subscriptions get every comment only when a single instance runs.
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/worker"
+)
+
+// Relay polls the outbox and publishes the events to every sink in id order.
+// Delivery is at least once: an event is marked published only after all sinks accepted it,
+// so after a sink failure or a crash it is delivered again to every sink with the same dedup id.
+type Relay struct {
+	repo      repository.Repository
+	sinks     []namedSink
+	logger    *slog.Logger
+	interval  time.Duration // poll interval when not notified
+	batchSize int
+	lease     time.Duration // how long claimed events are hidden from other relays
+	retention time.Duration // published events are deleted after it, zero keeps them
+	now       func() time.Time
+	lastPrune time.Time
+
+	*worker.Loop // Start, Stop and Notify
+}
+
+type namedSink struct {
+	name string
+	sink Sink
+}
+
+// pruneInterval is how often published events older than the retention are deleted
+const pruneInterval = time.Hour
+
+// Option configures the relay
+type Option func(*Relay)
+
+// WithSink adds a sink, the name is used in logs
+func WithSink(name string, sink Sink) Option {
+	return func(r *Relay) {
+		r.sinks = append(r.sinks, namedSink{name: name, sink: sink})
+	}
+}
+
+// WithInterval sets how often the outbox is polled, 1s by default
+func WithInterval(interval time.Duration) Option {
+	return func(r *Relay) {
+		r.interval = interval
+	}
+}
+
+// WithBatchSize sets how many events are claimed at once, 100 by default
+func WithBatchSize(size int) Option {
+	return func(r *Relay) {
+		r.batchSize = size
+	}
+}
+
+// WithLease sets how long claimed events are reserved for this relay, 30s by default
+func WithLease(lease time.Duration) Option {
+	return func(r *Relay) {
+		r.lease = lease
+	}
+}
+
+// WithRetention deletes published events older than the retention
+func WithRetention(retention time.Duration) Option {
+	return func(r *Relay) {
+		r.retention = retention
+	}
+}
+
+func NewRelay(repo repository.Repository, logger *slog.Logger, opts ...Option) *Relay {
+	r := &Relay{
+		repo:      repo,
+		logger:    logger,
+		interval:  time.Second,
+		batchSize: 100,
+		lease:     30 * time.Second,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	r.Loop = worker.New("outbox relay", r.interval, r.poll)
+
+	return r
+}
+
+// AddSink adds a sink to a relay that is not started yet
+func (r *Relay) AddSink(name string, sink Sink) {
+	r.sinks = append(r.sinks, namedSink{name: name, sink: sink})
+}
+
+// poll drains the outbox, a full batch means there may be more events
+func (r *Relay) poll(ctx context.Context) {
+	for {
+		n, err := r.PublishPending(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.logger.WarnContext(ctx, "failed to relay outbox events", "error", err)
+			}
+			break
+		}
+		if n < r.batchSize {
+			break
+		}
+	}
+	r.prune(ctx)
+}
+
+// PublishPending claims one batch of events, publishes them and returns how many were published.
+// It stops at the first event a sink fails on, so the events are published in order.
+func (r *Relay) PublishPending(ctx context.Context) (int, error) {
+	events, err := r.repo.ClaimEvents(ctx, r.batchSize, r.lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim events: %w", err)
+	}
+
+	published := make([]int, 0, len(events))
+	var publishErr error
+	for _, event := range events {
+		if err := r.publish(ctx, event); err != nil {
+			publishErr = err
+			break
+		}
+		published = append(published, event.ID)
+	}
+
+	if len(published) > 0 {
+		if err := r.repo.MarkEventsPublished(ctx, published, r.now().UTC()); err != nil {
+			return 0, fmt.Errorf("failed to mark events published: %w", err)
+		}
+	}
+
+	return len(published), publishErr
+}
+
+func (r *Relay) publish(ctx context.Context, event *domain.Event) error {
+	for _, s := range r.sinks {
+		if err := s.sink.Publish(ctx, event); err != nil {
+			return fmt.Errorf("sink %s failed to publish event %s: %w", s.name, event.DedupID, err)
+		}
+	}
+	return nil
+}
+
+// prune deletes old published events at most once per pruneInterval
+func (r *Relay) prune(ctx context.Context) {
+	if r.retention <= 0 || r.now().Sub(r.lastPrune) < pruneInterval {
+		return
+	}
+	r.lastPrune = r.now()
+
+	deleted, err := r.repo.DeletePublishedEvents(ctx, r.now().UTC().Add(-r.retention))
+	if err != nil {
+		r.logger.WarnContext(ctx, "failed to delete published outbox events", "error", err)
+		return
+	}
+	if deleted > 0 {
+		r.logger.InfoContext(ctx, "deleted published outbox events", "count", deleted)
+	}
+}
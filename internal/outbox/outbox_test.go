@@ -0,0 +1,137 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/in_memory"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder is a sink that records the dedup ids it got and fails on the ids in failOn
+type recorder struct {
+	got    []string
+	failOn map[string]bool
+}
+
+func (r *recorder) Publish(_ context.Context, event *domain.Event) error {
+	if r.failOn[event.DedupID] {
+		return errors.New("sink is down")
+	}
+	r.got = append(r.got, event.DedupID)
+	return nil
+}
+
+func addEvents(t *testing.T, repo repository.Repository, n int) {
+	for i := 1; i <= n; i++ {
+		_, err := repo.CreateEvent(context.Background(), &domain.Event{
+			DedupID:   fmt.Sprintf("e%d", i),
+			Type:      domain.EventCommentCreated,
+			PostID:    1,
+			Payload:   []byte(`{}`),
+			CreatedAt: time.Now().UTC(),
+		})
+		assert.NoError(t, err)
+	}
+}
+
+func TestRelay_PublishPending(t *testing.T) {
+	repo := in_memory.New()
+	addEvents(t, repo, 3)
+
+	first, second := &recorder{}, &recorder{}
+	relay := NewRelay(repo, slog.Default(), WithSink("first", first), WithSink("second", second), WithBatchSize(2))
+
+	n, err := relay.PublishPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = relay.PublishPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = relay.PublishPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	assert.Equal(t, []string{"e1", "e2", "e3"}, first.got)
+	assert.Equal(t, []string{"e1", "e2", "e3"}, second.got)
+}
+
+func TestRelay_PublishPending_SinkFailure(t *testing.T) {
+	repo := in_memory.New()
+	addEvents(t, repo, 3)
+
+	sink := &recorder{failOn: map[string]bool{"e2": true}}
+	relay := NewRelay(repo, slog.Default(), WithSink("sink", sink), WithLease(time.Millisecond))
+
+	n, err := relay.PublishPending(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 1, n)
+
+	// the failed event and the ones after it are delivered again once the lease ends
+	sink.failOn = nil
+	time.Sleep(5 * time.Millisecond)
+	n, err = relay.PublishPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"e1", "e2", "e3"}, sink.got)
+}
+
+func TestRelay_Lease(t *testing.T) {
+	repo := in_memory.New()
+	addEvents(t, repo, 1)
+
+	claimed, err := repo.ClaimEvents(context.Background(), 10, time.Hour)
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+
+	// another relay doesn't get the leased event
+	sink := &recorder{}
+	relay := NewRelay(repo, slog.Default(), WithSink("sink", sink))
+	n, err := relay.PublishPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+	assert.Empty(t, sink.got)
+}
+
+func TestRelay_Notify(t *testing.T) {
+	repo := in_memory.New()
+	delivered := make(chan string, 1)
+	relay := NewRelay(repo, slog.Default(), WithInterval(time.Hour), WithSink("sink", SinkFunc(
+		func(_ context.Context, event *domain.Event) error {
+			delivered <- event.DedupID
+			return nil
+		})))
+	relay.Start()
+
+	addEvents(t, repo, 1)
+	relay.Notify()
+
+	select {
+	case id := <-delivered:
+		assert.Equal(t, "e1", id)
+	case <-time.After(time.Second):
+		t.Fatal("event was not relayed")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, relay.Stop(ctx))
+}
+
+func TestDeduplicate(t *testing.T) {
+	sink := &recorder{}
+	dedup := Deduplicate(sink, 2)
+
+	for _, id := range []string{"a", "b", "a", "c", "a"} {
+		assert.NoError(t, dedup.Publish(context.Background(), &domain.Event{DedupID: id}))
+	}
+
+	// "a" is forgotten once two newer ids were published
+	assert.Equal(t, []string{"a", "b", "c", "a"}, sink.got)
+}
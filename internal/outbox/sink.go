@@ -0,0 +1,88 @@
+package outbox
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"sync"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+)
+
+// Sink receives the events of the outbox, an event may be published more than once
+type Sink interface {
+	Publish(ctx context.Context, event *domain.Event) error
+}
+
+// SinkFunc adapts a function to the Sink interface
+type SinkFunc func(ctx context.Context, event *domain.Event) error
+
+func (f SinkFunc) Publish(ctx context.Context, event *domain.Event) error {
+	return f(ctx, event)
+}
+
+// dedupSink drops the events whose dedup id was published recently
+type dedupSink struct {
+	sink Sink
+	size int
+
+	mu     sync.Mutex
+	seen   map[string]*list.Element
+	recent *list.List // dedup ids, most recent at the front
+}
+
+// Deduplicate wraps the sink so it gets each of the last size dedup ids once
+func Deduplicate(sink Sink, size int) Sink {
+	return &dedupSink{
+		sink:   sink,
+		size:   size,
+		seen:   make(map[string]*list.Element),
+		recent: list.New(),
+	}
+}
+
+func (s *dedupSink) Publish(ctx context.Context, event *domain.Event) error {
+	s.mu.Lock()
+	_, duplicate := s.seen[event.DedupID]
+	s.mu.Unlock()
+	if duplicate {
+		return nil
+	}
+
+	if err := s.sink.Publish(ctx, event); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen[event.DedupID] = s.recent.PushFront(event.DedupID)
+	if s.recent.Len() > s.size {
+		oldest := s.recent.Back()
+		s.recent.Remove(oldest)
+		delete(s.seen, oldest.Value.(string))
+	}
+
+	return nil
+}
+
+// NewLogSink logs every event
+func NewLogSink(logger *slog.Logger) Sink {
+	return SinkFunc(func(ctx context.Context, event *domain.Event) error {
+		logger.InfoContext(ctx, "outbox event",
+			"event_id", event.DedupID, "type", event.Type, "post_id", event.PostID)
+		return nil
+	})
+}
+
+// NewWriterSink writes every event to w as a JSON line
+func NewWriterSink(w io.Writer) Sink {
+	var mu sync.Mutex
+	encoder := json.NewEncoder(w)
+	return SinkFunc(func(_ context.Context, event *domain.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return encoder.Encode(event)
+	})
+}
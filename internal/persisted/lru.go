@@ -0,0 +1,57 @@
+package persisted
+
+import (
+	"container/list"
+	"sync"
+)
+
+// lru is a size-bounded least recently used cache of query texts by hash
+type lru struct {
+	mu    sync.Mutex
+	size  int
+	order *list.List               // front is the most recently used
+	items map[string]*list.Element // hash -> element with *entry
+}
+
+type entry struct {
+	hash  string
+	query string
+}
+
+func newLRU(size int) *lru {
+	return &lru{
+		size:  size,
+		order: list.New(),
+		items: make(map[string]*list.Element),
+	}
+}
+
+func (c *lru) get(hash string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[hash]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(el)
+
+	return el.Value.(*entry).query, true
+}
+
+func (c *lru) add(hash, query string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, ok := c.items[hash]; ok {
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.items[hash] = c.order.PushFront(&entry{hash: hash, query: query})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*entry).hash)
+	}
+}
@@ -0,0 +1,54 @@
+package persisted
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Manifest maps query hashes to query texts
+type Manifest map[string]string
+
+// apolloManifest is the format produced by @apollo/generate-persisted-query-manifest
+type apolloManifest struct {
+	Format     string `json:"format"`
+	Version    int    `json:"version"`
+	Operations []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		Body string `json:"body"`
+	} `json:"operations"`
+}
+
+// LoadManifest reads a manifest file. Both the Apollo manifest format
+// and a plain JSON object of hash to query are supported.
+// Hashes are checked against the query texts.
+func LoadManifest(path string) (Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	m := make(Manifest)
+
+	var apollo apolloManifest
+	if err := json.Unmarshal(data, &apollo); err == nil && apollo.Format != "" {
+		for _, op := range apollo.Operations {
+			m[op.ID] = op.Body
+		}
+	} else if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	checked := make(Manifest, len(m))
+	for hash, query := range m {
+		hash = strings.ToLower(hash)
+		if Hash(query) != hash {
+			return nil, fmt.Errorf("manifest hash %s doesn't match its query", hash)
+		}
+		checked[hash] = query
+	}
+
+	return checked, nil
+}
@@ -0,0 +1,155 @@
+package persisted
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// apqVersion is the only supported version of the automatic persisted queries protocol
+const apqVersion = 1
+
+// Error is a persisted query error in the format expected by Apollo clients.
+// It implements gqlerrors.ExtendedError, so the code ends up in the GraphQL response.
+type Error struct {
+	Message string
+	Code    string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Extensions() map[string]any {
+	return map[string]any{"code": e.Code}
+}
+
+var (
+	// ErrNotFound tells the client to send the query text along with the hash
+	ErrNotFound     = &Error{Message: "PersistedQueryNotFound", Code: "PERSISTED_QUERY_NOT_FOUND"}
+	ErrNotSupported = &Error{Message: "PersistedQueryNotSupported", Code: "PERSISTED_QUERY_NOT_SUPPORTED"}
+	ErrNotAllowed   = &Error{Message: "PersistedQueryNotAllowed", Code: "PERSISTED_QUERY_NOT_ALLOWED"}
+	ErrHashMismatch = &Error{Message: "provided sha does not match query", Code: "INVALID_PERSISTED_QUERY"}
+	ErrBadRequest   = &Error{Message: "invalid persisted query extension", Code: "INVALID_PERSISTED_QUERY"}
+)
+
+// Store resolves persisted queries.
+// Queries from the manifest are always known, other queries are registered
+// by clients and kept in a bounded LRU cache. In allowlist mode only manifest queries are executed.
+type Store struct {
+	manifest      map[string]string // hash -> query
+	cache         *lru              // nil when automatic registration is disabled
+	allowlistOnly bool
+}
+
+// Option configures the Store
+type Option func(*Store)
+
+// WithManifest preloads queries from the manifest
+func WithManifest(m Manifest) Option {
+	return func(s *Store) {
+		for hash, query := range m {
+			s.manifest[hash] = query
+		}
+	}
+}
+
+// AllowlistOnly rejects all queries that aren't in the manifest
+func AllowlistOnly() Option {
+	return func(s *Store) {
+		s.allowlistOnly = true
+	}
+}
+
+// New creates a store with a cache of cacheSize registered queries, zero disables registration
+func New(cacheSize int, opts ...Option) *Store {
+	s := &Store{manifest: make(map[string]string)}
+	if cacheSize > 0 {
+		s.cache = newLRU(cacheSize)
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Resolve returns the query text to execute for the request query and extensions
+func (s *Store) Resolve(query string, extensions map[string]any) (string, error) {
+	hash, ok, err := requestedHash(extensions)
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		if s == nil || !s.allowlistOnly {
+			return query, nil
+		}
+		// a full query is allowed when its text is in the manifest
+		if _, known := s.manifest[Hash(query)]; !known {
+			return "", ErrNotAllowed
+		}
+		return query, nil
+	}
+
+	if s == nil || (s.cache == nil && len(s.manifest) == 0) {
+		return "", ErrNotSupported
+	}
+
+	if known, ok := s.manifest[hash]; ok {
+		return known, nil
+	}
+	if s.allowlistOnly {
+		return "", ErrNotAllowed
+	}
+	if s.cache == nil {
+		return "", ErrNotFound
+	}
+
+	if query == "" {
+		cached, ok := s.cache.get(hash)
+		if !ok {
+			return "", ErrNotFound
+		}
+		return cached, nil
+	}
+
+	if Hash(query) != hash {
+		return "", ErrHashMismatch
+	}
+	s.cache.add(hash, query)
+
+	return query, nil
+}
+
+// Hash returns the hex encoded sha256 of the query, as computed by clients
+func Hash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// requestedHash extracts the hash from the persistedQuery extension
+func requestedHash(extensions map[string]any) (string, bool, error) {
+	raw, ok := extensions["persistedQuery"]
+	if !ok {
+		return "", false, nil
+	}
+
+	pq, ok := raw.(map[string]any)
+	if !ok {
+		return "", false, ErrBadRequest
+	}
+	if version, _ := pq["version"].(float64); version != apqVersion {
+		return "", false, &Error{
+			Message: fmt.Sprintf("unsupported persisted query version, only %d is supported", apqVersion),
+			Code:    ErrBadRequest.Code,
+		}
+	}
+	hash, _ := pq["sha256Hash"].(string)
+	if hash == "" {
+		return "", false, ErrBadRequest
+	}
+
+	return strings.ToLower(hash), true, nil
+}
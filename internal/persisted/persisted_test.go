@@ -0,0 +1,86 @@
+package persisted
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ext(hash string) map[string]any {
+	return map[string]any{
+		"persistedQuery": map[string]any{"version": float64(1), "sha256Hash": hash},
+	}
+}
+
+func TestStore_AutomaticPersistedQueries(t *testing.T) {
+	s := New(1)
+	query := "{ posts { id } }"
+	hash := Hash(query)
+
+	// the first request with only the hash is unknown
+	_, err := s.Resolve("", ext(hash))
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// the client retries with the query, which registers it
+	resolved, err := s.Resolve(query, ext(hash))
+	assert.NoError(t, err)
+	assert.Equal(t, query, resolved)
+
+	resolved, err = s.Resolve("", ext(hash))
+	assert.NoError(t, err)
+	assert.Equal(t, query, resolved)
+
+	// registering another query evicts the least recently used one
+	other := "{ post(id: 1) { id } }"
+	_, err = s.Resolve(other, ext(Hash(other)))
+	assert.NoError(t, err)
+	_, err = s.Resolve("", ext(hash))
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = s.Resolve("{ posts { title } }", ext(hash))
+	assert.ErrorIs(t, err, ErrHashMismatch)
+
+	// plain queries pass through
+	resolved, err = s.Resolve(query, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, query, resolved)
+}
+
+func TestStore_AllowlistOnly(t *testing.T) {
+	allowed := "{ posts { id } }"
+	path := filepath.Join(t.TempDir(), "manifest.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"format": "apollo-persisted-query-manifest",
+		"version": 1,
+		"operations": [{"id": "`+Hash(allowed)+`", "name": "Posts", "type": "query", "body": "{ posts { id } }"}]
+	}`), 0o600))
+
+	manifest, err := LoadManifest(path)
+	require.NoError(t, err)
+
+	s := New(100, WithManifest(manifest), AllowlistOnly())
+
+	resolved, err := s.Resolve("", ext(Hash(allowed)))
+	assert.NoError(t, err)
+	assert.Equal(t, allowed, resolved)
+
+	resolved, err = s.Resolve(allowed, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, allowed, resolved)
+
+	other := "{ posts { title } }"
+	_, err = s.Resolve(other, ext(Hash(other)))
+	assert.ErrorIs(t, err, ErrNotAllowed)
+	_, err = s.Resolve(other, nil)
+	assert.ErrorIs(t, err, ErrNotAllowed)
+}
+
+func TestStore_Disabled(t *testing.T) {
+	var s *Store
+
+	_, err := s.Resolve("", ext(Hash("{ posts { id } }")))
+	assert.ErrorIs(t, err, ErrNotSupported)
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// bucket is a token bucket state
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	period  time.Duration // time to refill the bucket completely
+}
+
+// memoryStore keeps buckets of a single process
+type memoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	now       func() time.Time
+	lastSweep time.Time
+}
+
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// sweepInterval is how often full buckets are dropped to free memory
+const sweepInterval = time.Minute
+
+func (s *memoryStore) Take(_ context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if now.Sub(s.lastSweep) > sweepInterval {
+		s.sweep(now)
+	}
+
+	b, ok := s.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		s.buckets[key] = b
+	}
+
+	b.tokens = min(float64(limit.Burst), b.tokens+now.Sub(b.updated).Seconds()*limit.rate())
+	b.updated = now
+	b.period = limit.Period
+
+	if b.tokens < 1 {
+		return false, time.Duration((1 - b.tokens) / limit.rate() * float64(time.Second)), nil
+	}
+	b.tokens--
+
+	return true, 0, nil
+}
+
+// sweep drops buckets that have been idle long enough to be full again,
+// a missing bucket behaves exactly like a full one
+func (s *memoryStore) sweep(now time.Time) {
+	for key, b := range s.buckets {
+		if now.Sub(b.updated) > b.period {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// postgresStore keeps buckets in a table, so the limits hold across replicas
+type postgresStore struct {
+	pool      *pgxpool.Pool
+	lastSweep atomic.Int64 // unix nanoseconds
+}
+
+func NewPostgresStore(pool *pgxpool.Pool) Store {
+	return &postgresStore{pool: pool}
+}
+
+// takeToken refills the bucket and takes a token in one statement,
+// no row is returned when the bucket is empty
+const takeToken = `
+INSERT INTO rate_limit_buckets AS b (key, tokens, updated_at, expires_at)
+VALUES ($1, $2 - 1, NOW(), NOW() + $4 * INTERVAL '1 microsecond')
+ON CONFLICT (key) DO UPDATE
+SET tokens     = LEAST($2, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * $3) - 1,
+    updated_at = NOW(),
+    expires_at = NOW() + $4 * INTERVAL '1 microsecond'
+WHERE LEAST($2, b.tokens + EXTRACT(EPOCH FROM NOW() - b.updated_at) * $3) >= 1
+RETURNING tokens
+`
+
+const selectTokens = `
+SELECT LEAST($2, tokens + EXTRACT(EPOCH FROM NOW() - updated_at) * $3)
+FROM rate_limit_buckets
+WHERE key = $1
+`
+
+// deleteExpired removes buckets that are full again, a missing bucket behaves exactly like a full one
+const deleteExpired = `
+DELETE FROM rate_limit_buckets
+WHERE expires_at < NOW()
+`
+
+func (s *postgresStore) Take(ctx context.Context, key string, limit Limit) (bool, time.Duration, error) {
+	if err := s.sweep(ctx); err != nil {
+		return false, 0, err
+	}
+
+	var tokens float64
+	err := s.pool.QueryRow(ctx, takeToken, key, limit.Burst, limit.rate(), limit.Period.Microseconds()).Scan(&tokens)
+	if err == nil {
+		return true, 0, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return false, 0, fmt.Errorf("can't take rate limit token: %w", err)
+	}
+
+	if err := s.pool.QueryRow(ctx, selectTokens, key, limit.Burst, limit.rate()).Scan(&tokens); err != nil {
+		return false, 0, fmt.Errorf("can't select rate limit tokens: %w", err)
+	}
+
+	return false, time.Duration((1 - tokens) / limit.rate() * float64(time.Second)), nil
+}
+
+// sweep deletes expired buckets at most once per sweepInterval across the process
+func (s *postgresStore) sweep(ctx context.Context) error {
+	now := time.Now().UnixNano()
+	last := s.lastSweep.Load()
+	if now-last < int64(sweepInterval) || !s.lastSweep.CompareAndSwap(last, now) {
+		return nil
+	}
+
+	if _, err := s.pool.Exec(ctx, deleteExpired); err != nil {
+		return fmt.Errorf("can't delete expired rate limit buckets: %w", err)
+	}
+
+	return nil
+}
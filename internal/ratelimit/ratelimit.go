@@ -0,0 +1,119 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Operation is a mutation with its own rate limit budget
+type Operation string
+
+const (
+	OpCreatePost    Operation = "createPost"
+	OpCreateComment Operation = "createComment"
+	OpReport        Operation = "report"
+)
+
+// Limit is a token bucket: up to Burst calls at once, refilled by Burst tokens every Period
+type Limit struct {
+	Burst  int
+	Period time.Duration
+}
+
+// rate returns refilled tokens per second
+func (l Limit) rate() float64 {
+	return float64(l.Burst) / l.Period.Seconds()
+}
+
+// Store keeps token buckets. Take removes one token from the bucket of the key,
+// or returns how long to wait until a token is available.
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (ok bool, retryAfter time.Duration, err error)
+}
+
+// LimitedError is returned when a call exceeds its budget.
+// It implements gqlerrors.ExtendedError, so the code and the hint end up in the GraphQL response.
+type LimitedError struct {
+	Operation  Operation
+	RetryAfter time.Duration
+}
+
+func (e *LimitedError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s, retry after %s", e.Operation, e.RetryAfter)
+}
+
+func (e *LimitedError) Extensions() map[string]any {
+	return map[string]any{
+		"code":       "RATE_LIMITED",
+		"retryAfter": int(math.Ceil(e.RetryAfter.Seconds())), // seconds
+	}
+}
+
+// Limiter checks per-operation budgets of users and client IPs
+type Limiter struct {
+	store    Store
+	limits   map[Operation]Limit // per user
+	ipLimits map[Operation]Limit // per client IP, larger since users behind a NAT or a proxy share it
+}
+
+// New creates a limiter with the budgets of users and of client IPs,
+// operations without a limit or with zero burst are not limited
+func New(store Store, limits, ipLimits map[Operation]Limit) *Limiter {
+	return &Limiter{store: store, limits: limits, ipLimits: ipLimits}
+}
+
+// Allow takes a token for the operation from the bucket of the user id
+// and from the bucket of the client IP in the context.
+// User ids come from the client, so the IP budget is always charged
+// and rotating ids doesn't give a fresh budget.
+func (l *Limiter) Allow(ctx context.Context, op Operation, userID int) error {
+	if l == nil {
+		return nil
+	}
+
+	if ip, ok := ClientIP(ctx); ok {
+		if err := l.take(ctx, op, "ip:"+ip, l.ipLimits[op]); err != nil {
+			return err
+		}
+	}
+	if userID > 0 {
+		if err := l.take(ctx, op, "user:"+strconv.Itoa(userID), l.limits[op]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// take takes a token from the bucket of the operation and the key
+func (l *Limiter) take(ctx context.Context, op Operation, key string, limit Limit) error {
+	if limit.Burst <= 0 || limit.Period <= 0 {
+		return nil
+	}
+
+	allowed, retryAfter, err := l.store.Take(ctx, string(op)+":"+key, limit)
+	if err != nil {
+		return fmt.Errorf("failed to check rate limit: %w", err)
+	}
+	if !allowed {
+		return &LimitedError{Operation: op, RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+type clientIPKey struct{}
+
+// WithClientIP stores the client IP in the context
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP returns the client IP stored by WithClientIP
+func ClientIP(ctx context.Context) (string, bool) {
+	ip, ok := ctx.Value(clientIPKey{}).(string)
+	return ip, ok && ip != ""
+}
@@ -0,0 +1,62 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	store := NewMemoryStore().(*memoryStore)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	limiter := New(store, map[Operation]Limit{
+		OpCreateComment: {Burst: 2, Period: time.Minute},
+	}, nil)
+	ctx := context.Background()
+
+	assert.NoError(t, limiter.Allow(ctx, OpCreateComment, 1))
+	assert.NoError(t, limiter.Allow(ctx, OpCreateComment, 1))
+
+	err := limiter.Allow(ctx, OpCreateComment, 1)
+	var limited *LimitedError
+	assert.ErrorAs(t, err, &limited)
+	assert.Equal(t, 30*time.Second, limited.RetryAfter)
+	assert.Equal(t, map[string]any{"code": "RATE_LIMITED", "retryAfter": 30}, limited.Extensions())
+
+	// other users and operations have their own budgets
+	assert.NoError(t, limiter.Allow(ctx, OpCreateComment, 2))
+	assert.NoError(t, limiter.Allow(ctx, OpCreatePost, 1))
+
+	// a token is refilled after Period / Burst
+	now = now.Add(30 * time.Second)
+	assert.NoError(t, limiter.Allow(ctx, OpCreateComment, 1))
+	assert.Error(t, limiter.Allow(ctx, OpCreateComment, 1))
+}
+
+func TestLimiter_ClientIP(t *testing.T) {
+	limiter := New(NewMemoryStore(), map[Operation]Limit{
+		OpReport: {Burst: 1, Period: time.Hour},
+	}, map[Operation]Limit{
+		OpReport: {Burst: 3, Period: time.Hour},
+	})
+
+	// users behind one IP have their own budgets within the larger budget of the IP
+	ctx := WithClientIP(context.Background(), "10.0.0.1")
+	assert.NoError(t, limiter.Allow(ctx, OpReport, 1))
+	assert.Error(t, limiter.Allow(ctx, OpReport, 1))
+	assert.NoError(t, limiter.Allow(ctx, OpReport, 2))
+
+	// the IP budget is charged whatever user id is sent, the call rejected by the user budget included
+	assert.Error(t, limiter.Allow(ctx, OpReport, 3))
+	assert.Error(t, limiter.Allow(ctx, OpReport, 0))
+
+	other := WithClientIP(context.Background(), "10.0.0.2")
+	assert.NoError(t, limiter.Allow(other, OpReport, 4))
+
+	// the user budget is charged from any IP
+	assert.Error(t, limiter.Allow(other, OpReport, 2))
+}
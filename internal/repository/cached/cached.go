@@ -0,0 +1,199 @@
+// Package cached wraps a repository with a cache of the hottest reads: single posts
+// and the first pages of their comments. The writes made through it drop the entries they change.
+package cached
+
+import (
+	"context"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+)
+
+// Observer is called for every cacheable read with the method and whether the cache had it
+type Observer func(method string, hit bool)
+
+// Repository caches GetPost and the first pages of visible comments of GetCommentsByPost,
+// every other call goes straight to the wrapped repository.
+// The cache is local, writes made by other instances show up when the entries expire.
+//
+// Misses are read from the primary: a replica may not have replayed a write
+// that has just dropped the entry, and its old row would be cached for the whole TTL.
+type Repository struct {
+	writer // the other calls, the writes drop the entries they change
+	cache  *lru
+	ttl    time.Duration
+	now    func() time.Time
+
+	observer Observer
+}
+
+var _ repository.Repository = (*Repository)(nil)
+
+// Option configures the cache
+type Option func(*Repository)
+
+// WithTTL sets how long an entry is served, 30s by default
+func WithTTL(ttl time.Duration) Option {
+	return func(r *Repository) {
+		r.ttl = ttl
+	}
+}
+
+// WithObserver sets the observer of the hits and misses
+func WithObserver(observer Observer) Option {
+	return func(r *Repository) {
+		r.observer = observer
+	}
+}
+
+// New wraps the repository with a cache of at most size entries, it returns the repository as is when size is zero
+func New(repo repository.Repository, size int, opts ...Option) repository.Repository {
+	if size <= 0 {
+		return repo
+	}
+
+	r := &Repository{
+		cache:    newLRU(size),
+		ttl:      30 * time.Second,
+		now:      time.Now,
+		observer: func(string, bool) {},
+	}
+	r.writer = writer{Repository: repo, invalidate: r.cache.invalidate}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+func (r *Repository) GetPost(ctx context.Context, id int) (*domain.Post, error) {
+	k := key{kind: postKind, postID: id}
+	return cachedRead(ctx, r, "GetPost", k, clonePost, func(ctx context.Context) (*domain.Post, error) {
+		return r.Repository.GetPost(ctx, id)
+	})
+}
+
+// GetCommentsByPost caches the first pages of the visible comments
+func (r *Repository) GetCommentsByPost(ctx context.Context, postID int, limit, offset int, includeHidden bool) ([]*domain.Comment, error) {
+	if offset != 0 || includeHidden {
+		return r.Repository.GetCommentsByPost(ctx, postID, limit, offset, includeHidden)
+	}
+
+	k := key{kind: commentsKind, postID: postID, limit: limit}
+	return cachedRead(ctx, r, "GetCommentsByPost", k, cloneComments, func(ctx context.Context) ([]*domain.Comment, error) {
+		return r.Repository.GetCommentsByPost(ctx, postID, limit, offset, includeHidden)
+	})
+}
+
+// cachedRead returns the cached value or reads it from the primary and caches it, the calls that must see
+// the latest writes skip the cache. The cache keeps a clone of the value and callers get clones of it,
+// neither the repository nor the callers can change a cached value.
+func cachedRead[T any](
+	ctx context.Context, r *Repository, method string, k key, clone func(T) T, read func(ctx context.Context) (T, error),
+) (T, error) {
+	if repository.PrimaryReads(ctx) {
+		return read(ctx)
+	}
+
+	value, gen, ok := r.cache.get(k, r.now())
+	r.observer(method, ok)
+	if ok {
+		return clone(value.(T)), nil
+	}
+
+	res, err := read(repository.WithPrimaryReads(ctx))
+	if err != nil {
+		return res, err
+	}
+	r.cache.add(k, clone(res), gen, r.now().Add(r.ttl))
+	return res, nil
+}
+
+func clonePost(post *domain.Post) *domain.Post {
+	cp := *post
+	return &cp
+}
+
+func cloneComments(comments []*domain.Comment) []*domain.Comment {
+	cp := make([]*domain.Comment, len(comments))
+	for i, c := range comments {
+		comment := *c
+		if c.ParentID != nil {
+			parentID := *c.ParentID
+			comment.ParentID = &parentID
+		}
+		cp[i] = &comment
+	}
+	return cp
+}
+
+// WithTx runs fn on the unit of work without the cache, since its reads must see its writes.
+// The entries changed by its writes are dropped when it is over, even if it failed.
+func (r *Repository) WithTx(ctx context.Context, fn func(repo repository.Repository) error) error {
+	var invs []invalidation
+	err := r.Repository.WithTx(ctx, func(tx repository.Repository) error {
+		return fn(writer{Repository: tx, invalidate: func(inv ...invalidation) {
+			invs = append(invs, inv...)
+		}})
+	})
+	if len(invs) > 0 {
+		r.cache.invalidate(invs...)
+	}
+	return err
+}
+
+// writer reports the cache entries its writes change
+type writer struct {
+	repository.Repository
+	invalidate func(invs ...invalidation)
+}
+
+func (w writer) CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	saved, err := w.Repository.CreateComment(ctx, comment)
+	if err == nil {
+		w.invalidate(invalidation{kind: commentsKind, postID: comment.PostID})
+	}
+	return saved, err
+}
+
+func (w writer) DisableComments(ctx context.Context, postID int) error {
+	return w.changed(w.Repository.DisableComments(ctx, postID), invalidation{kind: postKind, postID: postID})
+}
+
+func (w writer) EnableComments(ctx context.Context, postID int) error {
+	return w.changed(w.Repository.EnableComments(ctx, postID), invalidation{kind: postKind, postID: postID})
+}
+
+func (w writer) HidePost(ctx context.Context, id int) error {
+	return w.changed(w.Repository.HidePost(ctx, id), invalidation{kind: postKind, postID: id})
+}
+
+func (w writer) DeletePost(ctx context.Context, id int) error {
+	return w.changed(w.Repository.DeletePost(ctx, id),
+		invalidation{kind: postKind, postID: id}, invalidation{kind: commentsKind, postID: id})
+}
+
+// HideComment drops the comment pages of every post, the post of the comment is not known without a lookup
+func (w writer) HideComment(ctx context.Context, id int) error {
+	return w.changed(w.Repository.HideComment(ctx, id), invalidation{kind: commentsKind})
+}
+
+// DeleteComment drops the comment pages of every post like HideComment
+func (w writer) DeleteComment(ctx context.Context, id int) error {
+	return w.changed(w.Repository.DeleteComment(ctx, id), invalidation{kind: commentsKind})
+}
+
+// WithTx in a unit of work reports the changes to the same one
+func (w writer) WithTx(ctx context.Context, fn func(repo repository.Repository) error) error {
+	return w.Repository.WithTx(ctx, func(tx repository.Repository) error {
+		return fn(writer{Repository: tx, invalidate: w.invalidate})
+	})
+}
+
+func (w writer) changed(err error, invs ...invalidation) error {
+	if err == nil {
+		w.invalidate(invs...)
+	}
+	return err
+}
@@ -0,0 +1,211 @@
+package cached
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/in_memory"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/repotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// every write made through the cache must show in the reads that follow
+func TestCached_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		return New(in_memory.New(), 100)
+	})
+}
+
+// counts are the hits and misses by method
+type counts map[string][2]int
+
+func (c counts) observe(method string, hit bool) {
+	n := c[method]
+	if hit {
+		n[0]++
+	} else {
+		n[1]++
+	}
+	c[method] = n
+}
+
+func setup(t *testing.T, size int) (*Repository, repository.Repository, counts, *domain.Post) {
+	inner := in_memory.New()
+	c := counts{}
+	r := New(inner, size, WithObserver(c.observe)).(*Repository)
+
+	post, err := r.CreatePost(context.Background(), &domain.Post{Title: "title", Content: "content", AuthorID: 1})
+	require.NoError(t, err)
+	return r, inner, c, post
+}
+
+func comment(postID int, content string) *domain.Comment {
+	return &domain.Comment{PostID: postID, AuthorID: 2, Content: content, CreatedAt: time.Now().UTC()}
+}
+
+func TestCached_GetPost(t *testing.T) {
+	r, inner, c, post := setup(t, 10)
+	ctx := context.Background()
+
+	got, err := r.GetPost(ctx, post.ID)
+	require.NoError(t, err)
+	got.Title = "changed by the caller"
+	got, err = r.GetPost(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "title", got.Title)
+	assert.Equal(t, [2]int{1, 1}, c["GetPost"])
+
+	// a write around the cache is not seen until the entry expires
+	require.NoError(t, inner.HidePost(ctx, post.ID))
+	got, err = r.GetPost(ctx, post.ID)
+	require.NoError(t, err)
+	assert.False(t, got.Hidden)
+
+	r.now = func() time.Time { return time.Now().Add(time.Minute) }
+	got, err = r.GetPost(ctx, post.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Hidden)
+
+	// missing posts are not cached
+	_, err = r.GetPost(ctx, 100)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = r.GetPost(ctx, 100)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Equal(t, [2]int{2, 4}, c["GetPost"])
+}
+
+func TestCached_Invalidation(t *testing.T) {
+	r, _, c, post := setup(t, 10)
+	ctx := context.Background()
+
+	page := func() []*domain.Comment {
+		comments, err := r.GetCommentsByPost(ctx, post.ID, 10, 0, false)
+		require.NoError(t, err)
+		return comments
+	}
+
+	assert.Empty(t, page())
+	_, err := r.CreateComment(ctx, comment(post.ID, "first"))
+	require.NoError(t, err)
+	assert.Len(t, page(), 1)
+
+	// writes in a unit of work are dropped once it is over
+	require.NoError(t, r.WithTx(ctx, func(tx repository.Repository) error {
+		_, err := tx.CreateComment(ctx, comment(post.ID, "second"))
+		return err
+	}))
+	comments := page()
+	assert.Len(t, comments, 2)
+	assert.Equal(t, [2]int{0, 3}, c["GetCommentsByPost"])
+
+	// hiding a comment drops the pages, the post stays
+	_, err = r.GetPost(ctx, post.ID)
+	require.NoError(t, err)
+	require.NoError(t, r.HideComment(ctx, comments[0].ID))
+	assert.Len(t, page(), 1)
+	_, err = r.GetPost(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, [2]int{1, 1}, c["GetPost"])
+
+	// disabling comments drops the post
+	require.NoError(t, r.WithTx(ctx, func(tx repository.Repository) error {
+		return tx.WithTx(ctx, func(tx repository.Repository) error {
+			return tx.DisableComments(ctx, post.ID)
+		})
+	}))
+	got, err := r.GetPost(ctx, post.ID)
+	require.NoError(t, err)
+	assert.True(t, got.CommentsDisabled)
+
+	require.NoError(t, r.DeletePost(ctx, post.ID))
+	_, err = r.GetPost(ctx, post.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = r.GetCommentsByPost(ctx, post.ID, 10, 0, false)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func TestCached_Uncached(t *testing.T) {
+	r, _, c, post := setup(t, 10)
+	ctx := context.Background()
+
+	for range 2 {
+		_, err := r.GetCommentsByPost(ctx, post.ID, 10, 10, false)
+		require.NoError(t, err)
+		_, err = r.GetCommentsByPost(ctx, post.ID, 10, 0, true)
+		require.NoError(t, err)
+		_, err = r.GetPost(repository.WithPrimaryReads(ctx), post.ID)
+		require.NoError(t, err)
+	}
+	assert.Empty(t, c)
+	assert.Zero(t, r.cache.len())
+}
+
+// laggingReplica serves an old copy of the post unless the read goes to the primary
+type laggingReplica struct {
+	repository.Repository
+	stale *domain.Post
+}
+
+func (r laggingReplica) GetPost(ctx context.Context, id int) (*domain.Post, error) {
+	if !repository.PrimaryReads(ctx) {
+		return r.stale, nil
+	}
+	return r.Repository.GetPost(ctx, id)
+}
+
+func TestCached_MissesReadPrimary(t *testing.T) {
+	ctx := context.Background()
+	inner := in_memory.New()
+	post, err := inner.CreatePost(ctx, &domain.Post{Title: "title", Content: "content", AuthorID: 1})
+	require.NoError(t, err)
+	r := New(laggingReplica{Repository: inner, stale: clonePost(post)}, 10)
+
+	require.NoError(t, r.DisableComments(ctx, post.ID))
+
+	// the miss after the write doesn't cache the row the replica hasn't replayed yet
+	for range 2 {
+		got, err := r.GetPost(ctx, post.ID)
+		require.NoError(t, err)
+		assert.True(t, got.CommentsDisabled)
+	}
+}
+
+func TestLRU(t *testing.T) {
+	c := newLRU(2)
+	now := time.Now()
+	later := now.Add(time.Minute)
+	k := func(postID int) key { return key{kind: postKind, postID: postID} }
+
+	for id := 1; id <= 3; id++ {
+		_, gen, ok := c.get(k(id), now)
+		require.False(t, ok)
+		c.add(k(id), id, gen, later)
+	}
+	assert.Equal(t, 2, c.len())
+	_, _, ok := c.get(k(1), now)
+	assert.False(t, ok, "the least recently used is evicted")
+
+	// a value read before an invalidation is not added
+	_, gen, _ := c.get(k(4), now)
+	c.invalidate(invalidation{kind: commentsKind, postID: 4})
+	c.add(k(4), 4, gen, later)
+	_, _, ok = c.get(k(4), now)
+	assert.False(t, ok)
+
+	// entries expire
+	_, _, ok = c.get(k(3), later)
+	assert.False(t, ok)
+	assert.Equal(t, 1, c.len())
+
+	c.invalidate(invalidation{kind: postKind})
+	assert.Zero(t, c.len())
+}
+
+func TestNew_Disabled(t *testing.T) {
+	repo := in_memory.New()
+	assert.Same(t, repo, New(repo, 0))
+}
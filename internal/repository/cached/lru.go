@@ -0,0 +1,134 @@
+package cached
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// kind is what an entry of the cache holds
+type kind int
+
+const (
+	postKind     kind = iota // a post
+	commentsKind             // the first page of the visible comments of a post
+)
+
+type key struct {
+	kind   kind
+	postID int
+	limit  int // of a comments page
+}
+
+// invalidation drops the entries of a kind of the post, or of every post when postID is zero
+type invalidation struct {
+	kind   kind
+	postID int
+}
+
+// lru is a size-bounded least recently used cache whose entries expire
+type lru struct {
+	mu     sync.Mutex
+	size   int
+	order  *list.List                    // front is the most recently used
+	items  map[key]*list.Element         // key -> element with *entry
+	byPost map[int]map[key]*list.Element // the items of every post, to drop them together
+	gen    uint64                        // counts invalidations, see add
+}
+
+type entry struct {
+	key     key
+	value   any
+	expires time.Time
+}
+
+func newLRU(size int) *lru {
+	return &lru{
+		size:   size,
+		order:  list.New(),
+		items:  make(map[key]*list.Element),
+		byPost: make(map[int]map[key]*list.Element),
+	}
+}
+
+// get returns the value unless it is missing or expired, and the generation to add the value read instead with
+func (c *lru) get(k key, now time.Time) (any, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[k]
+	if !ok {
+		return nil, c.gen, false
+	}
+	e := el.Value.(*entry)
+	if !now.Before(e.expires) {
+		c.remove(el)
+		return nil, c.gen, false
+	}
+	c.order.MoveToFront(el)
+
+	return e.value, c.gen, true
+}
+
+// add stores the value unless something was invalidated since get returned the generation,
+// the value may have been read before that write and would be stale
+func (c *lru) add(k key, value any, gen uint64, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.gen {
+		return
+	}
+	if el, ok := c.items[k]; ok {
+		c.remove(el)
+	}
+
+	el := c.order.PushFront(&entry{key: k, value: value, expires: expires})
+	c.items[k] = el
+	if c.byPost[k.postID] == nil {
+		c.byPost[k.postID] = make(map[key]*list.Element)
+	}
+	c.byPost[k.postID][k] = el
+
+	if c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *lru) invalidate(invs ...invalidation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	for _, inv := range invs {
+		if inv.postID != 0 {
+			for k, el := range c.byPost[inv.postID] {
+				if k.kind == inv.kind {
+					c.remove(el)
+				}
+			}
+			continue
+		}
+		for k, el := range c.items {
+			if k.kind == inv.kind {
+				c.remove(el)
+			}
+		}
+	}
+}
+
+func (c *lru) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *lru) remove(el *list.Element) {
+	k := el.Value.(*entry).key
+	c.order.Remove(el)
+	delete(c.items, k)
+	delete(c.byPost[k.postID], k)
+	if len(c.byPost[k.postID]) == 0 {
+		delete(c.byPost, k.postID)
+	}
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+)
+
+// Remap prepares an import: it returns copies of the posts and comments with the new ids the repository
+// reserved for them, in the same order, with the post and parent ids of the comments remapped.
+// A reference to a post or a parent comment that is not imported before it is ErrNotFound, a duplicate id is ErrConflict.
+func Remap(posts []*domain.Post, comments []*domain.Comment, postIDs, commentIDs []int) ([]*domain.Post, []*domain.Comment, error) {
+	if len(postIDs) != len(posts) || len(commentIDs) != len(comments) {
+		return nil, nil, fmt.Errorf("got %d post ids for %d posts and %d comment ids for %d comments",
+			len(postIDs), len(posts), len(commentIDs), len(comments))
+	}
+
+	newPostIDs := make(map[int]int, len(posts))
+	remappedPosts := make([]*domain.Post, len(posts))
+	for i, post := range posts {
+		if _, ok := newPostIDs[post.ID]; ok {
+			return nil, nil, fmt.Errorf("%w: duplicate post %d", ErrConflict, post.ID)
+		}
+		newPostIDs[post.ID] = postIDs[i]
+
+		remapped := *post
+		remapped.ID = postIDs[i]
+		remappedPosts[i] = &remapped
+	}
+
+	newCommentIDs := make(map[int]int, len(comments))
+	remappedComments := make([]*domain.Comment, len(comments))
+	for i, comment := range comments {
+		if _, ok := newCommentIDs[comment.ID]; ok {
+			return nil, nil, fmt.Errorf("%w: duplicate comment %d", ErrConflict, comment.ID)
+		}
+
+		remapped := *comment
+		remapped.ID = commentIDs[i]
+		postID, ok := newPostIDs[comment.PostID]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: post %d of comment %d is not imported", ErrNotFound, comment.PostID, comment.ID)
+		}
+		remapped.PostID = postID
+		if comment.ParentID != nil {
+			parentID, ok := newCommentIDs[*comment.ParentID]
+			if !ok {
+				return nil, nil, fmt.Errorf("%w: parent %d of comment %d is not imported before it", ErrNotFound, *comment.ParentID, comment.ID)
+			}
+			remapped.ParentID = &parentID
+		}
+
+		newCommentIDs[comment.ID] = commentIDs[i]
+		remappedComments[i] = &remapped
+	}
+
+	return remappedPosts, remappedComments, nil
+}
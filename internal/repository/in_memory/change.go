@@ -0,0 +1,162 @@
+package in_memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+)
+
+// op is the kind of a change to the store
+type op string
+
+const (
+	opCreatePost              op = "create_post"
+	opCreateComment           op = "create_comment"
+	opDisableComments         op = "disable_comments"
+	opEnableComments          op = "enable_comments"
+	opImportPosts             op = "import_posts"
+	opHidePost                op = "hide_post"
+	opHideComment             op = "hide_comment"
+	opDeletePost              op = "delete_post"
+	opDeleteComment           op = "delete_comment"
+	opCreateReport            op = "create_report"
+	opResolveReport           op = "resolve_report"
+	opBanAuthor               op = "ban_author"
+	opCreateEvent             op = "create_event"
+	opPublishEvents           op = "publish_events"
+	opDeletePublishedEvents   op = "delete_published_events"
+	opCreateWebhook           op = "create_webhook"
+	opDeleteWebhook           op = "delete_webhook"
+	opCreateWebhookDeliveries op = "create_webhook_deliveries"
+	opUpdateWebhookDelivery   op = "update_webhook_delivery"
+)
+
+// change is a write to the store with everything needed to redo it, ids included.
+// Changes are applied the same way when they are made and when the write-ahead log is replayed.
+type change struct {
+	Op         op                        `json:"op"`
+	ID         int                       `json:"id,omitempty"` // post, comment, report, author or webhook id
+	IDs        []int                     `json:"ids,omitempty"`
+	Time       *time.Time                `json:"time,omitempty"`
+	Status     domain.ReportStatus       `json:"status,omitempty"`
+	Post       *domain.Post              `json:"post,omitempty"`
+	Comment    *domain.Comment           `json:"comment,omitempty"`
+	Posts      []*domain.Post            `json:"posts,omitempty"`
+	Comments   []*domain.Comment         `json:"comments,omitempty"`
+	Report     *domain.Report            `json:"report,omitempty"`
+	Record     *domain.ModerationRecord  `json:"record,omitempty"`
+	Event      *domain.Event             `json:"event,omitempty"`
+	Webhook    *persistedWebhook         `json:"webhook,omitempty"`
+	Deliveries []*domain.WebhookDelivery `json:"deliveries,omitempty"`
+}
+
+// persistedWebhook keeps the secret that domain.Webhook leaves out of JSON
+type persistedWebhook struct {
+	*domain.Webhook
+	Secret string `json:"secret"`
+}
+
+func persistWebhook(w *domain.Webhook) *persistedWebhook {
+	return &persistedWebhook{Webhook: w, Secret: w.Secret}
+}
+
+func (w *persistedWebhook) webhook() *domain.Webhook {
+	w.Webhook.Secret = w.Secret
+	return w.Webhook
+}
+
+// commit applies the change. When the store is persisted the change is logged first,
+// in a unit of work all its changes are logged together when it ends.
+func (r *inMemoryRepository) commit(c change) error {
+	if r.wal != nil {
+		encoded, err := json.Marshal(c)
+		if err != nil {
+			return fmt.Errorf("failed to encode change: %w", err)
+		}
+		if r.inTx {
+			r.pending = append(r.pending, encoded)
+		} else if err := r.wal.append([]json.RawMessage{encoded}); err != nil {
+			return err
+		}
+	}
+
+	r.apply(&c)
+	return nil
+}
+
+// apply makes the change to the store, the caller holds the write lock
+func (s *store) apply(c *change) {
+	switch c.Op {
+	case opCreatePost:
+		s.putPost(c.Post)
+	case opCreateComment:
+		s.putComment(c.Comment)
+	case opDisableComments:
+		if post, ok := s.posts[c.ID]; ok {
+			post.CommentsDisabled = true
+		}
+	case opEnableComments:
+		if post, ok := s.posts[c.ID]; ok {
+			post.CommentsDisabled = false
+		}
+	case opImportPosts:
+		for _, post := range c.Posts {
+			s.putPost(post)
+		}
+		for _, comment := range c.Comments {
+			s.putComment(comment)
+		}
+	case opHidePost:
+		if post, ok := s.posts[c.ID]; ok {
+			post.Hidden = true
+		}
+	case opHideComment:
+		if comment, ok := s.comments[c.ID]; ok {
+			s.hideComment(comment)
+		}
+	case opDeletePost:
+		s.deletePost(c.ID)
+	case opDeleteComment:
+		s.deleteThread(c.ID)
+	case opCreateReport:
+		s.reports[c.Report.ID] = c.Report
+		s.reportID = max(s.reportID, c.Report.ID)
+	case opResolveReport:
+		if report, ok := s.reports[c.ID]; ok {
+			resolvedAt := c.Record.CreatedAt
+			report.Status = c.Status
+			report.ResolvedAt = &resolvedAt
+		}
+		s.records = append(s.records, c.Record)
+		s.recordID = max(s.recordID, c.Record.ID)
+	case opBanAuthor:
+		s.banned[c.ID] = struct{}{}
+	case opCreateEvent:
+		s.events = append(s.events, c.Event)
+		s.eventID = max(s.eventID, c.Event.ID)
+	case opPublishEvents:
+		s.publishEvents(c.IDs, *c.Time)
+	case opDeletePublishedEvents:
+		s.deletePublishedEvents(*c.Time)
+	case opCreateWebhook:
+		w := c.Webhook.webhook()
+		s.webhooks[w.ID] = w
+		s.webhookID = max(s.webhookID, w.ID)
+	case opDeleteWebhook:
+		s.deleteWebhook(c.ID)
+	case opCreateWebhookDeliveries:
+		for _, d := range c.Deliveries {
+			s.deliveries = append(s.deliveries, d)
+			s.deliveryID = max(s.deliveryID, d.ID)
+		}
+	case opUpdateWebhookDelivery:
+		for i, d := range s.deliveries {
+			if d.ID == c.Deliveries[0].ID {
+				s.deliveries[i] = c.Deliveries[0]
+				break
+			}
+		}
+	}
+}
@@ -2,127 +2,268 @@ package in_memory
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/tidwall/btree"
 )
 
 // inMemoryRepository is an in-memory implementation of repository.Repository
 type inMemoryRepository struct {
-	mu        sync.RWMutex            // concurrent map access protection
-	posts     map[int]*domain.Post    // post id -> post
-	comments  map[int]*domain.Comment // comment id -> comment
-	postID    int                     // autoincrement
-	commentID int                     // autoincrement
+	*store
+	inTx bool // the write lock is held by WithTx
+}
+
+// store is the state shared by the repository and its units of work
+type store struct {
+	mu        sync.RWMutex                // concurrent map access protection
+	posts     map[int]*domain.Post        // post id -> post
+	postOrder *btree.BTreeG[*domain.Post] // posts by id
+	comments  map[int]*domain.Comment     // comment id -> comment
+	byPost    commentIndexes              // post id -> its comments
+	byParent  commentIndexes              // comment id -> its replies
+	postID    int                         // autoincrement
+	commentID int                         // autoincrement
+
+	reports  map[int]*domain.Report     // report id -> report
+	records  []*domain.ModerationRecord // moderation audit trail in insertion order
+	banned   map[int]struct{}           // banned author ids
+	reportID int                        // autoincrement
+	recordID int                        // autoincrement
+
+	events  []*domain.Event   // outbox in id order
+	leases  map[int]time.Time // event id -> end of its claim
+	eventID int               // autoincrement
+
+	webhooks   map[int]*domain.Webhook   // webhook id -> webhook
+	deliveries []*domain.WebhookDelivery // webhook deliveries in id order
+	webhookID  int                       // autoincrement
+	deliveryID int                       // autoincrement
+
+	wal     *wal              // nil unless the store is persisted
+	pending []json.RawMessage // changes of the running unit of work, logged when it ends
 }
 
 func New() repository.Repository {
-	return &inMemoryRepository{
+	return &inMemoryRepository{store: newStore()}
+}
+
+func newStore() *store {
+	return &store{
 		posts:     make(map[int]*domain.Post),
+		postOrder: btree.NewBTreeGOptions(byID, treeOptions),
 		comments:  make(map[int]*domain.Comment),
+		byPost:    make(commentIndexes),
+		byParent:  make(commentIndexes),
 		postID:    0,
 		commentID: 0,
+		reports:   make(map[int]*domain.Report),
+		banned:    make(map[int]struct{}),
+		leases:    make(map[int]time.Time),
+		webhooks:  make(map[int]*domain.Webhook),
+	}
+}
+
+// WithTx runs fn holding the write lock, so no other call interleaves with it.
+// Changes made before fn returns an error are kept, there is no rollback.
+// When the store is persisted, the changes are logged as one record, so after a crash
+// either all of them are replayed or none.
+func (r *inMemoryRepository) WithTx(_ context.Context, fn func(repo repository.Repository) error) error {
+	if r.inTx {
+		return fn(r)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	err := fn(&inMemoryRepository{store: r.store, inTx: true})
+
+	if len(r.pending) > 0 {
+		pending := r.pending
+		r.pending = nil
+		if walErr := r.wal.append(pending); walErr != nil {
+			return errors.Join(err, walErr)
+		}
+	}
+
+	return err
+}
+
+// lock, unlock, rlock and runlock guard the store unless the write lock is already held by WithTx
+
+func (r *inMemoryRepository) lock() {
+	if !r.inTx {
+		r.mu.Lock()
+	}
+}
+
+func (r *inMemoryRepository) unlock() {
+	if !r.inTx {
+		r.mu.Unlock()
+	}
+}
+
+func (r *inMemoryRepository) rlock() {
+	if !r.inTx {
+		r.mu.RLock()
+	}
+}
+
+func (r *inMemoryRepository) runlock() {
+	if !r.inTx {
+		r.mu.RUnlock()
 	}
 }
 
 func (r *inMemoryRepository) GetPosts(_ context.Context) ([]*domain.Post, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	r.rlock()
+	defer r.runlock()
 
-	posts := make([]*domain.Post, 0, len(r.posts))
-	for _, post := range r.posts {
-		posts = append(posts, post)
+	return r.postOrder.Items(), nil
+}
+
+func (r *inMemoryRepository) GetPostsAfter(_ context.Context, limit, afterID int) ([]*domain.Post, error) {
+	r.rlock()
+	defer r.runlock()
+
+	posts := make([]*domain.Post, 0, min(max(limit, 0), r.postOrder.Len()))
+	if limit <= 0 {
+		return posts, nil
 	}
+	r.postOrder.Ascend(&domain.Post{ID: afterID + 1}, func(post *domain.Post) bool {
+		posts = append(posts, post)
+		return len(posts) < limit
+	})
 	return posts, nil
 }
 
 func (r *inMemoryRepository) GetPost(_ context.Context, id int) (*domain.Post, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+	r.rlock()
+	defer r.runlock()
 
-	post, _ := r.posts[id]
+	post, ok := r.posts[id]
+	if !ok {
+		return nil, postNotFound(id)
+	}
 	return post, nil
 }
 
-func (r *inMemoryRepository) ContainsPost(_ context.Context, id int) (bool, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-
-	_, exists := r.posts[id]
-	return exists, nil
-}
-
 func (r *inMemoryRepository) CreatePost(_ context.Context, post *domain.Post) (*domain.Post, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	r.lock()
+	defer r.unlock()
 
-	r.postID++
-	post.ID = r.postID
-
-	r.posts[post.ID] = post
+	post.ID = r.postID + 1
+	if err := r.commit(change{Op: opCreatePost, Post: post}); err != nil {
+		return nil, err
+	}
 
 	return post, nil
 }
 
 func (r *inMemoryRepository) CreateComment(_ context.Context, comment *domain.Comment) (*domain.Comment, error) {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	r.lock()
+	defer r.unlock()
 
-	r.commentID++
-	comment.ID = r.commentID
+	if _, ok := r.posts[comment.PostID]; !ok {
+		return nil, postNotFound(comment.PostID)
+	}
+	if comment.ParentID != nil {
+		if _, ok := r.comments[*comment.ParentID]; !ok {
+			return nil, commentNotFound(*comment.ParentID)
+		}
+	}
 
-	r.comments[comment.ID] = comment
+	comment.ID = r.commentID + 1
+	if err := r.commit(change{Op: opCreateComment, Comment: comment}); err != nil {
+		return nil, err
+	}
 
 	return comment, nil
 }
 
-func (r *inMemoryRepository) ContainsComment(_ context.Context, id int) (bool, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+func (r *inMemoryRepository) GetComment(_ context.Context, id int) (*domain.Comment, error) {
+	r.rlock()
+	defer r.runlock()
 
-	_, exists := r.comments[id]
-	return exists, nil
+	comment, ok := r.comments[id]
+	if !ok {
+		return nil, commentNotFound(id)
+	}
+	return comment, nil
 }
 
-func (r *inMemoryRepository) GetCommentsByPost(ctx context.Context, postID, limit, offset int) ([]*domain.Comment, error) {
-	return r.getComments(func(c *domain.Comment) bool {
-		return c.PostID == postID
-	}, limit, offset)
-}
+func (r *inMemoryRepository) GetCommentsByPost(_ context.Context, postID, limit, offset int, includeHidden bool) ([]*domain.Comment, error) {
+	r.rlock()
+	defer r.runlock()
 
-func (r *inMemoryRepository) GetCommentsByParent(ctx context.Context, parentId, limit, offset int) ([]*domain.Comment, error) {
-	return r.getComments(func(c *domain.Comment) bool {
-		return c.ParentID != nil && *c.ParentID == parentId
-	}, limit, offset)
+	comments := r.byPost.page(postID, limit, offset, includeHidden)
+	if _, ok := r.posts[postID]; !ok {
+		return nil, postNotFound(postID)
+	}
+	return comments, nil
 }
 
-func (r *inMemoryRepository) getComments(filterFunc func(*domain.Comment) bool, limit, offset int) ([]*domain.Comment, error) {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
+func (r *inMemoryRepository) GetCommentsByParent(_ context.Context, parentId, limit, offset int, includeHidden bool) ([]*domain.Comment, error) {
+	r.rlock()
+	defer r.runlock()
 
-	comments := make([]*domain.Comment, 0, limit)
-	count := 0
-	for _, comment := range r.comments {
-		if filterFunc(comment) {
-			if count >= offset {
-				comments = append(comments, comment)
-				if len(comments) == limit {
-					break
-				}
-			}
-			count++
-		}
+	comments := r.byParent.page(parentId, limit, offset, includeHidden)
+	if _, ok := r.comments[parentId]; !ok {
+		return nil, commentNotFound(parentId)
 	}
-
 	return comments, nil
 }
 
 func (r *inMemoryRepository) DisableComments(_ context.Context, postID int) error {
-	r.mu.Lock()
-	defer r.mu.Unlock()
+	r.lock()
+	defer r.unlock()
+
+	if _, ok := r.posts[postID]; !ok {
+		return postNotFound(postID)
+	}
+	return r.commit(change{Op: opDisableComments, ID: postID})
+}
+
+func (r *inMemoryRepository) EnableComments(_ context.Context, postID int) error {
+	r.lock()
+	defer r.unlock()
+
+	if _, ok := r.posts[postID]; !ok {
+		return postNotFound(postID)
+	}
+	return r.commit(change{Op: opEnableComments, ID: postID})
+}
+
+// ImportPosts commits the import as one change, so it is applied and logged whole
+func (r *inMemoryRepository) ImportPosts(_ context.Context, posts []*domain.Post, comments []*domain.Comment) error {
+	r.lock()
+	defer r.unlock()
+
+	postIDs := make([]int, len(posts))
+	for i := range postIDs {
+		postIDs[i] = r.postID + i + 1
+	}
+	commentIDs := make([]int, len(comments))
+	for i := range commentIDs {
+		commentIDs[i] = r.commentID + i + 1
+	}
+
+	posts, comments, err := repository.Remap(posts, comments, postIDs, commentIDs)
+	if err != nil {
+		return err
+	}
+	return r.commit(change{Op: opImportPosts, Posts: posts, Comments: comments})
+}
+
+func postNotFound(id int) error {
+	return fmt.Errorf("post %d: %w", id, repository.ErrNotFound)
+}
 
-	post, _ := r.posts[postID]
-	post.CommentsDisabled = true
-	return nil
+func commentNotFound(id int) error {
+	return fmt.Errorf("comment %d: %w", id, repository.ErrNotFound)
 }
@@ -0,0 +1,94 @@
+package in_memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/repotest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemory_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		return New()
+	})
+}
+
+func TestPersistent_Conformance(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		repo := open(t, t.TempDir(), WithSnapshotAfter(20))
+		t.Cleanup(func() {
+			assert.NoError(t, repo.Close(context.Background()))
+		})
+		return repo
+	})
+}
+
+// benchmarkRepository has one post with the given number of comments, every tenth of them hidden,
+// and other posts with as many comments in total
+func benchmarkRepository(b *testing.B, comments int) repository.Repository {
+	ctx := context.Background()
+	repo := New()
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for postID := 1; postID <= 10; postID++ {
+		_, err := repo.CreatePost(ctx, &domain.Post{Title: "post", Content: "content", AuthorID: 1, CreatedAt: base})
+		require.NoError(b, err)
+	}
+	for i := 0; i < 2*comments; i++ {
+		postID := 1
+		if i%2 == 1 {
+			postID = 2 + i%9
+		}
+		comment, err := repo.CreateComment(ctx, &domain.Comment{
+			PostID:    postID,
+			AuthorID:  2,
+			Content:   "comment",
+			CreatedAt: base.Add(time.Duration(i%1000) * time.Second),
+		})
+		require.NoError(b, err)
+		if i%20 == 0 {
+			require.NoError(b, repo.HideComment(ctx, comment.ID))
+		}
+	}
+
+	return repo
+}
+
+func BenchmarkGetCommentsByPost(b *testing.B) {
+	for _, comments := range []int{1000, 100000} {
+		repo := benchmarkRepository(b, comments)
+		for _, offset := range []int{0, comments / 2, comments - 100} {
+			b.Run(fmt.Sprintf("comments=%d/offset=%d", comments, offset), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					page, err := repo.GetCommentsByPost(context.Background(), 1, 20, offset, true)
+					if err != nil || len(page) != 20 {
+						b.Fatalf("got %d comments, error %v", len(page), err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkCreateComment(b *testing.B) {
+	for _, comments := range []int{1000, 100000} {
+		b.Run(fmt.Sprintf("comments=%d", comments), func(b *testing.B) {
+			repo := benchmarkRepository(b, comments)
+			createdAt := time.Now().UTC()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				_, err := repo.CreateComment(context.Background(), &domain.Comment{PostID: 1, AuthorID: 2, Content: "comment", CreatedAt: createdAt})
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
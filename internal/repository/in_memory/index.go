@@ -0,0 +1,173 @@
+package in_memory
+
+import (
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/tidwall/btree"
+)
+
+// The store keeps posts and comments in maps for lookups by id and in B-trees for ordered reads.
+// The trees count the items in every subtree, so a page at any offset is found in O(log n)
+// and read in O(k). The store lock guards the trees, they don't lock themselves.
+
+// treeOptions turn off the locking of the trees
+var treeOptions = btree.Options{NoLocks: true}
+
+func byID(a, b *domain.Post) bool {
+	return a.ID < b.ID
+}
+
+// newestFirst is the comment order of the postgres implementation: created_at DESC, id DESC
+func newestFirst(a, b *domain.Comment) bool {
+	if !a.CreatedAt.Equal(b.CreatedAt) {
+		return a.CreatedAt.After(b.CreatedAt)
+	}
+	return a.ID > b.ID
+}
+
+// commentIndex orders the comments of one post or the replies to one comment
+type commentIndex struct {
+	all     *btree.BTreeG[*domain.Comment]
+	visible *btree.BTreeG[*domain.Comment] // without hidden comments
+}
+
+func newCommentIndex() *commentIndex {
+	return &commentIndex{
+		all:     btree.NewBTreeGOptions(newestFirst, treeOptions),
+		visible: btree.NewBTreeGOptions(newestFirst, treeOptions),
+	}
+}
+
+func (ix *commentIndex) add(comment *domain.Comment) {
+	ix.all.Set(comment)
+	if !comment.Hidden {
+		ix.visible.Set(comment)
+	}
+}
+
+func (ix *commentIndex) remove(comment *domain.Comment) {
+	ix.all.Delete(comment)
+	ix.visible.Delete(comment)
+}
+
+// page returns limit comments starting at offset, newest first
+func (ix *commentIndex) page(limit, offset int, includeHidden bool) []*domain.Comment {
+	tree := ix.visible
+	if includeHidden {
+		tree = ix.all
+	}
+
+	if limit <= 0 || offset < 0 {
+		return []*domain.Comment{}
+	}
+	first, ok := tree.GetAt(offset)
+	if !ok {
+		return []*domain.Comment{}
+	}
+
+	comments := make([]*domain.Comment, 0, min(limit, tree.Len()-offset))
+	tree.Ascend(first, func(comment *domain.Comment) bool {
+		comments = append(comments, comment)
+		return len(comments) < limit
+	})
+
+	return comments
+}
+
+// commentIndexes are the indexes of comments grouped by post or by parent
+type commentIndexes map[int]*commentIndex
+
+// page returns a page of the group, empty if the group has no comments
+func (ixs commentIndexes) page(id, limit, offset int, includeHidden bool) []*domain.Comment {
+	ix, ok := ixs[id]
+	if !ok {
+		return []*domain.Comment{}
+	}
+	return ix.page(limit, offset, includeHidden)
+}
+
+func (ixs commentIndexes) add(id int, comment *domain.Comment) {
+	ix, ok := ixs[id]
+	if !ok {
+		ix = newCommentIndex()
+		ixs[id] = ix
+	}
+	ix.add(comment)
+}
+
+func (ixs commentIndexes) remove(id int, comment *domain.Comment) {
+	ix, ok := ixs[id]
+	if !ok {
+		return
+	}
+	ix.remove(comment)
+	if ix.all.Len() == 0 {
+		delete(ixs, id)
+	}
+}
+
+// putPost stores the post
+func (s *store) putPost(post *domain.Post) {
+	s.posts[post.ID] = post
+	s.postOrder.Set(post)
+	s.postID = max(s.postID, post.ID)
+}
+
+// putComment stores the comment and adds it to the indexes of its post and its parent
+func (s *store) putComment(comment *domain.Comment) {
+	s.comments[comment.ID] = comment
+	s.byPost.add(comment.PostID, comment)
+	if comment.ParentID != nil {
+		s.byParent.add(*comment.ParentID, comment)
+	}
+	s.commentID = max(s.commentID, comment.ID)
+}
+
+// hideComment marks the comment hidden and takes it out of the visible comments
+func (s *store) hideComment(comment *domain.Comment) {
+	comment.Hidden = true
+	if ix := s.byPost[comment.PostID]; ix != nil {
+		ix.visible.Delete(comment)
+	}
+	if comment.ParentID != nil {
+		if ix := s.byParent[*comment.ParentID]; ix != nil {
+			ix.visible.Delete(comment)
+		}
+	}
+}
+
+// removeComment removes the comment alone, its replies stay
+func (s *store) removeComment(comment *domain.Comment) {
+	delete(s.comments, comment.ID)
+	s.byPost.remove(comment.PostID, comment)
+	if comment.ParentID != nil {
+		s.byParent.remove(*comment.ParentID, comment)
+	}
+}
+
+// deletePost removes the post and its comments, mirroring ON DELETE CASCADE
+func (s *store) deletePost(id int) {
+	if post, ok := s.posts[id]; ok {
+		delete(s.posts, id)
+		s.postOrder.Delete(post)
+	}
+
+	if ix, ok := s.byPost[id]; ok {
+		for _, comment := range ix.all.Items() {
+			s.deleteThread(comment.ID)
+		}
+	}
+}
+
+// deleteThread removes the comment and all of its replies, mirroring ON DELETE CASCADE
+func (s *store) deleteThread(id int) {
+	comment, ok := s.comments[id]
+	if !ok {
+		return
+	}
+	if ix := s.byParent[id]; ix != nil {
+		for _, reply := range ix.all.Items() {
+			s.deleteThread(reply.ID)
+		}
+	}
+	s.removeComment(comment)
+}
@@ -0,0 +1,139 @@
+package in_memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+)
+
+func (r *inMemoryRepository) HidePost(_ context.Context, id int) error {
+	r.lock()
+	defer r.unlock()
+
+	if _, ok := r.posts[id]; !ok {
+		return postNotFound(id)
+	}
+	return r.commit(change{Op: opHidePost, ID: id})
+}
+
+func (r *inMemoryRepository) HideComment(_ context.Context, id int) error {
+	r.lock()
+	defer r.unlock()
+
+	if _, ok := r.comments[id]; !ok {
+		return commentNotFound(id)
+	}
+	return r.commit(change{Op: opHideComment, ID: id})
+}
+
+func (r *inMemoryRepository) DeletePost(_ context.Context, id int) error {
+	r.lock()
+	defer r.unlock()
+
+	if _, ok := r.posts[id]; !ok {
+		return postNotFound(id)
+	}
+	return r.commit(change{Op: opDeletePost, ID: id})
+}
+
+func (r *inMemoryRepository) DeleteComment(_ context.Context, id int) error {
+	r.lock()
+	defer r.unlock()
+
+	if _, ok := r.comments[id]; !ok {
+		return commentNotFound(id)
+	}
+	return r.commit(change{Op: opDeleteComment, ID: id})
+}
+
+func (r *inMemoryRepository) CreateReport(_ context.Context, report *domain.Report) (*domain.Report, error) {
+	r.lock()
+	defer r.unlock()
+
+	report.ID = r.reportID + 1
+	if err := r.commit(change{Op: opCreateReport, Report: report}); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (r *inMemoryRepository) GetReport(_ context.Context, id int) (*domain.Report, error) {
+	r.rlock()
+	defer r.runlock()
+
+	report, ok := r.reports[id]
+	if !ok {
+		return nil, fmt.Errorf("report %d: %w", id, repository.ErrNotFound)
+	}
+	return report, nil
+}
+
+func (r *inMemoryRepository) GetReports(_ context.Context, status domain.ReportStatus, limit, afterID int) ([]*domain.Report, error) {
+	r.rlock()
+	defer r.runlock()
+
+	reports := make([]*domain.Report, 0, limit)
+	for _, report := range r.reports {
+		if report.Status == status && report.ID > afterID {
+			reports = append(reports, report)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].ID < reports[j].ID
+	})
+	if len(reports) > limit {
+		reports = reports[:limit]
+	}
+
+	return reports, nil
+}
+
+func (r *inMemoryRepository) ResolveReport(
+	_ context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord,
+) (*domain.ModerationRecord, error) {
+	r.lock()
+	defer r.unlock()
+
+	if _, ok := r.reports[reportID]; !ok {
+		return nil, fmt.Errorf("report %d: %w", reportID, repository.ErrNotFound)
+	}
+
+	record.ID = r.recordID + 1
+	if err := r.commit(change{Op: opResolveReport, ID: reportID, Status: status, Record: record}); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (r *inMemoryRepository) GetModerationRecords(_ context.Context, limit, offset int) ([]*domain.ModerationRecord, error) {
+	r.rlock()
+	defer r.runlock()
+
+	records := make([]*domain.ModerationRecord, 0, limit)
+	// newest first, like the postgres implementation
+	for i := len(r.records) - 1 - offset; i >= 0 && len(records) < limit; i-- {
+		records = append(records, r.records[i])
+	}
+
+	return records, nil
+}
+
+func (r *inMemoryRepository) BanAuthor(_ context.Context, authorID int) error {
+	r.lock()
+	defer r.unlock()
+
+	return r.commit(change{Op: opBanAuthor, ID: authorID})
+}
+
+func (r *inMemoryRepository) IsBanned(_ context.Context, authorID int) (bool, error) {
+	r.rlock()
+	defer r.runlock()
+
+	_, banned := r.banned[authorID]
+	return banned, nil
+}
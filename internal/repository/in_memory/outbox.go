@@ -0,0 +1,101 @@
+package in_memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+)
+
+func (r *inMemoryRepository) CreateEvent(_ context.Context, event *domain.Event) (*domain.Event, error) {
+	r.lock()
+	defer r.unlock()
+
+	for _, e := range r.events {
+		if e.DedupID == event.DedupID {
+			return nil, fmt.Errorf("event %s: %w", event.DedupID, repository.ErrConflict)
+		}
+	}
+
+	event.ID = r.eventID + 1
+	if err := r.commit(change{Op: opCreateEvent, Event: event}); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (r *inMemoryRepository) ClaimEvents(_ context.Context, limit int, lease time.Duration) ([]*domain.Event, error) {
+	r.lock()
+	defer r.unlock()
+
+	now := time.Now()
+	events := make([]*domain.Event, 0)
+	for _, e := range r.events {
+		if len(events) == limit {
+			break
+		}
+		if e.PublishedAt != nil || now.Before(r.leases[e.ID]) {
+			continue
+		}
+		r.leases[e.ID] = now.Add(lease)
+		claimed := *e
+		events = append(events, &claimed)
+	}
+
+	return events, nil
+}
+
+func (r *inMemoryRepository) MarkEventsPublished(_ context.Context, ids []int, publishedAt time.Time) error {
+	r.lock()
+	defer r.unlock()
+
+	return r.commit(change{Op: opPublishEvents, IDs: ids, Time: &publishedAt})
+}
+
+func (s *store) publishEvents(ids []int, publishedAt time.Time) {
+	published := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		published[id] = struct{}{}
+	}
+	for _, e := range s.events {
+		if _, ok := published[e.ID]; ok {
+			e.PublishedAt = &publishedAt
+			delete(s.leases, e.ID)
+		}
+	}
+}
+
+func (r *inMemoryRepository) DeletePublishedEvents(_ context.Context, before time.Time) (int, error) {
+	r.lock()
+	defer r.unlock()
+
+	deleted := 0
+	for _, e := range r.events {
+		if e.PublishedAt != nil && e.PublishedAt.Before(before) {
+			deleted++
+		}
+	}
+	if deleted == 0 {
+		return 0, nil
+	}
+
+	if err := r.commit(change{Op: opDeletePublishedEvents, Time: &before}); err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+func (s *store) deletePublishedEvents(before time.Time) {
+	kept := s.events[:0]
+	for _, e := range s.events {
+		if e.PublishedAt == nil || !e.PublishedAt.Before(before) {
+			kept = append(kept, e)
+		}
+	}
+	clear(s.events[len(kept):])
+	s.events = kept
+}
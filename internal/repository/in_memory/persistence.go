@@ -0,0 +1,148 @@
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/worker"
+)
+
+// Persistent is the in-memory repository kept in a directory as a snapshot and a write-ahead log.
+// Every write is logged and synced before it returns, the log is compacted into a new snapshot
+// periodically and when it gets long. Claims are not logged: events claimed before a restart
+// are claimable right away, webhook deliveries once their lease would have ended.
+type Persistent struct {
+	*inMemoryRepository
+	dir    string
+	logger *slog.Logger
+
+	snapshotInterval time.Duration
+	snapshotAfter    int
+	loop             *worker.Loop
+	snapshotMu       sync.Mutex // one snapshot at a time
+}
+
+// Option configures the persistent repository
+type Option func(*Persistent)
+
+// WithSnapshotInterval sets how often the log is compacted into a snapshot
+func WithSnapshotInterval(interval time.Duration) Option {
+	return func(p *Persistent) {
+		p.snapshotInterval = interval
+	}
+}
+
+// WithSnapshotAfter sets how many logged writes trigger a snapshot before the interval ends
+func WithSnapshotAfter(records int) Option {
+	return func(p *Persistent) {
+		p.snapshotAfter = records
+	}
+}
+
+// Open loads the repository from the directory, creating it if needed, and starts taking snapshots
+func Open(dir string, logger *slog.Logger, opts ...Option) (*Persistent, error) {
+	p := &Persistent{
+		inMemoryRepository: &inMemoryRepository{store: newStore()},
+		dir:                dir,
+		logger:             logger,
+		snapshotInterval:   5 * time.Minute,
+		snapshotAfter:      10000,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("can't create data directory: %w", err)
+	}
+
+	first, err := p.loadSnapshot(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers, err := segments(dir)
+	if err != nil {
+		return nil, err
+	}
+	next, records := first, 0
+	for i, n := range numbers {
+		next = max(next, n+1)
+		if n < first {
+			continue // left over from a compaction interrupted by a crash
+		}
+		replayed, err := replay(p.store, filepath.Join(dir, segmentName(n)), i == len(numbers)-1)
+		if err != nil {
+			return nil, err
+		}
+		records += replayed
+	}
+
+	p.loop = worker.New("snapshotter", p.snapshotInterval, func(context.Context) {
+		if err := p.Snapshot(); err != nil {
+			p.logger.Error("failed to take snapshot", "error", err)
+		}
+	})
+
+	p.wal, err = openWAL(dir, next, p.snapshotAfter, p.loop.Notify)
+	if err != nil {
+		return nil, err
+	}
+	p.wal.records = records
+
+	p.loop.Start()
+	if records >= p.snapshotAfter {
+		p.loop.Notify()
+	}
+
+	return p, nil
+}
+
+// Snapshot compacts the log into a new snapshot and removes the segments it covers
+func (p *Persistent) Snapshot() error {
+	p.snapshotMu.Lock()
+	defer p.snapshotMu.Unlock()
+
+	// the store is encoded and the log rotated together, so the snapshot covers exactly the earlier segments
+	p.mu.Lock()
+	if p.wal.records == 0 {
+		p.mu.Unlock()
+		return nil // nothing changed since the last snapshot
+	}
+	if err := p.wal.rotate(); err != nil {
+		p.mu.Unlock()
+		return err
+	}
+	segment := p.wal.segment
+	encoded, err := p.encodeSnapshot(segment)
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := writeSnapshot(p.dir, encoded); err != nil {
+		return err
+	}
+	if err := removeBefore(p.dir, segment); err != nil {
+		return err
+	}
+
+	p.logger.Debug("snapshot taken", "segment", segment)
+	return nil
+}
+
+// Close stops taking snapshots, takes the last one and closes the log, later writes fail
+func (p *Persistent) Close(ctx context.Context) error {
+	stopErr := p.loop.Stop(ctx)
+	snapshotErr := p.Snapshot()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return errors.Join(stopErr, snapshotErr, p.wal.close())
+}
@@ -0,0 +1,223 @@
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func open(t *testing.T, dir string, opts ...Option) *Persistent {
+	repo, err := Open(dir, slog.Default(), opts...)
+	require.NoError(t, err)
+	return repo
+}
+
+// crash drops the repository without a final snapshot
+func crash(t *testing.T, repo *Persistent) {
+	require.NoError(t, repo.loop.Stop(context.Background()))
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+	require.NoError(t, repo.wal.close())
+}
+
+func createPost(t *testing.T, repo repository.Repository, title string) *domain.Post {
+	post, err := repo.CreatePost(context.Background(), &domain.Post{
+		Title:     title,
+		Content:   "content",
+		AuthorID:  1,
+		CreatedAt: time.Now().UTC(),
+	})
+	require.NoError(t, err)
+	return post
+}
+
+func TestPersistent_Reopen(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	repo := open(t, dir)
+	post := createPost(t, repo, "first")
+	deleted := createPost(t, repo, "second")
+	comment, err := repo.CreateComment(ctx, &domain.Comment{PostID: post.ID, AuthorID: 2, Content: "comment", CreatedAt: time.Now().UTC()})
+	require.NoError(t, err)
+	require.NoError(t, repo.HidePost(ctx, post.ID))
+	require.NoError(t, repo.DeletePost(ctx, deleted.ID))
+	require.NoError(t, repo.BanAuthor(ctx, 3))
+	require.NoError(t, repo.ImportPosts(ctx,
+		[]*domain.Post{{ID: 1, Title: "imported", AuthorID: 1, CreatedAt: time.Now().UTC()}},
+		[]*domain.Comment{{ID: 1, PostID: 1, AuthorID: 2, Content: "imported comment", CreatedAt: time.Now().UTC()}},
+	))
+	imported := deleted.ID + 1
+	webhook, err := repo.CreateWebhook(ctx, &domain.Webhook{
+		OwnerID:   1,
+		URL:       "https://example.com/hook",
+		Events:    []domain.EventType{domain.EventPostCreated},
+		Secret:    "0123456789abcdef",
+		CreatedAt: time.Now().UTC(),
+	})
+	require.NoError(t, err)
+	crash(t, repo)
+
+	lastID := imported
+	for _, name := range []string{"after replay", "after snapshot"} {
+		t.Run(name, func(t *testing.T) {
+			repo := open(t, dir)
+
+			got, err := repo.GetPost(ctx, post.ID)
+			assert.NoError(t, err)
+			assert.Equal(t, "first", got.Title)
+			assert.True(t, got.Hidden)
+
+			_, err = repo.GetPost(ctx, deleted.ID)
+			assert.ErrorIs(t, err, repository.ErrNotFound)
+
+			comments, err := repo.GetCommentsByPost(ctx, post.ID, 10, 0, true)
+			assert.NoError(t, err)
+			assert.Len(t, comments, 1)
+			assert.Equal(t, comment.ID, comments[0].ID)
+
+			comments, err = repo.GetCommentsByPost(ctx, imported, 10, 0, false)
+			assert.NoError(t, err)
+			require.Len(t, comments, 1)
+			assert.Equal(t, "imported comment", comments[0].Content)
+
+			banned, err := repo.IsBanned(ctx, 3)
+			assert.NoError(t, err)
+			assert.True(t, banned)
+
+			webhooks, err := repo.GetWebhooks(ctx, 1)
+			assert.NoError(t, err)
+			assert.Len(t, webhooks, 1)
+			assert.Equal(t, webhook.Secret, webhooks[0].Secret)
+
+			// ids are not reused after a delete
+			created := createPost(t, repo, "third")
+			assert.Equal(t, lastID+1, created.ID)
+			require.NoError(t, repo.DeletePost(ctx, created.ID))
+			lastID = created.ID
+
+			require.NoError(t, repo.Close(ctx))
+		})
+	}
+}
+
+func TestPersistent_TxIsOneRecord(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	repo := open(t, dir)
+	err := repo.WithTx(ctx, func(repo repository.Repository) error {
+		createPost(t, repo, "first")
+		createPost(t, repo, "second")
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.wal.records)
+	crash(t, repo)
+
+	repo = open(t, dir)
+	posts, err := repo.GetPosts(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+	require.NoError(t, repo.Close(ctx))
+}
+
+func TestPersistent_TornTail(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	repo := open(t, dir)
+	createPost(t, repo, "first")
+	createPost(t, repo, "second")
+	segment := filepath.Join(dir, segmentName(repo.wal.segment))
+	crash(t, repo)
+
+	// cut the last record in half as if the process died while writing it
+	data, err := os.ReadFile(segment)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(segment, data[:len(data)-20], 0o644))
+
+	repo = open(t, dir)
+	posts, err := repo.GetPosts(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, "first", posts[0].Title)
+	crash(t, repo)
+
+	// the truncated segment is no longer the last one and replays cleanly
+	repo = open(t, dir)
+	posts, err = repo.GetPosts(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	require.NoError(t, repo.Close(ctx))
+}
+
+func TestPersistent_CorruptRecord(t *testing.T) {
+	dir := t.TempDir()
+
+	repo := open(t, dir)
+	createPost(t, repo, "first")
+	createPost(t, repo, "second")
+	segment := filepath.Join(dir, segmentName(repo.wal.segment))
+	crash(t, repo)
+
+	data, err := os.ReadFile(segment)
+	require.NoError(t, err)
+	i := len(`{"version":1}`) + 1 + len("00000000 ") + 10
+	data[i] ^= 0xff
+	require.NoError(t, os.WriteFile(segment, data, 0o644))
+
+	_, err = Open(dir, slog.Default())
+	assert.ErrorContains(t, err, "corrupt")
+}
+
+func TestPersistent_SnapshotCompactsLog(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	repo := open(t, dir, WithSnapshotAfter(3))
+	for i := 0; i < 3; i++ {
+		createPost(t, repo, "post")
+	}
+	assert.Eventually(t, func() bool {
+		numbers, err := segments(dir)
+		return err == nil && len(numbers) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.FileExists(t, filepath.Join(dir, snapshotName))
+
+	createPost(t, repo, "post")
+	crash(t, repo)
+
+	repo = open(t, dir)
+	posts, err := repo.GetPosts(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, posts, 4)
+	require.NoError(t, repo.Close(ctx))
+}
+
+func TestPersistent_UnsupportedVersion(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, segmentName(1)), []byte(`{"version":2}`+"\n"), 0o644))
+
+	_, err := Open(dir, slog.Default())
+	assert.ErrorContains(t, err, "unsupported version 2")
+}
+
+func TestPersistent_Closed(t *testing.T) {
+	ctx := context.Background()
+
+	repo := open(t, t.TempDir())
+	require.NoError(t, repo.Close(ctx))
+
+	_, err := repo.CreatePost(ctx, &domain.Post{Title: "title", Content: "content", AuthorID: 1})
+	assert.True(t, errors.Is(err, errWALClosed))
+}
@@ -0,0 +1,168 @@
+package in_memory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+)
+
+const snapshotName = "snapshot.json"
+
+// snapshot is the whole store as of the start of a log segment
+type snapshot struct {
+	Version    int                        `json:"version"`
+	Segment    int                        `json:"segment"` // first log segment to replay on top of the snapshot
+	Posts      []*domain.Post             `json:"posts"`
+	Comments   []*domain.Comment          `json:"comments"`
+	Reports    []*domain.Report           `json:"reports"`
+	Records    []*domain.ModerationRecord `json:"records"`
+	Banned     []int                      `json:"banned"`
+	Events     []*domain.Event            `json:"events"`
+	Webhooks   []*persistedWebhook        `json:"webhooks"`
+	Deliveries []*domain.WebhookDelivery  `json:"deliveries"`
+	Counters   counters                   `json:"counters"`
+}
+
+// counters are the last assigned ids, they can be ahead of the stored ones after deletes
+type counters struct {
+	Post     int `json:"post"`
+	Comment  int `json:"comment"`
+	Report   int `json:"report"`
+	Record   int `json:"record"`
+	Event    int `json:"event"`
+	Webhook  int `json:"webhook"`
+	Delivery int `json:"delivery"`
+}
+
+// encodeSnapshot encodes the store, the caller holds the lock
+func (s *store) encodeSnapshot(segment int) ([]byte, error) {
+	snap := snapshot{
+		Version:    formatVersion,
+		Segment:    segment,
+		Posts:      s.postOrder.Items(),
+		Comments:   sortedByID(s.comments),
+		Reports:    sortedByID(s.reports),
+		Records:    s.records,
+		Banned:     sortedKeys(s.banned),
+		Events:     s.events,
+		Webhooks:   make([]*persistedWebhook, 0, len(s.webhooks)),
+		Deliveries: s.deliveries,
+		Counters: counters{
+			Post:     s.postID,
+			Comment:  s.commentID,
+			Report:   s.reportID,
+			Record:   s.recordID,
+			Event:    s.eventID,
+			Webhook:  s.webhookID,
+			Delivery: s.deliveryID,
+		},
+	}
+	for _, w := range sortedByID(s.webhooks) {
+		snap.Webhooks = append(snap.Webhooks, persistWebhook(w))
+	}
+
+	encoded, err := json.Marshal(snap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	return encoded, nil
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+func sortedByID[T any](m map[int]*T) []*T {
+	sorted := make([]*T, 0, len(m))
+	for _, id := range sortedKeys(m) {
+		sorted = append(sorted, m[id])
+	}
+	return sorted
+}
+
+// writeSnapshot replaces the snapshot in the directory. The new one is written next to it and
+// renamed over it, so a crash leaves either the old snapshot or the new one.
+func writeSnapshot(dir string, encoded []byte) error {
+	tmp, err := os.CreateTemp(dir, snapshotName+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("can't create snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // fails once renamed
+
+	if _, err := tmp.Write(encoded); err != nil {
+		tmp.Close()
+		return fmt.Errorf("can't write snapshot: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("can't sync snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("can't close snapshot file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, snapshotName)); err != nil {
+		return fmt.Errorf("can't replace snapshot: %w", err)
+	}
+	return syncDir(dir)
+}
+
+// loadSnapshot fills the empty store from the snapshot in the directory and returns
+// the first log segment to replay, the store stays empty if there is no snapshot yet
+func (s *store) loadSnapshot(dir string) (int, error) {
+	data, err := os.ReadFile(filepath.Join(dir, snapshotName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("can't read snapshot: %w", err)
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return 0, fmt.Errorf("can't decode snapshot: %w", err)
+	}
+	if snap.Version != formatVersion {
+		return 0, fmt.Errorf("snapshot has unsupported version %d", snap.Version)
+	}
+
+	for _, post := range snap.Posts {
+		s.putPost(post)
+	}
+	for _, comment := range snap.Comments {
+		s.putComment(comment)
+	}
+	for _, report := range snap.Reports {
+		s.reports[report.ID] = report
+	}
+	s.records = snap.Records
+	for _, authorID := range snap.Banned {
+		s.banned[authorID] = struct{}{}
+	}
+	s.events = snap.Events
+	for _, w := range snap.Webhooks {
+		s.webhooks[w.ID] = w.webhook()
+	}
+	s.deliveries = snap.Deliveries
+
+	s.postID = snap.Counters.Post
+	s.commentID = snap.Counters.Comment
+	s.reportID = snap.Counters.Report
+	s.recordID = snap.Counters.Record
+	s.eventID = snap.Counters.Event
+	s.webhookID = snap.Counters.Webhook
+	s.deliveryID = snap.Counters.Delivery
+
+	return snap.Segment, nil
+}
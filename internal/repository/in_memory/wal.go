@@ -0,0 +1,293 @@
+package in_memory
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// formatVersion is the version of the log and snapshot format, files of other versions are not read
+const formatVersion = 1
+
+// header is the first line of every log segment
+type header struct {
+	Version int `json:"version"`
+}
+
+var errWALClosed = errors.New("write-ahead log is closed")
+
+// wal is the write-ahead log of the store. It is split into numbered segments, a snapshot
+// covers the segments before the one that was current when it was taken.
+// Every record is a line with the CRC-32 of its JSON followed by the JSON array of changes
+// made by one call or one unit of work, the file is synced before the call returns.
+// The store write lock guards the log.
+type wal struct {
+	dir     string
+	file    *os.File // current segment
+	segment int      // number of the current segment
+
+	records int    // records written since the last snapshot
+	after   int    // records that make the log full
+	full    func() // called when the log gets full
+
+	err error // once a write fails every later one fails too, memory would be ahead of the disk otherwise
+}
+
+func segmentName(segment int) string {
+	return fmt.Sprintf("wal-%016d.log", segment)
+}
+
+// segments returns the numbers of the log segments in the directory in ascending order
+func segments(dir string) ([]int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("can't list write-ahead log segments: %w", err)
+	}
+
+	var numbers []int
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "wal-") || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "wal-"), ".log"))
+		if err != nil {
+			continue
+		}
+		numbers = append(numbers, n)
+	}
+	slices.Sort(numbers)
+
+	return numbers, nil
+}
+
+// openWAL creates a new segment with the given number and makes it current
+func openWAL(dir string, segment int, after int, full func()) (*wal, error) {
+	w := &wal{dir: dir, after: after, full: full}
+	if err := w.create(segment); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *wal) create(segment int) error {
+	encoded, err := json.Marshal(header{Version: formatVersion})
+	if err != nil {
+		return fmt.Errorf("failed to encode write-ahead log header: %w", err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(w.dir, segmentName(segment)), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("can't create write-ahead log segment: %w", err)
+	}
+	if _, err := file.Write(append(encoded, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("can't write write-ahead log header: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("can't sync write-ahead log segment: %w", err)
+	}
+	if err := syncDir(w.dir); err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.segment = segment
+	return nil
+}
+
+// append writes the changes as one record
+func (w *wal) append(changes []json.RawMessage) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return fmt.Errorf("failed to encode changes: %w", err)
+	}
+
+	line := make([]byte, 0, len(data)+10)
+	line = fmt.Appendf(line, "%08x ", crc32.ChecksumIEEE(data))
+	line = append(line, data...)
+	line = append(line, '\n')
+
+	if _, err := w.file.Write(line); err != nil {
+		w.err = fmt.Errorf("can't write to write-ahead log: %w", err)
+		return w.err
+	}
+	if err := w.file.Sync(); err != nil {
+		w.err = fmt.Errorf("can't sync write-ahead log: %w", err)
+		return w.err
+	}
+
+	w.records++
+	if w.records >= w.after && w.full != nil {
+		w.full()
+	}
+
+	return nil
+}
+
+// rotate starts the next segment, a snapshot taken now covers all the earlier ones
+func (w *wal) rotate() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	previous := w.file
+	if err := w.create(w.segment + 1); err != nil {
+		return err
+	}
+	w.records = 0
+
+	if err := previous.Close(); err != nil {
+		return fmt.Errorf("can't close write-ahead log segment: %w", err)
+	}
+	return nil
+}
+
+// close closes the current segment, later writes fail
+func (w *wal) close() error {
+	if w.err == errWALClosed {
+		return nil
+	}
+	w.err = errWALClosed
+
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("can't close write-ahead log segment: %w", err)
+	}
+	return nil
+}
+
+// removeBefore deletes the segments a snapshot already covers
+func removeBefore(dir string, segment int) error {
+	numbers, err := segments(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range numbers {
+		if n >= segment {
+			break
+		}
+		if err := os.Remove(filepath.Join(dir, segmentName(n))); err != nil {
+			return fmt.Errorf("can't remove write-ahead log segment: %w", err)
+		}
+	}
+	return nil
+}
+
+// replay applies the records of the segment to the store and returns how many there were.
+// A record cut short by a crash can only be the last one of the last segment, it is truncated,
+// damage anywhere else is an error.
+func replay(s *store, path string, last bool) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("can't read write-ahead log segment: %w", err)
+	}
+
+	line, rest, complete := bytes.Cut(data, []byte("\n"))
+	if !complete {
+		if !last {
+			return 0, fmt.Errorf("write-ahead log segment %s has no header", filepath.Base(path))
+		}
+		// the crash happened while the segment was created
+		if err := os.Remove(path); err != nil {
+			return 0, fmt.Errorf("can't remove write-ahead log segment: %w", err)
+		}
+		return 0, syncDir(filepath.Dir(path))
+	}
+
+	var h header
+	if err := json.Unmarshal(line, &h); err != nil {
+		return 0, fmt.Errorf("can't decode header of write-ahead log segment %s: %w", filepath.Base(path), err)
+	}
+	if h.Version != formatVersion {
+		return 0, fmt.Errorf("write-ahead log segment %s has unsupported version %d", filepath.Base(path), h.Version)
+	}
+
+	records := 0
+	offset := len(line) + 1
+	for len(rest) > 0 {
+		line, rest, complete = bytes.Cut(rest, []byte("\n"))
+
+		changes, err := decodeRecord(line)
+		if err == nil && !complete {
+			err = errors.New("record is not terminated")
+		}
+		if err != nil {
+			if last && len(rest) == 0 {
+				return records, truncate(path, int64(offset))
+			}
+			return records, fmt.Errorf("write-ahead log segment %s is corrupt at offset %d: %w", filepath.Base(path), offset, err)
+		}
+
+		for _, c := range changes {
+			s.apply(c)
+		}
+		records++
+		offset += len(line) + 1
+	}
+
+	return records, nil
+}
+
+func decodeRecord(line []byte) ([]*change, error) {
+	sum, data, ok := bytes.Cut(line, []byte(" "))
+	if !ok {
+		return nil, errors.New("record has no checksum")
+	}
+	want, err := strconv.ParseUint(string(sum), 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse record checksum: %w", err)
+	}
+	if crc32.ChecksumIEEE(data) != uint32(want) {
+		return nil, errors.New("record checksum mismatch")
+	}
+
+	var changes []*change
+	if err := json.Unmarshal(data, &changes); err != nil {
+		return nil, fmt.Errorf("can't decode record: %w", err)
+	}
+	return changes, nil
+}
+
+func truncate(path string, size int64) error {
+	file, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return fmt.Errorf("can't open write-ahead log segment: %w", err)
+	}
+	defer file.Close()
+
+	if err := file.Truncate(size); err != nil {
+		return fmt.Errorf("can't truncate write-ahead log segment: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("can't sync write-ahead log segment: %w", err)
+	}
+	return nil
+}
+
+// syncDir makes created, renamed and removed files in the directory durable
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("can't open directory: %w", err)
+	}
+	defer d.Close()
+
+	if err := d.Sync(); err != nil {
+		return fmt.Errorf("can't sync directory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package in_memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+)
+
+func (r *inMemoryRepository) CreateWebhook(_ context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	r.lock()
+	defer r.unlock()
+
+	webhook.ID = r.webhookID + 1
+	if err := r.commit(change{Op: opCreateWebhook, Webhook: persistWebhook(webhook)}); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *inMemoryRepository) GetWebhook(_ context.Context, id int) (*domain.Webhook, error) {
+	r.rlock()
+	defer r.runlock()
+
+	webhook, ok := r.webhooks[id]
+	if !ok {
+		return nil, fmt.Errorf("webhook %d: %w", id, repository.ErrNotFound)
+	}
+	return webhook, nil
+}
+
+func (r *inMemoryRepository) GetWebhooks(_ context.Context, ownerID int) ([]*domain.Webhook, error) {
+	return r.filterWebhooks(func(w *domain.Webhook) bool {
+		return w.OwnerID == ownerID
+	}), nil
+}
+
+func (r *inMemoryRepository) GetWebhooksForEvent(_ context.Context, eventType domain.EventType, postID int) ([]*domain.Webhook, error) {
+	event := &domain.Event{Type: eventType, PostID: postID}
+	return r.filterWebhooks(func(w *domain.Webhook) bool {
+		return w.Matches(event)
+	}), nil
+}
+
+// filterWebhooks returns the webhooks matching the filter ordered by id
+func (r *inMemoryRepository) filterWebhooks(filterFunc func(*domain.Webhook) bool) []*domain.Webhook {
+	r.rlock()
+	defer r.runlock()
+
+	webhooks := make([]*domain.Webhook, 0)
+	for id := 1; id <= r.webhookID; id++ {
+		if w, ok := r.webhooks[id]; ok && filterFunc(w) {
+			webhooks = append(webhooks, w)
+		}
+	}
+	return webhooks
+}
+
+func (r *inMemoryRepository) DeleteWebhook(_ context.Context, id int) error {
+	r.lock()
+	defer r.unlock()
+
+	if _, ok := r.webhooks[id]; !ok {
+		return fmt.Errorf("webhook %d: %w", id, repository.ErrNotFound)
+	}
+	return r.commit(change{Op: opDeleteWebhook, ID: id})
+}
+
+func (s *store) deleteWebhook(id int) {
+	delete(s.webhooks, id)
+
+	// mirror ON DELETE CASCADE
+	kept := s.deliveries[:0]
+	for _, d := range s.deliveries {
+		if d.WebhookID != id {
+			kept = append(kept, d)
+		}
+	}
+	clear(s.deliveries[len(kept):])
+	s.deliveries = kept
+}
+
+func (r *inMemoryRepository) CreateWebhookDeliveries(_ context.Context, deliveries []*domain.WebhookDelivery) error {
+	r.lock()
+	defer r.unlock()
+
+	type key struct {
+		webhookID int
+		eventID   string
+	}
+	existing := make(map[key]struct{}, len(r.deliveries))
+	for _, d := range r.deliveries {
+		existing[key{d.WebhookID, d.EventID}] = struct{}{}
+	}
+
+	created := make([]*domain.WebhookDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		k := key{d.WebhookID, d.EventID}
+		if _, ok := existing[k]; ok {
+			continue
+		}
+		existing[k] = struct{}{}
+
+		saved := *d
+		saved.ID = r.deliveryID + len(created) + 1
+		created = append(created, &saved)
+	}
+	if len(created) == 0 {
+		return nil
+	}
+
+	return r.commit(change{Op: opCreateWebhookDeliveries, Deliveries: created})
+}
+
+func (r *inMemoryRepository) ClaimWebhookDeliveries(_ context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	r.lock()
+	defer r.unlock()
+
+	now := time.Now()
+	deliveries := make([]*domain.WebhookDelivery, 0)
+	for _, d := range r.deliveries {
+		if len(deliveries) == limit {
+			break
+		}
+		if d.Status != domain.DeliveryPending || d.NextAttemptAt.After(now) {
+			continue
+		}
+		d.NextAttemptAt = now.Add(lease)
+		claimed := *d
+		deliveries = append(deliveries, &claimed)
+	}
+
+	return deliveries, nil
+}
+
+func (r *inMemoryRepository) UpdateWebhookDelivery(_ context.Context, delivery *domain.WebhookDelivery) error {
+	r.lock()
+	defer r.unlock()
+
+	for _, d := range r.deliveries {
+		if d.ID == delivery.ID {
+			updated := *delivery
+			return r.commit(change{Op: opUpdateWebhookDelivery, Deliveries: []*domain.WebhookDelivery{&updated}})
+		}
+	}
+
+	return nil // the webhook was deleted meanwhile
+}
+
+func (r *inMemoryRepository) GetWebhookDeliveries(_ context.Context, webhookID int, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	r.rlock()
+	defer r.runlock()
+
+	deliveries := make([]*domain.WebhookDelivery, 0)
+	for i := len(r.deliveries) - 1; i >= 0 && len(deliveries) < limit; i-- {
+		d := r.deliveries[i]
+		if d.WebhookID != webhookID {
+			continue
+		}
+		if offset > 0 {
+			offset--
+			continue
+		}
+		found := *d
+		deliveries = append(deliveries, &found)
+	}
+
+	return deliveries, nil
+}
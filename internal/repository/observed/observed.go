@@ -0,0 +1,299 @@
+// Package observed wraps a repository so every call can be measured without touching the implementations
+package observed
+
+import (
+	"context"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+)
+
+// Observer is called before a repository method runs.
+// It may return a derived context for the call, and must return a function
+// that is called with the method's error once the call is done.
+type Observer func(ctx context.Context, method string) (context.Context, func(error))
+
+// Repository calls the observers around every method of the wrapped repository
+type Repository struct {
+	repo      repository.Repository
+	observers []Observer
+}
+
+var _ repository.Repository = (*Repository)(nil)
+
+// New wraps the repository, it returns the repository as is when there are no observers
+func New(repo repository.Repository, observers ...Observer) repository.Repository {
+	if len(observers) == 0 {
+		return repo
+	}
+	return &Repository{repo: repo, observers: observers}
+}
+
+// observe starts all observers and returns the context for the call and a function finishing them
+func (r *Repository) observe(ctx context.Context, method string) (context.Context, func(error)) {
+	done := make([]func(error), len(r.observers))
+	for i, o := range r.observers {
+		ctx, done[i] = o(ctx, method)
+	}
+	return ctx, func(err error) {
+		for i := len(done) - 1; i >= 0; i-- {
+			done[i](err)
+		}
+	}
+}
+
+func (r *Repository) GetPosts(ctx context.Context) ([]*domain.Post, error) {
+	ctx, done := r.observe(ctx, "GetPosts")
+	posts, err := r.repo.GetPosts(ctx)
+	done(err)
+	return posts, err
+}
+
+func (r *Repository) GetPostsAfter(ctx context.Context, limit, afterID int) ([]*domain.Post, error) {
+	ctx, done := r.observe(ctx, "GetPostsAfter")
+	posts, err := r.repo.GetPostsAfter(ctx, limit, afterID)
+	done(err)
+	return posts, err
+}
+
+func (r *Repository) GetPost(ctx context.Context, id int) (*domain.Post, error) {
+	ctx, done := r.observe(ctx, "GetPost")
+	post, err := r.repo.GetPost(ctx, id)
+	done(err)
+	return post, err
+}
+
+func (r *Repository) CreatePost(ctx context.Context, post *domain.Post) (*domain.Post, error) {
+	ctx, done := r.observe(ctx, "CreatePost")
+	saved, err := r.repo.CreatePost(ctx, post)
+	done(err)
+	return saved, err
+}
+
+func (r *Repository) CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	ctx, done := r.observe(ctx, "CreateComment")
+	saved, err := r.repo.CreateComment(ctx, comment)
+	done(err)
+	return saved, err
+}
+
+func (r *Repository) GetComment(ctx context.Context, id int) (*domain.Comment, error) {
+	ctx, done := r.observe(ctx, "GetComment")
+	comment, err := r.repo.GetComment(ctx, id)
+	done(err)
+	return comment, err
+}
+
+func (r *Repository) GetCommentsByPost(ctx context.Context, postID int, limit, offset int, includeHidden bool) ([]*domain.Comment, error) {
+	ctx, done := r.observe(ctx, "GetCommentsByPost")
+	comments, err := r.repo.GetCommentsByPost(ctx, postID, limit, offset, includeHidden)
+	done(err)
+	return comments, err
+}
+
+func (r *Repository) GetCommentsByParent(ctx context.Context, parentId int, limit, offset int, includeHidden bool) ([]*domain.Comment, error) {
+	ctx, done := r.observe(ctx, "GetCommentsByParent")
+	comments, err := r.repo.GetCommentsByParent(ctx, parentId, limit, offset, includeHidden)
+	done(err)
+	return comments, err
+}
+
+func (r *Repository) DisableComments(ctx context.Context, postID int) error {
+	ctx, done := r.observe(ctx, "DisableComments")
+	err := r.repo.DisableComments(ctx, postID)
+	done(err)
+	return err
+}
+
+func (r *Repository) EnableComments(ctx context.Context, postID int) error {
+	ctx, done := r.observe(ctx, "EnableComments")
+	err := r.repo.EnableComments(ctx, postID)
+	done(err)
+	return err
+}
+
+func (r *Repository) ImportPosts(ctx context.Context, posts []*domain.Post, comments []*domain.Comment) error {
+	ctx, done := r.observe(ctx, "ImportPosts")
+	err := r.repo.ImportPosts(ctx, posts, comments)
+	done(err)
+	return err
+}
+
+func (r *Repository) HidePost(ctx context.Context, id int) error {
+	ctx, done := r.observe(ctx, "HidePost")
+	err := r.repo.HidePost(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *Repository) HideComment(ctx context.Context, id int) error {
+	ctx, done := r.observe(ctx, "HideComment")
+	err := r.repo.HideComment(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *Repository) DeletePost(ctx context.Context, id int) error {
+	ctx, done := r.observe(ctx, "DeletePost")
+	err := r.repo.DeletePost(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *Repository) DeleteComment(ctx context.Context, id int) error {
+	ctx, done := r.observe(ctx, "DeleteComment")
+	err := r.repo.DeleteComment(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *Repository) CreateReport(ctx context.Context, report *domain.Report) (*domain.Report, error) {
+	ctx, done := r.observe(ctx, "CreateReport")
+	saved, err := r.repo.CreateReport(ctx, report)
+	done(err)
+	return saved, err
+}
+
+func (r *Repository) GetReport(ctx context.Context, id int) (*domain.Report, error) {
+	ctx, done := r.observe(ctx, "GetReport")
+	report, err := r.repo.GetReport(ctx, id)
+	done(err)
+	return report, err
+}
+
+func (r *Repository) GetReports(ctx context.Context, status domain.ReportStatus, limit, afterID int) ([]*domain.Report, error) {
+	ctx, done := r.observe(ctx, "GetReports")
+	reports, err := r.repo.GetReports(ctx, status, limit, afterID)
+	done(err)
+	return reports, err
+}
+
+func (r *Repository) ResolveReport(ctx context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord) (*domain.ModerationRecord, error) {
+	ctx, done := r.observe(ctx, "ResolveReport")
+	saved, err := r.repo.ResolveReport(ctx, reportID, status, record)
+	done(err)
+	return saved, err
+}
+
+func (r *Repository) GetModerationRecords(ctx context.Context, limit, offset int) ([]*domain.ModerationRecord, error) {
+	ctx, done := r.observe(ctx, "GetModerationRecords")
+	records, err := r.repo.GetModerationRecords(ctx, limit, offset)
+	done(err)
+	return records, err
+}
+
+func (r *Repository) BanAuthor(ctx context.Context, authorID int) error {
+	ctx, done := r.observe(ctx, "BanAuthor")
+	err := r.repo.BanAuthor(ctx, authorID)
+	done(err)
+	return err
+}
+
+func (r *Repository) IsBanned(ctx context.Context, authorID int) (bool, error) {
+	ctx, done := r.observe(ctx, "IsBanned")
+	banned, err := r.repo.IsBanned(ctx, authorID)
+	done(err)
+	return banned, err
+}
+
+func (r *Repository) CreateEvent(ctx context.Context, event *domain.Event) (*domain.Event, error) {
+	ctx, done := r.observe(ctx, "CreateEvent")
+	created, err := r.repo.CreateEvent(ctx, event)
+	done(err)
+	return created, err
+}
+
+func (r *Repository) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]*domain.Event, error) {
+	ctx, done := r.observe(ctx, "ClaimEvents")
+	events, err := r.repo.ClaimEvents(ctx, limit, lease)
+	done(err)
+	return events, err
+}
+
+func (r *Repository) MarkEventsPublished(ctx context.Context, ids []int, publishedAt time.Time) error {
+	ctx, done := r.observe(ctx, "MarkEventsPublished")
+	err := r.repo.MarkEventsPublished(ctx, ids, publishedAt)
+	done(err)
+	return err
+}
+
+func (r *Repository) DeletePublishedEvents(ctx context.Context, before time.Time) (int, error) {
+	ctx, done := r.observe(ctx, "DeletePublishedEvents")
+	deleted, err := r.repo.DeletePublishedEvents(ctx, before)
+	done(err)
+	return deleted, err
+}
+
+func (r *Repository) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	ctx, done := r.observe(ctx, "CreateWebhook")
+	created, err := r.repo.CreateWebhook(ctx, webhook)
+	done(err)
+	return created, err
+}
+
+func (r *Repository) GetWebhook(ctx context.Context, id int) (*domain.Webhook, error) {
+	ctx, done := r.observe(ctx, "GetWebhook")
+	webhook, err := r.repo.GetWebhook(ctx, id)
+	done(err)
+	return webhook, err
+}
+
+func (r *Repository) GetWebhooks(ctx context.Context, ownerID int) ([]*domain.Webhook, error) {
+	ctx, done := r.observe(ctx, "GetWebhooks")
+	webhooks, err := r.repo.GetWebhooks(ctx, ownerID)
+	done(err)
+	return webhooks, err
+}
+
+func (r *Repository) DeleteWebhook(ctx context.Context, id int) error {
+	ctx, done := r.observe(ctx, "DeleteWebhook")
+	err := r.repo.DeleteWebhook(ctx, id)
+	done(err)
+	return err
+}
+
+func (r *Repository) GetWebhooksForEvent(ctx context.Context, eventType domain.EventType, postID int) ([]*domain.Webhook, error) {
+	ctx, done := r.observe(ctx, "GetWebhooksForEvent")
+	webhooks, err := r.repo.GetWebhooksForEvent(ctx, eventType, postID)
+	done(err)
+	return webhooks, err
+}
+
+func (r *Repository) CreateWebhookDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	ctx, done := r.observe(ctx, "CreateWebhookDeliveries")
+	err := r.repo.CreateWebhookDeliveries(ctx, deliveries)
+	done(err)
+	return err
+}
+
+func (r *Repository) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	ctx, done := r.observe(ctx, "ClaimWebhookDeliveries")
+	deliveries, err := r.repo.ClaimWebhookDeliveries(ctx, limit, lease)
+	done(err)
+	return deliveries, err
+}
+
+func (r *Repository) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error {
+	ctx, done := r.observe(ctx, "UpdateWebhookDelivery")
+	err := r.repo.UpdateWebhookDelivery(ctx, delivery)
+	done(err)
+	return err
+}
+
+func (r *Repository) GetWebhookDeliveries(ctx context.Context, webhookID int, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	ctx, done := r.observe(ctx, "GetWebhookDeliveries")
+	deliveries, err := r.repo.GetWebhookDeliveries(ctx, webhookID, limit, offset)
+	done(err)
+	return deliveries, err
+}
+
+// WithTx observes the unit of work as a whole, the calls made through the repository given to fn are observed too
+func (r *Repository) WithTx(ctx context.Context, fn func(repo repository.Repository) error) error {
+	ctx, done := r.observe(ctx, "WithTx")
+	err := r.repo.WithTx(ctx, func(repo repository.Repository) error {
+		return fn(&Repository{repo: repo, observers: r.observers})
+	})
+	done(err)
+	return err
+}
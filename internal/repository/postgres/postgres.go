@@ -4,10 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"strconv"
+	"time"
 
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/postgres/queries"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/pkg/config"
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -15,24 +23,142 @@ import (
 type postgresRepo struct {
 	*queries.Queries // SQL queries
 	pool             *pgxpool.Pool
+	replicas         *Replicas // nil sends every read to the primary
+	txOptions        pgx.TxOptions
+	queryTimeout     time.Duration // zero leaves the queries unbounded
+	inTx             bool          // the queries run in a transaction started by WithTx
+}
+
+const (
+	// maxTxAttempts limits how many times WithTx runs a transaction that failed to serialize
+	maxTxAttempts = 3
+
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// Option configures the repository
+type Option func(*postgresRepo)
+
+// WithIsolation sets the isolation level of the transactions started by WithTx
+func WithIsolation(level pgx.TxIsoLevel) Option {
+	return func(r *postgresRepo) {
+		r.txOptions.IsoLevel = level
+	}
+}
+
+// WithQueryTimeout bounds every query, a stuck one fails with context.DeadlineExceeded instead of blocking its caller
+func WithQueryTimeout(timeout time.Duration) Option {
+	return func(r *postgresRepo) {
+		r.queryTimeout = timeout
+	}
 }
 
 // New creates a new instance of the repository based on connection pool
-func New(pgxPool *pgxpool.Pool) repository.Repository {
+func New(pgxPool *pgxpool.Pool, opts ...Option) repository.Repository {
 	r := &postgresRepo{
-		Queries: queries.New(pgxPool),
-		pool:    pgxPool,
+		pool:      pgxPool,
+		txOptions: pgx.TxOptions{IsoLevel: pgx.Serializable},
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	r.Queries = queries.New(pgxPool, queries.WithTimeout(r.queryTimeout))
 
 	return r
 }
 
-// SetupPgxPool creates a new pool of connections
-func SetupPgxPool(ctx context.Context, DbURL string) (*pgxpool.Pool, error) {
+// WithTx runs fn in a transaction, retrying it on serialization failures and deadlocks.
+// When every attempt fails that way the error wraps repository.ErrConflict.
+func (r *postgresRepo) WithTx(ctx context.Context, fn func(repo repository.Repository) error) error {
+	if r.inTx {
+		return fn(r)
+	}
+
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		err = r.runTx(ctx, fn)
+		if !isRetryable(err) {
+			return err
+		}
+	}
+
+	return fmt.Errorf("%w: transaction failed %d times: %w", repository.ErrConflict, maxTxAttempts, err)
+}
+
+func (r *postgresRepo) runTx(ctx context.Context, fn func(repo repository.Repository) error) error {
+	tx, err := r.pool.BeginTx(ctx, r.txOptions)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	txRepo := &postgresRepo{
+		Queries:      queries.New(tx, queries.WithTimeout(r.queryTimeout)),
+		pool:         r.pool,
+		txOptions:    r.txOptions,
+		queryTimeout: r.queryTimeout,
+		inTx:         true,
+	}
+	if err := fn(txRepo); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ImportPosts copies the posts and comments in a transaction
+func (r *postgresRepo) ImportPosts(ctx context.Context, posts []*domain.Post, comments []*domain.Comment) error {
+	return r.WithTx(ctx, func(tx repository.Repository) error {
+		return tx.(*postgresRepo).Queries.ImportPosts(ctx, posts, comments)
+	})
+}
+
+// isRetryable reports if the transaction failed to serialize or deadlocked and can be run again
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
+
+// ParseIsolation parses an isolation level name: READ_COMMITTED, REPEATABLE_READ or SERIALIZABLE
+func ParseIsolation(name string) (pgx.TxIsoLevel, error) {
+	switch name {
+	case "READ_COMMITTED":
+		return pgx.ReadCommitted, nil
+	case "REPEATABLE_READ":
+		return pgx.RepeatableRead, nil
+	case "SERIALIZABLE":
+		return pgx.Serializable, nil
+	default:
+		return "", fmt.Errorf("unknown isolation level: %s", name)
+	}
+}
+
+// SetupPgxPool creates a new pool of connections with the settings of cfg, the tracer is optional
+func SetupPgxPool(ctx context.Context, DbURL string, cfg config.PoolConfig, tracer pgx.QueryTracer) (*pgxpool.Pool, error) {
 	pgxConfig, err := pgxpool.ParseConfig(DbURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse pgx config: %w", err)
 	}
+	pgxConfig.ConnConfig.Tracer = tracer
+	pgxConfig.MaxConns = cfg.MaxConns
+	pgxConfig.MinConns = cfg.MinConns
+	pgxConfig.MaxConnLifetime = cfg.MaxConnLifetime
+	pgxConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	pgxConfig.HealthCheckPeriod = cfg.HealthCheckPeriod
+	if cfg.ApplicationName != "" {
+		pgxConfig.ConnConfig.RuntimeParams["application_name"] = cfg.ApplicationName
+	}
+	if cfg.StatementTimeout > 0 {
+		pgxConfig.ConnConfig.RuntimeParams["statement_timeout"] = strconv.FormatInt(cfg.StatementTimeout.Milliseconds(), 10)
+	}
 
 	pool, err := pgxpool.NewWithConfig(ctx, pgxConfig)
 	if err != nil {
@@ -56,3 +182,45 @@ func ProcessMigration(migrationURL string, dbSource string) error {
 
 	return nil
 }
+
+// LatestMigrationVersion returns the version of the newest migration in the source
+func LatestMigrationVersion(migrationURL string) (uint, error) {
+	src, err := source.Open(migrationURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open migration source: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read first migration: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to read migration after %d: %w", version, err)
+		}
+		version = next
+	}
+}
+
+const getMigrationVersion = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+// CheckMigrations returns an error unless the database is migrated to at least the given version
+func CheckMigrations(ctx context.Context, pool *pgxpool.Pool, version uint) error {
+	var current int64
+	var dirty bool
+	if err := pool.QueryRow(ctx, getMigrationVersion).Scan(&current, &dirty); err != nil {
+		return fmt.Errorf("can't get migration version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", current)
+	}
+	if current < int64(version) {
+		return fmt.Errorf("database is at migration %d, expected %d", current, version)
+	}
+	return nil
+}
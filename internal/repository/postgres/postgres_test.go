@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/repotest"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/pkg/config"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const migrationPath = "file://../../../migrations"
+
+// truncate empties the tables the suite writes to, the mock data goes too
+const truncate = `
+TRUNCATE posts, comments, reports, moderation_records, banned_authors, outbox, webhooks, webhook_deliveries
+RESTART IDENTITY CASCADE
+`
+
+var poolConfig = config.PoolConfig{
+	MaxConns:          10,
+	MaxConnLifetime:   time.Hour,
+	MaxConnIdleTime:   30 * time.Minute,
+	HealthCheckPeriod: time.Minute,
+	StatementTimeout:  30 * time.Second,
+	QueryTimeout:      10 * time.Second,
+	ApplicationName:   "posts-test",
+}
+
+// pool connects to the database in DB_URL and migrates it, the test is skipped when DB_URL is not set.
+// The tests empty the database, so point DB_URL at one used only for tests.
+func pool(t *testing.T) *pgxpool.Pool {
+	dbURL := os.Getenv("DB_URL")
+	if dbURL == "" {
+		t.Skip("DB_URL is not set")
+	}
+
+	require.NoError(t, ProcessMigration(migrationPath, dbURL))
+
+	pool, err := SetupPgxPool(context.Background(), dbURL, poolConfig, nil)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	return pool
+}
+
+func TestPostgres_Conformance(t *testing.T) {
+	pool := pool(t)
+
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		_, err := pool.Exec(context.Background(), truncate)
+		require.NoError(t, err)
+		return New(pool)
+	})
+}
+
+// the checks of the units of work must hold below SERIALIZABLE too
+func TestPostgres_ConformanceReadCommitted(t *testing.T) {
+	pool := pool(t)
+
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		_, err := pool.Exec(context.Background(), truncate)
+		require.NoError(t, err)
+		return New(pool, WithIsolation(pgx.ReadCommitted))
+	})
+}
+
+func TestSetupPgxPool(t *testing.T) {
+	// the pool connects lazily, nothing listens to the URL
+	pool, err := SetupPgxPool(context.Background(), "postgres://postgres@127.0.0.1:1/posts?pool_max_conns=50", poolConfig, nil)
+	require.NoError(t, err)
+	defer pool.Close()
+
+	cfg := pool.Config()
+	assert.EqualValues(t, 10, cfg.MaxConns)
+	assert.Equal(t, time.Hour, cfg.MaxConnLifetime)
+	assert.Equal(t, time.Minute, cfg.HealthCheckPeriod)
+	assert.Equal(t, "posts-test", cfg.ConnConfig.RuntimeParams["application_name"])
+	assert.Equal(t, "30000", cfg.ConnConfig.RuntimeParams["statement_timeout"])
+}
+
+func TestPostgres_ConnectionSettings(t *testing.T) {
+	pool := pool(t)
+	ctx := context.Background()
+
+	var name, timeout string
+	require.NoError(t, pool.QueryRow(ctx, "SELECT current_setting('application_name'), current_setting('statement_timeout')").
+		Scan(&name, &timeout))
+	assert.Equal(t, "posts-test", name)
+	assert.Equal(t, "30s", timeout)
+}
@@ -15,26 +15,26 @@ RETURNING id
 `
 
 func (q *Queries) CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
-	row := q.pool.QueryRow(ctx, insertComment,
-		comment.PostID, nil, comment.AuthorID, comment.Content, comment.CreatedAt)
+	row := q.db.QueryRow(ctx, insertComment,
+		comment.PostID, comment.ParentID, comment.AuthorID, comment.Content, comment.CreatedAt)
 
 	if err := row.Scan(&comment.ID); err != nil {
-		return nil, fmt.Errorf("can't scan comment id: %w", err)
+		return nil, fmt.Errorf("can't scan comment id: %w", classify(err))
 	}
 
 	return comment, nil
 }
 
 const selectCommentsByPost = `
-SELECT id, post_id, parent_id, author_id, content, created_at
+SELECT id, post_id, parent_id, author_id, content, created_at, hidden
 FROM comments
-WHERE post_id = $1
-ORDER BY created_at DESC
+WHERE post_id = $1 AND ($4 OR NOT hidden)
+ORDER BY created_at DESC, id DESC
 LIMIT $2 OFFSET $3
 `
 
-func (q *Queries) GetCommentsByPost(ctx context.Context, postID int, limit, offset int) ([]*domain.Comment, error) {
-	rows, err := q.pool.Query(ctx, selectCommentsByPost, postID, limit, offset)
+func (q *Queries) GetCommentsByPost(ctx context.Context, postID int, limit, offset int, includeHidden bool) ([]*domain.Comment, error) {
+	rows, err := q.db.Query(ctx, selectCommentsByPost, postID, limit, offset, includeHidden)
 	if err != nil {
 		return nil, fmt.Errorf("can't select comments: %w", err)
 	}
@@ -43,7 +43,7 @@ func (q *Queries) GetCommentsByPost(ctx context.Context, postID int, limit, offs
 	var comments []*domain.Comment
 	for rows.Next() {
 		var c domain.Comment
-		if err := rows.Scan(&c.ID, &c.PostID, &c.ParentID, &c.AuthorID, &c.Content, &c.CreatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.PostID, &c.ParentID, &c.AuthorID, &c.Content, &c.CreatedAt, &c.Hidden); err != nil {
 			return nil, fmt.Errorf("can't scan comment: %w", err)
 		}
 		comments = append(comments, &c)
@@ -53,19 +53,26 @@ func (q *Queries) GetCommentsByPost(ctx context.Context, postID int, limit, offs
 		return nil, fmt.Errorf("error while reading rows: %w", err)
 	}
 
+	// an empty page of a missing post is an error, the post is looked up only then
+	if len(comments) == 0 {
+		if err := q.exists(ctx, postExists, postID); err != nil {
+			return nil, fmt.Errorf("can't check if post exists: %w", err)
+		}
+	}
+
 	return comments, nil
 }
 
 const selectCommentsByParent = `
-SELECT id, post_id, parent_id, author_id, content, created_at
+SELECT id, post_id, parent_id, author_id, content, created_at, hidden
 FROM comments
-WHERE parent_id = $1
-ORDER BY created_at DESC
+WHERE parent_id = $1 AND ($4 OR NOT hidden)
+ORDER BY created_at DESC, id DESC
 LIMIT $2 OFFSET $3
 `
 
-func (q *Queries) GetCommentsByParent(ctx context.Context, parentId int, limit, offset int) ([]*domain.Comment, error) {
-	rows, err := q.pool.Query(ctx, selectCommentsByParent, parentId, limit, offset)
+func (q *Queries) GetCommentsByParent(ctx context.Context, parentId int, limit, offset int, includeHidden bool) ([]*domain.Comment, error) {
+	rows, err := q.db.Query(ctx, selectCommentsByParent, parentId, limit, offset, includeHidden)
 	if err != nil {
 		return nil, fmt.Errorf("can't selecect comments: %w", err)
 	}
@@ -74,7 +81,7 @@ func (q *Queries) GetCommentsByParent(ctx context.Context, parentId int, limit,
 	var comments []*domain.Comment
 	for rows.Next() {
 		var c domain.Comment
-		if err := rows.Scan(&c.ID, &c.PostID, &c.ParentID, &c.AuthorID, &c.Content, &c.CreatedAt); err != nil {
+		if err := rows.Scan(&c.ID, &c.PostID, &c.ParentID, &c.AuthorID, &c.Content, &c.CreatedAt, &c.Hidden); err != nil {
 			return nil, fmt.Errorf("can't scan comment: %w", err)
 		}
 		comments = append(comments, &c)
@@ -84,18 +91,63 @@ func (q *Queries) GetCommentsByParent(ctx context.Context, parentId int, limit,
 		return nil, fmt.Errorf("error while reading rows: %w", err)
 	}
 
+	if len(comments) == 0 {
+		if err := q.exists(ctx, commentExists, parentId); err != nil {
+			return nil, fmt.Errorf("can't check if comment exists: %w", err)
+		}
+	}
+
 	return comments, nil
 }
 
-const containsComment = `
+const postExists = `
+SELECT EXISTS(SELECT 1 FROM posts WHERE id = $1)
+`
+
+const commentExists = `
 SELECT EXISTS(SELECT 1 FROM comments WHERE id = $1)
 `
 
-func (q *Queries) ContainsComment(ctx context.Context, id int) (bool, error) {
-	var exists bool
-	if err := q.pool.QueryRow(ctx, containsComment, id).Scan(&exists); err != nil {
-		return false, fmt.Errorf("can't check if comment exists: %w", err)
+const selectComment = `
+SELECT id, post_id, parent_id, author_id, content, created_at, hidden
+FROM comments
+WHERE id = $1
+`
+
+func (q *Queries) GetComment(ctx context.Context, id int) (*domain.Comment, error) {
+	row := q.db.QueryRow(ctx, selectComment, id)
+
+	var c domain.Comment
+	if err := row.Scan(&c.ID, &c.PostID, &c.ParentID, &c.AuthorID, &c.Content, &c.CreatedAt, &c.Hidden); err != nil {
+		return nil, fmt.Errorf("can't scan comment: %w", classify(err))
+	}
+
+	return &c, nil
+}
+
+const updateHideComment = `
+UPDATE comments
+SET hidden = TRUE
+WHERE id = $1
+`
+
+func (q *Queries) HideComment(ctx context.Context, id int) error {
+	if err := execOne(ctx, q.db, updateHideComment, id); err != nil {
+		return fmt.Errorf("can't update row to hide comment: %w", err)
+	}
+
+	return nil
+}
+
+const deleteComment = `
+DELETE FROM comments
+WHERE id = $1
+`
+
+func (q *Queries) DeleteComment(ctx context.Context, id int) error {
+	if err := execOne(ctx, q.db, deleteComment, id); err != nil {
+		return fmt.Errorf("can't delete comment: %w", err)
 	}
 
-	return exists, nil
+	return nil
 }
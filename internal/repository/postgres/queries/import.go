@@ -0,0 +1,82 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/jackc/pgx/v5"
+)
+
+const selectNextIDs = `
+SELECT nextval(pg_get_serial_sequence($1, 'id'))
+FROM generate_series(1, $2)
+`
+
+// nextIDs reserves n ids of the table from its sequence
+func (q *Queries) nextIDs(ctx context.Context, table string, n int) ([]int, error) {
+	ids := make([]int, 0, n)
+	if n == 0 {
+		return ids, nil
+	}
+
+	rows, err := q.db.Query(ctx, selectNextIDs, table, n)
+	if err != nil {
+		return nil, fmt.Errorf("can't reserve %s ids: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("can't scan %s id: %w", table, err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading rows: %w", err)
+	}
+
+	return ids, nil
+}
+
+var (
+	postColumns    = []string{"id", "title", "content", "author_id", "created_at", "comments_disabled", "hidden"}
+	commentColumns = []string{"id", "post_id", "parent_id", "author_id", "content", "created_at", "hidden"}
+)
+
+// ImportPosts imports the posts and comments with COPY under ids reserved from the sequences,
+// it must run in a transaction so that the comments are not left without their posts
+func (q *Queries) ImportPosts(ctx context.Context, posts []*domain.Post, comments []*domain.Comment) error {
+	postIDs, err := q.nextIDs(ctx, "posts", len(posts))
+	if err != nil {
+		return err
+	}
+	commentIDs, err := q.nextIDs(ctx, "comments", len(comments))
+	if err != nil {
+		return err
+	}
+	posts, comments, err = repository.Remap(posts, comments, postIDs, commentIDs)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.db.CopyFrom(ctx, pgx.Identifier{"posts"}, postColumns, pgx.CopyFromSlice(len(posts), func(i int) ([]any, error) {
+		p := posts[i]
+		return []any{p.ID, p.Title, p.Content, p.AuthorID, p.CreatedAt, p.CommentsDisabled, p.Hidden}, nil
+	}))
+	if err != nil {
+		return fmt.Errorf("can't copy posts: %w", classify(err))
+	}
+
+	_, err = q.db.CopyFrom(ctx, pgx.Identifier{"comments"}, commentColumns, pgx.CopyFromSlice(len(comments), func(i int) ([]any, error) {
+		c := comments[i]
+		return []any{c.ID, c.PostID, c.ParentID, c.AuthorID, c.Content, c.CreatedAt, c.Hidden}, nil
+	}))
+	if err != nil {
+		return fmt.Errorf("can't copy comments: %w", classify(err))
+	}
+
+	return nil
+}
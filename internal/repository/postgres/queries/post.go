@@ -5,24 +5,48 @@ import (
 	"fmt"
 
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/jackc/pgx/v5"
 )
 
 const selectPosts = `
-SELECT id, title, content, author_id, created_at, comments_disabled
-FROM posts;
+SELECT id, title, content, author_id, created_at, comments_disabled, hidden
+FROM posts
+ORDER BY id;
 `
 
 func (q *Queries) GetPosts(ctx context.Context) ([]*domain.Post, error) {
-	rows, err := q.pool.Query(ctx, selectPosts)
+	rows, err := q.db.Query(ctx, selectPosts)
 	if err != nil {
 		return nil, fmt.Errorf("can't select all posts: %w", err)
 	}
 	defer rows.Close()
 
+	return scanPosts(rows)
+}
+
+const selectPostsAfter = `
+SELECT id, title, content, author_id, created_at, comments_disabled, hidden
+FROM posts
+WHERE id > $2
+ORDER BY id
+LIMIT $1;
+`
+
+func (q *Queries) GetPostsAfter(ctx context.Context, limit, afterID int) ([]*domain.Post, error) {
+	rows, err := q.db.Query(ctx, selectPostsAfter, limit, afterID)
+	if err != nil {
+		return nil, fmt.Errorf("can't select posts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPosts(rows)
+}
+
+func scanPosts(rows pgx.Rows) ([]*domain.Post, error) {
 	posts := make([]*domain.Post, 0)
 	for rows.Next() {
 		var post domain.Post
-		err = rows.Scan(&post.ID, &post.Title, &post.Content, &post.AuthorID, &post.CreatedAt, &post.CommentsDisabled)
+		err := rows.Scan(&post.ID, &post.Title, &post.Content, &post.AuthorID, &post.CreatedAt, &post.CommentsDisabled, &post.Hidden)
 		if err != nil {
 			return nil, fmt.Errorf("can't scan post row: %w", err)
 		}
@@ -36,18 +60,36 @@ func (q *Queries) GetPosts(ctx context.Context) ([]*domain.Post, error) {
 }
 
 const selectPost = `
-SELECT id, title, content, author_id, created_at, comments_disabled
+SELECT id, title, content, author_id, created_at, comments_disabled, hidden
 FROM posts
 WHERE id = $1;
 `
 
+// selectPostForShare locks the post against updates and deletes until the transaction ends
+const selectPostForShare = `
+SELECT id, title, content, author_id, created_at, comments_disabled, hidden
+FROM posts
+WHERE id = $1
+FOR SHARE;
+`
+
 func (q *Queries) GetPost(ctx context.Context, id int) (*domain.Post, error) {
-	row := q.pool.QueryRow(ctx, selectPost, id)
+	return q.getPost(ctx, selectPost, id)
+}
+
+// GetPostForShare reads the post and locks it until the transaction ends,
+// so what was checked on it holds when the transaction writes
+func (q *Queries) GetPostForShare(ctx context.Context, id int) (*domain.Post, error) {
+	return q.getPost(ctx, selectPostForShare, id)
+}
+
+func (q *Queries) getPost(ctx context.Context, query string, id int) (*domain.Post, error) {
+	row := q.db.QueryRow(ctx, query, id)
 
 	var post domain.Post
-	err := row.Scan(&post.ID, &post.Title, &post.Content, &post.AuthorID, &post.CreatedAt, &post.CommentsDisabled)
+	err := row.Scan(&post.ID, &post.Title, &post.Content, &post.AuthorID, &post.CreatedAt, &post.CommentsDisabled, &post.Hidden)
 	if err != nil {
-		return nil, fmt.Errorf("can't scan post row: %w", err)
+		return nil, fmt.Errorf("can't scan post row: %w", classify(err))
 	}
 
 	return &post, nil
@@ -61,7 +103,7 @@ RETURNING id
 `
 
 func (q *Queries) CreatePost(ctx context.Context, post *domain.Post) (*domain.Post, error) {
-	row := q.pool.QueryRow(ctx, insertPost, post.Title, post.Content, post.AuthorID, post.CreatedAt, post.CommentsDisabled)
+	row := q.db.QueryRow(ctx, insertPost, post.Title, post.Content, post.AuthorID, post.CreatedAt, post.CommentsDisabled)
 	if err := row.Scan(&post.ID); err != nil {
 		return nil, fmt.Errorf("can't scan post id: %w", err)
 	}
@@ -76,22 +118,50 @@ WHERE id = $1
 `
 
 func (q *Queries) DisableComments(ctx context.Context, postID int) error {
-	if _, err := q.pool.Exec(ctx, updateDisableComments, postID); err != nil {
+	if err := execOne(ctx, q.db, updateDisableComments, postID); err != nil {
 		return fmt.Errorf("can't update row to disable comments: %w", err)
 	}
 
 	return nil
 }
 
-const postExists = `
-SELECT EXISTS(SELECT 1 FROM posts WHERE id = $1)
+const updateEnableComments = `
+UPDATE posts
+SET comments_disabled = FALSE
+WHERE id = $1
+`
+
+func (q *Queries) EnableComments(ctx context.Context, postID int) error {
+	if err := execOne(ctx, q.db, updateEnableComments, postID); err != nil {
+		return fmt.Errorf("can't update row to enable comments: %w", err)
+	}
+
+	return nil
+}
+
+const updateHidePost = `
+UPDATE posts
+SET hidden = TRUE
+WHERE id = $1
+`
+
+func (q *Queries) HidePost(ctx context.Context, id int) error {
+	if err := execOne(ctx, q.db, updateHidePost, id); err != nil {
+		return fmt.Errorf("can't update row to hide post: %w", err)
+	}
+
+	return nil
+}
+
+const deletePost = `
+DELETE FROM posts
+WHERE id = $1
 `
 
-func (q *Queries) ContainsPost(ctx context.Context, id int) (bool, error) {
-	var exists bool
-	if err := q.pool.QueryRow(ctx, postExists, id).Scan(&exists); err != nil {
-		return false, fmt.Errorf("can't check if post exists: %w", err)
+func (q *Queries) DeletePost(ctx context.Context, id int) error {
+	if err := execOne(ctx, q.db, deletePost, id); err != nil {
+		return fmt.Errorf("can't delete post: %w", err)
 	}
 
-	return exists, nil
+	return nil
 }
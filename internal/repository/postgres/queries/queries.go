@@ -1,15 +1,99 @@
 package queries
 
 import (
-	"github.com/jackc/pgx/v5/pgxpool"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// DB is implemented by both pgxpool.Pool and pgx.Tx, so the queries run inside or outside a transaction
+type DB interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+	CopyFrom(ctx context.Context, table pgx.Identifier, columns []string, rows pgx.CopyFromSource) (int64, error)
+	Begin(ctx context.Context) (pgx.Tx, error) // a savepoint inside a transaction
+}
+
 // Queries implements the SQL queries for postgresRepo
 type Queries struct {
-	pool *pgxpool.Pool
+	db DB
+}
+
+// Option configures the queries
+type Option func(*Queries)
+
+// WithTimeout bounds every query by the timeout, its context is canceled when the time is over
+func WithTimeout(timeout time.Duration) Option {
+	return func(q *Queries) {
+		if timeout > 0 {
+			q.db = timeoutDB{DB: q.db, timeout: timeout}
+		}
+	}
 }
 
 // New creates a new instance of Queries to be embedded in postgresRepo
-func New(pgxPool *pgxpool.Pool) *Queries {
-	return &Queries{pool: pgxPool}
+func New(db DB, opts ...Option) *Queries {
+	q := &Queries{db: db}
+	for _, opt := range opts {
+		opt(q)
+	}
+	return q
+}
+
+const (
+	foreignKeyViolation = "23503"
+	uniqueViolation     = "23505"
+)
+
+// classify wraps the errors of the repository contract: a missing row or a reference to one
+// is repository.ErrNotFound, a duplicate unique key is repository.ErrConflict
+func classify(err error) error {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("%w: %w", repository.ErrNotFound, err)
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case foreignKeyViolation:
+			return fmt.Errorf("%w: %w", repository.ErrNotFound, err)
+		case uniqueViolation:
+			return fmt.Errorf("%w: %w", repository.ErrConflict, err)
+		}
+	}
+
+	return err
+}
+
+// execOne runs a statement on the row with the given id, it returns repository.ErrNotFound if there is no such row
+func execOne(ctx context.Context, db DB, sql string, id int, args ...any) error {
+	tag, err := db.Exec(ctx, sql, append([]any{id}, args...)...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// exists returns repository.ErrNotFound if the query selecting EXISTS finds nothing
+func (q *Queries) exists(ctx context.Context, query string, id int) error {
+	var exists bool
+	if err := q.db.QueryRow(ctx, query, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrNotFound
+	}
+
+	return nil
 }
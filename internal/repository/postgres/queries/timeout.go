@@ -0,0 +1,120 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// timeoutDB bounds every query with the timeout, so a stuck query can't hold its caller forever.
+// The rows and the batch results keep the deadline until they are closed or read to the end.
+type timeoutDB struct {
+	DB
+	timeout time.Duration
+}
+
+func (db timeoutDB) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	ctx, cancel := context.WithTimeout(ctx, db.timeout)
+	defer cancel()
+	return db.DB.Exec(ctx, sql, args...)
+}
+
+func (db timeoutDB) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	ctx, cancel := context.WithTimeout(ctx, db.timeout)
+	rows, err := db.DB.Query(ctx, sql, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &timeoutRows{Rows: rows, cancel: cancel}, nil
+}
+
+func (db timeoutDB) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	ctx, cancel := context.WithTimeout(ctx, db.timeout)
+	return timeoutRow{row: db.DB.QueryRow(ctx, sql, args...), cancel: cancel}
+}
+
+func (db timeoutDB) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	ctx, cancel := context.WithTimeout(ctx, db.timeout)
+	return timeoutBatch{BatchResults: db.DB.SendBatch(ctx, b), cancel: cancel}
+}
+
+func (db timeoutDB) CopyFrom(ctx context.Context, table pgx.Identifier, columns []string, rows pgx.CopyFromSource) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, db.timeout)
+	defer cancel()
+	return db.DB.CopyFrom(ctx, table, columns, rows)
+}
+
+// Begin starts a savepoint whose queries are bounded like the others, the savepoint itself is not
+func (db timeoutDB) Begin(ctx context.Context) (pgx.Tx, error) {
+	tx, err := db.DB.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return timeoutTx{Tx: tx, db: timeoutDB{DB: tx, timeout: db.timeout}}, nil
+}
+
+type timeoutRows struct {
+	pgx.Rows
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRows) Next() bool {
+	if r.Rows.Next() {
+		return true
+	}
+	r.cancel()
+	return false
+}
+
+func (r *timeoutRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
+
+type timeoutRow struct {
+	row    pgx.Row
+	cancel context.CancelFunc
+}
+
+func (r timeoutRow) Scan(dest ...any) error {
+	defer r.cancel()
+	return r.row.Scan(dest...)
+}
+
+type timeoutBatch struct {
+	pgx.BatchResults
+	cancel context.CancelFunc
+}
+
+func (b timeoutBatch) Close() error {
+	defer b.cancel()
+	return b.BatchResults.Close()
+}
+
+type timeoutTx struct {
+	pgx.Tx
+	db timeoutDB
+}
+
+func (tx timeoutTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return tx.db.Exec(ctx, sql, args...)
+}
+
+func (tx timeoutTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	return tx.db.Query(ctx, sql, args...)
+}
+
+func (tx timeoutTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	return tx.db.QueryRow(ctx, sql, args...)
+}
+
+func (tx timeoutTx) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return tx.db.SendBatch(ctx, b)
+}
+
+func (tx timeoutTx) CopyFrom(ctx context.Context, table pgx.Identifier, columns []string, rows pgx.CopyFromSource) (int64, error) {
+	return tx.db.CopyFrom(ctx, table, columns, rows)
+}
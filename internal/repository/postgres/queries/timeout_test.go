@@ -0,0 +1,76 @@
+package queries
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contextDB keeps the contexts of the queries, the rows it returns have one row
+type contextDB struct {
+	DB
+	contexts []context.Context
+}
+
+func (db *contextDB) Exec(ctx context.Context, _ string, _ ...any) (pgconn.CommandTag, error) {
+	db.contexts = append(db.contexts, ctx)
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (db *contextDB) Query(ctx context.Context, _ string, _ ...any) (pgx.Rows, error) {
+	db.contexts = append(db.contexts, ctx)
+	return &oneRow{}, nil
+}
+
+type oneRow struct {
+	pgx.Rows
+	read bool
+}
+
+func (r *oneRow) Next() bool {
+	next := !r.read
+	r.read = true
+	return next
+}
+
+func (r *oneRow) Close() {}
+
+func TestWithTimeout(t *testing.T) {
+	db := &contextDB{}
+	q := New(db, WithTimeout(time.Minute))
+	ctx := context.Background()
+
+	require.NoError(t, execOne(ctx, q.db, "UPDATE", 1))
+	deadline, ok := db.contexts[0].Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, time.Second)
+	assert.ErrorIs(t, db.contexts[0].Err(), context.Canceled, "the context ends with the statement")
+
+	// the rows keep the deadline until they are read
+	rows, err := q.db.Query(ctx, "SELECT")
+	require.NoError(t, err)
+	assert.True(t, rows.Next())
+	assert.NoError(t, db.contexts[1].Err())
+	assert.False(t, rows.Next())
+	assert.ErrorIs(t, db.contexts[1].Err(), context.Canceled)
+	rows.Close()
+
+	// a shorter deadline of the caller stays
+	short, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, execOne(short, q.db, "UPDATE", 1))
+	deadline, _ = db.contexts[2].Deadline()
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, time.Second)
+
+	// no timeout leaves the context alone
+	db = &contextDB{}
+	q = New(db, WithTimeout(0))
+	require.NoError(t, execOne(ctx, q.db, "UPDATE", 1))
+	_, ok = db.contexts[0].Deadline()
+	assert.False(t, ok)
+}
@@ -0,0 +1,230 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+const insertWebhook = `
+INSERT INTO webhooks
+(owner_id, url, events, post_ids, secret, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id
+`
+
+func (q *Queries) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error) {
+	postIDs := webhook.PostIDs
+	if postIDs == nil {
+		postIDs = []int{}
+	}
+
+	row := q.db.QueryRow(ctx, insertWebhook,
+		webhook.OwnerID, webhook.URL, eventTypesToStrings(webhook.Events), postIDs, webhook.Secret, webhook.CreatedAt)
+
+	if err := row.Scan(&webhook.ID); err != nil {
+		return nil, fmt.Errorf("can't scan webhook id: %w", err)
+	}
+
+	return webhook, nil
+}
+
+const selectWebhook = `
+SELECT id, owner_id, url, events, post_ids, secret, created_at
+FROM webhooks
+WHERE id = $1
+`
+
+func (q *Queries) GetWebhook(ctx context.Context, id int) (*domain.Webhook, error) {
+	webhook, err := scanWebhook(q.db.QueryRow(ctx, selectWebhook, id))
+	if err != nil {
+		return nil, fmt.Errorf("can't scan webhook row: %w", classify(err))
+	}
+
+	return webhook, nil
+}
+
+const selectWebhooksByOwner = `
+SELECT id, owner_id, url, events, post_ids, secret, created_at
+FROM webhooks
+WHERE owner_id = $1
+ORDER BY id
+`
+
+func (q *Queries) GetWebhooks(ctx context.Context, ownerID int) ([]*domain.Webhook, error) {
+	return q.selectWebhooks(ctx, selectWebhooksByOwner, ownerID)
+}
+
+const selectWebhooksForEvent = `
+SELECT id, owner_id, url, events, post_ids, secret, created_at
+FROM webhooks
+WHERE $1 = ANY(events) AND (cardinality(post_ids) = 0 OR $2 = ANY(post_ids))
+ORDER BY id
+`
+
+func (q *Queries) GetWebhooksForEvent(ctx context.Context, eventType domain.EventType, postID int) ([]*domain.Webhook, error) {
+	return q.selectWebhooks(ctx, selectWebhooksForEvent, string(eventType), postID)
+}
+
+func (q *Queries) selectWebhooks(ctx context.Context, query string, args ...any) ([]*domain.Webhook, error) {
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't select webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := make([]*domain.Webhook, 0)
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan webhook row: %w", err)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading rows: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func scanWebhook(row pgx.Row) (*domain.Webhook, error) {
+	var w domain.Webhook
+	var events []string
+	if err := row.Scan(&w.ID, &w.OwnerID, &w.URL, &events, &w.PostIDs, &w.Secret, &w.CreatedAt); err != nil {
+		return nil, err
+	}
+	for _, e := range events {
+		w.Events = append(w.Events, domain.EventType(e))
+	}
+
+	return &w, nil
+}
+
+func eventTypesToStrings(types []domain.EventType) []string {
+	s := make([]string, len(types))
+	for i, t := range types {
+		s[i] = string(t)
+	}
+	return s
+}
+
+const deleteWebhook = `
+DELETE FROM webhooks
+WHERE id = $1
+`
+
+func (q *Queries) DeleteWebhook(ctx context.Context, id int) error {
+	if err := execOne(ctx, q.db, deleteWebhook, id); err != nil {
+		return fmt.Errorf("can't delete webhook: %w", err)
+	}
+
+	return nil
+}
+
+const insertWebhookDelivery = `
+INSERT INTO webhook_deliveries
+(webhook_id, event_id, event_type, payload, status, next_attempt_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+ON CONFLICT (webhook_id, event_id) DO NOTHING
+`
+
+// CreateWebhookDeliveries inserts the deliveries in one batch, the ids are not set
+func (q *Queries) CreateWebhookDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	batch := &pgx.Batch{}
+	for _, d := range deliveries {
+		batch.Queue(insertWebhookDelivery,
+			d.WebhookID, d.EventID, d.EventType, d.Payload, d.Status, d.NextAttemptAt, d.CreatedAt)
+	}
+
+	if err := q.db.SendBatch(ctx, batch).Close(); err != nil {
+		return fmt.Errorf("can't insert webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+// claimWebhookDeliveries leases the due pending deliveries by moving their next attempt past the lease
+const claimWebhookDeliveries = `
+UPDATE webhook_deliveries
+SET next_attempt_at = NOW() + make_interval(secs => $2)
+WHERE id IN (
+    SELECT id
+    FROM webhook_deliveries
+    WHERE status = 'PENDING' AND next_attempt_at <= NOW()
+    ORDER BY id
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING id, webhook_id, event_id, event_type, payload, status, attempts, response_status, last_error,
+          next_attempt_at, created_at, delivered_at
+`
+
+func (q *Queries) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error) {
+	deliveries, err := q.selectWebhookDeliveries(ctx, claimWebhookDeliveries, limit, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+
+	// RETURNING doesn't keep the order of the subquery
+	sort.Slice(deliveries, func(i, j int) bool { return deliveries[i].ID < deliveries[j].ID })
+
+	return deliveries, nil
+}
+
+const updateWebhookDelivery = `
+UPDATE webhook_deliveries
+SET status = $2, attempts = $3, response_status = $4, last_error = $5, next_attempt_at = $6, delivered_at = $7
+WHERE id = $1
+`
+
+func (q *Queries) UpdateWebhookDelivery(ctx context.Context, d *domain.WebhookDelivery) error {
+	_, err := q.db.Exec(ctx, updateWebhookDelivery,
+		d.ID, d.Status, d.Attempts, d.ResponseStatus, d.LastError, d.NextAttemptAt, d.DeliveredAt)
+	if err != nil {
+		return fmt.Errorf("can't update webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+const selectWebhookDeliveries = `
+SELECT id, webhook_id, event_id, event_type, payload, status, attempts, response_status, last_error,
+       next_attempt_at, created_at, delivered_at
+FROM webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY id DESC
+LIMIT $2 OFFSET $3
+`
+
+func (q *Queries) GetWebhookDeliveries(ctx context.Context, webhookID int, limit, offset int) ([]*domain.WebhookDelivery, error) {
+	return q.selectWebhookDeliveries(ctx, selectWebhookDeliveries, webhookID, limit, offset)
+}
+
+func (q *Queries) selectWebhookDeliveries(ctx context.Context, query string, args ...any) ([]*domain.WebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't select webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]*domain.WebhookDelivery, 0)
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		err := rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+			&d.ResponseStatus, &d.LastError, &d.NextAttemptAt, &d.CreatedAt, &d.DeliveredAt)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan webhook delivery row: %w", err)
+		}
+		deliveries = append(deliveries, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading rows: %w", err)
+	}
+
+	return deliveries, nil
+}
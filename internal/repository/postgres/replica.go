@@ -0,0 +1,248 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/postgres/queries"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/worker"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Replicas are the read replicas of the primary database. Reads go to the healthy ones in turn,
+// a replica is checked in the background and skipped while it is down or lags too far behind.
+type Replicas struct {
+	replicas     []*replica
+	next         atomic.Uint64
+	logger       *slog.Logger
+	interval     time.Duration // how often the replicas are checked
+	maxLag       time.Duration // zero disables the lag check
+	queryTimeout time.Duration
+
+	*worker.Loop // Start and Stop the health checks
+}
+
+type replica struct {
+	name    string // host and port, used in logs
+	pool    *pgxpool.Pool
+	queries *queries.Queries
+	healthy atomic.Bool
+}
+
+// ReplicaOption configures the replicas
+type ReplicaOption func(*Replicas)
+
+// WithCheckInterval sets how often the replicas are checked, 5s by default
+func WithCheckInterval(interval time.Duration) ReplicaOption {
+	return func(r *Replicas) {
+		r.interval = interval
+	}
+}
+
+// WithMaxLag skips replicas that replay the changes of the primary later than the lag
+func WithMaxLag(lag time.Duration) ReplicaOption {
+	return func(r *Replicas) {
+		r.maxLag = lag
+	}
+}
+
+// WithReplicaQueryTimeout bounds every query on the replicas like WithQueryTimeout
+func WithReplicaQueryTimeout(timeout time.Duration) ReplicaOption {
+	return func(r *Replicas) {
+		r.queryTimeout = timeout
+	}
+}
+
+// NewReplicas creates Replicas on the pools, they are healthy until a check or a query fails.
+// The pools are closed by Close.
+func NewReplicas(pools []*pgxpool.Pool, logger *slog.Logger, opts ...ReplicaOption) *Replicas {
+	r := &Replicas{
+		logger:   logger,
+		interval: 5 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	for _, pool := range pools {
+		cfg := pool.Config().ConnConfig
+		rep := &replica{
+			name:    fmt.Sprintf("%s:%d", cfg.Host, cfg.Port),
+			pool:    pool,
+			queries: queries.New(pool, queries.WithTimeout(r.queryTimeout)),
+		}
+		rep.healthy.Store(true)
+		r.replicas = append(r.replicas, rep)
+	}
+	r.Loop = worker.New("replica health checks", r.interval, r.CheckAll)
+
+	return r
+}
+
+// Close closes the pools of the replicas, waiting until the connections in use are released
+func (r *Replicas) Close() {
+	for _, rep := range r.replicas {
+		rep.pool.Close()
+	}
+}
+
+// Pools returns the pools of the replicas in the order they were given
+func (r *Replicas) Pools() []*pgxpool.Pool {
+	pools := make([]*pgxpool.Pool, len(r.replicas))
+	for i, rep := range r.replicas {
+		pools[i] = rep.pool
+	}
+	return pools
+}
+
+// Healthy returns how many replicas take reads
+func (r *Replicas) Healthy() int {
+	n := 0
+	for _, rep := range r.replicas {
+		if rep.healthy.Load() {
+			n++
+		}
+	}
+	return n
+}
+
+// pick returns the next healthy replica, or nil if there is none
+func (r *Replicas) pick() *replica {
+	n := uint64(len(r.replicas))
+	start := r.next.Add(1)
+	for i := range n {
+		rep := r.replicas[(start+i)%n]
+		if rep.healthy.Load() {
+			return rep
+		}
+	}
+	return nil
+}
+
+// queryCanceled is the error of a query canceled by the statement timeout or a user request
+const queryCanceled = "57014"
+
+// replicationLag is zero on a database that is not a replica or has replayed everything it received
+const replicationLag = `
+SELECT CASE
+	WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+	ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END::float8
+`
+
+// CheckAll checks every replica and marks it healthy or not
+func (r *Replicas) CheckAll(ctx context.Context) {
+	for _, rep := range r.replicas {
+		r.setHealthy(ctx, rep, r.check(ctx, rep))
+	}
+}
+
+func (r *Replicas) check(ctx context.Context, rep *replica) error {
+	ctx, cancel := context.WithTimeout(ctx, r.interval)
+	defer cancel()
+
+	var lag float64
+	if err := rep.pool.QueryRow(ctx, replicationLag).Scan(&lag); err != nil {
+		return fmt.Errorf("can't get replication lag: %w", err)
+	}
+	if d := time.Duration(lag * float64(time.Second)); r.maxLag > 0 && d > r.maxLag {
+		return fmt.Errorf("replication lag %s is over %s", d.Round(time.Millisecond), r.maxLag)
+	}
+	return nil
+}
+
+// setHealthy logs the changes of the replica health
+func (r *Replicas) setHealthy(ctx context.Context, rep *replica, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	healthy := err == nil
+	if rep.healthy.Swap(healthy) == healthy {
+		return
+	}
+	if healthy {
+		r.logger.InfoContext(ctx, "replica is back, reads go to it again", "replica", rep.name)
+	} else {
+		r.logger.WarnContext(ctx, "replica is down, reads go to the other replicas or the primary",
+			"replica", rep.name, "error", err)
+	}
+}
+
+// WithReplicas routes GetPosts, GetPost, GetCommentsByPost and GetCommentsByParent to the replicas,
+// unless they run in a transaction or the context asks for the primary with repository.WithPrimaryReads
+func WithReplicas(replicas *Replicas) Option {
+	return func(r *postgresRepo) {
+		r.replicas = replicas
+	}
+}
+
+func (r *postgresRepo) GetPosts(ctx context.Context) ([]*domain.Post, error) {
+	return read(ctx, r, func(q *queries.Queries) ([]*domain.Post, error) {
+		return q.GetPosts(ctx)
+	})
+}
+
+// GetPost locks the post when it runs in a unit of work: the checks made on it, like comments being enabled,
+// hold until the unit of work ends at any isolation level, not only at SERIALIZABLE
+func (r *postgresRepo) GetPost(ctx context.Context, id int) (*domain.Post, error) {
+	if r.inTx {
+		return r.Queries.GetPostForShare(ctx, id)
+	}
+	return read(ctx, r, func(q *queries.Queries) (*domain.Post, error) {
+		return q.GetPost(ctx, id)
+	})
+}
+
+func (r *postgresRepo) GetCommentsByPost(ctx context.Context, postID int, limit, offset int, includeHidden bool) ([]*domain.Comment, error) {
+	return read(ctx, r, func(q *queries.Queries) ([]*domain.Comment, error) {
+		return q.GetCommentsByPost(ctx, postID, limit, offset, includeHidden)
+	})
+}
+
+func (r *postgresRepo) GetCommentsByParent(ctx context.Context, parentID int, limit, offset int, includeHidden bool) ([]*domain.Comment, error) {
+	return read(ctx, r, func(q *queries.Queries) ([]*domain.Comment, error) {
+		return q.GetCommentsByParent(ctx, parentID, limit, offset, includeHidden)
+	})
+}
+
+// read runs the query on a replica and falls back to the primary when there is no healthy replica
+// or the replica fails. A replica behind the primary may miss a new row, so ErrNotFound is checked on the primary too.
+func read[T any](ctx context.Context, r *postgresRepo, query func(q *queries.Queries) (T, error)) (T, error) {
+	if r.inTx || r.replicas == nil || repository.PrimaryReads(ctx) {
+		return query(r.Queries)
+	}
+	rep := r.replicas.pick()
+	if rep == nil {
+		return query(r.Queries)
+	}
+
+	res, err := query(rep.queries)
+	if err == nil || ctx.Err() != nil {
+		return res, err
+	}
+	if isConnectionFailure(err) {
+		r.replicas.setHealthy(ctx, rep, err)
+	}
+	return query(r.Queries)
+}
+
+// isConnectionFailure reports if the error is not a result of the query but of the database being unavailable
+func isConnectionFailure(err error) bool {
+	if errors.Is(err, repository.ErrNotFound) {
+		return false
+	}
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return true
+	}
+	// connection exceptions and operator interventions, like a shutdown or a database that is starting up,
+	// but not a query canceled by the statement timeout
+	class := pgErr.Code[:2]
+	return class == "08" || (class == "57" && pgErr.Code != queryCanceled)
+}
@@ -0,0 +1,134 @@
+package postgres
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"testing"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/repotest"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var discard = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+// unreachable is a pool of a database nobody listens to, pgxpool connects lazily so it is created anyway
+func unreachable(t *testing.T) *pgxpool.Pool {
+	pool, err := pgxpool.New(context.Background(), "postgres://postgres@127.0.0.1:1/posts?connect_timeout=1")
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestReplicas_Health(t *testing.T) {
+	replicas := NewReplicas([]*pgxpool.Pool{unreachable(t), unreachable(t)}, discard)
+	assert.Equal(t, 2, replicas.Healthy())
+	assert.NotNil(t, replicas.pick())
+
+	replicas.CheckAll(context.Background())
+	assert.Zero(t, replicas.Healthy())
+	assert.Nil(t, replicas.pick())
+}
+
+func TestIsConnectionFailure(t *testing.T) {
+	assert.False(t, isConnectionFailure(repository.ErrNotFound))
+	assert.False(t, isConnectionFailure(&pgconn.PgError{Code: "23505"}))
+	assert.False(t, isConnectionFailure(&pgconn.PgError{Code: queryCanceled}))
+	assert.True(t, isConnectionFailure(&pgconn.PgError{Code: "57P01"})) // admin shutdown
+	assert.True(t, isConnectionFailure(&pgconn.PgError{Code: "08006"}))
+	assert.True(t, isConnectionFailure(context.DeadlineExceeded))
+}
+
+// the replica is the primary database itself, so every read must behave the same
+func TestPostgres_ConformanceWithReplicas(t *testing.T) {
+	pool := pool(t)
+
+	repotest.Run(t, func(t *testing.T) repository.Repository {
+		_, err := pool.Exec(context.Background(), truncate)
+		require.NoError(t, err)
+		return New(pool, WithReplicas(NewReplicas([]*pgxpool.Pool{pool}, discard)))
+	})
+}
+
+// replicaPool connects to the database in DB_REPLICA_URL, the test is skipped when it is not set.
+// It must be another database than DB_URL, they tell apart where a read went.
+func replicaPool(t *testing.T) *pgxpool.Pool {
+	dbURL := os.Getenv("DB_REPLICA_URL")
+	if dbURL == "" {
+		t.Skip("DB_REPLICA_URL is not set")
+	}
+
+	require.NoError(t, ProcessMigration(migrationPath, dbURL))
+
+	pool, err := SetupPgxPool(context.Background(), dbURL, poolConfig, nil)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	_, err = pool.Exec(context.Background(), truncate)
+	require.NoError(t, err)
+	return pool
+}
+
+func TestPostgres_Replicas(t *testing.T) {
+	primary := pool(t)
+	replica := replicaPool(t)
+	ctx := context.Background()
+	_, err := primary.Exec(ctx, truncate)
+	require.NoError(t, err)
+
+	replicas := NewReplicas([]*pgxpool.Pool{replica}, discard)
+	repo := New(primary, WithReplicas(replicas))
+
+	post, err := repo.CreatePost(ctx, &domain.Post{Title: "title", Content: "content", AuthorID: 1})
+	require.NoError(t, err)
+
+	// the list comes from the empty replica, unless the primary is asked for
+	posts, err := repo.GetPosts(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, posts)
+	posts, err = repo.GetPosts(repository.WithPrimaryReads(ctx))
+	require.NoError(t, err)
+	assert.Len(t, posts, 1)
+
+	// a row missing on the replica is looked up on the primary
+	got, err := repo.GetPost(ctx, post.ID)
+	require.NoError(t, err)
+	assert.Equal(t, post.ID, got.ID)
+
+	// reads in a transaction go to the primary
+	require.NoError(t, repo.WithTx(ctx, func(tx repository.Repository) error {
+		posts, err := tx.GetPosts(ctx)
+		assert.Len(t, posts, 1)
+		return err
+	}))
+
+	// a replica that went down is skipped
+	replica.Close()
+	posts, err = repo.GetPosts(ctx)
+	require.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Zero(t, replicas.Healthy())
+}
+
+func TestPostgres_ReplicaFallback(t *testing.T) {
+	primary := pool(t)
+	ctx := context.Background()
+	_, err := primary.Exec(ctx, truncate)
+	require.NoError(t, err)
+
+	replicas := NewReplicas([]*pgxpool.Pool{unreachable(t)}, discard)
+	repo := New(primary, WithReplicas(replicas))
+	post, err := repo.CreatePost(ctx, &domain.Post{Title: "title", Content: "content", AuthorID: 1})
+	require.NoError(t, err)
+
+	comments, err := repo.GetCommentsByPost(ctx, post.ID, 10, 0, false)
+	require.NoError(t, err)
+	assert.Empty(t, comments)
+	assert.Zero(t, replicas.Healthy())
+}
@@ -2,18 +2,101 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
 )
 
+var (
+	// ErrNotFound is returned for an id that doesn't exist: by reads of a single entity,
+	// by writes to the entity and by writes that refer to it, like a comment on a missing post
+	ErrNotFound = errors.New("not found")
+	// ErrConflict is returned when a write clashes with the stored data, like a duplicate unique key,
+	// or when a unit of work keeps failing to serialize with concurrent ones
+	ErrConflict = errors.New("conflict")
+)
+
+// Repository stores the data of the service. Its errors wrap ErrNotFound and ErrConflict where they apply,
+// so callers check them with errors.Is instead of looking the entity up first.
 type Repository interface {
 	GetPosts(ctx context.Context) ([]*domain.Post, error)
+	// GetPostsAfter returns posts ordered by id, starting after the given id
+	GetPostsAfter(ctx context.Context, limit, afterID int) ([]*domain.Post, error)
 	GetPost(ctx context.Context, id int) (*domain.Post, error)
-	ContainsPost(ctx context.Context, id int) (bool, error)
 	CreatePost(ctx context.Context, post *domain.Post) (*domain.Post, error)
 	CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error)
-	ContainsComment(ctx context.Context, id int) (bool, error)
-	GetCommentsByPost(ctx context.Context, postID int, limit, offset int) ([]*domain.Comment, error)
-	GetCommentsByParent(ctx context.Context, parentId int, limit, offset int) ([]*domain.Comment, error)
+	GetComment(ctx context.Context, id int) (*domain.Comment, error)
+	// GetCommentsByPost and GetCommentsByParent skip hidden comments unless includeHidden is set,
+	// they return ErrNotFound if the post or the parent comment doesn't exist
+	GetCommentsByPost(ctx context.Context, postID int, limit, offset int, includeHidden bool) ([]*domain.Comment, error)
+	GetCommentsByParent(ctx context.Context, parentId int, limit, offset int, includeHidden bool) ([]*domain.Comment, error)
 	DisableComments(ctx context.Context, postID int) error
+	EnableComments(ctx context.Context, postID int) error
+	// ImportPosts creates the posts with their comments in one unit of work, keeping their timestamps and hidden flags.
+	// The repository assigns new ids, the post and parent ids of the comments refer to the given posts and comments
+	// and are remapped like Remap does. The given entities are not modified.
+	ImportPosts(ctx context.Context, posts []*domain.Post, comments []*domain.Comment) error
+
+	// moderation
+	HidePost(ctx context.Context, id int) error
+	HideComment(ctx context.Context, id int) error
+	DeletePost(ctx context.Context, id int) error
+	DeleteComment(ctx context.Context, id int) error
+	CreateReport(ctx context.Context, report *domain.Report) (*domain.Report, error)
+	GetReport(ctx context.Context, id int) (*domain.Report, error)
+	// GetReports returns reports with the given status ordered by id, starting after the given id
+	GetReports(ctx context.Context, status domain.ReportStatus, limit, afterID int) ([]*domain.Report, error)
+	// ResolveReport sets the final status of the report and records the decision in the audit trail
+	ResolveReport(ctx context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord) (*domain.ModerationRecord, error)
+	GetModerationRecords(ctx context.Context, limit, offset int) ([]*domain.ModerationRecord, error)
+	BanAuthor(ctx context.Context, authorID int) error
+	IsBanned(ctx context.Context, authorID int) (bool, error)
+
+	// outbox
+	// CreateEvent returns ErrConflict if an event with the same dedup id exists
+	CreateEvent(ctx context.Context, event *domain.Event) (*domain.Event, error)
+	// ClaimEvents leases up to limit unpublished events ordered by id, leased events are not claimed again
+	// until the lease ends, so an event that is not marked published in time is delivered again
+	ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]*domain.Event, error)
+	MarkEventsPublished(ctx context.Context, ids []int, publishedAt time.Time) error
+	// DeletePublishedEvents deletes events published before the given time and returns how many were deleted
+	DeletePublishedEvents(ctx context.Context, before time.Time) (int, error)
+
+	// webhooks
+	CreateWebhook(ctx context.Context, webhook *domain.Webhook) (*domain.Webhook, error)
+	GetWebhook(ctx context.Context, id int) (*domain.Webhook, error)
+	// GetWebhooks returns the webhooks of the owner ordered by id
+	GetWebhooks(ctx context.Context, ownerID int) ([]*domain.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int) error
+	// GetWebhooksForEvent returns the webhooks subscribed to the event type that watch the post
+	GetWebhooksForEvent(ctx context.Context, eventType domain.EventType, postID int) ([]*domain.Webhook, error)
+	// CreateWebhookDeliveries skips deliveries of events the webhook already has, so an event is delivered once
+	CreateWebhookDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error
+	// ClaimWebhookDeliveries leases up to limit due pending deliveries ordered by id,
+	// moving their next attempt to the end of the lease
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*domain.WebhookDelivery, error)
+	// UpdateWebhookDelivery saves the result of a delivery attempt
+	UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) error
+	// GetWebhookDeliveries returns the deliveries of the webhook, newest first
+	GetWebhookDeliveries(ctx context.Context, webhookID int, limit, offset int) ([]*domain.WebhookDelivery, error)
+
+	// WithTx runs fn as a unit of work, fn must make its calls through the repository it is given.
+	// fn may run again when the transaction is retried, so it must have no other side effects.
+	// Calling WithTx on the repository given to fn runs in the same unit of work.
+	WithTx(ctx context.Context, fn func(repo Repository) error) error
+}
+
+type primaryReadsKey struct{}
+
+// WithPrimaryReads marks the context of calls that must see the writes made before them,
+// a repository with read replicas sends their reads to the primary database
+func WithPrimaryReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryReadsKey{}, true)
+}
+
+// PrimaryReads reports if the context is marked by WithPrimaryReads
+func PrimaryReads(ctx context.Context) bool {
+	primary, _ := ctx.Value(primaryReadsKey{}).(bool)
+	return primary
 }
@@ -0,0 +1,758 @@
+// Package repotest is a conformance suite that every repository.Repository implementation runs in its tests
+package repotest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NewRepository returns a repository for one test. It may hold the posts and comments its migrations
+// add, but no reports, events or webhooks.
+type NewRepository func(t *testing.T) repository.Repository
+
+// Run runs the suite, every test gets a repository of its own
+func Run(t *testing.T, newRepo NewRepository) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, repo repository.Repository)
+	}{
+		{"Posts", testPosts},
+		{"CommentOrder", testCommentOrder},
+		{"CommentPagination", testCommentPagination},
+		{"Threads", testThreads},
+		{"Hidden", testHidden},
+		{"DisableComments", testDisableComments},
+		{"Delete", testDelete},
+		{"Import", testImport},
+		{"Reports", testReports},
+		{"Outbox", testOutbox},
+		{"Webhooks", testWebhooks},
+		{"NotFound", testNotFound},
+		{"WithTx", testWithTx},
+		{"ConcurrentWrites", testConcurrentWrites},
+		{"CommentsRaceDisable", testCommentsRaceDisable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newRepo(t))
+		})
+	}
+}
+
+// base is the creation time of the test data, in UTC and whole seconds so that every database keeps it exactly
+var base = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+func at(minutes int) time.Time {
+	return base.Add(time.Duration(minutes) * time.Minute)
+}
+
+func createPost(t *testing.T, repo repository.Repository, title string) *domain.Post {
+	t.Helper()
+	post, err := repo.CreatePost(context.Background(), &domain.Post{
+		Title:     title,
+		Content:   "content of " + title,
+		AuthorID:  1,
+		CreatedAt: base,
+	})
+	require.NoError(t, err)
+	return post
+}
+
+func createComment(t *testing.T, repo repository.Repository, postID int, parentID *int, createdAt time.Time) *domain.Comment {
+	t.Helper()
+	comment, err := repo.CreateComment(context.Background(), &domain.Comment{
+		PostID:    postID,
+		ParentID:  parentID,
+		AuthorID:  2,
+		Content:   "comment",
+		CreatedAt: createdAt,
+	})
+	require.NoError(t, err)
+	return comment
+}
+
+func ids[T any](items []*T, id func(*T) int) []int {
+	result := make([]int, 0, len(items))
+	for _, item := range items {
+		result = append(result, id(item))
+	}
+	return result
+}
+
+func commentIDs(comments []*domain.Comment) []int {
+	return ids(comments, func(c *domain.Comment) int { return c.ID })
+}
+
+func postComments(t *testing.T, repo repository.Repository, postID int, includeHidden bool) []int {
+	t.Helper()
+	comments, err := repo.GetCommentsByPost(context.Background(), postID, 100, 0, includeHidden)
+	require.NoError(t, err)
+	return commentIDs(comments)
+}
+
+func testPosts(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	first := createPost(t, repo, "first")
+	second := createPost(t, repo, "second")
+	assert.Greater(t, second.ID, first.ID)
+
+	got, err := repo.GetPost(ctx, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, got.ID)
+	assert.Equal(t, "first", got.Title)
+	assert.Equal(t, "content of first", got.Content)
+	assert.Equal(t, 1, got.AuthorID)
+	assert.True(t, base.Equal(got.CreatedAt))
+	assert.False(t, got.CommentsDisabled)
+	assert.False(t, got.Hidden)
+
+	// ordered by id
+	posts, err := repo.GetPosts(ctx)
+	require.NoError(t, err)
+	postIDs := ids(posts, func(p *domain.Post) int { return p.ID })
+	assert.IsIncreasing(t, postIDs)
+	assert.Equal(t, []int{first.ID, second.ID}, postIDs[len(postIDs)-2:])
+
+	// pages after an id
+	page, err := repo.GetPostsAfter(ctx, 1, first.ID-1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{first.ID}, ids(page, func(p *domain.Post) int { return p.ID }))
+	page, err = repo.GetPostsAfter(ctx, 10, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{second.ID}, ids(page, func(p *domain.Post) int { return p.ID }))
+	page, err = repo.GetPostsAfter(ctx, 10, second.ID)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+}
+
+func testCommentOrder(t *testing.T, repo repository.Repository) {
+	post := createPost(t, repo, "post")
+	oldest := createComment(t, repo, post.ID, nil, at(1))
+	newest := createComment(t, repo, post.ID, nil, at(3))
+	middle := createComment(t, repo, post.ID, nil, at(2))
+	tied := createComment(t, repo, post.ID, nil, at(3))
+
+	// newest first, ties broken by the greater id
+	assert.Equal(t, []int{tied.ID, newest.ID, middle.ID, oldest.ID}, postComments(t, repo, post.ID, false))
+}
+
+func testCommentPagination(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	post := createPost(t, repo, "post")
+	other := createPost(t, repo, "other")
+	var created []int
+	for i := 0; i < 5; i++ {
+		created = append(created, createComment(t, repo, post.ID, nil, at(i)).ID)
+		createComment(t, repo, other.ID, nil, at(i))
+	}
+
+	tests := []struct {
+		limit, offset int
+		want          []int
+	}{
+		{limit: 2, offset: 0, want: []int{created[4], created[3]}},
+		{limit: 2, offset: 2, want: []int{created[2], created[1]}},
+		{limit: 2, offset: 4, want: []int{created[0]}},
+		{limit: 2, offset: 5, want: []int{}},
+		{limit: 10, offset: 0, want: []int{created[4], created[3], created[2], created[1], created[0]}},
+	}
+	for _, tt := range tests {
+		comments, err := repo.GetCommentsByPost(ctx, post.ID, tt.limit, tt.offset, false)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, commentIDs(comments), "limit %d offset %d", tt.limit, tt.offset)
+	}
+}
+
+func testThreads(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	post := createPost(t, repo, "post")
+	root := createComment(t, repo, post.ID, nil, at(1))
+	first := createComment(t, repo, post.ID, &root.ID, at(2))
+	second := createComment(t, repo, post.ID, &root.ID, at(3))
+	nested := createComment(t, repo, post.ID, &first.ID, at(4))
+
+	got, err := repo.GetComment(ctx, nested.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.ParentID)
+	assert.Equal(t, first.ID, *got.ParentID)
+
+	got, err = repo.GetComment(ctx, root.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.ParentID)
+
+	replies, err := repo.GetCommentsByParent(ctx, root.ID, 10, 0, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int{second.ID, first.ID}, commentIDs(replies))
+
+	replies, err = repo.GetCommentsByParent(ctx, root.ID, 1, 1, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int{first.ID}, commentIDs(replies))
+
+	replies, err = repo.GetCommentsByParent(ctx, second.ID, 10, 0, false)
+	require.NoError(t, err)
+	assert.Empty(t, replies)
+
+	// comments of the post include replies at every depth
+	assert.Equal(t, []int{nested.ID, second.ID, first.ID, root.ID}, postComments(t, repo, post.ID, false))
+}
+
+func testHidden(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	post := createPost(t, repo, "post")
+	root := createComment(t, repo, post.ID, nil, at(1))
+	hidden := createComment(t, repo, post.ID, &root.ID, at(2))
+	require.NoError(t, repo.HideComment(ctx, hidden.ID))
+	require.NoError(t, repo.HidePost(ctx, post.ID))
+
+	got, err := repo.GetPost(ctx, post.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Hidden)
+
+	comment, err := repo.GetComment(ctx, hidden.ID)
+	require.NoError(t, err)
+	assert.True(t, comment.Hidden)
+
+	assert.Equal(t, []int{root.ID}, postComments(t, repo, post.ID, false))
+	assert.Equal(t, []int{hidden.ID, root.ID}, postComments(t, repo, post.ID, true))
+
+	replies, err := repo.GetCommentsByParent(ctx, root.ID, 10, 0, false)
+	require.NoError(t, err)
+	assert.Empty(t, replies)
+	replies, err = repo.GetCommentsByParent(ctx, root.ID, 10, 0, true)
+	require.NoError(t, err)
+	assert.Equal(t, []int{hidden.ID}, commentIDs(replies))
+}
+
+func testDisableComments(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	post := createPost(t, repo, "post")
+	other := createPost(t, repo, "other")
+	require.NoError(t, repo.DisableComments(ctx, post.ID))
+	// disabling twice is not an error
+	require.NoError(t, repo.DisableComments(ctx, post.ID))
+
+	got, err := repo.GetPost(ctx, post.ID)
+	require.NoError(t, err)
+	assert.True(t, got.CommentsDisabled)
+
+	got, err = repo.GetPost(ctx, other.ID)
+	require.NoError(t, err)
+	assert.False(t, got.CommentsDisabled)
+
+	require.NoError(t, repo.EnableComments(ctx, post.ID))
+	got, err = repo.GetPost(ctx, post.ID)
+	require.NoError(t, err)
+	assert.False(t, got.CommentsDisabled)
+}
+
+func testImport(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	existing := createPost(t, repo, "existing")
+	existingComment := createComment(t, repo, existing.ID, nil, at(1))
+
+	// the ids of the import clash with the existing ones
+	parentID := existingComment.ID
+	posts := []*domain.Post{
+		{ID: existing.ID, Title: "imported", Content: "content", AuthorID: 3, CreatedAt: at(-10), CommentsDisabled: true},
+		{ID: existing.ID + 1, Title: "hidden", Content: "content", AuthorID: 3, CreatedAt: at(-5), Hidden: true},
+	}
+	comments := []*domain.Comment{
+		{ID: existingComment.ID, PostID: existing.ID, AuthorID: 4, Content: "root", CreatedAt: at(-9)},
+		{ID: existingComment.ID + 1, PostID: existing.ID, ParentID: &parentID, AuthorID: 4, Content: "reply", CreatedAt: at(-8), Hidden: true},
+	}
+	require.NoError(t, repo.ImportPosts(ctx, posts, comments))
+	assert.Equal(t, existing.ID, posts[0].ID, "the given posts are not modified")
+	assert.Equal(t, existingComment.ID, *comments[1].ParentID, "the given comments are not modified")
+
+	all, err := repo.GetPostsAfter(ctx, 10, existing.ID)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	imported, hidden := all[0], all[1]
+	assert.Equal(t, "imported", imported.Title)
+	assert.Equal(t, 3, imported.AuthorID)
+	assert.True(t, at(-10).Equal(imported.CreatedAt))
+	assert.True(t, imported.CommentsDisabled)
+	assert.True(t, hidden.Hidden)
+
+	thread, err := repo.GetCommentsByPost(ctx, imported.ID, 10, 0, true)
+	require.NoError(t, err)
+	require.Len(t, thread, 2)
+	reply, root := thread[0], thread[1]
+	assert.Equal(t, "root", root.Content)
+	assert.Nil(t, root.ParentID)
+	require.NotNil(t, reply.ParentID)
+	assert.Equal(t, root.ID, *reply.ParentID)
+	assert.True(t, reply.Hidden)
+	assert.True(t, at(-8).Equal(reply.CreatedAt))
+	assert.Equal(t, []int{root.ID}, postComments(t, repo, imported.ID, false))
+	assert.Equal(t, []int{existingComment.ID}, postComments(t, repo, existing.ID, true), "the existing post keeps its comments")
+
+	// nothing is imported when a reference is missing
+	orphan := []*domain.Comment{{ID: 1, PostID: 1, ParentID: &parentID, AuthorID: 4, Content: "orphan", CreatedAt: base}}
+	err = repo.ImportPosts(ctx, []*domain.Post{{ID: 1, Title: "orphaned", AuthorID: 3, CreatedAt: base}}, orphan)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	after, err := repo.GetPostsAfter(ctx, 10, hidden.ID)
+	require.NoError(t, err)
+	assert.Empty(t, after)
+
+	// the repository keeps assigning ids after the imported ones
+	next := createPost(t, repo, "next")
+	assert.Greater(t, next.ID, hidden.ID)
+	nextComment := createComment(t, repo, next.ID, nil, at(2))
+	assert.Greater(t, nextComment.ID, reply.ID)
+}
+
+func testDelete(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	post := createPost(t, repo, "post")
+	root := createComment(t, repo, post.ID, nil, at(1))
+	reply := createComment(t, repo, post.ID, &root.ID, at(2))
+	nested := createComment(t, repo, post.ID, &reply.ID, at(3))
+	kept := createComment(t, repo, post.ID, nil, at(4))
+
+	// a comment takes its replies with it
+	require.NoError(t, repo.DeleteComment(ctx, root.ID))
+	for _, id := range []int{root.ID, reply.ID, nested.ID} {
+		_, err := repo.GetComment(ctx, id)
+		assert.ErrorIs(t, err, repository.ErrNotFound, "comment %d", id)
+	}
+	assert.Equal(t, []int{kept.ID}, postComments(t, repo, post.ID, true))
+
+	// a post takes its comments with it
+	require.NoError(t, repo.DeletePost(ctx, post.ID))
+	_, err := repo.GetPost(ctx, post.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = repo.GetComment(ctx, kept.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+}
+
+func testReports(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	post := createPost(t, repo, "post")
+	var reports []*domain.Report
+	for i := 0; i < 3; i++ {
+		report, err := repo.CreateReport(ctx, &domain.Report{
+			TargetType: domain.TargetPost,
+			TargetID:   post.ID,
+			ReporterID: 3 + i,
+			Reason:     "spam",
+			Status:     domain.ReportOpen,
+			CreatedAt:  at(i),
+		})
+		require.NoError(t, err)
+		reports = append(reports, report)
+	}
+
+	got, err := repo.GetReport(ctx, reports[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, post.ID, got.TargetID)
+	assert.Equal(t, domain.ReportOpen, got.Status)
+
+	// pages of open reports ordered by id
+	page, err := repo.GetReports(ctx, domain.ReportOpen, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int{reports[0].ID, reports[1].ID}, ids(page, func(r *domain.Report) int { return r.ID }))
+	page, err = repo.GetReports(ctx, domain.ReportOpen, 2, reports[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, []int{reports[2].ID}, ids(page, func(r *domain.Report) int { return r.ID }))
+
+	var records []*domain.ModerationRecord
+	for i, report := range reports[:2] {
+		record, err := repo.ResolveReport(ctx, report.ID, domain.ReportActioned, &domain.ModerationRecord{
+			ReportID:    report.ID,
+			ModeratorID: 9,
+			Action:      domain.ActionHide,
+			TargetType:  domain.TargetPost,
+			TargetID:    post.ID,
+			AuthorID:    post.AuthorID,
+			CreatedAt:   at(10 + i),
+		})
+		require.NoError(t, err)
+		records = append(records, record)
+	}
+	assert.Greater(t, records[1].ID, records[0].ID)
+
+	resolved, err := repo.GetReport(ctx, reports[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReportActioned, resolved.Status)
+	require.NotNil(t, resolved.ResolvedAt)
+	assert.True(t, at(10).Equal(*resolved.ResolvedAt))
+
+	page, err = repo.GetReports(ctx, domain.ReportOpen, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int{reports[2].ID}, ids(page, func(r *domain.Report) int { return r.ID }))
+
+	// newest first
+	trail, err := repo.GetModerationRecords(ctx, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int{records[1].ID, records[0].ID}, ids(trail, func(r *domain.ModerationRecord) int { return r.ID }))
+	trail, err = repo.GetModerationRecords(ctx, 10, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{records[0].ID}, ids(trail, func(r *domain.ModerationRecord) int { return r.ID }))
+
+	banned, err := repo.IsBanned(ctx, 7)
+	require.NoError(t, err)
+	assert.False(t, banned)
+	require.NoError(t, repo.BanAuthor(ctx, 7))
+	// banning twice is not an error
+	require.NoError(t, repo.BanAuthor(ctx, 7))
+	banned, err = repo.IsBanned(ctx, 7)
+	require.NoError(t, err)
+	assert.True(t, banned)
+}
+
+func testOutbox(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	var events []*domain.Event
+	for i := 0; i < 3; i++ {
+		event, err := repo.CreateEvent(ctx, &domain.Event{
+			DedupID:   fmt.Sprintf("COMMENT_CREATED:%d:a", i),
+			Type:      domain.EventCommentCreated,
+			PostID:    1,
+			Payload:   json.RawMessage(`{"id":1}`),
+			CreatedAt: time.Now().UTC(),
+		})
+		require.NoError(t, err)
+		events = append(events, event)
+	}
+	// the dedup id is unique
+	_, err := repo.CreateEvent(ctx, &domain.Event{
+		DedupID:   events[0].DedupID,
+		Type:      domain.EventCommentCreated,
+		PostID:    1,
+		Payload:   json.RawMessage(`{"id":1}`),
+		CreatedAt: time.Now().UTC(),
+	})
+	assert.ErrorIs(t, err, repository.ErrConflict)
+
+	eventIDs := func(events []*domain.Event) []int {
+		return ids(events, func(e *domain.Event) int { return e.ID })
+	}
+
+	// claimed events are not claimed again while the lease lasts
+	claimed, err := repo.ClaimEvents(ctx, 2, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []int{events[0].ID, events[1].ID}, eventIDs(claimed))
+	assert.JSONEq(t, `{"id":1}`, string(claimed[0].Payload))
+	claimed, err = repo.ClaimEvents(ctx, 2, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, []int{events[2].ID}, eventIDs(claimed))
+	claimed, err = repo.ClaimEvents(ctx, 2, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	publishedAt := time.Now().UTC()
+	require.NoError(t, repo.MarkEventsPublished(ctx, []int{events[0].ID, events[1].ID}, publishedAt))
+
+	deleted, err := repo.DeletePublishedEvents(ctx, publishedAt.Add(-time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 0, deleted)
+	deleted, err = repo.DeletePublishedEvents(ctx, publishedAt.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+}
+
+func testWebhooks(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	create := func(ownerID int, events []domain.EventType, postIDs []int) *domain.Webhook {
+		webhook, err := repo.CreateWebhook(ctx, &domain.Webhook{
+			OwnerID:   ownerID,
+			URL:       "https://example.com/hook",
+			Events:    events,
+			PostIDs:   postIDs,
+			Secret:    "0123456789abcdef",
+			CreatedAt: base,
+		})
+		require.NoError(t, err)
+		return webhook
+	}
+	webhookIDs := func(webhooks []*domain.Webhook) []int {
+		return ids(webhooks, func(w *domain.Webhook) int { return w.ID })
+	}
+	all := create(1, []domain.EventType{domain.EventCommentCreated}, nil)
+	watching := create(1, []domain.EventType{domain.EventCommentCreated, domain.EventPostDeleted}, []int{5})
+	other := create(2, []domain.EventType{domain.EventPostCreated}, nil)
+
+	got, err := repo.GetWebhook(ctx, watching.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef", got.Secret)
+	assert.Equal(t, []int{5}, got.PostIDs)
+	assert.Equal(t, []domain.EventType{domain.EventCommentCreated, domain.EventPostDeleted}, got.Events)
+
+	owned, err := repo.GetWebhooks(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{all.ID, watching.ID}, webhookIDs(owned))
+
+	matching, err := repo.GetWebhooksForEvent(ctx, domain.EventCommentCreated, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []int{all.ID, watching.ID}, webhookIDs(matching))
+	matching, err = repo.GetWebhooksForEvent(ctx, domain.EventCommentCreated, 6)
+	require.NoError(t, err)
+	assert.Equal(t, []int{all.ID}, webhookIDs(matching))
+	matching, err = repo.GetWebhooksForEvent(ctx, domain.EventPostCreated, 6)
+	require.NoError(t, err)
+	assert.Equal(t, []int{other.ID}, webhookIDs(matching))
+
+	delivery := func(webhookID int, eventID string) *domain.WebhookDelivery {
+		return &domain.WebhookDelivery{
+			WebhookID:     webhookID,
+			EventID:       eventID,
+			EventType:     domain.EventCommentCreated,
+			Payload:       json.RawMessage(`{"id":"` + eventID + `"}`),
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: time.Now().UTC().Add(-time.Minute),
+			CreatedAt:     time.Now().UTC(),
+		}
+	}
+	require.NoError(t, repo.CreateWebhookDeliveries(ctx, []*domain.WebhookDelivery{
+		delivery(all.ID, "a"), delivery(watching.ID, "a"), delivery(all.ID, "b"),
+	}))
+	// an event is delivered to a webhook once
+	require.NoError(t, repo.CreateWebhookDeliveries(ctx, []*domain.WebhookDelivery{delivery(all.ID, "a")}))
+
+	deliveries, err := repo.GetWebhookDeliveries(ctx, all.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, []string{"b", "a"}, []string{deliveries[0].EventID, deliveries[1].EventID})
+
+	// due deliveries ordered by id, claimed ones wait for the lease
+	claimed, err := repo.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 3)
+	assert.IsIncreasing(t, ids(claimed, func(d *domain.WebhookDelivery) int { return d.ID }))
+	again, err := repo.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	deliveredAt := time.Now().UTC()
+	claimed[0].Status = domain.DeliveryDelivered
+	claimed[0].Attempts = 1
+	claimed[0].ResponseStatus = 200
+	claimed[0].DeliveredAt = &deliveredAt
+	require.NoError(t, repo.UpdateWebhookDelivery(ctx, claimed[0]))
+
+	deliveries, err = repo.GetWebhookDeliveries(ctx, claimed[0].WebhookID, 10, 0)
+	require.NoError(t, err)
+	var updated *domain.WebhookDelivery
+	for _, d := range deliveries {
+		if d.ID == claimed[0].ID {
+			updated = d
+		}
+	}
+	require.NotNil(t, updated)
+	assert.Equal(t, domain.DeliveryDelivered, updated.Status)
+	assert.Equal(t, 1, updated.Attempts)
+	assert.Equal(t, 200, updated.ResponseStatus)
+	assert.NotNil(t, updated.DeliveredAt)
+
+	// deliveries go with their webhook
+	require.NoError(t, repo.DeleteWebhook(ctx, all.ID))
+	deliveries, err = repo.GetWebhookDeliveries(ctx, all.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+	owned, err = repo.GetWebhooks(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{watching.ID}, webhookIDs(owned))
+}
+
+func testNotFound(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	post := createPost(t, repo, "post")
+	comment := createComment(t, repo, post.ID, nil, at(1))
+	missing := 1_000_000
+
+	_, err := repo.GetPost(ctx, missing)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = repo.GetComment(ctx, missing)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = repo.GetReport(ctx, missing)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = repo.GetWebhook(ctx, missing)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+
+	// comments of a missing post or parent
+	_, err = repo.GetCommentsByPost(ctx, missing, 10, 0, true)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = repo.GetCommentsByParent(ctx, missing, 10, 0, true)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	// an empty page of an existing post or parent is not an error
+	comments, err := repo.GetCommentsByPost(ctx, post.ID, 10, 5, true)
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+	comments, err = repo.GetCommentsByParent(ctx, comment.ID, 10, 0, true)
+	assert.NoError(t, err)
+	assert.Empty(t, comments)
+
+	// writes to a missing entity
+	for name, write := range map[string]func() error{
+		"DisableComments": func() error { return repo.DisableComments(ctx, missing) },
+		"EnableComments":  func() error { return repo.EnableComments(ctx, missing) },
+		"HidePost":        func() error { return repo.HidePost(ctx, missing) },
+		"HideComment":     func() error { return repo.HideComment(ctx, missing) },
+		"DeletePost":      func() error { return repo.DeletePost(ctx, missing) },
+		"DeleteComment":   func() error { return repo.DeleteComment(ctx, missing) },
+		"DeleteWebhook":   func() error { return repo.DeleteWebhook(ctx, missing) },
+		"ResolveReport": func() error {
+			_, err := repo.ResolveReport(ctx, missing, domain.ReportDismissed, &domain.ModerationRecord{
+				ReportID:    missing,
+				ModeratorID: 1,
+				Action:      domain.ActionDismiss,
+				TargetType:  domain.TargetPost,
+				TargetID:    post.ID,
+				CreatedAt:   at(2),
+			})
+			return err
+		},
+	} {
+		assert.ErrorIs(t, write(), repository.ErrNotFound, name)
+	}
+
+	// writes referring to a missing entity
+	_, err = repo.CreateComment(ctx, &domain.Comment{PostID: missing, AuthorID: 2, Content: "comment", CreatedAt: at(2)})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	_, err = repo.CreateComment(ctx, &domain.Comment{PostID: post.ID, ParentID: &missing, AuthorID: 2, Content: "comment", CreatedAt: at(2)})
+	assert.ErrorIs(t, err, repository.ErrNotFound)
+	assert.Equal(t, []int{comment.ID}, postComments(t, repo, post.ID, true))
+}
+
+func testWithTx(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	var post *domain.Post
+	err := repo.WithTx(ctx, func(tx repository.Repository) error {
+		post = createPost(t, tx, "post")
+		// a nested unit of work joins the outer one and sees its writes
+		return tx.WithTx(ctx, func(nested repository.Repository) error {
+			if _, err := nested.GetPost(ctx, post.ID); err != nil {
+				return fmt.Errorf("post is not visible in the nested unit of work: %w", err)
+			}
+			createComment(t, nested, post.ID, nil, at(1))
+			return nil
+		})
+	})
+	require.NoError(t, err)
+	assert.Len(t, postComments(t, repo, post.ID, false), 1)
+
+	errFailed := errors.New("failed")
+	err = repo.WithTx(ctx, func(tx repository.Repository) error {
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+}
+
+func testConcurrentWrites(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	const writers, perWriter = 10, 5
+	post := createPost(t, repo, "post")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*perWriter)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				// read and write in one unit of work, like the resolvers do
+				errs <- repo.WithTx(ctx, func(tx repository.Repository) error {
+					if _, err := tx.GetPost(ctx, post.ID); err != nil {
+						return err
+					}
+					_, err := tx.CreateComment(ctx, &domain.Comment{
+						PostID:    post.ID,
+						AuthorID:  2,
+						Content:   "comment",
+						CreatedAt: at(i),
+					})
+					return err
+				})
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	commentIDs := postComments(t, repo, post.ID, false)
+	assert.Len(t, commentIDs, writers*perWriter)
+	unique := make(map[int]struct{}, len(commentIDs))
+	for _, id := range commentIDs {
+		unique[id] = struct{}{}
+	}
+	assert.Len(t, unique, writers*perWriter)
+}
+
+func testCommentsRaceDisable(t *testing.T, repo repository.Repository) {
+	ctx := context.Background()
+
+	const writers, perWriter = 10, 5
+	post := createPost(t, repo, "post")
+	errCommentsDisabled := errors.New("comments are disabled")
+
+	var wg sync.WaitGroup
+	errs := make(chan error, writers*perWriter)
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWriter; i++ {
+				// check and insert in one unit of work, like the resolvers do
+				errs <- repo.WithTx(ctx, func(tx repository.Repository) error {
+					got, err := tx.GetPost(ctx, post.ID)
+					if err != nil {
+						return err
+					}
+					if got.CommentsDisabled {
+						return errCommentsDisabled
+					}
+					_, err = tx.CreateComment(ctx, &domain.Comment{PostID: post.ID, AuthorID: 2, Content: "comment", CreatedAt: at(i)})
+					return err
+				})
+			}
+		}()
+	}
+
+	err := repo.WithTx(ctx, func(tx repository.Repository) error {
+		if _, err := tx.GetPost(ctx, post.ID); err != nil {
+			return err
+		}
+		return tx.DisableComments(ctx, post.ID)
+	})
+	require.NoError(t, err)
+	disabledWith := len(postComments(t, repo, post.ID, false))
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if !errors.Is(err, errCommentsDisabled) {
+			require.NoError(t, err)
+		}
+	}
+
+	// no comment got in after comments were disabled
+	assert.Len(t, postComments(t, repo, post.ID, false), disabledWith)
+}
@@ -0,0 +1,144 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+)
+
+const insertComment = `
+INSERT INTO comments
+(post_id, parent_id, author_id, content, created_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id
+`
+
+func (q *Queries) CreateComment(ctx context.Context, comment *domain.Comment) (*domain.Comment, error) {
+	row := q.db.QueryRowContext(ctx, insertComment,
+		comment.PostID, comment.ParentID, comment.AuthorID, comment.Content, comment.CreatedAt.UTC())
+
+	if err := row.Scan(&comment.ID); err != nil {
+		return nil, fmt.Errorf("can't scan comment id: %w", classify(err))
+	}
+
+	return comment, nil
+}
+
+const selectCommentsByPost = `
+SELECT id, post_id, parent_id, author_id, content, created_at, hidden
+FROM comments
+WHERE post_id = $1 AND ($4 OR NOT hidden)
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3
+`
+
+func (q *Queries) GetCommentsByPost(ctx context.Context, postID int, limit, offset int, includeHidden bool) ([]*domain.Comment, error) {
+	comments, err := q.selectComments(ctx, selectCommentsByPost, postID, limit, offset, includeHidden)
+	if err != nil {
+		return nil, err
+	}
+
+	// an empty page of a missing post is an error, the post is looked up only then
+	if len(comments) == 0 {
+		if err := q.exists(ctx, postExists, postID); err != nil {
+			return nil, fmt.Errorf("can't check if post exists: %w", err)
+		}
+	}
+
+	return comments, nil
+}
+
+const selectCommentsByParent = `
+SELECT id, post_id, parent_id, author_id, content, created_at, hidden
+FROM comments
+WHERE parent_id = $1 AND ($4 OR NOT hidden)
+ORDER BY created_at DESC, id DESC
+LIMIT $2 OFFSET $3
+`
+
+func (q *Queries) GetCommentsByParent(ctx context.Context, parentId int, limit, offset int, includeHidden bool) ([]*domain.Comment, error) {
+	comments, err := q.selectComments(ctx, selectCommentsByParent, parentId, limit, offset, includeHidden)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(comments) == 0 {
+		if err := q.exists(ctx, commentExists, parentId); err != nil {
+			return nil, fmt.Errorf("can't check if comment exists: %w", err)
+		}
+	}
+
+	return comments, nil
+}
+
+func (q *Queries) selectComments(ctx context.Context, query string, args ...any) ([]*domain.Comment, error) {
+	rows, err := q.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("can't select comments: %w", err)
+	}
+	defer rows.Close()
+
+	var comments []*domain.Comment
+	for rows.Next() {
+		var c domain.Comment
+		if err := rows.Scan(&c.ID, &c.PostID, &c.ParentID, &c.AuthorID, &c.Content, &c.CreatedAt, &c.Hidden); err != nil {
+			return nil, fmt.Errorf("can't scan comment: %w", err)
+		}
+		comments = append(comments, &c)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading rows: %w", err)
+	}
+
+	return comments, nil
+}
+
+const commentExists = `
+SELECT EXISTS(SELECT 1 FROM comments WHERE id = $1)
+`
+
+const selectComment = `
+SELECT id, post_id, parent_id, author_id, content, created_at, hidden
+FROM comments
+WHERE id = $1
+`
+
+func (q *Queries) GetComment(ctx context.Context, id int) (*domain.Comment, error) {
+	row := q.db.QueryRowContext(ctx, selectComment, id)
+
+	var c domain.Comment
+	if err := row.Scan(&c.ID, &c.PostID, &c.ParentID, &c.AuthorID, &c.Content, &c.CreatedAt, &c.Hidden); err != nil {
+		return nil, fmt.Errorf("can't scan comment: %w", classify(err))
+	}
+
+	return &c, nil
+}
+
+const updateHideComment = `
+UPDATE comments
+SET hidden = TRUE
+WHERE id = $1
+`
+
+func (q *Queries) HideComment(ctx context.Context, id int) error {
+	if err := execOne(ctx, q.db, updateHideComment, id); err != nil {
+		return fmt.Errorf("can't update row to hide comment: %w", err)
+	}
+
+	return nil
+}
+
+const deleteComment = `
+DELETE FROM comments
+WHERE id = $1
+`
+
+func (q *Queries) DeleteComment(ctx context.Context, id int) error {
+	if err := execOne(ctx, q.db, deleteComment, id); err != nil {
+		return fmt.Errorf("can't delete comment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+)
+
+const selectLastID = `
+SELECT COALESCE((SELECT seq FROM sqlite_sequence WHERE name = $1), 0)
+`
+
+// nextIDs returns the next n ids of the table. AUTOINCREMENT keeps the largest id ever used
+// in sqlite_sequence and updates it on inserts with an explicit id, so the ids are not reused
+// and they stay reserved while the transaction holds the write lock.
+func (q *Queries) nextIDs(ctx context.Context, table string, n int) ([]int, error) {
+	var last int
+	if err := q.db.QueryRowContext(ctx, selectLastID, table).Scan(&last); err != nil {
+		return nil, fmt.Errorf("can't get last %s id: %w", table, err)
+	}
+
+	ids := make([]int, n)
+	for i := range ids {
+		ids[i] = last + i + 1
+	}
+	return ids, nil
+}
+
+const insertImportedPost = `
+INSERT INTO posts
+(id, title, content, author_id, created_at, comments_disabled, hidden)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+const insertImportedComment = `
+INSERT INTO comments
+(id, post_id, parent_id, author_id, content, created_at, hidden)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+// ImportPosts inserts the posts and comments under the next ids,
+// it must run in a transaction so that the ids stay reserved
+func (q *Queries) ImportPosts(ctx context.Context, posts []*domain.Post, comments []*domain.Comment) error {
+	postIDs, err := q.nextIDs(ctx, "posts", len(posts))
+	if err != nil {
+		return err
+	}
+	commentIDs, err := q.nextIDs(ctx, "comments", len(comments))
+	if err != nil {
+		return err
+	}
+	posts, comments, err = repository.Remap(posts, comments, postIDs, commentIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range posts {
+		_, err := q.db.ExecContext(ctx, insertImportedPost,
+			p.ID, p.Title, p.Content, p.AuthorID, p.CreatedAt.UTC(), p.CommentsDisabled, p.Hidden)
+		if err != nil {
+			return fmt.Errorf("can't insert post: %w", classify(err))
+		}
+	}
+	for _, c := range comments {
+		_, err := q.db.ExecContext(ctx, insertImportedComment,
+			c.ID, c.PostID, c.ParentID, c.AuthorID, c.Content, c.CreatedAt.UTC(), c.Hidden)
+		if err != nil {
+			return fmt.Errorf("can't insert comment: %w", classify(err))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+)
+
+const insertReport = `
+INSERT INTO reports
+(target_type, target_id, reporter_id, reason, status, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id
+`
+
+func (q *Queries) CreateReport(ctx context.Context, report *domain.Report) (*domain.Report, error) {
+	row := q.db.QueryRowContext(ctx, insertReport,
+		report.TargetType, report.TargetID, report.ReporterID, report.Reason, report.Status, report.CreatedAt)
+
+	if err := row.Scan(&report.ID); err != nil {
+		return nil, fmt.Errorf("can't scan report id: %w", err)
+	}
+
+	return report, nil
+}
+
+const selectReport = `
+SELECT id, target_type, target_id, reporter_id, reason, status, created_at, resolved_at
+FROM reports
+WHERE id = $1
+`
+
+func (q *Queries) GetReport(ctx context.Context, id int) (*domain.Report, error) {
+	row := q.db.QueryRowContext(ctx, selectReport, id)
+
+	var r domain.Report
+	err := row.Scan(&r.ID, &r.TargetType, &r.TargetID, &r.ReporterID, &r.Reason, &r.Status, &r.CreatedAt, &r.ResolvedAt)
+	if err != nil {
+		return nil, fmt.Errorf("can't scan report row: %w", classify(err))
+	}
+
+	return &r, nil
+}
+
+const selectReports = `
+SELECT id, target_type, target_id, reporter_id, reason, status, created_at, resolved_at
+FROM reports
+WHERE status = $1 AND id > $3
+ORDER BY id
+LIMIT $2
+`
+
+func (q *Queries) GetReports(ctx context.Context, status domain.ReportStatus, limit, afterID int) ([]*domain.Report, error) {
+	rows, err := q.db.QueryContext(ctx, selectReports, status, limit, afterID)
+	if err != nil {
+		return nil, fmt.Errorf("can't select reports: %w", err)
+	}
+	defer rows.Close()
+
+	reports := make([]*domain.Report, 0)
+	for rows.Next() {
+		var r domain.Report
+		err := rows.Scan(&r.ID, &r.TargetType, &r.TargetID, &r.ReporterID, &r.Reason, &r.Status, &r.CreatedAt, &r.ResolvedAt)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan report row: %w", err)
+		}
+		reports = append(reports, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading rows: %w", err)
+	}
+
+	return reports, nil
+}
+
+const updateResolveReport = `
+UPDATE reports
+SET status = $2, resolved_at = $3
+WHERE id = $1
+`
+
+const insertModerationRecord = `
+INSERT INTO moderation_records
+(report_id, moderator_id, action, target_type, target_id, author_id, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id
+`
+
+// ResolveReport updates the report and writes the audit record,
+// the caller runs it in a transaction as sql.Tx has no savepoints
+func (q *Queries) ResolveReport(
+	ctx context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord,
+) (*domain.ModerationRecord, error) {
+	if err := execOne(ctx, q.db, updateResolveReport, reportID, status, record.CreatedAt); err != nil {
+		return nil, fmt.Errorf("can't update report status: %w", err)
+	}
+
+	row := q.db.QueryRowContext(ctx, insertModerationRecord, reportID, record.ModeratorID, record.Action,
+		record.TargetType, record.TargetID, record.AuthorID, record.CreatedAt)
+	if err := row.Scan(&record.ID); err != nil {
+		return nil, fmt.Errorf("can't scan moderation record id: %w", err)
+	}
+
+	return record, nil
+}
+
+const selectModerationRecords = `
+SELECT id, report_id, moderator_id, action, target_type, target_id, author_id, created_at
+FROM moderation_records
+ORDER BY id DESC
+LIMIT $1 OFFSET $2
+`
+
+func (q *Queries) GetModerationRecords(ctx context.Context, limit, offset int) ([]*domain.ModerationRecord, error) {
+	rows, err := q.db.QueryContext(ctx, selectModerationRecords, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("can't select moderation records: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]*domain.ModerationRecord, 0)
+	for rows.Next() {
+		var r domain.ModerationRecord
+		err := rows.Scan(&r.ID, &r.ReportID, &r.ModeratorID, &r.Action, &r.TargetType, &r.TargetID, &r.AuthorID, &r.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan moderation record row: %w", err)
+		}
+		records = append(records, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading rows: %w", err)
+	}
+
+	return records, nil
+}
+
+const insertBannedAuthor = `
+INSERT INTO banned_authors (author_id)
+VALUES ($1)
+ON CONFLICT DO NOTHING
+`
+
+func (q *Queries) BanAuthor(ctx context.Context, authorID int) error {
+	if _, err := q.db.ExecContext(ctx, insertBannedAuthor, authorID); err != nil {
+		return fmt.Errorf("can't insert banned author: %w", err)
+	}
+
+	return nil
+}
+
+const authorBanned = `
+SELECT EXISTS(SELECT 1 FROM banned_authors WHERE author_id = $1)
+`
+
+func (q *Queries) IsBanned(ctx context.Context, authorID int) (bool, error) {
+	var banned bool
+	if err := q.db.QueryRowContext(ctx, authorBanned, authorID).Scan(&banned); err != nil {
+		return false, fmt.Errorf("can't check if author is banned: %w", err)
+	}
+
+	return banned, nil
+}
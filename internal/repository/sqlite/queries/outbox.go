@@ -0,0 +1,106 @@
+package queries
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+)
+
+const insertEvent = `
+INSERT INTO outbox
+(dedup_id, type, post_id, payload, created_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id
+`
+
+func (q *Queries) CreateEvent(ctx context.Context, event *domain.Event) (*domain.Event, error) {
+	row := q.db.QueryRowContext(ctx, insertEvent,
+		event.DedupID, event.Type, event.PostID, string(event.Payload), event.CreatedAt)
+
+	if err := row.Scan(&event.ID); err != nil {
+		return nil, fmt.Errorf("can't scan event id: %w", classify(err))
+	}
+
+	return event, nil
+}
+
+// claimEvents leases the oldest unpublished events, SQLite has a single writer so the update is atomic
+const claimEvents = `
+UPDATE outbox
+SET locked_until = $3
+WHERE id IN (
+    SELECT id
+    FROM outbox
+    WHERE published_at IS NULL AND (locked_until IS NULL OR locked_until < $2)
+    ORDER BY id
+    LIMIT $1
+)
+RETURNING id, dedup_id, type, post_id, payload, created_at
+`
+
+func (q *Queries) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]*domain.Event, error) {
+	now := time.Now().UTC()
+	rows, err := q.db.QueryContext(ctx, claimEvents, limit, now, now.Add(lease))
+	if err != nil {
+		return nil, fmt.Errorf("can't claim events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]*domain.Event, 0)
+	for rows.Next() {
+		var e domain.Event
+		if err := rows.Scan(&e.ID, &e.DedupID, &e.Type, &e.PostID, (*[]byte)(&e.Payload), &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("can't scan event row: %w", err)
+		}
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading rows: %w", err)
+	}
+
+	// RETURNING doesn't keep the order of the subquery
+	sort.Slice(events, func(i, j int) bool { return events[i].ID < events[j].ID })
+
+	return events, nil
+}
+
+const updatePublishEvents = `
+UPDATE outbox
+SET published_at = $2, locked_until = NULL
+WHERE id IN (SELECT value FROM json_each($1))
+`
+
+func (q *Queries) MarkEventsPublished(ctx context.Context, ids []int, publishedAt time.Time) error {
+	idList, err := toJSON(ids)
+	if err != nil {
+		return err
+	}
+
+	if _, err := q.db.ExecContext(ctx, updatePublishEvents, idList, publishedAt.UTC()); err != nil {
+		return fmt.Errorf("can't mark events published: %w", err)
+	}
+
+	return nil
+}
+
+const deletePublishedEvents = `
+DELETE FROM outbox
+WHERE published_at < $1
+`
+
+func (q *Queries) DeletePublishedEvents(ctx context.Context, before time.Time) (int, error) {
+	res, err := q.db.ExecContext(ctx, deletePublishedEvents, before.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("can't delete published events: %w", err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("can't get deleted events count: %w", err)
+	}
+
+	return int(n), nil
+}
@@ -0,0 +1,153 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+)
+
+const selectPosts = `
+SELECT id, title, content, author_id, created_at, comments_disabled, hidden
+FROM posts
+ORDER BY id;
+`
+
+func (q *Queries) GetPosts(ctx context.Context) ([]*domain.Post, error) {
+	rows, err := q.db.QueryContext(ctx, selectPosts)
+	if err != nil {
+		return nil, fmt.Errorf("can't select all posts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPosts(rows)
+}
+
+const selectPostsAfter = `
+SELECT id, title, content, author_id, created_at, comments_disabled, hidden
+FROM posts
+WHERE id > $2
+ORDER BY id
+LIMIT $1;
+`
+
+func (q *Queries) GetPostsAfter(ctx context.Context, limit, afterID int) ([]*domain.Post, error) {
+	rows, err := q.db.QueryContext(ctx, selectPostsAfter, limit, afterID)
+	if err != nil {
+		return nil, fmt.Errorf("can't select posts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanPosts(rows)
+}
+
+func scanPosts(rows *sql.Rows) ([]*domain.Post, error) {
+	posts := make([]*domain.Post, 0)
+	for rows.Next() {
+		var post domain.Post
+		err := rows.Scan(&post.ID, &post.Title, &post.Content, &post.AuthorID, &post.CreatedAt, &post.CommentsDisabled, &post.Hidden)
+		if err != nil {
+			return nil, fmt.Errorf("can't scan post row: %w", err)
+		}
+		posts = append(posts, &post)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error while reading rows: %w", err)
+	}
+
+	return posts, nil
+}
+
+const selectPost = `
+SELECT id, title, content, author_id, created_at, comments_disabled, hidden
+FROM posts
+WHERE id = $1;
+`
+
+func (q *Queries) GetPost(ctx context.Context, id int) (*domain.Post, error) {
+	row := q.db.QueryRowContext(ctx, selectPost, id)
+
+	var post domain.Post
+	err := row.Scan(&post.ID, &post.Title, &post.Content, &post.AuthorID, &post.CreatedAt, &post.CommentsDisabled, &post.Hidden)
+	if err != nil {
+		return nil, fmt.Errorf("can't scan post row: %w", classify(err))
+	}
+
+	return &post, nil
+}
+
+const insertPost = `
+INSERT INTO posts
+(title, content, author_id, created_at, comments_disabled)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id
+`
+
+func (q *Queries) CreatePost(ctx context.Context, post *domain.Post) (*domain.Post, error) {
+	row := q.db.QueryRowContext(ctx, insertPost, post.Title, post.Content, post.AuthorID, post.CreatedAt.UTC(), post.CommentsDisabled)
+	if err := row.Scan(&post.ID); err != nil {
+		return nil, fmt.Errorf("can't scan post id: %w", err)
+	}
+
+	return post, nil
+}
+
+const updateDisableComments = `
+UPDATE posts
+SET comments_disabled = TRUE
+WHERE id = $1
+`
+
+func (q *Queries) DisableComments(ctx context.Context, postID int) error {
+	if err := execOne(ctx, q.db, updateDisableComments, postID); err != nil {
+		return fmt.Errorf("can't update row to disable comments: %w", err)
+	}
+
+	return nil
+}
+
+const updateEnableComments = `
+UPDATE posts
+SET comments_disabled = FALSE
+WHERE id = $1
+`
+
+func (q *Queries) EnableComments(ctx context.Context, postID int) error {
+	if err := execOne(ctx, q.db, updateEnableComments, postID); err != nil {
+		return fmt.Errorf("can't update row to enable comments: %w", err)
+	}
+
+	return nil
+}
+
+const postExists = `
+SELECT EXISTS(SELECT 1 FROM posts WHERE id = $1)
+`
+
+const updateHidePost = `
+UPDATE posts
+SET hidden = TRUE
+WHERE id = $1
+`
+
+func (q *Queries) HidePost(ctx context.Context, id int) error {
+	if err := execOne(ctx, q.db, updateHidePost, id); err != nil {
+		return fmt.Errorf("can't update row to hide post: %w", err)
+	}
+
+	return nil
+}
+
+const deletePost = `
+DELETE FROM posts
+WHERE id = $1
+`
+
+func (q *Queries) DeletePost(ctx context.Context, id int) error {
+	if err := execOne(ctx, q.db, deletePost, id); err != nil {
+		return fmt.Errorf("can't delete post: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package queries
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// DB is implemented by both sql.DB and sql.Tx, so the queries run inside or outside a transaction
+type DB interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// Queries implements the SQL queries for sqliteRepo
+type Queries struct {
+	db DB
+}
+
+// New creates a new instance of Queries to be embedded in sqliteRepo
+func New(db DB) *Queries {
+	return &Queries{db: db}
+}
+
+// classify wraps the errors of the repository contract: a missing row or a reference to one
+// is repository.ErrNotFound, a duplicate unique key is repository.ErrConflict
+func classify(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %w", repository.ErrNotFound, err)
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return fmt.Errorf("%w: %w", repository.ErrNotFound, err)
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE:
+			return fmt.Errorf("%w: %w", repository.ErrConflict, err)
+		}
+	}
+
+	return err
+}
+
+// execOne runs a statement on the row with the given id, it returns repository.ErrNotFound if there is no such row
+func execOne(ctx context.Context, db DB, query string, id int, args ...any) error {
+	res, err := db.ExecContext(ctx, query, append([]any{id}, args...)...)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// exists returns repository.ErrNotFound if the query selecting EXISTS finds nothing
+func (q *Queries) exists(ctx context.Context, query string, id int) error {
+	var exists bool
+	if err := q.db.QueryRowContext(ctx, query, id).Scan(&exists); err != nil {
+		return err
+	}
+	if !exists {
+		return repository.ErrNotFound
+	}
+
+	return nil
+}
+
+// Times are stored as text in UTC, so they compare in order. Every time written or passed
+// to a comparison must be converted to UTC, imported times may come with any offset.
+
+// toJSON encodes a value stored in a JSON column, SQLite has no arrays
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("can't encode json column: %w", err)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,147 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/sqlite/queries"
+	"github.com/golang-migrate/migrate/v4"
+	_ "modernc.org/sqlite"
+)
+
+// sqliteRepo is a SQLite implementation of repository.Repository
+type sqliteRepo struct {
+	*queries.Queries // SQL queries
+	db               *sql.DB
+	inTx             bool // the queries run in a transaction started by WithTx
+}
+
+// busyTimeout is how long a write waits for the database lock held by another connection, in milliseconds
+const busyTimeout = 5000
+
+// New creates a new instance of the repository based on the database opened with Open
+func New(db *sql.DB) repository.Repository {
+	return &sqliteRepo{
+		Queries: queries.New(db),
+		db:      db,
+	}
+}
+
+// Open opens the database file, creating it if needed. Foreign keys are enforced,
+// the journal is in WAL mode so reads don't wait for writes, and every transaction
+// takes the write lock when it begins, so transactions are serializable and never deadlock.
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", fmt.Sprintf("busy_timeout(%d)", busyTimeout))
+	params.Set("_txlock", "immediate")
+
+	db, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to sqlite database: %w", err)
+	}
+
+	return db, nil
+}
+
+// WithTx runs fn in a transaction, a failed fn rolls back everything it wrote
+func (r *sqliteRepo) WithTx(ctx context.Context, fn func(repo repository.Repository) error) error {
+	if r.inTx {
+		return fn(r)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	txRepo := &sqliteRepo{
+		Queries: queries.New(tx),
+		db:      r.db,
+		inTx:    true,
+	}
+	if err := fn(txRepo); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ImportPosts inserts the posts and comments in one transaction
+func (r *sqliteRepo) ImportPosts(ctx context.Context, posts []*domain.Post, comments []*domain.Comment) error {
+	return r.WithTx(ctx, func(repo repository.Repository) error {
+		return repo.(*sqliteRepo).Queries.ImportPosts(ctx, posts, comments)
+	})
+}
+
+// ResolveReport updates the report and writes the audit record in one transaction
+func (r *sqliteRepo) ResolveReport(
+	ctx context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord,
+) (*domain.ModerationRecord, error) {
+	var saved *domain.ModerationRecord
+	err := r.WithTx(ctx, func(repo repository.Repository) error {
+		var err error
+		saved, err = repo.(*sqliteRepo).Queries.ResolveReport(ctx, reportID, status, record)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return saved, nil
+}
+
+// CreateWebhookDeliveries creates the deliveries in one transaction
+func (r *sqliteRepo) CreateWebhookDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) error {
+	return r.WithTx(ctx, func(repo repository.Repository) error {
+		return repo.(*sqliteRepo).Queries.CreateWebhookDeliveries(ctx, deliveries)
+	})
+}
+
+// ProcessMigration runs the migration up on the database file
+func ProcessMigration(migrationURL string, path string) error {
+	migration, err := migrate.New(migrationURL, "sqlite://"+path)
+	if err != nil {
+		return fmt.Errorf("failed to create new migration: %w", err)
+	}
+	defer migration.Close()
+
+	if err = migration.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to migrate up: %w", err)
+	}
+
+	return nil
+}
+
+const getMigrationVersion = `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+// CheckMigrations returns an error unless the database is migrated to at least the given version
+func CheckMigrations(ctx context.Context, db *sql.DB, version uint) error {
+	var current int64
+	var dirty bool
+	if err := db.QueryRowContext(ctx, getMigrationVersion).Scan(&current, &dirty); err != nil {
+		return fmt.Errorf("can't get migration version: %w", err)
+	}
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", current)
+	}
+	if current < int64(version) {
+		return fmt.Errorf("database is at migration %d, expected %d", current, version)
+	}
+	return nil
+}
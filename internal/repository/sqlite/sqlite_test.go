@@ -0,0 +1,238 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/repotest"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const migrationPath = "file://../../../migrations/sqlite"
+
+func newRepository(t *testing.T) repository.Repository {
+	path := filepath.Join(t.TempDir(), "posts.db")
+	require.NoError(t, ProcessMigration(migrationPath, path))
+
+	db, err := Open(context.Background(), path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return New(db)
+}
+
+func TestSQLite_MockData(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+
+	posts, err := repo.GetPosts(ctx)
+	require.NoError(t, err)
+	require.Len(t, posts, 3)
+	assert.Equal(t, []int{1, 2, 3}, []int{posts[0].ID, posts[1].ID, posts[2].ID})
+
+	replies, err := repo.GetCommentsByParent(ctx, 1, 10, 0, false)
+	require.NoError(t, err)
+	require.Len(t, replies, 1)
+	assert.Equal(t, 7, replies[0].ID)
+}
+
+func TestSQLite_CommentThreads(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	post, err := repo.CreatePost(ctx, &domain.Post{Title: "t", Content: "c", AuthorID: 1, CreatedAt: now})
+	require.NoError(t, err)
+
+	root, err := repo.CreateComment(ctx, &domain.Comment{PostID: post.ID, AuthorID: 1, Content: "root", CreatedAt: now})
+	require.NoError(t, err)
+	reply, err := repo.CreateComment(ctx, &domain.Comment{
+		PostID: post.ID, ParentID: &root.ID, AuthorID: 2, Content: "reply", CreatedAt: now.Add(time.Second),
+	})
+	require.NoError(t, err)
+
+	got, err := repo.GetComment(ctx, reply.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.ParentID)
+	assert.Equal(t, root.ID, *got.ParentID)
+
+	comments, err := repo.GetCommentsByPost(ctx, post.ID, 10, 0, false)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, reply.ID, comments[0].ID, "newest first")
+	assert.Nil(t, comments[1].ParentID)
+
+	require.NoError(t, repo.HideComment(ctx, reply.ID))
+	replies, err := repo.GetCommentsByParent(ctx, root.ID, 10, 0, false)
+	require.NoError(t, err)
+	assert.Empty(t, replies)
+	replies, err = repo.GetCommentsByParent(ctx, root.ID, 10, 0, true)
+	require.NoError(t, err)
+	assert.Len(t, replies, 1)
+
+	require.NoError(t, repo.DeleteComment(ctx, root.ID))
+	_, err = repo.GetComment(ctx, reply.ID)
+	assert.ErrorIs(t, err, repository.ErrNotFound, "replies are deleted with their parent")
+}
+
+func TestSQLite_WithTx_Rollback(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+
+	err := repo.WithTx(ctx, func(tx repository.Repository) error {
+		if _, err := tx.CreatePost(ctx, &domain.Post{Title: "t", Content: "c", AuthorID: 1, CreatedAt: time.Now().UTC()}); err != nil {
+			return err
+		}
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+
+	posts, err := repo.GetPosts(ctx)
+	require.NoError(t, err)
+	assert.Len(t, posts, 3)
+}
+
+func TestSQLite_ImportPosts_Offsets(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+	base := time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	// the older comment has the greater local time, as text it would sort after the newer one
+	older := base.Add(-time.Hour).In(moscow)
+	newer := base
+
+	posts := []*domain.Post{{ID: 1, Title: "t", Content: "c", AuthorID: 1, CreatedAt: older}}
+	comments := []*domain.Comment{
+		{ID: 1, PostID: 1, AuthorID: 1, Content: "older", CreatedAt: older},
+		{ID: 2, PostID: 1, AuthorID: 2, Content: "newer", CreatedAt: newer},
+	}
+	require.NoError(t, repo.ImportPosts(ctx, posts, comments))
+
+	all, err := repo.GetPosts(ctx)
+	require.NoError(t, err)
+	imported := all[len(all)-1]
+	assert.True(t, older.Equal(imported.CreatedAt))
+
+	thread, err := repo.GetCommentsByPost(ctx, imported.ID, 10, 0, false)
+	require.NoError(t, err)
+	require.Len(t, thread, 2)
+	assert.Equal(t, "newer", thread[0].Content, "newest first")
+	assert.Equal(t, "older", thread[1].Content)
+	assert.True(t, older.Equal(thread[1].CreatedAt))
+}
+
+func TestSQLite_Outbox(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"a", "b", "c"} {
+		_, err := repo.CreateEvent(ctx, &domain.Event{
+			DedupID: id, Type: domain.EventCommentCreated, PostID: 1, Payload: []byte(`{"id":1}`), CreatedAt: time.Now().UTC(),
+		})
+		require.NoError(t, err)
+	}
+
+	claimed, err := repo.ClaimEvents(ctx, 2, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	assert.Equal(t, "a", claimed[0].DedupID)
+	assert.JSONEq(t, `{"id":1}`, string(claimed[0].Payload))
+
+	again, err := repo.ClaimEvents(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, again, 1, "leased events are not claimed again")
+	assert.Equal(t, "c", again[0].DedupID)
+
+	publishedAt := time.Now().Add(-time.Hour)
+	require.NoError(t, repo.MarkEventsPublished(ctx, []int{claimed[0].ID, claimed[1].ID}, publishedAt))
+
+	n, err := repo.DeletePublishedEvents(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+}
+
+func TestSQLite_Webhooks(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+
+	all, err := repo.CreateWebhook(ctx, &domain.Webhook{
+		OwnerID: 1, URL: "http://a", Events: []domain.EventType{domain.EventCommentCreated}, Secret: "s", CreatedAt: time.Now().UTC(),
+	})
+	require.NoError(t, err)
+	_, err = repo.CreateWebhook(ctx, &domain.Webhook{
+		OwnerID: 1, URL: "http://b", Events: []domain.EventType{domain.EventCommentCreated}, PostIDs: []int{2}, Secret: "s",
+		CreatedAt: time.Now().UTC(),
+	})
+	require.NoError(t, err)
+
+	matching, err := repo.GetWebhooksForEvent(ctx, domain.EventCommentCreated, 1)
+	require.NoError(t, err)
+	require.Len(t, matching, 1)
+	assert.Equal(t, all.ID, matching[0].ID)
+
+	delivery := &domain.WebhookDelivery{
+		WebhookID: all.ID, EventID: "e", EventType: domain.EventCommentCreated, Payload: []byte(`{}`),
+		Status: domain.DeliveryPending, NextAttemptAt: time.Now().UTC(), CreatedAt: time.Now().UTC(),
+	}
+	require.NoError(t, repo.CreateWebhookDeliveries(ctx, []*domain.WebhookDelivery{delivery, delivery}))
+
+	claimed, err := repo.ClaimWebhookDeliveries(ctx, 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1, "an event is delivered once per webhook")
+
+	claimed[0].Status = domain.DeliveryDelivered
+	claimed[0].Attempts = 1
+	deliveredAt := time.Now().UTC()
+	claimed[0].DeliveredAt = &deliveredAt
+	require.NoError(t, repo.UpdateWebhookDelivery(ctx, claimed[0]))
+
+	deliveries, err := repo.GetWebhookDeliveries(ctx, all.ID, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, domain.DeliveryDelivered, deliveries[0].Status)
+	assert.NotNil(t, deliveries[0].DeliveredAt)
+
+	require.NoError(t, repo.DeleteWebhook(ctx, all.ID))
+	deliveries, err = repo.GetWebhookDeliveries(ctx, all.ID, 10, 0)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
+
+func TestSQLite_ConcurrentWrites(t *testing.T) {
+	repo := newRepository(t)
+	ctx := context.Background()
+
+	const writers = 20
+	errs := make(chan error, writers)
+	for i := 0; i < writers; i++ {
+		go func() {
+			errs <- repo.WithTx(ctx, func(tx repository.Repository) error {
+				post, err := tx.CreatePost(ctx, &domain.Post{Title: "t", Content: "c", AuthorID: 1, CreatedAt: time.Now().UTC()})
+				if err != nil {
+					return err
+				}
+				_, err = tx.CreateComment(ctx, &domain.Comment{PostID: post.ID, AuthorID: 1, Content: "c", CreatedAt: time.Now().UTC()})
+				return err
+			})
+		}()
+	}
+	for i := 0; i < writers; i++ {
+		assert.NoError(t, <-errs)
+	}
+
+	posts, err := repo.GetPosts(ctx)
+	require.NoError(t, err)
+	assert.Len(t, posts, 3+writers)
+}
+
+func TestSQLite_Conformance(t *testing.T) {
+	repotest.Run(t, newRepository)
+}
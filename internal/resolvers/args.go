@@ -1,11 +1,18 @@
 package resolvers
 
+import "github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+
 /*
 	GraphQL arguments for queries and mutations
 */
 
+type PostsArgs struct {
+	ViewerID int `json:"viewerId"`
+}
+
 type PostArgs struct {
-	ID int `json:"id"`
+	ID       int `json:"id"`
+	ViewerID int `json:"viewerId"`
 }
 
 type CreatePostArgs struct {
@@ -26,9 +33,60 @@ type GetCommentsArgs struct {
 	ParentID *int `json:"parentId"`
 	Limit    int  `json:"limit"`
 	Offset   int  `json:"offset"`
+	ViewerID int  `json:"viewerId"`
 }
 
 type DisableCommentsArgs struct {
 	PostID   int `json:"postId"`
 	AuthorId int `json:"authorId"`
 }
+
+type ReportArgs struct {
+	TargetType domain.TargetType `json:"targetType"`
+	TargetID   int               `json:"targetId"`
+	ReporterID int               `json:"reporterId"`
+	Reason     string            `json:"reason"`
+}
+
+type ModerationQueueArgs struct {
+	ModeratorID int                 `json:"moderatorId"`
+	Status      domain.ReportStatus `json:"status"`
+	First       int                 `json:"first"`
+	After       string              `json:"after"`
+}
+
+type ResolveReportArgs struct {
+	ReportID    int                     `json:"reportId"`
+	ModeratorID int                     `json:"moderatorId"`
+	Action      domain.ModerationAction `json:"action"`
+}
+
+type ModerationLogArgs struct {
+	ModeratorID int `json:"moderatorId"`
+	Limit       int `json:"limit"`
+	Offset      int `json:"offset"`
+}
+
+type RegisterWebhookArgs struct {
+	OwnerID int                `json:"ownerId"`
+	URL     string             `json:"url"`
+	Events  []domain.EventType `json:"events"`
+	PostIDs []int              `json:"postIds"`
+	Secret  string             `json:"secret"`
+}
+
+type DeleteWebhookArgs struct {
+	ID      int `json:"id"`
+	OwnerID int `json:"ownerId"`
+}
+
+type WebhooksArgs struct {
+	OwnerID int `json:"ownerId"`
+}
+
+type WebhookDeliveriesArgs struct {
+	WebhookID int `json:"webhookId"`
+	OwnerID   int `json:"ownerId"`
+	Limit     int `json:"limit"`
+	Offset    int `json:"offset"`
+}
@@ -0,0 +1,235 @@
+package resolvers
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/logging"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/ratelimit"
+)
+
+const cursorPrefix = "report:"
+
+var (
+	ErrNotModerator     = fmt.Errorf("only moderators can do this")
+	ErrReportNotFound   = fmt.Errorf("report not found")
+	ErrReportResolved   = fmt.Errorf("report is already resolved")
+	ErrInvalidCursor    = fmt.Errorf("invalid cursor")
+	ErrUnknownAction    = fmt.Errorf("unknown moderation action")
+	ErrUnknownTarget    = fmt.Errorf("unknown report target type")
+	ErrInvalidQueueArgs = fmt.Errorf("invalid moderation queue args")
+)
+
+// ReportPage is a page of the moderation queue
+type ReportPage struct {
+	Reports     []*domain.Report `json:"reports"`
+	EndCursor   string           `json:"endCursor"`
+	HasNextPage bool             `json:"hasNextPage"`
+}
+
+// Report files a report against a post or a comment
+func (r *Resolver) Report(ctx context.Context, args ReportArgs) (any, error) {
+	logging.SetUser(ctx, args.ReporterID)
+
+	if err := validateID(args.TargetID, args.ReporterID); err != nil {
+		return nil, err
+	}
+
+	if err := validateReason(args.Reason); err != nil {
+		return nil, err
+	}
+
+	if err := r.limiter.Allow(ctx, ratelimit.OpReport, args.ReporterID); err != nil {
+		return nil, err
+	}
+
+	var savedReport *domain.Report
+	err := r.repo.withTx(ctx, func(tx store) error {
+		if _, err := tx.targetAuthor(ctx, args.TargetType, args.TargetID); err != nil {
+			return err
+		}
+
+		report := &domain.Report{
+			TargetType: args.TargetType,
+			TargetID:   args.TargetID,
+			ReporterID: args.ReporterID,
+			Reason:     args.Reason,
+			Status:     domain.ReportOpen,
+			CreatedAt:  time.Now().UTC(),
+		}
+
+		var err error
+		savedReport, err = tx.CreateReport(ctx, report)
+		if err != nil {
+			return fmt.Errorf("failed to create report: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return savedReport, nil
+}
+
+// ModerationQueue returns reports with the given status, oldest first
+func (r *Resolver) ModerationQueue(ctx context.Context, args ModerationQueueArgs) (any, error) {
+	logging.SetUser(ctx, args.ModeratorID)
+
+	if err := r.authorizeModerator(args.ModeratorID); err != nil {
+		return nil, err
+	}
+
+	if args.First < 1 {
+		return nil, ErrInvalidQueueArgs
+	}
+
+	afterID, err := decodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	status := args.Status
+	if status == "" {
+		status = domain.ReportOpen
+	}
+
+	// fetch one extra report to know if there is a next page
+	reports, err := r.repo.GetReports(ctx, status, args.First+1, afterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reports: %w", err)
+	}
+
+	page := &ReportPage{Reports: reports}
+	if len(reports) > args.First {
+		page.Reports = reports[:args.First]
+		page.HasNextPage = true
+	}
+	if len(page.Reports) > 0 {
+		page.EndCursor = encodeCursor(page.Reports[len(page.Reports)-1].ID)
+	}
+
+	return page, nil
+}
+
+// ResolveReport applies the moderator decision to the reported content and records it in the audit trail
+func (r *Resolver) ResolveReport(ctx context.Context, args ResolveReportArgs) (any, error) {
+	logging.SetUser(ctx, args.ModeratorID)
+
+	if err := validateID(args.ReportID, args.ModeratorID); err != nil {
+		return nil, err
+	}
+
+	if err := r.authorizeModerator(args.ModeratorID); err != nil {
+		return nil, err
+	}
+
+	var savedRecord *domain.ModerationRecord
+	var report *domain.Report
+	err := r.repo.withTx(ctx, func(tx store) error {
+		var err error
+		report, err = tx.getReport(ctx, args.ReportID)
+		if err != nil {
+			return err
+		}
+		if report.Status != domain.ReportOpen {
+			return fmt.Errorf("%w: %d", ErrReportResolved, report.ID)
+		}
+
+		record := &domain.ModerationRecord{
+			ReportID:    report.ID,
+			ModeratorID: args.ModeratorID,
+			Action:      args.Action,
+			TargetType:  report.TargetType,
+			TargetID:    report.TargetID,
+		}
+
+		status := domain.ReportActioned
+		if args.Action == domain.ActionDismiss {
+			status = domain.ReportDismissed
+		} else {
+			if record.AuthorID, err = tx.targetAuthor(ctx, report.TargetType, report.TargetID); err != nil {
+				return err
+			}
+			if err := tx.applyAction(ctx, args.Action, report.TargetType, report.TargetID, record.AuthorID); err != nil {
+				return err
+			}
+		}
+
+		record.CreatedAt = time.Now().UTC()
+		savedRecord, err = tx.ResolveReport(ctx, report.ID, status, record)
+		if err != nil {
+			return fmt.Errorf("failed to resolve report: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.notifyEvents()
+	r.logger.InfoContext(ctx, "report resolved",
+		"report_id", report.ID, "action", args.Action, "target_type", report.TargetType, "target_id", report.TargetID)
+
+	return savedRecord, nil
+}
+
+// ModerationLog returns the moderation audit trail, newest first
+func (r *Resolver) ModerationLog(ctx context.Context, args ModerationLogArgs) (any, error) {
+	logging.SetUser(ctx, args.ModeratorID)
+
+	if err := r.authorizeModerator(args.ModeratorID); err != nil {
+		return nil, err
+	}
+
+	if err := validatePaginationArgs(args.Limit, args.Offset); err != nil {
+		return nil, err
+	}
+
+	records, err := r.repo.GetModerationRecords(ctx, args.Limit, args.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation records: %w", err)
+	}
+
+	return records, nil
+}
+
+func (r *Resolver) isModerator(userID int) bool {
+	_, ok := r.moderators[userID]
+	return ok
+}
+
+func (r *Resolver) authorizeModerator(userID int) error {
+	if !r.isModerator(userID) {
+		return ErrNotModerator
+	}
+	return nil
+}
+
+// encodeCursor makes an opaque moderation queue cursor from a report id
+func encodeCursor(id int) string {
+	return base64.StdEncoding.EncodeToString([]byte(cursorPrefix + strconv.Itoa(id)))
+}
+
+// decodeCursor returns the report id of the cursor, an empty cursor points before the first report
+func decodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	id, err := strconv.Atoi(strings.TrimPrefix(string(raw), cursorPrefix))
+	if err != nil || !strings.HasPrefix(string(raw), cursorPrefix) {
+		return 0, ErrInvalidCursor
+	}
+
+	return id, nil
+}
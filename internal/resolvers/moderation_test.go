@@ -0,0 +1,237 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/filter"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolver_Report(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
+
+	mockRepo.GetCommentMock.Expect(minimock.AnyContext, 1).Return(&domain.Comment{ID: 1, AuthorID: 3}, nil)
+	mockRepo.CreateReportMock.Set(func(ctx context.Context, r *domain.Report) (*domain.Report, error) {
+		r.ID = 1
+		return r, nil
+	})
+
+	resolver := NewResolver(mockRepo)
+
+	res, err := resolver.Report(context.Background(), ReportArgs{
+		TargetType: domain.TargetComment,
+		TargetID:   1,
+		ReporterID: 2,
+		Reason:     "spam",
+	})
+	assert.NoError(t, err)
+
+	report, ok := res.(*domain.Report)
+	assert.True(t, ok)
+
+	assert.Equal(t, 1, report.ID)
+	assert.Equal(t, domain.ReportOpen, report.Status)
+	assert.Equal(t, domain.TargetComment, report.TargetType)
+}
+
+func TestResolver_Report_EmptyReason(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+
+	resolver := NewResolver(mockRepo)
+
+	res, err := resolver.Report(context.Background(), ReportArgs{
+		TargetType: domain.TargetPost,
+		TargetID:   1,
+		ReporterID: 2,
+		Reason:     "  ",
+	})
+	assert.ErrorIs(t, err, ErrInvalidReason)
+	assert.Nil(t, res)
+}
+
+func TestResolver_Report_NoSuchTarget(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
+
+	mockRepo.GetPostMock.Expect(minimock.AnyContext, 1).Return(nil, repository.ErrNotFound)
+
+	resolver := NewResolver(mockRepo)
+
+	res, err := resolver.Report(context.Background(), ReportArgs{
+		TargetType: domain.TargetPost,
+		TargetID:   1,
+		ReporterID: 2,
+		Reason:     "spam",
+	})
+	assert.ErrorIs(t, err, ErrPostNotFound)
+	assert.Nil(t, res)
+}
+
+func TestResolver_ModerationQueue_NotModerator(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+
+	resolver := NewResolver(mockRepo, WithModerators(1))
+
+	res, err := resolver.ModerationQueue(context.Background(), ModerationQueueArgs{ModeratorID: 2, First: 10})
+	assert.ErrorIs(t, err, ErrNotModerator)
+	assert.Nil(t, res)
+}
+
+func TestResolver_ModerationQueue(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+
+	reports := []*domain.Report{{ID: 3}, {ID: 4}, {ID: 5}}
+	mockRepo.GetReportsMock.Expect(minimock.AnyContext, domain.ReportOpen, 3, 2).Return(reports, nil)
+
+	resolver := NewResolver(mockRepo, WithModerators(1))
+
+	res, err := resolver.ModerationQueue(context.Background(), ModerationQueueArgs{
+		ModeratorID: 1,
+		First:       2,
+		After:       encodeCursor(2),
+	})
+	assert.NoError(t, err)
+
+	page, ok := res.(*ReportPage)
+	assert.True(t, ok)
+
+	assert.Len(t, page.Reports, 2)
+	assert.True(t, page.HasNextPage)
+	assert.Equal(t, encodeCursor(4), page.EndCursor)
+}
+
+func TestResolver_ResolveReport_Hide(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
+	mockRepo.CreateEventMock.Return(&domain.Event{}, nil)
+
+	report := &domain.Report{ID: 1, TargetType: domain.TargetComment, TargetID: 7, Status: domain.ReportOpen}
+	mockRepo.GetReportMock.Expect(minimock.AnyContext, 1).Return(report, nil)
+	mockRepo.GetCommentMock.Expect(minimock.AnyContext, 7).Return(&domain.Comment{ID: 7, AuthorID: 3}, nil)
+	mockRepo.HideCommentMock.Expect(minimock.AnyContext, 7).Return(nil)
+	mockRepo.ResolveReportMock.Set(func(ctx context.Context, id int, status domain.ReportStatus, r *domain.ModerationRecord) (*domain.ModerationRecord, error) {
+		assert.Equal(t, domain.ReportActioned, status)
+		r.ID = 1
+		return r, nil
+	})
+
+	resolver := NewResolver(mockRepo, WithModerators(1))
+
+	res, err := resolver.ResolveReport(context.Background(), ResolveReportArgs{
+		ReportID:    1,
+		ModeratorID: 1,
+		Action:      domain.ActionHide,
+	})
+	assert.NoError(t, err)
+
+	record, ok := res.(*domain.ModerationRecord)
+	assert.True(t, ok)
+
+	assert.Equal(t, domain.ActionHide, record.Action)
+	assert.Equal(t, 3, record.AuthorID)
+	assert.Equal(t, 1, record.ModeratorID)
+}
+
+func TestResolver_ResolveReport_AlreadyResolved(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
+
+	resolvedAt := time.Now()
+	report := &domain.Report{ID: 1, Status: domain.ReportDismissed, ResolvedAt: &resolvedAt}
+	mockRepo.GetReportMock.Expect(minimock.AnyContext, 1).Return(report, nil)
+
+	resolver := NewResolver(mockRepo, WithModerators(1))
+
+	res, err := resolver.ResolveReport(context.Background(), ResolveReportArgs{
+		ReportID:    1,
+		ModeratorID: 1,
+		Action:      domain.ActionDelete,
+	})
+	assert.ErrorIs(t, err, ErrReportResolved)
+	assert.Nil(t, res)
+}
+
+func TestResolver_GetCommentsByPost_ModeratorSeesHidden(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+
+	hidden := &domain.Comment{ID: 1, PostID: 1, Hidden: true}
+	mockRepo.GetCommentsByPostMock.Expect(minimock.AnyContext, 1, 10, 0, true).Return([]*domain.Comment{hidden}, nil)
+
+	resolver := NewResolver(mockRepo, WithModerators(5))
+
+	res, err := resolver.GetCommentsByPost(context.Background(), GetCommentsArgs{PostID: 1, Limit: 10, ViewerID: 5})
+	assert.NoError(t, err)
+
+	comments, ok := res.([]*domain.Comment)
+	assert.True(t, ok)
+	assert.Equal(t, []*domain.Comment{hidden}, comments)
+}
+
+func TestResolver_CreateComment_AuthorBanned(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
+
+	mockRepo.GetPostMock.Expect(minimock.AnyContext, 1).Return(&domain.Post{ID: 1}, nil)
+	mockRepo.IsBannedMock.Expect(minimock.AnyContext, 2).Return(true, nil)
+
+	resolver := NewResolver(mockRepo)
+
+	res, err := resolver.CreateComment(context.Background(), CreateCommentArgs{
+		PostID:   1,
+		AuthorID: 2,
+		Content:  "Content",
+	})
+	assert.ErrorIs(t, err, ErrAuthorBanned)
+	assert.Nil(t, res)
+}
+
+func TestResolver_CreateComment_FilterRejects(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+
+	// the content is rejected before the repository is used
+
+	resolver := NewResolver(mockRepo, WithFilters(filter.NewChain(filter.NewBannedWords([]string{"spam"}, filter.Reject))))
+
+	res, err := resolver.CreateComment(context.Background(), CreateCommentArgs{
+		PostID:   1,
+		AuthorID: 1,
+		Content:  "buy spam",
+	})
+	assert.ErrorIs(t, err, filter.ErrRejected)
+	assert.Nil(t, res)
+}
+
+func TestResolver_CreatePost_FilterFlags(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
+	mockRepo.CreateEventMock.Return(&domain.Event{}, nil)
+
+	mockRepo.IsBannedMock.Expect(minimock.AnyContext, 1).Return(false, nil)
+	mockRepo.CreatePostMock.Set(func(ctx context.Context, p *domain.Post) (*domain.Post, error) {
+		p.ID = 1
+		return p, nil
+	})
+	mockRepo.CreateReportMock.Set(func(ctx context.Context, r *domain.Report) (*domain.Report, error) {
+		assert.Equal(t, domain.TargetPost, r.TargetType)
+		assert.Equal(t, 1, r.TargetID)
+		assert.Equal(t, domain.SystemReporterID, r.ReporterID)
+		return r, nil
+	})
+
+	resolver := NewResolver(mockRepo, WithFilters(filter.NewChain(filter.NewLinkLimit(0, filter.Flag))))
+
+	res, err := resolver.CreatePost(context.Background(), CreatePostArgs{
+		Title:    "Title",
+		Content:  "see https://example.com",
+		AuthorID: 1,
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, res)
+	assert.Equal(t, uint64(1), mockRepo.CreateReportAfterCounter())
+}
@@ -8,9 +8,11 @@ import (
 	"context"
 	"sync"
 	mm_atomic "sync/atomic"
+	"time"
 	mm_time "time"
 
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	mm_repository "github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
 	"github.com/gojuno/minimock/v3"
 )
 
@@ -19,17 +21,23 @@ type RepositoryMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
-	funcContainsComment          func(ctx context.Context, id int) (b1 bool, err error)
-	inspectFuncContainsComment   func(ctx context.Context, id int)
-	afterContainsCommentCounter  uint64
-	beforeContainsCommentCounter uint64
-	ContainsCommentMock          mRepositoryMockContainsComment
+	funcBanAuthor          func(ctx context.Context, authorID int) (err error)
+	inspectFuncBanAuthor   func(ctx context.Context, authorID int)
+	afterBanAuthorCounter  uint64
+	beforeBanAuthorCounter uint64
+	BanAuthorMock          mRepositoryMockBanAuthor
 
-	funcContainsPost          func(ctx context.Context, id int) (b1 bool, err error)
-	inspectFuncContainsPost   func(ctx context.Context, id int)
-	afterContainsPostCounter  uint64
-	beforeContainsPostCounter uint64
-	ContainsPostMock          mRepositoryMockContainsPost
+	funcClaimEvents          func(ctx context.Context, limit int, lease time.Duration) (epa1 []*domain.Event, err error)
+	inspectFuncClaimEvents   func(ctx context.Context, limit int, lease time.Duration)
+	afterClaimEventsCounter  uint64
+	beforeClaimEventsCounter uint64
+	ClaimEventsMock          mRepositoryMockClaimEvents
+
+	funcClaimWebhookDeliveries          func(ctx context.Context, limit int, lease time.Duration) (wpa1 []*domain.WebhookDelivery, err error)
+	inspectFuncClaimWebhookDeliveries   func(ctx context.Context, limit int, lease time.Duration)
+	afterClaimWebhookDeliveriesCounter  uint64
+	beforeClaimWebhookDeliveriesCounter uint64
+	ClaimWebhookDeliveriesMock          mRepositoryMockClaimWebhookDeliveries
 
 	funcCreateComment          func(ctx context.Context, comment *domain.Comment) (cp1 *domain.Comment, err error)
 	inspectFuncCreateComment   func(ctx context.Context, comment *domain.Comment)
@@ -37,30 +45,96 @@ type RepositoryMock struct {
 	beforeCreateCommentCounter uint64
 	CreateCommentMock          mRepositoryMockCreateComment
 
+	funcCreateEvent          func(ctx context.Context, event *domain.Event) (ep1 *domain.Event, err error)
+	inspectFuncCreateEvent   func(ctx context.Context, event *domain.Event)
+	afterCreateEventCounter  uint64
+	beforeCreateEventCounter uint64
+	CreateEventMock          mRepositoryMockCreateEvent
+
 	funcCreatePost          func(ctx context.Context, post *domain.Post) (pp1 *domain.Post, err error)
 	inspectFuncCreatePost   func(ctx context.Context, post *domain.Post)
 	afterCreatePostCounter  uint64
 	beforeCreatePostCounter uint64
 	CreatePostMock          mRepositoryMockCreatePost
 
+	funcCreateReport          func(ctx context.Context, report *domain.Report) (rp1 *domain.Report, err error)
+	inspectFuncCreateReport   func(ctx context.Context, report *domain.Report)
+	afterCreateReportCounter  uint64
+	beforeCreateReportCounter uint64
+	CreateReportMock          mRepositoryMockCreateReport
+
+	funcCreateWebhook          func(ctx context.Context, webhook *domain.Webhook) (wp1 *domain.Webhook, err error)
+	inspectFuncCreateWebhook   func(ctx context.Context, webhook *domain.Webhook)
+	afterCreateWebhookCounter  uint64
+	beforeCreateWebhookCounter uint64
+	CreateWebhookMock          mRepositoryMockCreateWebhook
+
+	funcCreateWebhookDeliveries          func(ctx context.Context, deliveries []*domain.WebhookDelivery) (err error)
+	inspectFuncCreateWebhookDeliveries   func(ctx context.Context, deliveries []*domain.WebhookDelivery)
+	afterCreateWebhookDeliveriesCounter  uint64
+	beforeCreateWebhookDeliveriesCounter uint64
+	CreateWebhookDeliveriesMock          mRepositoryMockCreateWebhookDeliveries
+
+	funcDeleteComment          func(ctx context.Context, id int) (err error)
+	inspectFuncDeleteComment   func(ctx context.Context, id int)
+	afterDeleteCommentCounter  uint64
+	beforeDeleteCommentCounter uint64
+	DeleteCommentMock          mRepositoryMockDeleteComment
+
+	funcDeletePost          func(ctx context.Context, id int) (err error)
+	inspectFuncDeletePost   func(ctx context.Context, id int)
+	afterDeletePostCounter  uint64
+	beforeDeletePostCounter uint64
+	DeletePostMock          mRepositoryMockDeletePost
+
+	funcDeletePublishedEvents          func(ctx context.Context, before time.Time) (i1 int, err error)
+	inspectFuncDeletePublishedEvents   func(ctx context.Context, before time.Time)
+	afterDeletePublishedEventsCounter  uint64
+	beforeDeletePublishedEventsCounter uint64
+	DeletePublishedEventsMock          mRepositoryMockDeletePublishedEvents
+
+	funcDeleteWebhook          func(ctx context.Context, id int) (err error)
+	inspectFuncDeleteWebhook   func(ctx context.Context, id int)
+	afterDeleteWebhookCounter  uint64
+	beforeDeleteWebhookCounter uint64
+	DeleteWebhookMock          mRepositoryMockDeleteWebhook
+
 	funcDisableComments          func(ctx context.Context, postID int) (err error)
 	inspectFuncDisableComments   func(ctx context.Context, postID int)
 	afterDisableCommentsCounter  uint64
 	beforeDisableCommentsCounter uint64
 	DisableCommentsMock          mRepositoryMockDisableComments
 
-	funcGetCommentsByParent          func(ctx context.Context, parentId int, limit int, offset int) (cpa1 []*domain.Comment, err error)
-	inspectFuncGetCommentsByParent   func(ctx context.Context, parentId int, limit int, offset int)
+	funcEnableComments          func(ctx context.Context, postID int) (err error)
+	inspectFuncEnableComments   func(ctx context.Context, postID int)
+	afterEnableCommentsCounter  uint64
+	beforeEnableCommentsCounter uint64
+	EnableCommentsMock          mRepositoryMockEnableComments
+
+	funcGetComment          func(ctx context.Context, id int) (cp1 *domain.Comment, err error)
+	inspectFuncGetComment   func(ctx context.Context, id int)
+	afterGetCommentCounter  uint64
+	beforeGetCommentCounter uint64
+	GetCommentMock          mRepositoryMockGetComment
+
+	funcGetCommentsByParent          func(ctx context.Context, parentId int, limit int, offset int, includeHidden bool) (cpa1 []*domain.Comment, err error)
+	inspectFuncGetCommentsByParent   func(ctx context.Context, parentId int, limit int, offset int, includeHidden bool)
 	afterGetCommentsByParentCounter  uint64
 	beforeGetCommentsByParentCounter uint64
 	GetCommentsByParentMock          mRepositoryMockGetCommentsByParent
 
-	funcGetCommentsByPost          func(ctx context.Context, postID int, limit int, offset int) (cpa1 []*domain.Comment, err error)
-	inspectFuncGetCommentsByPost   func(ctx context.Context, postID int, limit int, offset int)
+	funcGetCommentsByPost          func(ctx context.Context, postID int, limit int, offset int, includeHidden bool) (cpa1 []*domain.Comment, err error)
+	inspectFuncGetCommentsByPost   func(ctx context.Context, postID int, limit int, offset int, includeHidden bool)
 	afterGetCommentsByPostCounter  uint64
 	beforeGetCommentsByPostCounter uint64
 	GetCommentsByPostMock          mRepositoryMockGetCommentsByPost
 
+	funcGetModerationRecords          func(ctx context.Context, limit int, offset int) (mpa1 []*domain.ModerationRecord, err error)
+	inspectFuncGetModerationRecords   func(ctx context.Context, limit int, offset int)
+	afterGetModerationRecordsCounter  uint64
+	beforeGetModerationRecordsCounter uint64
+	GetModerationRecordsMock          mRepositoryMockGetModerationRecords
+
 	funcGetPost          func(ctx context.Context, id int) (pp1 *domain.Post, err error)
 	inspectFuncGetPost   func(ctx context.Context, id int)
 	afterGetPostCounter  uint64
@@ -72,6 +146,96 @@ type RepositoryMock struct {
 	afterGetPostsCounter  uint64
 	beforeGetPostsCounter uint64
 	GetPostsMock          mRepositoryMockGetPosts
+
+	funcGetPostsAfter          func(ctx context.Context, limit int, afterID int) (ppa1 []*domain.Post, err error)
+	inspectFuncGetPostsAfter   func(ctx context.Context, limit int, afterID int)
+	afterGetPostsAfterCounter  uint64
+	beforeGetPostsAfterCounter uint64
+	GetPostsAfterMock          mRepositoryMockGetPostsAfter
+
+	funcGetReport          func(ctx context.Context, id int) (rp1 *domain.Report, err error)
+	inspectFuncGetReport   func(ctx context.Context, id int)
+	afterGetReportCounter  uint64
+	beforeGetReportCounter uint64
+	GetReportMock          mRepositoryMockGetReport
+
+	funcGetReports          func(ctx context.Context, status domain.ReportStatus, limit int, afterID int) (rpa1 []*domain.Report, err error)
+	inspectFuncGetReports   func(ctx context.Context, status domain.ReportStatus, limit int, afterID int)
+	afterGetReportsCounter  uint64
+	beforeGetReportsCounter uint64
+	GetReportsMock          mRepositoryMockGetReports
+
+	funcGetWebhook          func(ctx context.Context, id int) (wp1 *domain.Webhook, err error)
+	inspectFuncGetWebhook   func(ctx context.Context, id int)
+	afterGetWebhookCounter  uint64
+	beforeGetWebhookCounter uint64
+	GetWebhookMock          mRepositoryMockGetWebhook
+
+	funcGetWebhookDeliveries          func(ctx context.Context, webhookID int, limit int, offset int) (wpa1 []*domain.WebhookDelivery, err error)
+	inspectFuncGetWebhookDeliveries   func(ctx context.Context, webhookID int, limit int, offset int)
+	afterGetWebhookDeliveriesCounter  uint64
+	beforeGetWebhookDeliveriesCounter uint64
+	GetWebhookDeliveriesMock          mRepositoryMockGetWebhookDeliveries
+
+	funcGetWebhooks          func(ctx context.Context, ownerID int) (wpa1 []*domain.Webhook, err error)
+	inspectFuncGetWebhooks   func(ctx context.Context, ownerID int)
+	afterGetWebhooksCounter  uint64
+	beforeGetWebhooksCounter uint64
+	GetWebhooksMock          mRepositoryMockGetWebhooks
+
+	funcGetWebhooksForEvent          func(ctx context.Context, eventType domain.EventType, postID int) (wpa1 []*domain.Webhook, err error)
+	inspectFuncGetWebhooksForEvent   func(ctx context.Context, eventType domain.EventType, postID int)
+	afterGetWebhooksForEventCounter  uint64
+	beforeGetWebhooksForEventCounter uint64
+	GetWebhooksForEventMock          mRepositoryMockGetWebhooksForEvent
+
+	funcHideComment          func(ctx context.Context, id int) (err error)
+	inspectFuncHideComment   func(ctx context.Context, id int)
+	afterHideCommentCounter  uint64
+	beforeHideCommentCounter uint64
+	HideCommentMock          mRepositoryMockHideComment
+
+	funcHidePost          func(ctx context.Context, id int) (err error)
+	inspectFuncHidePost   func(ctx context.Context, id int)
+	afterHidePostCounter  uint64
+	beforeHidePostCounter uint64
+	HidePostMock          mRepositoryMockHidePost
+
+	funcImportPosts          func(ctx context.Context, posts []*domain.Post, comments []*domain.Comment) (err error)
+	inspectFuncImportPosts   func(ctx context.Context, posts []*domain.Post, comments []*domain.Comment)
+	afterImportPostsCounter  uint64
+	beforeImportPostsCounter uint64
+	ImportPostsMock          mRepositoryMockImportPosts
+
+	funcIsBanned          func(ctx context.Context, authorID int) (b1 bool, err error)
+	inspectFuncIsBanned   func(ctx context.Context, authorID int)
+	afterIsBannedCounter  uint64
+	beforeIsBannedCounter uint64
+	IsBannedMock          mRepositoryMockIsBanned
+
+	funcMarkEventsPublished          func(ctx context.Context, ids []int, publishedAt time.Time) (err error)
+	inspectFuncMarkEventsPublished   func(ctx context.Context, ids []int, publishedAt time.Time)
+	afterMarkEventsPublishedCounter  uint64
+	beforeMarkEventsPublishedCounter uint64
+	MarkEventsPublishedMock          mRepositoryMockMarkEventsPublished
+
+	funcResolveReport          func(ctx context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord) (mp1 *domain.ModerationRecord, err error)
+	inspectFuncResolveReport   func(ctx context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord)
+	afterResolveReportCounter  uint64
+	beforeResolveReportCounter uint64
+	ResolveReportMock          mRepositoryMockResolveReport
+
+	funcUpdateWebhookDelivery          func(ctx context.Context, delivery *domain.WebhookDelivery) (err error)
+	inspectFuncUpdateWebhookDelivery   func(ctx context.Context, delivery *domain.WebhookDelivery)
+	afterUpdateWebhookDeliveryCounter  uint64
+	beforeUpdateWebhookDeliveryCounter uint64
+	UpdateWebhookDeliveryMock          mRepositoryMockUpdateWebhookDelivery
+
+	funcWithTx          func(ctx context.Context, fn func(repo mm_repository.Repository) error) (err error)
+	inspectFuncWithTx   func(ctx context.Context, fn func(repo mm_repository.Repository) error)
+	afterWithTxCounter  uint64
+	beforeWithTxCounter uint64
+	WithTxMock          mRepositoryMockWithTx
 }
 
 // NewRepositoryMock returns a mock for repository.Repository
@@ -82,645 +246,1086 @@ func NewRepositoryMock(t minimock.Tester) *RepositoryMock {
 		controller.RegisterMocker(m)
 	}
 
-	m.ContainsCommentMock = mRepositoryMockContainsComment{mock: m}
-	m.ContainsCommentMock.callArgs = []*RepositoryMockContainsCommentParams{}
+	m.BanAuthorMock = mRepositoryMockBanAuthor{mock: m}
+	m.BanAuthorMock.callArgs = []*RepositoryMockBanAuthorParams{}
+
+	m.ClaimEventsMock = mRepositoryMockClaimEvents{mock: m}
+	m.ClaimEventsMock.callArgs = []*RepositoryMockClaimEventsParams{}
 
-	m.ContainsPostMock = mRepositoryMockContainsPost{mock: m}
-	m.ContainsPostMock.callArgs = []*RepositoryMockContainsPostParams{}
+	m.ClaimWebhookDeliveriesMock = mRepositoryMockClaimWebhookDeliveries{mock: m}
+	m.ClaimWebhookDeliveriesMock.callArgs = []*RepositoryMockClaimWebhookDeliveriesParams{}
 
 	m.CreateCommentMock = mRepositoryMockCreateComment{mock: m}
 	m.CreateCommentMock.callArgs = []*RepositoryMockCreateCommentParams{}
 
+	m.CreateEventMock = mRepositoryMockCreateEvent{mock: m}
+	m.CreateEventMock.callArgs = []*RepositoryMockCreateEventParams{}
+
 	m.CreatePostMock = mRepositoryMockCreatePost{mock: m}
 	m.CreatePostMock.callArgs = []*RepositoryMockCreatePostParams{}
 
+	m.CreateReportMock = mRepositoryMockCreateReport{mock: m}
+	m.CreateReportMock.callArgs = []*RepositoryMockCreateReportParams{}
+
+	m.CreateWebhookMock = mRepositoryMockCreateWebhook{mock: m}
+	m.CreateWebhookMock.callArgs = []*RepositoryMockCreateWebhookParams{}
+
+	m.CreateWebhookDeliveriesMock = mRepositoryMockCreateWebhookDeliveries{mock: m}
+	m.CreateWebhookDeliveriesMock.callArgs = []*RepositoryMockCreateWebhookDeliveriesParams{}
+
+	m.DeleteCommentMock = mRepositoryMockDeleteComment{mock: m}
+	m.DeleteCommentMock.callArgs = []*RepositoryMockDeleteCommentParams{}
+
+	m.DeletePostMock = mRepositoryMockDeletePost{mock: m}
+	m.DeletePostMock.callArgs = []*RepositoryMockDeletePostParams{}
+
+	m.DeletePublishedEventsMock = mRepositoryMockDeletePublishedEvents{mock: m}
+	m.DeletePublishedEventsMock.callArgs = []*RepositoryMockDeletePublishedEventsParams{}
+
+	m.DeleteWebhookMock = mRepositoryMockDeleteWebhook{mock: m}
+	m.DeleteWebhookMock.callArgs = []*RepositoryMockDeleteWebhookParams{}
+
 	m.DisableCommentsMock = mRepositoryMockDisableComments{mock: m}
 	m.DisableCommentsMock.callArgs = []*RepositoryMockDisableCommentsParams{}
 
+	m.EnableCommentsMock = mRepositoryMockEnableComments{mock: m}
+	m.EnableCommentsMock.callArgs = []*RepositoryMockEnableCommentsParams{}
+
+	m.GetCommentMock = mRepositoryMockGetComment{mock: m}
+	m.GetCommentMock.callArgs = []*RepositoryMockGetCommentParams{}
+
 	m.GetCommentsByParentMock = mRepositoryMockGetCommentsByParent{mock: m}
 	m.GetCommentsByParentMock.callArgs = []*RepositoryMockGetCommentsByParentParams{}
 
 	m.GetCommentsByPostMock = mRepositoryMockGetCommentsByPost{mock: m}
 	m.GetCommentsByPostMock.callArgs = []*RepositoryMockGetCommentsByPostParams{}
 
+	m.GetModerationRecordsMock = mRepositoryMockGetModerationRecords{mock: m}
+	m.GetModerationRecordsMock.callArgs = []*RepositoryMockGetModerationRecordsParams{}
+
 	m.GetPostMock = mRepositoryMockGetPost{mock: m}
 	m.GetPostMock.callArgs = []*RepositoryMockGetPostParams{}
 
 	m.GetPostsMock = mRepositoryMockGetPosts{mock: m}
 	m.GetPostsMock.callArgs = []*RepositoryMockGetPostsParams{}
 
+	m.GetPostsAfterMock = mRepositoryMockGetPostsAfter{mock: m}
+	m.GetPostsAfterMock.callArgs = []*RepositoryMockGetPostsAfterParams{}
+
+	m.GetReportMock = mRepositoryMockGetReport{mock: m}
+	m.GetReportMock.callArgs = []*RepositoryMockGetReportParams{}
+
+	m.GetReportsMock = mRepositoryMockGetReports{mock: m}
+	m.GetReportsMock.callArgs = []*RepositoryMockGetReportsParams{}
+
+	m.GetWebhookMock = mRepositoryMockGetWebhook{mock: m}
+	m.GetWebhookMock.callArgs = []*RepositoryMockGetWebhookParams{}
+
+	m.GetWebhookDeliveriesMock = mRepositoryMockGetWebhookDeliveries{mock: m}
+	m.GetWebhookDeliveriesMock.callArgs = []*RepositoryMockGetWebhookDeliveriesParams{}
+
+	m.GetWebhooksMock = mRepositoryMockGetWebhooks{mock: m}
+	m.GetWebhooksMock.callArgs = []*RepositoryMockGetWebhooksParams{}
+
+	m.GetWebhooksForEventMock = mRepositoryMockGetWebhooksForEvent{mock: m}
+	m.GetWebhooksForEventMock.callArgs = []*RepositoryMockGetWebhooksForEventParams{}
+
+	m.HideCommentMock = mRepositoryMockHideComment{mock: m}
+	m.HideCommentMock.callArgs = []*RepositoryMockHideCommentParams{}
+
+	m.HidePostMock = mRepositoryMockHidePost{mock: m}
+	m.HidePostMock.callArgs = []*RepositoryMockHidePostParams{}
+
+	m.ImportPostsMock = mRepositoryMockImportPosts{mock: m}
+	m.ImportPostsMock.callArgs = []*RepositoryMockImportPostsParams{}
+
+	m.IsBannedMock = mRepositoryMockIsBanned{mock: m}
+	m.IsBannedMock.callArgs = []*RepositoryMockIsBannedParams{}
+
+	m.MarkEventsPublishedMock = mRepositoryMockMarkEventsPublished{mock: m}
+	m.MarkEventsPublishedMock.callArgs = []*RepositoryMockMarkEventsPublishedParams{}
+
+	m.ResolveReportMock = mRepositoryMockResolveReport{mock: m}
+	m.ResolveReportMock.callArgs = []*RepositoryMockResolveReportParams{}
+
+	m.UpdateWebhookDeliveryMock = mRepositoryMockUpdateWebhookDelivery{mock: m}
+	m.UpdateWebhookDeliveryMock.callArgs = []*RepositoryMockUpdateWebhookDeliveryParams{}
+
+	m.WithTxMock = mRepositoryMockWithTx{mock: m}
+	m.WithTxMock.callArgs = []*RepositoryMockWithTxParams{}
+
 	t.Cleanup(m.MinimockFinish)
 
 	return m
 }
 
-type mRepositoryMockContainsComment struct {
+type mRepositoryMockBanAuthor struct {
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockContainsCommentExpectation
-	expectations       []*RepositoryMockContainsCommentExpectation
+	defaultExpectation *RepositoryMockBanAuthorExpectation
+	expectations       []*RepositoryMockBanAuthorExpectation
 
-	callArgs []*RepositoryMockContainsCommentParams
+	callArgs []*RepositoryMockBanAuthorParams
 	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// RepositoryMockContainsCommentExpectation specifies expectation struct of the Repository.ContainsComment
-type RepositoryMockContainsCommentExpectation struct {
+// RepositoryMockBanAuthorExpectation specifies expectation struct of the Repository.BanAuthor
+type RepositoryMockBanAuthorExpectation struct {
 	mock      *RepositoryMock
-	params    *RepositoryMockContainsCommentParams
-	paramPtrs *RepositoryMockContainsCommentParamPtrs
-	results   *RepositoryMockContainsCommentResults
+	params    *RepositoryMockBanAuthorParams
+	paramPtrs *RepositoryMockBanAuthorParamPtrs
+	results   *RepositoryMockBanAuthorResults
 	Counter   uint64
 }
 
-// RepositoryMockContainsCommentParams contains parameters of the Repository.ContainsComment
-type RepositoryMockContainsCommentParams struct {
-	ctx context.Context
-	id  int
+// RepositoryMockBanAuthorParams contains parameters of the Repository.BanAuthor
+type RepositoryMockBanAuthorParams struct {
+	ctx      context.Context
+	authorID int
 }
 
-// RepositoryMockContainsCommentParamPtrs contains pointers to parameters of the Repository.ContainsComment
-type RepositoryMockContainsCommentParamPtrs struct {
-	ctx *context.Context
-	id  *int
+// RepositoryMockBanAuthorParamPtrs contains pointers to parameters of the Repository.BanAuthor
+type RepositoryMockBanAuthorParamPtrs struct {
+	ctx      *context.Context
+	authorID *int
 }
 
-// RepositoryMockContainsCommentResults contains results of the Repository.ContainsComment
-type RepositoryMockContainsCommentResults struct {
-	b1  bool
+// RepositoryMockBanAuthorResults contains results of the Repository.BanAuthor
+type RepositoryMockBanAuthorResults struct {
 	err error
 }
 
-// Expect sets up expected params for Repository.ContainsComment
-func (mmContainsComment *mRepositoryMockContainsComment) Expect(ctx context.Context, id int) *mRepositoryMockContainsComment {
-	if mmContainsComment.mock.funcContainsComment != nil {
-		mmContainsComment.mock.t.Fatalf("RepositoryMock.ContainsComment mock is already set by Set")
+// Expect sets up expected params for Repository.BanAuthor
+func (mmBanAuthor *mRepositoryMockBanAuthor) Expect(ctx context.Context, authorID int) *mRepositoryMockBanAuthor {
+	if mmBanAuthor.mock.funcBanAuthor != nil {
+		mmBanAuthor.mock.t.Fatalf("RepositoryMock.BanAuthor mock is already set by Set")
 	}
 
-	if mmContainsComment.defaultExpectation == nil {
-		mmContainsComment.defaultExpectation = &RepositoryMockContainsCommentExpectation{}
+	if mmBanAuthor.defaultExpectation == nil {
+		mmBanAuthor.defaultExpectation = &RepositoryMockBanAuthorExpectation{}
 	}
 
-	if mmContainsComment.defaultExpectation.paramPtrs != nil {
-		mmContainsComment.mock.t.Fatalf("RepositoryMock.ContainsComment mock is already set by ExpectParams functions")
+	if mmBanAuthor.defaultExpectation.paramPtrs != nil {
+		mmBanAuthor.mock.t.Fatalf("RepositoryMock.BanAuthor mock is already set by ExpectParams functions")
 	}
 
-	mmContainsComment.defaultExpectation.params = &RepositoryMockContainsCommentParams{ctx, id}
-	for _, e := range mmContainsComment.expectations {
-		if minimock.Equal(e.params, mmContainsComment.defaultExpectation.params) {
-			mmContainsComment.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmContainsComment.defaultExpectation.params)
+	mmBanAuthor.defaultExpectation.params = &RepositoryMockBanAuthorParams{ctx, authorID}
+	for _, e := range mmBanAuthor.expectations {
+		if minimock.Equal(e.params, mmBanAuthor.defaultExpectation.params) {
+			mmBanAuthor.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmBanAuthor.defaultExpectation.params)
 		}
 	}
 
-	return mmContainsComment
+	return mmBanAuthor
 }
 
-// ExpectCtxParam1 sets up expected param ctx for Repository.ContainsComment
-func (mmContainsComment *mRepositoryMockContainsComment) ExpectCtxParam1(ctx context.Context) *mRepositoryMockContainsComment {
-	if mmContainsComment.mock.funcContainsComment != nil {
-		mmContainsComment.mock.t.Fatalf("RepositoryMock.ContainsComment mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Repository.BanAuthor
+func (mmBanAuthor *mRepositoryMockBanAuthor) ExpectCtxParam1(ctx context.Context) *mRepositoryMockBanAuthor {
+	if mmBanAuthor.mock.funcBanAuthor != nil {
+		mmBanAuthor.mock.t.Fatalf("RepositoryMock.BanAuthor mock is already set by Set")
 	}
 
-	if mmContainsComment.defaultExpectation == nil {
-		mmContainsComment.defaultExpectation = &RepositoryMockContainsCommentExpectation{}
+	if mmBanAuthor.defaultExpectation == nil {
+		mmBanAuthor.defaultExpectation = &RepositoryMockBanAuthorExpectation{}
 	}
 
-	if mmContainsComment.defaultExpectation.params != nil {
-		mmContainsComment.mock.t.Fatalf("RepositoryMock.ContainsComment mock is already set by Expect")
+	if mmBanAuthor.defaultExpectation.params != nil {
+		mmBanAuthor.mock.t.Fatalf("RepositoryMock.BanAuthor mock is already set by Expect")
 	}
 
-	if mmContainsComment.defaultExpectation.paramPtrs == nil {
-		mmContainsComment.defaultExpectation.paramPtrs = &RepositoryMockContainsCommentParamPtrs{}
+	if mmBanAuthor.defaultExpectation.paramPtrs == nil {
+		mmBanAuthor.defaultExpectation.paramPtrs = &RepositoryMockBanAuthorParamPtrs{}
 	}
-	mmContainsComment.defaultExpectation.paramPtrs.ctx = &ctx
+	mmBanAuthor.defaultExpectation.paramPtrs.ctx = &ctx
 
-	return mmContainsComment
+	return mmBanAuthor
 }
 
-// ExpectIdParam2 sets up expected param id for Repository.ContainsComment
-func (mmContainsComment *mRepositoryMockContainsComment) ExpectIdParam2(id int) *mRepositoryMockContainsComment {
-	if mmContainsComment.mock.funcContainsComment != nil {
-		mmContainsComment.mock.t.Fatalf("RepositoryMock.ContainsComment mock is already set by Set")
+// ExpectAuthorIDParam2 sets up expected param authorID for Repository.BanAuthor
+func (mmBanAuthor *mRepositoryMockBanAuthor) ExpectAuthorIDParam2(authorID int) *mRepositoryMockBanAuthor {
+	if mmBanAuthor.mock.funcBanAuthor != nil {
+		mmBanAuthor.mock.t.Fatalf("RepositoryMock.BanAuthor mock is already set by Set")
 	}
 
-	if mmContainsComment.defaultExpectation == nil {
-		mmContainsComment.defaultExpectation = &RepositoryMockContainsCommentExpectation{}
+	if mmBanAuthor.defaultExpectation == nil {
+		mmBanAuthor.defaultExpectation = &RepositoryMockBanAuthorExpectation{}
 	}
 
-	if mmContainsComment.defaultExpectation.params != nil {
-		mmContainsComment.mock.t.Fatalf("RepositoryMock.ContainsComment mock is already set by Expect")
+	if mmBanAuthor.defaultExpectation.params != nil {
+		mmBanAuthor.mock.t.Fatalf("RepositoryMock.BanAuthor mock is already set by Expect")
 	}
 
-	if mmContainsComment.defaultExpectation.paramPtrs == nil {
-		mmContainsComment.defaultExpectation.paramPtrs = &RepositoryMockContainsCommentParamPtrs{}
+	if mmBanAuthor.defaultExpectation.paramPtrs == nil {
+		mmBanAuthor.defaultExpectation.paramPtrs = &RepositoryMockBanAuthorParamPtrs{}
 	}
-	mmContainsComment.defaultExpectation.paramPtrs.id = &id
+	mmBanAuthor.defaultExpectation.paramPtrs.authorID = &authorID
 
-	return mmContainsComment
+	return mmBanAuthor
 }
 
-// Inspect accepts an inspector function that has same arguments as the Repository.ContainsComment
-func (mmContainsComment *mRepositoryMockContainsComment) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockContainsComment {
-	if mmContainsComment.mock.inspectFuncContainsComment != nil {
-		mmContainsComment.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ContainsComment")
+// Inspect accepts an inspector function that has same arguments as the Repository.BanAuthor
+func (mmBanAuthor *mRepositoryMockBanAuthor) Inspect(f func(ctx context.Context, authorID int)) *mRepositoryMockBanAuthor {
+	if mmBanAuthor.mock.inspectFuncBanAuthor != nil {
+		mmBanAuthor.mock.t.Fatalf("Inspect function is already set for RepositoryMock.BanAuthor")
 	}
 
-	mmContainsComment.mock.inspectFuncContainsComment = f
+	mmBanAuthor.mock.inspectFuncBanAuthor = f
 
-	return mmContainsComment
+	return mmBanAuthor
 }
 
-// Return sets up results that will be returned by Repository.ContainsComment
-func (mmContainsComment *mRepositoryMockContainsComment) Return(b1 bool, err error) *RepositoryMock {
-	if mmContainsComment.mock.funcContainsComment != nil {
-		mmContainsComment.mock.t.Fatalf("RepositoryMock.ContainsComment mock is already set by Set")
+// Return sets up results that will be returned by Repository.BanAuthor
+func (mmBanAuthor *mRepositoryMockBanAuthor) Return(err error) *RepositoryMock {
+	if mmBanAuthor.mock.funcBanAuthor != nil {
+		mmBanAuthor.mock.t.Fatalf("RepositoryMock.BanAuthor mock is already set by Set")
 	}
 
-	if mmContainsComment.defaultExpectation == nil {
-		mmContainsComment.defaultExpectation = &RepositoryMockContainsCommentExpectation{mock: mmContainsComment.mock}
+	if mmBanAuthor.defaultExpectation == nil {
+		mmBanAuthor.defaultExpectation = &RepositoryMockBanAuthorExpectation{mock: mmBanAuthor.mock}
 	}
-	mmContainsComment.defaultExpectation.results = &RepositoryMockContainsCommentResults{b1, err}
-	return mmContainsComment.mock
+	mmBanAuthor.defaultExpectation.results = &RepositoryMockBanAuthorResults{err}
+	return mmBanAuthor.mock
 }
 
-// Set uses given function f to mock the Repository.ContainsComment method
-func (mmContainsComment *mRepositoryMockContainsComment) Set(f func(ctx context.Context, id int) (b1 bool, err error)) *RepositoryMock {
-	if mmContainsComment.defaultExpectation != nil {
-		mmContainsComment.mock.t.Fatalf("Default expectation is already set for the Repository.ContainsComment method")
+// Set uses given function f to mock the Repository.BanAuthor method
+func (mmBanAuthor *mRepositoryMockBanAuthor) Set(f func(ctx context.Context, authorID int) (err error)) *RepositoryMock {
+	if mmBanAuthor.defaultExpectation != nil {
+		mmBanAuthor.mock.t.Fatalf("Default expectation is already set for the Repository.BanAuthor method")
 	}
 
-	if len(mmContainsComment.expectations) > 0 {
-		mmContainsComment.mock.t.Fatalf("Some expectations are already set for the Repository.ContainsComment method")
+	if len(mmBanAuthor.expectations) > 0 {
+		mmBanAuthor.mock.t.Fatalf("Some expectations are already set for the Repository.BanAuthor method")
 	}
 
-	mmContainsComment.mock.funcContainsComment = f
-	return mmContainsComment.mock
+	mmBanAuthor.mock.funcBanAuthor = f
+	return mmBanAuthor.mock
 }
 
-// When sets expectation for the Repository.ContainsComment which will trigger the result defined by the following
+// When sets expectation for the Repository.BanAuthor which will trigger the result defined by the following
 // Then helper
-func (mmContainsComment *mRepositoryMockContainsComment) When(ctx context.Context, id int) *RepositoryMockContainsCommentExpectation {
-	if mmContainsComment.mock.funcContainsComment != nil {
-		mmContainsComment.mock.t.Fatalf("RepositoryMock.ContainsComment mock is already set by Set")
+func (mmBanAuthor *mRepositoryMockBanAuthor) When(ctx context.Context, authorID int) *RepositoryMockBanAuthorExpectation {
+	if mmBanAuthor.mock.funcBanAuthor != nil {
+		mmBanAuthor.mock.t.Fatalf("RepositoryMock.BanAuthor mock is already set by Set")
 	}
 
-	expectation := &RepositoryMockContainsCommentExpectation{
-		mock:   mmContainsComment.mock,
-		params: &RepositoryMockContainsCommentParams{ctx, id},
+	expectation := &RepositoryMockBanAuthorExpectation{
+		mock:   mmBanAuthor.mock,
+		params: &RepositoryMockBanAuthorParams{ctx, authorID},
 	}
-	mmContainsComment.expectations = append(mmContainsComment.expectations, expectation)
+	mmBanAuthor.expectations = append(mmBanAuthor.expectations, expectation)
 	return expectation
 }
 
-// Then sets up Repository.ContainsComment return parameters for the expectation previously defined by the When method
-func (e *RepositoryMockContainsCommentExpectation) Then(b1 bool, err error) *RepositoryMock {
-	e.results = &RepositoryMockContainsCommentResults{b1, err}
+// Then sets up Repository.BanAuthor return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockBanAuthorExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockBanAuthorResults{err}
 	return e.mock
 }
 
-// Times sets number of times Repository.ContainsComment should be invoked
-func (mmContainsComment *mRepositoryMockContainsComment) Times(n uint64) *mRepositoryMockContainsComment {
+// Times sets number of times Repository.BanAuthor should be invoked
+func (mmBanAuthor *mRepositoryMockBanAuthor) Times(n uint64) *mRepositoryMockBanAuthor {
 	if n == 0 {
-		mmContainsComment.mock.t.Fatalf("Times of RepositoryMock.ContainsComment mock can not be zero")
+		mmBanAuthor.mock.t.Fatalf("Times of RepositoryMock.BanAuthor mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmContainsComment.expectedInvocations, n)
-	return mmContainsComment
+	mm_atomic.StoreUint64(&mmBanAuthor.expectedInvocations, n)
+	return mmBanAuthor
 }
 
-func (mmContainsComment *mRepositoryMockContainsComment) invocationsDone() bool {
-	if len(mmContainsComment.expectations) == 0 && mmContainsComment.defaultExpectation == nil && mmContainsComment.mock.funcContainsComment == nil {
+func (mmBanAuthor *mRepositoryMockBanAuthor) invocationsDone() bool {
+	if len(mmBanAuthor.expectations) == 0 && mmBanAuthor.defaultExpectation == nil && mmBanAuthor.mock.funcBanAuthor == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmContainsComment.mock.afterContainsCommentCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmContainsComment.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmBanAuthor.mock.afterBanAuthorCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmBanAuthor.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// ContainsComment implements repository.Repository
-func (mmContainsComment *RepositoryMock) ContainsComment(ctx context.Context, id int) (b1 bool, err error) {
-	mm_atomic.AddUint64(&mmContainsComment.beforeContainsCommentCounter, 1)
-	defer mm_atomic.AddUint64(&mmContainsComment.afterContainsCommentCounter, 1)
+// BanAuthor implements repository.Repository
+func (mmBanAuthor *RepositoryMock) BanAuthor(ctx context.Context, authorID int) (err error) {
+	mm_atomic.AddUint64(&mmBanAuthor.beforeBanAuthorCounter, 1)
+	defer mm_atomic.AddUint64(&mmBanAuthor.afterBanAuthorCounter, 1)
 
-	if mmContainsComment.inspectFuncContainsComment != nil {
-		mmContainsComment.inspectFuncContainsComment(ctx, id)
+	if mmBanAuthor.inspectFuncBanAuthor != nil {
+		mmBanAuthor.inspectFuncBanAuthor(ctx, authorID)
 	}
 
-	mm_params := RepositoryMockContainsCommentParams{ctx, id}
+	mm_params := RepositoryMockBanAuthorParams{ctx, authorID}
 
 	// Record call args
-	mmContainsComment.ContainsCommentMock.mutex.Lock()
-	mmContainsComment.ContainsCommentMock.callArgs = append(mmContainsComment.ContainsCommentMock.callArgs, &mm_params)
-	mmContainsComment.ContainsCommentMock.mutex.Unlock()
+	mmBanAuthor.BanAuthorMock.mutex.Lock()
+	mmBanAuthor.BanAuthorMock.callArgs = append(mmBanAuthor.BanAuthorMock.callArgs, &mm_params)
+	mmBanAuthor.BanAuthorMock.mutex.Unlock()
 
-	for _, e := range mmContainsComment.ContainsCommentMock.expectations {
+	for _, e := range mmBanAuthor.BanAuthorMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.b1, e.results.err
+			return e.results.err
 		}
 	}
 
-	if mmContainsComment.ContainsCommentMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmContainsComment.ContainsCommentMock.defaultExpectation.Counter, 1)
-		mm_want := mmContainsComment.ContainsCommentMock.defaultExpectation.params
-		mm_want_ptrs := mmContainsComment.ContainsCommentMock.defaultExpectation.paramPtrs
+	if mmBanAuthor.BanAuthorMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmBanAuthor.BanAuthorMock.defaultExpectation.Counter, 1)
+		mm_want := mmBanAuthor.BanAuthorMock.defaultExpectation.params
+		mm_want_ptrs := mmBanAuthor.BanAuthorMock.defaultExpectation.paramPtrs
 
-		mm_got := RepositoryMockContainsCommentParams{ctx, id}
+		mm_got := RepositoryMockBanAuthorParams{ctx, authorID}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmContainsComment.t.Errorf("RepositoryMock.ContainsComment got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmBanAuthor.t.Errorf("RepositoryMock.BanAuthor got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
-				mmContainsComment.t.Errorf("RepositoryMock.ContainsComment got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			if mm_want_ptrs.authorID != nil && !minimock.Equal(*mm_want_ptrs.authorID, mm_got.authorID) {
+				mmBanAuthor.t.Errorf("RepositoryMock.BanAuthor got unexpected parameter authorID, want: %#v, got: %#v%s\n", *mm_want_ptrs.authorID, mm_got.authorID, minimock.Diff(*mm_want_ptrs.authorID, mm_got.authorID))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmContainsComment.t.Errorf("RepositoryMock.ContainsComment got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmBanAuthor.t.Errorf("RepositoryMock.BanAuthor got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmContainsComment.ContainsCommentMock.defaultExpectation.results
+		mm_results := mmBanAuthor.BanAuthorMock.defaultExpectation.results
 		if mm_results == nil {
-			mmContainsComment.t.Fatal("No results are set for the RepositoryMock.ContainsComment")
+			mmBanAuthor.t.Fatal("No results are set for the RepositoryMock.BanAuthor")
 		}
-		return (*mm_results).b1, (*mm_results).err
+		return (*mm_results).err
 	}
-	if mmContainsComment.funcContainsComment != nil {
-		return mmContainsComment.funcContainsComment(ctx, id)
+	if mmBanAuthor.funcBanAuthor != nil {
+		return mmBanAuthor.funcBanAuthor(ctx, authorID)
 	}
-	mmContainsComment.t.Fatalf("Unexpected call to RepositoryMock.ContainsComment. %v %v", ctx, id)
+	mmBanAuthor.t.Fatalf("Unexpected call to RepositoryMock.BanAuthor. %v %v", ctx, authorID)
 	return
 }
 
-// ContainsCommentAfterCounter returns a count of finished RepositoryMock.ContainsComment invocations
-func (mmContainsComment *RepositoryMock) ContainsCommentAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmContainsComment.afterContainsCommentCounter)
+// BanAuthorAfterCounter returns a count of finished RepositoryMock.BanAuthor invocations
+func (mmBanAuthor *RepositoryMock) BanAuthorAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmBanAuthor.afterBanAuthorCounter)
 }
 
-// ContainsCommentBeforeCounter returns a count of RepositoryMock.ContainsComment invocations
-func (mmContainsComment *RepositoryMock) ContainsCommentBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmContainsComment.beforeContainsCommentCounter)
+// BanAuthorBeforeCounter returns a count of RepositoryMock.BanAuthor invocations
+func (mmBanAuthor *RepositoryMock) BanAuthorBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmBanAuthor.beforeBanAuthorCounter)
 }
 
-// Calls returns a list of arguments used in each call to RepositoryMock.ContainsComment.
+// Calls returns a list of arguments used in each call to RepositoryMock.BanAuthor.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmContainsComment *mRepositoryMockContainsComment) Calls() []*RepositoryMockContainsCommentParams {
-	mmContainsComment.mutex.RLock()
+func (mmBanAuthor *mRepositoryMockBanAuthor) Calls() []*RepositoryMockBanAuthorParams {
+	mmBanAuthor.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockContainsCommentParams, len(mmContainsComment.callArgs))
-	copy(argCopy, mmContainsComment.callArgs)
+	argCopy := make([]*RepositoryMockBanAuthorParams, len(mmBanAuthor.callArgs))
+	copy(argCopy, mmBanAuthor.callArgs)
 
-	mmContainsComment.mutex.RUnlock()
+	mmBanAuthor.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockContainsCommentDone returns true if the count of the ContainsComment invocations corresponds
+// MinimockBanAuthorDone returns true if the count of the BanAuthor invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockContainsCommentDone() bool {
-	for _, e := range m.ContainsCommentMock.expectations {
+func (m *RepositoryMock) MinimockBanAuthorDone() bool {
+	for _, e := range m.BanAuthorMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.ContainsCommentMock.invocationsDone()
+	return m.BanAuthorMock.invocationsDone()
 }
 
-// MinimockContainsCommentInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockContainsCommentInspect() {
-	for _, e := range m.ContainsCommentMock.expectations {
+// MinimockBanAuthorInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockBanAuthorInspect() {
+	for _, e := range m.BanAuthorMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.ContainsComment with params: %#v", *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.BanAuthor with params: %#v", *e.params)
 		}
 	}
 
-	afterContainsCommentCounter := mm_atomic.LoadUint64(&m.afterContainsCommentCounter)
+	afterBanAuthorCounter := mm_atomic.LoadUint64(&m.afterBanAuthorCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.ContainsCommentMock.defaultExpectation != nil && afterContainsCommentCounter < 1 {
-		if m.ContainsCommentMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to RepositoryMock.ContainsComment")
+	if m.BanAuthorMock.defaultExpectation != nil && afterBanAuthorCounter < 1 {
+		if m.BanAuthorMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.BanAuthor")
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.ContainsComment with params: %#v", *m.ContainsCommentMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.BanAuthor with params: %#v", *m.BanAuthorMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcContainsComment != nil && afterContainsCommentCounter < 1 {
-		m.t.Error("Expected call to RepositoryMock.ContainsComment")
+	if m.funcBanAuthor != nil && afterBanAuthorCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.BanAuthor")
 	}
 
-	if !m.ContainsCommentMock.invocationsDone() && afterContainsCommentCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.ContainsComment but found %d calls",
-			mm_atomic.LoadUint64(&m.ContainsCommentMock.expectedInvocations), afterContainsCommentCounter)
+	if !m.BanAuthorMock.invocationsDone() && afterBanAuthorCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.BanAuthor but found %d calls",
+			mm_atomic.LoadUint64(&m.BanAuthorMock.expectedInvocations), afterBanAuthorCounter)
 	}
 }
 
-type mRepositoryMockContainsPost struct {
+type mRepositoryMockClaimEvents struct {
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockContainsPostExpectation
-	expectations       []*RepositoryMockContainsPostExpectation
+	defaultExpectation *RepositoryMockClaimEventsExpectation
+	expectations       []*RepositoryMockClaimEventsExpectation
 
-	callArgs []*RepositoryMockContainsPostParams
+	callArgs []*RepositoryMockClaimEventsParams
 	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// RepositoryMockContainsPostExpectation specifies expectation struct of the Repository.ContainsPost
-type RepositoryMockContainsPostExpectation struct {
+// RepositoryMockClaimEventsExpectation specifies expectation struct of the Repository.ClaimEvents
+type RepositoryMockClaimEventsExpectation struct {
 	mock      *RepositoryMock
-	params    *RepositoryMockContainsPostParams
-	paramPtrs *RepositoryMockContainsPostParamPtrs
-	results   *RepositoryMockContainsPostResults
+	params    *RepositoryMockClaimEventsParams
+	paramPtrs *RepositoryMockClaimEventsParamPtrs
+	results   *RepositoryMockClaimEventsResults
 	Counter   uint64
 }
 
-// RepositoryMockContainsPostParams contains parameters of the Repository.ContainsPost
-type RepositoryMockContainsPostParams struct {
-	ctx context.Context
-	id  int
+// RepositoryMockClaimEventsParams contains parameters of the Repository.ClaimEvents
+type RepositoryMockClaimEventsParams struct {
+	ctx   context.Context
+	limit int
+	lease time.Duration
 }
 
-// RepositoryMockContainsPostParamPtrs contains pointers to parameters of the Repository.ContainsPost
-type RepositoryMockContainsPostParamPtrs struct {
-	ctx *context.Context
-	id  *int
+// RepositoryMockClaimEventsParamPtrs contains pointers to parameters of the Repository.ClaimEvents
+type RepositoryMockClaimEventsParamPtrs struct {
+	ctx   *context.Context
+	limit *int
+	lease *time.Duration
 }
 
-// RepositoryMockContainsPostResults contains results of the Repository.ContainsPost
-type RepositoryMockContainsPostResults struct {
-	b1  bool
-	err error
+// RepositoryMockClaimEventsResults contains results of the Repository.ClaimEvents
+type RepositoryMockClaimEventsResults struct {
+	epa1 []*domain.Event
+	err  error
 }
 
-// Expect sets up expected params for Repository.ContainsPost
-func (mmContainsPost *mRepositoryMockContainsPost) Expect(ctx context.Context, id int) *mRepositoryMockContainsPost {
-	if mmContainsPost.mock.funcContainsPost != nil {
-		mmContainsPost.mock.t.Fatalf("RepositoryMock.ContainsPost mock is already set by Set")
+// Expect sets up expected params for Repository.ClaimEvents
+func (mmClaimEvents *mRepositoryMockClaimEvents) Expect(ctx context.Context, limit int, lease time.Duration) *mRepositoryMockClaimEvents {
+	if mmClaimEvents.mock.funcClaimEvents != nil {
+		mmClaimEvents.mock.t.Fatalf("RepositoryMock.ClaimEvents mock is already set by Set")
 	}
 
-	if mmContainsPost.defaultExpectation == nil {
-		mmContainsPost.defaultExpectation = &RepositoryMockContainsPostExpectation{}
+	if mmClaimEvents.defaultExpectation == nil {
+		mmClaimEvents.defaultExpectation = &RepositoryMockClaimEventsExpectation{}
 	}
 
-	if mmContainsPost.defaultExpectation.paramPtrs != nil {
-		mmContainsPost.mock.t.Fatalf("RepositoryMock.ContainsPost mock is already set by ExpectParams functions")
+	if mmClaimEvents.defaultExpectation.paramPtrs != nil {
+		mmClaimEvents.mock.t.Fatalf("RepositoryMock.ClaimEvents mock is already set by ExpectParams functions")
 	}
 
-	mmContainsPost.defaultExpectation.params = &RepositoryMockContainsPostParams{ctx, id}
-	for _, e := range mmContainsPost.expectations {
-		if minimock.Equal(e.params, mmContainsPost.defaultExpectation.params) {
-			mmContainsPost.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmContainsPost.defaultExpectation.params)
+	mmClaimEvents.defaultExpectation.params = &RepositoryMockClaimEventsParams{ctx, limit, lease}
+	for _, e := range mmClaimEvents.expectations {
+		if minimock.Equal(e.params, mmClaimEvents.defaultExpectation.params) {
+			mmClaimEvents.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmClaimEvents.defaultExpectation.params)
 		}
 	}
 
-	return mmContainsPost
+	return mmClaimEvents
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ClaimEvents
+func (mmClaimEvents *mRepositoryMockClaimEvents) ExpectCtxParam1(ctx context.Context) *mRepositoryMockClaimEvents {
+	if mmClaimEvents.mock.funcClaimEvents != nil {
+		mmClaimEvents.mock.t.Fatalf("RepositoryMock.ClaimEvents mock is already set by Set")
+	}
+
+	if mmClaimEvents.defaultExpectation == nil {
+		mmClaimEvents.defaultExpectation = &RepositoryMockClaimEventsExpectation{}
+	}
+
+	if mmClaimEvents.defaultExpectation.params != nil {
+		mmClaimEvents.mock.t.Fatalf("RepositoryMock.ClaimEvents mock is already set by Expect")
+	}
+
+	if mmClaimEvents.defaultExpectation.paramPtrs == nil {
+		mmClaimEvents.defaultExpectation.paramPtrs = &RepositoryMockClaimEventsParamPtrs{}
+	}
+	mmClaimEvents.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmClaimEvents
 }
 
-// ExpectCtxParam1 sets up expected param ctx for Repository.ContainsPost
-func (mmContainsPost *mRepositoryMockContainsPost) ExpectCtxParam1(ctx context.Context) *mRepositoryMockContainsPost {
-	if mmContainsPost.mock.funcContainsPost != nil {
-		mmContainsPost.mock.t.Fatalf("RepositoryMock.ContainsPost mock is already set by Set")
+// ExpectLimitParam2 sets up expected param limit for Repository.ClaimEvents
+func (mmClaimEvents *mRepositoryMockClaimEvents) ExpectLimitParam2(limit int) *mRepositoryMockClaimEvents {
+	if mmClaimEvents.mock.funcClaimEvents != nil {
+		mmClaimEvents.mock.t.Fatalf("RepositoryMock.ClaimEvents mock is already set by Set")
 	}
 
-	if mmContainsPost.defaultExpectation == nil {
-		mmContainsPost.defaultExpectation = &RepositoryMockContainsPostExpectation{}
+	if mmClaimEvents.defaultExpectation == nil {
+		mmClaimEvents.defaultExpectation = &RepositoryMockClaimEventsExpectation{}
 	}
 
-	if mmContainsPost.defaultExpectation.params != nil {
-		mmContainsPost.mock.t.Fatalf("RepositoryMock.ContainsPost mock is already set by Expect")
+	if mmClaimEvents.defaultExpectation.params != nil {
+		mmClaimEvents.mock.t.Fatalf("RepositoryMock.ClaimEvents mock is already set by Expect")
 	}
 
-	if mmContainsPost.defaultExpectation.paramPtrs == nil {
-		mmContainsPost.defaultExpectation.paramPtrs = &RepositoryMockContainsPostParamPtrs{}
+	if mmClaimEvents.defaultExpectation.paramPtrs == nil {
+		mmClaimEvents.defaultExpectation.paramPtrs = &RepositoryMockClaimEventsParamPtrs{}
 	}
-	mmContainsPost.defaultExpectation.paramPtrs.ctx = &ctx
+	mmClaimEvents.defaultExpectation.paramPtrs.limit = &limit
 
-	return mmContainsPost
+	return mmClaimEvents
 }
 
-// ExpectIdParam2 sets up expected param id for Repository.ContainsPost
-func (mmContainsPost *mRepositoryMockContainsPost) ExpectIdParam2(id int) *mRepositoryMockContainsPost {
-	if mmContainsPost.mock.funcContainsPost != nil {
-		mmContainsPost.mock.t.Fatalf("RepositoryMock.ContainsPost mock is already set by Set")
+// ExpectLeaseParam3 sets up expected param lease for Repository.ClaimEvents
+func (mmClaimEvents *mRepositoryMockClaimEvents) ExpectLeaseParam3(lease time.Duration) *mRepositoryMockClaimEvents {
+	if mmClaimEvents.mock.funcClaimEvents != nil {
+		mmClaimEvents.mock.t.Fatalf("RepositoryMock.ClaimEvents mock is already set by Set")
 	}
 
-	if mmContainsPost.defaultExpectation == nil {
-		mmContainsPost.defaultExpectation = &RepositoryMockContainsPostExpectation{}
+	if mmClaimEvents.defaultExpectation == nil {
+		mmClaimEvents.defaultExpectation = &RepositoryMockClaimEventsExpectation{}
 	}
 
-	if mmContainsPost.defaultExpectation.params != nil {
-		mmContainsPost.mock.t.Fatalf("RepositoryMock.ContainsPost mock is already set by Expect")
+	if mmClaimEvents.defaultExpectation.params != nil {
+		mmClaimEvents.mock.t.Fatalf("RepositoryMock.ClaimEvents mock is already set by Expect")
 	}
 
-	if mmContainsPost.defaultExpectation.paramPtrs == nil {
-		mmContainsPost.defaultExpectation.paramPtrs = &RepositoryMockContainsPostParamPtrs{}
+	if mmClaimEvents.defaultExpectation.paramPtrs == nil {
+		mmClaimEvents.defaultExpectation.paramPtrs = &RepositoryMockClaimEventsParamPtrs{}
 	}
-	mmContainsPost.defaultExpectation.paramPtrs.id = &id
+	mmClaimEvents.defaultExpectation.paramPtrs.lease = &lease
 
-	return mmContainsPost
+	return mmClaimEvents
 }
 
-// Inspect accepts an inspector function that has same arguments as the Repository.ContainsPost
-func (mmContainsPost *mRepositoryMockContainsPost) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockContainsPost {
-	if mmContainsPost.mock.inspectFuncContainsPost != nil {
-		mmContainsPost.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ContainsPost")
+// Inspect accepts an inspector function that has same arguments as the Repository.ClaimEvents
+func (mmClaimEvents *mRepositoryMockClaimEvents) Inspect(f func(ctx context.Context, limit int, lease time.Duration)) *mRepositoryMockClaimEvents {
+	if mmClaimEvents.mock.inspectFuncClaimEvents != nil {
+		mmClaimEvents.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ClaimEvents")
 	}
 
-	mmContainsPost.mock.inspectFuncContainsPost = f
+	mmClaimEvents.mock.inspectFuncClaimEvents = f
 
-	return mmContainsPost
+	return mmClaimEvents
 }
 
-// Return sets up results that will be returned by Repository.ContainsPost
-func (mmContainsPost *mRepositoryMockContainsPost) Return(b1 bool, err error) *RepositoryMock {
-	if mmContainsPost.mock.funcContainsPost != nil {
-		mmContainsPost.mock.t.Fatalf("RepositoryMock.ContainsPost mock is already set by Set")
+// Return sets up results that will be returned by Repository.ClaimEvents
+func (mmClaimEvents *mRepositoryMockClaimEvents) Return(epa1 []*domain.Event, err error) *RepositoryMock {
+	if mmClaimEvents.mock.funcClaimEvents != nil {
+		mmClaimEvents.mock.t.Fatalf("RepositoryMock.ClaimEvents mock is already set by Set")
 	}
 
-	if mmContainsPost.defaultExpectation == nil {
-		mmContainsPost.defaultExpectation = &RepositoryMockContainsPostExpectation{mock: mmContainsPost.mock}
+	if mmClaimEvents.defaultExpectation == nil {
+		mmClaimEvents.defaultExpectation = &RepositoryMockClaimEventsExpectation{mock: mmClaimEvents.mock}
 	}
-	mmContainsPost.defaultExpectation.results = &RepositoryMockContainsPostResults{b1, err}
-	return mmContainsPost.mock
+	mmClaimEvents.defaultExpectation.results = &RepositoryMockClaimEventsResults{epa1, err}
+	return mmClaimEvents.mock
 }
 
-// Set uses given function f to mock the Repository.ContainsPost method
-func (mmContainsPost *mRepositoryMockContainsPost) Set(f func(ctx context.Context, id int) (b1 bool, err error)) *RepositoryMock {
-	if mmContainsPost.defaultExpectation != nil {
-		mmContainsPost.mock.t.Fatalf("Default expectation is already set for the Repository.ContainsPost method")
+// Set uses given function f to mock the Repository.ClaimEvents method
+func (mmClaimEvents *mRepositoryMockClaimEvents) Set(f func(ctx context.Context, limit int, lease time.Duration) (epa1 []*domain.Event, err error)) *RepositoryMock {
+	if mmClaimEvents.defaultExpectation != nil {
+		mmClaimEvents.mock.t.Fatalf("Default expectation is already set for the Repository.ClaimEvents method")
 	}
 
-	if len(mmContainsPost.expectations) > 0 {
-		mmContainsPost.mock.t.Fatalf("Some expectations are already set for the Repository.ContainsPost method")
+	if len(mmClaimEvents.expectations) > 0 {
+		mmClaimEvents.mock.t.Fatalf("Some expectations are already set for the Repository.ClaimEvents method")
 	}
 
-	mmContainsPost.mock.funcContainsPost = f
-	return mmContainsPost.mock
+	mmClaimEvents.mock.funcClaimEvents = f
+	return mmClaimEvents.mock
 }
 
-// When sets expectation for the Repository.ContainsPost which will trigger the result defined by the following
+// When sets expectation for the Repository.ClaimEvents which will trigger the result defined by the following
 // Then helper
-func (mmContainsPost *mRepositoryMockContainsPost) When(ctx context.Context, id int) *RepositoryMockContainsPostExpectation {
-	if mmContainsPost.mock.funcContainsPost != nil {
-		mmContainsPost.mock.t.Fatalf("RepositoryMock.ContainsPost mock is already set by Set")
+func (mmClaimEvents *mRepositoryMockClaimEvents) When(ctx context.Context, limit int, lease time.Duration) *RepositoryMockClaimEventsExpectation {
+	if mmClaimEvents.mock.funcClaimEvents != nil {
+		mmClaimEvents.mock.t.Fatalf("RepositoryMock.ClaimEvents mock is already set by Set")
 	}
 
-	expectation := &RepositoryMockContainsPostExpectation{
-		mock:   mmContainsPost.mock,
-		params: &RepositoryMockContainsPostParams{ctx, id},
+	expectation := &RepositoryMockClaimEventsExpectation{
+		mock:   mmClaimEvents.mock,
+		params: &RepositoryMockClaimEventsParams{ctx, limit, lease},
 	}
-	mmContainsPost.expectations = append(mmContainsPost.expectations, expectation)
+	mmClaimEvents.expectations = append(mmClaimEvents.expectations, expectation)
 	return expectation
 }
 
-// Then sets up Repository.ContainsPost return parameters for the expectation previously defined by the When method
-func (e *RepositoryMockContainsPostExpectation) Then(b1 bool, err error) *RepositoryMock {
-	e.results = &RepositoryMockContainsPostResults{b1, err}
+// Then sets up Repository.ClaimEvents return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockClaimEventsExpectation) Then(epa1 []*domain.Event, err error) *RepositoryMock {
+	e.results = &RepositoryMockClaimEventsResults{epa1, err}
 	return e.mock
 }
 
-// Times sets number of times Repository.ContainsPost should be invoked
-func (mmContainsPost *mRepositoryMockContainsPost) Times(n uint64) *mRepositoryMockContainsPost {
+// Times sets number of times Repository.ClaimEvents should be invoked
+func (mmClaimEvents *mRepositoryMockClaimEvents) Times(n uint64) *mRepositoryMockClaimEvents {
 	if n == 0 {
-		mmContainsPost.mock.t.Fatalf("Times of RepositoryMock.ContainsPost mock can not be zero")
+		mmClaimEvents.mock.t.Fatalf("Times of RepositoryMock.ClaimEvents mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmContainsPost.expectedInvocations, n)
-	return mmContainsPost
+	mm_atomic.StoreUint64(&mmClaimEvents.expectedInvocations, n)
+	return mmClaimEvents
 }
 
-func (mmContainsPost *mRepositoryMockContainsPost) invocationsDone() bool {
-	if len(mmContainsPost.expectations) == 0 && mmContainsPost.defaultExpectation == nil && mmContainsPost.mock.funcContainsPost == nil {
+func (mmClaimEvents *mRepositoryMockClaimEvents) invocationsDone() bool {
+	if len(mmClaimEvents.expectations) == 0 && mmClaimEvents.defaultExpectation == nil && mmClaimEvents.mock.funcClaimEvents == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmContainsPost.mock.afterContainsPostCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmContainsPost.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmClaimEvents.mock.afterClaimEventsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmClaimEvents.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// ContainsPost implements repository.Repository
-func (mmContainsPost *RepositoryMock) ContainsPost(ctx context.Context, id int) (b1 bool, err error) {
-	mm_atomic.AddUint64(&mmContainsPost.beforeContainsPostCounter, 1)
-	defer mm_atomic.AddUint64(&mmContainsPost.afterContainsPostCounter, 1)
+// ClaimEvents implements repository.Repository
+func (mmClaimEvents *RepositoryMock) ClaimEvents(ctx context.Context, limit int, lease time.Duration) (epa1 []*domain.Event, err error) {
+	mm_atomic.AddUint64(&mmClaimEvents.beforeClaimEventsCounter, 1)
+	defer mm_atomic.AddUint64(&mmClaimEvents.afterClaimEventsCounter, 1)
 
-	if mmContainsPost.inspectFuncContainsPost != nil {
-		mmContainsPost.inspectFuncContainsPost(ctx, id)
+	if mmClaimEvents.inspectFuncClaimEvents != nil {
+		mmClaimEvents.inspectFuncClaimEvents(ctx, limit, lease)
 	}
 
-	mm_params := RepositoryMockContainsPostParams{ctx, id}
+	mm_params := RepositoryMockClaimEventsParams{ctx, limit, lease}
 
 	// Record call args
-	mmContainsPost.ContainsPostMock.mutex.Lock()
-	mmContainsPost.ContainsPostMock.callArgs = append(mmContainsPost.ContainsPostMock.callArgs, &mm_params)
-	mmContainsPost.ContainsPostMock.mutex.Unlock()
+	mmClaimEvents.ClaimEventsMock.mutex.Lock()
+	mmClaimEvents.ClaimEventsMock.callArgs = append(mmClaimEvents.ClaimEventsMock.callArgs, &mm_params)
+	mmClaimEvents.ClaimEventsMock.mutex.Unlock()
 
-	for _, e := range mmContainsPost.ContainsPostMock.expectations {
+	for _, e := range mmClaimEvents.ClaimEventsMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.b1, e.results.err
+			return e.results.epa1, e.results.err
 		}
 	}
 
-	if mmContainsPost.ContainsPostMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmContainsPost.ContainsPostMock.defaultExpectation.Counter, 1)
-		mm_want := mmContainsPost.ContainsPostMock.defaultExpectation.params
-		mm_want_ptrs := mmContainsPost.ContainsPostMock.defaultExpectation.paramPtrs
+	if mmClaimEvents.ClaimEventsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmClaimEvents.ClaimEventsMock.defaultExpectation.Counter, 1)
+		mm_want := mmClaimEvents.ClaimEventsMock.defaultExpectation.params
+		mm_want_ptrs := mmClaimEvents.ClaimEventsMock.defaultExpectation.paramPtrs
 
-		mm_got := RepositoryMockContainsPostParams{ctx, id}
+		mm_got := RepositoryMockClaimEventsParams{ctx, limit, lease}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmContainsPost.t.Errorf("RepositoryMock.ContainsPost got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmClaimEvents.t.Errorf("RepositoryMock.ClaimEvents got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
-				mmContainsPost.t.Errorf("RepositoryMock.ContainsPost got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmClaimEvents.t.Errorf("RepositoryMock.ClaimEvents got unexpected parameter limit, want: %#v, got: %#v%s\n", *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+			if mm_want_ptrs.lease != nil && !minimock.Equal(*mm_want_ptrs.lease, mm_got.lease) {
+				mmClaimEvents.t.Errorf("RepositoryMock.ClaimEvents got unexpected parameter lease, want: %#v, got: %#v%s\n", *mm_want_ptrs.lease, mm_got.lease, minimock.Diff(*mm_want_ptrs.lease, mm_got.lease))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmContainsPost.t.Errorf("RepositoryMock.ContainsPost got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmClaimEvents.t.Errorf("RepositoryMock.ClaimEvents got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmContainsPost.ContainsPostMock.defaultExpectation.results
+		mm_results := mmClaimEvents.ClaimEventsMock.defaultExpectation.results
 		if mm_results == nil {
-			mmContainsPost.t.Fatal("No results are set for the RepositoryMock.ContainsPost")
+			mmClaimEvents.t.Fatal("No results are set for the RepositoryMock.ClaimEvents")
 		}
-		return (*mm_results).b1, (*mm_results).err
+		return (*mm_results).epa1, (*mm_results).err
+	}
+	if mmClaimEvents.funcClaimEvents != nil {
+		return mmClaimEvents.funcClaimEvents(ctx, limit, lease)
+	}
+	mmClaimEvents.t.Fatalf("Unexpected call to RepositoryMock.ClaimEvents. %v %v %v", ctx, limit, lease)
+	return
+}
+
+// ClaimEventsAfterCounter returns a count of finished RepositoryMock.ClaimEvents invocations
+func (mmClaimEvents *RepositoryMock) ClaimEventsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmClaimEvents.afterClaimEventsCounter)
+}
+
+// ClaimEventsBeforeCounter returns a count of RepositoryMock.ClaimEvents invocations
+func (mmClaimEvents *RepositoryMock) ClaimEventsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmClaimEvents.beforeClaimEventsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.ClaimEvents.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmClaimEvents *mRepositoryMockClaimEvents) Calls() []*RepositoryMockClaimEventsParams {
+	mmClaimEvents.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockClaimEventsParams, len(mmClaimEvents.callArgs))
+	copy(argCopy, mmClaimEvents.callArgs)
+
+	mmClaimEvents.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockClaimEventsDone returns true if the count of the ClaimEvents invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockClaimEventsDone() bool {
+	for _, e := range m.ClaimEventsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ClaimEventsMock.invocationsDone()
+}
+
+// MinimockClaimEventsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockClaimEventsInspect() {
+	for _, e := range m.ClaimEventsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.ClaimEvents with params: %#v", *e.params)
+		}
+	}
+
+	afterClaimEventsCounter := mm_atomic.LoadUint64(&m.afterClaimEventsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ClaimEventsMock.defaultExpectation != nil && afterClaimEventsCounter < 1 {
+		if m.ClaimEventsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.ClaimEvents")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.ClaimEvents with params: %#v", *m.ClaimEventsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcClaimEvents != nil && afterClaimEventsCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.ClaimEvents")
+	}
+
+	if !m.ClaimEventsMock.invocationsDone() && afterClaimEventsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ClaimEvents but found %d calls",
+			mm_atomic.LoadUint64(&m.ClaimEventsMock.expectedInvocations), afterClaimEventsCounter)
+	}
+}
+
+type mRepositoryMockClaimWebhookDeliveries struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockClaimWebhookDeliveriesExpectation
+	expectations       []*RepositoryMockClaimWebhookDeliveriesExpectation
+
+	callArgs []*RepositoryMockClaimWebhookDeliveriesParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockClaimWebhookDeliveriesExpectation specifies expectation struct of the Repository.ClaimWebhookDeliveries
+type RepositoryMockClaimWebhookDeliveriesExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockClaimWebhookDeliveriesParams
+	paramPtrs *RepositoryMockClaimWebhookDeliveriesParamPtrs
+	results   *RepositoryMockClaimWebhookDeliveriesResults
+	Counter   uint64
+}
+
+// RepositoryMockClaimWebhookDeliveriesParams contains parameters of the Repository.ClaimWebhookDeliveries
+type RepositoryMockClaimWebhookDeliveriesParams struct {
+	ctx   context.Context
+	limit int
+	lease time.Duration
+}
+
+// RepositoryMockClaimWebhookDeliveriesParamPtrs contains pointers to parameters of the Repository.ClaimWebhookDeliveries
+type RepositoryMockClaimWebhookDeliveriesParamPtrs struct {
+	ctx   *context.Context
+	limit *int
+	lease *time.Duration
+}
+
+// RepositoryMockClaimWebhookDeliveriesResults contains results of the Repository.ClaimWebhookDeliveries
+type RepositoryMockClaimWebhookDeliveriesResults struct {
+	wpa1 []*domain.WebhookDelivery
+	err  error
+}
+
+// Expect sets up expected params for Repository.ClaimWebhookDeliveries
+func (mmClaimWebhookDeliveries *mRepositoryMockClaimWebhookDeliveries) Expect(ctx context.Context, limit int, lease time.Duration) *mRepositoryMockClaimWebhookDeliveries {
+	if mmClaimWebhookDeliveries.mock.funcClaimWebhookDeliveries != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("RepositoryMock.ClaimWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation == nil {
+		mmClaimWebhookDeliveries.defaultExpectation = &RepositoryMockClaimWebhookDeliveriesExpectation{}
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation.paramPtrs != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("RepositoryMock.ClaimWebhookDeliveries mock is already set by ExpectParams functions")
+	}
+
+	mmClaimWebhookDeliveries.defaultExpectation.params = &RepositoryMockClaimWebhookDeliveriesParams{ctx, limit, lease}
+	for _, e := range mmClaimWebhookDeliveries.expectations {
+		if minimock.Equal(e.params, mmClaimWebhookDeliveries.defaultExpectation.params) {
+			mmClaimWebhookDeliveries.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmClaimWebhookDeliveries.defaultExpectation.params)
+		}
+	}
+
+	return mmClaimWebhookDeliveries
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ClaimWebhookDeliveries
+func (mmClaimWebhookDeliveries *mRepositoryMockClaimWebhookDeliveries) ExpectCtxParam1(ctx context.Context) *mRepositoryMockClaimWebhookDeliveries {
+	if mmClaimWebhookDeliveries.mock.funcClaimWebhookDeliveries != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("RepositoryMock.ClaimWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation == nil {
+		mmClaimWebhookDeliveries.defaultExpectation = &RepositoryMockClaimWebhookDeliveriesExpectation{}
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation.params != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("RepositoryMock.ClaimWebhookDeliveries mock is already set by Expect")
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation.paramPtrs == nil {
+		mmClaimWebhookDeliveries.defaultExpectation.paramPtrs = &RepositoryMockClaimWebhookDeliveriesParamPtrs{}
+	}
+	mmClaimWebhookDeliveries.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmClaimWebhookDeliveries
+}
+
+// ExpectLimitParam2 sets up expected param limit for Repository.ClaimWebhookDeliveries
+func (mmClaimWebhookDeliveries *mRepositoryMockClaimWebhookDeliveries) ExpectLimitParam2(limit int) *mRepositoryMockClaimWebhookDeliveries {
+	if mmClaimWebhookDeliveries.mock.funcClaimWebhookDeliveries != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("RepositoryMock.ClaimWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation == nil {
+		mmClaimWebhookDeliveries.defaultExpectation = &RepositoryMockClaimWebhookDeliveriesExpectation{}
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation.params != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("RepositoryMock.ClaimWebhookDeliveries mock is already set by Expect")
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation.paramPtrs == nil {
+		mmClaimWebhookDeliveries.defaultExpectation.paramPtrs = &RepositoryMockClaimWebhookDeliveriesParamPtrs{}
+	}
+	mmClaimWebhookDeliveries.defaultExpectation.paramPtrs.limit = &limit
+
+	return mmClaimWebhookDeliveries
+}
+
+// ExpectLeaseParam3 sets up expected param lease for Repository.ClaimWebhookDeliveries
+func (mmClaimWebhookDeliveries *mRepositoryMockClaimWebhookDeliveries) ExpectLeaseParam3(lease time.Duration) *mRepositoryMockClaimWebhookDeliveries {
+	if mmClaimWebhookDeliveries.mock.funcClaimWebhookDeliveries != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("RepositoryMock.ClaimWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation == nil {
+		mmClaimWebhookDeliveries.defaultExpectation = &RepositoryMockClaimWebhookDeliveriesExpectation{}
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation.params != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("RepositoryMock.ClaimWebhookDeliveries mock is already set by Expect")
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation.paramPtrs == nil {
+		mmClaimWebhookDeliveries.defaultExpectation.paramPtrs = &RepositoryMockClaimWebhookDeliveriesParamPtrs{}
+	}
+	mmClaimWebhookDeliveries.defaultExpectation.paramPtrs.lease = &lease
+
+	return mmClaimWebhookDeliveries
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.ClaimWebhookDeliveries
+func (mmClaimWebhookDeliveries *mRepositoryMockClaimWebhookDeliveries) Inspect(f func(ctx context.Context, limit int, lease time.Duration)) *mRepositoryMockClaimWebhookDeliveries {
+	if mmClaimWebhookDeliveries.mock.inspectFuncClaimWebhookDeliveries != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ClaimWebhookDeliveries")
+	}
+
+	mmClaimWebhookDeliveries.mock.inspectFuncClaimWebhookDeliveries = f
+
+	return mmClaimWebhookDeliveries
+}
+
+// Return sets up results that will be returned by Repository.ClaimWebhookDeliveries
+func (mmClaimWebhookDeliveries *mRepositoryMockClaimWebhookDeliveries) Return(wpa1 []*domain.WebhookDelivery, err error) *RepositoryMock {
+	if mmClaimWebhookDeliveries.mock.funcClaimWebhookDeliveries != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("RepositoryMock.ClaimWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmClaimWebhookDeliveries.defaultExpectation == nil {
+		mmClaimWebhookDeliveries.defaultExpectation = &RepositoryMockClaimWebhookDeliveriesExpectation{mock: mmClaimWebhookDeliveries.mock}
+	}
+	mmClaimWebhookDeliveries.defaultExpectation.results = &RepositoryMockClaimWebhookDeliveriesResults{wpa1, err}
+	return mmClaimWebhookDeliveries.mock
+}
+
+// Set uses given function f to mock the Repository.ClaimWebhookDeliveries method
+func (mmClaimWebhookDeliveries *mRepositoryMockClaimWebhookDeliveries) Set(f func(ctx context.Context, limit int, lease time.Duration) (wpa1 []*domain.WebhookDelivery, err error)) *RepositoryMock {
+	if mmClaimWebhookDeliveries.defaultExpectation != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("Default expectation is already set for the Repository.ClaimWebhookDeliveries method")
+	}
+
+	if len(mmClaimWebhookDeliveries.expectations) > 0 {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("Some expectations are already set for the Repository.ClaimWebhookDeliveries method")
+	}
+
+	mmClaimWebhookDeliveries.mock.funcClaimWebhookDeliveries = f
+	return mmClaimWebhookDeliveries.mock
+}
+
+// When sets expectation for the Repository.ClaimWebhookDeliveries which will trigger the result defined by the following
+// Then helper
+func (mmClaimWebhookDeliveries *mRepositoryMockClaimWebhookDeliveries) When(ctx context.Context, limit int, lease time.Duration) *RepositoryMockClaimWebhookDeliveriesExpectation {
+	if mmClaimWebhookDeliveries.mock.funcClaimWebhookDeliveries != nil {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("RepositoryMock.ClaimWebhookDeliveries mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockClaimWebhookDeliveriesExpectation{
+		mock:   mmClaimWebhookDeliveries.mock,
+		params: &RepositoryMockClaimWebhookDeliveriesParams{ctx, limit, lease},
+	}
+	mmClaimWebhookDeliveries.expectations = append(mmClaimWebhookDeliveries.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.ClaimWebhookDeliveries return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockClaimWebhookDeliveriesExpectation) Then(wpa1 []*domain.WebhookDelivery, err error) *RepositoryMock {
+	e.results = &RepositoryMockClaimWebhookDeliveriesResults{wpa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.ClaimWebhookDeliveries should be invoked
+func (mmClaimWebhookDeliveries *mRepositoryMockClaimWebhookDeliveries) Times(n uint64) *mRepositoryMockClaimWebhookDeliveries {
+	if n == 0 {
+		mmClaimWebhookDeliveries.mock.t.Fatalf("Times of RepositoryMock.ClaimWebhookDeliveries mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmClaimWebhookDeliveries.expectedInvocations, n)
+	return mmClaimWebhookDeliveries
+}
+
+func (mmClaimWebhookDeliveries *mRepositoryMockClaimWebhookDeliveries) invocationsDone() bool {
+	if len(mmClaimWebhookDeliveries.expectations) == 0 && mmClaimWebhookDeliveries.defaultExpectation == nil && mmClaimWebhookDeliveries.mock.funcClaimWebhookDeliveries == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmClaimWebhookDeliveries.mock.afterClaimWebhookDeliveriesCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmClaimWebhookDeliveries.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ClaimWebhookDeliveries implements repository.Repository
+func (mmClaimWebhookDeliveries *RepositoryMock) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) (wpa1 []*domain.WebhookDelivery, err error) {
+	mm_atomic.AddUint64(&mmClaimWebhookDeliveries.beforeClaimWebhookDeliveriesCounter, 1)
+	defer mm_atomic.AddUint64(&mmClaimWebhookDeliveries.afterClaimWebhookDeliveriesCounter, 1)
+
+	if mmClaimWebhookDeliveries.inspectFuncClaimWebhookDeliveries != nil {
+		mmClaimWebhookDeliveries.inspectFuncClaimWebhookDeliveries(ctx, limit, lease)
+	}
+
+	mm_params := RepositoryMockClaimWebhookDeliveriesParams{ctx, limit, lease}
+
+	// Record call args
+	mmClaimWebhookDeliveries.ClaimWebhookDeliveriesMock.mutex.Lock()
+	mmClaimWebhookDeliveries.ClaimWebhookDeliveriesMock.callArgs = append(mmClaimWebhookDeliveries.ClaimWebhookDeliveriesMock.callArgs, &mm_params)
+	mmClaimWebhookDeliveries.ClaimWebhookDeliveriesMock.mutex.Unlock()
+
+	for _, e := range mmClaimWebhookDeliveries.ClaimWebhookDeliveriesMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.wpa1, e.results.err
+		}
+	}
+
+	if mmClaimWebhookDeliveries.ClaimWebhookDeliveriesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmClaimWebhookDeliveries.ClaimWebhookDeliveriesMock.defaultExpectation.Counter, 1)
+		mm_want := mmClaimWebhookDeliveries.ClaimWebhookDeliveriesMock.defaultExpectation.params
+		mm_want_ptrs := mmClaimWebhookDeliveries.ClaimWebhookDeliveriesMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockClaimWebhookDeliveriesParams{ctx, limit, lease}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmClaimWebhookDeliveries.t.Errorf("RepositoryMock.ClaimWebhookDeliveries got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmClaimWebhookDeliveries.t.Errorf("RepositoryMock.ClaimWebhookDeliveries got unexpected parameter limit, want: %#v, got: %#v%s\n", *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+			if mm_want_ptrs.lease != nil && !minimock.Equal(*mm_want_ptrs.lease, mm_got.lease) {
+				mmClaimWebhookDeliveries.t.Errorf("RepositoryMock.ClaimWebhookDeliveries got unexpected parameter lease, want: %#v, got: %#v%s\n", *mm_want_ptrs.lease, mm_got.lease, minimock.Diff(*mm_want_ptrs.lease, mm_got.lease))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmClaimWebhookDeliveries.t.Errorf("RepositoryMock.ClaimWebhookDeliveries got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmClaimWebhookDeliveries.ClaimWebhookDeliveriesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmClaimWebhookDeliveries.t.Fatal("No results are set for the RepositoryMock.ClaimWebhookDeliveries")
+		}
+		return (*mm_results).wpa1, (*mm_results).err
 	}
-	if mmContainsPost.funcContainsPost != nil {
-		return mmContainsPost.funcContainsPost(ctx, id)
+	if mmClaimWebhookDeliveries.funcClaimWebhookDeliveries != nil {
+		return mmClaimWebhookDeliveries.funcClaimWebhookDeliveries(ctx, limit, lease)
 	}
-	mmContainsPost.t.Fatalf("Unexpected call to RepositoryMock.ContainsPost. %v %v", ctx, id)
+	mmClaimWebhookDeliveries.t.Fatalf("Unexpected call to RepositoryMock.ClaimWebhookDeliveries. %v %v %v", ctx, limit, lease)
 	return
 }
 
-// ContainsPostAfterCounter returns a count of finished RepositoryMock.ContainsPost invocations
-func (mmContainsPost *RepositoryMock) ContainsPostAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmContainsPost.afterContainsPostCounter)
+// ClaimWebhookDeliveriesAfterCounter returns a count of finished RepositoryMock.ClaimWebhookDeliveries invocations
+func (mmClaimWebhookDeliveries *RepositoryMock) ClaimWebhookDeliveriesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmClaimWebhookDeliveries.afterClaimWebhookDeliveriesCounter)
 }
 
-// ContainsPostBeforeCounter returns a count of RepositoryMock.ContainsPost invocations
-func (mmContainsPost *RepositoryMock) ContainsPostBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmContainsPost.beforeContainsPostCounter)
+// ClaimWebhookDeliveriesBeforeCounter returns a count of RepositoryMock.ClaimWebhookDeliveries invocations
+func (mmClaimWebhookDeliveries *RepositoryMock) ClaimWebhookDeliveriesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmClaimWebhookDeliveries.beforeClaimWebhookDeliveriesCounter)
 }
 
-// Calls returns a list of arguments used in each call to RepositoryMock.ContainsPost.
+// Calls returns a list of arguments used in each call to RepositoryMock.ClaimWebhookDeliveries.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmContainsPost *mRepositoryMockContainsPost) Calls() []*RepositoryMockContainsPostParams {
-	mmContainsPost.mutex.RLock()
+func (mmClaimWebhookDeliveries *mRepositoryMockClaimWebhookDeliveries) Calls() []*RepositoryMockClaimWebhookDeliveriesParams {
+	mmClaimWebhookDeliveries.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockContainsPostParams, len(mmContainsPost.callArgs))
-	copy(argCopy, mmContainsPost.callArgs)
+	argCopy := make([]*RepositoryMockClaimWebhookDeliveriesParams, len(mmClaimWebhookDeliveries.callArgs))
+	copy(argCopy, mmClaimWebhookDeliveries.callArgs)
 
-	mmContainsPost.mutex.RUnlock()
+	mmClaimWebhookDeliveries.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockContainsPostDone returns true if the count of the ContainsPost invocations corresponds
+// MinimockClaimWebhookDeliveriesDone returns true if the count of the ClaimWebhookDeliveries invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockContainsPostDone() bool {
-	for _, e := range m.ContainsPostMock.expectations {
+func (m *RepositoryMock) MinimockClaimWebhookDeliveriesDone() bool {
+	for _, e := range m.ClaimWebhookDeliveriesMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.ContainsPostMock.invocationsDone()
+	return m.ClaimWebhookDeliveriesMock.invocationsDone()
 }
 
-// MinimockContainsPostInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockContainsPostInspect() {
-	for _, e := range m.ContainsPostMock.expectations {
+// MinimockClaimWebhookDeliveriesInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockClaimWebhookDeliveriesInspect() {
+	for _, e := range m.ClaimWebhookDeliveriesMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.ContainsPost with params: %#v", *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.ClaimWebhookDeliveries with params: %#v", *e.params)
 		}
 	}
 
-	afterContainsPostCounter := mm_atomic.LoadUint64(&m.afterContainsPostCounter)
+	afterClaimWebhookDeliveriesCounter := mm_atomic.LoadUint64(&m.afterClaimWebhookDeliveriesCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.ContainsPostMock.defaultExpectation != nil && afterContainsPostCounter < 1 {
-		if m.ContainsPostMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to RepositoryMock.ContainsPost")
+	if m.ClaimWebhookDeliveriesMock.defaultExpectation != nil && afterClaimWebhookDeliveriesCounter < 1 {
+		if m.ClaimWebhookDeliveriesMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.ClaimWebhookDeliveries")
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.ContainsPost with params: %#v", *m.ContainsPostMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.ClaimWebhookDeliveries with params: %#v", *m.ClaimWebhookDeliveriesMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcContainsPost != nil && afterContainsPostCounter < 1 {
-		m.t.Error("Expected call to RepositoryMock.ContainsPost")
+	if m.funcClaimWebhookDeliveries != nil && afterClaimWebhookDeliveriesCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.ClaimWebhookDeliveries")
 	}
 
-	if !m.ContainsPostMock.invocationsDone() && afterContainsPostCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.ContainsPost but found %d calls",
-			mm_atomic.LoadUint64(&m.ContainsPostMock.expectedInvocations), afterContainsPostCounter)
+	if !m.ClaimWebhookDeliveriesMock.invocationsDone() && afterClaimWebhookDeliveriesCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ClaimWebhookDeliveries but found %d calls",
+			mm_atomic.LoadUint64(&m.ClaimWebhookDeliveriesMock.expectedInvocations), afterClaimWebhookDeliveriesCounter)
 	}
 }
 
@@ -1029,51 +1634,356 @@ func (m *RepositoryMock) MinimockCreateCommentInspect() {
 	}
 }
 
-type mRepositoryMockCreatePost struct {
+type mRepositoryMockCreateEvent struct {
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockCreatePostExpectation
-	expectations       []*RepositoryMockCreatePostExpectation
+	defaultExpectation *RepositoryMockCreateEventExpectation
+	expectations       []*RepositoryMockCreateEventExpectation
 
-	callArgs []*RepositoryMockCreatePostParams
+	callArgs []*RepositoryMockCreateEventParams
 	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// RepositoryMockCreatePostExpectation specifies expectation struct of the Repository.CreatePost
-type RepositoryMockCreatePostExpectation struct {
+// RepositoryMockCreateEventExpectation specifies expectation struct of the Repository.CreateEvent
+type RepositoryMockCreateEventExpectation struct {
 	mock      *RepositoryMock
-	params    *RepositoryMockCreatePostParams
-	paramPtrs *RepositoryMockCreatePostParamPtrs
-	results   *RepositoryMockCreatePostResults
+	params    *RepositoryMockCreateEventParams
+	paramPtrs *RepositoryMockCreateEventParamPtrs
+	results   *RepositoryMockCreateEventResults
 	Counter   uint64
 }
 
-// RepositoryMockCreatePostParams contains parameters of the Repository.CreatePost
-type RepositoryMockCreatePostParams struct {
-	ctx  context.Context
-	post *domain.Post
+// RepositoryMockCreateEventParams contains parameters of the Repository.CreateEvent
+type RepositoryMockCreateEventParams struct {
+	ctx   context.Context
+	event *domain.Event
 }
 
-// RepositoryMockCreatePostParamPtrs contains pointers to parameters of the Repository.CreatePost
-type RepositoryMockCreatePostParamPtrs struct {
-	ctx  *context.Context
-	post **domain.Post
+// RepositoryMockCreateEventParamPtrs contains pointers to parameters of the Repository.CreateEvent
+type RepositoryMockCreateEventParamPtrs struct {
+	ctx   *context.Context
+	event **domain.Event
 }
 
-// RepositoryMockCreatePostResults contains results of the Repository.CreatePost
-type RepositoryMockCreatePostResults struct {
-	pp1 *domain.Post
+// RepositoryMockCreateEventResults contains results of the Repository.CreateEvent
+type RepositoryMockCreateEventResults struct {
+	ep1 *domain.Event
 	err error
 }
 
-// Expect sets up expected params for Repository.CreatePost
-func (mmCreatePost *mRepositoryMockCreatePost) Expect(ctx context.Context, post *domain.Post) *mRepositoryMockCreatePost {
-	if mmCreatePost.mock.funcCreatePost != nil {
-		mmCreatePost.mock.t.Fatalf("RepositoryMock.CreatePost mock is already set by Set")
+// Expect sets up expected params for Repository.CreateEvent
+func (mmCreateEvent *mRepositoryMockCreateEvent) Expect(ctx context.Context, event *domain.Event) *mRepositoryMockCreateEvent {
+	if mmCreateEvent.mock.funcCreateEvent != nil {
+		mmCreateEvent.mock.t.Fatalf("RepositoryMock.CreateEvent mock is already set by Set")
 	}
 
-	if mmCreatePost.defaultExpectation == nil {
+	if mmCreateEvent.defaultExpectation == nil {
+		mmCreateEvent.defaultExpectation = &RepositoryMockCreateEventExpectation{}
+	}
+
+	if mmCreateEvent.defaultExpectation.paramPtrs != nil {
+		mmCreateEvent.mock.t.Fatalf("RepositoryMock.CreateEvent mock is already set by ExpectParams functions")
+	}
+
+	mmCreateEvent.defaultExpectation.params = &RepositoryMockCreateEventParams{ctx, event}
+	for _, e := range mmCreateEvent.expectations {
+		if minimock.Equal(e.params, mmCreateEvent.defaultExpectation.params) {
+			mmCreateEvent.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateEvent.defaultExpectation.params)
+		}
+	}
+
+	return mmCreateEvent
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.CreateEvent
+func (mmCreateEvent *mRepositoryMockCreateEvent) ExpectCtxParam1(ctx context.Context) *mRepositoryMockCreateEvent {
+	if mmCreateEvent.mock.funcCreateEvent != nil {
+		mmCreateEvent.mock.t.Fatalf("RepositoryMock.CreateEvent mock is already set by Set")
+	}
+
+	if mmCreateEvent.defaultExpectation == nil {
+		mmCreateEvent.defaultExpectation = &RepositoryMockCreateEventExpectation{}
+	}
+
+	if mmCreateEvent.defaultExpectation.params != nil {
+		mmCreateEvent.mock.t.Fatalf("RepositoryMock.CreateEvent mock is already set by Expect")
+	}
+
+	if mmCreateEvent.defaultExpectation.paramPtrs == nil {
+		mmCreateEvent.defaultExpectation.paramPtrs = &RepositoryMockCreateEventParamPtrs{}
+	}
+	mmCreateEvent.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmCreateEvent
+}
+
+// ExpectEventParam2 sets up expected param event for Repository.CreateEvent
+func (mmCreateEvent *mRepositoryMockCreateEvent) ExpectEventParam2(event *domain.Event) *mRepositoryMockCreateEvent {
+	if mmCreateEvent.mock.funcCreateEvent != nil {
+		mmCreateEvent.mock.t.Fatalf("RepositoryMock.CreateEvent mock is already set by Set")
+	}
+
+	if mmCreateEvent.defaultExpectation == nil {
+		mmCreateEvent.defaultExpectation = &RepositoryMockCreateEventExpectation{}
+	}
+
+	if mmCreateEvent.defaultExpectation.params != nil {
+		mmCreateEvent.mock.t.Fatalf("RepositoryMock.CreateEvent mock is already set by Expect")
+	}
+
+	if mmCreateEvent.defaultExpectation.paramPtrs == nil {
+		mmCreateEvent.defaultExpectation.paramPtrs = &RepositoryMockCreateEventParamPtrs{}
+	}
+	mmCreateEvent.defaultExpectation.paramPtrs.event = &event
+
+	return mmCreateEvent
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.CreateEvent
+func (mmCreateEvent *mRepositoryMockCreateEvent) Inspect(f func(ctx context.Context, event *domain.Event)) *mRepositoryMockCreateEvent {
+	if mmCreateEvent.mock.inspectFuncCreateEvent != nil {
+		mmCreateEvent.mock.t.Fatalf("Inspect function is already set for RepositoryMock.CreateEvent")
+	}
+
+	mmCreateEvent.mock.inspectFuncCreateEvent = f
+
+	return mmCreateEvent
+}
+
+// Return sets up results that will be returned by Repository.CreateEvent
+func (mmCreateEvent *mRepositoryMockCreateEvent) Return(ep1 *domain.Event, err error) *RepositoryMock {
+	if mmCreateEvent.mock.funcCreateEvent != nil {
+		mmCreateEvent.mock.t.Fatalf("RepositoryMock.CreateEvent mock is already set by Set")
+	}
+
+	if mmCreateEvent.defaultExpectation == nil {
+		mmCreateEvent.defaultExpectation = &RepositoryMockCreateEventExpectation{mock: mmCreateEvent.mock}
+	}
+	mmCreateEvent.defaultExpectation.results = &RepositoryMockCreateEventResults{ep1, err}
+	return mmCreateEvent.mock
+}
+
+// Set uses given function f to mock the Repository.CreateEvent method
+func (mmCreateEvent *mRepositoryMockCreateEvent) Set(f func(ctx context.Context, event *domain.Event) (ep1 *domain.Event, err error)) *RepositoryMock {
+	if mmCreateEvent.defaultExpectation != nil {
+		mmCreateEvent.mock.t.Fatalf("Default expectation is already set for the Repository.CreateEvent method")
+	}
+
+	if len(mmCreateEvent.expectations) > 0 {
+		mmCreateEvent.mock.t.Fatalf("Some expectations are already set for the Repository.CreateEvent method")
+	}
+
+	mmCreateEvent.mock.funcCreateEvent = f
+	return mmCreateEvent.mock
+}
+
+// When sets expectation for the Repository.CreateEvent which will trigger the result defined by the following
+// Then helper
+func (mmCreateEvent *mRepositoryMockCreateEvent) When(ctx context.Context, event *domain.Event) *RepositoryMockCreateEventExpectation {
+	if mmCreateEvent.mock.funcCreateEvent != nil {
+		mmCreateEvent.mock.t.Fatalf("RepositoryMock.CreateEvent mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockCreateEventExpectation{
+		mock:   mmCreateEvent.mock,
+		params: &RepositoryMockCreateEventParams{ctx, event},
+	}
+	mmCreateEvent.expectations = append(mmCreateEvent.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.CreateEvent return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockCreateEventExpectation) Then(ep1 *domain.Event, err error) *RepositoryMock {
+	e.results = &RepositoryMockCreateEventResults{ep1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.CreateEvent should be invoked
+func (mmCreateEvent *mRepositoryMockCreateEvent) Times(n uint64) *mRepositoryMockCreateEvent {
+	if n == 0 {
+		mmCreateEvent.mock.t.Fatalf("Times of RepositoryMock.CreateEvent mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmCreateEvent.expectedInvocations, n)
+	return mmCreateEvent
+}
+
+func (mmCreateEvent *mRepositoryMockCreateEvent) invocationsDone() bool {
+	if len(mmCreateEvent.expectations) == 0 && mmCreateEvent.defaultExpectation == nil && mmCreateEvent.mock.funcCreateEvent == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmCreateEvent.mock.afterCreateEventCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmCreateEvent.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// CreateEvent implements repository.Repository
+func (mmCreateEvent *RepositoryMock) CreateEvent(ctx context.Context, event *domain.Event) (ep1 *domain.Event, err error) {
+	mm_atomic.AddUint64(&mmCreateEvent.beforeCreateEventCounter, 1)
+	defer mm_atomic.AddUint64(&mmCreateEvent.afterCreateEventCounter, 1)
+
+	if mmCreateEvent.inspectFuncCreateEvent != nil {
+		mmCreateEvent.inspectFuncCreateEvent(ctx, event)
+	}
+
+	mm_params := RepositoryMockCreateEventParams{ctx, event}
+
+	// Record call args
+	mmCreateEvent.CreateEventMock.mutex.Lock()
+	mmCreateEvent.CreateEventMock.callArgs = append(mmCreateEvent.CreateEventMock.callArgs, &mm_params)
+	mmCreateEvent.CreateEventMock.mutex.Unlock()
+
+	for _, e := range mmCreateEvent.CreateEventMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ep1, e.results.err
+		}
+	}
+
+	if mmCreateEvent.CreateEventMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCreateEvent.CreateEventMock.defaultExpectation.Counter, 1)
+		mm_want := mmCreateEvent.CreateEventMock.defaultExpectation.params
+		mm_want_ptrs := mmCreateEvent.CreateEventMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockCreateEventParams{ctx, event}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmCreateEvent.t.Errorf("RepositoryMock.CreateEvent got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.event != nil && !minimock.Equal(*mm_want_ptrs.event, mm_got.event) {
+				mmCreateEvent.t.Errorf("RepositoryMock.CreateEvent got unexpected parameter event, want: %#v, got: %#v%s\n", *mm_want_ptrs.event, mm_got.event, minimock.Diff(*mm_want_ptrs.event, mm_got.event))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmCreateEvent.t.Errorf("RepositoryMock.CreateEvent got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmCreateEvent.CreateEventMock.defaultExpectation.results
+		if mm_results == nil {
+			mmCreateEvent.t.Fatal("No results are set for the RepositoryMock.CreateEvent")
+		}
+		return (*mm_results).ep1, (*mm_results).err
+	}
+	if mmCreateEvent.funcCreateEvent != nil {
+		return mmCreateEvent.funcCreateEvent(ctx, event)
+	}
+	mmCreateEvent.t.Fatalf("Unexpected call to RepositoryMock.CreateEvent. %v %v", ctx, event)
+	return
+}
+
+// CreateEventAfterCounter returns a count of finished RepositoryMock.CreateEvent invocations
+func (mmCreateEvent *RepositoryMock) CreateEventAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateEvent.afterCreateEventCounter)
+}
+
+// CreateEventBeforeCounter returns a count of RepositoryMock.CreateEvent invocations
+func (mmCreateEvent *RepositoryMock) CreateEventBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateEvent.beforeCreateEventCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.CreateEvent.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmCreateEvent *mRepositoryMockCreateEvent) Calls() []*RepositoryMockCreateEventParams {
+	mmCreateEvent.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockCreateEventParams, len(mmCreateEvent.callArgs))
+	copy(argCopy, mmCreateEvent.callArgs)
+
+	mmCreateEvent.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockCreateEventDone returns true if the count of the CreateEvent invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockCreateEventDone() bool {
+	for _, e := range m.CreateEventMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.CreateEventMock.invocationsDone()
+}
+
+// MinimockCreateEventInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockCreateEventInspect() {
+	for _, e := range m.CreateEventMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.CreateEvent with params: %#v", *e.params)
+		}
+	}
+
+	afterCreateEventCounter := mm_atomic.LoadUint64(&m.afterCreateEventCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.CreateEventMock.defaultExpectation != nil && afterCreateEventCounter < 1 {
+		if m.CreateEventMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.CreateEvent")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.CreateEvent with params: %#v", *m.CreateEventMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcCreateEvent != nil && afterCreateEventCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.CreateEvent")
+	}
+
+	if !m.CreateEventMock.invocationsDone() && afterCreateEventCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.CreateEvent but found %d calls",
+			mm_atomic.LoadUint64(&m.CreateEventMock.expectedInvocations), afterCreateEventCounter)
+	}
+}
+
+type mRepositoryMockCreatePost struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockCreatePostExpectation
+	expectations       []*RepositoryMockCreatePostExpectation
+
+	callArgs []*RepositoryMockCreatePostParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockCreatePostExpectation specifies expectation struct of the Repository.CreatePost
+type RepositoryMockCreatePostExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockCreatePostParams
+	paramPtrs *RepositoryMockCreatePostParamPtrs
+	results   *RepositoryMockCreatePostResults
+	Counter   uint64
+}
+
+// RepositoryMockCreatePostParams contains parameters of the Repository.CreatePost
+type RepositoryMockCreatePostParams struct {
+	ctx  context.Context
+	post *domain.Post
+}
+
+// RepositoryMockCreatePostParamPtrs contains pointers to parameters of the Repository.CreatePost
+type RepositoryMockCreatePostParamPtrs struct {
+	ctx  *context.Context
+	post **domain.Post
+}
+
+// RepositoryMockCreatePostResults contains results of the Repository.CreatePost
+type RepositoryMockCreatePostResults struct {
+	pp1 *domain.Post
+	err error
+}
+
+// Expect sets up expected params for Repository.CreatePost
+func (mmCreatePost *mRepositoryMockCreatePost) Expect(ctx context.Context, post *domain.Post) *mRepositoryMockCreatePost {
+	if mmCreatePost.mock.funcCreatePost != nil {
+		mmCreatePost.mock.t.Fatalf("RepositoryMock.CreatePost mock is already set by Set")
+	}
+
+	if mmCreatePost.defaultExpectation == nil {
 		mmCreatePost.defaultExpectation = &RepositoryMockCreatePostExpectation{}
 	}
 
@@ -1334,1611 +2244,9589 @@ func (m *RepositoryMock) MinimockCreatePostInspect() {
 	}
 }
 
-type mRepositoryMockDisableComments struct {
+type mRepositoryMockCreateReport struct {
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockDisableCommentsExpectation
-	expectations       []*RepositoryMockDisableCommentsExpectation
+	defaultExpectation *RepositoryMockCreateReportExpectation
+	expectations       []*RepositoryMockCreateReportExpectation
 
-	callArgs []*RepositoryMockDisableCommentsParams
+	callArgs []*RepositoryMockCreateReportParams
 	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// RepositoryMockDisableCommentsExpectation specifies expectation struct of the Repository.DisableComments
-type RepositoryMockDisableCommentsExpectation struct {
+// RepositoryMockCreateReportExpectation specifies expectation struct of the Repository.CreateReport
+type RepositoryMockCreateReportExpectation struct {
 	mock      *RepositoryMock
-	params    *RepositoryMockDisableCommentsParams
-	paramPtrs *RepositoryMockDisableCommentsParamPtrs
-	results   *RepositoryMockDisableCommentsResults
+	params    *RepositoryMockCreateReportParams
+	paramPtrs *RepositoryMockCreateReportParamPtrs
+	results   *RepositoryMockCreateReportResults
 	Counter   uint64
 }
 
-// RepositoryMockDisableCommentsParams contains parameters of the Repository.DisableComments
-type RepositoryMockDisableCommentsParams struct {
+// RepositoryMockCreateReportParams contains parameters of the Repository.CreateReport
+type RepositoryMockCreateReportParams struct {
 	ctx    context.Context
-	postID int
+	report *domain.Report
 }
 
-// RepositoryMockDisableCommentsParamPtrs contains pointers to parameters of the Repository.DisableComments
-type RepositoryMockDisableCommentsParamPtrs struct {
+// RepositoryMockCreateReportParamPtrs contains pointers to parameters of the Repository.CreateReport
+type RepositoryMockCreateReportParamPtrs struct {
 	ctx    *context.Context
-	postID *int
+	report **domain.Report
 }
 
-// RepositoryMockDisableCommentsResults contains results of the Repository.DisableComments
-type RepositoryMockDisableCommentsResults struct {
+// RepositoryMockCreateReportResults contains results of the Repository.CreateReport
+type RepositoryMockCreateReportResults struct {
+	rp1 *domain.Report
 	err error
 }
 
-// Expect sets up expected params for Repository.DisableComments
-func (mmDisableComments *mRepositoryMockDisableComments) Expect(ctx context.Context, postID int) *mRepositoryMockDisableComments {
-	if mmDisableComments.mock.funcDisableComments != nil {
-		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Set")
+// Expect sets up expected params for Repository.CreateReport
+func (mmCreateReport *mRepositoryMockCreateReport) Expect(ctx context.Context, report *domain.Report) *mRepositoryMockCreateReport {
+	if mmCreateReport.mock.funcCreateReport != nil {
+		mmCreateReport.mock.t.Fatalf("RepositoryMock.CreateReport mock is already set by Set")
 	}
 
-	if mmDisableComments.defaultExpectation == nil {
-		mmDisableComments.defaultExpectation = &RepositoryMockDisableCommentsExpectation{}
+	if mmCreateReport.defaultExpectation == nil {
+		mmCreateReport.defaultExpectation = &RepositoryMockCreateReportExpectation{}
 	}
 
-	if mmDisableComments.defaultExpectation.paramPtrs != nil {
-		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by ExpectParams functions")
+	if mmCreateReport.defaultExpectation.paramPtrs != nil {
+		mmCreateReport.mock.t.Fatalf("RepositoryMock.CreateReport mock is already set by ExpectParams functions")
 	}
 
-	mmDisableComments.defaultExpectation.params = &RepositoryMockDisableCommentsParams{ctx, postID}
-	for _, e := range mmDisableComments.expectations {
-		if minimock.Equal(e.params, mmDisableComments.defaultExpectation.params) {
-			mmDisableComments.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDisableComments.defaultExpectation.params)
+	mmCreateReport.defaultExpectation.params = &RepositoryMockCreateReportParams{ctx, report}
+	for _, e := range mmCreateReport.expectations {
+		if minimock.Equal(e.params, mmCreateReport.defaultExpectation.params) {
+			mmCreateReport.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateReport.defaultExpectation.params)
 		}
 	}
 
-	return mmDisableComments
+	return mmCreateReport
 }
 
-// ExpectCtxParam1 sets up expected param ctx for Repository.DisableComments
-func (mmDisableComments *mRepositoryMockDisableComments) ExpectCtxParam1(ctx context.Context) *mRepositoryMockDisableComments {
-	if mmDisableComments.mock.funcDisableComments != nil {
-		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Repository.CreateReport
+func (mmCreateReport *mRepositoryMockCreateReport) ExpectCtxParam1(ctx context.Context) *mRepositoryMockCreateReport {
+	if mmCreateReport.mock.funcCreateReport != nil {
+		mmCreateReport.mock.t.Fatalf("RepositoryMock.CreateReport mock is already set by Set")
 	}
 
-	if mmDisableComments.defaultExpectation == nil {
-		mmDisableComments.defaultExpectation = &RepositoryMockDisableCommentsExpectation{}
+	if mmCreateReport.defaultExpectation == nil {
+		mmCreateReport.defaultExpectation = &RepositoryMockCreateReportExpectation{}
 	}
 
-	if mmDisableComments.defaultExpectation.params != nil {
-		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Expect")
+	if mmCreateReport.defaultExpectation.params != nil {
+		mmCreateReport.mock.t.Fatalf("RepositoryMock.CreateReport mock is already set by Expect")
 	}
 
-	if mmDisableComments.defaultExpectation.paramPtrs == nil {
-		mmDisableComments.defaultExpectation.paramPtrs = &RepositoryMockDisableCommentsParamPtrs{}
+	if mmCreateReport.defaultExpectation.paramPtrs == nil {
+		mmCreateReport.defaultExpectation.paramPtrs = &RepositoryMockCreateReportParamPtrs{}
 	}
-	mmDisableComments.defaultExpectation.paramPtrs.ctx = &ctx
+	mmCreateReport.defaultExpectation.paramPtrs.ctx = &ctx
 
-	return mmDisableComments
+	return mmCreateReport
 }
 
-// ExpectPostIDParam2 sets up expected param postID for Repository.DisableComments
-func (mmDisableComments *mRepositoryMockDisableComments) ExpectPostIDParam2(postID int) *mRepositoryMockDisableComments {
-	if mmDisableComments.mock.funcDisableComments != nil {
-		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Set")
+// ExpectReportParam2 sets up expected param report for Repository.CreateReport
+func (mmCreateReport *mRepositoryMockCreateReport) ExpectReportParam2(report *domain.Report) *mRepositoryMockCreateReport {
+	if mmCreateReport.mock.funcCreateReport != nil {
+		mmCreateReport.mock.t.Fatalf("RepositoryMock.CreateReport mock is already set by Set")
 	}
 
-	if mmDisableComments.defaultExpectation == nil {
-		mmDisableComments.defaultExpectation = &RepositoryMockDisableCommentsExpectation{}
+	if mmCreateReport.defaultExpectation == nil {
+		mmCreateReport.defaultExpectation = &RepositoryMockCreateReportExpectation{}
 	}
 
-	if mmDisableComments.defaultExpectation.params != nil {
-		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Expect")
+	if mmCreateReport.defaultExpectation.params != nil {
+		mmCreateReport.mock.t.Fatalf("RepositoryMock.CreateReport mock is already set by Expect")
 	}
 
-	if mmDisableComments.defaultExpectation.paramPtrs == nil {
-		mmDisableComments.defaultExpectation.paramPtrs = &RepositoryMockDisableCommentsParamPtrs{}
+	if mmCreateReport.defaultExpectation.paramPtrs == nil {
+		mmCreateReport.defaultExpectation.paramPtrs = &RepositoryMockCreateReportParamPtrs{}
 	}
-	mmDisableComments.defaultExpectation.paramPtrs.postID = &postID
+	mmCreateReport.defaultExpectation.paramPtrs.report = &report
 
-	return mmDisableComments
+	return mmCreateReport
 }
 
-// Inspect accepts an inspector function that has same arguments as the Repository.DisableComments
-func (mmDisableComments *mRepositoryMockDisableComments) Inspect(f func(ctx context.Context, postID int)) *mRepositoryMockDisableComments {
-	if mmDisableComments.mock.inspectFuncDisableComments != nil {
-		mmDisableComments.mock.t.Fatalf("Inspect function is already set for RepositoryMock.DisableComments")
+// Inspect accepts an inspector function that has same arguments as the Repository.CreateReport
+func (mmCreateReport *mRepositoryMockCreateReport) Inspect(f func(ctx context.Context, report *domain.Report)) *mRepositoryMockCreateReport {
+	if mmCreateReport.mock.inspectFuncCreateReport != nil {
+		mmCreateReport.mock.t.Fatalf("Inspect function is already set for RepositoryMock.CreateReport")
 	}
 
-	mmDisableComments.mock.inspectFuncDisableComments = f
+	mmCreateReport.mock.inspectFuncCreateReport = f
 
-	return mmDisableComments
+	return mmCreateReport
 }
 
-// Return sets up results that will be returned by Repository.DisableComments
-func (mmDisableComments *mRepositoryMockDisableComments) Return(err error) *RepositoryMock {
-	if mmDisableComments.mock.funcDisableComments != nil {
-		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Set")
+// Return sets up results that will be returned by Repository.CreateReport
+func (mmCreateReport *mRepositoryMockCreateReport) Return(rp1 *domain.Report, err error) *RepositoryMock {
+	if mmCreateReport.mock.funcCreateReport != nil {
+		mmCreateReport.mock.t.Fatalf("RepositoryMock.CreateReport mock is already set by Set")
 	}
 
-	if mmDisableComments.defaultExpectation == nil {
-		mmDisableComments.defaultExpectation = &RepositoryMockDisableCommentsExpectation{mock: mmDisableComments.mock}
+	if mmCreateReport.defaultExpectation == nil {
+		mmCreateReport.defaultExpectation = &RepositoryMockCreateReportExpectation{mock: mmCreateReport.mock}
 	}
-	mmDisableComments.defaultExpectation.results = &RepositoryMockDisableCommentsResults{err}
-	return mmDisableComments.mock
+	mmCreateReport.defaultExpectation.results = &RepositoryMockCreateReportResults{rp1, err}
+	return mmCreateReport.mock
 }
 
-// Set uses given function f to mock the Repository.DisableComments method
-func (mmDisableComments *mRepositoryMockDisableComments) Set(f func(ctx context.Context, postID int) (err error)) *RepositoryMock {
-	if mmDisableComments.defaultExpectation != nil {
-		mmDisableComments.mock.t.Fatalf("Default expectation is already set for the Repository.DisableComments method")
+// Set uses given function f to mock the Repository.CreateReport method
+func (mmCreateReport *mRepositoryMockCreateReport) Set(f func(ctx context.Context, report *domain.Report) (rp1 *domain.Report, err error)) *RepositoryMock {
+	if mmCreateReport.defaultExpectation != nil {
+		mmCreateReport.mock.t.Fatalf("Default expectation is already set for the Repository.CreateReport method")
 	}
 
-	if len(mmDisableComments.expectations) > 0 {
-		mmDisableComments.mock.t.Fatalf("Some expectations are already set for the Repository.DisableComments method")
+	if len(mmCreateReport.expectations) > 0 {
+		mmCreateReport.mock.t.Fatalf("Some expectations are already set for the Repository.CreateReport method")
 	}
 
-	mmDisableComments.mock.funcDisableComments = f
-	return mmDisableComments.mock
+	mmCreateReport.mock.funcCreateReport = f
+	return mmCreateReport.mock
 }
 
-// When sets expectation for the Repository.DisableComments which will trigger the result defined by the following
+// When sets expectation for the Repository.CreateReport which will trigger the result defined by the following
 // Then helper
-func (mmDisableComments *mRepositoryMockDisableComments) When(ctx context.Context, postID int) *RepositoryMockDisableCommentsExpectation {
-	if mmDisableComments.mock.funcDisableComments != nil {
-		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Set")
+func (mmCreateReport *mRepositoryMockCreateReport) When(ctx context.Context, report *domain.Report) *RepositoryMockCreateReportExpectation {
+	if mmCreateReport.mock.funcCreateReport != nil {
+		mmCreateReport.mock.t.Fatalf("RepositoryMock.CreateReport mock is already set by Set")
 	}
 
-	expectation := &RepositoryMockDisableCommentsExpectation{
-		mock:   mmDisableComments.mock,
+	expectation := &RepositoryMockCreateReportExpectation{
+		mock:   mmCreateReport.mock,
+		params: &RepositoryMockCreateReportParams{ctx, report},
+	}
+	mmCreateReport.expectations = append(mmCreateReport.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.CreateReport return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockCreateReportExpectation) Then(rp1 *domain.Report, err error) *RepositoryMock {
+	e.results = &RepositoryMockCreateReportResults{rp1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.CreateReport should be invoked
+func (mmCreateReport *mRepositoryMockCreateReport) Times(n uint64) *mRepositoryMockCreateReport {
+	if n == 0 {
+		mmCreateReport.mock.t.Fatalf("Times of RepositoryMock.CreateReport mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmCreateReport.expectedInvocations, n)
+	return mmCreateReport
+}
+
+func (mmCreateReport *mRepositoryMockCreateReport) invocationsDone() bool {
+	if len(mmCreateReport.expectations) == 0 && mmCreateReport.defaultExpectation == nil && mmCreateReport.mock.funcCreateReport == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmCreateReport.mock.afterCreateReportCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmCreateReport.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// CreateReport implements repository.Repository
+func (mmCreateReport *RepositoryMock) CreateReport(ctx context.Context, report *domain.Report) (rp1 *domain.Report, err error) {
+	mm_atomic.AddUint64(&mmCreateReport.beforeCreateReportCounter, 1)
+	defer mm_atomic.AddUint64(&mmCreateReport.afterCreateReportCounter, 1)
+
+	if mmCreateReport.inspectFuncCreateReport != nil {
+		mmCreateReport.inspectFuncCreateReport(ctx, report)
+	}
+
+	mm_params := RepositoryMockCreateReportParams{ctx, report}
+
+	// Record call args
+	mmCreateReport.CreateReportMock.mutex.Lock()
+	mmCreateReport.CreateReportMock.callArgs = append(mmCreateReport.CreateReportMock.callArgs, &mm_params)
+	mmCreateReport.CreateReportMock.mutex.Unlock()
+
+	for _, e := range mmCreateReport.CreateReportMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.rp1, e.results.err
+		}
+	}
+
+	if mmCreateReport.CreateReportMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCreateReport.CreateReportMock.defaultExpectation.Counter, 1)
+		mm_want := mmCreateReport.CreateReportMock.defaultExpectation.params
+		mm_want_ptrs := mmCreateReport.CreateReportMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockCreateReportParams{ctx, report}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmCreateReport.t.Errorf("RepositoryMock.CreateReport got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.report != nil && !minimock.Equal(*mm_want_ptrs.report, mm_got.report) {
+				mmCreateReport.t.Errorf("RepositoryMock.CreateReport got unexpected parameter report, want: %#v, got: %#v%s\n", *mm_want_ptrs.report, mm_got.report, minimock.Diff(*mm_want_ptrs.report, mm_got.report))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmCreateReport.t.Errorf("RepositoryMock.CreateReport got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmCreateReport.CreateReportMock.defaultExpectation.results
+		if mm_results == nil {
+			mmCreateReport.t.Fatal("No results are set for the RepositoryMock.CreateReport")
+		}
+		return (*mm_results).rp1, (*mm_results).err
+	}
+	if mmCreateReport.funcCreateReport != nil {
+		return mmCreateReport.funcCreateReport(ctx, report)
+	}
+	mmCreateReport.t.Fatalf("Unexpected call to RepositoryMock.CreateReport. %v %v", ctx, report)
+	return
+}
+
+// CreateReportAfterCounter returns a count of finished RepositoryMock.CreateReport invocations
+func (mmCreateReport *RepositoryMock) CreateReportAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateReport.afterCreateReportCounter)
+}
+
+// CreateReportBeforeCounter returns a count of RepositoryMock.CreateReport invocations
+func (mmCreateReport *RepositoryMock) CreateReportBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateReport.beforeCreateReportCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.CreateReport.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmCreateReport *mRepositoryMockCreateReport) Calls() []*RepositoryMockCreateReportParams {
+	mmCreateReport.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockCreateReportParams, len(mmCreateReport.callArgs))
+	copy(argCopy, mmCreateReport.callArgs)
+
+	mmCreateReport.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockCreateReportDone returns true if the count of the CreateReport invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockCreateReportDone() bool {
+	for _, e := range m.CreateReportMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.CreateReportMock.invocationsDone()
+}
+
+// MinimockCreateReportInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockCreateReportInspect() {
+	for _, e := range m.CreateReportMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.CreateReport with params: %#v", *e.params)
+		}
+	}
+
+	afterCreateReportCounter := mm_atomic.LoadUint64(&m.afterCreateReportCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.CreateReportMock.defaultExpectation != nil && afterCreateReportCounter < 1 {
+		if m.CreateReportMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.CreateReport")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.CreateReport with params: %#v", *m.CreateReportMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcCreateReport != nil && afterCreateReportCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.CreateReport")
+	}
+
+	if !m.CreateReportMock.invocationsDone() && afterCreateReportCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.CreateReport but found %d calls",
+			mm_atomic.LoadUint64(&m.CreateReportMock.expectedInvocations), afterCreateReportCounter)
+	}
+}
+
+type mRepositoryMockCreateWebhook struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockCreateWebhookExpectation
+	expectations       []*RepositoryMockCreateWebhookExpectation
+
+	callArgs []*RepositoryMockCreateWebhookParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockCreateWebhookExpectation specifies expectation struct of the Repository.CreateWebhook
+type RepositoryMockCreateWebhookExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockCreateWebhookParams
+	paramPtrs *RepositoryMockCreateWebhookParamPtrs
+	results   *RepositoryMockCreateWebhookResults
+	Counter   uint64
+}
+
+// RepositoryMockCreateWebhookParams contains parameters of the Repository.CreateWebhook
+type RepositoryMockCreateWebhookParams struct {
+	ctx     context.Context
+	webhook *domain.Webhook
+}
+
+// RepositoryMockCreateWebhookParamPtrs contains pointers to parameters of the Repository.CreateWebhook
+type RepositoryMockCreateWebhookParamPtrs struct {
+	ctx     *context.Context
+	webhook **domain.Webhook
+}
+
+// RepositoryMockCreateWebhookResults contains results of the Repository.CreateWebhook
+type RepositoryMockCreateWebhookResults struct {
+	wp1 *domain.Webhook
+	err error
+}
+
+// Expect sets up expected params for Repository.CreateWebhook
+func (mmCreateWebhook *mRepositoryMockCreateWebhook) Expect(ctx context.Context, webhook *domain.Webhook) *mRepositoryMockCreateWebhook {
+	if mmCreateWebhook.mock.funcCreateWebhook != nil {
+		mmCreateWebhook.mock.t.Fatalf("RepositoryMock.CreateWebhook mock is already set by Set")
+	}
+
+	if mmCreateWebhook.defaultExpectation == nil {
+		mmCreateWebhook.defaultExpectation = &RepositoryMockCreateWebhookExpectation{}
+	}
+
+	if mmCreateWebhook.defaultExpectation.paramPtrs != nil {
+		mmCreateWebhook.mock.t.Fatalf("RepositoryMock.CreateWebhook mock is already set by ExpectParams functions")
+	}
+
+	mmCreateWebhook.defaultExpectation.params = &RepositoryMockCreateWebhookParams{ctx, webhook}
+	for _, e := range mmCreateWebhook.expectations {
+		if minimock.Equal(e.params, mmCreateWebhook.defaultExpectation.params) {
+			mmCreateWebhook.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateWebhook.defaultExpectation.params)
+		}
+	}
+
+	return mmCreateWebhook
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.CreateWebhook
+func (mmCreateWebhook *mRepositoryMockCreateWebhook) ExpectCtxParam1(ctx context.Context) *mRepositoryMockCreateWebhook {
+	if mmCreateWebhook.mock.funcCreateWebhook != nil {
+		mmCreateWebhook.mock.t.Fatalf("RepositoryMock.CreateWebhook mock is already set by Set")
+	}
+
+	if mmCreateWebhook.defaultExpectation == nil {
+		mmCreateWebhook.defaultExpectation = &RepositoryMockCreateWebhookExpectation{}
+	}
+
+	if mmCreateWebhook.defaultExpectation.params != nil {
+		mmCreateWebhook.mock.t.Fatalf("RepositoryMock.CreateWebhook mock is already set by Expect")
+	}
+
+	if mmCreateWebhook.defaultExpectation.paramPtrs == nil {
+		mmCreateWebhook.defaultExpectation.paramPtrs = &RepositoryMockCreateWebhookParamPtrs{}
+	}
+	mmCreateWebhook.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmCreateWebhook
+}
+
+// ExpectWebhookParam2 sets up expected param webhook for Repository.CreateWebhook
+func (mmCreateWebhook *mRepositoryMockCreateWebhook) ExpectWebhookParam2(webhook *domain.Webhook) *mRepositoryMockCreateWebhook {
+	if mmCreateWebhook.mock.funcCreateWebhook != nil {
+		mmCreateWebhook.mock.t.Fatalf("RepositoryMock.CreateWebhook mock is already set by Set")
+	}
+
+	if mmCreateWebhook.defaultExpectation == nil {
+		mmCreateWebhook.defaultExpectation = &RepositoryMockCreateWebhookExpectation{}
+	}
+
+	if mmCreateWebhook.defaultExpectation.params != nil {
+		mmCreateWebhook.mock.t.Fatalf("RepositoryMock.CreateWebhook mock is already set by Expect")
+	}
+
+	if mmCreateWebhook.defaultExpectation.paramPtrs == nil {
+		mmCreateWebhook.defaultExpectation.paramPtrs = &RepositoryMockCreateWebhookParamPtrs{}
+	}
+	mmCreateWebhook.defaultExpectation.paramPtrs.webhook = &webhook
+
+	return mmCreateWebhook
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.CreateWebhook
+func (mmCreateWebhook *mRepositoryMockCreateWebhook) Inspect(f func(ctx context.Context, webhook *domain.Webhook)) *mRepositoryMockCreateWebhook {
+	if mmCreateWebhook.mock.inspectFuncCreateWebhook != nil {
+		mmCreateWebhook.mock.t.Fatalf("Inspect function is already set for RepositoryMock.CreateWebhook")
+	}
+
+	mmCreateWebhook.mock.inspectFuncCreateWebhook = f
+
+	return mmCreateWebhook
+}
+
+// Return sets up results that will be returned by Repository.CreateWebhook
+func (mmCreateWebhook *mRepositoryMockCreateWebhook) Return(wp1 *domain.Webhook, err error) *RepositoryMock {
+	if mmCreateWebhook.mock.funcCreateWebhook != nil {
+		mmCreateWebhook.mock.t.Fatalf("RepositoryMock.CreateWebhook mock is already set by Set")
+	}
+
+	if mmCreateWebhook.defaultExpectation == nil {
+		mmCreateWebhook.defaultExpectation = &RepositoryMockCreateWebhookExpectation{mock: mmCreateWebhook.mock}
+	}
+	mmCreateWebhook.defaultExpectation.results = &RepositoryMockCreateWebhookResults{wp1, err}
+	return mmCreateWebhook.mock
+}
+
+// Set uses given function f to mock the Repository.CreateWebhook method
+func (mmCreateWebhook *mRepositoryMockCreateWebhook) Set(f func(ctx context.Context, webhook *domain.Webhook) (wp1 *domain.Webhook, err error)) *RepositoryMock {
+	if mmCreateWebhook.defaultExpectation != nil {
+		mmCreateWebhook.mock.t.Fatalf("Default expectation is already set for the Repository.CreateWebhook method")
+	}
+
+	if len(mmCreateWebhook.expectations) > 0 {
+		mmCreateWebhook.mock.t.Fatalf("Some expectations are already set for the Repository.CreateWebhook method")
+	}
+
+	mmCreateWebhook.mock.funcCreateWebhook = f
+	return mmCreateWebhook.mock
+}
+
+// When sets expectation for the Repository.CreateWebhook which will trigger the result defined by the following
+// Then helper
+func (mmCreateWebhook *mRepositoryMockCreateWebhook) When(ctx context.Context, webhook *domain.Webhook) *RepositoryMockCreateWebhookExpectation {
+	if mmCreateWebhook.mock.funcCreateWebhook != nil {
+		mmCreateWebhook.mock.t.Fatalf("RepositoryMock.CreateWebhook mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockCreateWebhookExpectation{
+		mock:   mmCreateWebhook.mock,
+		params: &RepositoryMockCreateWebhookParams{ctx, webhook},
+	}
+	mmCreateWebhook.expectations = append(mmCreateWebhook.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.CreateWebhook return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockCreateWebhookExpectation) Then(wp1 *domain.Webhook, err error) *RepositoryMock {
+	e.results = &RepositoryMockCreateWebhookResults{wp1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.CreateWebhook should be invoked
+func (mmCreateWebhook *mRepositoryMockCreateWebhook) Times(n uint64) *mRepositoryMockCreateWebhook {
+	if n == 0 {
+		mmCreateWebhook.mock.t.Fatalf("Times of RepositoryMock.CreateWebhook mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmCreateWebhook.expectedInvocations, n)
+	return mmCreateWebhook
+}
+
+func (mmCreateWebhook *mRepositoryMockCreateWebhook) invocationsDone() bool {
+	if len(mmCreateWebhook.expectations) == 0 && mmCreateWebhook.defaultExpectation == nil && mmCreateWebhook.mock.funcCreateWebhook == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmCreateWebhook.mock.afterCreateWebhookCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmCreateWebhook.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// CreateWebhook implements repository.Repository
+func (mmCreateWebhook *RepositoryMock) CreateWebhook(ctx context.Context, webhook *domain.Webhook) (wp1 *domain.Webhook, err error) {
+	mm_atomic.AddUint64(&mmCreateWebhook.beforeCreateWebhookCounter, 1)
+	defer mm_atomic.AddUint64(&mmCreateWebhook.afterCreateWebhookCounter, 1)
+
+	if mmCreateWebhook.inspectFuncCreateWebhook != nil {
+		mmCreateWebhook.inspectFuncCreateWebhook(ctx, webhook)
+	}
+
+	mm_params := RepositoryMockCreateWebhookParams{ctx, webhook}
+
+	// Record call args
+	mmCreateWebhook.CreateWebhookMock.mutex.Lock()
+	mmCreateWebhook.CreateWebhookMock.callArgs = append(mmCreateWebhook.CreateWebhookMock.callArgs, &mm_params)
+	mmCreateWebhook.CreateWebhookMock.mutex.Unlock()
+
+	for _, e := range mmCreateWebhook.CreateWebhookMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.wp1, e.results.err
+		}
+	}
+
+	if mmCreateWebhook.CreateWebhookMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCreateWebhook.CreateWebhookMock.defaultExpectation.Counter, 1)
+		mm_want := mmCreateWebhook.CreateWebhookMock.defaultExpectation.params
+		mm_want_ptrs := mmCreateWebhook.CreateWebhookMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockCreateWebhookParams{ctx, webhook}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmCreateWebhook.t.Errorf("RepositoryMock.CreateWebhook got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.webhook != nil && !minimock.Equal(*mm_want_ptrs.webhook, mm_got.webhook) {
+				mmCreateWebhook.t.Errorf("RepositoryMock.CreateWebhook got unexpected parameter webhook, want: %#v, got: %#v%s\n", *mm_want_ptrs.webhook, mm_got.webhook, minimock.Diff(*mm_want_ptrs.webhook, mm_got.webhook))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmCreateWebhook.t.Errorf("RepositoryMock.CreateWebhook got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmCreateWebhook.CreateWebhookMock.defaultExpectation.results
+		if mm_results == nil {
+			mmCreateWebhook.t.Fatal("No results are set for the RepositoryMock.CreateWebhook")
+		}
+		return (*mm_results).wp1, (*mm_results).err
+	}
+	if mmCreateWebhook.funcCreateWebhook != nil {
+		return mmCreateWebhook.funcCreateWebhook(ctx, webhook)
+	}
+	mmCreateWebhook.t.Fatalf("Unexpected call to RepositoryMock.CreateWebhook. %v %v", ctx, webhook)
+	return
+}
+
+// CreateWebhookAfterCounter returns a count of finished RepositoryMock.CreateWebhook invocations
+func (mmCreateWebhook *RepositoryMock) CreateWebhookAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateWebhook.afterCreateWebhookCounter)
+}
+
+// CreateWebhookBeforeCounter returns a count of RepositoryMock.CreateWebhook invocations
+func (mmCreateWebhook *RepositoryMock) CreateWebhookBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateWebhook.beforeCreateWebhookCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.CreateWebhook.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmCreateWebhook *mRepositoryMockCreateWebhook) Calls() []*RepositoryMockCreateWebhookParams {
+	mmCreateWebhook.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockCreateWebhookParams, len(mmCreateWebhook.callArgs))
+	copy(argCopy, mmCreateWebhook.callArgs)
+
+	mmCreateWebhook.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockCreateWebhookDone returns true if the count of the CreateWebhook invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockCreateWebhookDone() bool {
+	for _, e := range m.CreateWebhookMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.CreateWebhookMock.invocationsDone()
+}
+
+// MinimockCreateWebhookInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockCreateWebhookInspect() {
+	for _, e := range m.CreateWebhookMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.CreateWebhook with params: %#v", *e.params)
+		}
+	}
+
+	afterCreateWebhookCounter := mm_atomic.LoadUint64(&m.afterCreateWebhookCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.CreateWebhookMock.defaultExpectation != nil && afterCreateWebhookCounter < 1 {
+		if m.CreateWebhookMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.CreateWebhook")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.CreateWebhook with params: %#v", *m.CreateWebhookMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcCreateWebhook != nil && afterCreateWebhookCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.CreateWebhook")
+	}
+
+	if !m.CreateWebhookMock.invocationsDone() && afterCreateWebhookCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.CreateWebhook but found %d calls",
+			mm_atomic.LoadUint64(&m.CreateWebhookMock.expectedInvocations), afterCreateWebhookCounter)
+	}
+}
+
+type mRepositoryMockCreateWebhookDeliveries struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockCreateWebhookDeliveriesExpectation
+	expectations       []*RepositoryMockCreateWebhookDeliveriesExpectation
+
+	callArgs []*RepositoryMockCreateWebhookDeliveriesParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockCreateWebhookDeliveriesExpectation specifies expectation struct of the Repository.CreateWebhookDeliveries
+type RepositoryMockCreateWebhookDeliveriesExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockCreateWebhookDeliveriesParams
+	paramPtrs *RepositoryMockCreateWebhookDeliveriesParamPtrs
+	results   *RepositoryMockCreateWebhookDeliveriesResults
+	Counter   uint64
+}
+
+// RepositoryMockCreateWebhookDeliveriesParams contains parameters of the Repository.CreateWebhookDeliveries
+type RepositoryMockCreateWebhookDeliveriesParams struct {
+	ctx        context.Context
+	deliveries []*domain.WebhookDelivery
+}
+
+// RepositoryMockCreateWebhookDeliveriesParamPtrs contains pointers to parameters of the Repository.CreateWebhookDeliveries
+type RepositoryMockCreateWebhookDeliveriesParamPtrs struct {
+	ctx        *context.Context
+	deliveries *[]*domain.WebhookDelivery
+}
+
+// RepositoryMockCreateWebhookDeliveriesResults contains results of the Repository.CreateWebhookDeliveries
+type RepositoryMockCreateWebhookDeliveriesResults struct {
+	err error
+}
+
+// Expect sets up expected params for Repository.CreateWebhookDeliveries
+func (mmCreateWebhookDeliveries *mRepositoryMockCreateWebhookDeliveries) Expect(ctx context.Context, deliveries []*domain.WebhookDelivery) *mRepositoryMockCreateWebhookDeliveries {
+	if mmCreateWebhookDeliveries.mock.funcCreateWebhookDeliveries != nil {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("RepositoryMock.CreateWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmCreateWebhookDeliveries.defaultExpectation == nil {
+		mmCreateWebhookDeliveries.defaultExpectation = &RepositoryMockCreateWebhookDeliveriesExpectation{}
+	}
+
+	if mmCreateWebhookDeliveries.defaultExpectation.paramPtrs != nil {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("RepositoryMock.CreateWebhookDeliveries mock is already set by ExpectParams functions")
+	}
+
+	mmCreateWebhookDeliveries.defaultExpectation.params = &RepositoryMockCreateWebhookDeliveriesParams{ctx, deliveries}
+	for _, e := range mmCreateWebhookDeliveries.expectations {
+		if minimock.Equal(e.params, mmCreateWebhookDeliveries.defaultExpectation.params) {
+			mmCreateWebhookDeliveries.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmCreateWebhookDeliveries.defaultExpectation.params)
+		}
+	}
+
+	return mmCreateWebhookDeliveries
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.CreateWebhookDeliveries
+func (mmCreateWebhookDeliveries *mRepositoryMockCreateWebhookDeliveries) ExpectCtxParam1(ctx context.Context) *mRepositoryMockCreateWebhookDeliveries {
+	if mmCreateWebhookDeliveries.mock.funcCreateWebhookDeliveries != nil {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("RepositoryMock.CreateWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmCreateWebhookDeliveries.defaultExpectation == nil {
+		mmCreateWebhookDeliveries.defaultExpectation = &RepositoryMockCreateWebhookDeliveriesExpectation{}
+	}
+
+	if mmCreateWebhookDeliveries.defaultExpectation.params != nil {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("RepositoryMock.CreateWebhookDeliveries mock is already set by Expect")
+	}
+
+	if mmCreateWebhookDeliveries.defaultExpectation.paramPtrs == nil {
+		mmCreateWebhookDeliveries.defaultExpectation.paramPtrs = &RepositoryMockCreateWebhookDeliveriesParamPtrs{}
+	}
+	mmCreateWebhookDeliveries.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmCreateWebhookDeliveries
+}
+
+// ExpectDeliveriesParam2 sets up expected param deliveries for Repository.CreateWebhookDeliveries
+func (mmCreateWebhookDeliveries *mRepositoryMockCreateWebhookDeliveries) ExpectDeliveriesParam2(deliveries []*domain.WebhookDelivery) *mRepositoryMockCreateWebhookDeliveries {
+	if mmCreateWebhookDeliveries.mock.funcCreateWebhookDeliveries != nil {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("RepositoryMock.CreateWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmCreateWebhookDeliveries.defaultExpectation == nil {
+		mmCreateWebhookDeliveries.defaultExpectation = &RepositoryMockCreateWebhookDeliveriesExpectation{}
+	}
+
+	if mmCreateWebhookDeliveries.defaultExpectation.params != nil {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("RepositoryMock.CreateWebhookDeliveries mock is already set by Expect")
+	}
+
+	if mmCreateWebhookDeliveries.defaultExpectation.paramPtrs == nil {
+		mmCreateWebhookDeliveries.defaultExpectation.paramPtrs = &RepositoryMockCreateWebhookDeliveriesParamPtrs{}
+	}
+	mmCreateWebhookDeliveries.defaultExpectation.paramPtrs.deliveries = &deliveries
+
+	return mmCreateWebhookDeliveries
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.CreateWebhookDeliveries
+func (mmCreateWebhookDeliveries *mRepositoryMockCreateWebhookDeliveries) Inspect(f func(ctx context.Context, deliveries []*domain.WebhookDelivery)) *mRepositoryMockCreateWebhookDeliveries {
+	if mmCreateWebhookDeliveries.mock.inspectFuncCreateWebhookDeliveries != nil {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("Inspect function is already set for RepositoryMock.CreateWebhookDeliveries")
+	}
+
+	mmCreateWebhookDeliveries.mock.inspectFuncCreateWebhookDeliveries = f
+
+	return mmCreateWebhookDeliveries
+}
+
+// Return sets up results that will be returned by Repository.CreateWebhookDeliveries
+func (mmCreateWebhookDeliveries *mRepositoryMockCreateWebhookDeliveries) Return(err error) *RepositoryMock {
+	if mmCreateWebhookDeliveries.mock.funcCreateWebhookDeliveries != nil {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("RepositoryMock.CreateWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmCreateWebhookDeliveries.defaultExpectation == nil {
+		mmCreateWebhookDeliveries.defaultExpectation = &RepositoryMockCreateWebhookDeliveriesExpectation{mock: mmCreateWebhookDeliveries.mock}
+	}
+	mmCreateWebhookDeliveries.defaultExpectation.results = &RepositoryMockCreateWebhookDeliveriesResults{err}
+	return mmCreateWebhookDeliveries.mock
+}
+
+// Set uses given function f to mock the Repository.CreateWebhookDeliveries method
+func (mmCreateWebhookDeliveries *mRepositoryMockCreateWebhookDeliveries) Set(f func(ctx context.Context, deliveries []*domain.WebhookDelivery) (err error)) *RepositoryMock {
+	if mmCreateWebhookDeliveries.defaultExpectation != nil {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("Default expectation is already set for the Repository.CreateWebhookDeliveries method")
+	}
+
+	if len(mmCreateWebhookDeliveries.expectations) > 0 {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("Some expectations are already set for the Repository.CreateWebhookDeliveries method")
+	}
+
+	mmCreateWebhookDeliveries.mock.funcCreateWebhookDeliveries = f
+	return mmCreateWebhookDeliveries.mock
+}
+
+// When sets expectation for the Repository.CreateWebhookDeliveries which will trigger the result defined by the following
+// Then helper
+func (mmCreateWebhookDeliveries *mRepositoryMockCreateWebhookDeliveries) When(ctx context.Context, deliveries []*domain.WebhookDelivery) *RepositoryMockCreateWebhookDeliveriesExpectation {
+	if mmCreateWebhookDeliveries.mock.funcCreateWebhookDeliveries != nil {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("RepositoryMock.CreateWebhookDeliveries mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockCreateWebhookDeliveriesExpectation{
+		mock:   mmCreateWebhookDeliveries.mock,
+		params: &RepositoryMockCreateWebhookDeliveriesParams{ctx, deliveries},
+	}
+	mmCreateWebhookDeliveries.expectations = append(mmCreateWebhookDeliveries.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.CreateWebhookDeliveries return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockCreateWebhookDeliveriesExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockCreateWebhookDeliveriesResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.CreateWebhookDeliveries should be invoked
+func (mmCreateWebhookDeliveries *mRepositoryMockCreateWebhookDeliveries) Times(n uint64) *mRepositoryMockCreateWebhookDeliveries {
+	if n == 0 {
+		mmCreateWebhookDeliveries.mock.t.Fatalf("Times of RepositoryMock.CreateWebhookDeliveries mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmCreateWebhookDeliveries.expectedInvocations, n)
+	return mmCreateWebhookDeliveries
+}
+
+func (mmCreateWebhookDeliveries *mRepositoryMockCreateWebhookDeliveries) invocationsDone() bool {
+	if len(mmCreateWebhookDeliveries.expectations) == 0 && mmCreateWebhookDeliveries.defaultExpectation == nil && mmCreateWebhookDeliveries.mock.funcCreateWebhookDeliveries == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmCreateWebhookDeliveries.mock.afterCreateWebhookDeliveriesCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmCreateWebhookDeliveries.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// CreateWebhookDeliveries implements repository.Repository
+func (mmCreateWebhookDeliveries *RepositoryMock) CreateWebhookDeliveries(ctx context.Context, deliveries []*domain.WebhookDelivery) (err error) {
+	mm_atomic.AddUint64(&mmCreateWebhookDeliveries.beforeCreateWebhookDeliveriesCounter, 1)
+	defer mm_atomic.AddUint64(&mmCreateWebhookDeliveries.afterCreateWebhookDeliveriesCounter, 1)
+
+	if mmCreateWebhookDeliveries.inspectFuncCreateWebhookDeliveries != nil {
+		mmCreateWebhookDeliveries.inspectFuncCreateWebhookDeliveries(ctx, deliveries)
+	}
+
+	mm_params := RepositoryMockCreateWebhookDeliveriesParams{ctx, deliveries}
+
+	// Record call args
+	mmCreateWebhookDeliveries.CreateWebhookDeliveriesMock.mutex.Lock()
+	mmCreateWebhookDeliveries.CreateWebhookDeliveriesMock.callArgs = append(mmCreateWebhookDeliveries.CreateWebhookDeliveriesMock.callArgs, &mm_params)
+	mmCreateWebhookDeliveries.CreateWebhookDeliveriesMock.mutex.Unlock()
+
+	for _, e := range mmCreateWebhookDeliveries.CreateWebhookDeliveriesMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmCreateWebhookDeliveries.CreateWebhookDeliveriesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmCreateWebhookDeliveries.CreateWebhookDeliveriesMock.defaultExpectation.Counter, 1)
+		mm_want := mmCreateWebhookDeliveries.CreateWebhookDeliveriesMock.defaultExpectation.params
+		mm_want_ptrs := mmCreateWebhookDeliveries.CreateWebhookDeliveriesMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockCreateWebhookDeliveriesParams{ctx, deliveries}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmCreateWebhookDeliveries.t.Errorf("RepositoryMock.CreateWebhookDeliveries got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.deliveries != nil && !minimock.Equal(*mm_want_ptrs.deliveries, mm_got.deliveries) {
+				mmCreateWebhookDeliveries.t.Errorf("RepositoryMock.CreateWebhookDeliveries got unexpected parameter deliveries, want: %#v, got: %#v%s\n", *mm_want_ptrs.deliveries, mm_got.deliveries, minimock.Diff(*mm_want_ptrs.deliveries, mm_got.deliveries))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmCreateWebhookDeliveries.t.Errorf("RepositoryMock.CreateWebhookDeliveries got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmCreateWebhookDeliveries.CreateWebhookDeliveriesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmCreateWebhookDeliveries.t.Fatal("No results are set for the RepositoryMock.CreateWebhookDeliveries")
+		}
+		return (*mm_results).err
+	}
+	if mmCreateWebhookDeliveries.funcCreateWebhookDeliveries != nil {
+		return mmCreateWebhookDeliveries.funcCreateWebhookDeliveries(ctx, deliveries)
+	}
+	mmCreateWebhookDeliveries.t.Fatalf("Unexpected call to RepositoryMock.CreateWebhookDeliveries. %v %v", ctx, deliveries)
+	return
+}
+
+// CreateWebhookDeliveriesAfterCounter returns a count of finished RepositoryMock.CreateWebhookDeliveries invocations
+func (mmCreateWebhookDeliveries *RepositoryMock) CreateWebhookDeliveriesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateWebhookDeliveries.afterCreateWebhookDeliveriesCounter)
+}
+
+// CreateWebhookDeliveriesBeforeCounter returns a count of RepositoryMock.CreateWebhookDeliveries invocations
+func (mmCreateWebhookDeliveries *RepositoryMock) CreateWebhookDeliveriesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmCreateWebhookDeliveries.beforeCreateWebhookDeliveriesCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.CreateWebhookDeliveries.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmCreateWebhookDeliveries *mRepositoryMockCreateWebhookDeliveries) Calls() []*RepositoryMockCreateWebhookDeliveriesParams {
+	mmCreateWebhookDeliveries.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockCreateWebhookDeliveriesParams, len(mmCreateWebhookDeliveries.callArgs))
+	copy(argCopy, mmCreateWebhookDeliveries.callArgs)
+
+	mmCreateWebhookDeliveries.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockCreateWebhookDeliveriesDone returns true if the count of the CreateWebhookDeliveries invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockCreateWebhookDeliveriesDone() bool {
+	for _, e := range m.CreateWebhookDeliveriesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.CreateWebhookDeliveriesMock.invocationsDone()
+}
+
+// MinimockCreateWebhookDeliveriesInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockCreateWebhookDeliveriesInspect() {
+	for _, e := range m.CreateWebhookDeliveriesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.CreateWebhookDeliveries with params: %#v", *e.params)
+		}
+	}
+
+	afterCreateWebhookDeliveriesCounter := mm_atomic.LoadUint64(&m.afterCreateWebhookDeliveriesCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.CreateWebhookDeliveriesMock.defaultExpectation != nil && afterCreateWebhookDeliveriesCounter < 1 {
+		if m.CreateWebhookDeliveriesMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.CreateWebhookDeliveries")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.CreateWebhookDeliveries with params: %#v", *m.CreateWebhookDeliveriesMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcCreateWebhookDeliveries != nil && afterCreateWebhookDeliveriesCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.CreateWebhookDeliveries")
+	}
+
+	if !m.CreateWebhookDeliveriesMock.invocationsDone() && afterCreateWebhookDeliveriesCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.CreateWebhookDeliveries but found %d calls",
+			mm_atomic.LoadUint64(&m.CreateWebhookDeliveriesMock.expectedInvocations), afterCreateWebhookDeliveriesCounter)
+	}
+}
+
+type mRepositoryMockDeleteComment struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockDeleteCommentExpectation
+	expectations       []*RepositoryMockDeleteCommentExpectation
+
+	callArgs []*RepositoryMockDeleteCommentParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockDeleteCommentExpectation specifies expectation struct of the Repository.DeleteComment
+type RepositoryMockDeleteCommentExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockDeleteCommentParams
+	paramPtrs *RepositoryMockDeleteCommentParamPtrs
+	results   *RepositoryMockDeleteCommentResults
+	Counter   uint64
+}
+
+// RepositoryMockDeleteCommentParams contains parameters of the Repository.DeleteComment
+type RepositoryMockDeleteCommentParams struct {
+	ctx context.Context
+	id  int
+}
+
+// RepositoryMockDeleteCommentParamPtrs contains pointers to parameters of the Repository.DeleteComment
+type RepositoryMockDeleteCommentParamPtrs struct {
+	ctx *context.Context
+	id  *int
+}
+
+// RepositoryMockDeleteCommentResults contains results of the Repository.DeleteComment
+type RepositoryMockDeleteCommentResults struct {
+	err error
+}
+
+// Expect sets up expected params for Repository.DeleteComment
+func (mmDeleteComment *mRepositoryMockDeleteComment) Expect(ctx context.Context, id int) *mRepositoryMockDeleteComment {
+	if mmDeleteComment.mock.funcDeleteComment != nil {
+		mmDeleteComment.mock.t.Fatalf("RepositoryMock.DeleteComment mock is already set by Set")
+	}
+
+	if mmDeleteComment.defaultExpectation == nil {
+		mmDeleteComment.defaultExpectation = &RepositoryMockDeleteCommentExpectation{}
+	}
+
+	if mmDeleteComment.defaultExpectation.paramPtrs != nil {
+		mmDeleteComment.mock.t.Fatalf("RepositoryMock.DeleteComment mock is already set by ExpectParams functions")
+	}
+
+	mmDeleteComment.defaultExpectation.params = &RepositoryMockDeleteCommentParams{ctx, id}
+	for _, e := range mmDeleteComment.expectations {
+		if minimock.Equal(e.params, mmDeleteComment.defaultExpectation.params) {
+			mmDeleteComment.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteComment.defaultExpectation.params)
+		}
+	}
+
+	return mmDeleteComment
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.DeleteComment
+func (mmDeleteComment *mRepositoryMockDeleteComment) ExpectCtxParam1(ctx context.Context) *mRepositoryMockDeleteComment {
+	if mmDeleteComment.mock.funcDeleteComment != nil {
+		mmDeleteComment.mock.t.Fatalf("RepositoryMock.DeleteComment mock is already set by Set")
+	}
+
+	if mmDeleteComment.defaultExpectation == nil {
+		mmDeleteComment.defaultExpectation = &RepositoryMockDeleteCommentExpectation{}
+	}
+
+	if mmDeleteComment.defaultExpectation.params != nil {
+		mmDeleteComment.mock.t.Fatalf("RepositoryMock.DeleteComment mock is already set by Expect")
+	}
+
+	if mmDeleteComment.defaultExpectation.paramPtrs == nil {
+		mmDeleteComment.defaultExpectation.paramPtrs = &RepositoryMockDeleteCommentParamPtrs{}
+	}
+	mmDeleteComment.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmDeleteComment
+}
+
+// ExpectIdParam2 sets up expected param id for Repository.DeleteComment
+func (mmDeleteComment *mRepositoryMockDeleteComment) ExpectIdParam2(id int) *mRepositoryMockDeleteComment {
+	if mmDeleteComment.mock.funcDeleteComment != nil {
+		mmDeleteComment.mock.t.Fatalf("RepositoryMock.DeleteComment mock is already set by Set")
+	}
+
+	if mmDeleteComment.defaultExpectation == nil {
+		mmDeleteComment.defaultExpectation = &RepositoryMockDeleteCommentExpectation{}
+	}
+
+	if mmDeleteComment.defaultExpectation.params != nil {
+		mmDeleteComment.mock.t.Fatalf("RepositoryMock.DeleteComment mock is already set by Expect")
+	}
+
+	if mmDeleteComment.defaultExpectation.paramPtrs == nil {
+		mmDeleteComment.defaultExpectation.paramPtrs = &RepositoryMockDeleteCommentParamPtrs{}
+	}
+	mmDeleteComment.defaultExpectation.paramPtrs.id = &id
+
+	return mmDeleteComment
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.DeleteComment
+func (mmDeleteComment *mRepositoryMockDeleteComment) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockDeleteComment {
+	if mmDeleteComment.mock.inspectFuncDeleteComment != nil {
+		mmDeleteComment.mock.t.Fatalf("Inspect function is already set for RepositoryMock.DeleteComment")
+	}
+
+	mmDeleteComment.mock.inspectFuncDeleteComment = f
+
+	return mmDeleteComment
+}
+
+// Return sets up results that will be returned by Repository.DeleteComment
+func (mmDeleteComment *mRepositoryMockDeleteComment) Return(err error) *RepositoryMock {
+	if mmDeleteComment.mock.funcDeleteComment != nil {
+		mmDeleteComment.mock.t.Fatalf("RepositoryMock.DeleteComment mock is already set by Set")
+	}
+
+	if mmDeleteComment.defaultExpectation == nil {
+		mmDeleteComment.defaultExpectation = &RepositoryMockDeleteCommentExpectation{mock: mmDeleteComment.mock}
+	}
+	mmDeleteComment.defaultExpectation.results = &RepositoryMockDeleteCommentResults{err}
+	return mmDeleteComment.mock
+}
+
+// Set uses given function f to mock the Repository.DeleteComment method
+func (mmDeleteComment *mRepositoryMockDeleteComment) Set(f func(ctx context.Context, id int) (err error)) *RepositoryMock {
+	if mmDeleteComment.defaultExpectation != nil {
+		mmDeleteComment.mock.t.Fatalf("Default expectation is already set for the Repository.DeleteComment method")
+	}
+
+	if len(mmDeleteComment.expectations) > 0 {
+		mmDeleteComment.mock.t.Fatalf("Some expectations are already set for the Repository.DeleteComment method")
+	}
+
+	mmDeleteComment.mock.funcDeleteComment = f
+	return mmDeleteComment.mock
+}
+
+// When sets expectation for the Repository.DeleteComment which will trigger the result defined by the following
+// Then helper
+func (mmDeleteComment *mRepositoryMockDeleteComment) When(ctx context.Context, id int) *RepositoryMockDeleteCommentExpectation {
+	if mmDeleteComment.mock.funcDeleteComment != nil {
+		mmDeleteComment.mock.t.Fatalf("RepositoryMock.DeleteComment mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockDeleteCommentExpectation{
+		mock:   mmDeleteComment.mock,
+		params: &RepositoryMockDeleteCommentParams{ctx, id},
+	}
+	mmDeleteComment.expectations = append(mmDeleteComment.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.DeleteComment return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockDeleteCommentExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockDeleteCommentResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.DeleteComment should be invoked
+func (mmDeleteComment *mRepositoryMockDeleteComment) Times(n uint64) *mRepositoryMockDeleteComment {
+	if n == 0 {
+		mmDeleteComment.mock.t.Fatalf("Times of RepositoryMock.DeleteComment mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmDeleteComment.expectedInvocations, n)
+	return mmDeleteComment
+}
+
+func (mmDeleteComment *mRepositoryMockDeleteComment) invocationsDone() bool {
+	if len(mmDeleteComment.expectations) == 0 && mmDeleteComment.defaultExpectation == nil && mmDeleteComment.mock.funcDeleteComment == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmDeleteComment.mock.afterDeleteCommentCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDeleteComment.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// DeleteComment implements repository.Repository
+func (mmDeleteComment *RepositoryMock) DeleteComment(ctx context.Context, id int) (err error) {
+	mm_atomic.AddUint64(&mmDeleteComment.beforeDeleteCommentCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteComment.afterDeleteCommentCounter, 1)
+
+	if mmDeleteComment.inspectFuncDeleteComment != nil {
+		mmDeleteComment.inspectFuncDeleteComment(ctx, id)
+	}
+
+	mm_params := RepositoryMockDeleteCommentParams{ctx, id}
+
+	// Record call args
+	mmDeleteComment.DeleteCommentMock.mutex.Lock()
+	mmDeleteComment.DeleteCommentMock.callArgs = append(mmDeleteComment.DeleteCommentMock.callArgs, &mm_params)
+	mmDeleteComment.DeleteCommentMock.mutex.Unlock()
+
+	for _, e := range mmDeleteComment.DeleteCommentMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmDeleteComment.DeleteCommentMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteComment.DeleteCommentMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteComment.DeleteCommentMock.defaultExpectation.params
+		mm_want_ptrs := mmDeleteComment.DeleteCommentMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockDeleteCommentParams{ctx, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmDeleteComment.t.Errorf("RepositoryMock.DeleteComment got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmDeleteComment.t.Errorf("RepositoryMock.DeleteComment got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeleteComment.t.Errorf("RepositoryMock.DeleteComment got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeleteComment.DeleteCommentMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteComment.t.Fatal("No results are set for the RepositoryMock.DeleteComment")
+		}
+		return (*mm_results).err
+	}
+	if mmDeleteComment.funcDeleteComment != nil {
+		return mmDeleteComment.funcDeleteComment(ctx, id)
+	}
+	mmDeleteComment.t.Fatalf("Unexpected call to RepositoryMock.DeleteComment. %v %v", ctx, id)
+	return
+}
+
+// DeleteCommentAfterCounter returns a count of finished RepositoryMock.DeleteComment invocations
+func (mmDeleteComment *RepositoryMock) DeleteCommentAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteComment.afterDeleteCommentCounter)
+}
+
+// DeleteCommentBeforeCounter returns a count of RepositoryMock.DeleteComment invocations
+func (mmDeleteComment *RepositoryMock) DeleteCommentBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteComment.beforeDeleteCommentCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.DeleteComment.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeleteComment *mRepositoryMockDeleteComment) Calls() []*RepositoryMockDeleteCommentParams {
+	mmDeleteComment.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockDeleteCommentParams, len(mmDeleteComment.callArgs))
+	copy(argCopy, mmDeleteComment.callArgs)
+
+	mmDeleteComment.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDeleteCommentDone returns true if the count of the DeleteComment invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockDeleteCommentDone() bool {
+	for _, e := range m.DeleteCommentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.DeleteCommentMock.invocationsDone()
+}
+
+// MinimockDeleteCommentInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockDeleteCommentInspect() {
+	for _, e := range m.DeleteCommentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.DeleteComment with params: %#v", *e.params)
+		}
+	}
+
+	afterDeleteCommentCounter := mm_atomic.LoadUint64(&m.afterDeleteCommentCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteCommentMock.defaultExpectation != nil && afterDeleteCommentCounter < 1 {
+		if m.DeleteCommentMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.DeleteComment")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.DeleteComment with params: %#v", *m.DeleteCommentMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteComment != nil && afterDeleteCommentCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.DeleteComment")
+	}
+
+	if !m.DeleteCommentMock.invocationsDone() && afterDeleteCommentCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.DeleteComment but found %d calls",
+			mm_atomic.LoadUint64(&m.DeleteCommentMock.expectedInvocations), afterDeleteCommentCounter)
+	}
+}
+
+type mRepositoryMockDeletePost struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockDeletePostExpectation
+	expectations       []*RepositoryMockDeletePostExpectation
+
+	callArgs []*RepositoryMockDeletePostParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockDeletePostExpectation specifies expectation struct of the Repository.DeletePost
+type RepositoryMockDeletePostExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockDeletePostParams
+	paramPtrs *RepositoryMockDeletePostParamPtrs
+	results   *RepositoryMockDeletePostResults
+	Counter   uint64
+}
+
+// RepositoryMockDeletePostParams contains parameters of the Repository.DeletePost
+type RepositoryMockDeletePostParams struct {
+	ctx context.Context
+	id  int
+}
+
+// RepositoryMockDeletePostParamPtrs contains pointers to parameters of the Repository.DeletePost
+type RepositoryMockDeletePostParamPtrs struct {
+	ctx *context.Context
+	id  *int
+}
+
+// RepositoryMockDeletePostResults contains results of the Repository.DeletePost
+type RepositoryMockDeletePostResults struct {
+	err error
+}
+
+// Expect sets up expected params for Repository.DeletePost
+func (mmDeletePost *mRepositoryMockDeletePost) Expect(ctx context.Context, id int) *mRepositoryMockDeletePost {
+	if mmDeletePost.mock.funcDeletePost != nil {
+		mmDeletePost.mock.t.Fatalf("RepositoryMock.DeletePost mock is already set by Set")
+	}
+
+	if mmDeletePost.defaultExpectation == nil {
+		mmDeletePost.defaultExpectation = &RepositoryMockDeletePostExpectation{}
+	}
+
+	if mmDeletePost.defaultExpectation.paramPtrs != nil {
+		mmDeletePost.mock.t.Fatalf("RepositoryMock.DeletePost mock is already set by ExpectParams functions")
+	}
+
+	mmDeletePost.defaultExpectation.params = &RepositoryMockDeletePostParams{ctx, id}
+	for _, e := range mmDeletePost.expectations {
+		if minimock.Equal(e.params, mmDeletePost.defaultExpectation.params) {
+			mmDeletePost.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeletePost.defaultExpectation.params)
+		}
+	}
+
+	return mmDeletePost
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.DeletePost
+func (mmDeletePost *mRepositoryMockDeletePost) ExpectCtxParam1(ctx context.Context) *mRepositoryMockDeletePost {
+	if mmDeletePost.mock.funcDeletePost != nil {
+		mmDeletePost.mock.t.Fatalf("RepositoryMock.DeletePost mock is already set by Set")
+	}
+
+	if mmDeletePost.defaultExpectation == nil {
+		mmDeletePost.defaultExpectation = &RepositoryMockDeletePostExpectation{}
+	}
+
+	if mmDeletePost.defaultExpectation.params != nil {
+		mmDeletePost.mock.t.Fatalf("RepositoryMock.DeletePost mock is already set by Expect")
+	}
+
+	if mmDeletePost.defaultExpectation.paramPtrs == nil {
+		mmDeletePost.defaultExpectation.paramPtrs = &RepositoryMockDeletePostParamPtrs{}
+	}
+	mmDeletePost.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmDeletePost
+}
+
+// ExpectIdParam2 sets up expected param id for Repository.DeletePost
+func (mmDeletePost *mRepositoryMockDeletePost) ExpectIdParam2(id int) *mRepositoryMockDeletePost {
+	if mmDeletePost.mock.funcDeletePost != nil {
+		mmDeletePost.mock.t.Fatalf("RepositoryMock.DeletePost mock is already set by Set")
+	}
+
+	if mmDeletePost.defaultExpectation == nil {
+		mmDeletePost.defaultExpectation = &RepositoryMockDeletePostExpectation{}
+	}
+
+	if mmDeletePost.defaultExpectation.params != nil {
+		mmDeletePost.mock.t.Fatalf("RepositoryMock.DeletePost mock is already set by Expect")
+	}
+
+	if mmDeletePost.defaultExpectation.paramPtrs == nil {
+		mmDeletePost.defaultExpectation.paramPtrs = &RepositoryMockDeletePostParamPtrs{}
+	}
+	mmDeletePost.defaultExpectation.paramPtrs.id = &id
+
+	return mmDeletePost
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.DeletePost
+func (mmDeletePost *mRepositoryMockDeletePost) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockDeletePost {
+	if mmDeletePost.mock.inspectFuncDeletePost != nil {
+		mmDeletePost.mock.t.Fatalf("Inspect function is already set for RepositoryMock.DeletePost")
+	}
+
+	mmDeletePost.mock.inspectFuncDeletePost = f
+
+	return mmDeletePost
+}
+
+// Return sets up results that will be returned by Repository.DeletePost
+func (mmDeletePost *mRepositoryMockDeletePost) Return(err error) *RepositoryMock {
+	if mmDeletePost.mock.funcDeletePost != nil {
+		mmDeletePost.mock.t.Fatalf("RepositoryMock.DeletePost mock is already set by Set")
+	}
+
+	if mmDeletePost.defaultExpectation == nil {
+		mmDeletePost.defaultExpectation = &RepositoryMockDeletePostExpectation{mock: mmDeletePost.mock}
+	}
+	mmDeletePost.defaultExpectation.results = &RepositoryMockDeletePostResults{err}
+	return mmDeletePost.mock
+}
+
+// Set uses given function f to mock the Repository.DeletePost method
+func (mmDeletePost *mRepositoryMockDeletePost) Set(f func(ctx context.Context, id int) (err error)) *RepositoryMock {
+	if mmDeletePost.defaultExpectation != nil {
+		mmDeletePost.mock.t.Fatalf("Default expectation is already set for the Repository.DeletePost method")
+	}
+
+	if len(mmDeletePost.expectations) > 0 {
+		mmDeletePost.mock.t.Fatalf("Some expectations are already set for the Repository.DeletePost method")
+	}
+
+	mmDeletePost.mock.funcDeletePost = f
+	return mmDeletePost.mock
+}
+
+// When sets expectation for the Repository.DeletePost which will trigger the result defined by the following
+// Then helper
+func (mmDeletePost *mRepositoryMockDeletePost) When(ctx context.Context, id int) *RepositoryMockDeletePostExpectation {
+	if mmDeletePost.mock.funcDeletePost != nil {
+		mmDeletePost.mock.t.Fatalf("RepositoryMock.DeletePost mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockDeletePostExpectation{
+		mock:   mmDeletePost.mock,
+		params: &RepositoryMockDeletePostParams{ctx, id},
+	}
+	mmDeletePost.expectations = append(mmDeletePost.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.DeletePost return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockDeletePostExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockDeletePostResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.DeletePost should be invoked
+func (mmDeletePost *mRepositoryMockDeletePost) Times(n uint64) *mRepositoryMockDeletePost {
+	if n == 0 {
+		mmDeletePost.mock.t.Fatalf("Times of RepositoryMock.DeletePost mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmDeletePost.expectedInvocations, n)
+	return mmDeletePost
+}
+
+func (mmDeletePost *mRepositoryMockDeletePost) invocationsDone() bool {
+	if len(mmDeletePost.expectations) == 0 && mmDeletePost.defaultExpectation == nil && mmDeletePost.mock.funcDeletePost == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmDeletePost.mock.afterDeletePostCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDeletePost.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// DeletePost implements repository.Repository
+func (mmDeletePost *RepositoryMock) DeletePost(ctx context.Context, id int) (err error) {
+	mm_atomic.AddUint64(&mmDeletePost.beforeDeletePostCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeletePost.afterDeletePostCounter, 1)
+
+	if mmDeletePost.inspectFuncDeletePost != nil {
+		mmDeletePost.inspectFuncDeletePost(ctx, id)
+	}
+
+	mm_params := RepositoryMockDeletePostParams{ctx, id}
+
+	// Record call args
+	mmDeletePost.DeletePostMock.mutex.Lock()
+	mmDeletePost.DeletePostMock.callArgs = append(mmDeletePost.DeletePostMock.callArgs, &mm_params)
+	mmDeletePost.DeletePostMock.mutex.Unlock()
+
+	for _, e := range mmDeletePost.DeletePostMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmDeletePost.DeletePostMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeletePost.DeletePostMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeletePost.DeletePostMock.defaultExpectation.params
+		mm_want_ptrs := mmDeletePost.DeletePostMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockDeletePostParams{ctx, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmDeletePost.t.Errorf("RepositoryMock.DeletePost got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmDeletePost.t.Errorf("RepositoryMock.DeletePost got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeletePost.t.Errorf("RepositoryMock.DeletePost got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeletePost.DeletePostMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeletePost.t.Fatal("No results are set for the RepositoryMock.DeletePost")
+		}
+		return (*mm_results).err
+	}
+	if mmDeletePost.funcDeletePost != nil {
+		return mmDeletePost.funcDeletePost(ctx, id)
+	}
+	mmDeletePost.t.Fatalf("Unexpected call to RepositoryMock.DeletePost. %v %v", ctx, id)
+	return
+}
+
+// DeletePostAfterCounter returns a count of finished RepositoryMock.DeletePost invocations
+func (mmDeletePost *RepositoryMock) DeletePostAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeletePost.afterDeletePostCounter)
+}
+
+// DeletePostBeforeCounter returns a count of RepositoryMock.DeletePost invocations
+func (mmDeletePost *RepositoryMock) DeletePostBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeletePost.beforeDeletePostCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.DeletePost.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeletePost *mRepositoryMockDeletePost) Calls() []*RepositoryMockDeletePostParams {
+	mmDeletePost.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockDeletePostParams, len(mmDeletePost.callArgs))
+	copy(argCopy, mmDeletePost.callArgs)
+
+	mmDeletePost.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDeletePostDone returns true if the count of the DeletePost invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockDeletePostDone() bool {
+	for _, e := range m.DeletePostMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.DeletePostMock.invocationsDone()
+}
+
+// MinimockDeletePostInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockDeletePostInspect() {
+	for _, e := range m.DeletePostMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.DeletePost with params: %#v", *e.params)
+		}
+	}
+
+	afterDeletePostCounter := mm_atomic.LoadUint64(&m.afterDeletePostCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeletePostMock.defaultExpectation != nil && afterDeletePostCounter < 1 {
+		if m.DeletePostMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.DeletePost")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.DeletePost with params: %#v", *m.DeletePostMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeletePost != nil && afterDeletePostCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.DeletePost")
+	}
+
+	if !m.DeletePostMock.invocationsDone() && afterDeletePostCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.DeletePost but found %d calls",
+			mm_atomic.LoadUint64(&m.DeletePostMock.expectedInvocations), afterDeletePostCounter)
+	}
+}
+
+type mRepositoryMockDeletePublishedEvents struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockDeletePublishedEventsExpectation
+	expectations       []*RepositoryMockDeletePublishedEventsExpectation
+
+	callArgs []*RepositoryMockDeletePublishedEventsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockDeletePublishedEventsExpectation specifies expectation struct of the Repository.DeletePublishedEvents
+type RepositoryMockDeletePublishedEventsExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockDeletePublishedEventsParams
+	paramPtrs *RepositoryMockDeletePublishedEventsParamPtrs
+	results   *RepositoryMockDeletePublishedEventsResults
+	Counter   uint64
+}
+
+// RepositoryMockDeletePublishedEventsParams contains parameters of the Repository.DeletePublishedEvents
+type RepositoryMockDeletePublishedEventsParams struct {
+	ctx    context.Context
+	before time.Time
+}
+
+// RepositoryMockDeletePublishedEventsParamPtrs contains pointers to parameters of the Repository.DeletePublishedEvents
+type RepositoryMockDeletePublishedEventsParamPtrs struct {
+	ctx    *context.Context
+	before *time.Time
+}
+
+// RepositoryMockDeletePublishedEventsResults contains results of the Repository.DeletePublishedEvents
+type RepositoryMockDeletePublishedEventsResults struct {
+	i1  int
+	err error
+}
+
+// Expect sets up expected params for Repository.DeletePublishedEvents
+func (mmDeletePublishedEvents *mRepositoryMockDeletePublishedEvents) Expect(ctx context.Context, before time.Time) *mRepositoryMockDeletePublishedEvents {
+	if mmDeletePublishedEvents.mock.funcDeletePublishedEvents != nil {
+		mmDeletePublishedEvents.mock.t.Fatalf("RepositoryMock.DeletePublishedEvents mock is already set by Set")
+	}
+
+	if mmDeletePublishedEvents.defaultExpectation == nil {
+		mmDeletePublishedEvents.defaultExpectation = &RepositoryMockDeletePublishedEventsExpectation{}
+	}
+
+	if mmDeletePublishedEvents.defaultExpectation.paramPtrs != nil {
+		mmDeletePublishedEvents.mock.t.Fatalf("RepositoryMock.DeletePublishedEvents mock is already set by ExpectParams functions")
+	}
+
+	mmDeletePublishedEvents.defaultExpectation.params = &RepositoryMockDeletePublishedEventsParams{ctx, before}
+	for _, e := range mmDeletePublishedEvents.expectations {
+		if minimock.Equal(e.params, mmDeletePublishedEvents.defaultExpectation.params) {
+			mmDeletePublishedEvents.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeletePublishedEvents.defaultExpectation.params)
+		}
+	}
+
+	return mmDeletePublishedEvents
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.DeletePublishedEvents
+func (mmDeletePublishedEvents *mRepositoryMockDeletePublishedEvents) ExpectCtxParam1(ctx context.Context) *mRepositoryMockDeletePublishedEvents {
+	if mmDeletePublishedEvents.mock.funcDeletePublishedEvents != nil {
+		mmDeletePublishedEvents.mock.t.Fatalf("RepositoryMock.DeletePublishedEvents mock is already set by Set")
+	}
+
+	if mmDeletePublishedEvents.defaultExpectation == nil {
+		mmDeletePublishedEvents.defaultExpectation = &RepositoryMockDeletePublishedEventsExpectation{}
+	}
+
+	if mmDeletePublishedEvents.defaultExpectation.params != nil {
+		mmDeletePublishedEvents.mock.t.Fatalf("RepositoryMock.DeletePublishedEvents mock is already set by Expect")
+	}
+
+	if mmDeletePublishedEvents.defaultExpectation.paramPtrs == nil {
+		mmDeletePublishedEvents.defaultExpectation.paramPtrs = &RepositoryMockDeletePublishedEventsParamPtrs{}
+	}
+	mmDeletePublishedEvents.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmDeletePublishedEvents
+}
+
+// ExpectBeforeParam2 sets up expected param before for Repository.DeletePublishedEvents
+func (mmDeletePublishedEvents *mRepositoryMockDeletePublishedEvents) ExpectBeforeParam2(before time.Time) *mRepositoryMockDeletePublishedEvents {
+	if mmDeletePublishedEvents.mock.funcDeletePublishedEvents != nil {
+		mmDeletePublishedEvents.mock.t.Fatalf("RepositoryMock.DeletePublishedEvents mock is already set by Set")
+	}
+
+	if mmDeletePublishedEvents.defaultExpectation == nil {
+		mmDeletePublishedEvents.defaultExpectation = &RepositoryMockDeletePublishedEventsExpectation{}
+	}
+
+	if mmDeletePublishedEvents.defaultExpectation.params != nil {
+		mmDeletePublishedEvents.mock.t.Fatalf("RepositoryMock.DeletePublishedEvents mock is already set by Expect")
+	}
+
+	if mmDeletePublishedEvents.defaultExpectation.paramPtrs == nil {
+		mmDeletePublishedEvents.defaultExpectation.paramPtrs = &RepositoryMockDeletePublishedEventsParamPtrs{}
+	}
+	mmDeletePublishedEvents.defaultExpectation.paramPtrs.before = &before
+
+	return mmDeletePublishedEvents
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.DeletePublishedEvents
+func (mmDeletePublishedEvents *mRepositoryMockDeletePublishedEvents) Inspect(f func(ctx context.Context, before time.Time)) *mRepositoryMockDeletePublishedEvents {
+	if mmDeletePublishedEvents.mock.inspectFuncDeletePublishedEvents != nil {
+		mmDeletePublishedEvents.mock.t.Fatalf("Inspect function is already set for RepositoryMock.DeletePublishedEvents")
+	}
+
+	mmDeletePublishedEvents.mock.inspectFuncDeletePublishedEvents = f
+
+	return mmDeletePublishedEvents
+}
+
+// Return sets up results that will be returned by Repository.DeletePublishedEvents
+func (mmDeletePublishedEvents *mRepositoryMockDeletePublishedEvents) Return(i1 int, err error) *RepositoryMock {
+	if mmDeletePublishedEvents.mock.funcDeletePublishedEvents != nil {
+		mmDeletePublishedEvents.mock.t.Fatalf("RepositoryMock.DeletePublishedEvents mock is already set by Set")
+	}
+
+	if mmDeletePublishedEvents.defaultExpectation == nil {
+		mmDeletePublishedEvents.defaultExpectation = &RepositoryMockDeletePublishedEventsExpectation{mock: mmDeletePublishedEvents.mock}
+	}
+	mmDeletePublishedEvents.defaultExpectation.results = &RepositoryMockDeletePublishedEventsResults{i1, err}
+	return mmDeletePublishedEvents.mock
+}
+
+// Set uses given function f to mock the Repository.DeletePublishedEvents method
+func (mmDeletePublishedEvents *mRepositoryMockDeletePublishedEvents) Set(f func(ctx context.Context, before time.Time) (i1 int, err error)) *RepositoryMock {
+	if mmDeletePublishedEvents.defaultExpectation != nil {
+		mmDeletePublishedEvents.mock.t.Fatalf("Default expectation is already set for the Repository.DeletePublishedEvents method")
+	}
+
+	if len(mmDeletePublishedEvents.expectations) > 0 {
+		mmDeletePublishedEvents.mock.t.Fatalf("Some expectations are already set for the Repository.DeletePublishedEvents method")
+	}
+
+	mmDeletePublishedEvents.mock.funcDeletePublishedEvents = f
+	return mmDeletePublishedEvents.mock
+}
+
+// When sets expectation for the Repository.DeletePublishedEvents which will trigger the result defined by the following
+// Then helper
+func (mmDeletePublishedEvents *mRepositoryMockDeletePublishedEvents) When(ctx context.Context, before time.Time) *RepositoryMockDeletePublishedEventsExpectation {
+	if mmDeletePublishedEvents.mock.funcDeletePublishedEvents != nil {
+		mmDeletePublishedEvents.mock.t.Fatalf("RepositoryMock.DeletePublishedEvents mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockDeletePublishedEventsExpectation{
+		mock:   mmDeletePublishedEvents.mock,
+		params: &RepositoryMockDeletePublishedEventsParams{ctx, before},
+	}
+	mmDeletePublishedEvents.expectations = append(mmDeletePublishedEvents.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.DeletePublishedEvents return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockDeletePublishedEventsExpectation) Then(i1 int, err error) *RepositoryMock {
+	e.results = &RepositoryMockDeletePublishedEventsResults{i1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.DeletePublishedEvents should be invoked
+func (mmDeletePublishedEvents *mRepositoryMockDeletePublishedEvents) Times(n uint64) *mRepositoryMockDeletePublishedEvents {
+	if n == 0 {
+		mmDeletePublishedEvents.mock.t.Fatalf("Times of RepositoryMock.DeletePublishedEvents mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmDeletePublishedEvents.expectedInvocations, n)
+	return mmDeletePublishedEvents
+}
+
+func (mmDeletePublishedEvents *mRepositoryMockDeletePublishedEvents) invocationsDone() bool {
+	if len(mmDeletePublishedEvents.expectations) == 0 && mmDeletePublishedEvents.defaultExpectation == nil && mmDeletePublishedEvents.mock.funcDeletePublishedEvents == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmDeletePublishedEvents.mock.afterDeletePublishedEventsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDeletePublishedEvents.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// DeletePublishedEvents implements repository.Repository
+func (mmDeletePublishedEvents *RepositoryMock) DeletePublishedEvents(ctx context.Context, before time.Time) (i1 int, err error) {
+	mm_atomic.AddUint64(&mmDeletePublishedEvents.beforeDeletePublishedEventsCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeletePublishedEvents.afterDeletePublishedEventsCounter, 1)
+
+	if mmDeletePublishedEvents.inspectFuncDeletePublishedEvents != nil {
+		mmDeletePublishedEvents.inspectFuncDeletePublishedEvents(ctx, before)
+	}
+
+	mm_params := RepositoryMockDeletePublishedEventsParams{ctx, before}
+
+	// Record call args
+	mmDeletePublishedEvents.DeletePublishedEventsMock.mutex.Lock()
+	mmDeletePublishedEvents.DeletePublishedEventsMock.callArgs = append(mmDeletePublishedEvents.DeletePublishedEventsMock.callArgs, &mm_params)
+	mmDeletePublishedEvents.DeletePublishedEventsMock.mutex.Unlock()
+
+	for _, e := range mmDeletePublishedEvents.DeletePublishedEventsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.i1, e.results.err
+		}
+	}
+
+	if mmDeletePublishedEvents.DeletePublishedEventsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeletePublishedEvents.DeletePublishedEventsMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeletePublishedEvents.DeletePublishedEventsMock.defaultExpectation.params
+		mm_want_ptrs := mmDeletePublishedEvents.DeletePublishedEventsMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockDeletePublishedEventsParams{ctx, before}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmDeletePublishedEvents.t.Errorf("RepositoryMock.DeletePublishedEvents got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.before != nil && !minimock.Equal(*mm_want_ptrs.before, mm_got.before) {
+				mmDeletePublishedEvents.t.Errorf("RepositoryMock.DeletePublishedEvents got unexpected parameter before, want: %#v, got: %#v%s\n", *mm_want_ptrs.before, mm_got.before, minimock.Diff(*mm_want_ptrs.before, mm_got.before))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeletePublishedEvents.t.Errorf("RepositoryMock.DeletePublishedEvents got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeletePublishedEvents.DeletePublishedEventsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeletePublishedEvents.t.Fatal("No results are set for the RepositoryMock.DeletePublishedEvents")
+		}
+		return (*mm_results).i1, (*mm_results).err
+	}
+	if mmDeletePublishedEvents.funcDeletePublishedEvents != nil {
+		return mmDeletePublishedEvents.funcDeletePublishedEvents(ctx, before)
+	}
+	mmDeletePublishedEvents.t.Fatalf("Unexpected call to RepositoryMock.DeletePublishedEvents. %v %v", ctx, before)
+	return
+}
+
+// DeletePublishedEventsAfterCounter returns a count of finished RepositoryMock.DeletePublishedEvents invocations
+func (mmDeletePublishedEvents *RepositoryMock) DeletePublishedEventsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeletePublishedEvents.afterDeletePublishedEventsCounter)
+}
+
+// DeletePublishedEventsBeforeCounter returns a count of RepositoryMock.DeletePublishedEvents invocations
+func (mmDeletePublishedEvents *RepositoryMock) DeletePublishedEventsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeletePublishedEvents.beforeDeletePublishedEventsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.DeletePublishedEvents.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeletePublishedEvents *mRepositoryMockDeletePublishedEvents) Calls() []*RepositoryMockDeletePublishedEventsParams {
+	mmDeletePublishedEvents.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockDeletePublishedEventsParams, len(mmDeletePublishedEvents.callArgs))
+	copy(argCopy, mmDeletePublishedEvents.callArgs)
+
+	mmDeletePublishedEvents.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDeletePublishedEventsDone returns true if the count of the DeletePublishedEvents invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockDeletePublishedEventsDone() bool {
+	for _, e := range m.DeletePublishedEventsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.DeletePublishedEventsMock.invocationsDone()
+}
+
+// MinimockDeletePublishedEventsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockDeletePublishedEventsInspect() {
+	for _, e := range m.DeletePublishedEventsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.DeletePublishedEvents with params: %#v", *e.params)
+		}
+	}
+
+	afterDeletePublishedEventsCounter := mm_atomic.LoadUint64(&m.afterDeletePublishedEventsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeletePublishedEventsMock.defaultExpectation != nil && afterDeletePublishedEventsCounter < 1 {
+		if m.DeletePublishedEventsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.DeletePublishedEvents")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.DeletePublishedEvents with params: %#v", *m.DeletePublishedEventsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeletePublishedEvents != nil && afterDeletePublishedEventsCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.DeletePublishedEvents")
+	}
+
+	if !m.DeletePublishedEventsMock.invocationsDone() && afterDeletePublishedEventsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.DeletePublishedEvents but found %d calls",
+			mm_atomic.LoadUint64(&m.DeletePublishedEventsMock.expectedInvocations), afterDeletePublishedEventsCounter)
+	}
+}
+
+type mRepositoryMockDeleteWebhook struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockDeleteWebhookExpectation
+	expectations       []*RepositoryMockDeleteWebhookExpectation
+
+	callArgs []*RepositoryMockDeleteWebhookParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockDeleteWebhookExpectation specifies expectation struct of the Repository.DeleteWebhook
+type RepositoryMockDeleteWebhookExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockDeleteWebhookParams
+	paramPtrs *RepositoryMockDeleteWebhookParamPtrs
+	results   *RepositoryMockDeleteWebhookResults
+	Counter   uint64
+}
+
+// RepositoryMockDeleteWebhookParams contains parameters of the Repository.DeleteWebhook
+type RepositoryMockDeleteWebhookParams struct {
+	ctx context.Context
+	id  int
+}
+
+// RepositoryMockDeleteWebhookParamPtrs contains pointers to parameters of the Repository.DeleteWebhook
+type RepositoryMockDeleteWebhookParamPtrs struct {
+	ctx *context.Context
+	id  *int
+}
+
+// RepositoryMockDeleteWebhookResults contains results of the Repository.DeleteWebhook
+type RepositoryMockDeleteWebhookResults struct {
+	err error
+}
+
+// Expect sets up expected params for Repository.DeleteWebhook
+func (mmDeleteWebhook *mRepositoryMockDeleteWebhook) Expect(ctx context.Context, id int) *mRepositoryMockDeleteWebhook {
+	if mmDeleteWebhook.mock.funcDeleteWebhook != nil {
+		mmDeleteWebhook.mock.t.Fatalf("RepositoryMock.DeleteWebhook mock is already set by Set")
+	}
+
+	if mmDeleteWebhook.defaultExpectation == nil {
+		mmDeleteWebhook.defaultExpectation = &RepositoryMockDeleteWebhookExpectation{}
+	}
+
+	if mmDeleteWebhook.defaultExpectation.paramPtrs != nil {
+		mmDeleteWebhook.mock.t.Fatalf("RepositoryMock.DeleteWebhook mock is already set by ExpectParams functions")
+	}
+
+	mmDeleteWebhook.defaultExpectation.params = &RepositoryMockDeleteWebhookParams{ctx, id}
+	for _, e := range mmDeleteWebhook.expectations {
+		if minimock.Equal(e.params, mmDeleteWebhook.defaultExpectation.params) {
+			mmDeleteWebhook.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDeleteWebhook.defaultExpectation.params)
+		}
+	}
+
+	return mmDeleteWebhook
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.DeleteWebhook
+func (mmDeleteWebhook *mRepositoryMockDeleteWebhook) ExpectCtxParam1(ctx context.Context) *mRepositoryMockDeleteWebhook {
+	if mmDeleteWebhook.mock.funcDeleteWebhook != nil {
+		mmDeleteWebhook.mock.t.Fatalf("RepositoryMock.DeleteWebhook mock is already set by Set")
+	}
+
+	if mmDeleteWebhook.defaultExpectation == nil {
+		mmDeleteWebhook.defaultExpectation = &RepositoryMockDeleteWebhookExpectation{}
+	}
+
+	if mmDeleteWebhook.defaultExpectation.params != nil {
+		mmDeleteWebhook.mock.t.Fatalf("RepositoryMock.DeleteWebhook mock is already set by Expect")
+	}
+
+	if mmDeleteWebhook.defaultExpectation.paramPtrs == nil {
+		mmDeleteWebhook.defaultExpectation.paramPtrs = &RepositoryMockDeleteWebhookParamPtrs{}
+	}
+	mmDeleteWebhook.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmDeleteWebhook
+}
+
+// ExpectIdParam2 sets up expected param id for Repository.DeleteWebhook
+func (mmDeleteWebhook *mRepositoryMockDeleteWebhook) ExpectIdParam2(id int) *mRepositoryMockDeleteWebhook {
+	if mmDeleteWebhook.mock.funcDeleteWebhook != nil {
+		mmDeleteWebhook.mock.t.Fatalf("RepositoryMock.DeleteWebhook mock is already set by Set")
+	}
+
+	if mmDeleteWebhook.defaultExpectation == nil {
+		mmDeleteWebhook.defaultExpectation = &RepositoryMockDeleteWebhookExpectation{}
+	}
+
+	if mmDeleteWebhook.defaultExpectation.params != nil {
+		mmDeleteWebhook.mock.t.Fatalf("RepositoryMock.DeleteWebhook mock is already set by Expect")
+	}
+
+	if mmDeleteWebhook.defaultExpectation.paramPtrs == nil {
+		mmDeleteWebhook.defaultExpectation.paramPtrs = &RepositoryMockDeleteWebhookParamPtrs{}
+	}
+	mmDeleteWebhook.defaultExpectation.paramPtrs.id = &id
+
+	return mmDeleteWebhook
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.DeleteWebhook
+func (mmDeleteWebhook *mRepositoryMockDeleteWebhook) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockDeleteWebhook {
+	if mmDeleteWebhook.mock.inspectFuncDeleteWebhook != nil {
+		mmDeleteWebhook.mock.t.Fatalf("Inspect function is already set for RepositoryMock.DeleteWebhook")
+	}
+
+	mmDeleteWebhook.mock.inspectFuncDeleteWebhook = f
+
+	return mmDeleteWebhook
+}
+
+// Return sets up results that will be returned by Repository.DeleteWebhook
+func (mmDeleteWebhook *mRepositoryMockDeleteWebhook) Return(err error) *RepositoryMock {
+	if mmDeleteWebhook.mock.funcDeleteWebhook != nil {
+		mmDeleteWebhook.mock.t.Fatalf("RepositoryMock.DeleteWebhook mock is already set by Set")
+	}
+
+	if mmDeleteWebhook.defaultExpectation == nil {
+		mmDeleteWebhook.defaultExpectation = &RepositoryMockDeleteWebhookExpectation{mock: mmDeleteWebhook.mock}
+	}
+	mmDeleteWebhook.defaultExpectation.results = &RepositoryMockDeleteWebhookResults{err}
+	return mmDeleteWebhook.mock
+}
+
+// Set uses given function f to mock the Repository.DeleteWebhook method
+func (mmDeleteWebhook *mRepositoryMockDeleteWebhook) Set(f func(ctx context.Context, id int) (err error)) *RepositoryMock {
+	if mmDeleteWebhook.defaultExpectation != nil {
+		mmDeleteWebhook.mock.t.Fatalf("Default expectation is already set for the Repository.DeleteWebhook method")
+	}
+
+	if len(mmDeleteWebhook.expectations) > 0 {
+		mmDeleteWebhook.mock.t.Fatalf("Some expectations are already set for the Repository.DeleteWebhook method")
+	}
+
+	mmDeleteWebhook.mock.funcDeleteWebhook = f
+	return mmDeleteWebhook.mock
+}
+
+// When sets expectation for the Repository.DeleteWebhook which will trigger the result defined by the following
+// Then helper
+func (mmDeleteWebhook *mRepositoryMockDeleteWebhook) When(ctx context.Context, id int) *RepositoryMockDeleteWebhookExpectation {
+	if mmDeleteWebhook.mock.funcDeleteWebhook != nil {
+		mmDeleteWebhook.mock.t.Fatalf("RepositoryMock.DeleteWebhook mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockDeleteWebhookExpectation{
+		mock:   mmDeleteWebhook.mock,
+		params: &RepositoryMockDeleteWebhookParams{ctx, id},
+	}
+	mmDeleteWebhook.expectations = append(mmDeleteWebhook.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.DeleteWebhook return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockDeleteWebhookExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockDeleteWebhookResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.DeleteWebhook should be invoked
+func (mmDeleteWebhook *mRepositoryMockDeleteWebhook) Times(n uint64) *mRepositoryMockDeleteWebhook {
+	if n == 0 {
+		mmDeleteWebhook.mock.t.Fatalf("Times of RepositoryMock.DeleteWebhook mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmDeleteWebhook.expectedInvocations, n)
+	return mmDeleteWebhook
+}
+
+func (mmDeleteWebhook *mRepositoryMockDeleteWebhook) invocationsDone() bool {
+	if len(mmDeleteWebhook.expectations) == 0 && mmDeleteWebhook.defaultExpectation == nil && mmDeleteWebhook.mock.funcDeleteWebhook == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmDeleteWebhook.mock.afterDeleteWebhookCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDeleteWebhook.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// DeleteWebhook implements repository.Repository
+func (mmDeleteWebhook *RepositoryMock) DeleteWebhook(ctx context.Context, id int) (err error) {
+	mm_atomic.AddUint64(&mmDeleteWebhook.beforeDeleteWebhookCounter, 1)
+	defer mm_atomic.AddUint64(&mmDeleteWebhook.afterDeleteWebhookCounter, 1)
+
+	if mmDeleteWebhook.inspectFuncDeleteWebhook != nil {
+		mmDeleteWebhook.inspectFuncDeleteWebhook(ctx, id)
+	}
+
+	mm_params := RepositoryMockDeleteWebhookParams{ctx, id}
+
+	// Record call args
+	mmDeleteWebhook.DeleteWebhookMock.mutex.Lock()
+	mmDeleteWebhook.DeleteWebhookMock.callArgs = append(mmDeleteWebhook.DeleteWebhookMock.callArgs, &mm_params)
+	mmDeleteWebhook.DeleteWebhookMock.mutex.Unlock()
+
+	for _, e := range mmDeleteWebhook.DeleteWebhookMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmDeleteWebhook.DeleteWebhookMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDeleteWebhook.DeleteWebhookMock.defaultExpectation.Counter, 1)
+		mm_want := mmDeleteWebhook.DeleteWebhookMock.defaultExpectation.params
+		mm_want_ptrs := mmDeleteWebhook.DeleteWebhookMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockDeleteWebhookParams{ctx, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmDeleteWebhook.t.Errorf("RepositoryMock.DeleteWebhook got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmDeleteWebhook.t.Errorf("RepositoryMock.DeleteWebhook got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDeleteWebhook.t.Errorf("RepositoryMock.DeleteWebhook got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDeleteWebhook.DeleteWebhookMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDeleteWebhook.t.Fatal("No results are set for the RepositoryMock.DeleteWebhook")
+		}
+		return (*mm_results).err
+	}
+	if mmDeleteWebhook.funcDeleteWebhook != nil {
+		return mmDeleteWebhook.funcDeleteWebhook(ctx, id)
+	}
+	mmDeleteWebhook.t.Fatalf("Unexpected call to RepositoryMock.DeleteWebhook. %v %v", ctx, id)
+	return
+}
+
+// DeleteWebhookAfterCounter returns a count of finished RepositoryMock.DeleteWebhook invocations
+func (mmDeleteWebhook *RepositoryMock) DeleteWebhookAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteWebhook.afterDeleteWebhookCounter)
+}
+
+// DeleteWebhookBeforeCounter returns a count of RepositoryMock.DeleteWebhook invocations
+func (mmDeleteWebhook *RepositoryMock) DeleteWebhookBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDeleteWebhook.beforeDeleteWebhookCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.DeleteWebhook.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDeleteWebhook *mRepositoryMockDeleteWebhook) Calls() []*RepositoryMockDeleteWebhookParams {
+	mmDeleteWebhook.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockDeleteWebhookParams, len(mmDeleteWebhook.callArgs))
+	copy(argCopy, mmDeleteWebhook.callArgs)
+
+	mmDeleteWebhook.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDeleteWebhookDone returns true if the count of the DeleteWebhook invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockDeleteWebhookDone() bool {
+	for _, e := range m.DeleteWebhookMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.DeleteWebhookMock.invocationsDone()
+}
+
+// MinimockDeleteWebhookInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockDeleteWebhookInspect() {
+	for _, e := range m.DeleteWebhookMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.DeleteWebhook with params: %#v", *e.params)
+		}
+	}
+
+	afterDeleteWebhookCounter := mm_atomic.LoadUint64(&m.afterDeleteWebhookCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DeleteWebhookMock.defaultExpectation != nil && afterDeleteWebhookCounter < 1 {
+		if m.DeleteWebhookMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.DeleteWebhook")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.DeleteWebhook with params: %#v", *m.DeleteWebhookMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDeleteWebhook != nil && afterDeleteWebhookCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.DeleteWebhook")
+	}
+
+	if !m.DeleteWebhookMock.invocationsDone() && afterDeleteWebhookCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.DeleteWebhook but found %d calls",
+			mm_atomic.LoadUint64(&m.DeleteWebhookMock.expectedInvocations), afterDeleteWebhookCounter)
+	}
+}
+
+type mRepositoryMockDisableComments struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockDisableCommentsExpectation
+	expectations       []*RepositoryMockDisableCommentsExpectation
+
+	callArgs []*RepositoryMockDisableCommentsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockDisableCommentsExpectation specifies expectation struct of the Repository.DisableComments
+type RepositoryMockDisableCommentsExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockDisableCommentsParams
+	paramPtrs *RepositoryMockDisableCommentsParamPtrs
+	results   *RepositoryMockDisableCommentsResults
+	Counter   uint64
+}
+
+// RepositoryMockDisableCommentsParams contains parameters of the Repository.DisableComments
+type RepositoryMockDisableCommentsParams struct {
+	ctx    context.Context
+	postID int
+}
+
+// RepositoryMockDisableCommentsParamPtrs contains pointers to parameters of the Repository.DisableComments
+type RepositoryMockDisableCommentsParamPtrs struct {
+	ctx    *context.Context
+	postID *int
+}
+
+// RepositoryMockDisableCommentsResults contains results of the Repository.DisableComments
+type RepositoryMockDisableCommentsResults struct {
+	err error
+}
+
+// Expect sets up expected params for Repository.DisableComments
+func (mmDisableComments *mRepositoryMockDisableComments) Expect(ctx context.Context, postID int) *mRepositoryMockDisableComments {
+	if mmDisableComments.mock.funcDisableComments != nil {
+		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Set")
+	}
+
+	if mmDisableComments.defaultExpectation == nil {
+		mmDisableComments.defaultExpectation = &RepositoryMockDisableCommentsExpectation{}
+	}
+
+	if mmDisableComments.defaultExpectation.paramPtrs != nil {
+		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by ExpectParams functions")
+	}
+
+	mmDisableComments.defaultExpectation.params = &RepositoryMockDisableCommentsParams{ctx, postID}
+	for _, e := range mmDisableComments.expectations {
+		if minimock.Equal(e.params, mmDisableComments.defaultExpectation.params) {
+			mmDisableComments.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmDisableComments.defaultExpectation.params)
+		}
+	}
+
+	return mmDisableComments
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.DisableComments
+func (mmDisableComments *mRepositoryMockDisableComments) ExpectCtxParam1(ctx context.Context) *mRepositoryMockDisableComments {
+	if mmDisableComments.mock.funcDisableComments != nil {
+		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Set")
+	}
+
+	if mmDisableComments.defaultExpectation == nil {
+		mmDisableComments.defaultExpectation = &RepositoryMockDisableCommentsExpectation{}
+	}
+
+	if mmDisableComments.defaultExpectation.params != nil {
+		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Expect")
+	}
+
+	if mmDisableComments.defaultExpectation.paramPtrs == nil {
+		mmDisableComments.defaultExpectation.paramPtrs = &RepositoryMockDisableCommentsParamPtrs{}
+	}
+	mmDisableComments.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmDisableComments
+}
+
+// ExpectPostIDParam2 sets up expected param postID for Repository.DisableComments
+func (mmDisableComments *mRepositoryMockDisableComments) ExpectPostIDParam2(postID int) *mRepositoryMockDisableComments {
+	if mmDisableComments.mock.funcDisableComments != nil {
+		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Set")
+	}
+
+	if mmDisableComments.defaultExpectation == nil {
+		mmDisableComments.defaultExpectation = &RepositoryMockDisableCommentsExpectation{}
+	}
+
+	if mmDisableComments.defaultExpectation.params != nil {
+		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Expect")
+	}
+
+	if mmDisableComments.defaultExpectation.paramPtrs == nil {
+		mmDisableComments.defaultExpectation.paramPtrs = &RepositoryMockDisableCommentsParamPtrs{}
+	}
+	mmDisableComments.defaultExpectation.paramPtrs.postID = &postID
+
+	return mmDisableComments
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.DisableComments
+func (mmDisableComments *mRepositoryMockDisableComments) Inspect(f func(ctx context.Context, postID int)) *mRepositoryMockDisableComments {
+	if mmDisableComments.mock.inspectFuncDisableComments != nil {
+		mmDisableComments.mock.t.Fatalf("Inspect function is already set for RepositoryMock.DisableComments")
+	}
+
+	mmDisableComments.mock.inspectFuncDisableComments = f
+
+	return mmDisableComments
+}
+
+// Return sets up results that will be returned by Repository.DisableComments
+func (mmDisableComments *mRepositoryMockDisableComments) Return(err error) *RepositoryMock {
+	if mmDisableComments.mock.funcDisableComments != nil {
+		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Set")
+	}
+
+	if mmDisableComments.defaultExpectation == nil {
+		mmDisableComments.defaultExpectation = &RepositoryMockDisableCommentsExpectation{mock: mmDisableComments.mock}
+	}
+	mmDisableComments.defaultExpectation.results = &RepositoryMockDisableCommentsResults{err}
+	return mmDisableComments.mock
+}
+
+// Set uses given function f to mock the Repository.DisableComments method
+func (mmDisableComments *mRepositoryMockDisableComments) Set(f func(ctx context.Context, postID int) (err error)) *RepositoryMock {
+	if mmDisableComments.defaultExpectation != nil {
+		mmDisableComments.mock.t.Fatalf("Default expectation is already set for the Repository.DisableComments method")
+	}
+
+	if len(mmDisableComments.expectations) > 0 {
+		mmDisableComments.mock.t.Fatalf("Some expectations are already set for the Repository.DisableComments method")
+	}
+
+	mmDisableComments.mock.funcDisableComments = f
+	return mmDisableComments.mock
+}
+
+// When sets expectation for the Repository.DisableComments which will trigger the result defined by the following
+// Then helper
+func (mmDisableComments *mRepositoryMockDisableComments) When(ctx context.Context, postID int) *RepositoryMockDisableCommentsExpectation {
+	if mmDisableComments.mock.funcDisableComments != nil {
+		mmDisableComments.mock.t.Fatalf("RepositoryMock.DisableComments mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockDisableCommentsExpectation{
+		mock:   mmDisableComments.mock,
 		params: &RepositoryMockDisableCommentsParams{ctx, postID},
 	}
-	mmDisableComments.expectations = append(mmDisableComments.expectations, expectation)
+	mmDisableComments.expectations = append(mmDisableComments.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.DisableComments return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockDisableCommentsExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockDisableCommentsResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.DisableComments should be invoked
+func (mmDisableComments *mRepositoryMockDisableComments) Times(n uint64) *mRepositoryMockDisableComments {
+	if n == 0 {
+		mmDisableComments.mock.t.Fatalf("Times of RepositoryMock.DisableComments mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmDisableComments.expectedInvocations, n)
+	return mmDisableComments
+}
+
+func (mmDisableComments *mRepositoryMockDisableComments) invocationsDone() bool {
+	if len(mmDisableComments.expectations) == 0 && mmDisableComments.defaultExpectation == nil && mmDisableComments.mock.funcDisableComments == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmDisableComments.mock.afterDisableCommentsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmDisableComments.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// DisableComments implements repository.Repository
+func (mmDisableComments *RepositoryMock) DisableComments(ctx context.Context, postID int) (err error) {
+	mm_atomic.AddUint64(&mmDisableComments.beforeDisableCommentsCounter, 1)
+	defer mm_atomic.AddUint64(&mmDisableComments.afterDisableCommentsCounter, 1)
+
+	if mmDisableComments.inspectFuncDisableComments != nil {
+		mmDisableComments.inspectFuncDisableComments(ctx, postID)
+	}
+
+	mm_params := RepositoryMockDisableCommentsParams{ctx, postID}
+
+	// Record call args
+	mmDisableComments.DisableCommentsMock.mutex.Lock()
+	mmDisableComments.DisableCommentsMock.callArgs = append(mmDisableComments.DisableCommentsMock.callArgs, &mm_params)
+	mmDisableComments.DisableCommentsMock.mutex.Unlock()
+
+	for _, e := range mmDisableComments.DisableCommentsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmDisableComments.DisableCommentsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmDisableComments.DisableCommentsMock.defaultExpectation.Counter, 1)
+		mm_want := mmDisableComments.DisableCommentsMock.defaultExpectation.params
+		mm_want_ptrs := mmDisableComments.DisableCommentsMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockDisableCommentsParams{ctx, postID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmDisableComments.t.Errorf("RepositoryMock.DisableComments got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.postID != nil && !minimock.Equal(*mm_want_ptrs.postID, mm_got.postID) {
+				mmDisableComments.t.Errorf("RepositoryMock.DisableComments got unexpected parameter postID, want: %#v, got: %#v%s\n", *mm_want_ptrs.postID, mm_got.postID, minimock.Diff(*mm_want_ptrs.postID, mm_got.postID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmDisableComments.t.Errorf("RepositoryMock.DisableComments got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmDisableComments.DisableCommentsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmDisableComments.t.Fatal("No results are set for the RepositoryMock.DisableComments")
+		}
+		return (*mm_results).err
+	}
+	if mmDisableComments.funcDisableComments != nil {
+		return mmDisableComments.funcDisableComments(ctx, postID)
+	}
+	mmDisableComments.t.Fatalf("Unexpected call to RepositoryMock.DisableComments. %v %v", ctx, postID)
+	return
+}
+
+// DisableCommentsAfterCounter returns a count of finished RepositoryMock.DisableComments invocations
+func (mmDisableComments *RepositoryMock) DisableCommentsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDisableComments.afterDisableCommentsCounter)
+}
+
+// DisableCommentsBeforeCounter returns a count of RepositoryMock.DisableComments invocations
+func (mmDisableComments *RepositoryMock) DisableCommentsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmDisableComments.beforeDisableCommentsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.DisableComments.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmDisableComments *mRepositoryMockDisableComments) Calls() []*RepositoryMockDisableCommentsParams {
+	mmDisableComments.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockDisableCommentsParams, len(mmDisableComments.callArgs))
+	copy(argCopy, mmDisableComments.callArgs)
+
+	mmDisableComments.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockDisableCommentsDone returns true if the count of the DisableComments invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockDisableCommentsDone() bool {
+	for _, e := range m.DisableCommentsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.DisableCommentsMock.invocationsDone()
+}
+
+// MinimockDisableCommentsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockDisableCommentsInspect() {
+	for _, e := range m.DisableCommentsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.DisableComments with params: %#v", *e.params)
+		}
+	}
+
+	afterDisableCommentsCounter := mm_atomic.LoadUint64(&m.afterDisableCommentsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.DisableCommentsMock.defaultExpectation != nil && afterDisableCommentsCounter < 1 {
+		if m.DisableCommentsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.DisableComments")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.DisableComments with params: %#v", *m.DisableCommentsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcDisableComments != nil && afterDisableCommentsCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.DisableComments")
+	}
+
+	if !m.DisableCommentsMock.invocationsDone() && afterDisableCommentsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.DisableComments but found %d calls",
+			mm_atomic.LoadUint64(&m.DisableCommentsMock.expectedInvocations), afterDisableCommentsCounter)
+	}
+}
+
+type mRepositoryMockEnableComments struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockEnableCommentsExpectation
+	expectations       []*RepositoryMockEnableCommentsExpectation
+
+	callArgs []*RepositoryMockEnableCommentsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockEnableCommentsExpectation specifies expectation struct of the Repository.EnableComments
+type RepositoryMockEnableCommentsExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockEnableCommentsParams
+	paramPtrs *RepositoryMockEnableCommentsParamPtrs
+	results   *RepositoryMockEnableCommentsResults
+	Counter   uint64
+}
+
+// RepositoryMockEnableCommentsParams contains parameters of the Repository.EnableComments
+type RepositoryMockEnableCommentsParams struct {
+	ctx    context.Context
+	postID int
+}
+
+// RepositoryMockEnableCommentsParamPtrs contains pointers to parameters of the Repository.EnableComments
+type RepositoryMockEnableCommentsParamPtrs struct {
+	ctx    *context.Context
+	postID *int
+}
+
+// RepositoryMockEnableCommentsResults contains results of the Repository.EnableComments
+type RepositoryMockEnableCommentsResults struct {
+	err error
+}
+
+// Expect sets up expected params for Repository.EnableComments
+func (mmEnableComments *mRepositoryMockEnableComments) Expect(ctx context.Context, postID int) *mRepositoryMockEnableComments {
+	if mmEnableComments.mock.funcEnableComments != nil {
+		mmEnableComments.mock.t.Fatalf("RepositoryMock.EnableComments mock is already set by Set")
+	}
+
+	if mmEnableComments.defaultExpectation == nil {
+		mmEnableComments.defaultExpectation = &RepositoryMockEnableCommentsExpectation{}
+	}
+
+	if mmEnableComments.defaultExpectation.paramPtrs != nil {
+		mmEnableComments.mock.t.Fatalf("RepositoryMock.EnableComments mock is already set by ExpectParams functions")
+	}
+
+	mmEnableComments.defaultExpectation.params = &RepositoryMockEnableCommentsParams{ctx, postID}
+	for _, e := range mmEnableComments.expectations {
+		if minimock.Equal(e.params, mmEnableComments.defaultExpectation.params) {
+			mmEnableComments.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmEnableComments.defaultExpectation.params)
+		}
+	}
+
+	return mmEnableComments
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.EnableComments
+func (mmEnableComments *mRepositoryMockEnableComments) ExpectCtxParam1(ctx context.Context) *mRepositoryMockEnableComments {
+	if mmEnableComments.mock.funcEnableComments != nil {
+		mmEnableComments.mock.t.Fatalf("RepositoryMock.EnableComments mock is already set by Set")
+	}
+
+	if mmEnableComments.defaultExpectation == nil {
+		mmEnableComments.defaultExpectation = &RepositoryMockEnableCommentsExpectation{}
+	}
+
+	if mmEnableComments.defaultExpectation.params != nil {
+		mmEnableComments.mock.t.Fatalf("RepositoryMock.EnableComments mock is already set by Expect")
+	}
+
+	if mmEnableComments.defaultExpectation.paramPtrs == nil {
+		mmEnableComments.defaultExpectation.paramPtrs = &RepositoryMockEnableCommentsParamPtrs{}
+	}
+	mmEnableComments.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmEnableComments
+}
+
+// ExpectPostIDParam2 sets up expected param postID for Repository.EnableComments
+func (mmEnableComments *mRepositoryMockEnableComments) ExpectPostIDParam2(postID int) *mRepositoryMockEnableComments {
+	if mmEnableComments.mock.funcEnableComments != nil {
+		mmEnableComments.mock.t.Fatalf("RepositoryMock.EnableComments mock is already set by Set")
+	}
+
+	if mmEnableComments.defaultExpectation == nil {
+		mmEnableComments.defaultExpectation = &RepositoryMockEnableCommentsExpectation{}
+	}
+
+	if mmEnableComments.defaultExpectation.params != nil {
+		mmEnableComments.mock.t.Fatalf("RepositoryMock.EnableComments mock is already set by Expect")
+	}
+
+	if mmEnableComments.defaultExpectation.paramPtrs == nil {
+		mmEnableComments.defaultExpectation.paramPtrs = &RepositoryMockEnableCommentsParamPtrs{}
+	}
+	mmEnableComments.defaultExpectation.paramPtrs.postID = &postID
+
+	return mmEnableComments
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.EnableComments
+func (mmEnableComments *mRepositoryMockEnableComments) Inspect(f func(ctx context.Context, postID int)) *mRepositoryMockEnableComments {
+	if mmEnableComments.mock.inspectFuncEnableComments != nil {
+		mmEnableComments.mock.t.Fatalf("Inspect function is already set for RepositoryMock.EnableComments")
+	}
+
+	mmEnableComments.mock.inspectFuncEnableComments = f
+
+	return mmEnableComments
+}
+
+// Return sets up results that will be returned by Repository.EnableComments
+func (mmEnableComments *mRepositoryMockEnableComments) Return(err error) *RepositoryMock {
+	if mmEnableComments.mock.funcEnableComments != nil {
+		mmEnableComments.mock.t.Fatalf("RepositoryMock.EnableComments mock is already set by Set")
+	}
+
+	if mmEnableComments.defaultExpectation == nil {
+		mmEnableComments.defaultExpectation = &RepositoryMockEnableCommentsExpectation{mock: mmEnableComments.mock}
+	}
+	mmEnableComments.defaultExpectation.results = &RepositoryMockEnableCommentsResults{err}
+	return mmEnableComments.mock
+}
+
+// Set uses given function f to mock the Repository.EnableComments method
+func (mmEnableComments *mRepositoryMockEnableComments) Set(f func(ctx context.Context, postID int) (err error)) *RepositoryMock {
+	if mmEnableComments.defaultExpectation != nil {
+		mmEnableComments.mock.t.Fatalf("Default expectation is already set for the Repository.EnableComments method")
+	}
+
+	if len(mmEnableComments.expectations) > 0 {
+		mmEnableComments.mock.t.Fatalf("Some expectations are already set for the Repository.EnableComments method")
+	}
+
+	mmEnableComments.mock.funcEnableComments = f
+	return mmEnableComments.mock
+}
+
+// When sets expectation for the Repository.EnableComments which will trigger the result defined by the following
+// Then helper
+func (mmEnableComments *mRepositoryMockEnableComments) When(ctx context.Context, postID int) *RepositoryMockEnableCommentsExpectation {
+	if mmEnableComments.mock.funcEnableComments != nil {
+		mmEnableComments.mock.t.Fatalf("RepositoryMock.EnableComments mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockEnableCommentsExpectation{
+		mock:   mmEnableComments.mock,
+		params: &RepositoryMockEnableCommentsParams{ctx, postID},
+	}
+	mmEnableComments.expectations = append(mmEnableComments.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.EnableComments return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockEnableCommentsExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockEnableCommentsResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.EnableComments should be invoked
+func (mmEnableComments *mRepositoryMockEnableComments) Times(n uint64) *mRepositoryMockEnableComments {
+	if n == 0 {
+		mmEnableComments.mock.t.Fatalf("Times of RepositoryMock.EnableComments mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmEnableComments.expectedInvocations, n)
+	return mmEnableComments
+}
+
+func (mmEnableComments *mRepositoryMockEnableComments) invocationsDone() bool {
+	if len(mmEnableComments.expectations) == 0 && mmEnableComments.defaultExpectation == nil && mmEnableComments.mock.funcEnableComments == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmEnableComments.mock.afterEnableCommentsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmEnableComments.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// EnableComments implements repository.Repository
+func (mmEnableComments *RepositoryMock) EnableComments(ctx context.Context, postID int) (err error) {
+	mm_atomic.AddUint64(&mmEnableComments.beforeEnableCommentsCounter, 1)
+	defer mm_atomic.AddUint64(&mmEnableComments.afterEnableCommentsCounter, 1)
+
+	if mmEnableComments.inspectFuncEnableComments != nil {
+		mmEnableComments.inspectFuncEnableComments(ctx, postID)
+	}
+
+	mm_params := RepositoryMockEnableCommentsParams{ctx, postID}
+
+	// Record call args
+	mmEnableComments.EnableCommentsMock.mutex.Lock()
+	mmEnableComments.EnableCommentsMock.callArgs = append(mmEnableComments.EnableCommentsMock.callArgs, &mm_params)
+	mmEnableComments.EnableCommentsMock.mutex.Unlock()
+
+	for _, e := range mmEnableComments.EnableCommentsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmEnableComments.EnableCommentsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmEnableComments.EnableCommentsMock.defaultExpectation.Counter, 1)
+		mm_want := mmEnableComments.EnableCommentsMock.defaultExpectation.params
+		mm_want_ptrs := mmEnableComments.EnableCommentsMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockEnableCommentsParams{ctx, postID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmEnableComments.t.Errorf("RepositoryMock.EnableComments got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.postID != nil && !minimock.Equal(*mm_want_ptrs.postID, mm_got.postID) {
+				mmEnableComments.t.Errorf("RepositoryMock.EnableComments got unexpected parameter postID, want: %#v, got: %#v%s\n", *mm_want_ptrs.postID, mm_got.postID, minimock.Diff(*mm_want_ptrs.postID, mm_got.postID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmEnableComments.t.Errorf("RepositoryMock.EnableComments got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmEnableComments.EnableCommentsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmEnableComments.t.Fatal("No results are set for the RepositoryMock.EnableComments")
+		}
+		return (*mm_results).err
+	}
+	if mmEnableComments.funcEnableComments != nil {
+		return mmEnableComments.funcEnableComments(ctx, postID)
+	}
+	mmEnableComments.t.Fatalf("Unexpected call to RepositoryMock.EnableComments. %v %v", ctx, postID)
+	return
+}
+
+// EnableCommentsAfterCounter returns a count of finished RepositoryMock.EnableComments invocations
+func (mmEnableComments *RepositoryMock) EnableCommentsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmEnableComments.afterEnableCommentsCounter)
+}
+
+// EnableCommentsBeforeCounter returns a count of RepositoryMock.EnableComments invocations
+func (mmEnableComments *RepositoryMock) EnableCommentsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmEnableComments.beforeEnableCommentsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.EnableComments.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmEnableComments *mRepositoryMockEnableComments) Calls() []*RepositoryMockEnableCommentsParams {
+	mmEnableComments.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockEnableCommentsParams, len(mmEnableComments.callArgs))
+	copy(argCopy, mmEnableComments.callArgs)
+
+	mmEnableComments.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockEnableCommentsDone returns true if the count of the EnableComments invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockEnableCommentsDone() bool {
+	for _, e := range m.EnableCommentsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.EnableCommentsMock.invocationsDone()
+}
+
+// MinimockEnableCommentsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockEnableCommentsInspect() {
+	for _, e := range m.EnableCommentsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.EnableComments with params: %#v", *e.params)
+		}
+	}
+
+	afterEnableCommentsCounter := mm_atomic.LoadUint64(&m.afterEnableCommentsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.EnableCommentsMock.defaultExpectation != nil && afterEnableCommentsCounter < 1 {
+		if m.EnableCommentsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.EnableComments")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.EnableComments with params: %#v", *m.EnableCommentsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcEnableComments != nil && afterEnableCommentsCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.EnableComments")
+	}
+
+	if !m.EnableCommentsMock.invocationsDone() && afterEnableCommentsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.EnableComments but found %d calls",
+			mm_atomic.LoadUint64(&m.EnableCommentsMock.expectedInvocations), afterEnableCommentsCounter)
+	}
+}
+
+type mRepositoryMockGetComment struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetCommentExpectation
+	expectations       []*RepositoryMockGetCommentExpectation
+
+	callArgs []*RepositoryMockGetCommentParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetCommentExpectation specifies expectation struct of the Repository.GetComment
+type RepositoryMockGetCommentExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetCommentParams
+	paramPtrs *RepositoryMockGetCommentParamPtrs
+	results   *RepositoryMockGetCommentResults
+	Counter   uint64
+}
+
+// RepositoryMockGetCommentParams contains parameters of the Repository.GetComment
+type RepositoryMockGetCommentParams struct {
+	ctx context.Context
+	id  int
+}
+
+// RepositoryMockGetCommentParamPtrs contains pointers to parameters of the Repository.GetComment
+type RepositoryMockGetCommentParamPtrs struct {
+	ctx *context.Context
+	id  *int
+}
+
+// RepositoryMockGetCommentResults contains results of the Repository.GetComment
+type RepositoryMockGetCommentResults struct {
+	cp1 *domain.Comment
+	err error
+}
+
+// Expect sets up expected params for Repository.GetComment
+func (mmGetComment *mRepositoryMockGetComment) Expect(ctx context.Context, id int) *mRepositoryMockGetComment {
+	if mmGetComment.mock.funcGetComment != nil {
+		mmGetComment.mock.t.Fatalf("RepositoryMock.GetComment mock is already set by Set")
+	}
+
+	if mmGetComment.defaultExpectation == nil {
+		mmGetComment.defaultExpectation = &RepositoryMockGetCommentExpectation{}
+	}
+
+	if mmGetComment.defaultExpectation.paramPtrs != nil {
+		mmGetComment.mock.t.Fatalf("RepositoryMock.GetComment mock is already set by ExpectParams functions")
+	}
+
+	mmGetComment.defaultExpectation.params = &RepositoryMockGetCommentParams{ctx, id}
+	for _, e := range mmGetComment.expectations {
+		if minimock.Equal(e.params, mmGetComment.defaultExpectation.params) {
+			mmGetComment.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetComment.defaultExpectation.params)
+		}
+	}
+
+	return mmGetComment
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetComment
+func (mmGetComment *mRepositoryMockGetComment) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetComment {
+	if mmGetComment.mock.funcGetComment != nil {
+		mmGetComment.mock.t.Fatalf("RepositoryMock.GetComment mock is already set by Set")
+	}
+
+	if mmGetComment.defaultExpectation == nil {
+		mmGetComment.defaultExpectation = &RepositoryMockGetCommentExpectation{}
+	}
+
+	if mmGetComment.defaultExpectation.params != nil {
+		mmGetComment.mock.t.Fatalf("RepositoryMock.GetComment mock is already set by Expect")
+	}
+
+	if mmGetComment.defaultExpectation.paramPtrs == nil {
+		mmGetComment.defaultExpectation.paramPtrs = &RepositoryMockGetCommentParamPtrs{}
+	}
+	mmGetComment.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetComment
+}
+
+// ExpectIdParam2 sets up expected param id for Repository.GetComment
+func (mmGetComment *mRepositoryMockGetComment) ExpectIdParam2(id int) *mRepositoryMockGetComment {
+	if mmGetComment.mock.funcGetComment != nil {
+		mmGetComment.mock.t.Fatalf("RepositoryMock.GetComment mock is already set by Set")
+	}
+
+	if mmGetComment.defaultExpectation == nil {
+		mmGetComment.defaultExpectation = &RepositoryMockGetCommentExpectation{}
+	}
+
+	if mmGetComment.defaultExpectation.params != nil {
+		mmGetComment.mock.t.Fatalf("RepositoryMock.GetComment mock is already set by Expect")
+	}
+
+	if mmGetComment.defaultExpectation.paramPtrs == nil {
+		mmGetComment.defaultExpectation.paramPtrs = &RepositoryMockGetCommentParamPtrs{}
+	}
+	mmGetComment.defaultExpectation.paramPtrs.id = &id
+
+	return mmGetComment
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetComment
+func (mmGetComment *mRepositoryMockGetComment) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockGetComment {
+	if mmGetComment.mock.inspectFuncGetComment != nil {
+		mmGetComment.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetComment")
+	}
+
+	mmGetComment.mock.inspectFuncGetComment = f
+
+	return mmGetComment
+}
+
+// Return sets up results that will be returned by Repository.GetComment
+func (mmGetComment *mRepositoryMockGetComment) Return(cp1 *domain.Comment, err error) *RepositoryMock {
+	if mmGetComment.mock.funcGetComment != nil {
+		mmGetComment.mock.t.Fatalf("RepositoryMock.GetComment mock is already set by Set")
+	}
+
+	if mmGetComment.defaultExpectation == nil {
+		mmGetComment.defaultExpectation = &RepositoryMockGetCommentExpectation{mock: mmGetComment.mock}
+	}
+	mmGetComment.defaultExpectation.results = &RepositoryMockGetCommentResults{cp1, err}
+	return mmGetComment.mock
+}
+
+// Set uses given function f to mock the Repository.GetComment method
+func (mmGetComment *mRepositoryMockGetComment) Set(f func(ctx context.Context, id int) (cp1 *domain.Comment, err error)) *RepositoryMock {
+	if mmGetComment.defaultExpectation != nil {
+		mmGetComment.mock.t.Fatalf("Default expectation is already set for the Repository.GetComment method")
+	}
+
+	if len(mmGetComment.expectations) > 0 {
+		mmGetComment.mock.t.Fatalf("Some expectations are already set for the Repository.GetComment method")
+	}
+
+	mmGetComment.mock.funcGetComment = f
+	return mmGetComment.mock
+}
+
+// When sets expectation for the Repository.GetComment which will trigger the result defined by the following
+// Then helper
+func (mmGetComment *mRepositoryMockGetComment) When(ctx context.Context, id int) *RepositoryMockGetCommentExpectation {
+	if mmGetComment.mock.funcGetComment != nil {
+		mmGetComment.mock.t.Fatalf("RepositoryMock.GetComment mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetCommentExpectation{
+		mock:   mmGetComment.mock,
+		params: &RepositoryMockGetCommentParams{ctx, id},
+	}
+	mmGetComment.expectations = append(mmGetComment.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetComment return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetCommentExpectation) Then(cp1 *domain.Comment, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetCommentResults{cp1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetComment should be invoked
+func (mmGetComment *mRepositoryMockGetComment) Times(n uint64) *mRepositoryMockGetComment {
+	if n == 0 {
+		mmGetComment.mock.t.Fatalf("Times of RepositoryMock.GetComment mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetComment.expectedInvocations, n)
+	return mmGetComment
+}
+
+func (mmGetComment *mRepositoryMockGetComment) invocationsDone() bool {
+	if len(mmGetComment.expectations) == 0 && mmGetComment.defaultExpectation == nil && mmGetComment.mock.funcGetComment == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetComment.mock.afterGetCommentCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetComment.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetComment implements repository.Repository
+func (mmGetComment *RepositoryMock) GetComment(ctx context.Context, id int) (cp1 *domain.Comment, err error) {
+	mm_atomic.AddUint64(&mmGetComment.beforeGetCommentCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetComment.afterGetCommentCounter, 1)
+
+	if mmGetComment.inspectFuncGetComment != nil {
+		mmGetComment.inspectFuncGetComment(ctx, id)
+	}
+
+	mm_params := RepositoryMockGetCommentParams{ctx, id}
+
+	// Record call args
+	mmGetComment.GetCommentMock.mutex.Lock()
+	mmGetComment.GetCommentMock.callArgs = append(mmGetComment.GetCommentMock.callArgs, &mm_params)
+	mmGetComment.GetCommentMock.mutex.Unlock()
+
+	for _, e := range mmGetComment.GetCommentMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.cp1, e.results.err
+		}
+	}
+
+	if mmGetComment.GetCommentMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetComment.GetCommentMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetComment.GetCommentMock.defaultExpectation.params
+		mm_want_ptrs := mmGetComment.GetCommentMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetCommentParams{ctx, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetComment.t.Errorf("RepositoryMock.GetComment got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmGetComment.t.Errorf("RepositoryMock.GetComment got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetComment.t.Errorf("RepositoryMock.GetComment got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetComment.GetCommentMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetComment.t.Fatal("No results are set for the RepositoryMock.GetComment")
+		}
+		return (*mm_results).cp1, (*mm_results).err
+	}
+	if mmGetComment.funcGetComment != nil {
+		return mmGetComment.funcGetComment(ctx, id)
+	}
+	mmGetComment.t.Fatalf("Unexpected call to RepositoryMock.GetComment. %v %v", ctx, id)
+	return
+}
+
+// GetCommentAfterCounter returns a count of finished RepositoryMock.GetComment invocations
+func (mmGetComment *RepositoryMock) GetCommentAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetComment.afterGetCommentCounter)
+}
+
+// GetCommentBeforeCounter returns a count of RepositoryMock.GetComment invocations
+func (mmGetComment *RepositoryMock) GetCommentBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetComment.beforeGetCommentCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetComment.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetComment *mRepositoryMockGetComment) Calls() []*RepositoryMockGetCommentParams {
+	mmGetComment.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetCommentParams, len(mmGetComment.callArgs))
+	copy(argCopy, mmGetComment.callArgs)
+
+	mmGetComment.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetCommentDone returns true if the count of the GetComment invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetCommentDone() bool {
+	for _, e := range m.GetCommentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetCommentMock.invocationsDone()
+}
+
+// MinimockGetCommentInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetCommentInspect() {
+	for _, e := range m.GetCommentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetComment with params: %#v", *e.params)
+		}
+	}
+
+	afterGetCommentCounter := mm_atomic.LoadUint64(&m.afterGetCommentCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetCommentMock.defaultExpectation != nil && afterGetCommentCounter < 1 {
+		if m.GetCommentMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetComment")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetComment with params: %#v", *m.GetCommentMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetComment != nil && afterGetCommentCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetComment")
+	}
+
+	if !m.GetCommentMock.invocationsDone() && afterGetCommentCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetComment but found %d calls",
+			mm_atomic.LoadUint64(&m.GetCommentMock.expectedInvocations), afterGetCommentCounter)
+	}
+}
+
+type mRepositoryMockGetCommentsByParent struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetCommentsByParentExpectation
+	expectations       []*RepositoryMockGetCommentsByParentExpectation
+
+	callArgs []*RepositoryMockGetCommentsByParentParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetCommentsByParentExpectation specifies expectation struct of the Repository.GetCommentsByParent
+type RepositoryMockGetCommentsByParentExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetCommentsByParentParams
+	paramPtrs *RepositoryMockGetCommentsByParentParamPtrs
+	results   *RepositoryMockGetCommentsByParentResults
+	Counter   uint64
+}
+
+// RepositoryMockGetCommentsByParentParams contains parameters of the Repository.GetCommentsByParent
+type RepositoryMockGetCommentsByParentParams struct {
+	ctx           context.Context
+	parentId      int
+	limit         int
+	offset        int
+	includeHidden bool
+}
+
+// RepositoryMockGetCommentsByParentParamPtrs contains pointers to parameters of the Repository.GetCommentsByParent
+type RepositoryMockGetCommentsByParentParamPtrs struct {
+	ctx           *context.Context
+	parentId      *int
+	limit         *int
+	offset        *int
+	includeHidden *bool
+}
+
+// RepositoryMockGetCommentsByParentResults contains results of the Repository.GetCommentsByParent
+type RepositoryMockGetCommentsByParentResults struct {
+	cpa1 []*domain.Comment
+	err  error
+}
+
+// Expect sets up expected params for Repository.GetCommentsByParent
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Expect(ctx context.Context, parentId int, limit int, offset int, includeHidden bool) *mRepositoryMockGetCommentsByParent {
+	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation == nil {
+		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{}
+	}
+
+	if mmGetCommentsByParent.defaultExpectation.paramPtrs != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by ExpectParams functions")
+	}
+
+	mmGetCommentsByParent.defaultExpectation.params = &RepositoryMockGetCommentsByParentParams{ctx, parentId, limit, offset, includeHidden}
+	for _, e := range mmGetCommentsByParent.expectations {
+		if minimock.Equal(e.params, mmGetCommentsByParent.defaultExpectation.params) {
+			mmGetCommentsByParent.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetCommentsByParent.defaultExpectation.params)
+		}
+	}
+
+	return mmGetCommentsByParent
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetCommentsByParent
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetCommentsByParent {
+	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation == nil {
+		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{}
+	}
+
+	if mmGetCommentsByParent.defaultExpectation.params != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Expect")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation.paramPtrs == nil {
+		mmGetCommentsByParent.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByParentParamPtrs{}
+	}
+	mmGetCommentsByParent.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetCommentsByParent
+}
+
+// ExpectParentIdParam2 sets up expected param parentId for Repository.GetCommentsByParent
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) ExpectParentIdParam2(parentId int) *mRepositoryMockGetCommentsByParent {
+	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation == nil {
+		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{}
+	}
+
+	if mmGetCommentsByParent.defaultExpectation.params != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Expect")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation.paramPtrs == nil {
+		mmGetCommentsByParent.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByParentParamPtrs{}
+	}
+	mmGetCommentsByParent.defaultExpectation.paramPtrs.parentId = &parentId
+
+	return mmGetCommentsByParent
+}
+
+// ExpectLimitParam3 sets up expected param limit for Repository.GetCommentsByParent
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) ExpectLimitParam3(limit int) *mRepositoryMockGetCommentsByParent {
+	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation == nil {
+		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{}
+	}
+
+	if mmGetCommentsByParent.defaultExpectation.params != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Expect")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation.paramPtrs == nil {
+		mmGetCommentsByParent.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByParentParamPtrs{}
+	}
+	mmGetCommentsByParent.defaultExpectation.paramPtrs.limit = &limit
+
+	return mmGetCommentsByParent
+}
+
+// ExpectOffsetParam4 sets up expected param offset for Repository.GetCommentsByParent
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) ExpectOffsetParam4(offset int) *mRepositoryMockGetCommentsByParent {
+	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation == nil {
+		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{}
+	}
+
+	if mmGetCommentsByParent.defaultExpectation.params != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Expect")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation.paramPtrs == nil {
+		mmGetCommentsByParent.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByParentParamPtrs{}
+	}
+	mmGetCommentsByParent.defaultExpectation.paramPtrs.offset = &offset
+
+	return mmGetCommentsByParent
+}
+
+// ExpectIncludeHiddenParam5 sets up expected param includeHidden for Repository.GetCommentsByParent
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) ExpectIncludeHiddenParam5(includeHidden bool) *mRepositoryMockGetCommentsByParent {
+	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation == nil {
+		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{}
+	}
+
+	if mmGetCommentsByParent.defaultExpectation.params != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Expect")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation.paramPtrs == nil {
+		mmGetCommentsByParent.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByParentParamPtrs{}
+	}
+	mmGetCommentsByParent.defaultExpectation.paramPtrs.includeHidden = &includeHidden
+
+	return mmGetCommentsByParent
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetCommentsByParent
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Inspect(f func(ctx context.Context, parentId int, limit int, offset int, includeHidden bool)) *mRepositoryMockGetCommentsByParent {
+	if mmGetCommentsByParent.mock.inspectFuncGetCommentsByParent != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetCommentsByParent")
+	}
+
+	mmGetCommentsByParent.mock.inspectFuncGetCommentsByParent = f
+
+	return mmGetCommentsByParent
+}
+
+// Return sets up results that will be returned by Repository.GetCommentsByParent
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Return(cpa1 []*domain.Comment, err error) *RepositoryMock {
+	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+	}
+
+	if mmGetCommentsByParent.defaultExpectation == nil {
+		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{mock: mmGetCommentsByParent.mock}
+	}
+	mmGetCommentsByParent.defaultExpectation.results = &RepositoryMockGetCommentsByParentResults{cpa1, err}
+	return mmGetCommentsByParent.mock
+}
+
+// Set uses given function f to mock the Repository.GetCommentsByParent method
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Set(f func(ctx context.Context, parentId int, limit int, offset int, includeHidden bool) (cpa1 []*domain.Comment, err error)) *RepositoryMock {
+	if mmGetCommentsByParent.defaultExpectation != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("Default expectation is already set for the Repository.GetCommentsByParent method")
+	}
+
+	if len(mmGetCommentsByParent.expectations) > 0 {
+		mmGetCommentsByParent.mock.t.Fatalf("Some expectations are already set for the Repository.GetCommentsByParent method")
+	}
+
+	mmGetCommentsByParent.mock.funcGetCommentsByParent = f
+	return mmGetCommentsByParent.mock
+}
+
+// When sets expectation for the Repository.GetCommentsByParent which will trigger the result defined by the following
+// Then helper
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) When(ctx context.Context, parentId int, limit int, offset int, includeHidden bool) *RepositoryMockGetCommentsByParentExpectation {
+	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
+		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetCommentsByParentExpectation{
+		mock:   mmGetCommentsByParent.mock,
+		params: &RepositoryMockGetCommentsByParentParams{ctx, parentId, limit, offset, includeHidden},
+	}
+	mmGetCommentsByParent.expectations = append(mmGetCommentsByParent.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetCommentsByParent return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetCommentsByParentExpectation) Then(cpa1 []*domain.Comment, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetCommentsByParentResults{cpa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetCommentsByParent should be invoked
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Times(n uint64) *mRepositoryMockGetCommentsByParent {
+	if n == 0 {
+		mmGetCommentsByParent.mock.t.Fatalf("Times of RepositoryMock.GetCommentsByParent mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetCommentsByParent.expectedInvocations, n)
+	return mmGetCommentsByParent
+}
+
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) invocationsDone() bool {
+	if len(mmGetCommentsByParent.expectations) == 0 && mmGetCommentsByParent.defaultExpectation == nil && mmGetCommentsByParent.mock.funcGetCommentsByParent == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetCommentsByParent.mock.afterGetCommentsByParentCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetCommentsByParent.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetCommentsByParent implements repository.Repository
+func (mmGetCommentsByParent *RepositoryMock) GetCommentsByParent(ctx context.Context, parentId int, limit int, offset int, includeHidden bool) (cpa1 []*domain.Comment, err error) {
+	mm_atomic.AddUint64(&mmGetCommentsByParent.beforeGetCommentsByParentCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetCommentsByParent.afterGetCommentsByParentCounter, 1)
+
+	if mmGetCommentsByParent.inspectFuncGetCommentsByParent != nil {
+		mmGetCommentsByParent.inspectFuncGetCommentsByParent(ctx, parentId, limit, offset, includeHidden)
+	}
+
+	mm_params := RepositoryMockGetCommentsByParentParams{ctx, parentId, limit, offset, includeHidden}
+
+	// Record call args
+	mmGetCommentsByParent.GetCommentsByParentMock.mutex.Lock()
+	mmGetCommentsByParent.GetCommentsByParentMock.callArgs = append(mmGetCommentsByParent.GetCommentsByParentMock.callArgs, &mm_params)
+	mmGetCommentsByParent.GetCommentsByParentMock.mutex.Unlock()
+
+	for _, e := range mmGetCommentsByParent.GetCommentsByParentMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.cpa1, e.results.err
+		}
+	}
+
+	if mmGetCommentsByParent.GetCommentsByParentMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetCommentsByParent.GetCommentsByParentMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetCommentsByParent.GetCommentsByParentMock.defaultExpectation.params
+		mm_want_ptrs := mmGetCommentsByParent.GetCommentsByParentMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetCommentsByParentParams{ctx, parentId, limit, offset, includeHidden}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetCommentsByParent.t.Errorf("RepositoryMock.GetCommentsByParent got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.parentId != nil && !minimock.Equal(*mm_want_ptrs.parentId, mm_got.parentId) {
+				mmGetCommentsByParent.t.Errorf("RepositoryMock.GetCommentsByParent got unexpected parameter parentId, want: %#v, got: %#v%s\n", *mm_want_ptrs.parentId, mm_got.parentId, minimock.Diff(*mm_want_ptrs.parentId, mm_got.parentId))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmGetCommentsByParent.t.Errorf("RepositoryMock.GetCommentsByParent got unexpected parameter limit, want: %#v, got: %#v%s\n", *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+			if mm_want_ptrs.offset != nil && !minimock.Equal(*mm_want_ptrs.offset, mm_got.offset) {
+				mmGetCommentsByParent.t.Errorf("RepositoryMock.GetCommentsByParent got unexpected parameter offset, want: %#v, got: %#v%s\n", *mm_want_ptrs.offset, mm_got.offset, minimock.Diff(*mm_want_ptrs.offset, mm_got.offset))
+			}
+
+			if mm_want_ptrs.includeHidden != nil && !minimock.Equal(*mm_want_ptrs.includeHidden, mm_got.includeHidden) {
+				mmGetCommentsByParent.t.Errorf("RepositoryMock.GetCommentsByParent got unexpected parameter includeHidden, want: %#v, got: %#v%s\n", *mm_want_ptrs.includeHidden, mm_got.includeHidden, minimock.Diff(*mm_want_ptrs.includeHidden, mm_got.includeHidden))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetCommentsByParent.t.Errorf("RepositoryMock.GetCommentsByParent got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetCommentsByParent.GetCommentsByParentMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetCommentsByParent.t.Fatal("No results are set for the RepositoryMock.GetCommentsByParent")
+		}
+		return (*mm_results).cpa1, (*mm_results).err
+	}
+	if mmGetCommentsByParent.funcGetCommentsByParent != nil {
+		return mmGetCommentsByParent.funcGetCommentsByParent(ctx, parentId, limit, offset, includeHidden)
+	}
+	mmGetCommentsByParent.t.Fatalf("Unexpected call to RepositoryMock.GetCommentsByParent. %v %v %v %v %v", ctx, parentId, limit, offset, includeHidden)
+	return
+}
+
+// GetCommentsByParentAfterCounter returns a count of finished RepositoryMock.GetCommentsByParent invocations
+func (mmGetCommentsByParent *RepositoryMock) GetCommentsByParentAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetCommentsByParent.afterGetCommentsByParentCounter)
+}
+
+// GetCommentsByParentBeforeCounter returns a count of RepositoryMock.GetCommentsByParent invocations
+func (mmGetCommentsByParent *RepositoryMock) GetCommentsByParentBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetCommentsByParent.beforeGetCommentsByParentCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetCommentsByParent.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Calls() []*RepositoryMockGetCommentsByParentParams {
+	mmGetCommentsByParent.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetCommentsByParentParams, len(mmGetCommentsByParent.callArgs))
+	copy(argCopy, mmGetCommentsByParent.callArgs)
+
+	mmGetCommentsByParent.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetCommentsByParentDone returns true if the count of the GetCommentsByParent invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetCommentsByParentDone() bool {
+	for _, e := range m.GetCommentsByParentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetCommentsByParentMock.invocationsDone()
+}
+
+// MinimockGetCommentsByParentInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetCommentsByParentInspect() {
+	for _, e := range m.GetCommentsByParentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetCommentsByParent with params: %#v", *e.params)
+		}
+	}
+
+	afterGetCommentsByParentCounter := mm_atomic.LoadUint64(&m.afterGetCommentsByParentCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetCommentsByParentMock.defaultExpectation != nil && afterGetCommentsByParentCounter < 1 {
+		if m.GetCommentsByParentMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetCommentsByParent")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetCommentsByParent with params: %#v", *m.GetCommentsByParentMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetCommentsByParent != nil && afterGetCommentsByParentCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetCommentsByParent")
+	}
+
+	if !m.GetCommentsByParentMock.invocationsDone() && afterGetCommentsByParentCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetCommentsByParent but found %d calls",
+			mm_atomic.LoadUint64(&m.GetCommentsByParentMock.expectedInvocations), afterGetCommentsByParentCounter)
+	}
+}
+
+type mRepositoryMockGetCommentsByPost struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetCommentsByPostExpectation
+	expectations       []*RepositoryMockGetCommentsByPostExpectation
+
+	callArgs []*RepositoryMockGetCommentsByPostParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetCommentsByPostExpectation specifies expectation struct of the Repository.GetCommentsByPost
+type RepositoryMockGetCommentsByPostExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetCommentsByPostParams
+	paramPtrs *RepositoryMockGetCommentsByPostParamPtrs
+	results   *RepositoryMockGetCommentsByPostResults
+	Counter   uint64
+}
+
+// RepositoryMockGetCommentsByPostParams contains parameters of the Repository.GetCommentsByPost
+type RepositoryMockGetCommentsByPostParams struct {
+	ctx           context.Context
+	postID        int
+	limit         int
+	offset        int
+	includeHidden bool
+}
+
+// RepositoryMockGetCommentsByPostParamPtrs contains pointers to parameters of the Repository.GetCommentsByPost
+type RepositoryMockGetCommentsByPostParamPtrs struct {
+	ctx           *context.Context
+	postID        *int
+	limit         *int
+	offset        *int
+	includeHidden *bool
+}
+
+// RepositoryMockGetCommentsByPostResults contains results of the Repository.GetCommentsByPost
+type RepositoryMockGetCommentsByPostResults struct {
+	cpa1 []*domain.Comment
+	err  error
+}
+
+// Expect sets up expected params for Repository.GetCommentsByPost
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Expect(ctx context.Context, postID int, limit int, offset int, includeHidden bool) *mRepositoryMockGetCommentsByPost {
+	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation == nil {
+		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{}
+	}
+
+	if mmGetCommentsByPost.defaultExpectation.paramPtrs != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by ExpectParams functions")
+	}
+
+	mmGetCommentsByPost.defaultExpectation.params = &RepositoryMockGetCommentsByPostParams{ctx, postID, limit, offset, includeHidden}
+	for _, e := range mmGetCommentsByPost.expectations {
+		if minimock.Equal(e.params, mmGetCommentsByPost.defaultExpectation.params) {
+			mmGetCommentsByPost.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetCommentsByPost.defaultExpectation.params)
+		}
+	}
+
+	return mmGetCommentsByPost
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetCommentsByPost
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetCommentsByPost {
+	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation == nil {
+		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{}
+	}
+
+	if mmGetCommentsByPost.defaultExpectation.params != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Expect")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation.paramPtrs == nil {
+		mmGetCommentsByPost.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByPostParamPtrs{}
+	}
+	mmGetCommentsByPost.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetCommentsByPost
+}
+
+// ExpectPostIDParam2 sets up expected param postID for Repository.GetCommentsByPost
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) ExpectPostIDParam2(postID int) *mRepositoryMockGetCommentsByPost {
+	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation == nil {
+		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{}
+	}
+
+	if mmGetCommentsByPost.defaultExpectation.params != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Expect")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation.paramPtrs == nil {
+		mmGetCommentsByPost.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByPostParamPtrs{}
+	}
+	mmGetCommentsByPost.defaultExpectation.paramPtrs.postID = &postID
+
+	return mmGetCommentsByPost
+}
+
+// ExpectLimitParam3 sets up expected param limit for Repository.GetCommentsByPost
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) ExpectLimitParam3(limit int) *mRepositoryMockGetCommentsByPost {
+	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation == nil {
+		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{}
+	}
+
+	if mmGetCommentsByPost.defaultExpectation.params != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Expect")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation.paramPtrs == nil {
+		mmGetCommentsByPost.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByPostParamPtrs{}
+	}
+	mmGetCommentsByPost.defaultExpectation.paramPtrs.limit = &limit
+
+	return mmGetCommentsByPost
+}
+
+// ExpectOffsetParam4 sets up expected param offset for Repository.GetCommentsByPost
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) ExpectOffsetParam4(offset int) *mRepositoryMockGetCommentsByPost {
+	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation == nil {
+		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{}
+	}
+
+	if mmGetCommentsByPost.defaultExpectation.params != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Expect")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation.paramPtrs == nil {
+		mmGetCommentsByPost.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByPostParamPtrs{}
+	}
+	mmGetCommentsByPost.defaultExpectation.paramPtrs.offset = &offset
+
+	return mmGetCommentsByPost
+}
+
+// ExpectIncludeHiddenParam5 sets up expected param includeHidden for Repository.GetCommentsByPost
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) ExpectIncludeHiddenParam5(includeHidden bool) *mRepositoryMockGetCommentsByPost {
+	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation == nil {
+		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{}
+	}
+
+	if mmGetCommentsByPost.defaultExpectation.params != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Expect")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation.paramPtrs == nil {
+		mmGetCommentsByPost.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByPostParamPtrs{}
+	}
+	mmGetCommentsByPost.defaultExpectation.paramPtrs.includeHidden = &includeHidden
+
+	return mmGetCommentsByPost
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetCommentsByPost
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Inspect(f func(ctx context.Context, postID int, limit int, offset int, includeHidden bool)) *mRepositoryMockGetCommentsByPost {
+	if mmGetCommentsByPost.mock.inspectFuncGetCommentsByPost != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetCommentsByPost")
+	}
+
+	mmGetCommentsByPost.mock.inspectFuncGetCommentsByPost = f
+
+	return mmGetCommentsByPost
+}
+
+// Return sets up results that will be returned by Repository.GetCommentsByPost
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Return(cpa1 []*domain.Comment, err error) *RepositoryMock {
+	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+	}
+
+	if mmGetCommentsByPost.defaultExpectation == nil {
+		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{mock: mmGetCommentsByPost.mock}
+	}
+	mmGetCommentsByPost.defaultExpectation.results = &RepositoryMockGetCommentsByPostResults{cpa1, err}
+	return mmGetCommentsByPost.mock
+}
+
+// Set uses given function f to mock the Repository.GetCommentsByPost method
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Set(f func(ctx context.Context, postID int, limit int, offset int, includeHidden bool) (cpa1 []*domain.Comment, err error)) *RepositoryMock {
+	if mmGetCommentsByPost.defaultExpectation != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("Default expectation is already set for the Repository.GetCommentsByPost method")
+	}
+
+	if len(mmGetCommentsByPost.expectations) > 0 {
+		mmGetCommentsByPost.mock.t.Fatalf("Some expectations are already set for the Repository.GetCommentsByPost method")
+	}
+
+	mmGetCommentsByPost.mock.funcGetCommentsByPost = f
+	return mmGetCommentsByPost.mock
+}
+
+// When sets expectation for the Repository.GetCommentsByPost which will trigger the result defined by the following
+// Then helper
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) When(ctx context.Context, postID int, limit int, offset int, includeHidden bool) *RepositoryMockGetCommentsByPostExpectation {
+	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
+		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetCommentsByPostExpectation{
+		mock:   mmGetCommentsByPost.mock,
+		params: &RepositoryMockGetCommentsByPostParams{ctx, postID, limit, offset, includeHidden},
+	}
+	mmGetCommentsByPost.expectations = append(mmGetCommentsByPost.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetCommentsByPost return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetCommentsByPostExpectation) Then(cpa1 []*domain.Comment, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetCommentsByPostResults{cpa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetCommentsByPost should be invoked
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Times(n uint64) *mRepositoryMockGetCommentsByPost {
+	if n == 0 {
+		mmGetCommentsByPost.mock.t.Fatalf("Times of RepositoryMock.GetCommentsByPost mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetCommentsByPost.expectedInvocations, n)
+	return mmGetCommentsByPost
+}
+
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) invocationsDone() bool {
+	if len(mmGetCommentsByPost.expectations) == 0 && mmGetCommentsByPost.defaultExpectation == nil && mmGetCommentsByPost.mock.funcGetCommentsByPost == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetCommentsByPost.mock.afterGetCommentsByPostCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetCommentsByPost.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetCommentsByPost implements repository.Repository
+func (mmGetCommentsByPost *RepositoryMock) GetCommentsByPost(ctx context.Context, postID int, limit int, offset int, includeHidden bool) (cpa1 []*domain.Comment, err error) {
+	mm_atomic.AddUint64(&mmGetCommentsByPost.beforeGetCommentsByPostCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetCommentsByPost.afterGetCommentsByPostCounter, 1)
+
+	if mmGetCommentsByPost.inspectFuncGetCommentsByPost != nil {
+		mmGetCommentsByPost.inspectFuncGetCommentsByPost(ctx, postID, limit, offset, includeHidden)
+	}
+
+	mm_params := RepositoryMockGetCommentsByPostParams{ctx, postID, limit, offset, includeHidden}
+
+	// Record call args
+	mmGetCommentsByPost.GetCommentsByPostMock.mutex.Lock()
+	mmGetCommentsByPost.GetCommentsByPostMock.callArgs = append(mmGetCommentsByPost.GetCommentsByPostMock.callArgs, &mm_params)
+	mmGetCommentsByPost.GetCommentsByPostMock.mutex.Unlock()
+
+	for _, e := range mmGetCommentsByPost.GetCommentsByPostMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.cpa1, e.results.err
+		}
+	}
+
+	if mmGetCommentsByPost.GetCommentsByPostMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetCommentsByPost.GetCommentsByPostMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetCommentsByPost.GetCommentsByPostMock.defaultExpectation.params
+		mm_want_ptrs := mmGetCommentsByPost.GetCommentsByPostMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetCommentsByPostParams{ctx, postID, limit, offset, includeHidden}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetCommentsByPost.t.Errorf("RepositoryMock.GetCommentsByPost got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.postID != nil && !minimock.Equal(*mm_want_ptrs.postID, mm_got.postID) {
+				mmGetCommentsByPost.t.Errorf("RepositoryMock.GetCommentsByPost got unexpected parameter postID, want: %#v, got: %#v%s\n", *mm_want_ptrs.postID, mm_got.postID, minimock.Diff(*mm_want_ptrs.postID, mm_got.postID))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmGetCommentsByPost.t.Errorf("RepositoryMock.GetCommentsByPost got unexpected parameter limit, want: %#v, got: %#v%s\n", *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+			if mm_want_ptrs.offset != nil && !minimock.Equal(*mm_want_ptrs.offset, mm_got.offset) {
+				mmGetCommentsByPost.t.Errorf("RepositoryMock.GetCommentsByPost got unexpected parameter offset, want: %#v, got: %#v%s\n", *mm_want_ptrs.offset, mm_got.offset, minimock.Diff(*mm_want_ptrs.offset, mm_got.offset))
+			}
+
+			if mm_want_ptrs.includeHidden != nil && !minimock.Equal(*mm_want_ptrs.includeHidden, mm_got.includeHidden) {
+				mmGetCommentsByPost.t.Errorf("RepositoryMock.GetCommentsByPost got unexpected parameter includeHidden, want: %#v, got: %#v%s\n", *mm_want_ptrs.includeHidden, mm_got.includeHidden, minimock.Diff(*mm_want_ptrs.includeHidden, mm_got.includeHidden))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetCommentsByPost.t.Errorf("RepositoryMock.GetCommentsByPost got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetCommentsByPost.GetCommentsByPostMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetCommentsByPost.t.Fatal("No results are set for the RepositoryMock.GetCommentsByPost")
+		}
+		return (*mm_results).cpa1, (*mm_results).err
+	}
+	if mmGetCommentsByPost.funcGetCommentsByPost != nil {
+		return mmGetCommentsByPost.funcGetCommentsByPost(ctx, postID, limit, offset, includeHidden)
+	}
+	mmGetCommentsByPost.t.Fatalf("Unexpected call to RepositoryMock.GetCommentsByPost. %v %v %v %v %v", ctx, postID, limit, offset, includeHidden)
+	return
+}
+
+// GetCommentsByPostAfterCounter returns a count of finished RepositoryMock.GetCommentsByPost invocations
+func (mmGetCommentsByPost *RepositoryMock) GetCommentsByPostAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetCommentsByPost.afterGetCommentsByPostCounter)
+}
+
+// GetCommentsByPostBeforeCounter returns a count of RepositoryMock.GetCommentsByPost invocations
+func (mmGetCommentsByPost *RepositoryMock) GetCommentsByPostBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetCommentsByPost.beforeGetCommentsByPostCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetCommentsByPost.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Calls() []*RepositoryMockGetCommentsByPostParams {
+	mmGetCommentsByPost.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetCommentsByPostParams, len(mmGetCommentsByPost.callArgs))
+	copy(argCopy, mmGetCommentsByPost.callArgs)
+
+	mmGetCommentsByPost.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetCommentsByPostDone returns true if the count of the GetCommentsByPost invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetCommentsByPostDone() bool {
+	for _, e := range m.GetCommentsByPostMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetCommentsByPostMock.invocationsDone()
+}
+
+// MinimockGetCommentsByPostInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetCommentsByPostInspect() {
+	for _, e := range m.GetCommentsByPostMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetCommentsByPost with params: %#v", *e.params)
+		}
+	}
+
+	afterGetCommentsByPostCounter := mm_atomic.LoadUint64(&m.afterGetCommentsByPostCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetCommentsByPostMock.defaultExpectation != nil && afterGetCommentsByPostCounter < 1 {
+		if m.GetCommentsByPostMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetCommentsByPost")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetCommentsByPost with params: %#v", *m.GetCommentsByPostMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetCommentsByPost != nil && afterGetCommentsByPostCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetCommentsByPost")
+	}
+
+	if !m.GetCommentsByPostMock.invocationsDone() && afterGetCommentsByPostCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetCommentsByPost but found %d calls",
+			mm_atomic.LoadUint64(&m.GetCommentsByPostMock.expectedInvocations), afterGetCommentsByPostCounter)
+	}
+}
+
+type mRepositoryMockGetModerationRecords struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetModerationRecordsExpectation
+	expectations       []*RepositoryMockGetModerationRecordsExpectation
+
+	callArgs []*RepositoryMockGetModerationRecordsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetModerationRecordsExpectation specifies expectation struct of the Repository.GetModerationRecords
+type RepositoryMockGetModerationRecordsExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetModerationRecordsParams
+	paramPtrs *RepositoryMockGetModerationRecordsParamPtrs
+	results   *RepositoryMockGetModerationRecordsResults
+	Counter   uint64
+}
+
+// RepositoryMockGetModerationRecordsParams contains parameters of the Repository.GetModerationRecords
+type RepositoryMockGetModerationRecordsParams struct {
+	ctx    context.Context
+	limit  int
+	offset int
+}
+
+// RepositoryMockGetModerationRecordsParamPtrs contains pointers to parameters of the Repository.GetModerationRecords
+type RepositoryMockGetModerationRecordsParamPtrs struct {
+	ctx    *context.Context
+	limit  *int
+	offset *int
+}
+
+// RepositoryMockGetModerationRecordsResults contains results of the Repository.GetModerationRecords
+type RepositoryMockGetModerationRecordsResults struct {
+	mpa1 []*domain.ModerationRecord
+	err  error
+}
+
+// Expect sets up expected params for Repository.GetModerationRecords
+func (mmGetModerationRecords *mRepositoryMockGetModerationRecords) Expect(ctx context.Context, limit int, offset int) *mRepositoryMockGetModerationRecords {
+	if mmGetModerationRecords.mock.funcGetModerationRecords != nil {
+		mmGetModerationRecords.mock.t.Fatalf("RepositoryMock.GetModerationRecords mock is already set by Set")
+	}
+
+	if mmGetModerationRecords.defaultExpectation == nil {
+		mmGetModerationRecords.defaultExpectation = &RepositoryMockGetModerationRecordsExpectation{}
+	}
+
+	if mmGetModerationRecords.defaultExpectation.paramPtrs != nil {
+		mmGetModerationRecords.mock.t.Fatalf("RepositoryMock.GetModerationRecords mock is already set by ExpectParams functions")
+	}
+
+	mmGetModerationRecords.defaultExpectation.params = &RepositoryMockGetModerationRecordsParams{ctx, limit, offset}
+	for _, e := range mmGetModerationRecords.expectations {
+		if minimock.Equal(e.params, mmGetModerationRecords.defaultExpectation.params) {
+			mmGetModerationRecords.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetModerationRecords.defaultExpectation.params)
+		}
+	}
+
+	return mmGetModerationRecords
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetModerationRecords
+func (mmGetModerationRecords *mRepositoryMockGetModerationRecords) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetModerationRecords {
+	if mmGetModerationRecords.mock.funcGetModerationRecords != nil {
+		mmGetModerationRecords.mock.t.Fatalf("RepositoryMock.GetModerationRecords mock is already set by Set")
+	}
+
+	if mmGetModerationRecords.defaultExpectation == nil {
+		mmGetModerationRecords.defaultExpectation = &RepositoryMockGetModerationRecordsExpectation{}
+	}
+
+	if mmGetModerationRecords.defaultExpectation.params != nil {
+		mmGetModerationRecords.mock.t.Fatalf("RepositoryMock.GetModerationRecords mock is already set by Expect")
+	}
+
+	if mmGetModerationRecords.defaultExpectation.paramPtrs == nil {
+		mmGetModerationRecords.defaultExpectation.paramPtrs = &RepositoryMockGetModerationRecordsParamPtrs{}
+	}
+	mmGetModerationRecords.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetModerationRecords
+}
+
+// ExpectLimitParam2 sets up expected param limit for Repository.GetModerationRecords
+func (mmGetModerationRecords *mRepositoryMockGetModerationRecords) ExpectLimitParam2(limit int) *mRepositoryMockGetModerationRecords {
+	if mmGetModerationRecords.mock.funcGetModerationRecords != nil {
+		mmGetModerationRecords.mock.t.Fatalf("RepositoryMock.GetModerationRecords mock is already set by Set")
+	}
+
+	if mmGetModerationRecords.defaultExpectation == nil {
+		mmGetModerationRecords.defaultExpectation = &RepositoryMockGetModerationRecordsExpectation{}
+	}
+
+	if mmGetModerationRecords.defaultExpectation.params != nil {
+		mmGetModerationRecords.mock.t.Fatalf("RepositoryMock.GetModerationRecords mock is already set by Expect")
+	}
+
+	if mmGetModerationRecords.defaultExpectation.paramPtrs == nil {
+		mmGetModerationRecords.defaultExpectation.paramPtrs = &RepositoryMockGetModerationRecordsParamPtrs{}
+	}
+	mmGetModerationRecords.defaultExpectation.paramPtrs.limit = &limit
+
+	return mmGetModerationRecords
+}
+
+// ExpectOffsetParam3 sets up expected param offset for Repository.GetModerationRecords
+func (mmGetModerationRecords *mRepositoryMockGetModerationRecords) ExpectOffsetParam3(offset int) *mRepositoryMockGetModerationRecords {
+	if mmGetModerationRecords.mock.funcGetModerationRecords != nil {
+		mmGetModerationRecords.mock.t.Fatalf("RepositoryMock.GetModerationRecords mock is already set by Set")
+	}
+
+	if mmGetModerationRecords.defaultExpectation == nil {
+		mmGetModerationRecords.defaultExpectation = &RepositoryMockGetModerationRecordsExpectation{}
+	}
+
+	if mmGetModerationRecords.defaultExpectation.params != nil {
+		mmGetModerationRecords.mock.t.Fatalf("RepositoryMock.GetModerationRecords mock is already set by Expect")
+	}
+
+	if mmGetModerationRecords.defaultExpectation.paramPtrs == nil {
+		mmGetModerationRecords.defaultExpectation.paramPtrs = &RepositoryMockGetModerationRecordsParamPtrs{}
+	}
+	mmGetModerationRecords.defaultExpectation.paramPtrs.offset = &offset
+
+	return mmGetModerationRecords
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetModerationRecords
+func (mmGetModerationRecords *mRepositoryMockGetModerationRecords) Inspect(f func(ctx context.Context, limit int, offset int)) *mRepositoryMockGetModerationRecords {
+	if mmGetModerationRecords.mock.inspectFuncGetModerationRecords != nil {
+		mmGetModerationRecords.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetModerationRecords")
+	}
+
+	mmGetModerationRecords.mock.inspectFuncGetModerationRecords = f
+
+	return mmGetModerationRecords
+}
+
+// Return sets up results that will be returned by Repository.GetModerationRecords
+func (mmGetModerationRecords *mRepositoryMockGetModerationRecords) Return(mpa1 []*domain.ModerationRecord, err error) *RepositoryMock {
+	if mmGetModerationRecords.mock.funcGetModerationRecords != nil {
+		mmGetModerationRecords.mock.t.Fatalf("RepositoryMock.GetModerationRecords mock is already set by Set")
+	}
+
+	if mmGetModerationRecords.defaultExpectation == nil {
+		mmGetModerationRecords.defaultExpectation = &RepositoryMockGetModerationRecordsExpectation{mock: mmGetModerationRecords.mock}
+	}
+	mmGetModerationRecords.defaultExpectation.results = &RepositoryMockGetModerationRecordsResults{mpa1, err}
+	return mmGetModerationRecords.mock
+}
+
+// Set uses given function f to mock the Repository.GetModerationRecords method
+func (mmGetModerationRecords *mRepositoryMockGetModerationRecords) Set(f func(ctx context.Context, limit int, offset int) (mpa1 []*domain.ModerationRecord, err error)) *RepositoryMock {
+	if mmGetModerationRecords.defaultExpectation != nil {
+		mmGetModerationRecords.mock.t.Fatalf("Default expectation is already set for the Repository.GetModerationRecords method")
+	}
+
+	if len(mmGetModerationRecords.expectations) > 0 {
+		mmGetModerationRecords.mock.t.Fatalf("Some expectations are already set for the Repository.GetModerationRecords method")
+	}
+
+	mmGetModerationRecords.mock.funcGetModerationRecords = f
+	return mmGetModerationRecords.mock
+}
+
+// When sets expectation for the Repository.GetModerationRecords which will trigger the result defined by the following
+// Then helper
+func (mmGetModerationRecords *mRepositoryMockGetModerationRecords) When(ctx context.Context, limit int, offset int) *RepositoryMockGetModerationRecordsExpectation {
+	if mmGetModerationRecords.mock.funcGetModerationRecords != nil {
+		mmGetModerationRecords.mock.t.Fatalf("RepositoryMock.GetModerationRecords mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetModerationRecordsExpectation{
+		mock:   mmGetModerationRecords.mock,
+		params: &RepositoryMockGetModerationRecordsParams{ctx, limit, offset},
+	}
+	mmGetModerationRecords.expectations = append(mmGetModerationRecords.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetModerationRecords return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetModerationRecordsExpectation) Then(mpa1 []*domain.ModerationRecord, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetModerationRecordsResults{mpa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetModerationRecords should be invoked
+func (mmGetModerationRecords *mRepositoryMockGetModerationRecords) Times(n uint64) *mRepositoryMockGetModerationRecords {
+	if n == 0 {
+		mmGetModerationRecords.mock.t.Fatalf("Times of RepositoryMock.GetModerationRecords mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetModerationRecords.expectedInvocations, n)
+	return mmGetModerationRecords
+}
+
+func (mmGetModerationRecords *mRepositoryMockGetModerationRecords) invocationsDone() bool {
+	if len(mmGetModerationRecords.expectations) == 0 && mmGetModerationRecords.defaultExpectation == nil && mmGetModerationRecords.mock.funcGetModerationRecords == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetModerationRecords.mock.afterGetModerationRecordsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetModerationRecords.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetModerationRecords implements repository.Repository
+func (mmGetModerationRecords *RepositoryMock) GetModerationRecords(ctx context.Context, limit int, offset int) (mpa1 []*domain.ModerationRecord, err error) {
+	mm_atomic.AddUint64(&mmGetModerationRecords.beforeGetModerationRecordsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetModerationRecords.afterGetModerationRecordsCounter, 1)
+
+	if mmGetModerationRecords.inspectFuncGetModerationRecords != nil {
+		mmGetModerationRecords.inspectFuncGetModerationRecords(ctx, limit, offset)
+	}
+
+	mm_params := RepositoryMockGetModerationRecordsParams{ctx, limit, offset}
+
+	// Record call args
+	mmGetModerationRecords.GetModerationRecordsMock.mutex.Lock()
+	mmGetModerationRecords.GetModerationRecordsMock.callArgs = append(mmGetModerationRecords.GetModerationRecordsMock.callArgs, &mm_params)
+	mmGetModerationRecords.GetModerationRecordsMock.mutex.Unlock()
+
+	for _, e := range mmGetModerationRecords.GetModerationRecordsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.mpa1, e.results.err
+		}
+	}
+
+	if mmGetModerationRecords.GetModerationRecordsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetModerationRecords.GetModerationRecordsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetModerationRecords.GetModerationRecordsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetModerationRecords.GetModerationRecordsMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetModerationRecordsParams{ctx, limit, offset}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetModerationRecords.t.Errorf("RepositoryMock.GetModerationRecords got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmGetModerationRecords.t.Errorf("RepositoryMock.GetModerationRecords got unexpected parameter limit, want: %#v, got: %#v%s\n", *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+			if mm_want_ptrs.offset != nil && !minimock.Equal(*mm_want_ptrs.offset, mm_got.offset) {
+				mmGetModerationRecords.t.Errorf("RepositoryMock.GetModerationRecords got unexpected parameter offset, want: %#v, got: %#v%s\n", *mm_want_ptrs.offset, mm_got.offset, minimock.Diff(*mm_want_ptrs.offset, mm_got.offset))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetModerationRecords.t.Errorf("RepositoryMock.GetModerationRecords got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetModerationRecords.GetModerationRecordsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetModerationRecords.t.Fatal("No results are set for the RepositoryMock.GetModerationRecords")
+		}
+		return (*mm_results).mpa1, (*mm_results).err
+	}
+	if mmGetModerationRecords.funcGetModerationRecords != nil {
+		return mmGetModerationRecords.funcGetModerationRecords(ctx, limit, offset)
+	}
+	mmGetModerationRecords.t.Fatalf("Unexpected call to RepositoryMock.GetModerationRecords. %v %v %v", ctx, limit, offset)
+	return
+}
+
+// GetModerationRecordsAfterCounter returns a count of finished RepositoryMock.GetModerationRecords invocations
+func (mmGetModerationRecords *RepositoryMock) GetModerationRecordsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetModerationRecords.afterGetModerationRecordsCounter)
+}
+
+// GetModerationRecordsBeforeCounter returns a count of RepositoryMock.GetModerationRecords invocations
+func (mmGetModerationRecords *RepositoryMock) GetModerationRecordsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetModerationRecords.beforeGetModerationRecordsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetModerationRecords.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetModerationRecords *mRepositoryMockGetModerationRecords) Calls() []*RepositoryMockGetModerationRecordsParams {
+	mmGetModerationRecords.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetModerationRecordsParams, len(mmGetModerationRecords.callArgs))
+	copy(argCopy, mmGetModerationRecords.callArgs)
+
+	mmGetModerationRecords.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetModerationRecordsDone returns true if the count of the GetModerationRecords invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetModerationRecordsDone() bool {
+	for _, e := range m.GetModerationRecordsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetModerationRecordsMock.invocationsDone()
+}
+
+// MinimockGetModerationRecordsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetModerationRecordsInspect() {
+	for _, e := range m.GetModerationRecordsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetModerationRecords with params: %#v", *e.params)
+		}
+	}
+
+	afterGetModerationRecordsCounter := mm_atomic.LoadUint64(&m.afterGetModerationRecordsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetModerationRecordsMock.defaultExpectation != nil && afterGetModerationRecordsCounter < 1 {
+		if m.GetModerationRecordsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetModerationRecords")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetModerationRecords with params: %#v", *m.GetModerationRecordsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetModerationRecords != nil && afterGetModerationRecordsCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetModerationRecords")
+	}
+
+	if !m.GetModerationRecordsMock.invocationsDone() && afterGetModerationRecordsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetModerationRecords but found %d calls",
+			mm_atomic.LoadUint64(&m.GetModerationRecordsMock.expectedInvocations), afterGetModerationRecordsCounter)
+	}
+}
+
+type mRepositoryMockGetPost struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetPostExpectation
+	expectations       []*RepositoryMockGetPostExpectation
+
+	callArgs []*RepositoryMockGetPostParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetPostExpectation specifies expectation struct of the Repository.GetPost
+type RepositoryMockGetPostExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetPostParams
+	paramPtrs *RepositoryMockGetPostParamPtrs
+	results   *RepositoryMockGetPostResults
+	Counter   uint64
+}
+
+// RepositoryMockGetPostParams contains parameters of the Repository.GetPost
+type RepositoryMockGetPostParams struct {
+	ctx context.Context
+	id  int
+}
+
+// RepositoryMockGetPostParamPtrs contains pointers to parameters of the Repository.GetPost
+type RepositoryMockGetPostParamPtrs struct {
+	ctx *context.Context
+	id  *int
+}
+
+// RepositoryMockGetPostResults contains results of the Repository.GetPost
+type RepositoryMockGetPostResults struct {
+	pp1 *domain.Post
+	err error
+}
+
+// Expect sets up expected params for Repository.GetPost
+func (mmGetPost *mRepositoryMockGetPost) Expect(ctx context.Context, id int) *mRepositoryMockGetPost {
+	if mmGetPost.mock.funcGetPost != nil {
+		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Set")
+	}
+
+	if mmGetPost.defaultExpectation == nil {
+		mmGetPost.defaultExpectation = &RepositoryMockGetPostExpectation{}
+	}
+
+	if mmGetPost.defaultExpectation.paramPtrs != nil {
+		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by ExpectParams functions")
+	}
+
+	mmGetPost.defaultExpectation.params = &RepositoryMockGetPostParams{ctx, id}
+	for _, e := range mmGetPost.expectations {
+		if minimock.Equal(e.params, mmGetPost.defaultExpectation.params) {
+			mmGetPost.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetPost.defaultExpectation.params)
+		}
+	}
+
+	return mmGetPost
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetPost
+func (mmGetPost *mRepositoryMockGetPost) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetPost {
+	if mmGetPost.mock.funcGetPost != nil {
+		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Set")
+	}
+
+	if mmGetPost.defaultExpectation == nil {
+		mmGetPost.defaultExpectation = &RepositoryMockGetPostExpectation{}
+	}
+
+	if mmGetPost.defaultExpectation.params != nil {
+		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Expect")
+	}
+
+	if mmGetPost.defaultExpectation.paramPtrs == nil {
+		mmGetPost.defaultExpectation.paramPtrs = &RepositoryMockGetPostParamPtrs{}
+	}
+	mmGetPost.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetPost
+}
+
+// ExpectIdParam2 sets up expected param id for Repository.GetPost
+func (mmGetPost *mRepositoryMockGetPost) ExpectIdParam2(id int) *mRepositoryMockGetPost {
+	if mmGetPost.mock.funcGetPost != nil {
+		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Set")
+	}
+
+	if mmGetPost.defaultExpectation == nil {
+		mmGetPost.defaultExpectation = &RepositoryMockGetPostExpectation{}
+	}
+
+	if mmGetPost.defaultExpectation.params != nil {
+		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Expect")
+	}
+
+	if mmGetPost.defaultExpectation.paramPtrs == nil {
+		mmGetPost.defaultExpectation.paramPtrs = &RepositoryMockGetPostParamPtrs{}
+	}
+	mmGetPost.defaultExpectation.paramPtrs.id = &id
+
+	return mmGetPost
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetPost
+func (mmGetPost *mRepositoryMockGetPost) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockGetPost {
+	if mmGetPost.mock.inspectFuncGetPost != nil {
+		mmGetPost.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetPost")
+	}
+
+	mmGetPost.mock.inspectFuncGetPost = f
+
+	return mmGetPost
+}
+
+// Return sets up results that will be returned by Repository.GetPost
+func (mmGetPost *mRepositoryMockGetPost) Return(pp1 *domain.Post, err error) *RepositoryMock {
+	if mmGetPost.mock.funcGetPost != nil {
+		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Set")
+	}
+
+	if mmGetPost.defaultExpectation == nil {
+		mmGetPost.defaultExpectation = &RepositoryMockGetPostExpectation{mock: mmGetPost.mock}
+	}
+	mmGetPost.defaultExpectation.results = &RepositoryMockGetPostResults{pp1, err}
+	return mmGetPost.mock
+}
+
+// Set uses given function f to mock the Repository.GetPost method
+func (mmGetPost *mRepositoryMockGetPost) Set(f func(ctx context.Context, id int) (pp1 *domain.Post, err error)) *RepositoryMock {
+	if mmGetPost.defaultExpectation != nil {
+		mmGetPost.mock.t.Fatalf("Default expectation is already set for the Repository.GetPost method")
+	}
+
+	if len(mmGetPost.expectations) > 0 {
+		mmGetPost.mock.t.Fatalf("Some expectations are already set for the Repository.GetPost method")
+	}
+
+	mmGetPost.mock.funcGetPost = f
+	return mmGetPost.mock
+}
+
+// When sets expectation for the Repository.GetPost which will trigger the result defined by the following
+// Then helper
+func (mmGetPost *mRepositoryMockGetPost) When(ctx context.Context, id int) *RepositoryMockGetPostExpectation {
+	if mmGetPost.mock.funcGetPost != nil {
+		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetPostExpectation{
+		mock:   mmGetPost.mock,
+		params: &RepositoryMockGetPostParams{ctx, id},
+	}
+	mmGetPost.expectations = append(mmGetPost.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetPost return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetPostExpectation) Then(pp1 *domain.Post, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetPostResults{pp1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetPost should be invoked
+func (mmGetPost *mRepositoryMockGetPost) Times(n uint64) *mRepositoryMockGetPost {
+	if n == 0 {
+		mmGetPost.mock.t.Fatalf("Times of RepositoryMock.GetPost mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetPost.expectedInvocations, n)
+	return mmGetPost
+}
+
+func (mmGetPost *mRepositoryMockGetPost) invocationsDone() bool {
+	if len(mmGetPost.expectations) == 0 && mmGetPost.defaultExpectation == nil && mmGetPost.mock.funcGetPost == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetPost.mock.afterGetPostCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetPost.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetPost implements repository.Repository
+func (mmGetPost *RepositoryMock) GetPost(ctx context.Context, id int) (pp1 *domain.Post, err error) {
+	mm_atomic.AddUint64(&mmGetPost.beforeGetPostCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetPost.afterGetPostCounter, 1)
+
+	if mmGetPost.inspectFuncGetPost != nil {
+		mmGetPost.inspectFuncGetPost(ctx, id)
+	}
+
+	mm_params := RepositoryMockGetPostParams{ctx, id}
+
+	// Record call args
+	mmGetPost.GetPostMock.mutex.Lock()
+	mmGetPost.GetPostMock.callArgs = append(mmGetPost.GetPostMock.callArgs, &mm_params)
+	mmGetPost.GetPostMock.mutex.Unlock()
+
+	for _, e := range mmGetPost.GetPostMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.pp1, e.results.err
+		}
+	}
+
+	if mmGetPost.GetPostMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetPost.GetPostMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetPost.GetPostMock.defaultExpectation.params
+		mm_want_ptrs := mmGetPost.GetPostMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetPostParams{ctx, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetPost.t.Errorf("RepositoryMock.GetPost got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmGetPost.t.Errorf("RepositoryMock.GetPost got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetPost.t.Errorf("RepositoryMock.GetPost got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetPost.GetPostMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetPost.t.Fatal("No results are set for the RepositoryMock.GetPost")
+		}
+		return (*mm_results).pp1, (*mm_results).err
+	}
+	if mmGetPost.funcGetPost != nil {
+		return mmGetPost.funcGetPost(ctx, id)
+	}
+	mmGetPost.t.Fatalf("Unexpected call to RepositoryMock.GetPost. %v %v", ctx, id)
+	return
+}
+
+// GetPostAfterCounter returns a count of finished RepositoryMock.GetPost invocations
+func (mmGetPost *RepositoryMock) GetPostAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPost.afterGetPostCounter)
+}
+
+// GetPostBeforeCounter returns a count of RepositoryMock.GetPost invocations
+func (mmGetPost *RepositoryMock) GetPostBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPost.beforeGetPostCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetPost.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetPost *mRepositoryMockGetPost) Calls() []*RepositoryMockGetPostParams {
+	mmGetPost.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetPostParams, len(mmGetPost.callArgs))
+	copy(argCopy, mmGetPost.callArgs)
+
+	mmGetPost.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetPostDone returns true if the count of the GetPost invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetPostDone() bool {
+	for _, e := range m.GetPostMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetPostMock.invocationsDone()
+}
+
+// MinimockGetPostInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetPostInspect() {
+	for _, e := range m.GetPostMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetPost with params: %#v", *e.params)
+		}
+	}
+
+	afterGetPostCounter := mm_atomic.LoadUint64(&m.afterGetPostCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetPostMock.defaultExpectation != nil && afterGetPostCounter < 1 {
+		if m.GetPostMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetPost")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetPost with params: %#v", *m.GetPostMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetPost != nil && afterGetPostCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetPost")
+	}
+
+	if !m.GetPostMock.invocationsDone() && afterGetPostCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetPost but found %d calls",
+			mm_atomic.LoadUint64(&m.GetPostMock.expectedInvocations), afterGetPostCounter)
+	}
+}
+
+type mRepositoryMockGetPosts struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetPostsExpectation
+	expectations       []*RepositoryMockGetPostsExpectation
+
+	callArgs []*RepositoryMockGetPostsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetPostsExpectation specifies expectation struct of the Repository.GetPosts
+type RepositoryMockGetPostsExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetPostsParams
+	paramPtrs *RepositoryMockGetPostsParamPtrs
+	results   *RepositoryMockGetPostsResults
+	Counter   uint64
+}
+
+// RepositoryMockGetPostsParams contains parameters of the Repository.GetPosts
+type RepositoryMockGetPostsParams struct {
+	ctx context.Context
+}
+
+// RepositoryMockGetPostsParamPtrs contains pointers to parameters of the Repository.GetPosts
+type RepositoryMockGetPostsParamPtrs struct {
+	ctx *context.Context
+}
+
+// RepositoryMockGetPostsResults contains results of the Repository.GetPosts
+type RepositoryMockGetPostsResults struct {
+	ppa1 []*domain.Post
+	err  error
+}
+
+// Expect sets up expected params for Repository.GetPosts
+func (mmGetPosts *mRepositoryMockGetPosts) Expect(ctx context.Context) *mRepositoryMockGetPosts {
+	if mmGetPosts.mock.funcGetPosts != nil {
+		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by Set")
+	}
+
+	if mmGetPosts.defaultExpectation == nil {
+		mmGetPosts.defaultExpectation = &RepositoryMockGetPostsExpectation{}
+	}
+
+	if mmGetPosts.defaultExpectation.paramPtrs != nil {
+		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by ExpectParams functions")
+	}
+
+	mmGetPosts.defaultExpectation.params = &RepositoryMockGetPostsParams{ctx}
+	for _, e := range mmGetPosts.expectations {
+		if minimock.Equal(e.params, mmGetPosts.defaultExpectation.params) {
+			mmGetPosts.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetPosts.defaultExpectation.params)
+		}
+	}
+
+	return mmGetPosts
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetPosts
+func (mmGetPosts *mRepositoryMockGetPosts) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetPosts {
+	if mmGetPosts.mock.funcGetPosts != nil {
+		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by Set")
+	}
+
+	if mmGetPosts.defaultExpectation == nil {
+		mmGetPosts.defaultExpectation = &RepositoryMockGetPostsExpectation{}
+	}
+
+	if mmGetPosts.defaultExpectation.params != nil {
+		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by Expect")
+	}
+
+	if mmGetPosts.defaultExpectation.paramPtrs == nil {
+		mmGetPosts.defaultExpectation.paramPtrs = &RepositoryMockGetPostsParamPtrs{}
+	}
+	mmGetPosts.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetPosts
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetPosts
+func (mmGetPosts *mRepositoryMockGetPosts) Inspect(f func(ctx context.Context)) *mRepositoryMockGetPosts {
+	if mmGetPosts.mock.inspectFuncGetPosts != nil {
+		mmGetPosts.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetPosts")
+	}
+
+	mmGetPosts.mock.inspectFuncGetPosts = f
+
+	return mmGetPosts
+}
+
+// Return sets up results that will be returned by Repository.GetPosts
+func (mmGetPosts *mRepositoryMockGetPosts) Return(ppa1 []*domain.Post, err error) *RepositoryMock {
+	if mmGetPosts.mock.funcGetPosts != nil {
+		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by Set")
+	}
+
+	if mmGetPosts.defaultExpectation == nil {
+		mmGetPosts.defaultExpectation = &RepositoryMockGetPostsExpectation{mock: mmGetPosts.mock}
+	}
+	mmGetPosts.defaultExpectation.results = &RepositoryMockGetPostsResults{ppa1, err}
+	return mmGetPosts.mock
+}
+
+// Set uses given function f to mock the Repository.GetPosts method
+func (mmGetPosts *mRepositoryMockGetPosts) Set(f func(ctx context.Context) (ppa1 []*domain.Post, err error)) *RepositoryMock {
+	if mmGetPosts.defaultExpectation != nil {
+		mmGetPosts.mock.t.Fatalf("Default expectation is already set for the Repository.GetPosts method")
+	}
+
+	if len(mmGetPosts.expectations) > 0 {
+		mmGetPosts.mock.t.Fatalf("Some expectations are already set for the Repository.GetPosts method")
+	}
+
+	mmGetPosts.mock.funcGetPosts = f
+	return mmGetPosts.mock
+}
+
+// When sets expectation for the Repository.GetPosts which will trigger the result defined by the following
+// Then helper
+func (mmGetPosts *mRepositoryMockGetPosts) When(ctx context.Context) *RepositoryMockGetPostsExpectation {
+	if mmGetPosts.mock.funcGetPosts != nil {
+		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetPostsExpectation{
+		mock:   mmGetPosts.mock,
+		params: &RepositoryMockGetPostsParams{ctx},
+	}
+	mmGetPosts.expectations = append(mmGetPosts.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetPosts return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetPostsExpectation) Then(ppa1 []*domain.Post, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetPostsResults{ppa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetPosts should be invoked
+func (mmGetPosts *mRepositoryMockGetPosts) Times(n uint64) *mRepositoryMockGetPosts {
+	if n == 0 {
+		mmGetPosts.mock.t.Fatalf("Times of RepositoryMock.GetPosts mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetPosts.expectedInvocations, n)
+	return mmGetPosts
+}
+
+func (mmGetPosts *mRepositoryMockGetPosts) invocationsDone() bool {
+	if len(mmGetPosts.expectations) == 0 && mmGetPosts.defaultExpectation == nil && mmGetPosts.mock.funcGetPosts == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetPosts.mock.afterGetPostsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetPosts.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetPosts implements repository.Repository
+func (mmGetPosts *RepositoryMock) GetPosts(ctx context.Context) (ppa1 []*domain.Post, err error) {
+	mm_atomic.AddUint64(&mmGetPosts.beforeGetPostsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetPosts.afterGetPostsCounter, 1)
+
+	if mmGetPosts.inspectFuncGetPosts != nil {
+		mmGetPosts.inspectFuncGetPosts(ctx)
+	}
+
+	mm_params := RepositoryMockGetPostsParams{ctx}
+
+	// Record call args
+	mmGetPosts.GetPostsMock.mutex.Lock()
+	mmGetPosts.GetPostsMock.callArgs = append(mmGetPosts.GetPostsMock.callArgs, &mm_params)
+	mmGetPosts.GetPostsMock.mutex.Unlock()
+
+	for _, e := range mmGetPosts.GetPostsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ppa1, e.results.err
+		}
+	}
+
+	if mmGetPosts.GetPostsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetPosts.GetPostsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetPosts.GetPostsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetPosts.GetPostsMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetPostsParams{ctx}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetPosts.t.Errorf("RepositoryMock.GetPosts got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetPosts.t.Errorf("RepositoryMock.GetPosts got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetPosts.GetPostsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetPosts.t.Fatal("No results are set for the RepositoryMock.GetPosts")
+		}
+		return (*mm_results).ppa1, (*mm_results).err
+	}
+	if mmGetPosts.funcGetPosts != nil {
+		return mmGetPosts.funcGetPosts(ctx)
+	}
+	mmGetPosts.t.Fatalf("Unexpected call to RepositoryMock.GetPosts. %v", ctx)
+	return
+}
+
+// GetPostsAfterCounter returns a count of finished RepositoryMock.GetPosts invocations
+func (mmGetPosts *RepositoryMock) GetPostsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPosts.afterGetPostsCounter)
+}
+
+// GetPostsBeforeCounter returns a count of RepositoryMock.GetPosts invocations
+func (mmGetPosts *RepositoryMock) GetPostsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPosts.beforeGetPostsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetPosts.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetPosts *mRepositoryMockGetPosts) Calls() []*RepositoryMockGetPostsParams {
+	mmGetPosts.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetPostsParams, len(mmGetPosts.callArgs))
+	copy(argCopy, mmGetPosts.callArgs)
+
+	mmGetPosts.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetPostsDone returns true if the count of the GetPosts invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetPostsDone() bool {
+	for _, e := range m.GetPostsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetPostsMock.invocationsDone()
+}
+
+// MinimockGetPostsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetPostsInspect() {
+	for _, e := range m.GetPostsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetPosts with params: %#v", *e.params)
+		}
+	}
+
+	afterGetPostsCounter := mm_atomic.LoadUint64(&m.afterGetPostsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetPostsMock.defaultExpectation != nil && afterGetPostsCounter < 1 {
+		if m.GetPostsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetPosts")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetPosts with params: %#v", *m.GetPostsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetPosts != nil && afterGetPostsCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetPosts")
+	}
+
+	if !m.GetPostsMock.invocationsDone() && afterGetPostsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetPosts but found %d calls",
+			mm_atomic.LoadUint64(&m.GetPostsMock.expectedInvocations), afterGetPostsCounter)
+	}
+}
+
+type mRepositoryMockGetPostsAfter struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetPostsAfterExpectation
+	expectations       []*RepositoryMockGetPostsAfterExpectation
+
+	callArgs []*RepositoryMockGetPostsAfterParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetPostsAfterExpectation specifies expectation struct of the Repository.GetPostsAfter
+type RepositoryMockGetPostsAfterExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetPostsAfterParams
+	paramPtrs *RepositoryMockGetPostsAfterParamPtrs
+	results   *RepositoryMockGetPostsAfterResults
+	Counter   uint64
+}
+
+// RepositoryMockGetPostsAfterParams contains parameters of the Repository.GetPostsAfter
+type RepositoryMockGetPostsAfterParams struct {
+	ctx     context.Context
+	limit   int
+	afterID int
+}
+
+// RepositoryMockGetPostsAfterParamPtrs contains pointers to parameters of the Repository.GetPostsAfter
+type RepositoryMockGetPostsAfterParamPtrs struct {
+	ctx     *context.Context
+	limit   *int
+	afterID *int
+}
+
+// RepositoryMockGetPostsAfterResults contains results of the Repository.GetPostsAfter
+type RepositoryMockGetPostsAfterResults struct {
+	ppa1 []*domain.Post
+	err  error
+}
+
+// Expect sets up expected params for Repository.GetPostsAfter
+func (mmGetPostsAfter *mRepositoryMockGetPostsAfter) Expect(ctx context.Context, limit int, afterID int) *mRepositoryMockGetPostsAfter {
+	if mmGetPostsAfter.mock.funcGetPostsAfter != nil {
+		mmGetPostsAfter.mock.t.Fatalf("RepositoryMock.GetPostsAfter mock is already set by Set")
+	}
+
+	if mmGetPostsAfter.defaultExpectation == nil {
+		mmGetPostsAfter.defaultExpectation = &RepositoryMockGetPostsAfterExpectation{}
+	}
+
+	if mmGetPostsAfter.defaultExpectation.paramPtrs != nil {
+		mmGetPostsAfter.mock.t.Fatalf("RepositoryMock.GetPostsAfter mock is already set by ExpectParams functions")
+	}
+
+	mmGetPostsAfter.defaultExpectation.params = &RepositoryMockGetPostsAfterParams{ctx, limit, afterID}
+	for _, e := range mmGetPostsAfter.expectations {
+		if minimock.Equal(e.params, mmGetPostsAfter.defaultExpectation.params) {
+			mmGetPostsAfter.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetPostsAfter.defaultExpectation.params)
+		}
+	}
+
+	return mmGetPostsAfter
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetPostsAfter
+func (mmGetPostsAfter *mRepositoryMockGetPostsAfter) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetPostsAfter {
+	if mmGetPostsAfter.mock.funcGetPostsAfter != nil {
+		mmGetPostsAfter.mock.t.Fatalf("RepositoryMock.GetPostsAfter mock is already set by Set")
+	}
+
+	if mmGetPostsAfter.defaultExpectation == nil {
+		mmGetPostsAfter.defaultExpectation = &RepositoryMockGetPostsAfterExpectation{}
+	}
+
+	if mmGetPostsAfter.defaultExpectation.params != nil {
+		mmGetPostsAfter.mock.t.Fatalf("RepositoryMock.GetPostsAfter mock is already set by Expect")
+	}
+
+	if mmGetPostsAfter.defaultExpectation.paramPtrs == nil {
+		mmGetPostsAfter.defaultExpectation.paramPtrs = &RepositoryMockGetPostsAfterParamPtrs{}
+	}
+	mmGetPostsAfter.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetPostsAfter
+}
+
+// ExpectLimitParam2 sets up expected param limit for Repository.GetPostsAfter
+func (mmGetPostsAfter *mRepositoryMockGetPostsAfter) ExpectLimitParam2(limit int) *mRepositoryMockGetPostsAfter {
+	if mmGetPostsAfter.mock.funcGetPostsAfter != nil {
+		mmGetPostsAfter.mock.t.Fatalf("RepositoryMock.GetPostsAfter mock is already set by Set")
+	}
+
+	if mmGetPostsAfter.defaultExpectation == nil {
+		mmGetPostsAfter.defaultExpectation = &RepositoryMockGetPostsAfterExpectation{}
+	}
+
+	if mmGetPostsAfter.defaultExpectation.params != nil {
+		mmGetPostsAfter.mock.t.Fatalf("RepositoryMock.GetPostsAfter mock is already set by Expect")
+	}
+
+	if mmGetPostsAfter.defaultExpectation.paramPtrs == nil {
+		mmGetPostsAfter.defaultExpectation.paramPtrs = &RepositoryMockGetPostsAfterParamPtrs{}
+	}
+	mmGetPostsAfter.defaultExpectation.paramPtrs.limit = &limit
+
+	return mmGetPostsAfter
+}
+
+// ExpectAfterIDParam3 sets up expected param afterID for Repository.GetPostsAfter
+func (mmGetPostsAfter *mRepositoryMockGetPostsAfter) ExpectAfterIDParam3(afterID int) *mRepositoryMockGetPostsAfter {
+	if mmGetPostsAfter.mock.funcGetPostsAfter != nil {
+		mmGetPostsAfter.mock.t.Fatalf("RepositoryMock.GetPostsAfter mock is already set by Set")
+	}
+
+	if mmGetPostsAfter.defaultExpectation == nil {
+		mmGetPostsAfter.defaultExpectation = &RepositoryMockGetPostsAfterExpectation{}
+	}
+
+	if mmGetPostsAfter.defaultExpectation.params != nil {
+		mmGetPostsAfter.mock.t.Fatalf("RepositoryMock.GetPostsAfter mock is already set by Expect")
+	}
+
+	if mmGetPostsAfter.defaultExpectation.paramPtrs == nil {
+		mmGetPostsAfter.defaultExpectation.paramPtrs = &RepositoryMockGetPostsAfterParamPtrs{}
+	}
+	mmGetPostsAfter.defaultExpectation.paramPtrs.afterID = &afterID
+
+	return mmGetPostsAfter
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetPostsAfter
+func (mmGetPostsAfter *mRepositoryMockGetPostsAfter) Inspect(f func(ctx context.Context, limit int, afterID int)) *mRepositoryMockGetPostsAfter {
+	if mmGetPostsAfter.mock.inspectFuncGetPostsAfter != nil {
+		mmGetPostsAfter.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetPostsAfter")
+	}
+
+	mmGetPostsAfter.mock.inspectFuncGetPostsAfter = f
+
+	return mmGetPostsAfter
+}
+
+// Return sets up results that will be returned by Repository.GetPostsAfter
+func (mmGetPostsAfter *mRepositoryMockGetPostsAfter) Return(ppa1 []*domain.Post, err error) *RepositoryMock {
+	if mmGetPostsAfter.mock.funcGetPostsAfter != nil {
+		mmGetPostsAfter.mock.t.Fatalf("RepositoryMock.GetPostsAfter mock is already set by Set")
+	}
+
+	if mmGetPostsAfter.defaultExpectation == nil {
+		mmGetPostsAfter.defaultExpectation = &RepositoryMockGetPostsAfterExpectation{mock: mmGetPostsAfter.mock}
+	}
+	mmGetPostsAfter.defaultExpectation.results = &RepositoryMockGetPostsAfterResults{ppa1, err}
+	return mmGetPostsAfter.mock
+}
+
+// Set uses given function f to mock the Repository.GetPostsAfter method
+func (mmGetPostsAfter *mRepositoryMockGetPostsAfter) Set(f func(ctx context.Context, limit int, afterID int) (ppa1 []*domain.Post, err error)) *RepositoryMock {
+	if mmGetPostsAfter.defaultExpectation != nil {
+		mmGetPostsAfter.mock.t.Fatalf("Default expectation is already set for the Repository.GetPostsAfter method")
+	}
+
+	if len(mmGetPostsAfter.expectations) > 0 {
+		mmGetPostsAfter.mock.t.Fatalf("Some expectations are already set for the Repository.GetPostsAfter method")
+	}
+
+	mmGetPostsAfter.mock.funcGetPostsAfter = f
+	return mmGetPostsAfter.mock
+}
+
+// When sets expectation for the Repository.GetPostsAfter which will trigger the result defined by the following
+// Then helper
+func (mmGetPostsAfter *mRepositoryMockGetPostsAfter) When(ctx context.Context, limit int, afterID int) *RepositoryMockGetPostsAfterExpectation {
+	if mmGetPostsAfter.mock.funcGetPostsAfter != nil {
+		mmGetPostsAfter.mock.t.Fatalf("RepositoryMock.GetPostsAfter mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetPostsAfterExpectation{
+		mock:   mmGetPostsAfter.mock,
+		params: &RepositoryMockGetPostsAfterParams{ctx, limit, afterID},
+	}
+	mmGetPostsAfter.expectations = append(mmGetPostsAfter.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetPostsAfter return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetPostsAfterExpectation) Then(ppa1 []*domain.Post, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetPostsAfterResults{ppa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetPostsAfter should be invoked
+func (mmGetPostsAfter *mRepositoryMockGetPostsAfter) Times(n uint64) *mRepositoryMockGetPostsAfter {
+	if n == 0 {
+		mmGetPostsAfter.mock.t.Fatalf("Times of RepositoryMock.GetPostsAfter mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetPostsAfter.expectedInvocations, n)
+	return mmGetPostsAfter
+}
+
+func (mmGetPostsAfter *mRepositoryMockGetPostsAfter) invocationsDone() bool {
+	if len(mmGetPostsAfter.expectations) == 0 && mmGetPostsAfter.defaultExpectation == nil && mmGetPostsAfter.mock.funcGetPostsAfter == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetPostsAfter.mock.afterGetPostsAfterCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetPostsAfter.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetPostsAfter implements repository.Repository
+func (mmGetPostsAfter *RepositoryMock) GetPostsAfter(ctx context.Context, limit int, afterID int) (ppa1 []*domain.Post, err error) {
+	mm_atomic.AddUint64(&mmGetPostsAfter.beforeGetPostsAfterCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetPostsAfter.afterGetPostsAfterCounter, 1)
+
+	if mmGetPostsAfter.inspectFuncGetPostsAfter != nil {
+		mmGetPostsAfter.inspectFuncGetPostsAfter(ctx, limit, afterID)
+	}
+
+	mm_params := RepositoryMockGetPostsAfterParams{ctx, limit, afterID}
+
+	// Record call args
+	mmGetPostsAfter.GetPostsAfterMock.mutex.Lock()
+	mmGetPostsAfter.GetPostsAfterMock.callArgs = append(mmGetPostsAfter.GetPostsAfterMock.callArgs, &mm_params)
+	mmGetPostsAfter.GetPostsAfterMock.mutex.Unlock()
+
+	for _, e := range mmGetPostsAfter.GetPostsAfterMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.ppa1, e.results.err
+		}
+	}
+
+	if mmGetPostsAfter.GetPostsAfterMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetPostsAfter.GetPostsAfterMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetPostsAfter.GetPostsAfterMock.defaultExpectation.params
+		mm_want_ptrs := mmGetPostsAfter.GetPostsAfterMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetPostsAfterParams{ctx, limit, afterID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetPostsAfter.t.Errorf("RepositoryMock.GetPostsAfter got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmGetPostsAfter.t.Errorf("RepositoryMock.GetPostsAfter got unexpected parameter limit, want: %#v, got: %#v%s\n", *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+			if mm_want_ptrs.afterID != nil && !minimock.Equal(*mm_want_ptrs.afterID, mm_got.afterID) {
+				mmGetPostsAfter.t.Errorf("RepositoryMock.GetPostsAfter got unexpected parameter afterID, want: %#v, got: %#v%s\n", *mm_want_ptrs.afterID, mm_got.afterID, minimock.Diff(*mm_want_ptrs.afterID, mm_got.afterID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetPostsAfter.t.Errorf("RepositoryMock.GetPostsAfter got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetPostsAfter.GetPostsAfterMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetPostsAfter.t.Fatal("No results are set for the RepositoryMock.GetPostsAfter")
+		}
+		return (*mm_results).ppa1, (*mm_results).err
+	}
+	if mmGetPostsAfter.funcGetPostsAfter != nil {
+		return mmGetPostsAfter.funcGetPostsAfter(ctx, limit, afterID)
+	}
+	mmGetPostsAfter.t.Fatalf("Unexpected call to RepositoryMock.GetPostsAfter. %v %v %v", ctx, limit, afterID)
+	return
+}
+
+// GetPostsAfterAfterCounter returns a count of finished RepositoryMock.GetPostsAfter invocations
+func (mmGetPostsAfter *RepositoryMock) GetPostsAfterAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPostsAfter.afterGetPostsAfterCounter)
+}
+
+// GetPostsAfterBeforeCounter returns a count of RepositoryMock.GetPostsAfter invocations
+func (mmGetPostsAfter *RepositoryMock) GetPostsAfterBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetPostsAfter.beforeGetPostsAfterCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetPostsAfter.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetPostsAfter *mRepositoryMockGetPostsAfter) Calls() []*RepositoryMockGetPostsAfterParams {
+	mmGetPostsAfter.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetPostsAfterParams, len(mmGetPostsAfter.callArgs))
+	copy(argCopy, mmGetPostsAfter.callArgs)
+
+	mmGetPostsAfter.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetPostsAfterDone returns true if the count of the GetPostsAfter invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetPostsAfterDone() bool {
+	for _, e := range m.GetPostsAfterMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetPostsAfterMock.invocationsDone()
+}
+
+// MinimockGetPostsAfterInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetPostsAfterInspect() {
+	for _, e := range m.GetPostsAfterMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetPostsAfter with params: %#v", *e.params)
+		}
+	}
+
+	afterGetPostsAfterCounter := mm_atomic.LoadUint64(&m.afterGetPostsAfterCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetPostsAfterMock.defaultExpectation != nil && afterGetPostsAfterCounter < 1 {
+		if m.GetPostsAfterMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetPostsAfter")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetPostsAfter with params: %#v", *m.GetPostsAfterMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetPostsAfter != nil && afterGetPostsAfterCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetPostsAfter")
+	}
+
+	if !m.GetPostsAfterMock.invocationsDone() && afterGetPostsAfterCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetPostsAfter but found %d calls",
+			mm_atomic.LoadUint64(&m.GetPostsAfterMock.expectedInvocations), afterGetPostsAfterCounter)
+	}
+}
+
+type mRepositoryMockGetReport struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetReportExpectation
+	expectations       []*RepositoryMockGetReportExpectation
+
+	callArgs []*RepositoryMockGetReportParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetReportExpectation specifies expectation struct of the Repository.GetReport
+type RepositoryMockGetReportExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetReportParams
+	paramPtrs *RepositoryMockGetReportParamPtrs
+	results   *RepositoryMockGetReportResults
+	Counter   uint64
+}
+
+// RepositoryMockGetReportParams contains parameters of the Repository.GetReport
+type RepositoryMockGetReportParams struct {
+	ctx context.Context
+	id  int
+}
+
+// RepositoryMockGetReportParamPtrs contains pointers to parameters of the Repository.GetReport
+type RepositoryMockGetReportParamPtrs struct {
+	ctx *context.Context
+	id  *int
+}
+
+// RepositoryMockGetReportResults contains results of the Repository.GetReport
+type RepositoryMockGetReportResults struct {
+	rp1 *domain.Report
+	err error
+}
+
+// Expect sets up expected params for Repository.GetReport
+func (mmGetReport *mRepositoryMockGetReport) Expect(ctx context.Context, id int) *mRepositoryMockGetReport {
+	if mmGetReport.mock.funcGetReport != nil {
+		mmGetReport.mock.t.Fatalf("RepositoryMock.GetReport mock is already set by Set")
+	}
+
+	if mmGetReport.defaultExpectation == nil {
+		mmGetReport.defaultExpectation = &RepositoryMockGetReportExpectation{}
+	}
+
+	if mmGetReport.defaultExpectation.paramPtrs != nil {
+		mmGetReport.mock.t.Fatalf("RepositoryMock.GetReport mock is already set by ExpectParams functions")
+	}
+
+	mmGetReport.defaultExpectation.params = &RepositoryMockGetReportParams{ctx, id}
+	for _, e := range mmGetReport.expectations {
+		if minimock.Equal(e.params, mmGetReport.defaultExpectation.params) {
+			mmGetReport.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetReport.defaultExpectation.params)
+		}
+	}
+
+	return mmGetReport
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetReport
+func (mmGetReport *mRepositoryMockGetReport) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetReport {
+	if mmGetReport.mock.funcGetReport != nil {
+		mmGetReport.mock.t.Fatalf("RepositoryMock.GetReport mock is already set by Set")
+	}
+
+	if mmGetReport.defaultExpectation == nil {
+		mmGetReport.defaultExpectation = &RepositoryMockGetReportExpectation{}
+	}
+
+	if mmGetReport.defaultExpectation.params != nil {
+		mmGetReport.mock.t.Fatalf("RepositoryMock.GetReport mock is already set by Expect")
+	}
+
+	if mmGetReport.defaultExpectation.paramPtrs == nil {
+		mmGetReport.defaultExpectation.paramPtrs = &RepositoryMockGetReportParamPtrs{}
+	}
+	mmGetReport.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetReport
+}
+
+// ExpectIdParam2 sets up expected param id for Repository.GetReport
+func (mmGetReport *mRepositoryMockGetReport) ExpectIdParam2(id int) *mRepositoryMockGetReport {
+	if mmGetReport.mock.funcGetReport != nil {
+		mmGetReport.mock.t.Fatalf("RepositoryMock.GetReport mock is already set by Set")
+	}
+
+	if mmGetReport.defaultExpectation == nil {
+		mmGetReport.defaultExpectation = &RepositoryMockGetReportExpectation{}
+	}
+
+	if mmGetReport.defaultExpectation.params != nil {
+		mmGetReport.mock.t.Fatalf("RepositoryMock.GetReport mock is already set by Expect")
+	}
+
+	if mmGetReport.defaultExpectation.paramPtrs == nil {
+		mmGetReport.defaultExpectation.paramPtrs = &RepositoryMockGetReportParamPtrs{}
+	}
+	mmGetReport.defaultExpectation.paramPtrs.id = &id
+
+	return mmGetReport
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetReport
+func (mmGetReport *mRepositoryMockGetReport) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockGetReport {
+	if mmGetReport.mock.inspectFuncGetReport != nil {
+		mmGetReport.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetReport")
+	}
+
+	mmGetReport.mock.inspectFuncGetReport = f
+
+	return mmGetReport
+}
+
+// Return sets up results that will be returned by Repository.GetReport
+func (mmGetReport *mRepositoryMockGetReport) Return(rp1 *domain.Report, err error) *RepositoryMock {
+	if mmGetReport.mock.funcGetReport != nil {
+		mmGetReport.mock.t.Fatalf("RepositoryMock.GetReport mock is already set by Set")
+	}
+
+	if mmGetReport.defaultExpectation == nil {
+		mmGetReport.defaultExpectation = &RepositoryMockGetReportExpectation{mock: mmGetReport.mock}
+	}
+	mmGetReport.defaultExpectation.results = &RepositoryMockGetReportResults{rp1, err}
+	return mmGetReport.mock
+}
+
+// Set uses given function f to mock the Repository.GetReport method
+func (mmGetReport *mRepositoryMockGetReport) Set(f func(ctx context.Context, id int) (rp1 *domain.Report, err error)) *RepositoryMock {
+	if mmGetReport.defaultExpectation != nil {
+		mmGetReport.mock.t.Fatalf("Default expectation is already set for the Repository.GetReport method")
+	}
+
+	if len(mmGetReport.expectations) > 0 {
+		mmGetReport.mock.t.Fatalf("Some expectations are already set for the Repository.GetReport method")
+	}
+
+	mmGetReport.mock.funcGetReport = f
+	return mmGetReport.mock
+}
+
+// When sets expectation for the Repository.GetReport which will trigger the result defined by the following
+// Then helper
+func (mmGetReport *mRepositoryMockGetReport) When(ctx context.Context, id int) *RepositoryMockGetReportExpectation {
+	if mmGetReport.mock.funcGetReport != nil {
+		mmGetReport.mock.t.Fatalf("RepositoryMock.GetReport mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetReportExpectation{
+		mock:   mmGetReport.mock,
+		params: &RepositoryMockGetReportParams{ctx, id},
+	}
+	mmGetReport.expectations = append(mmGetReport.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetReport return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetReportExpectation) Then(rp1 *domain.Report, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetReportResults{rp1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetReport should be invoked
+func (mmGetReport *mRepositoryMockGetReport) Times(n uint64) *mRepositoryMockGetReport {
+	if n == 0 {
+		mmGetReport.mock.t.Fatalf("Times of RepositoryMock.GetReport mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetReport.expectedInvocations, n)
+	return mmGetReport
+}
+
+func (mmGetReport *mRepositoryMockGetReport) invocationsDone() bool {
+	if len(mmGetReport.expectations) == 0 && mmGetReport.defaultExpectation == nil && mmGetReport.mock.funcGetReport == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetReport.mock.afterGetReportCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetReport.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetReport implements repository.Repository
+func (mmGetReport *RepositoryMock) GetReport(ctx context.Context, id int) (rp1 *domain.Report, err error) {
+	mm_atomic.AddUint64(&mmGetReport.beforeGetReportCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetReport.afterGetReportCounter, 1)
+
+	if mmGetReport.inspectFuncGetReport != nil {
+		mmGetReport.inspectFuncGetReport(ctx, id)
+	}
+
+	mm_params := RepositoryMockGetReportParams{ctx, id}
+
+	// Record call args
+	mmGetReport.GetReportMock.mutex.Lock()
+	mmGetReport.GetReportMock.callArgs = append(mmGetReport.GetReportMock.callArgs, &mm_params)
+	mmGetReport.GetReportMock.mutex.Unlock()
+
+	for _, e := range mmGetReport.GetReportMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.rp1, e.results.err
+		}
+	}
+
+	if mmGetReport.GetReportMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetReport.GetReportMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetReport.GetReportMock.defaultExpectation.params
+		mm_want_ptrs := mmGetReport.GetReportMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetReportParams{ctx, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetReport.t.Errorf("RepositoryMock.GetReport got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmGetReport.t.Errorf("RepositoryMock.GetReport got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetReport.t.Errorf("RepositoryMock.GetReport got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetReport.GetReportMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetReport.t.Fatal("No results are set for the RepositoryMock.GetReport")
+		}
+		return (*mm_results).rp1, (*mm_results).err
+	}
+	if mmGetReport.funcGetReport != nil {
+		return mmGetReport.funcGetReport(ctx, id)
+	}
+	mmGetReport.t.Fatalf("Unexpected call to RepositoryMock.GetReport. %v %v", ctx, id)
+	return
+}
+
+// GetReportAfterCounter returns a count of finished RepositoryMock.GetReport invocations
+func (mmGetReport *RepositoryMock) GetReportAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetReport.afterGetReportCounter)
+}
+
+// GetReportBeforeCounter returns a count of RepositoryMock.GetReport invocations
+func (mmGetReport *RepositoryMock) GetReportBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetReport.beforeGetReportCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetReport.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetReport *mRepositoryMockGetReport) Calls() []*RepositoryMockGetReportParams {
+	mmGetReport.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetReportParams, len(mmGetReport.callArgs))
+	copy(argCopy, mmGetReport.callArgs)
+
+	mmGetReport.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetReportDone returns true if the count of the GetReport invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetReportDone() bool {
+	for _, e := range m.GetReportMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetReportMock.invocationsDone()
+}
+
+// MinimockGetReportInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetReportInspect() {
+	for _, e := range m.GetReportMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetReport with params: %#v", *e.params)
+		}
+	}
+
+	afterGetReportCounter := mm_atomic.LoadUint64(&m.afterGetReportCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetReportMock.defaultExpectation != nil && afterGetReportCounter < 1 {
+		if m.GetReportMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetReport")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetReport with params: %#v", *m.GetReportMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetReport != nil && afterGetReportCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetReport")
+	}
+
+	if !m.GetReportMock.invocationsDone() && afterGetReportCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetReport but found %d calls",
+			mm_atomic.LoadUint64(&m.GetReportMock.expectedInvocations), afterGetReportCounter)
+	}
+}
+
+type mRepositoryMockGetReports struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetReportsExpectation
+	expectations       []*RepositoryMockGetReportsExpectation
+
+	callArgs []*RepositoryMockGetReportsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetReportsExpectation specifies expectation struct of the Repository.GetReports
+type RepositoryMockGetReportsExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetReportsParams
+	paramPtrs *RepositoryMockGetReportsParamPtrs
+	results   *RepositoryMockGetReportsResults
+	Counter   uint64
+}
+
+// RepositoryMockGetReportsParams contains parameters of the Repository.GetReports
+type RepositoryMockGetReportsParams struct {
+	ctx     context.Context
+	status  domain.ReportStatus
+	limit   int
+	afterID int
+}
+
+// RepositoryMockGetReportsParamPtrs contains pointers to parameters of the Repository.GetReports
+type RepositoryMockGetReportsParamPtrs struct {
+	ctx     *context.Context
+	status  *domain.ReportStatus
+	limit   *int
+	afterID *int
+}
+
+// RepositoryMockGetReportsResults contains results of the Repository.GetReports
+type RepositoryMockGetReportsResults struct {
+	rpa1 []*domain.Report
+	err  error
+}
+
+// Expect sets up expected params for Repository.GetReports
+func (mmGetReports *mRepositoryMockGetReports) Expect(ctx context.Context, status domain.ReportStatus, limit int, afterID int) *mRepositoryMockGetReports {
+	if mmGetReports.mock.funcGetReports != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by Set")
+	}
+
+	if mmGetReports.defaultExpectation == nil {
+		mmGetReports.defaultExpectation = &RepositoryMockGetReportsExpectation{}
+	}
+
+	if mmGetReports.defaultExpectation.paramPtrs != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by ExpectParams functions")
+	}
+
+	mmGetReports.defaultExpectation.params = &RepositoryMockGetReportsParams{ctx, status, limit, afterID}
+	for _, e := range mmGetReports.expectations {
+		if minimock.Equal(e.params, mmGetReports.defaultExpectation.params) {
+			mmGetReports.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetReports.defaultExpectation.params)
+		}
+	}
+
+	return mmGetReports
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetReports
+func (mmGetReports *mRepositoryMockGetReports) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetReports {
+	if mmGetReports.mock.funcGetReports != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by Set")
+	}
+
+	if mmGetReports.defaultExpectation == nil {
+		mmGetReports.defaultExpectation = &RepositoryMockGetReportsExpectation{}
+	}
+
+	if mmGetReports.defaultExpectation.params != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by Expect")
+	}
+
+	if mmGetReports.defaultExpectation.paramPtrs == nil {
+		mmGetReports.defaultExpectation.paramPtrs = &RepositoryMockGetReportsParamPtrs{}
+	}
+	mmGetReports.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetReports
+}
+
+// ExpectStatusParam2 sets up expected param status for Repository.GetReports
+func (mmGetReports *mRepositoryMockGetReports) ExpectStatusParam2(status domain.ReportStatus) *mRepositoryMockGetReports {
+	if mmGetReports.mock.funcGetReports != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by Set")
+	}
+
+	if mmGetReports.defaultExpectation == nil {
+		mmGetReports.defaultExpectation = &RepositoryMockGetReportsExpectation{}
+	}
+
+	if mmGetReports.defaultExpectation.params != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by Expect")
+	}
+
+	if mmGetReports.defaultExpectation.paramPtrs == nil {
+		mmGetReports.defaultExpectation.paramPtrs = &RepositoryMockGetReportsParamPtrs{}
+	}
+	mmGetReports.defaultExpectation.paramPtrs.status = &status
+
+	return mmGetReports
+}
+
+// ExpectLimitParam3 sets up expected param limit for Repository.GetReports
+func (mmGetReports *mRepositoryMockGetReports) ExpectLimitParam3(limit int) *mRepositoryMockGetReports {
+	if mmGetReports.mock.funcGetReports != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by Set")
+	}
+
+	if mmGetReports.defaultExpectation == nil {
+		mmGetReports.defaultExpectation = &RepositoryMockGetReportsExpectation{}
+	}
+
+	if mmGetReports.defaultExpectation.params != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by Expect")
+	}
+
+	if mmGetReports.defaultExpectation.paramPtrs == nil {
+		mmGetReports.defaultExpectation.paramPtrs = &RepositoryMockGetReportsParamPtrs{}
+	}
+	mmGetReports.defaultExpectation.paramPtrs.limit = &limit
+
+	return mmGetReports
+}
+
+// ExpectAfterIDParam4 sets up expected param afterID for Repository.GetReports
+func (mmGetReports *mRepositoryMockGetReports) ExpectAfterIDParam4(afterID int) *mRepositoryMockGetReports {
+	if mmGetReports.mock.funcGetReports != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by Set")
+	}
+
+	if mmGetReports.defaultExpectation == nil {
+		mmGetReports.defaultExpectation = &RepositoryMockGetReportsExpectation{}
+	}
+
+	if mmGetReports.defaultExpectation.params != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by Expect")
+	}
+
+	if mmGetReports.defaultExpectation.paramPtrs == nil {
+		mmGetReports.defaultExpectation.paramPtrs = &RepositoryMockGetReportsParamPtrs{}
+	}
+	mmGetReports.defaultExpectation.paramPtrs.afterID = &afterID
+
+	return mmGetReports
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetReports
+func (mmGetReports *mRepositoryMockGetReports) Inspect(f func(ctx context.Context, status domain.ReportStatus, limit int, afterID int)) *mRepositoryMockGetReports {
+	if mmGetReports.mock.inspectFuncGetReports != nil {
+		mmGetReports.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetReports")
+	}
+
+	mmGetReports.mock.inspectFuncGetReports = f
+
+	return mmGetReports
+}
+
+// Return sets up results that will be returned by Repository.GetReports
+func (mmGetReports *mRepositoryMockGetReports) Return(rpa1 []*domain.Report, err error) *RepositoryMock {
+	if mmGetReports.mock.funcGetReports != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by Set")
+	}
+
+	if mmGetReports.defaultExpectation == nil {
+		mmGetReports.defaultExpectation = &RepositoryMockGetReportsExpectation{mock: mmGetReports.mock}
+	}
+	mmGetReports.defaultExpectation.results = &RepositoryMockGetReportsResults{rpa1, err}
+	return mmGetReports.mock
+}
+
+// Set uses given function f to mock the Repository.GetReports method
+func (mmGetReports *mRepositoryMockGetReports) Set(f func(ctx context.Context, status domain.ReportStatus, limit int, afterID int) (rpa1 []*domain.Report, err error)) *RepositoryMock {
+	if mmGetReports.defaultExpectation != nil {
+		mmGetReports.mock.t.Fatalf("Default expectation is already set for the Repository.GetReports method")
+	}
+
+	if len(mmGetReports.expectations) > 0 {
+		mmGetReports.mock.t.Fatalf("Some expectations are already set for the Repository.GetReports method")
+	}
+
+	mmGetReports.mock.funcGetReports = f
+	return mmGetReports.mock
+}
+
+// When sets expectation for the Repository.GetReports which will trigger the result defined by the following
+// Then helper
+func (mmGetReports *mRepositoryMockGetReports) When(ctx context.Context, status domain.ReportStatus, limit int, afterID int) *RepositoryMockGetReportsExpectation {
+	if mmGetReports.mock.funcGetReports != nil {
+		mmGetReports.mock.t.Fatalf("RepositoryMock.GetReports mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetReportsExpectation{
+		mock:   mmGetReports.mock,
+		params: &RepositoryMockGetReportsParams{ctx, status, limit, afterID},
+	}
+	mmGetReports.expectations = append(mmGetReports.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetReports return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetReportsExpectation) Then(rpa1 []*domain.Report, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetReportsResults{rpa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetReports should be invoked
+func (mmGetReports *mRepositoryMockGetReports) Times(n uint64) *mRepositoryMockGetReports {
+	if n == 0 {
+		mmGetReports.mock.t.Fatalf("Times of RepositoryMock.GetReports mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetReports.expectedInvocations, n)
+	return mmGetReports
+}
+
+func (mmGetReports *mRepositoryMockGetReports) invocationsDone() bool {
+	if len(mmGetReports.expectations) == 0 && mmGetReports.defaultExpectation == nil && mmGetReports.mock.funcGetReports == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetReports.mock.afterGetReportsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetReports.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetReports implements repository.Repository
+func (mmGetReports *RepositoryMock) GetReports(ctx context.Context, status domain.ReportStatus, limit int, afterID int) (rpa1 []*domain.Report, err error) {
+	mm_atomic.AddUint64(&mmGetReports.beforeGetReportsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetReports.afterGetReportsCounter, 1)
+
+	if mmGetReports.inspectFuncGetReports != nil {
+		mmGetReports.inspectFuncGetReports(ctx, status, limit, afterID)
+	}
+
+	mm_params := RepositoryMockGetReportsParams{ctx, status, limit, afterID}
+
+	// Record call args
+	mmGetReports.GetReportsMock.mutex.Lock()
+	mmGetReports.GetReportsMock.callArgs = append(mmGetReports.GetReportsMock.callArgs, &mm_params)
+	mmGetReports.GetReportsMock.mutex.Unlock()
+
+	for _, e := range mmGetReports.GetReportsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.rpa1, e.results.err
+		}
+	}
+
+	if mmGetReports.GetReportsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetReports.GetReportsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetReports.GetReportsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetReports.GetReportsMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetReportsParams{ctx, status, limit, afterID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetReports.t.Errorf("RepositoryMock.GetReports got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.status != nil && !minimock.Equal(*mm_want_ptrs.status, mm_got.status) {
+				mmGetReports.t.Errorf("RepositoryMock.GetReports got unexpected parameter status, want: %#v, got: %#v%s\n", *mm_want_ptrs.status, mm_got.status, minimock.Diff(*mm_want_ptrs.status, mm_got.status))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmGetReports.t.Errorf("RepositoryMock.GetReports got unexpected parameter limit, want: %#v, got: %#v%s\n", *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+			if mm_want_ptrs.afterID != nil && !minimock.Equal(*mm_want_ptrs.afterID, mm_got.afterID) {
+				mmGetReports.t.Errorf("RepositoryMock.GetReports got unexpected parameter afterID, want: %#v, got: %#v%s\n", *mm_want_ptrs.afterID, mm_got.afterID, minimock.Diff(*mm_want_ptrs.afterID, mm_got.afterID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetReports.t.Errorf("RepositoryMock.GetReports got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetReports.GetReportsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetReports.t.Fatal("No results are set for the RepositoryMock.GetReports")
+		}
+		return (*mm_results).rpa1, (*mm_results).err
+	}
+	if mmGetReports.funcGetReports != nil {
+		return mmGetReports.funcGetReports(ctx, status, limit, afterID)
+	}
+	mmGetReports.t.Fatalf("Unexpected call to RepositoryMock.GetReports. %v %v %v %v", ctx, status, limit, afterID)
+	return
+}
+
+// GetReportsAfterCounter returns a count of finished RepositoryMock.GetReports invocations
+func (mmGetReports *RepositoryMock) GetReportsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetReports.afterGetReportsCounter)
+}
+
+// GetReportsBeforeCounter returns a count of RepositoryMock.GetReports invocations
+func (mmGetReports *RepositoryMock) GetReportsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetReports.beforeGetReportsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetReports.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetReports *mRepositoryMockGetReports) Calls() []*RepositoryMockGetReportsParams {
+	mmGetReports.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetReportsParams, len(mmGetReports.callArgs))
+	copy(argCopy, mmGetReports.callArgs)
+
+	mmGetReports.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetReportsDone returns true if the count of the GetReports invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetReportsDone() bool {
+	for _, e := range m.GetReportsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetReportsMock.invocationsDone()
+}
+
+// MinimockGetReportsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetReportsInspect() {
+	for _, e := range m.GetReportsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetReports with params: %#v", *e.params)
+		}
+	}
+
+	afterGetReportsCounter := mm_atomic.LoadUint64(&m.afterGetReportsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetReportsMock.defaultExpectation != nil && afterGetReportsCounter < 1 {
+		if m.GetReportsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetReports")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetReports with params: %#v", *m.GetReportsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetReports != nil && afterGetReportsCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetReports")
+	}
+
+	if !m.GetReportsMock.invocationsDone() && afterGetReportsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetReports but found %d calls",
+			mm_atomic.LoadUint64(&m.GetReportsMock.expectedInvocations), afterGetReportsCounter)
+	}
+}
+
+type mRepositoryMockGetWebhook struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetWebhookExpectation
+	expectations       []*RepositoryMockGetWebhookExpectation
+
+	callArgs []*RepositoryMockGetWebhookParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetWebhookExpectation specifies expectation struct of the Repository.GetWebhook
+type RepositoryMockGetWebhookExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetWebhookParams
+	paramPtrs *RepositoryMockGetWebhookParamPtrs
+	results   *RepositoryMockGetWebhookResults
+	Counter   uint64
+}
+
+// RepositoryMockGetWebhookParams contains parameters of the Repository.GetWebhook
+type RepositoryMockGetWebhookParams struct {
+	ctx context.Context
+	id  int
+}
+
+// RepositoryMockGetWebhookParamPtrs contains pointers to parameters of the Repository.GetWebhook
+type RepositoryMockGetWebhookParamPtrs struct {
+	ctx *context.Context
+	id  *int
+}
+
+// RepositoryMockGetWebhookResults contains results of the Repository.GetWebhook
+type RepositoryMockGetWebhookResults struct {
+	wp1 *domain.Webhook
+	err error
+}
+
+// Expect sets up expected params for Repository.GetWebhook
+func (mmGetWebhook *mRepositoryMockGetWebhook) Expect(ctx context.Context, id int) *mRepositoryMockGetWebhook {
+	if mmGetWebhook.mock.funcGetWebhook != nil {
+		mmGetWebhook.mock.t.Fatalf("RepositoryMock.GetWebhook mock is already set by Set")
+	}
+
+	if mmGetWebhook.defaultExpectation == nil {
+		mmGetWebhook.defaultExpectation = &RepositoryMockGetWebhookExpectation{}
+	}
+
+	if mmGetWebhook.defaultExpectation.paramPtrs != nil {
+		mmGetWebhook.mock.t.Fatalf("RepositoryMock.GetWebhook mock is already set by ExpectParams functions")
+	}
+
+	mmGetWebhook.defaultExpectation.params = &RepositoryMockGetWebhookParams{ctx, id}
+	for _, e := range mmGetWebhook.expectations {
+		if minimock.Equal(e.params, mmGetWebhook.defaultExpectation.params) {
+			mmGetWebhook.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetWebhook.defaultExpectation.params)
+		}
+	}
+
+	return mmGetWebhook
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetWebhook
+func (mmGetWebhook *mRepositoryMockGetWebhook) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetWebhook {
+	if mmGetWebhook.mock.funcGetWebhook != nil {
+		mmGetWebhook.mock.t.Fatalf("RepositoryMock.GetWebhook mock is already set by Set")
+	}
+
+	if mmGetWebhook.defaultExpectation == nil {
+		mmGetWebhook.defaultExpectation = &RepositoryMockGetWebhookExpectation{}
+	}
+
+	if mmGetWebhook.defaultExpectation.params != nil {
+		mmGetWebhook.mock.t.Fatalf("RepositoryMock.GetWebhook mock is already set by Expect")
+	}
+
+	if mmGetWebhook.defaultExpectation.paramPtrs == nil {
+		mmGetWebhook.defaultExpectation.paramPtrs = &RepositoryMockGetWebhookParamPtrs{}
+	}
+	mmGetWebhook.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetWebhook
+}
+
+// ExpectIdParam2 sets up expected param id for Repository.GetWebhook
+func (mmGetWebhook *mRepositoryMockGetWebhook) ExpectIdParam2(id int) *mRepositoryMockGetWebhook {
+	if mmGetWebhook.mock.funcGetWebhook != nil {
+		mmGetWebhook.mock.t.Fatalf("RepositoryMock.GetWebhook mock is already set by Set")
+	}
+
+	if mmGetWebhook.defaultExpectation == nil {
+		mmGetWebhook.defaultExpectation = &RepositoryMockGetWebhookExpectation{}
+	}
+
+	if mmGetWebhook.defaultExpectation.params != nil {
+		mmGetWebhook.mock.t.Fatalf("RepositoryMock.GetWebhook mock is already set by Expect")
+	}
+
+	if mmGetWebhook.defaultExpectation.paramPtrs == nil {
+		mmGetWebhook.defaultExpectation.paramPtrs = &RepositoryMockGetWebhookParamPtrs{}
+	}
+	mmGetWebhook.defaultExpectation.paramPtrs.id = &id
+
+	return mmGetWebhook
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetWebhook
+func (mmGetWebhook *mRepositoryMockGetWebhook) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockGetWebhook {
+	if mmGetWebhook.mock.inspectFuncGetWebhook != nil {
+		mmGetWebhook.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetWebhook")
+	}
+
+	mmGetWebhook.mock.inspectFuncGetWebhook = f
+
+	return mmGetWebhook
+}
+
+// Return sets up results that will be returned by Repository.GetWebhook
+func (mmGetWebhook *mRepositoryMockGetWebhook) Return(wp1 *domain.Webhook, err error) *RepositoryMock {
+	if mmGetWebhook.mock.funcGetWebhook != nil {
+		mmGetWebhook.mock.t.Fatalf("RepositoryMock.GetWebhook mock is already set by Set")
+	}
+
+	if mmGetWebhook.defaultExpectation == nil {
+		mmGetWebhook.defaultExpectation = &RepositoryMockGetWebhookExpectation{mock: mmGetWebhook.mock}
+	}
+	mmGetWebhook.defaultExpectation.results = &RepositoryMockGetWebhookResults{wp1, err}
+	return mmGetWebhook.mock
+}
+
+// Set uses given function f to mock the Repository.GetWebhook method
+func (mmGetWebhook *mRepositoryMockGetWebhook) Set(f func(ctx context.Context, id int) (wp1 *domain.Webhook, err error)) *RepositoryMock {
+	if mmGetWebhook.defaultExpectation != nil {
+		mmGetWebhook.mock.t.Fatalf("Default expectation is already set for the Repository.GetWebhook method")
+	}
+
+	if len(mmGetWebhook.expectations) > 0 {
+		mmGetWebhook.mock.t.Fatalf("Some expectations are already set for the Repository.GetWebhook method")
+	}
+
+	mmGetWebhook.mock.funcGetWebhook = f
+	return mmGetWebhook.mock
+}
+
+// When sets expectation for the Repository.GetWebhook which will trigger the result defined by the following
+// Then helper
+func (mmGetWebhook *mRepositoryMockGetWebhook) When(ctx context.Context, id int) *RepositoryMockGetWebhookExpectation {
+	if mmGetWebhook.mock.funcGetWebhook != nil {
+		mmGetWebhook.mock.t.Fatalf("RepositoryMock.GetWebhook mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetWebhookExpectation{
+		mock:   mmGetWebhook.mock,
+		params: &RepositoryMockGetWebhookParams{ctx, id},
+	}
+	mmGetWebhook.expectations = append(mmGetWebhook.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetWebhook return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetWebhookExpectation) Then(wp1 *domain.Webhook, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetWebhookResults{wp1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetWebhook should be invoked
+func (mmGetWebhook *mRepositoryMockGetWebhook) Times(n uint64) *mRepositoryMockGetWebhook {
+	if n == 0 {
+		mmGetWebhook.mock.t.Fatalf("Times of RepositoryMock.GetWebhook mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetWebhook.expectedInvocations, n)
+	return mmGetWebhook
+}
+
+func (mmGetWebhook *mRepositoryMockGetWebhook) invocationsDone() bool {
+	if len(mmGetWebhook.expectations) == 0 && mmGetWebhook.defaultExpectation == nil && mmGetWebhook.mock.funcGetWebhook == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetWebhook.mock.afterGetWebhookCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetWebhook.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetWebhook implements repository.Repository
+func (mmGetWebhook *RepositoryMock) GetWebhook(ctx context.Context, id int) (wp1 *domain.Webhook, err error) {
+	mm_atomic.AddUint64(&mmGetWebhook.beforeGetWebhookCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetWebhook.afterGetWebhookCounter, 1)
+
+	if mmGetWebhook.inspectFuncGetWebhook != nil {
+		mmGetWebhook.inspectFuncGetWebhook(ctx, id)
+	}
+
+	mm_params := RepositoryMockGetWebhookParams{ctx, id}
+
+	// Record call args
+	mmGetWebhook.GetWebhookMock.mutex.Lock()
+	mmGetWebhook.GetWebhookMock.callArgs = append(mmGetWebhook.GetWebhookMock.callArgs, &mm_params)
+	mmGetWebhook.GetWebhookMock.mutex.Unlock()
+
+	for _, e := range mmGetWebhook.GetWebhookMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.wp1, e.results.err
+		}
+	}
+
+	if mmGetWebhook.GetWebhookMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetWebhook.GetWebhookMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetWebhook.GetWebhookMock.defaultExpectation.params
+		mm_want_ptrs := mmGetWebhook.GetWebhookMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetWebhookParams{ctx, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetWebhook.t.Errorf("RepositoryMock.GetWebhook got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmGetWebhook.t.Errorf("RepositoryMock.GetWebhook got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetWebhook.t.Errorf("RepositoryMock.GetWebhook got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetWebhook.GetWebhookMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetWebhook.t.Fatal("No results are set for the RepositoryMock.GetWebhook")
+		}
+		return (*mm_results).wp1, (*mm_results).err
+	}
+	if mmGetWebhook.funcGetWebhook != nil {
+		return mmGetWebhook.funcGetWebhook(ctx, id)
+	}
+	mmGetWebhook.t.Fatalf("Unexpected call to RepositoryMock.GetWebhook. %v %v", ctx, id)
+	return
+}
+
+// GetWebhookAfterCounter returns a count of finished RepositoryMock.GetWebhook invocations
+func (mmGetWebhook *RepositoryMock) GetWebhookAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetWebhook.afterGetWebhookCounter)
+}
+
+// GetWebhookBeforeCounter returns a count of RepositoryMock.GetWebhook invocations
+func (mmGetWebhook *RepositoryMock) GetWebhookBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetWebhook.beforeGetWebhookCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetWebhook.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetWebhook *mRepositoryMockGetWebhook) Calls() []*RepositoryMockGetWebhookParams {
+	mmGetWebhook.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetWebhookParams, len(mmGetWebhook.callArgs))
+	copy(argCopy, mmGetWebhook.callArgs)
+
+	mmGetWebhook.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetWebhookDone returns true if the count of the GetWebhook invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetWebhookDone() bool {
+	for _, e := range m.GetWebhookMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetWebhookMock.invocationsDone()
+}
+
+// MinimockGetWebhookInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetWebhookInspect() {
+	for _, e := range m.GetWebhookMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetWebhook with params: %#v", *e.params)
+		}
+	}
+
+	afterGetWebhookCounter := mm_atomic.LoadUint64(&m.afterGetWebhookCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetWebhookMock.defaultExpectation != nil && afterGetWebhookCounter < 1 {
+		if m.GetWebhookMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetWebhook")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetWebhook with params: %#v", *m.GetWebhookMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetWebhook != nil && afterGetWebhookCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetWebhook")
+	}
+
+	if !m.GetWebhookMock.invocationsDone() && afterGetWebhookCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetWebhook but found %d calls",
+			mm_atomic.LoadUint64(&m.GetWebhookMock.expectedInvocations), afterGetWebhookCounter)
+	}
+}
+
+type mRepositoryMockGetWebhookDeliveries struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetWebhookDeliveriesExpectation
+	expectations       []*RepositoryMockGetWebhookDeliveriesExpectation
+
+	callArgs []*RepositoryMockGetWebhookDeliveriesParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetWebhookDeliveriesExpectation specifies expectation struct of the Repository.GetWebhookDeliveries
+type RepositoryMockGetWebhookDeliveriesExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetWebhookDeliveriesParams
+	paramPtrs *RepositoryMockGetWebhookDeliveriesParamPtrs
+	results   *RepositoryMockGetWebhookDeliveriesResults
+	Counter   uint64
+}
+
+// RepositoryMockGetWebhookDeliveriesParams contains parameters of the Repository.GetWebhookDeliveries
+type RepositoryMockGetWebhookDeliveriesParams struct {
+	ctx       context.Context
+	webhookID int
+	limit     int
+	offset    int
+}
+
+// RepositoryMockGetWebhookDeliveriesParamPtrs contains pointers to parameters of the Repository.GetWebhookDeliveries
+type RepositoryMockGetWebhookDeliveriesParamPtrs struct {
+	ctx       *context.Context
+	webhookID *int
+	limit     *int
+	offset    *int
+}
+
+// RepositoryMockGetWebhookDeliveriesResults contains results of the Repository.GetWebhookDeliveries
+type RepositoryMockGetWebhookDeliveriesResults struct {
+	wpa1 []*domain.WebhookDelivery
+	err  error
+}
+
+// Expect sets up expected params for Repository.GetWebhookDeliveries
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) Expect(ctx context.Context, webhookID int, limit int, offset int) *mRepositoryMockGetWebhookDeliveries {
+	if mmGetWebhookDeliveries.mock.funcGetWebhookDeliveries != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation == nil {
+		mmGetWebhookDeliveries.defaultExpectation = &RepositoryMockGetWebhookDeliveriesExpectation{}
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation.paramPtrs != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by ExpectParams functions")
+	}
+
+	mmGetWebhookDeliveries.defaultExpectation.params = &RepositoryMockGetWebhookDeliveriesParams{ctx, webhookID, limit, offset}
+	for _, e := range mmGetWebhookDeliveries.expectations {
+		if minimock.Equal(e.params, mmGetWebhookDeliveries.defaultExpectation.params) {
+			mmGetWebhookDeliveries.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetWebhookDeliveries.defaultExpectation.params)
+		}
+	}
+
+	return mmGetWebhookDeliveries
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetWebhookDeliveries
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetWebhookDeliveries {
+	if mmGetWebhookDeliveries.mock.funcGetWebhookDeliveries != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation == nil {
+		mmGetWebhookDeliveries.defaultExpectation = &RepositoryMockGetWebhookDeliveriesExpectation{}
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation.params != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by Expect")
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation.paramPtrs == nil {
+		mmGetWebhookDeliveries.defaultExpectation.paramPtrs = &RepositoryMockGetWebhookDeliveriesParamPtrs{}
+	}
+	mmGetWebhookDeliveries.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetWebhookDeliveries
+}
+
+// ExpectWebhookIDParam2 sets up expected param webhookID for Repository.GetWebhookDeliveries
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) ExpectWebhookIDParam2(webhookID int) *mRepositoryMockGetWebhookDeliveries {
+	if mmGetWebhookDeliveries.mock.funcGetWebhookDeliveries != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation == nil {
+		mmGetWebhookDeliveries.defaultExpectation = &RepositoryMockGetWebhookDeliveriesExpectation{}
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation.params != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by Expect")
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation.paramPtrs == nil {
+		mmGetWebhookDeliveries.defaultExpectation.paramPtrs = &RepositoryMockGetWebhookDeliveriesParamPtrs{}
+	}
+	mmGetWebhookDeliveries.defaultExpectation.paramPtrs.webhookID = &webhookID
+
+	return mmGetWebhookDeliveries
+}
+
+// ExpectLimitParam3 sets up expected param limit for Repository.GetWebhookDeliveries
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) ExpectLimitParam3(limit int) *mRepositoryMockGetWebhookDeliveries {
+	if mmGetWebhookDeliveries.mock.funcGetWebhookDeliveries != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation == nil {
+		mmGetWebhookDeliveries.defaultExpectation = &RepositoryMockGetWebhookDeliveriesExpectation{}
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation.params != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by Expect")
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation.paramPtrs == nil {
+		mmGetWebhookDeliveries.defaultExpectation.paramPtrs = &RepositoryMockGetWebhookDeliveriesParamPtrs{}
+	}
+	mmGetWebhookDeliveries.defaultExpectation.paramPtrs.limit = &limit
+
+	return mmGetWebhookDeliveries
+}
+
+// ExpectOffsetParam4 sets up expected param offset for Repository.GetWebhookDeliveries
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) ExpectOffsetParam4(offset int) *mRepositoryMockGetWebhookDeliveries {
+	if mmGetWebhookDeliveries.mock.funcGetWebhookDeliveries != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation == nil {
+		mmGetWebhookDeliveries.defaultExpectation = &RepositoryMockGetWebhookDeliveriesExpectation{}
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation.params != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by Expect")
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation.paramPtrs == nil {
+		mmGetWebhookDeliveries.defaultExpectation.paramPtrs = &RepositoryMockGetWebhookDeliveriesParamPtrs{}
+	}
+	mmGetWebhookDeliveries.defaultExpectation.paramPtrs.offset = &offset
+
+	return mmGetWebhookDeliveries
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetWebhookDeliveries
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) Inspect(f func(ctx context.Context, webhookID int, limit int, offset int)) *mRepositoryMockGetWebhookDeliveries {
+	if mmGetWebhookDeliveries.mock.inspectFuncGetWebhookDeliveries != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetWebhookDeliveries")
+	}
+
+	mmGetWebhookDeliveries.mock.inspectFuncGetWebhookDeliveries = f
+
+	return mmGetWebhookDeliveries
+}
+
+// Return sets up results that will be returned by Repository.GetWebhookDeliveries
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) Return(wpa1 []*domain.WebhookDelivery, err error) *RepositoryMock {
+	if mmGetWebhookDeliveries.mock.funcGetWebhookDeliveries != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by Set")
+	}
+
+	if mmGetWebhookDeliveries.defaultExpectation == nil {
+		mmGetWebhookDeliveries.defaultExpectation = &RepositoryMockGetWebhookDeliveriesExpectation{mock: mmGetWebhookDeliveries.mock}
+	}
+	mmGetWebhookDeliveries.defaultExpectation.results = &RepositoryMockGetWebhookDeliveriesResults{wpa1, err}
+	return mmGetWebhookDeliveries.mock
+}
+
+// Set uses given function f to mock the Repository.GetWebhookDeliveries method
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) Set(f func(ctx context.Context, webhookID int, limit int, offset int) (wpa1 []*domain.WebhookDelivery, err error)) *RepositoryMock {
+	if mmGetWebhookDeliveries.defaultExpectation != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("Default expectation is already set for the Repository.GetWebhookDeliveries method")
+	}
+
+	if len(mmGetWebhookDeliveries.expectations) > 0 {
+		mmGetWebhookDeliveries.mock.t.Fatalf("Some expectations are already set for the Repository.GetWebhookDeliveries method")
+	}
+
+	mmGetWebhookDeliveries.mock.funcGetWebhookDeliveries = f
+	return mmGetWebhookDeliveries.mock
+}
+
+// When sets expectation for the Repository.GetWebhookDeliveries which will trigger the result defined by the following
+// Then helper
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) When(ctx context.Context, webhookID int, limit int, offset int) *RepositoryMockGetWebhookDeliveriesExpectation {
+	if mmGetWebhookDeliveries.mock.funcGetWebhookDeliveries != nil {
+		mmGetWebhookDeliveries.mock.t.Fatalf("RepositoryMock.GetWebhookDeliveries mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetWebhookDeliveriesExpectation{
+		mock:   mmGetWebhookDeliveries.mock,
+		params: &RepositoryMockGetWebhookDeliveriesParams{ctx, webhookID, limit, offset},
+	}
+	mmGetWebhookDeliveries.expectations = append(mmGetWebhookDeliveries.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetWebhookDeliveries return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetWebhookDeliveriesExpectation) Then(wpa1 []*domain.WebhookDelivery, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetWebhookDeliveriesResults{wpa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetWebhookDeliveries should be invoked
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) Times(n uint64) *mRepositoryMockGetWebhookDeliveries {
+	if n == 0 {
+		mmGetWebhookDeliveries.mock.t.Fatalf("Times of RepositoryMock.GetWebhookDeliveries mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetWebhookDeliveries.expectedInvocations, n)
+	return mmGetWebhookDeliveries
+}
+
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) invocationsDone() bool {
+	if len(mmGetWebhookDeliveries.expectations) == 0 && mmGetWebhookDeliveries.defaultExpectation == nil && mmGetWebhookDeliveries.mock.funcGetWebhookDeliveries == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetWebhookDeliveries.mock.afterGetWebhookDeliveriesCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetWebhookDeliveries.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetWebhookDeliveries implements repository.Repository
+func (mmGetWebhookDeliveries *RepositoryMock) GetWebhookDeliveries(ctx context.Context, webhookID int, limit int, offset int) (wpa1 []*domain.WebhookDelivery, err error) {
+	mm_atomic.AddUint64(&mmGetWebhookDeliveries.beforeGetWebhookDeliveriesCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetWebhookDeliveries.afterGetWebhookDeliveriesCounter, 1)
+
+	if mmGetWebhookDeliveries.inspectFuncGetWebhookDeliveries != nil {
+		mmGetWebhookDeliveries.inspectFuncGetWebhookDeliveries(ctx, webhookID, limit, offset)
+	}
+
+	mm_params := RepositoryMockGetWebhookDeliveriesParams{ctx, webhookID, limit, offset}
+
+	// Record call args
+	mmGetWebhookDeliveries.GetWebhookDeliveriesMock.mutex.Lock()
+	mmGetWebhookDeliveries.GetWebhookDeliveriesMock.callArgs = append(mmGetWebhookDeliveries.GetWebhookDeliveriesMock.callArgs, &mm_params)
+	mmGetWebhookDeliveries.GetWebhookDeliveriesMock.mutex.Unlock()
+
+	for _, e := range mmGetWebhookDeliveries.GetWebhookDeliveriesMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.wpa1, e.results.err
+		}
+	}
+
+	if mmGetWebhookDeliveries.GetWebhookDeliveriesMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetWebhookDeliveries.GetWebhookDeliveriesMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetWebhookDeliveries.GetWebhookDeliveriesMock.defaultExpectation.params
+		mm_want_ptrs := mmGetWebhookDeliveries.GetWebhookDeliveriesMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetWebhookDeliveriesParams{ctx, webhookID, limit, offset}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetWebhookDeliveries.t.Errorf("RepositoryMock.GetWebhookDeliveries got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.webhookID != nil && !minimock.Equal(*mm_want_ptrs.webhookID, mm_got.webhookID) {
+				mmGetWebhookDeliveries.t.Errorf("RepositoryMock.GetWebhookDeliveries got unexpected parameter webhookID, want: %#v, got: %#v%s\n", *mm_want_ptrs.webhookID, mm_got.webhookID, minimock.Diff(*mm_want_ptrs.webhookID, mm_got.webhookID))
+			}
+
+			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
+				mmGetWebhookDeliveries.t.Errorf("RepositoryMock.GetWebhookDeliveries got unexpected parameter limit, want: %#v, got: %#v%s\n", *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			}
+
+			if mm_want_ptrs.offset != nil && !minimock.Equal(*mm_want_ptrs.offset, mm_got.offset) {
+				mmGetWebhookDeliveries.t.Errorf("RepositoryMock.GetWebhookDeliveries got unexpected parameter offset, want: %#v, got: %#v%s\n", *mm_want_ptrs.offset, mm_got.offset, minimock.Diff(*mm_want_ptrs.offset, mm_got.offset))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetWebhookDeliveries.t.Errorf("RepositoryMock.GetWebhookDeliveries got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetWebhookDeliveries.GetWebhookDeliveriesMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetWebhookDeliveries.t.Fatal("No results are set for the RepositoryMock.GetWebhookDeliveries")
+		}
+		return (*mm_results).wpa1, (*mm_results).err
+	}
+	if mmGetWebhookDeliveries.funcGetWebhookDeliveries != nil {
+		return mmGetWebhookDeliveries.funcGetWebhookDeliveries(ctx, webhookID, limit, offset)
+	}
+	mmGetWebhookDeliveries.t.Fatalf("Unexpected call to RepositoryMock.GetWebhookDeliveries. %v %v %v %v", ctx, webhookID, limit, offset)
+	return
+}
+
+// GetWebhookDeliveriesAfterCounter returns a count of finished RepositoryMock.GetWebhookDeliveries invocations
+func (mmGetWebhookDeliveries *RepositoryMock) GetWebhookDeliveriesAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetWebhookDeliveries.afterGetWebhookDeliveriesCounter)
+}
+
+// GetWebhookDeliveriesBeforeCounter returns a count of RepositoryMock.GetWebhookDeliveries invocations
+func (mmGetWebhookDeliveries *RepositoryMock) GetWebhookDeliveriesBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetWebhookDeliveries.beforeGetWebhookDeliveriesCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetWebhookDeliveries.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetWebhookDeliveries *mRepositoryMockGetWebhookDeliveries) Calls() []*RepositoryMockGetWebhookDeliveriesParams {
+	mmGetWebhookDeliveries.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetWebhookDeliveriesParams, len(mmGetWebhookDeliveries.callArgs))
+	copy(argCopy, mmGetWebhookDeliveries.callArgs)
+
+	mmGetWebhookDeliveries.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetWebhookDeliveriesDone returns true if the count of the GetWebhookDeliveries invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetWebhookDeliveriesDone() bool {
+	for _, e := range m.GetWebhookDeliveriesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetWebhookDeliveriesMock.invocationsDone()
+}
+
+// MinimockGetWebhookDeliveriesInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetWebhookDeliveriesInspect() {
+	for _, e := range m.GetWebhookDeliveriesMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetWebhookDeliveries with params: %#v", *e.params)
+		}
+	}
+
+	afterGetWebhookDeliveriesCounter := mm_atomic.LoadUint64(&m.afterGetWebhookDeliveriesCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetWebhookDeliveriesMock.defaultExpectation != nil && afterGetWebhookDeliveriesCounter < 1 {
+		if m.GetWebhookDeliveriesMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetWebhookDeliveries")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetWebhookDeliveries with params: %#v", *m.GetWebhookDeliveriesMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetWebhookDeliveries != nil && afterGetWebhookDeliveriesCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetWebhookDeliveries")
+	}
+
+	if !m.GetWebhookDeliveriesMock.invocationsDone() && afterGetWebhookDeliveriesCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetWebhookDeliveries but found %d calls",
+			mm_atomic.LoadUint64(&m.GetWebhookDeliveriesMock.expectedInvocations), afterGetWebhookDeliveriesCounter)
+	}
+}
+
+type mRepositoryMockGetWebhooks struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetWebhooksExpectation
+	expectations       []*RepositoryMockGetWebhooksExpectation
+
+	callArgs []*RepositoryMockGetWebhooksParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetWebhooksExpectation specifies expectation struct of the Repository.GetWebhooks
+type RepositoryMockGetWebhooksExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetWebhooksParams
+	paramPtrs *RepositoryMockGetWebhooksParamPtrs
+	results   *RepositoryMockGetWebhooksResults
+	Counter   uint64
+}
+
+// RepositoryMockGetWebhooksParams contains parameters of the Repository.GetWebhooks
+type RepositoryMockGetWebhooksParams struct {
+	ctx     context.Context
+	ownerID int
+}
+
+// RepositoryMockGetWebhooksParamPtrs contains pointers to parameters of the Repository.GetWebhooks
+type RepositoryMockGetWebhooksParamPtrs struct {
+	ctx     *context.Context
+	ownerID *int
+}
+
+// RepositoryMockGetWebhooksResults contains results of the Repository.GetWebhooks
+type RepositoryMockGetWebhooksResults struct {
+	wpa1 []*domain.Webhook
+	err  error
+}
+
+// Expect sets up expected params for Repository.GetWebhooks
+func (mmGetWebhooks *mRepositoryMockGetWebhooks) Expect(ctx context.Context, ownerID int) *mRepositoryMockGetWebhooks {
+	if mmGetWebhooks.mock.funcGetWebhooks != nil {
+		mmGetWebhooks.mock.t.Fatalf("RepositoryMock.GetWebhooks mock is already set by Set")
+	}
+
+	if mmGetWebhooks.defaultExpectation == nil {
+		mmGetWebhooks.defaultExpectation = &RepositoryMockGetWebhooksExpectation{}
+	}
+
+	if mmGetWebhooks.defaultExpectation.paramPtrs != nil {
+		mmGetWebhooks.mock.t.Fatalf("RepositoryMock.GetWebhooks mock is already set by ExpectParams functions")
+	}
+
+	mmGetWebhooks.defaultExpectation.params = &RepositoryMockGetWebhooksParams{ctx, ownerID}
+	for _, e := range mmGetWebhooks.expectations {
+		if minimock.Equal(e.params, mmGetWebhooks.defaultExpectation.params) {
+			mmGetWebhooks.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetWebhooks.defaultExpectation.params)
+		}
+	}
+
+	return mmGetWebhooks
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetWebhooks
+func (mmGetWebhooks *mRepositoryMockGetWebhooks) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetWebhooks {
+	if mmGetWebhooks.mock.funcGetWebhooks != nil {
+		mmGetWebhooks.mock.t.Fatalf("RepositoryMock.GetWebhooks mock is already set by Set")
+	}
+
+	if mmGetWebhooks.defaultExpectation == nil {
+		mmGetWebhooks.defaultExpectation = &RepositoryMockGetWebhooksExpectation{}
+	}
+
+	if mmGetWebhooks.defaultExpectation.params != nil {
+		mmGetWebhooks.mock.t.Fatalf("RepositoryMock.GetWebhooks mock is already set by Expect")
+	}
+
+	if mmGetWebhooks.defaultExpectation.paramPtrs == nil {
+		mmGetWebhooks.defaultExpectation.paramPtrs = &RepositoryMockGetWebhooksParamPtrs{}
+	}
+	mmGetWebhooks.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetWebhooks
+}
+
+// ExpectOwnerIDParam2 sets up expected param ownerID for Repository.GetWebhooks
+func (mmGetWebhooks *mRepositoryMockGetWebhooks) ExpectOwnerIDParam2(ownerID int) *mRepositoryMockGetWebhooks {
+	if mmGetWebhooks.mock.funcGetWebhooks != nil {
+		mmGetWebhooks.mock.t.Fatalf("RepositoryMock.GetWebhooks mock is already set by Set")
+	}
+
+	if mmGetWebhooks.defaultExpectation == nil {
+		mmGetWebhooks.defaultExpectation = &RepositoryMockGetWebhooksExpectation{}
+	}
+
+	if mmGetWebhooks.defaultExpectation.params != nil {
+		mmGetWebhooks.mock.t.Fatalf("RepositoryMock.GetWebhooks mock is already set by Expect")
+	}
+
+	if mmGetWebhooks.defaultExpectation.paramPtrs == nil {
+		mmGetWebhooks.defaultExpectation.paramPtrs = &RepositoryMockGetWebhooksParamPtrs{}
+	}
+	mmGetWebhooks.defaultExpectation.paramPtrs.ownerID = &ownerID
+
+	return mmGetWebhooks
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetWebhooks
+func (mmGetWebhooks *mRepositoryMockGetWebhooks) Inspect(f func(ctx context.Context, ownerID int)) *mRepositoryMockGetWebhooks {
+	if mmGetWebhooks.mock.inspectFuncGetWebhooks != nil {
+		mmGetWebhooks.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetWebhooks")
+	}
+
+	mmGetWebhooks.mock.inspectFuncGetWebhooks = f
+
+	return mmGetWebhooks
+}
+
+// Return sets up results that will be returned by Repository.GetWebhooks
+func (mmGetWebhooks *mRepositoryMockGetWebhooks) Return(wpa1 []*domain.Webhook, err error) *RepositoryMock {
+	if mmGetWebhooks.mock.funcGetWebhooks != nil {
+		mmGetWebhooks.mock.t.Fatalf("RepositoryMock.GetWebhooks mock is already set by Set")
+	}
+
+	if mmGetWebhooks.defaultExpectation == nil {
+		mmGetWebhooks.defaultExpectation = &RepositoryMockGetWebhooksExpectation{mock: mmGetWebhooks.mock}
+	}
+	mmGetWebhooks.defaultExpectation.results = &RepositoryMockGetWebhooksResults{wpa1, err}
+	return mmGetWebhooks.mock
+}
+
+// Set uses given function f to mock the Repository.GetWebhooks method
+func (mmGetWebhooks *mRepositoryMockGetWebhooks) Set(f func(ctx context.Context, ownerID int) (wpa1 []*domain.Webhook, err error)) *RepositoryMock {
+	if mmGetWebhooks.defaultExpectation != nil {
+		mmGetWebhooks.mock.t.Fatalf("Default expectation is already set for the Repository.GetWebhooks method")
+	}
+
+	if len(mmGetWebhooks.expectations) > 0 {
+		mmGetWebhooks.mock.t.Fatalf("Some expectations are already set for the Repository.GetWebhooks method")
+	}
+
+	mmGetWebhooks.mock.funcGetWebhooks = f
+	return mmGetWebhooks.mock
+}
+
+// When sets expectation for the Repository.GetWebhooks which will trigger the result defined by the following
+// Then helper
+func (mmGetWebhooks *mRepositoryMockGetWebhooks) When(ctx context.Context, ownerID int) *RepositoryMockGetWebhooksExpectation {
+	if mmGetWebhooks.mock.funcGetWebhooks != nil {
+		mmGetWebhooks.mock.t.Fatalf("RepositoryMock.GetWebhooks mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetWebhooksExpectation{
+		mock:   mmGetWebhooks.mock,
+		params: &RepositoryMockGetWebhooksParams{ctx, ownerID},
+	}
+	mmGetWebhooks.expectations = append(mmGetWebhooks.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetWebhooks return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetWebhooksExpectation) Then(wpa1 []*domain.Webhook, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetWebhooksResults{wpa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetWebhooks should be invoked
+func (mmGetWebhooks *mRepositoryMockGetWebhooks) Times(n uint64) *mRepositoryMockGetWebhooks {
+	if n == 0 {
+		mmGetWebhooks.mock.t.Fatalf("Times of RepositoryMock.GetWebhooks mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetWebhooks.expectedInvocations, n)
+	return mmGetWebhooks
+}
+
+func (mmGetWebhooks *mRepositoryMockGetWebhooks) invocationsDone() bool {
+	if len(mmGetWebhooks.expectations) == 0 && mmGetWebhooks.defaultExpectation == nil && mmGetWebhooks.mock.funcGetWebhooks == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetWebhooks.mock.afterGetWebhooksCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetWebhooks.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetWebhooks implements repository.Repository
+func (mmGetWebhooks *RepositoryMock) GetWebhooks(ctx context.Context, ownerID int) (wpa1 []*domain.Webhook, err error) {
+	mm_atomic.AddUint64(&mmGetWebhooks.beforeGetWebhooksCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetWebhooks.afterGetWebhooksCounter, 1)
+
+	if mmGetWebhooks.inspectFuncGetWebhooks != nil {
+		mmGetWebhooks.inspectFuncGetWebhooks(ctx, ownerID)
+	}
+
+	mm_params := RepositoryMockGetWebhooksParams{ctx, ownerID}
+
+	// Record call args
+	mmGetWebhooks.GetWebhooksMock.mutex.Lock()
+	mmGetWebhooks.GetWebhooksMock.callArgs = append(mmGetWebhooks.GetWebhooksMock.callArgs, &mm_params)
+	mmGetWebhooks.GetWebhooksMock.mutex.Unlock()
+
+	for _, e := range mmGetWebhooks.GetWebhooksMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.wpa1, e.results.err
+		}
+	}
+
+	if mmGetWebhooks.GetWebhooksMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetWebhooks.GetWebhooksMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetWebhooks.GetWebhooksMock.defaultExpectation.params
+		mm_want_ptrs := mmGetWebhooks.GetWebhooksMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetWebhooksParams{ctx, ownerID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetWebhooks.t.Errorf("RepositoryMock.GetWebhooks got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.ownerID != nil && !minimock.Equal(*mm_want_ptrs.ownerID, mm_got.ownerID) {
+				mmGetWebhooks.t.Errorf("RepositoryMock.GetWebhooks got unexpected parameter ownerID, want: %#v, got: %#v%s\n", *mm_want_ptrs.ownerID, mm_got.ownerID, minimock.Diff(*mm_want_ptrs.ownerID, mm_got.ownerID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetWebhooks.t.Errorf("RepositoryMock.GetWebhooks got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetWebhooks.GetWebhooksMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetWebhooks.t.Fatal("No results are set for the RepositoryMock.GetWebhooks")
+		}
+		return (*mm_results).wpa1, (*mm_results).err
+	}
+	if mmGetWebhooks.funcGetWebhooks != nil {
+		return mmGetWebhooks.funcGetWebhooks(ctx, ownerID)
+	}
+	mmGetWebhooks.t.Fatalf("Unexpected call to RepositoryMock.GetWebhooks. %v %v", ctx, ownerID)
+	return
+}
+
+// GetWebhooksAfterCounter returns a count of finished RepositoryMock.GetWebhooks invocations
+func (mmGetWebhooks *RepositoryMock) GetWebhooksAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetWebhooks.afterGetWebhooksCounter)
+}
+
+// GetWebhooksBeforeCounter returns a count of RepositoryMock.GetWebhooks invocations
+func (mmGetWebhooks *RepositoryMock) GetWebhooksBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetWebhooks.beforeGetWebhooksCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetWebhooks.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetWebhooks *mRepositoryMockGetWebhooks) Calls() []*RepositoryMockGetWebhooksParams {
+	mmGetWebhooks.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetWebhooksParams, len(mmGetWebhooks.callArgs))
+	copy(argCopy, mmGetWebhooks.callArgs)
+
+	mmGetWebhooks.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetWebhooksDone returns true if the count of the GetWebhooks invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetWebhooksDone() bool {
+	for _, e := range m.GetWebhooksMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetWebhooksMock.invocationsDone()
+}
+
+// MinimockGetWebhooksInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetWebhooksInspect() {
+	for _, e := range m.GetWebhooksMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetWebhooks with params: %#v", *e.params)
+		}
+	}
+
+	afterGetWebhooksCounter := mm_atomic.LoadUint64(&m.afterGetWebhooksCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetWebhooksMock.defaultExpectation != nil && afterGetWebhooksCounter < 1 {
+		if m.GetWebhooksMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetWebhooks")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetWebhooks with params: %#v", *m.GetWebhooksMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetWebhooks != nil && afterGetWebhooksCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetWebhooks")
+	}
+
+	if !m.GetWebhooksMock.invocationsDone() && afterGetWebhooksCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetWebhooks but found %d calls",
+			mm_atomic.LoadUint64(&m.GetWebhooksMock.expectedInvocations), afterGetWebhooksCounter)
+	}
+}
+
+type mRepositoryMockGetWebhooksForEvent struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockGetWebhooksForEventExpectation
+	expectations       []*RepositoryMockGetWebhooksForEventExpectation
+
+	callArgs []*RepositoryMockGetWebhooksForEventParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockGetWebhooksForEventExpectation specifies expectation struct of the Repository.GetWebhooksForEvent
+type RepositoryMockGetWebhooksForEventExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockGetWebhooksForEventParams
+	paramPtrs *RepositoryMockGetWebhooksForEventParamPtrs
+	results   *RepositoryMockGetWebhooksForEventResults
+	Counter   uint64
+}
+
+// RepositoryMockGetWebhooksForEventParams contains parameters of the Repository.GetWebhooksForEvent
+type RepositoryMockGetWebhooksForEventParams struct {
+	ctx       context.Context
+	eventType domain.EventType
+	postID    int
+}
+
+// RepositoryMockGetWebhooksForEventParamPtrs contains pointers to parameters of the Repository.GetWebhooksForEvent
+type RepositoryMockGetWebhooksForEventParamPtrs struct {
+	ctx       *context.Context
+	eventType *domain.EventType
+	postID    *int
+}
+
+// RepositoryMockGetWebhooksForEventResults contains results of the Repository.GetWebhooksForEvent
+type RepositoryMockGetWebhooksForEventResults struct {
+	wpa1 []*domain.Webhook
+	err  error
+}
+
+// Expect sets up expected params for Repository.GetWebhooksForEvent
+func (mmGetWebhooksForEvent *mRepositoryMockGetWebhooksForEvent) Expect(ctx context.Context, eventType domain.EventType, postID int) *mRepositoryMockGetWebhooksForEvent {
+	if mmGetWebhooksForEvent.mock.funcGetWebhooksForEvent != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("RepositoryMock.GetWebhooksForEvent mock is already set by Set")
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation == nil {
+		mmGetWebhooksForEvent.defaultExpectation = &RepositoryMockGetWebhooksForEventExpectation{}
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation.paramPtrs != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("RepositoryMock.GetWebhooksForEvent mock is already set by ExpectParams functions")
+	}
+
+	mmGetWebhooksForEvent.defaultExpectation.params = &RepositoryMockGetWebhooksForEventParams{ctx, eventType, postID}
+	for _, e := range mmGetWebhooksForEvent.expectations {
+		if minimock.Equal(e.params, mmGetWebhooksForEvent.defaultExpectation.params) {
+			mmGetWebhooksForEvent.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetWebhooksForEvent.defaultExpectation.params)
+		}
+	}
+
+	return mmGetWebhooksForEvent
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.GetWebhooksForEvent
+func (mmGetWebhooksForEvent *mRepositoryMockGetWebhooksForEvent) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetWebhooksForEvent {
+	if mmGetWebhooksForEvent.mock.funcGetWebhooksForEvent != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("RepositoryMock.GetWebhooksForEvent mock is already set by Set")
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation == nil {
+		mmGetWebhooksForEvent.defaultExpectation = &RepositoryMockGetWebhooksForEventExpectation{}
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation.params != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("RepositoryMock.GetWebhooksForEvent mock is already set by Expect")
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation.paramPtrs == nil {
+		mmGetWebhooksForEvent.defaultExpectation.paramPtrs = &RepositoryMockGetWebhooksForEventParamPtrs{}
+	}
+	mmGetWebhooksForEvent.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetWebhooksForEvent
+}
+
+// ExpectEventTypeParam2 sets up expected param eventType for Repository.GetWebhooksForEvent
+func (mmGetWebhooksForEvent *mRepositoryMockGetWebhooksForEvent) ExpectEventTypeParam2(eventType domain.EventType) *mRepositoryMockGetWebhooksForEvent {
+	if mmGetWebhooksForEvent.mock.funcGetWebhooksForEvent != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("RepositoryMock.GetWebhooksForEvent mock is already set by Set")
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation == nil {
+		mmGetWebhooksForEvent.defaultExpectation = &RepositoryMockGetWebhooksForEventExpectation{}
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation.params != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("RepositoryMock.GetWebhooksForEvent mock is already set by Expect")
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation.paramPtrs == nil {
+		mmGetWebhooksForEvent.defaultExpectation.paramPtrs = &RepositoryMockGetWebhooksForEventParamPtrs{}
+	}
+	mmGetWebhooksForEvent.defaultExpectation.paramPtrs.eventType = &eventType
+
+	return mmGetWebhooksForEvent
+}
+
+// ExpectPostIDParam3 sets up expected param postID for Repository.GetWebhooksForEvent
+func (mmGetWebhooksForEvent *mRepositoryMockGetWebhooksForEvent) ExpectPostIDParam3(postID int) *mRepositoryMockGetWebhooksForEvent {
+	if mmGetWebhooksForEvent.mock.funcGetWebhooksForEvent != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("RepositoryMock.GetWebhooksForEvent mock is already set by Set")
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation == nil {
+		mmGetWebhooksForEvent.defaultExpectation = &RepositoryMockGetWebhooksForEventExpectation{}
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation.params != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("RepositoryMock.GetWebhooksForEvent mock is already set by Expect")
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation.paramPtrs == nil {
+		mmGetWebhooksForEvent.defaultExpectation.paramPtrs = &RepositoryMockGetWebhooksForEventParamPtrs{}
+	}
+	mmGetWebhooksForEvent.defaultExpectation.paramPtrs.postID = &postID
+
+	return mmGetWebhooksForEvent
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.GetWebhooksForEvent
+func (mmGetWebhooksForEvent *mRepositoryMockGetWebhooksForEvent) Inspect(f func(ctx context.Context, eventType domain.EventType, postID int)) *mRepositoryMockGetWebhooksForEvent {
+	if mmGetWebhooksForEvent.mock.inspectFuncGetWebhooksForEvent != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetWebhooksForEvent")
+	}
+
+	mmGetWebhooksForEvent.mock.inspectFuncGetWebhooksForEvent = f
+
+	return mmGetWebhooksForEvent
+}
+
+// Return sets up results that will be returned by Repository.GetWebhooksForEvent
+func (mmGetWebhooksForEvent *mRepositoryMockGetWebhooksForEvent) Return(wpa1 []*domain.Webhook, err error) *RepositoryMock {
+	if mmGetWebhooksForEvent.mock.funcGetWebhooksForEvent != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("RepositoryMock.GetWebhooksForEvent mock is already set by Set")
+	}
+
+	if mmGetWebhooksForEvent.defaultExpectation == nil {
+		mmGetWebhooksForEvent.defaultExpectation = &RepositoryMockGetWebhooksForEventExpectation{mock: mmGetWebhooksForEvent.mock}
+	}
+	mmGetWebhooksForEvent.defaultExpectation.results = &RepositoryMockGetWebhooksForEventResults{wpa1, err}
+	return mmGetWebhooksForEvent.mock
+}
+
+// Set uses given function f to mock the Repository.GetWebhooksForEvent method
+func (mmGetWebhooksForEvent *mRepositoryMockGetWebhooksForEvent) Set(f func(ctx context.Context, eventType domain.EventType, postID int) (wpa1 []*domain.Webhook, err error)) *RepositoryMock {
+	if mmGetWebhooksForEvent.defaultExpectation != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("Default expectation is already set for the Repository.GetWebhooksForEvent method")
+	}
+
+	if len(mmGetWebhooksForEvent.expectations) > 0 {
+		mmGetWebhooksForEvent.mock.t.Fatalf("Some expectations are already set for the Repository.GetWebhooksForEvent method")
+	}
+
+	mmGetWebhooksForEvent.mock.funcGetWebhooksForEvent = f
+	return mmGetWebhooksForEvent.mock
+}
+
+// When sets expectation for the Repository.GetWebhooksForEvent which will trigger the result defined by the following
+// Then helper
+func (mmGetWebhooksForEvent *mRepositoryMockGetWebhooksForEvent) When(ctx context.Context, eventType domain.EventType, postID int) *RepositoryMockGetWebhooksForEventExpectation {
+	if mmGetWebhooksForEvent.mock.funcGetWebhooksForEvent != nil {
+		mmGetWebhooksForEvent.mock.t.Fatalf("RepositoryMock.GetWebhooksForEvent mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockGetWebhooksForEventExpectation{
+		mock:   mmGetWebhooksForEvent.mock,
+		params: &RepositoryMockGetWebhooksForEventParams{ctx, eventType, postID},
+	}
+	mmGetWebhooksForEvent.expectations = append(mmGetWebhooksForEvent.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.GetWebhooksForEvent return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockGetWebhooksForEventExpectation) Then(wpa1 []*domain.Webhook, err error) *RepositoryMock {
+	e.results = &RepositoryMockGetWebhooksForEventResults{wpa1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.GetWebhooksForEvent should be invoked
+func (mmGetWebhooksForEvent *mRepositoryMockGetWebhooksForEvent) Times(n uint64) *mRepositoryMockGetWebhooksForEvent {
+	if n == 0 {
+		mmGetWebhooksForEvent.mock.t.Fatalf("Times of RepositoryMock.GetWebhooksForEvent mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetWebhooksForEvent.expectedInvocations, n)
+	return mmGetWebhooksForEvent
+}
+
+func (mmGetWebhooksForEvent *mRepositoryMockGetWebhooksForEvent) invocationsDone() bool {
+	if len(mmGetWebhooksForEvent.expectations) == 0 && mmGetWebhooksForEvent.defaultExpectation == nil && mmGetWebhooksForEvent.mock.funcGetWebhooksForEvent == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetWebhooksForEvent.mock.afterGetWebhooksForEventCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetWebhooksForEvent.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetWebhooksForEvent implements repository.Repository
+func (mmGetWebhooksForEvent *RepositoryMock) GetWebhooksForEvent(ctx context.Context, eventType domain.EventType, postID int) (wpa1 []*domain.Webhook, err error) {
+	mm_atomic.AddUint64(&mmGetWebhooksForEvent.beforeGetWebhooksForEventCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetWebhooksForEvent.afterGetWebhooksForEventCounter, 1)
+
+	if mmGetWebhooksForEvent.inspectFuncGetWebhooksForEvent != nil {
+		mmGetWebhooksForEvent.inspectFuncGetWebhooksForEvent(ctx, eventType, postID)
+	}
+
+	mm_params := RepositoryMockGetWebhooksForEventParams{ctx, eventType, postID}
+
+	// Record call args
+	mmGetWebhooksForEvent.GetWebhooksForEventMock.mutex.Lock()
+	mmGetWebhooksForEvent.GetWebhooksForEventMock.callArgs = append(mmGetWebhooksForEvent.GetWebhooksForEventMock.callArgs, &mm_params)
+	mmGetWebhooksForEvent.GetWebhooksForEventMock.mutex.Unlock()
+
+	for _, e := range mmGetWebhooksForEvent.GetWebhooksForEventMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.wpa1, e.results.err
+		}
+	}
+
+	if mmGetWebhooksForEvent.GetWebhooksForEventMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetWebhooksForEvent.GetWebhooksForEventMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetWebhooksForEvent.GetWebhooksForEventMock.defaultExpectation.params
+		mm_want_ptrs := mmGetWebhooksForEvent.GetWebhooksForEventMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockGetWebhooksForEventParams{ctx, eventType, postID}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetWebhooksForEvent.t.Errorf("RepositoryMock.GetWebhooksForEvent got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.eventType != nil && !minimock.Equal(*mm_want_ptrs.eventType, mm_got.eventType) {
+				mmGetWebhooksForEvent.t.Errorf("RepositoryMock.GetWebhooksForEvent got unexpected parameter eventType, want: %#v, got: %#v%s\n", *mm_want_ptrs.eventType, mm_got.eventType, minimock.Diff(*mm_want_ptrs.eventType, mm_got.eventType))
+			}
+
+			if mm_want_ptrs.postID != nil && !minimock.Equal(*mm_want_ptrs.postID, mm_got.postID) {
+				mmGetWebhooksForEvent.t.Errorf("RepositoryMock.GetWebhooksForEvent got unexpected parameter postID, want: %#v, got: %#v%s\n", *mm_want_ptrs.postID, mm_got.postID, minimock.Diff(*mm_want_ptrs.postID, mm_got.postID))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetWebhooksForEvent.t.Errorf("RepositoryMock.GetWebhooksForEvent got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetWebhooksForEvent.GetWebhooksForEventMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetWebhooksForEvent.t.Fatal("No results are set for the RepositoryMock.GetWebhooksForEvent")
+		}
+		return (*mm_results).wpa1, (*mm_results).err
+	}
+	if mmGetWebhooksForEvent.funcGetWebhooksForEvent != nil {
+		return mmGetWebhooksForEvent.funcGetWebhooksForEvent(ctx, eventType, postID)
+	}
+	mmGetWebhooksForEvent.t.Fatalf("Unexpected call to RepositoryMock.GetWebhooksForEvent. %v %v %v", ctx, eventType, postID)
+	return
+}
+
+// GetWebhooksForEventAfterCounter returns a count of finished RepositoryMock.GetWebhooksForEvent invocations
+func (mmGetWebhooksForEvent *RepositoryMock) GetWebhooksForEventAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetWebhooksForEvent.afterGetWebhooksForEventCounter)
+}
+
+// GetWebhooksForEventBeforeCounter returns a count of RepositoryMock.GetWebhooksForEvent invocations
+func (mmGetWebhooksForEvent *RepositoryMock) GetWebhooksForEventBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetWebhooksForEvent.beforeGetWebhooksForEventCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.GetWebhooksForEvent.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetWebhooksForEvent *mRepositoryMockGetWebhooksForEvent) Calls() []*RepositoryMockGetWebhooksForEventParams {
+	mmGetWebhooksForEvent.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockGetWebhooksForEventParams, len(mmGetWebhooksForEvent.callArgs))
+	copy(argCopy, mmGetWebhooksForEvent.callArgs)
+
+	mmGetWebhooksForEvent.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetWebhooksForEventDone returns true if the count of the GetWebhooksForEvent invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockGetWebhooksForEventDone() bool {
+	for _, e := range m.GetWebhooksForEventMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetWebhooksForEventMock.invocationsDone()
+}
+
+// MinimockGetWebhooksForEventInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockGetWebhooksForEventInspect() {
+	for _, e := range m.GetWebhooksForEventMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.GetWebhooksForEvent with params: %#v", *e.params)
+		}
+	}
+
+	afterGetWebhooksForEventCounter := mm_atomic.LoadUint64(&m.afterGetWebhooksForEventCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetWebhooksForEventMock.defaultExpectation != nil && afterGetWebhooksForEventCounter < 1 {
+		if m.GetWebhooksForEventMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.GetWebhooksForEvent")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.GetWebhooksForEvent with params: %#v", *m.GetWebhooksForEventMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetWebhooksForEvent != nil && afterGetWebhooksForEventCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.GetWebhooksForEvent")
+	}
+
+	if !m.GetWebhooksForEventMock.invocationsDone() && afterGetWebhooksForEventCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.GetWebhooksForEvent but found %d calls",
+			mm_atomic.LoadUint64(&m.GetWebhooksForEventMock.expectedInvocations), afterGetWebhooksForEventCounter)
+	}
+}
+
+type mRepositoryMockHideComment struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockHideCommentExpectation
+	expectations       []*RepositoryMockHideCommentExpectation
+
+	callArgs []*RepositoryMockHideCommentParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockHideCommentExpectation specifies expectation struct of the Repository.HideComment
+type RepositoryMockHideCommentExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockHideCommentParams
+	paramPtrs *RepositoryMockHideCommentParamPtrs
+	results   *RepositoryMockHideCommentResults
+	Counter   uint64
+}
+
+// RepositoryMockHideCommentParams contains parameters of the Repository.HideComment
+type RepositoryMockHideCommentParams struct {
+	ctx context.Context
+	id  int
+}
+
+// RepositoryMockHideCommentParamPtrs contains pointers to parameters of the Repository.HideComment
+type RepositoryMockHideCommentParamPtrs struct {
+	ctx *context.Context
+	id  *int
+}
+
+// RepositoryMockHideCommentResults contains results of the Repository.HideComment
+type RepositoryMockHideCommentResults struct {
+	err error
+}
+
+// Expect sets up expected params for Repository.HideComment
+func (mmHideComment *mRepositoryMockHideComment) Expect(ctx context.Context, id int) *mRepositoryMockHideComment {
+	if mmHideComment.mock.funcHideComment != nil {
+		mmHideComment.mock.t.Fatalf("RepositoryMock.HideComment mock is already set by Set")
+	}
+
+	if mmHideComment.defaultExpectation == nil {
+		mmHideComment.defaultExpectation = &RepositoryMockHideCommentExpectation{}
+	}
+
+	if mmHideComment.defaultExpectation.paramPtrs != nil {
+		mmHideComment.mock.t.Fatalf("RepositoryMock.HideComment mock is already set by ExpectParams functions")
+	}
+
+	mmHideComment.defaultExpectation.params = &RepositoryMockHideCommentParams{ctx, id}
+	for _, e := range mmHideComment.expectations {
+		if minimock.Equal(e.params, mmHideComment.defaultExpectation.params) {
+			mmHideComment.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmHideComment.defaultExpectation.params)
+		}
+	}
+
+	return mmHideComment
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.HideComment
+func (mmHideComment *mRepositoryMockHideComment) ExpectCtxParam1(ctx context.Context) *mRepositoryMockHideComment {
+	if mmHideComment.mock.funcHideComment != nil {
+		mmHideComment.mock.t.Fatalf("RepositoryMock.HideComment mock is already set by Set")
+	}
+
+	if mmHideComment.defaultExpectation == nil {
+		mmHideComment.defaultExpectation = &RepositoryMockHideCommentExpectation{}
+	}
+
+	if mmHideComment.defaultExpectation.params != nil {
+		mmHideComment.mock.t.Fatalf("RepositoryMock.HideComment mock is already set by Expect")
+	}
+
+	if mmHideComment.defaultExpectation.paramPtrs == nil {
+		mmHideComment.defaultExpectation.paramPtrs = &RepositoryMockHideCommentParamPtrs{}
+	}
+	mmHideComment.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmHideComment
+}
+
+// ExpectIdParam2 sets up expected param id for Repository.HideComment
+func (mmHideComment *mRepositoryMockHideComment) ExpectIdParam2(id int) *mRepositoryMockHideComment {
+	if mmHideComment.mock.funcHideComment != nil {
+		mmHideComment.mock.t.Fatalf("RepositoryMock.HideComment mock is already set by Set")
+	}
+
+	if mmHideComment.defaultExpectation == nil {
+		mmHideComment.defaultExpectation = &RepositoryMockHideCommentExpectation{}
+	}
+
+	if mmHideComment.defaultExpectation.params != nil {
+		mmHideComment.mock.t.Fatalf("RepositoryMock.HideComment mock is already set by Expect")
+	}
+
+	if mmHideComment.defaultExpectation.paramPtrs == nil {
+		mmHideComment.defaultExpectation.paramPtrs = &RepositoryMockHideCommentParamPtrs{}
+	}
+	mmHideComment.defaultExpectation.paramPtrs.id = &id
+
+	return mmHideComment
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.HideComment
+func (mmHideComment *mRepositoryMockHideComment) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockHideComment {
+	if mmHideComment.mock.inspectFuncHideComment != nil {
+		mmHideComment.mock.t.Fatalf("Inspect function is already set for RepositoryMock.HideComment")
+	}
+
+	mmHideComment.mock.inspectFuncHideComment = f
+
+	return mmHideComment
+}
+
+// Return sets up results that will be returned by Repository.HideComment
+func (mmHideComment *mRepositoryMockHideComment) Return(err error) *RepositoryMock {
+	if mmHideComment.mock.funcHideComment != nil {
+		mmHideComment.mock.t.Fatalf("RepositoryMock.HideComment mock is already set by Set")
+	}
+
+	if mmHideComment.defaultExpectation == nil {
+		mmHideComment.defaultExpectation = &RepositoryMockHideCommentExpectation{mock: mmHideComment.mock}
+	}
+	mmHideComment.defaultExpectation.results = &RepositoryMockHideCommentResults{err}
+	return mmHideComment.mock
+}
+
+// Set uses given function f to mock the Repository.HideComment method
+func (mmHideComment *mRepositoryMockHideComment) Set(f func(ctx context.Context, id int) (err error)) *RepositoryMock {
+	if mmHideComment.defaultExpectation != nil {
+		mmHideComment.mock.t.Fatalf("Default expectation is already set for the Repository.HideComment method")
+	}
+
+	if len(mmHideComment.expectations) > 0 {
+		mmHideComment.mock.t.Fatalf("Some expectations are already set for the Repository.HideComment method")
+	}
+
+	mmHideComment.mock.funcHideComment = f
+	return mmHideComment.mock
+}
+
+// When sets expectation for the Repository.HideComment which will trigger the result defined by the following
+// Then helper
+func (mmHideComment *mRepositoryMockHideComment) When(ctx context.Context, id int) *RepositoryMockHideCommentExpectation {
+	if mmHideComment.mock.funcHideComment != nil {
+		mmHideComment.mock.t.Fatalf("RepositoryMock.HideComment mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockHideCommentExpectation{
+		mock:   mmHideComment.mock,
+		params: &RepositoryMockHideCommentParams{ctx, id},
+	}
+	mmHideComment.expectations = append(mmHideComment.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.HideComment return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockHideCommentExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockHideCommentResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.HideComment should be invoked
+func (mmHideComment *mRepositoryMockHideComment) Times(n uint64) *mRepositoryMockHideComment {
+	if n == 0 {
+		mmHideComment.mock.t.Fatalf("Times of RepositoryMock.HideComment mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmHideComment.expectedInvocations, n)
+	return mmHideComment
+}
+
+func (mmHideComment *mRepositoryMockHideComment) invocationsDone() bool {
+	if len(mmHideComment.expectations) == 0 && mmHideComment.defaultExpectation == nil && mmHideComment.mock.funcHideComment == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmHideComment.mock.afterHideCommentCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmHideComment.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// HideComment implements repository.Repository
+func (mmHideComment *RepositoryMock) HideComment(ctx context.Context, id int) (err error) {
+	mm_atomic.AddUint64(&mmHideComment.beforeHideCommentCounter, 1)
+	defer mm_atomic.AddUint64(&mmHideComment.afterHideCommentCounter, 1)
+
+	if mmHideComment.inspectFuncHideComment != nil {
+		mmHideComment.inspectFuncHideComment(ctx, id)
+	}
+
+	mm_params := RepositoryMockHideCommentParams{ctx, id}
+
+	// Record call args
+	mmHideComment.HideCommentMock.mutex.Lock()
+	mmHideComment.HideCommentMock.callArgs = append(mmHideComment.HideCommentMock.callArgs, &mm_params)
+	mmHideComment.HideCommentMock.mutex.Unlock()
+
+	for _, e := range mmHideComment.HideCommentMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmHideComment.HideCommentMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmHideComment.HideCommentMock.defaultExpectation.Counter, 1)
+		mm_want := mmHideComment.HideCommentMock.defaultExpectation.params
+		mm_want_ptrs := mmHideComment.HideCommentMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockHideCommentParams{ctx, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmHideComment.t.Errorf("RepositoryMock.HideComment got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmHideComment.t.Errorf("RepositoryMock.HideComment got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmHideComment.t.Errorf("RepositoryMock.HideComment got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmHideComment.HideCommentMock.defaultExpectation.results
+		if mm_results == nil {
+			mmHideComment.t.Fatal("No results are set for the RepositoryMock.HideComment")
+		}
+		return (*mm_results).err
+	}
+	if mmHideComment.funcHideComment != nil {
+		return mmHideComment.funcHideComment(ctx, id)
+	}
+	mmHideComment.t.Fatalf("Unexpected call to RepositoryMock.HideComment. %v %v", ctx, id)
+	return
+}
+
+// HideCommentAfterCounter returns a count of finished RepositoryMock.HideComment invocations
+func (mmHideComment *RepositoryMock) HideCommentAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHideComment.afterHideCommentCounter)
+}
+
+// HideCommentBeforeCounter returns a count of RepositoryMock.HideComment invocations
+func (mmHideComment *RepositoryMock) HideCommentBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHideComment.beforeHideCommentCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.HideComment.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmHideComment *mRepositoryMockHideComment) Calls() []*RepositoryMockHideCommentParams {
+	mmHideComment.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockHideCommentParams, len(mmHideComment.callArgs))
+	copy(argCopy, mmHideComment.callArgs)
+
+	mmHideComment.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockHideCommentDone returns true if the count of the HideComment invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockHideCommentDone() bool {
+	for _, e := range m.HideCommentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.HideCommentMock.invocationsDone()
+}
+
+// MinimockHideCommentInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockHideCommentInspect() {
+	for _, e := range m.HideCommentMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.HideComment with params: %#v", *e.params)
+		}
+	}
+
+	afterHideCommentCounter := mm_atomic.LoadUint64(&m.afterHideCommentCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.HideCommentMock.defaultExpectation != nil && afterHideCommentCounter < 1 {
+		if m.HideCommentMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.HideComment")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.HideComment with params: %#v", *m.HideCommentMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcHideComment != nil && afterHideCommentCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.HideComment")
+	}
+
+	if !m.HideCommentMock.invocationsDone() && afterHideCommentCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.HideComment but found %d calls",
+			mm_atomic.LoadUint64(&m.HideCommentMock.expectedInvocations), afterHideCommentCounter)
+	}
+}
+
+type mRepositoryMockHidePost struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockHidePostExpectation
+	expectations       []*RepositoryMockHidePostExpectation
+
+	callArgs []*RepositoryMockHidePostParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockHidePostExpectation specifies expectation struct of the Repository.HidePost
+type RepositoryMockHidePostExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockHidePostParams
+	paramPtrs *RepositoryMockHidePostParamPtrs
+	results   *RepositoryMockHidePostResults
+	Counter   uint64
+}
+
+// RepositoryMockHidePostParams contains parameters of the Repository.HidePost
+type RepositoryMockHidePostParams struct {
+	ctx context.Context
+	id  int
+}
+
+// RepositoryMockHidePostParamPtrs contains pointers to parameters of the Repository.HidePost
+type RepositoryMockHidePostParamPtrs struct {
+	ctx *context.Context
+	id  *int
+}
+
+// RepositoryMockHidePostResults contains results of the Repository.HidePost
+type RepositoryMockHidePostResults struct {
+	err error
+}
+
+// Expect sets up expected params for Repository.HidePost
+func (mmHidePost *mRepositoryMockHidePost) Expect(ctx context.Context, id int) *mRepositoryMockHidePost {
+	if mmHidePost.mock.funcHidePost != nil {
+		mmHidePost.mock.t.Fatalf("RepositoryMock.HidePost mock is already set by Set")
+	}
+
+	if mmHidePost.defaultExpectation == nil {
+		mmHidePost.defaultExpectation = &RepositoryMockHidePostExpectation{}
+	}
+
+	if mmHidePost.defaultExpectation.paramPtrs != nil {
+		mmHidePost.mock.t.Fatalf("RepositoryMock.HidePost mock is already set by ExpectParams functions")
+	}
+
+	mmHidePost.defaultExpectation.params = &RepositoryMockHidePostParams{ctx, id}
+	for _, e := range mmHidePost.expectations {
+		if minimock.Equal(e.params, mmHidePost.defaultExpectation.params) {
+			mmHidePost.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmHidePost.defaultExpectation.params)
+		}
+	}
+
+	return mmHidePost
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.HidePost
+func (mmHidePost *mRepositoryMockHidePost) ExpectCtxParam1(ctx context.Context) *mRepositoryMockHidePost {
+	if mmHidePost.mock.funcHidePost != nil {
+		mmHidePost.mock.t.Fatalf("RepositoryMock.HidePost mock is already set by Set")
+	}
+
+	if mmHidePost.defaultExpectation == nil {
+		mmHidePost.defaultExpectation = &RepositoryMockHidePostExpectation{}
+	}
+
+	if mmHidePost.defaultExpectation.params != nil {
+		mmHidePost.mock.t.Fatalf("RepositoryMock.HidePost mock is already set by Expect")
+	}
+
+	if mmHidePost.defaultExpectation.paramPtrs == nil {
+		mmHidePost.defaultExpectation.paramPtrs = &RepositoryMockHidePostParamPtrs{}
+	}
+	mmHidePost.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmHidePost
+}
+
+// ExpectIdParam2 sets up expected param id for Repository.HidePost
+func (mmHidePost *mRepositoryMockHidePost) ExpectIdParam2(id int) *mRepositoryMockHidePost {
+	if mmHidePost.mock.funcHidePost != nil {
+		mmHidePost.mock.t.Fatalf("RepositoryMock.HidePost mock is already set by Set")
+	}
+
+	if mmHidePost.defaultExpectation == nil {
+		mmHidePost.defaultExpectation = &RepositoryMockHidePostExpectation{}
+	}
+
+	if mmHidePost.defaultExpectation.params != nil {
+		mmHidePost.mock.t.Fatalf("RepositoryMock.HidePost mock is already set by Expect")
+	}
+
+	if mmHidePost.defaultExpectation.paramPtrs == nil {
+		mmHidePost.defaultExpectation.paramPtrs = &RepositoryMockHidePostParamPtrs{}
+	}
+	mmHidePost.defaultExpectation.paramPtrs.id = &id
+
+	return mmHidePost
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.HidePost
+func (mmHidePost *mRepositoryMockHidePost) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockHidePost {
+	if mmHidePost.mock.inspectFuncHidePost != nil {
+		mmHidePost.mock.t.Fatalf("Inspect function is already set for RepositoryMock.HidePost")
+	}
+
+	mmHidePost.mock.inspectFuncHidePost = f
+
+	return mmHidePost
+}
+
+// Return sets up results that will be returned by Repository.HidePost
+func (mmHidePost *mRepositoryMockHidePost) Return(err error) *RepositoryMock {
+	if mmHidePost.mock.funcHidePost != nil {
+		mmHidePost.mock.t.Fatalf("RepositoryMock.HidePost mock is already set by Set")
+	}
+
+	if mmHidePost.defaultExpectation == nil {
+		mmHidePost.defaultExpectation = &RepositoryMockHidePostExpectation{mock: mmHidePost.mock}
+	}
+	mmHidePost.defaultExpectation.results = &RepositoryMockHidePostResults{err}
+	return mmHidePost.mock
+}
+
+// Set uses given function f to mock the Repository.HidePost method
+func (mmHidePost *mRepositoryMockHidePost) Set(f func(ctx context.Context, id int) (err error)) *RepositoryMock {
+	if mmHidePost.defaultExpectation != nil {
+		mmHidePost.mock.t.Fatalf("Default expectation is already set for the Repository.HidePost method")
+	}
+
+	if len(mmHidePost.expectations) > 0 {
+		mmHidePost.mock.t.Fatalf("Some expectations are already set for the Repository.HidePost method")
+	}
+
+	mmHidePost.mock.funcHidePost = f
+	return mmHidePost.mock
+}
+
+// When sets expectation for the Repository.HidePost which will trigger the result defined by the following
+// Then helper
+func (mmHidePost *mRepositoryMockHidePost) When(ctx context.Context, id int) *RepositoryMockHidePostExpectation {
+	if mmHidePost.mock.funcHidePost != nil {
+		mmHidePost.mock.t.Fatalf("RepositoryMock.HidePost mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockHidePostExpectation{
+		mock:   mmHidePost.mock,
+		params: &RepositoryMockHidePostParams{ctx, id},
+	}
+	mmHidePost.expectations = append(mmHidePost.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.HidePost return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockHidePostExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockHidePostResults{err}
+	return e.mock
+}
+
+// Times sets number of times Repository.HidePost should be invoked
+func (mmHidePost *mRepositoryMockHidePost) Times(n uint64) *mRepositoryMockHidePost {
+	if n == 0 {
+		mmHidePost.mock.t.Fatalf("Times of RepositoryMock.HidePost mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmHidePost.expectedInvocations, n)
+	return mmHidePost
+}
+
+func (mmHidePost *mRepositoryMockHidePost) invocationsDone() bool {
+	if len(mmHidePost.expectations) == 0 && mmHidePost.defaultExpectation == nil && mmHidePost.mock.funcHidePost == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmHidePost.mock.afterHidePostCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmHidePost.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// HidePost implements repository.Repository
+func (mmHidePost *RepositoryMock) HidePost(ctx context.Context, id int) (err error) {
+	mm_atomic.AddUint64(&mmHidePost.beforeHidePostCounter, 1)
+	defer mm_atomic.AddUint64(&mmHidePost.afterHidePostCounter, 1)
+
+	if mmHidePost.inspectFuncHidePost != nil {
+		mmHidePost.inspectFuncHidePost(ctx, id)
+	}
+
+	mm_params := RepositoryMockHidePostParams{ctx, id}
+
+	// Record call args
+	mmHidePost.HidePostMock.mutex.Lock()
+	mmHidePost.HidePostMock.callArgs = append(mmHidePost.HidePostMock.callArgs, &mm_params)
+	mmHidePost.HidePostMock.mutex.Unlock()
+
+	for _, e := range mmHidePost.HidePostMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmHidePost.HidePostMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmHidePost.HidePostMock.defaultExpectation.Counter, 1)
+		mm_want := mmHidePost.HidePostMock.defaultExpectation.params
+		mm_want_ptrs := mmHidePost.HidePostMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockHidePostParams{ctx, id}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmHidePost.t.Errorf("RepositoryMock.HidePost got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
+				mmHidePost.t.Errorf("RepositoryMock.HidePost got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmHidePost.t.Errorf("RepositoryMock.HidePost got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmHidePost.HidePostMock.defaultExpectation.results
+		if mm_results == nil {
+			mmHidePost.t.Fatal("No results are set for the RepositoryMock.HidePost")
+		}
+		return (*mm_results).err
+	}
+	if mmHidePost.funcHidePost != nil {
+		return mmHidePost.funcHidePost(ctx, id)
+	}
+	mmHidePost.t.Fatalf("Unexpected call to RepositoryMock.HidePost. %v %v", ctx, id)
+	return
+}
+
+// HidePostAfterCounter returns a count of finished RepositoryMock.HidePost invocations
+func (mmHidePost *RepositoryMock) HidePostAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHidePost.afterHidePostCounter)
+}
+
+// HidePostBeforeCounter returns a count of RepositoryMock.HidePost invocations
+func (mmHidePost *RepositoryMock) HidePostBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHidePost.beforeHidePostCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.HidePost.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmHidePost *mRepositoryMockHidePost) Calls() []*RepositoryMockHidePostParams {
+	mmHidePost.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockHidePostParams, len(mmHidePost.callArgs))
+	copy(argCopy, mmHidePost.callArgs)
+
+	mmHidePost.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockHidePostDone returns true if the count of the HidePost invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockHidePostDone() bool {
+	for _, e := range m.HidePostMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.HidePostMock.invocationsDone()
+}
+
+// MinimockHidePostInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockHidePostInspect() {
+	for _, e := range m.HidePostMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.HidePost with params: %#v", *e.params)
+		}
+	}
+
+	afterHidePostCounter := mm_atomic.LoadUint64(&m.afterHidePostCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.HidePostMock.defaultExpectation != nil && afterHidePostCounter < 1 {
+		if m.HidePostMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.HidePost")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.HidePost with params: %#v", *m.HidePostMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcHidePost != nil && afterHidePostCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.HidePost")
+	}
+
+	if !m.HidePostMock.invocationsDone() && afterHidePostCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.HidePost but found %d calls",
+			mm_atomic.LoadUint64(&m.HidePostMock.expectedInvocations), afterHidePostCounter)
+	}
+}
+
+type mRepositoryMockImportPosts struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockImportPostsExpectation
+	expectations       []*RepositoryMockImportPostsExpectation
+
+	callArgs []*RepositoryMockImportPostsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockImportPostsExpectation specifies expectation struct of the Repository.ImportPosts
+type RepositoryMockImportPostsExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockImportPostsParams
+	paramPtrs *RepositoryMockImportPostsParamPtrs
+	results   *RepositoryMockImportPostsResults
+	Counter   uint64
+}
+
+// RepositoryMockImportPostsParams contains parameters of the Repository.ImportPosts
+type RepositoryMockImportPostsParams struct {
+	ctx      context.Context
+	posts    []*domain.Post
+	comments []*domain.Comment
+}
+
+// RepositoryMockImportPostsParamPtrs contains pointers to parameters of the Repository.ImportPosts
+type RepositoryMockImportPostsParamPtrs struct {
+	ctx      *context.Context
+	posts    *[]*domain.Post
+	comments *[]*domain.Comment
+}
+
+// RepositoryMockImportPostsResults contains results of the Repository.ImportPosts
+type RepositoryMockImportPostsResults struct {
+	err error
+}
+
+// Expect sets up expected params for Repository.ImportPosts
+func (mmImportPosts *mRepositoryMockImportPosts) Expect(ctx context.Context, posts []*domain.Post, comments []*domain.Comment) *mRepositoryMockImportPosts {
+	if mmImportPosts.mock.funcImportPosts != nil {
+		mmImportPosts.mock.t.Fatalf("RepositoryMock.ImportPosts mock is already set by Set")
+	}
+
+	if mmImportPosts.defaultExpectation == nil {
+		mmImportPosts.defaultExpectation = &RepositoryMockImportPostsExpectation{}
+	}
+
+	if mmImportPosts.defaultExpectation.paramPtrs != nil {
+		mmImportPosts.mock.t.Fatalf("RepositoryMock.ImportPosts mock is already set by ExpectParams functions")
+	}
+
+	mmImportPosts.defaultExpectation.params = &RepositoryMockImportPostsParams{ctx, posts, comments}
+	for _, e := range mmImportPosts.expectations {
+		if minimock.Equal(e.params, mmImportPosts.defaultExpectation.params) {
+			mmImportPosts.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmImportPosts.defaultExpectation.params)
+		}
+	}
+
+	return mmImportPosts
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.ImportPosts
+func (mmImportPosts *mRepositoryMockImportPosts) ExpectCtxParam1(ctx context.Context) *mRepositoryMockImportPosts {
+	if mmImportPosts.mock.funcImportPosts != nil {
+		mmImportPosts.mock.t.Fatalf("RepositoryMock.ImportPosts mock is already set by Set")
+	}
+
+	if mmImportPosts.defaultExpectation == nil {
+		mmImportPosts.defaultExpectation = &RepositoryMockImportPostsExpectation{}
+	}
+
+	if mmImportPosts.defaultExpectation.params != nil {
+		mmImportPosts.mock.t.Fatalf("RepositoryMock.ImportPosts mock is already set by Expect")
+	}
+
+	if mmImportPosts.defaultExpectation.paramPtrs == nil {
+		mmImportPosts.defaultExpectation.paramPtrs = &RepositoryMockImportPostsParamPtrs{}
+	}
+	mmImportPosts.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmImportPosts
+}
+
+// ExpectPostsParam2 sets up expected param posts for Repository.ImportPosts
+func (mmImportPosts *mRepositoryMockImportPosts) ExpectPostsParam2(posts []*domain.Post) *mRepositoryMockImportPosts {
+	if mmImportPosts.mock.funcImportPosts != nil {
+		mmImportPosts.mock.t.Fatalf("RepositoryMock.ImportPosts mock is already set by Set")
+	}
+
+	if mmImportPosts.defaultExpectation == nil {
+		mmImportPosts.defaultExpectation = &RepositoryMockImportPostsExpectation{}
+	}
+
+	if mmImportPosts.defaultExpectation.params != nil {
+		mmImportPosts.mock.t.Fatalf("RepositoryMock.ImportPosts mock is already set by Expect")
+	}
+
+	if mmImportPosts.defaultExpectation.paramPtrs == nil {
+		mmImportPosts.defaultExpectation.paramPtrs = &RepositoryMockImportPostsParamPtrs{}
+	}
+	mmImportPosts.defaultExpectation.paramPtrs.posts = &posts
+
+	return mmImportPosts
+}
+
+// ExpectCommentsParam3 sets up expected param comments for Repository.ImportPosts
+func (mmImportPosts *mRepositoryMockImportPosts) ExpectCommentsParam3(comments []*domain.Comment) *mRepositoryMockImportPosts {
+	if mmImportPosts.mock.funcImportPosts != nil {
+		mmImportPosts.mock.t.Fatalf("RepositoryMock.ImportPosts mock is already set by Set")
+	}
+
+	if mmImportPosts.defaultExpectation == nil {
+		mmImportPosts.defaultExpectation = &RepositoryMockImportPostsExpectation{}
+	}
+
+	if mmImportPosts.defaultExpectation.params != nil {
+		mmImportPosts.mock.t.Fatalf("RepositoryMock.ImportPosts mock is already set by Expect")
+	}
+
+	if mmImportPosts.defaultExpectation.paramPtrs == nil {
+		mmImportPosts.defaultExpectation.paramPtrs = &RepositoryMockImportPostsParamPtrs{}
+	}
+	mmImportPosts.defaultExpectation.paramPtrs.comments = &comments
+
+	return mmImportPosts
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.ImportPosts
+func (mmImportPosts *mRepositoryMockImportPosts) Inspect(f func(ctx context.Context, posts []*domain.Post, comments []*domain.Comment)) *mRepositoryMockImportPosts {
+	if mmImportPosts.mock.inspectFuncImportPosts != nil {
+		mmImportPosts.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ImportPosts")
+	}
+
+	mmImportPosts.mock.inspectFuncImportPosts = f
+
+	return mmImportPosts
+}
+
+// Return sets up results that will be returned by Repository.ImportPosts
+func (mmImportPosts *mRepositoryMockImportPosts) Return(err error) *RepositoryMock {
+	if mmImportPosts.mock.funcImportPosts != nil {
+		mmImportPosts.mock.t.Fatalf("RepositoryMock.ImportPosts mock is already set by Set")
+	}
+
+	if mmImportPosts.defaultExpectation == nil {
+		mmImportPosts.defaultExpectation = &RepositoryMockImportPostsExpectation{mock: mmImportPosts.mock}
+	}
+	mmImportPosts.defaultExpectation.results = &RepositoryMockImportPostsResults{err}
+	return mmImportPosts.mock
+}
+
+// Set uses given function f to mock the Repository.ImportPosts method
+func (mmImportPosts *mRepositoryMockImportPosts) Set(f func(ctx context.Context, posts []*domain.Post, comments []*domain.Comment) (err error)) *RepositoryMock {
+	if mmImportPosts.defaultExpectation != nil {
+		mmImportPosts.mock.t.Fatalf("Default expectation is already set for the Repository.ImportPosts method")
+	}
+
+	if len(mmImportPosts.expectations) > 0 {
+		mmImportPosts.mock.t.Fatalf("Some expectations are already set for the Repository.ImportPosts method")
+	}
+
+	mmImportPosts.mock.funcImportPosts = f
+	return mmImportPosts.mock
+}
+
+// When sets expectation for the Repository.ImportPosts which will trigger the result defined by the following
+// Then helper
+func (mmImportPosts *mRepositoryMockImportPosts) When(ctx context.Context, posts []*domain.Post, comments []*domain.Comment) *RepositoryMockImportPostsExpectation {
+	if mmImportPosts.mock.funcImportPosts != nil {
+		mmImportPosts.mock.t.Fatalf("RepositoryMock.ImportPosts mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockImportPostsExpectation{
+		mock:   mmImportPosts.mock,
+		params: &RepositoryMockImportPostsParams{ctx, posts, comments},
+	}
+	mmImportPosts.expectations = append(mmImportPosts.expectations, expectation)
 	return expectation
 }
 
-// Then sets up Repository.DisableComments return parameters for the expectation previously defined by the When method
-func (e *RepositoryMockDisableCommentsExpectation) Then(err error) *RepositoryMock {
-	e.results = &RepositoryMockDisableCommentsResults{err}
+// Then sets up Repository.ImportPosts return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockImportPostsExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockImportPostsResults{err}
 	return e.mock
 }
 
-// Times sets number of times Repository.DisableComments should be invoked
-func (mmDisableComments *mRepositoryMockDisableComments) Times(n uint64) *mRepositoryMockDisableComments {
+// Times sets number of times Repository.ImportPosts should be invoked
+func (mmImportPosts *mRepositoryMockImportPosts) Times(n uint64) *mRepositoryMockImportPosts {
 	if n == 0 {
-		mmDisableComments.mock.t.Fatalf("Times of RepositoryMock.DisableComments mock can not be zero")
+		mmImportPosts.mock.t.Fatalf("Times of RepositoryMock.ImportPosts mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmDisableComments.expectedInvocations, n)
-	return mmDisableComments
+	mm_atomic.StoreUint64(&mmImportPosts.expectedInvocations, n)
+	return mmImportPosts
 }
 
-func (mmDisableComments *mRepositoryMockDisableComments) invocationsDone() bool {
-	if len(mmDisableComments.expectations) == 0 && mmDisableComments.defaultExpectation == nil && mmDisableComments.mock.funcDisableComments == nil {
+func (mmImportPosts *mRepositoryMockImportPosts) invocationsDone() bool {
+	if len(mmImportPosts.expectations) == 0 && mmImportPosts.defaultExpectation == nil && mmImportPosts.mock.funcImportPosts == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmImportPosts.mock.afterImportPostsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmImportPosts.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ImportPosts implements repository.Repository
+func (mmImportPosts *RepositoryMock) ImportPosts(ctx context.Context, posts []*domain.Post, comments []*domain.Comment) (err error) {
+	mm_atomic.AddUint64(&mmImportPosts.beforeImportPostsCounter, 1)
+	defer mm_atomic.AddUint64(&mmImportPosts.afterImportPostsCounter, 1)
+
+	if mmImportPosts.inspectFuncImportPosts != nil {
+		mmImportPosts.inspectFuncImportPosts(ctx, posts, comments)
+	}
+
+	mm_params := RepositoryMockImportPostsParams{ctx, posts, comments}
+
+	// Record call args
+	mmImportPosts.ImportPostsMock.mutex.Lock()
+	mmImportPosts.ImportPostsMock.callArgs = append(mmImportPosts.ImportPostsMock.callArgs, &mm_params)
+	mmImportPosts.ImportPostsMock.mutex.Unlock()
+
+	for _, e := range mmImportPosts.ImportPostsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmImportPosts.ImportPostsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmImportPosts.ImportPostsMock.defaultExpectation.Counter, 1)
+		mm_want := mmImportPosts.ImportPostsMock.defaultExpectation.params
+		mm_want_ptrs := mmImportPosts.ImportPostsMock.defaultExpectation.paramPtrs
+
+		mm_got := RepositoryMockImportPostsParams{ctx, posts, comments}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmImportPosts.t.Errorf("RepositoryMock.ImportPosts got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.posts != nil && !minimock.Equal(*mm_want_ptrs.posts, mm_got.posts) {
+				mmImportPosts.t.Errorf("RepositoryMock.ImportPosts got unexpected parameter posts, want: %#v, got: %#v%s\n", *mm_want_ptrs.posts, mm_got.posts, minimock.Diff(*mm_want_ptrs.posts, mm_got.posts))
+			}
+
+			if mm_want_ptrs.comments != nil && !minimock.Equal(*mm_want_ptrs.comments, mm_got.comments) {
+				mmImportPosts.t.Errorf("RepositoryMock.ImportPosts got unexpected parameter comments, want: %#v, got: %#v%s\n", *mm_want_ptrs.comments, mm_got.comments, minimock.Diff(*mm_want_ptrs.comments, mm_got.comments))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmImportPosts.t.Errorf("RepositoryMock.ImportPosts got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmImportPosts.ImportPostsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmImportPosts.t.Fatal("No results are set for the RepositoryMock.ImportPosts")
+		}
+		return (*mm_results).err
+	}
+	if mmImportPosts.funcImportPosts != nil {
+		return mmImportPosts.funcImportPosts(ctx, posts, comments)
+	}
+	mmImportPosts.t.Fatalf("Unexpected call to RepositoryMock.ImportPosts. %v %v %v", ctx, posts, comments)
+	return
+}
+
+// ImportPostsAfterCounter returns a count of finished RepositoryMock.ImportPosts invocations
+func (mmImportPosts *RepositoryMock) ImportPostsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmImportPosts.afterImportPostsCounter)
+}
+
+// ImportPostsBeforeCounter returns a count of RepositoryMock.ImportPosts invocations
+func (mmImportPosts *RepositoryMock) ImportPostsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmImportPosts.beforeImportPostsCounter)
+}
+
+// Calls returns a list of arguments used in each call to RepositoryMock.ImportPosts.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmImportPosts *mRepositoryMockImportPosts) Calls() []*RepositoryMockImportPostsParams {
+	mmImportPosts.mutex.RLock()
+
+	argCopy := make([]*RepositoryMockImportPostsParams, len(mmImportPosts.callArgs))
+	copy(argCopy, mmImportPosts.callArgs)
+
+	mmImportPosts.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockImportPostsDone returns true if the count of the ImportPosts invocations corresponds
+// the number of defined expectations
+func (m *RepositoryMock) MinimockImportPostsDone() bool {
+	for _, e := range m.ImportPostsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ImportPostsMock.invocationsDone()
+}
+
+// MinimockImportPostsInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockImportPostsInspect() {
+	for _, e := range m.ImportPostsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to RepositoryMock.ImportPosts with params: %#v", *e.params)
+		}
+	}
+
+	afterImportPostsCounter := mm_atomic.LoadUint64(&m.afterImportPostsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ImportPostsMock.defaultExpectation != nil && afterImportPostsCounter < 1 {
+		if m.ImportPostsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.ImportPosts")
+		} else {
+			m.t.Errorf("Expected call to RepositoryMock.ImportPosts with params: %#v", *m.ImportPostsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcImportPosts != nil && afterImportPostsCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.ImportPosts")
+	}
+
+	if !m.ImportPostsMock.invocationsDone() && afterImportPostsCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ImportPosts but found %d calls",
+			mm_atomic.LoadUint64(&m.ImportPostsMock.expectedInvocations), afterImportPostsCounter)
+	}
+}
+
+type mRepositoryMockIsBanned struct {
+	mock               *RepositoryMock
+	defaultExpectation *RepositoryMockIsBannedExpectation
+	expectations       []*RepositoryMockIsBannedExpectation
+
+	callArgs []*RepositoryMockIsBannedParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// RepositoryMockIsBannedExpectation specifies expectation struct of the Repository.IsBanned
+type RepositoryMockIsBannedExpectation struct {
+	mock      *RepositoryMock
+	params    *RepositoryMockIsBannedParams
+	paramPtrs *RepositoryMockIsBannedParamPtrs
+	results   *RepositoryMockIsBannedResults
+	Counter   uint64
+}
+
+// RepositoryMockIsBannedParams contains parameters of the Repository.IsBanned
+type RepositoryMockIsBannedParams struct {
+	ctx      context.Context
+	authorID int
+}
+
+// RepositoryMockIsBannedParamPtrs contains pointers to parameters of the Repository.IsBanned
+type RepositoryMockIsBannedParamPtrs struct {
+	ctx      *context.Context
+	authorID *int
+}
+
+// RepositoryMockIsBannedResults contains results of the Repository.IsBanned
+type RepositoryMockIsBannedResults struct {
+	b1  bool
+	err error
+}
+
+// Expect sets up expected params for Repository.IsBanned
+func (mmIsBanned *mRepositoryMockIsBanned) Expect(ctx context.Context, authorID int) *mRepositoryMockIsBanned {
+	if mmIsBanned.mock.funcIsBanned != nil {
+		mmIsBanned.mock.t.Fatalf("RepositoryMock.IsBanned mock is already set by Set")
+	}
+
+	if mmIsBanned.defaultExpectation == nil {
+		mmIsBanned.defaultExpectation = &RepositoryMockIsBannedExpectation{}
+	}
+
+	if mmIsBanned.defaultExpectation.paramPtrs != nil {
+		mmIsBanned.mock.t.Fatalf("RepositoryMock.IsBanned mock is already set by ExpectParams functions")
+	}
+
+	mmIsBanned.defaultExpectation.params = &RepositoryMockIsBannedParams{ctx, authorID}
+	for _, e := range mmIsBanned.expectations {
+		if minimock.Equal(e.params, mmIsBanned.defaultExpectation.params) {
+			mmIsBanned.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmIsBanned.defaultExpectation.params)
+		}
+	}
+
+	return mmIsBanned
+}
+
+// ExpectCtxParam1 sets up expected param ctx for Repository.IsBanned
+func (mmIsBanned *mRepositoryMockIsBanned) ExpectCtxParam1(ctx context.Context) *mRepositoryMockIsBanned {
+	if mmIsBanned.mock.funcIsBanned != nil {
+		mmIsBanned.mock.t.Fatalf("RepositoryMock.IsBanned mock is already set by Set")
+	}
+
+	if mmIsBanned.defaultExpectation == nil {
+		mmIsBanned.defaultExpectation = &RepositoryMockIsBannedExpectation{}
+	}
+
+	if mmIsBanned.defaultExpectation.params != nil {
+		mmIsBanned.mock.t.Fatalf("RepositoryMock.IsBanned mock is already set by Expect")
+	}
+
+	if mmIsBanned.defaultExpectation.paramPtrs == nil {
+		mmIsBanned.defaultExpectation.paramPtrs = &RepositoryMockIsBannedParamPtrs{}
+	}
+	mmIsBanned.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmIsBanned
+}
+
+// ExpectAuthorIDParam2 sets up expected param authorID for Repository.IsBanned
+func (mmIsBanned *mRepositoryMockIsBanned) ExpectAuthorIDParam2(authorID int) *mRepositoryMockIsBanned {
+	if mmIsBanned.mock.funcIsBanned != nil {
+		mmIsBanned.mock.t.Fatalf("RepositoryMock.IsBanned mock is already set by Set")
+	}
+
+	if mmIsBanned.defaultExpectation == nil {
+		mmIsBanned.defaultExpectation = &RepositoryMockIsBannedExpectation{}
+	}
+
+	if mmIsBanned.defaultExpectation.params != nil {
+		mmIsBanned.mock.t.Fatalf("RepositoryMock.IsBanned mock is already set by Expect")
+	}
+
+	if mmIsBanned.defaultExpectation.paramPtrs == nil {
+		mmIsBanned.defaultExpectation.paramPtrs = &RepositoryMockIsBannedParamPtrs{}
+	}
+	mmIsBanned.defaultExpectation.paramPtrs.authorID = &authorID
+
+	return mmIsBanned
+}
+
+// Inspect accepts an inspector function that has same arguments as the Repository.IsBanned
+func (mmIsBanned *mRepositoryMockIsBanned) Inspect(f func(ctx context.Context, authorID int)) *mRepositoryMockIsBanned {
+	if mmIsBanned.mock.inspectFuncIsBanned != nil {
+		mmIsBanned.mock.t.Fatalf("Inspect function is already set for RepositoryMock.IsBanned")
+	}
+
+	mmIsBanned.mock.inspectFuncIsBanned = f
+
+	return mmIsBanned
+}
+
+// Return sets up results that will be returned by Repository.IsBanned
+func (mmIsBanned *mRepositoryMockIsBanned) Return(b1 bool, err error) *RepositoryMock {
+	if mmIsBanned.mock.funcIsBanned != nil {
+		mmIsBanned.mock.t.Fatalf("RepositoryMock.IsBanned mock is already set by Set")
+	}
+
+	if mmIsBanned.defaultExpectation == nil {
+		mmIsBanned.defaultExpectation = &RepositoryMockIsBannedExpectation{mock: mmIsBanned.mock}
+	}
+	mmIsBanned.defaultExpectation.results = &RepositoryMockIsBannedResults{b1, err}
+	return mmIsBanned.mock
+}
+
+// Set uses given function f to mock the Repository.IsBanned method
+func (mmIsBanned *mRepositoryMockIsBanned) Set(f func(ctx context.Context, authorID int) (b1 bool, err error)) *RepositoryMock {
+	if mmIsBanned.defaultExpectation != nil {
+		mmIsBanned.mock.t.Fatalf("Default expectation is already set for the Repository.IsBanned method")
+	}
+
+	if len(mmIsBanned.expectations) > 0 {
+		mmIsBanned.mock.t.Fatalf("Some expectations are already set for the Repository.IsBanned method")
+	}
+
+	mmIsBanned.mock.funcIsBanned = f
+	return mmIsBanned.mock
+}
+
+// When sets expectation for the Repository.IsBanned which will trigger the result defined by the following
+// Then helper
+func (mmIsBanned *mRepositoryMockIsBanned) When(ctx context.Context, authorID int) *RepositoryMockIsBannedExpectation {
+	if mmIsBanned.mock.funcIsBanned != nil {
+		mmIsBanned.mock.t.Fatalf("RepositoryMock.IsBanned mock is already set by Set")
+	}
+
+	expectation := &RepositoryMockIsBannedExpectation{
+		mock:   mmIsBanned.mock,
+		params: &RepositoryMockIsBannedParams{ctx, authorID},
+	}
+	mmIsBanned.expectations = append(mmIsBanned.expectations, expectation)
+	return expectation
+}
+
+// Then sets up Repository.IsBanned return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockIsBannedExpectation) Then(b1 bool, err error) *RepositoryMock {
+	e.results = &RepositoryMockIsBannedResults{b1, err}
+	return e.mock
+}
+
+// Times sets number of times Repository.IsBanned should be invoked
+func (mmIsBanned *mRepositoryMockIsBanned) Times(n uint64) *mRepositoryMockIsBanned {
+	if n == 0 {
+		mmIsBanned.mock.t.Fatalf("Times of RepositoryMock.IsBanned mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmIsBanned.expectedInvocations, n)
+	return mmIsBanned
+}
+
+func (mmIsBanned *mRepositoryMockIsBanned) invocationsDone() bool {
+	if len(mmIsBanned.expectations) == 0 && mmIsBanned.defaultExpectation == nil && mmIsBanned.mock.funcIsBanned == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmDisableComments.mock.afterDisableCommentsCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmDisableComments.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmIsBanned.mock.afterIsBannedCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmIsBanned.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// DisableComments implements repository.Repository
-func (mmDisableComments *RepositoryMock) DisableComments(ctx context.Context, postID int) (err error) {
-	mm_atomic.AddUint64(&mmDisableComments.beforeDisableCommentsCounter, 1)
-	defer mm_atomic.AddUint64(&mmDisableComments.afterDisableCommentsCounter, 1)
+// IsBanned implements repository.Repository
+func (mmIsBanned *RepositoryMock) IsBanned(ctx context.Context, authorID int) (b1 bool, err error) {
+	mm_atomic.AddUint64(&mmIsBanned.beforeIsBannedCounter, 1)
+	defer mm_atomic.AddUint64(&mmIsBanned.afterIsBannedCounter, 1)
 
-	if mmDisableComments.inspectFuncDisableComments != nil {
-		mmDisableComments.inspectFuncDisableComments(ctx, postID)
+	if mmIsBanned.inspectFuncIsBanned != nil {
+		mmIsBanned.inspectFuncIsBanned(ctx, authorID)
 	}
 
-	mm_params := RepositoryMockDisableCommentsParams{ctx, postID}
+	mm_params := RepositoryMockIsBannedParams{ctx, authorID}
 
 	// Record call args
-	mmDisableComments.DisableCommentsMock.mutex.Lock()
-	mmDisableComments.DisableCommentsMock.callArgs = append(mmDisableComments.DisableCommentsMock.callArgs, &mm_params)
-	mmDisableComments.DisableCommentsMock.mutex.Unlock()
+	mmIsBanned.IsBannedMock.mutex.Lock()
+	mmIsBanned.IsBannedMock.callArgs = append(mmIsBanned.IsBannedMock.callArgs, &mm_params)
+	mmIsBanned.IsBannedMock.mutex.Unlock()
 
-	for _, e := range mmDisableComments.DisableCommentsMock.expectations {
+	for _, e := range mmIsBanned.IsBannedMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.b1, e.results.err
 		}
 	}
 
-	if mmDisableComments.DisableCommentsMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmDisableComments.DisableCommentsMock.defaultExpectation.Counter, 1)
-		mm_want := mmDisableComments.DisableCommentsMock.defaultExpectation.params
-		mm_want_ptrs := mmDisableComments.DisableCommentsMock.defaultExpectation.paramPtrs
+	if mmIsBanned.IsBannedMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmIsBanned.IsBannedMock.defaultExpectation.Counter, 1)
+		mm_want := mmIsBanned.IsBannedMock.defaultExpectation.params
+		mm_want_ptrs := mmIsBanned.IsBannedMock.defaultExpectation.paramPtrs
 
-		mm_got := RepositoryMockDisableCommentsParams{ctx, postID}
+		mm_got := RepositoryMockIsBannedParams{ctx, authorID}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmDisableComments.t.Errorf("RepositoryMock.DisableComments got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmIsBanned.t.Errorf("RepositoryMock.IsBanned got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.postID != nil && !minimock.Equal(*mm_want_ptrs.postID, mm_got.postID) {
-				mmDisableComments.t.Errorf("RepositoryMock.DisableComments got unexpected parameter postID, want: %#v, got: %#v%s\n", *mm_want_ptrs.postID, mm_got.postID, minimock.Diff(*mm_want_ptrs.postID, mm_got.postID))
+			if mm_want_ptrs.authorID != nil && !minimock.Equal(*mm_want_ptrs.authorID, mm_got.authorID) {
+				mmIsBanned.t.Errorf("RepositoryMock.IsBanned got unexpected parameter authorID, want: %#v, got: %#v%s\n", *mm_want_ptrs.authorID, mm_got.authorID, minimock.Diff(*mm_want_ptrs.authorID, mm_got.authorID))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmDisableComments.t.Errorf("RepositoryMock.DisableComments got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmIsBanned.t.Errorf("RepositoryMock.IsBanned got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmDisableComments.DisableCommentsMock.defaultExpectation.results
+		mm_results := mmIsBanned.IsBannedMock.defaultExpectation.results
 		if mm_results == nil {
-			mmDisableComments.t.Fatal("No results are set for the RepositoryMock.DisableComments")
+			mmIsBanned.t.Fatal("No results are set for the RepositoryMock.IsBanned")
 		}
-		return (*mm_results).err
+		return (*mm_results).b1, (*mm_results).err
 	}
-	if mmDisableComments.funcDisableComments != nil {
-		return mmDisableComments.funcDisableComments(ctx, postID)
+	if mmIsBanned.funcIsBanned != nil {
+		return mmIsBanned.funcIsBanned(ctx, authorID)
 	}
-	mmDisableComments.t.Fatalf("Unexpected call to RepositoryMock.DisableComments. %v %v", ctx, postID)
+	mmIsBanned.t.Fatalf("Unexpected call to RepositoryMock.IsBanned. %v %v", ctx, authorID)
 	return
 }
 
-// DisableCommentsAfterCounter returns a count of finished RepositoryMock.DisableComments invocations
-func (mmDisableComments *RepositoryMock) DisableCommentsAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmDisableComments.afterDisableCommentsCounter)
+// IsBannedAfterCounter returns a count of finished RepositoryMock.IsBanned invocations
+func (mmIsBanned *RepositoryMock) IsBannedAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmIsBanned.afterIsBannedCounter)
 }
 
-// DisableCommentsBeforeCounter returns a count of RepositoryMock.DisableComments invocations
-func (mmDisableComments *RepositoryMock) DisableCommentsBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmDisableComments.beforeDisableCommentsCounter)
+// IsBannedBeforeCounter returns a count of RepositoryMock.IsBanned invocations
+func (mmIsBanned *RepositoryMock) IsBannedBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmIsBanned.beforeIsBannedCounter)
 }
 
-// Calls returns a list of arguments used in each call to RepositoryMock.DisableComments.
+// Calls returns a list of arguments used in each call to RepositoryMock.IsBanned.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmDisableComments *mRepositoryMockDisableComments) Calls() []*RepositoryMockDisableCommentsParams {
-	mmDisableComments.mutex.RLock()
+func (mmIsBanned *mRepositoryMockIsBanned) Calls() []*RepositoryMockIsBannedParams {
+	mmIsBanned.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockDisableCommentsParams, len(mmDisableComments.callArgs))
-	copy(argCopy, mmDisableComments.callArgs)
+	argCopy := make([]*RepositoryMockIsBannedParams, len(mmIsBanned.callArgs))
+	copy(argCopy, mmIsBanned.callArgs)
 
-	mmDisableComments.mutex.RUnlock()
+	mmIsBanned.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockDisableCommentsDone returns true if the count of the DisableComments invocations corresponds
+// MinimockIsBannedDone returns true if the count of the IsBanned invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockDisableCommentsDone() bool {
-	for _, e := range m.DisableCommentsMock.expectations {
+func (m *RepositoryMock) MinimockIsBannedDone() bool {
+	for _, e := range m.IsBannedMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.DisableCommentsMock.invocationsDone()
+	return m.IsBannedMock.invocationsDone()
 }
 
-// MinimockDisableCommentsInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockDisableCommentsInspect() {
-	for _, e := range m.DisableCommentsMock.expectations {
+// MinimockIsBannedInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockIsBannedInspect() {
+	for _, e := range m.IsBannedMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.DisableComments with params: %#v", *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.IsBanned with params: %#v", *e.params)
 		}
 	}
 
-	afterDisableCommentsCounter := mm_atomic.LoadUint64(&m.afterDisableCommentsCounter)
+	afterIsBannedCounter := mm_atomic.LoadUint64(&m.afterIsBannedCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.DisableCommentsMock.defaultExpectation != nil && afterDisableCommentsCounter < 1 {
-		if m.DisableCommentsMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to RepositoryMock.DisableComments")
+	if m.IsBannedMock.defaultExpectation != nil && afterIsBannedCounter < 1 {
+		if m.IsBannedMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.IsBanned")
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.DisableComments with params: %#v", *m.DisableCommentsMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.IsBanned with params: %#v", *m.IsBannedMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcDisableComments != nil && afterDisableCommentsCounter < 1 {
-		m.t.Error("Expected call to RepositoryMock.DisableComments")
+	if m.funcIsBanned != nil && afterIsBannedCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.IsBanned")
 	}
 
-	if !m.DisableCommentsMock.invocationsDone() && afterDisableCommentsCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.DisableComments but found %d calls",
-			mm_atomic.LoadUint64(&m.DisableCommentsMock.expectedInvocations), afterDisableCommentsCounter)
+	if !m.IsBannedMock.invocationsDone() && afterIsBannedCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.IsBanned but found %d calls",
+			mm_atomic.LoadUint64(&m.IsBannedMock.expectedInvocations), afterIsBannedCounter)
 	}
 }
 
-type mRepositoryMockGetCommentsByParent struct {
+type mRepositoryMockMarkEventsPublished struct {
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockGetCommentsByParentExpectation
-	expectations       []*RepositoryMockGetCommentsByParentExpectation
+	defaultExpectation *RepositoryMockMarkEventsPublishedExpectation
+	expectations       []*RepositoryMockMarkEventsPublishedExpectation
 
-	callArgs []*RepositoryMockGetCommentsByParentParams
+	callArgs []*RepositoryMockMarkEventsPublishedParams
 	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// RepositoryMockGetCommentsByParentExpectation specifies expectation struct of the Repository.GetCommentsByParent
-type RepositoryMockGetCommentsByParentExpectation struct {
+// RepositoryMockMarkEventsPublishedExpectation specifies expectation struct of the Repository.MarkEventsPublished
+type RepositoryMockMarkEventsPublishedExpectation struct {
 	mock      *RepositoryMock
-	params    *RepositoryMockGetCommentsByParentParams
-	paramPtrs *RepositoryMockGetCommentsByParentParamPtrs
-	results   *RepositoryMockGetCommentsByParentResults
+	params    *RepositoryMockMarkEventsPublishedParams
+	paramPtrs *RepositoryMockMarkEventsPublishedParamPtrs
+	results   *RepositoryMockMarkEventsPublishedResults
 	Counter   uint64
 }
 
-// RepositoryMockGetCommentsByParentParams contains parameters of the Repository.GetCommentsByParent
-type RepositoryMockGetCommentsByParentParams struct {
-	ctx      context.Context
-	parentId int
-	limit    int
-	offset   int
+// RepositoryMockMarkEventsPublishedParams contains parameters of the Repository.MarkEventsPublished
+type RepositoryMockMarkEventsPublishedParams struct {
+	ctx         context.Context
+	ids         []int
+	publishedAt time.Time
 }
 
-// RepositoryMockGetCommentsByParentParamPtrs contains pointers to parameters of the Repository.GetCommentsByParent
-type RepositoryMockGetCommentsByParentParamPtrs struct {
-	ctx      *context.Context
-	parentId *int
-	limit    *int
-	offset   *int
+// RepositoryMockMarkEventsPublishedParamPtrs contains pointers to parameters of the Repository.MarkEventsPublished
+type RepositoryMockMarkEventsPublishedParamPtrs struct {
+	ctx         *context.Context
+	ids         *[]int
+	publishedAt *time.Time
 }
 
-// RepositoryMockGetCommentsByParentResults contains results of the Repository.GetCommentsByParent
-type RepositoryMockGetCommentsByParentResults struct {
-	cpa1 []*domain.Comment
-	err  error
+// RepositoryMockMarkEventsPublishedResults contains results of the Repository.MarkEventsPublished
+type RepositoryMockMarkEventsPublishedResults struct {
+	err error
 }
 
-// Expect sets up expected params for Repository.GetCommentsByParent
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Expect(ctx context.Context, parentId int, limit int, offset int) *mRepositoryMockGetCommentsByParent {
-	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+// Expect sets up expected params for Repository.MarkEventsPublished
+func (mmMarkEventsPublished *mRepositoryMockMarkEventsPublished) Expect(ctx context.Context, ids []int, publishedAt time.Time) *mRepositoryMockMarkEventsPublished {
+	if mmMarkEventsPublished.mock.funcMarkEventsPublished != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("RepositoryMock.MarkEventsPublished mock is already set by Set")
 	}
 
-	if mmGetCommentsByParent.defaultExpectation == nil {
-		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{}
+	if mmMarkEventsPublished.defaultExpectation == nil {
+		mmMarkEventsPublished.defaultExpectation = &RepositoryMockMarkEventsPublishedExpectation{}
 	}
 
-	if mmGetCommentsByParent.defaultExpectation.paramPtrs != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by ExpectParams functions")
+	if mmMarkEventsPublished.defaultExpectation.paramPtrs != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("RepositoryMock.MarkEventsPublished mock is already set by ExpectParams functions")
 	}
 
-	mmGetCommentsByParent.defaultExpectation.params = &RepositoryMockGetCommentsByParentParams{ctx, parentId, limit, offset}
-	for _, e := range mmGetCommentsByParent.expectations {
-		if minimock.Equal(e.params, mmGetCommentsByParent.defaultExpectation.params) {
-			mmGetCommentsByParent.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetCommentsByParent.defaultExpectation.params)
+	mmMarkEventsPublished.defaultExpectation.params = &RepositoryMockMarkEventsPublishedParams{ctx, ids, publishedAt}
+	for _, e := range mmMarkEventsPublished.expectations {
+		if minimock.Equal(e.params, mmMarkEventsPublished.defaultExpectation.params) {
+			mmMarkEventsPublished.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmMarkEventsPublished.defaultExpectation.params)
 		}
 	}
 
-	return mmGetCommentsByParent
-}
-
-// ExpectCtxParam1 sets up expected param ctx for Repository.GetCommentsByParent
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetCommentsByParent {
-	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
-	}
-
-	if mmGetCommentsByParent.defaultExpectation == nil {
-		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{}
-	}
-
-	if mmGetCommentsByParent.defaultExpectation.params != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Expect")
-	}
-
-	if mmGetCommentsByParent.defaultExpectation.paramPtrs == nil {
-		mmGetCommentsByParent.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByParentParamPtrs{}
-	}
-	mmGetCommentsByParent.defaultExpectation.paramPtrs.ctx = &ctx
-
-	return mmGetCommentsByParent
+	return mmMarkEventsPublished
 }
 
-// ExpectParentIdParam2 sets up expected param parentId for Repository.GetCommentsByParent
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) ExpectParentIdParam2(parentId int) *mRepositoryMockGetCommentsByParent {
-	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Repository.MarkEventsPublished
+func (mmMarkEventsPublished *mRepositoryMockMarkEventsPublished) ExpectCtxParam1(ctx context.Context) *mRepositoryMockMarkEventsPublished {
+	if mmMarkEventsPublished.mock.funcMarkEventsPublished != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("RepositoryMock.MarkEventsPublished mock is already set by Set")
 	}
 
-	if mmGetCommentsByParent.defaultExpectation == nil {
-		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{}
+	if mmMarkEventsPublished.defaultExpectation == nil {
+		mmMarkEventsPublished.defaultExpectation = &RepositoryMockMarkEventsPublishedExpectation{}
 	}
 
-	if mmGetCommentsByParent.defaultExpectation.params != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Expect")
+	if mmMarkEventsPublished.defaultExpectation.params != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("RepositoryMock.MarkEventsPublished mock is already set by Expect")
 	}
 
-	if mmGetCommentsByParent.defaultExpectation.paramPtrs == nil {
-		mmGetCommentsByParent.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByParentParamPtrs{}
+	if mmMarkEventsPublished.defaultExpectation.paramPtrs == nil {
+		mmMarkEventsPublished.defaultExpectation.paramPtrs = &RepositoryMockMarkEventsPublishedParamPtrs{}
 	}
-	mmGetCommentsByParent.defaultExpectation.paramPtrs.parentId = &parentId
+	mmMarkEventsPublished.defaultExpectation.paramPtrs.ctx = &ctx
 
-	return mmGetCommentsByParent
+	return mmMarkEventsPublished
 }
 
-// ExpectLimitParam3 sets up expected param limit for Repository.GetCommentsByParent
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) ExpectLimitParam3(limit int) *mRepositoryMockGetCommentsByParent {
-	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+// ExpectIdsParam2 sets up expected param ids for Repository.MarkEventsPublished
+func (mmMarkEventsPublished *mRepositoryMockMarkEventsPublished) ExpectIdsParam2(ids []int) *mRepositoryMockMarkEventsPublished {
+	if mmMarkEventsPublished.mock.funcMarkEventsPublished != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("RepositoryMock.MarkEventsPublished mock is already set by Set")
 	}
 
-	if mmGetCommentsByParent.defaultExpectation == nil {
-		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{}
+	if mmMarkEventsPublished.defaultExpectation == nil {
+		mmMarkEventsPublished.defaultExpectation = &RepositoryMockMarkEventsPublishedExpectation{}
 	}
 
-	if mmGetCommentsByParent.defaultExpectation.params != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Expect")
+	if mmMarkEventsPublished.defaultExpectation.params != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("RepositoryMock.MarkEventsPublished mock is already set by Expect")
 	}
 
-	if mmGetCommentsByParent.defaultExpectation.paramPtrs == nil {
-		mmGetCommentsByParent.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByParentParamPtrs{}
+	if mmMarkEventsPublished.defaultExpectation.paramPtrs == nil {
+		mmMarkEventsPublished.defaultExpectation.paramPtrs = &RepositoryMockMarkEventsPublishedParamPtrs{}
 	}
-	mmGetCommentsByParent.defaultExpectation.paramPtrs.limit = &limit
+	mmMarkEventsPublished.defaultExpectation.paramPtrs.ids = &ids
 
-	return mmGetCommentsByParent
+	return mmMarkEventsPublished
 }
 
-// ExpectOffsetParam4 sets up expected param offset for Repository.GetCommentsByParent
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) ExpectOffsetParam4(offset int) *mRepositoryMockGetCommentsByParent {
-	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+// ExpectPublishedAtParam3 sets up expected param publishedAt for Repository.MarkEventsPublished
+func (mmMarkEventsPublished *mRepositoryMockMarkEventsPublished) ExpectPublishedAtParam3(publishedAt time.Time) *mRepositoryMockMarkEventsPublished {
+	if mmMarkEventsPublished.mock.funcMarkEventsPublished != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("RepositoryMock.MarkEventsPublished mock is already set by Set")
 	}
 
-	if mmGetCommentsByParent.defaultExpectation == nil {
-		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{}
+	if mmMarkEventsPublished.defaultExpectation == nil {
+		mmMarkEventsPublished.defaultExpectation = &RepositoryMockMarkEventsPublishedExpectation{}
 	}
 
-	if mmGetCommentsByParent.defaultExpectation.params != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Expect")
+	if mmMarkEventsPublished.defaultExpectation.params != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("RepositoryMock.MarkEventsPublished mock is already set by Expect")
 	}
 
-	if mmGetCommentsByParent.defaultExpectation.paramPtrs == nil {
-		mmGetCommentsByParent.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByParentParamPtrs{}
+	if mmMarkEventsPublished.defaultExpectation.paramPtrs == nil {
+		mmMarkEventsPublished.defaultExpectation.paramPtrs = &RepositoryMockMarkEventsPublishedParamPtrs{}
 	}
-	mmGetCommentsByParent.defaultExpectation.paramPtrs.offset = &offset
+	mmMarkEventsPublished.defaultExpectation.paramPtrs.publishedAt = &publishedAt
 
-	return mmGetCommentsByParent
+	return mmMarkEventsPublished
 }
 
-// Inspect accepts an inspector function that has same arguments as the Repository.GetCommentsByParent
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Inspect(f func(ctx context.Context, parentId int, limit int, offset int)) *mRepositoryMockGetCommentsByParent {
-	if mmGetCommentsByParent.mock.inspectFuncGetCommentsByParent != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetCommentsByParent")
+// Inspect accepts an inspector function that has same arguments as the Repository.MarkEventsPublished
+func (mmMarkEventsPublished *mRepositoryMockMarkEventsPublished) Inspect(f func(ctx context.Context, ids []int, publishedAt time.Time)) *mRepositoryMockMarkEventsPublished {
+	if mmMarkEventsPublished.mock.inspectFuncMarkEventsPublished != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("Inspect function is already set for RepositoryMock.MarkEventsPublished")
 	}
 
-	mmGetCommentsByParent.mock.inspectFuncGetCommentsByParent = f
+	mmMarkEventsPublished.mock.inspectFuncMarkEventsPublished = f
 
-	return mmGetCommentsByParent
+	return mmMarkEventsPublished
 }
 
-// Return sets up results that will be returned by Repository.GetCommentsByParent
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Return(cpa1 []*domain.Comment, err error) *RepositoryMock {
-	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+// Return sets up results that will be returned by Repository.MarkEventsPublished
+func (mmMarkEventsPublished *mRepositoryMockMarkEventsPublished) Return(err error) *RepositoryMock {
+	if mmMarkEventsPublished.mock.funcMarkEventsPublished != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("RepositoryMock.MarkEventsPublished mock is already set by Set")
 	}
 
-	if mmGetCommentsByParent.defaultExpectation == nil {
-		mmGetCommentsByParent.defaultExpectation = &RepositoryMockGetCommentsByParentExpectation{mock: mmGetCommentsByParent.mock}
+	if mmMarkEventsPublished.defaultExpectation == nil {
+		mmMarkEventsPublished.defaultExpectation = &RepositoryMockMarkEventsPublishedExpectation{mock: mmMarkEventsPublished.mock}
 	}
-	mmGetCommentsByParent.defaultExpectation.results = &RepositoryMockGetCommentsByParentResults{cpa1, err}
-	return mmGetCommentsByParent.mock
+	mmMarkEventsPublished.defaultExpectation.results = &RepositoryMockMarkEventsPublishedResults{err}
+	return mmMarkEventsPublished.mock
 }
 
-// Set uses given function f to mock the Repository.GetCommentsByParent method
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Set(f func(ctx context.Context, parentId int, limit int, offset int) (cpa1 []*domain.Comment, err error)) *RepositoryMock {
-	if mmGetCommentsByParent.defaultExpectation != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("Default expectation is already set for the Repository.GetCommentsByParent method")
+// Set uses given function f to mock the Repository.MarkEventsPublished method
+func (mmMarkEventsPublished *mRepositoryMockMarkEventsPublished) Set(f func(ctx context.Context, ids []int, publishedAt time.Time) (err error)) *RepositoryMock {
+	if mmMarkEventsPublished.defaultExpectation != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("Default expectation is already set for the Repository.MarkEventsPublished method")
 	}
 
-	if len(mmGetCommentsByParent.expectations) > 0 {
-		mmGetCommentsByParent.mock.t.Fatalf("Some expectations are already set for the Repository.GetCommentsByParent method")
+	if len(mmMarkEventsPublished.expectations) > 0 {
+		mmMarkEventsPublished.mock.t.Fatalf("Some expectations are already set for the Repository.MarkEventsPublished method")
 	}
 
-	mmGetCommentsByParent.mock.funcGetCommentsByParent = f
-	return mmGetCommentsByParent.mock
+	mmMarkEventsPublished.mock.funcMarkEventsPublished = f
+	return mmMarkEventsPublished.mock
 }
 
-// When sets expectation for the Repository.GetCommentsByParent which will trigger the result defined by the following
+// When sets expectation for the Repository.MarkEventsPublished which will trigger the result defined by the following
 // Then helper
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) When(ctx context.Context, parentId int, limit int, offset int) *RepositoryMockGetCommentsByParentExpectation {
-	if mmGetCommentsByParent.mock.funcGetCommentsByParent != nil {
-		mmGetCommentsByParent.mock.t.Fatalf("RepositoryMock.GetCommentsByParent mock is already set by Set")
+func (mmMarkEventsPublished *mRepositoryMockMarkEventsPublished) When(ctx context.Context, ids []int, publishedAt time.Time) *RepositoryMockMarkEventsPublishedExpectation {
+	if mmMarkEventsPublished.mock.funcMarkEventsPublished != nil {
+		mmMarkEventsPublished.mock.t.Fatalf("RepositoryMock.MarkEventsPublished mock is already set by Set")
 	}
 
-	expectation := &RepositoryMockGetCommentsByParentExpectation{
-		mock:   mmGetCommentsByParent.mock,
-		params: &RepositoryMockGetCommentsByParentParams{ctx, parentId, limit, offset},
+	expectation := &RepositoryMockMarkEventsPublishedExpectation{
+		mock:   mmMarkEventsPublished.mock,
+		params: &RepositoryMockMarkEventsPublishedParams{ctx, ids, publishedAt},
 	}
-	mmGetCommentsByParent.expectations = append(mmGetCommentsByParent.expectations, expectation)
+	mmMarkEventsPublished.expectations = append(mmMarkEventsPublished.expectations, expectation)
 	return expectation
 }
 
-// Then sets up Repository.GetCommentsByParent return parameters for the expectation previously defined by the When method
-func (e *RepositoryMockGetCommentsByParentExpectation) Then(cpa1 []*domain.Comment, err error) *RepositoryMock {
-	e.results = &RepositoryMockGetCommentsByParentResults{cpa1, err}
+// Then sets up Repository.MarkEventsPublished return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockMarkEventsPublishedExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockMarkEventsPublishedResults{err}
 	return e.mock
 }
 
-// Times sets number of times Repository.GetCommentsByParent should be invoked
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Times(n uint64) *mRepositoryMockGetCommentsByParent {
+// Times sets number of times Repository.MarkEventsPublished should be invoked
+func (mmMarkEventsPublished *mRepositoryMockMarkEventsPublished) Times(n uint64) *mRepositoryMockMarkEventsPublished {
 	if n == 0 {
-		mmGetCommentsByParent.mock.t.Fatalf("Times of RepositoryMock.GetCommentsByParent mock can not be zero")
+		mmMarkEventsPublished.mock.t.Fatalf("Times of RepositoryMock.MarkEventsPublished mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmGetCommentsByParent.expectedInvocations, n)
-	return mmGetCommentsByParent
+	mm_atomic.StoreUint64(&mmMarkEventsPublished.expectedInvocations, n)
+	return mmMarkEventsPublished
 }
 
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) invocationsDone() bool {
-	if len(mmGetCommentsByParent.expectations) == 0 && mmGetCommentsByParent.defaultExpectation == nil && mmGetCommentsByParent.mock.funcGetCommentsByParent == nil {
+func (mmMarkEventsPublished *mRepositoryMockMarkEventsPublished) invocationsDone() bool {
+	if len(mmMarkEventsPublished.expectations) == 0 && mmMarkEventsPublished.defaultExpectation == nil && mmMarkEventsPublished.mock.funcMarkEventsPublished == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmGetCommentsByParent.mock.afterGetCommentsByParentCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmGetCommentsByParent.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmMarkEventsPublished.mock.afterMarkEventsPublishedCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmMarkEventsPublished.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// GetCommentsByParent implements repository.Repository
-func (mmGetCommentsByParent *RepositoryMock) GetCommentsByParent(ctx context.Context, parentId int, limit int, offset int) (cpa1 []*domain.Comment, err error) {
-	mm_atomic.AddUint64(&mmGetCommentsByParent.beforeGetCommentsByParentCounter, 1)
-	defer mm_atomic.AddUint64(&mmGetCommentsByParent.afterGetCommentsByParentCounter, 1)
+// MarkEventsPublished implements repository.Repository
+func (mmMarkEventsPublished *RepositoryMock) MarkEventsPublished(ctx context.Context, ids []int, publishedAt time.Time) (err error) {
+	mm_atomic.AddUint64(&mmMarkEventsPublished.beforeMarkEventsPublishedCounter, 1)
+	defer mm_atomic.AddUint64(&mmMarkEventsPublished.afterMarkEventsPublishedCounter, 1)
 
-	if mmGetCommentsByParent.inspectFuncGetCommentsByParent != nil {
-		mmGetCommentsByParent.inspectFuncGetCommentsByParent(ctx, parentId, limit, offset)
+	if mmMarkEventsPublished.inspectFuncMarkEventsPublished != nil {
+		mmMarkEventsPublished.inspectFuncMarkEventsPublished(ctx, ids, publishedAt)
 	}
 
-	mm_params := RepositoryMockGetCommentsByParentParams{ctx, parentId, limit, offset}
+	mm_params := RepositoryMockMarkEventsPublishedParams{ctx, ids, publishedAt}
 
 	// Record call args
-	mmGetCommentsByParent.GetCommentsByParentMock.mutex.Lock()
-	mmGetCommentsByParent.GetCommentsByParentMock.callArgs = append(mmGetCommentsByParent.GetCommentsByParentMock.callArgs, &mm_params)
-	mmGetCommentsByParent.GetCommentsByParentMock.mutex.Unlock()
+	mmMarkEventsPublished.MarkEventsPublishedMock.mutex.Lock()
+	mmMarkEventsPublished.MarkEventsPublishedMock.callArgs = append(mmMarkEventsPublished.MarkEventsPublishedMock.callArgs, &mm_params)
+	mmMarkEventsPublished.MarkEventsPublishedMock.mutex.Unlock()
 
-	for _, e := range mmGetCommentsByParent.GetCommentsByParentMock.expectations {
+	for _, e := range mmMarkEventsPublished.MarkEventsPublishedMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.cpa1, e.results.err
+			return e.results.err
 		}
 	}
 
-	if mmGetCommentsByParent.GetCommentsByParentMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmGetCommentsByParent.GetCommentsByParentMock.defaultExpectation.Counter, 1)
-		mm_want := mmGetCommentsByParent.GetCommentsByParentMock.defaultExpectation.params
-		mm_want_ptrs := mmGetCommentsByParent.GetCommentsByParentMock.defaultExpectation.paramPtrs
+	if mmMarkEventsPublished.MarkEventsPublishedMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmMarkEventsPublished.MarkEventsPublishedMock.defaultExpectation.Counter, 1)
+		mm_want := mmMarkEventsPublished.MarkEventsPublishedMock.defaultExpectation.params
+		mm_want_ptrs := mmMarkEventsPublished.MarkEventsPublishedMock.defaultExpectation.paramPtrs
 
-		mm_got := RepositoryMockGetCommentsByParentParams{ctx, parentId, limit, offset}
+		mm_got := RepositoryMockMarkEventsPublishedParams{ctx, ids, publishedAt}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmGetCommentsByParent.t.Errorf("RepositoryMock.GetCommentsByParent got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
-			}
-
-			if mm_want_ptrs.parentId != nil && !minimock.Equal(*mm_want_ptrs.parentId, mm_got.parentId) {
-				mmGetCommentsByParent.t.Errorf("RepositoryMock.GetCommentsByParent got unexpected parameter parentId, want: %#v, got: %#v%s\n", *mm_want_ptrs.parentId, mm_got.parentId, minimock.Diff(*mm_want_ptrs.parentId, mm_got.parentId))
+				mmMarkEventsPublished.t.Errorf("RepositoryMock.MarkEventsPublished got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
-				mmGetCommentsByParent.t.Errorf("RepositoryMock.GetCommentsByParent got unexpected parameter limit, want: %#v, got: %#v%s\n", *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			if mm_want_ptrs.ids != nil && !minimock.Equal(*mm_want_ptrs.ids, mm_got.ids) {
+				mmMarkEventsPublished.t.Errorf("RepositoryMock.MarkEventsPublished got unexpected parameter ids, want: %#v, got: %#v%s\n", *mm_want_ptrs.ids, mm_got.ids, minimock.Diff(*mm_want_ptrs.ids, mm_got.ids))
 			}
 
-			if mm_want_ptrs.offset != nil && !minimock.Equal(*mm_want_ptrs.offset, mm_got.offset) {
-				mmGetCommentsByParent.t.Errorf("RepositoryMock.GetCommentsByParent got unexpected parameter offset, want: %#v, got: %#v%s\n", *mm_want_ptrs.offset, mm_got.offset, minimock.Diff(*mm_want_ptrs.offset, mm_got.offset))
+			if mm_want_ptrs.publishedAt != nil && !minimock.Equal(*mm_want_ptrs.publishedAt, mm_got.publishedAt) {
+				mmMarkEventsPublished.t.Errorf("RepositoryMock.MarkEventsPublished got unexpected parameter publishedAt, want: %#v, got: %#v%s\n", *mm_want_ptrs.publishedAt, mm_got.publishedAt, minimock.Diff(*mm_want_ptrs.publishedAt, mm_got.publishedAt))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmGetCommentsByParent.t.Errorf("RepositoryMock.GetCommentsByParent got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmMarkEventsPublished.t.Errorf("RepositoryMock.MarkEventsPublished got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmGetCommentsByParent.GetCommentsByParentMock.defaultExpectation.results
+		mm_results := mmMarkEventsPublished.MarkEventsPublishedMock.defaultExpectation.results
 		if mm_results == nil {
-			mmGetCommentsByParent.t.Fatal("No results are set for the RepositoryMock.GetCommentsByParent")
+			mmMarkEventsPublished.t.Fatal("No results are set for the RepositoryMock.MarkEventsPublished")
 		}
-		return (*mm_results).cpa1, (*mm_results).err
+		return (*mm_results).err
 	}
-	if mmGetCommentsByParent.funcGetCommentsByParent != nil {
-		return mmGetCommentsByParent.funcGetCommentsByParent(ctx, parentId, limit, offset)
+	if mmMarkEventsPublished.funcMarkEventsPublished != nil {
+		return mmMarkEventsPublished.funcMarkEventsPublished(ctx, ids, publishedAt)
 	}
-	mmGetCommentsByParent.t.Fatalf("Unexpected call to RepositoryMock.GetCommentsByParent. %v %v %v %v", ctx, parentId, limit, offset)
+	mmMarkEventsPublished.t.Fatalf("Unexpected call to RepositoryMock.MarkEventsPublished. %v %v %v", ctx, ids, publishedAt)
 	return
 }
 
-// GetCommentsByParentAfterCounter returns a count of finished RepositoryMock.GetCommentsByParent invocations
-func (mmGetCommentsByParent *RepositoryMock) GetCommentsByParentAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetCommentsByParent.afterGetCommentsByParentCounter)
+// MarkEventsPublishedAfterCounter returns a count of finished RepositoryMock.MarkEventsPublished invocations
+func (mmMarkEventsPublished *RepositoryMock) MarkEventsPublishedAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmMarkEventsPublished.afterMarkEventsPublishedCounter)
 }
 
-// GetCommentsByParentBeforeCounter returns a count of RepositoryMock.GetCommentsByParent invocations
-func (mmGetCommentsByParent *RepositoryMock) GetCommentsByParentBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetCommentsByParent.beforeGetCommentsByParentCounter)
+// MarkEventsPublishedBeforeCounter returns a count of RepositoryMock.MarkEventsPublished invocations
+func (mmMarkEventsPublished *RepositoryMock) MarkEventsPublishedBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmMarkEventsPublished.beforeMarkEventsPublishedCounter)
 }
 
-// Calls returns a list of arguments used in each call to RepositoryMock.GetCommentsByParent.
+// Calls returns a list of arguments used in each call to RepositoryMock.MarkEventsPublished.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmGetCommentsByParent *mRepositoryMockGetCommentsByParent) Calls() []*RepositoryMockGetCommentsByParentParams {
-	mmGetCommentsByParent.mutex.RLock()
+func (mmMarkEventsPublished *mRepositoryMockMarkEventsPublished) Calls() []*RepositoryMockMarkEventsPublishedParams {
+	mmMarkEventsPublished.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockGetCommentsByParentParams, len(mmGetCommentsByParent.callArgs))
-	copy(argCopy, mmGetCommentsByParent.callArgs)
+	argCopy := make([]*RepositoryMockMarkEventsPublishedParams, len(mmMarkEventsPublished.callArgs))
+	copy(argCopy, mmMarkEventsPublished.callArgs)
 
-	mmGetCommentsByParent.mutex.RUnlock()
+	mmMarkEventsPublished.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockGetCommentsByParentDone returns true if the count of the GetCommentsByParent invocations corresponds
+// MinimockMarkEventsPublishedDone returns true if the count of the MarkEventsPublished invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockGetCommentsByParentDone() bool {
-	for _, e := range m.GetCommentsByParentMock.expectations {
+func (m *RepositoryMock) MinimockMarkEventsPublishedDone() bool {
+	for _, e := range m.MarkEventsPublishedMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.GetCommentsByParentMock.invocationsDone()
+	return m.MarkEventsPublishedMock.invocationsDone()
 }
 
-// MinimockGetCommentsByParentInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockGetCommentsByParentInspect() {
-	for _, e := range m.GetCommentsByParentMock.expectations {
+// MinimockMarkEventsPublishedInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockMarkEventsPublishedInspect() {
+	for _, e := range m.MarkEventsPublishedMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.GetCommentsByParent with params: %#v", *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.MarkEventsPublished with params: %#v", *e.params)
 		}
 	}
 
-	afterGetCommentsByParentCounter := mm_atomic.LoadUint64(&m.afterGetCommentsByParentCounter)
+	afterMarkEventsPublishedCounter := mm_atomic.LoadUint64(&m.afterMarkEventsPublishedCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.GetCommentsByParentMock.defaultExpectation != nil && afterGetCommentsByParentCounter < 1 {
-		if m.GetCommentsByParentMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to RepositoryMock.GetCommentsByParent")
+	if m.MarkEventsPublishedMock.defaultExpectation != nil && afterMarkEventsPublishedCounter < 1 {
+		if m.MarkEventsPublishedMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.MarkEventsPublished")
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.GetCommentsByParent with params: %#v", *m.GetCommentsByParentMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.MarkEventsPublished with params: %#v", *m.MarkEventsPublishedMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcGetCommentsByParent != nil && afterGetCommentsByParentCounter < 1 {
-		m.t.Error("Expected call to RepositoryMock.GetCommentsByParent")
+	if m.funcMarkEventsPublished != nil && afterMarkEventsPublishedCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.MarkEventsPublished")
 	}
 
-	if !m.GetCommentsByParentMock.invocationsDone() && afterGetCommentsByParentCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.GetCommentsByParent but found %d calls",
-			mm_atomic.LoadUint64(&m.GetCommentsByParentMock.expectedInvocations), afterGetCommentsByParentCounter)
+	if !m.MarkEventsPublishedMock.invocationsDone() && afterMarkEventsPublishedCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.MarkEventsPublished but found %d calls",
+			mm_atomic.LoadUint64(&m.MarkEventsPublishedMock.expectedInvocations), afterMarkEventsPublishedCounter)
 	}
 }
 
-type mRepositoryMockGetCommentsByPost struct {
+type mRepositoryMockResolveReport struct {
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockGetCommentsByPostExpectation
-	expectations       []*RepositoryMockGetCommentsByPostExpectation
+	defaultExpectation *RepositoryMockResolveReportExpectation
+	expectations       []*RepositoryMockResolveReportExpectation
 
-	callArgs []*RepositoryMockGetCommentsByPostParams
+	callArgs []*RepositoryMockResolveReportParams
 	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// RepositoryMockGetCommentsByPostExpectation specifies expectation struct of the Repository.GetCommentsByPost
-type RepositoryMockGetCommentsByPostExpectation struct {
+// RepositoryMockResolveReportExpectation specifies expectation struct of the Repository.ResolveReport
+type RepositoryMockResolveReportExpectation struct {
 	mock      *RepositoryMock
-	params    *RepositoryMockGetCommentsByPostParams
-	paramPtrs *RepositoryMockGetCommentsByPostParamPtrs
-	results   *RepositoryMockGetCommentsByPostResults
+	params    *RepositoryMockResolveReportParams
+	paramPtrs *RepositoryMockResolveReportParamPtrs
+	results   *RepositoryMockResolveReportResults
 	Counter   uint64
 }
 
-// RepositoryMockGetCommentsByPostParams contains parameters of the Repository.GetCommentsByPost
-type RepositoryMockGetCommentsByPostParams struct {
-	ctx    context.Context
-	postID int
-	limit  int
-	offset int
+// RepositoryMockResolveReportParams contains parameters of the Repository.ResolveReport
+type RepositoryMockResolveReportParams struct {
+	ctx      context.Context
+	reportID int
+	status   domain.ReportStatus
+	record   *domain.ModerationRecord
 }
 
-// RepositoryMockGetCommentsByPostParamPtrs contains pointers to parameters of the Repository.GetCommentsByPost
-type RepositoryMockGetCommentsByPostParamPtrs struct {
-	ctx    *context.Context
-	postID *int
-	limit  *int
-	offset *int
+// RepositoryMockResolveReportParamPtrs contains pointers to parameters of the Repository.ResolveReport
+type RepositoryMockResolveReportParamPtrs struct {
+	ctx      *context.Context
+	reportID *int
+	status   *domain.ReportStatus
+	record   **domain.ModerationRecord
 }
 
-// RepositoryMockGetCommentsByPostResults contains results of the Repository.GetCommentsByPost
-type RepositoryMockGetCommentsByPostResults struct {
-	cpa1 []*domain.Comment
-	err  error
+// RepositoryMockResolveReportResults contains results of the Repository.ResolveReport
+type RepositoryMockResolveReportResults struct {
+	mp1 *domain.ModerationRecord
+	err error
 }
 
-// Expect sets up expected params for Repository.GetCommentsByPost
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Expect(ctx context.Context, postID int, limit int, offset int) *mRepositoryMockGetCommentsByPost {
-	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+// Expect sets up expected params for Repository.ResolveReport
+func (mmResolveReport *mRepositoryMockResolveReport) Expect(ctx context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord) *mRepositoryMockResolveReport {
+	if mmResolveReport.mock.funcResolveReport != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by Set")
 	}
 
-	if mmGetCommentsByPost.defaultExpectation == nil {
-		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{}
+	if mmResolveReport.defaultExpectation == nil {
+		mmResolveReport.defaultExpectation = &RepositoryMockResolveReportExpectation{}
 	}
 
-	if mmGetCommentsByPost.defaultExpectation.paramPtrs != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by ExpectParams functions")
+	if mmResolveReport.defaultExpectation.paramPtrs != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by ExpectParams functions")
 	}
 
-	mmGetCommentsByPost.defaultExpectation.params = &RepositoryMockGetCommentsByPostParams{ctx, postID, limit, offset}
-	for _, e := range mmGetCommentsByPost.expectations {
-		if minimock.Equal(e.params, mmGetCommentsByPost.defaultExpectation.params) {
-			mmGetCommentsByPost.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetCommentsByPost.defaultExpectation.params)
+	mmResolveReport.defaultExpectation.params = &RepositoryMockResolveReportParams{ctx, reportID, status, record}
+	for _, e := range mmResolveReport.expectations {
+		if minimock.Equal(e.params, mmResolveReport.defaultExpectation.params) {
+			mmResolveReport.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmResolveReport.defaultExpectation.params)
 		}
 	}
 
-	return mmGetCommentsByPost
+	return mmResolveReport
 }
 
-// ExpectCtxParam1 sets up expected param ctx for Repository.GetCommentsByPost
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetCommentsByPost {
-	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Repository.ResolveReport
+func (mmResolveReport *mRepositoryMockResolveReport) ExpectCtxParam1(ctx context.Context) *mRepositoryMockResolveReport {
+	if mmResolveReport.mock.funcResolveReport != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by Set")
 	}
 
-	if mmGetCommentsByPost.defaultExpectation == nil {
-		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{}
+	if mmResolveReport.defaultExpectation == nil {
+		mmResolveReport.defaultExpectation = &RepositoryMockResolveReportExpectation{}
 	}
 
-	if mmGetCommentsByPost.defaultExpectation.params != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Expect")
+	if mmResolveReport.defaultExpectation.params != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by Expect")
 	}
 
-	if mmGetCommentsByPost.defaultExpectation.paramPtrs == nil {
-		mmGetCommentsByPost.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByPostParamPtrs{}
+	if mmResolveReport.defaultExpectation.paramPtrs == nil {
+		mmResolveReport.defaultExpectation.paramPtrs = &RepositoryMockResolveReportParamPtrs{}
 	}
-	mmGetCommentsByPost.defaultExpectation.paramPtrs.ctx = &ctx
+	mmResolveReport.defaultExpectation.paramPtrs.ctx = &ctx
 
-	return mmGetCommentsByPost
+	return mmResolveReport
 }
 
-// ExpectPostIDParam2 sets up expected param postID for Repository.GetCommentsByPost
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) ExpectPostIDParam2(postID int) *mRepositoryMockGetCommentsByPost {
-	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+// ExpectReportIDParam2 sets up expected param reportID for Repository.ResolveReport
+func (mmResolveReport *mRepositoryMockResolveReport) ExpectReportIDParam2(reportID int) *mRepositoryMockResolveReport {
+	if mmResolveReport.mock.funcResolveReport != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by Set")
 	}
 
-	if mmGetCommentsByPost.defaultExpectation == nil {
-		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{}
+	if mmResolveReport.defaultExpectation == nil {
+		mmResolveReport.defaultExpectation = &RepositoryMockResolveReportExpectation{}
 	}
 
-	if mmGetCommentsByPost.defaultExpectation.params != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Expect")
+	if mmResolveReport.defaultExpectation.params != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by Expect")
 	}
 
-	if mmGetCommentsByPost.defaultExpectation.paramPtrs == nil {
-		mmGetCommentsByPost.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByPostParamPtrs{}
+	if mmResolveReport.defaultExpectation.paramPtrs == nil {
+		mmResolveReport.defaultExpectation.paramPtrs = &RepositoryMockResolveReportParamPtrs{}
 	}
-	mmGetCommentsByPost.defaultExpectation.paramPtrs.postID = &postID
+	mmResolveReport.defaultExpectation.paramPtrs.reportID = &reportID
 
-	return mmGetCommentsByPost
+	return mmResolveReport
 }
 
-// ExpectLimitParam3 sets up expected param limit for Repository.GetCommentsByPost
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) ExpectLimitParam3(limit int) *mRepositoryMockGetCommentsByPost {
-	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+// ExpectStatusParam3 sets up expected param status for Repository.ResolveReport
+func (mmResolveReport *mRepositoryMockResolveReport) ExpectStatusParam3(status domain.ReportStatus) *mRepositoryMockResolveReport {
+	if mmResolveReport.mock.funcResolveReport != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by Set")
 	}
 
-	if mmGetCommentsByPost.defaultExpectation == nil {
-		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{}
+	if mmResolveReport.defaultExpectation == nil {
+		mmResolveReport.defaultExpectation = &RepositoryMockResolveReportExpectation{}
 	}
 
-	if mmGetCommentsByPost.defaultExpectation.params != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Expect")
+	if mmResolveReport.defaultExpectation.params != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by Expect")
 	}
 
-	if mmGetCommentsByPost.defaultExpectation.paramPtrs == nil {
-		mmGetCommentsByPost.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByPostParamPtrs{}
+	if mmResolveReport.defaultExpectation.paramPtrs == nil {
+		mmResolveReport.defaultExpectation.paramPtrs = &RepositoryMockResolveReportParamPtrs{}
 	}
-	mmGetCommentsByPost.defaultExpectation.paramPtrs.limit = &limit
+	mmResolveReport.defaultExpectation.paramPtrs.status = &status
 
-	return mmGetCommentsByPost
+	return mmResolveReport
 }
 
-// ExpectOffsetParam4 sets up expected param offset for Repository.GetCommentsByPost
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) ExpectOffsetParam4(offset int) *mRepositoryMockGetCommentsByPost {
-	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+// ExpectRecordParam4 sets up expected param record for Repository.ResolveReport
+func (mmResolveReport *mRepositoryMockResolveReport) ExpectRecordParam4(record *domain.ModerationRecord) *mRepositoryMockResolveReport {
+	if mmResolveReport.mock.funcResolveReport != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by Set")
 	}
 
-	if mmGetCommentsByPost.defaultExpectation == nil {
-		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{}
+	if mmResolveReport.defaultExpectation == nil {
+		mmResolveReport.defaultExpectation = &RepositoryMockResolveReportExpectation{}
 	}
 
-	if mmGetCommentsByPost.defaultExpectation.params != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Expect")
+	if mmResolveReport.defaultExpectation.params != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by Expect")
 	}
 
-	if mmGetCommentsByPost.defaultExpectation.paramPtrs == nil {
-		mmGetCommentsByPost.defaultExpectation.paramPtrs = &RepositoryMockGetCommentsByPostParamPtrs{}
+	if mmResolveReport.defaultExpectation.paramPtrs == nil {
+		mmResolveReport.defaultExpectation.paramPtrs = &RepositoryMockResolveReportParamPtrs{}
 	}
-	mmGetCommentsByPost.defaultExpectation.paramPtrs.offset = &offset
+	mmResolveReport.defaultExpectation.paramPtrs.record = &record
 
-	return mmGetCommentsByPost
+	return mmResolveReport
 }
 
-// Inspect accepts an inspector function that has same arguments as the Repository.GetCommentsByPost
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Inspect(f func(ctx context.Context, postID int, limit int, offset int)) *mRepositoryMockGetCommentsByPost {
-	if mmGetCommentsByPost.mock.inspectFuncGetCommentsByPost != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetCommentsByPost")
+// Inspect accepts an inspector function that has same arguments as the Repository.ResolveReport
+func (mmResolveReport *mRepositoryMockResolveReport) Inspect(f func(ctx context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord)) *mRepositoryMockResolveReport {
+	if mmResolveReport.mock.inspectFuncResolveReport != nil {
+		mmResolveReport.mock.t.Fatalf("Inspect function is already set for RepositoryMock.ResolveReport")
 	}
 
-	mmGetCommentsByPost.mock.inspectFuncGetCommentsByPost = f
+	mmResolveReport.mock.inspectFuncResolveReport = f
 
-	return mmGetCommentsByPost
+	return mmResolveReport
 }
 
-// Return sets up results that will be returned by Repository.GetCommentsByPost
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Return(cpa1 []*domain.Comment, err error) *RepositoryMock {
-	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+// Return sets up results that will be returned by Repository.ResolveReport
+func (mmResolveReport *mRepositoryMockResolveReport) Return(mp1 *domain.ModerationRecord, err error) *RepositoryMock {
+	if mmResolveReport.mock.funcResolveReport != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by Set")
 	}
 
-	if mmGetCommentsByPost.defaultExpectation == nil {
-		mmGetCommentsByPost.defaultExpectation = &RepositoryMockGetCommentsByPostExpectation{mock: mmGetCommentsByPost.mock}
+	if mmResolveReport.defaultExpectation == nil {
+		mmResolveReport.defaultExpectation = &RepositoryMockResolveReportExpectation{mock: mmResolveReport.mock}
 	}
-	mmGetCommentsByPost.defaultExpectation.results = &RepositoryMockGetCommentsByPostResults{cpa1, err}
-	return mmGetCommentsByPost.mock
+	mmResolveReport.defaultExpectation.results = &RepositoryMockResolveReportResults{mp1, err}
+	return mmResolveReport.mock
 }
 
-// Set uses given function f to mock the Repository.GetCommentsByPost method
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Set(f func(ctx context.Context, postID int, limit int, offset int) (cpa1 []*domain.Comment, err error)) *RepositoryMock {
-	if mmGetCommentsByPost.defaultExpectation != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("Default expectation is already set for the Repository.GetCommentsByPost method")
+// Set uses given function f to mock the Repository.ResolveReport method
+func (mmResolveReport *mRepositoryMockResolveReport) Set(f func(ctx context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord) (mp1 *domain.ModerationRecord, err error)) *RepositoryMock {
+	if mmResolveReport.defaultExpectation != nil {
+		mmResolveReport.mock.t.Fatalf("Default expectation is already set for the Repository.ResolveReport method")
 	}
 
-	if len(mmGetCommentsByPost.expectations) > 0 {
-		mmGetCommentsByPost.mock.t.Fatalf("Some expectations are already set for the Repository.GetCommentsByPost method")
+	if len(mmResolveReport.expectations) > 0 {
+		mmResolveReport.mock.t.Fatalf("Some expectations are already set for the Repository.ResolveReport method")
 	}
 
-	mmGetCommentsByPost.mock.funcGetCommentsByPost = f
-	return mmGetCommentsByPost.mock
+	mmResolveReport.mock.funcResolveReport = f
+	return mmResolveReport.mock
 }
 
-// When sets expectation for the Repository.GetCommentsByPost which will trigger the result defined by the following
+// When sets expectation for the Repository.ResolveReport which will trigger the result defined by the following
 // Then helper
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) When(ctx context.Context, postID int, limit int, offset int) *RepositoryMockGetCommentsByPostExpectation {
-	if mmGetCommentsByPost.mock.funcGetCommentsByPost != nil {
-		mmGetCommentsByPost.mock.t.Fatalf("RepositoryMock.GetCommentsByPost mock is already set by Set")
+func (mmResolveReport *mRepositoryMockResolveReport) When(ctx context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord) *RepositoryMockResolveReportExpectation {
+	if mmResolveReport.mock.funcResolveReport != nil {
+		mmResolveReport.mock.t.Fatalf("RepositoryMock.ResolveReport mock is already set by Set")
 	}
 
-	expectation := &RepositoryMockGetCommentsByPostExpectation{
-		mock:   mmGetCommentsByPost.mock,
-		params: &RepositoryMockGetCommentsByPostParams{ctx, postID, limit, offset},
+	expectation := &RepositoryMockResolveReportExpectation{
+		mock:   mmResolveReport.mock,
+		params: &RepositoryMockResolveReportParams{ctx, reportID, status, record},
 	}
-	mmGetCommentsByPost.expectations = append(mmGetCommentsByPost.expectations, expectation)
+	mmResolveReport.expectations = append(mmResolveReport.expectations, expectation)
 	return expectation
 }
 
-// Then sets up Repository.GetCommentsByPost return parameters for the expectation previously defined by the When method
-func (e *RepositoryMockGetCommentsByPostExpectation) Then(cpa1 []*domain.Comment, err error) *RepositoryMock {
-	e.results = &RepositoryMockGetCommentsByPostResults{cpa1, err}
+// Then sets up Repository.ResolveReport return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockResolveReportExpectation) Then(mp1 *domain.ModerationRecord, err error) *RepositoryMock {
+	e.results = &RepositoryMockResolveReportResults{mp1, err}
 	return e.mock
 }
 
-// Times sets number of times Repository.GetCommentsByPost should be invoked
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Times(n uint64) *mRepositoryMockGetCommentsByPost {
+// Times sets number of times Repository.ResolveReport should be invoked
+func (mmResolveReport *mRepositoryMockResolveReport) Times(n uint64) *mRepositoryMockResolveReport {
 	if n == 0 {
-		mmGetCommentsByPost.mock.t.Fatalf("Times of RepositoryMock.GetCommentsByPost mock can not be zero")
+		mmResolveReport.mock.t.Fatalf("Times of RepositoryMock.ResolveReport mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmGetCommentsByPost.expectedInvocations, n)
-	return mmGetCommentsByPost
+	mm_atomic.StoreUint64(&mmResolveReport.expectedInvocations, n)
+	return mmResolveReport
 }
 
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) invocationsDone() bool {
-	if len(mmGetCommentsByPost.expectations) == 0 && mmGetCommentsByPost.defaultExpectation == nil && mmGetCommentsByPost.mock.funcGetCommentsByPost == nil {
+func (mmResolveReport *mRepositoryMockResolveReport) invocationsDone() bool {
+	if len(mmResolveReport.expectations) == 0 && mmResolveReport.defaultExpectation == nil && mmResolveReport.mock.funcResolveReport == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmGetCommentsByPost.mock.afterGetCommentsByPostCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmGetCommentsByPost.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmResolveReport.mock.afterResolveReportCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmResolveReport.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// GetCommentsByPost implements repository.Repository
-func (mmGetCommentsByPost *RepositoryMock) GetCommentsByPost(ctx context.Context, postID int, limit int, offset int) (cpa1 []*domain.Comment, err error) {
-	mm_atomic.AddUint64(&mmGetCommentsByPost.beforeGetCommentsByPostCounter, 1)
-	defer mm_atomic.AddUint64(&mmGetCommentsByPost.afterGetCommentsByPostCounter, 1)
+// ResolveReport implements repository.Repository
+func (mmResolveReport *RepositoryMock) ResolveReport(ctx context.Context, reportID int, status domain.ReportStatus, record *domain.ModerationRecord) (mp1 *domain.ModerationRecord, err error) {
+	mm_atomic.AddUint64(&mmResolveReport.beforeResolveReportCounter, 1)
+	defer mm_atomic.AddUint64(&mmResolveReport.afterResolveReportCounter, 1)
 
-	if mmGetCommentsByPost.inspectFuncGetCommentsByPost != nil {
-		mmGetCommentsByPost.inspectFuncGetCommentsByPost(ctx, postID, limit, offset)
+	if mmResolveReport.inspectFuncResolveReport != nil {
+		mmResolveReport.inspectFuncResolveReport(ctx, reportID, status, record)
 	}
 
-	mm_params := RepositoryMockGetCommentsByPostParams{ctx, postID, limit, offset}
+	mm_params := RepositoryMockResolveReportParams{ctx, reportID, status, record}
 
 	// Record call args
-	mmGetCommentsByPost.GetCommentsByPostMock.mutex.Lock()
-	mmGetCommentsByPost.GetCommentsByPostMock.callArgs = append(mmGetCommentsByPost.GetCommentsByPostMock.callArgs, &mm_params)
-	mmGetCommentsByPost.GetCommentsByPostMock.mutex.Unlock()
+	mmResolveReport.ResolveReportMock.mutex.Lock()
+	mmResolveReport.ResolveReportMock.callArgs = append(mmResolveReport.ResolveReportMock.callArgs, &mm_params)
+	mmResolveReport.ResolveReportMock.mutex.Unlock()
 
-	for _, e := range mmGetCommentsByPost.GetCommentsByPostMock.expectations {
+	for _, e := range mmResolveReport.ResolveReportMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.cpa1, e.results.err
+			return e.results.mp1, e.results.err
 		}
 	}
 
-	if mmGetCommentsByPost.GetCommentsByPostMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmGetCommentsByPost.GetCommentsByPostMock.defaultExpectation.Counter, 1)
-		mm_want := mmGetCommentsByPost.GetCommentsByPostMock.defaultExpectation.params
-		mm_want_ptrs := mmGetCommentsByPost.GetCommentsByPostMock.defaultExpectation.paramPtrs
+	if mmResolveReport.ResolveReportMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmResolveReport.ResolveReportMock.defaultExpectation.Counter, 1)
+		mm_want := mmResolveReport.ResolveReportMock.defaultExpectation.params
+		mm_want_ptrs := mmResolveReport.ResolveReportMock.defaultExpectation.paramPtrs
 
-		mm_got := RepositoryMockGetCommentsByPostParams{ctx, postID, limit, offset}
+		mm_got := RepositoryMockResolveReportParams{ctx, reportID, status, record}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmGetCommentsByPost.t.Errorf("RepositoryMock.GetCommentsByPost got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmResolveReport.t.Errorf("RepositoryMock.ResolveReport got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.postID != nil && !minimock.Equal(*mm_want_ptrs.postID, mm_got.postID) {
-				mmGetCommentsByPost.t.Errorf("RepositoryMock.GetCommentsByPost got unexpected parameter postID, want: %#v, got: %#v%s\n", *mm_want_ptrs.postID, mm_got.postID, minimock.Diff(*mm_want_ptrs.postID, mm_got.postID))
+			if mm_want_ptrs.reportID != nil && !minimock.Equal(*mm_want_ptrs.reportID, mm_got.reportID) {
+				mmResolveReport.t.Errorf("RepositoryMock.ResolveReport got unexpected parameter reportID, want: %#v, got: %#v%s\n", *mm_want_ptrs.reportID, mm_got.reportID, minimock.Diff(*mm_want_ptrs.reportID, mm_got.reportID))
 			}
 
-			if mm_want_ptrs.limit != nil && !minimock.Equal(*mm_want_ptrs.limit, mm_got.limit) {
-				mmGetCommentsByPost.t.Errorf("RepositoryMock.GetCommentsByPost got unexpected parameter limit, want: %#v, got: %#v%s\n", *mm_want_ptrs.limit, mm_got.limit, minimock.Diff(*mm_want_ptrs.limit, mm_got.limit))
+			if mm_want_ptrs.status != nil && !minimock.Equal(*mm_want_ptrs.status, mm_got.status) {
+				mmResolveReport.t.Errorf("RepositoryMock.ResolveReport got unexpected parameter status, want: %#v, got: %#v%s\n", *mm_want_ptrs.status, mm_got.status, minimock.Diff(*mm_want_ptrs.status, mm_got.status))
 			}
 
-			if mm_want_ptrs.offset != nil && !minimock.Equal(*mm_want_ptrs.offset, mm_got.offset) {
-				mmGetCommentsByPost.t.Errorf("RepositoryMock.GetCommentsByPost got unexpected parameter offset, want: %#v, got: %#v%s\n", *mm_want_ptrs.offset, mm_got.offset, minimock.Diff(*mm_want_ptrs.offset, mm_got.offset))
+			if mm_want_ptrs.record != nil && !minimock.Equal(*mm_want_ptrs.record, mm_got.record) {
+				mmResolveReport.t.Errorf("RepositoryMock.ResolveReport got unexpected parameter record, want: %#v, got: %#v%s\n", *mm_want_ptrs.record, mm_got.record, minimock.Diff(*mm_want_ptrs.record, mm_got.record))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmGetCommentsByPost.t.Errorf("RepositoryMock.GetCommentsByPost got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmResolveReport.t.Errorf("RepositoryMock.ResolveReport got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmGetCommentsByPost.GetCommentsByPostMock.defaultExpectation.results
+		mm_results := mmResolveReport.ResolveReportMock.defaultExpectation.results
 		if mm_results == nil {
-			mmGetCommentsByPost.t.Fatal("No results are set for the RepositoryMock.GetCommentsByPost")
+			mmResolveReport.t.Fatal("No results are set for the RepositoryMock.ResolveReport")
 		}
-		return (*mm_results).cpa1, (*mm_results).err
+		return (*mm_results).mp1, (*mm_results).err
 	}
-	if mmGetCommentsByPost.funcGetCommentsByPost != nil {
-		return mmGetCommentsByPost.funcGetCommentsByPost(ctx, postID, limit, offset)
+	if mmResolveReport.funcResolveReport != nil {
+		return mmResolveReport.funcResolveReport(ctx, reportID, status, record)
 	}
-	mmGetCommentsByPost.t.Fatalf("Unexpected call to RepositoryMock.GetCommentsByPost. %v %v %v %v", ctx, postID, limit, offset)
+	mmResolveReport.t.Fatalf("Unexpected call to RepositoryMock.ResolveReport. %v %v %v %v", ctx, reportID, status, record)
 	return
 }
 
-// GetCommentsByPostAfterCounter returns a count of finished RepositoryMock.GetCommentsByPost invocations
-func (mmGetCommentsByPost *RepositoryMock) GetCommentsByPostAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetCommentsByPost.afterGetCommentsByPostCounter)
+// ResolveReportAfterCounter returns a count of finished RepositoryMock.ResolveReport invocations
+func (mmResolveReport *RepositoryMock) ResolveReportAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmResolveReport.afterResolveReportCounter)
 }
 
-// GetCommentsByPostBeforeCounter returns a count of RepositoryMock.GetCommentsByPost invocations
-func (mmGetCommentsByPost *RepositoryMock) GetCommentsByPostBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetCommentsByPost.beforeGetCommentsByPostCounter)
+// ResolveReportBeforeCounter returns a count of RepositoryMock.ResolveReport invocations
+func (mmResolveReport *RepositoryMock) ResolveReportBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmResolveReport.beforeResolveReportCounter)
 }
 
-// Calls returns a list of arguments used in each call to RepositoryMock.GetCommentsByPost.
+// Calls returns a list of arguments used in each call to RepositoryMock.ResolveReport.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmGetCommentsByPost *mRepositoryMockGetCommentsByPost) Calls() []*RepositoryMockGetCommentsByPostParams {
-	mmGetCommentsByPost.mutex.RLock()
+func (mmResolveReport *mRepositoryMockResolveReport) Calls() []*RepositoryMockResolveReportParams {
+	mmResolveReport.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockGetCommentsByPostParams, len(mmGetCommentsByPost.callArgs))
-	copy(argCopy, mmGetCommentsByPost.callArgs)
+	argCopy := make([]*RepositoryMockResolveReportParams, len(mmResolveReport.callArgs))
+	copy(argCopy, mmResolveReport.callArgs)
 
-	mmGetCommentsByPost.mutex.RUnlock()
+	mmResolveReport.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockGetCommentsByPostDone returns true if the count of the GetCommentsByPost invocations corresponds
+// MinimockResolveReportDone returns true if the count of the ResolveReport invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockGetCommentsByPostDone() bool {
-	for _, e := range m.GetCommentsByPostMock.expectations {
+func (m *RepositoryMock) MinimockResolveReportDone() bool {
+	for _, e := range m.ResolveReportMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.GetCommentsByPostMock.invocationsDone()
+	return m.ResolveReportMock.invocationsDone()
 }
 
-// MinimockGetCommentsByPostInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockGetCommentsByPostInspect() {
-	for _, e := range m.GetCommentsByPostMock.expectations {
+// MinimockResolveReportInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockResolveReportInspect() {
+	for _, e := range m.ResolveReportMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.GetCommentsByPost with params: %#v", *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.ResolveReport with params: %#v", *e.params)
 		}
 	}
 
-	afterGetCommentsByPostCounter := mm_atomic.LoadUint64(&m.afterGetCommentsByPostCounter)
+	afterResolveReportCounter := mm_atomic.LoadUint64(&m.afterResolveReportCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.GetCommentsByPostMock.defaultExpectation != nil && afterGetCommentsByPostCounter < 1 {
-		if m.GetCommentsByPostMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to RepositoryMock.GetCommentsByPost")
+	if m.ResolveReportMock.defaultExpectation != nil && afterResolveReportCounter < 1 {
+		if m.ResolveReportMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.ResolveReport")
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.GetCommentsByPost with params: %#v", *m.GetCommentsByPostMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.ResolveReport with params: %#v", *m.ResolveReportMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcGetCommentsByPost != nil && afterGetCommentsByPostCounter < 1 {
-		m.t.Error("Expected call to RepositoryMock.GetCommentsByPost")
+	if m.funcResolveReport != nil && afterResolveReportCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.ResolveReport")
 	}
 
-	if !m.GetCommentsByPostMock.invocationsDone() && afterGetCommentsByPostCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.GetCommentsByPost but found %d calls",
-			mm_atomic.LoadUint64(&m.GetCommentsByPostMock.expectedInvocations), afterGetCommentsByPostCounter)
+	if !m.ResolveReportMock.invocationsDone() && afterResolveReportCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.ResolveReport but found %d calls",
+			mm_atomic.LoadUint64(&m.ResolveReportMock.expectedInvocations), afterResolveReportCounter)
 	}
 }
 
-type mRepositoryMockGetPost struct {
+type mRepositoryMockUpdateWebhookDelivery struct {
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockGetPostExpectation
-	expectations       []*RepositoryMockGetPostExpectation
+	defaultExpectation *RepositoryMockUpdateWebhookDeliveryExpectation
+	expectations       []*RepositoryMockUpdateWebhookDeliveryExpectation
 
-	callArgs []*RepositoryMockGetPostParams
+	callArgs []*RepositoryMockUpdateWebhookDeliveryParams
 	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// RepositoryMockGetPostExpectation specifies expectation struct of the Repository.GetPost
-type RepositoryMockGetPostExpectation struct {
+// RepositoryMockUpdateWebhookDeliveryExpectation specifies expectation struct of the Repository.UpdateWebhookDelivery
+type RepositoryMockUpdateWebhookDeliveryExpectation struct {
 	mock      *RepositoryMock
-	params    *RepositoryMockGetPostParams
-	paramPtrs *RepositoryMockGetPostParamPtrs
-	results   *RepositoryMockGetPostResults
+	params    *RepositoryMockUpdateWebhookDeliveryParams
+	paramPtrs *RepositoryMockUpdateWebhookDeliveryParamPtrs
+	results   *RepositoryMockUpdateWebhookDeliveryResults
 	Counter   uint64
 }
 
-// RepositoryMockGetPostParams contains parameters of the Repository.GetPost
-type RepositoryMockGetPostParams struct {
-	ctx context.Context
-	id  int
+// RepositoryMockUpdateWebhookDeliveryParams contains parameters of the Repository.UpdateWebhookDelivery
+type RepositoryMockUpdateWebhookDeliveryParams struct {
+	ctx      context.Context
+	delivery *domain.WebhookDelivery
 }
 
-// RepositoryMockGetPostParamPtrs contains pointers to parameters of the Repository.GetPost
-type RepositoryMockGetPostParamPtrs struct {
-	ctx *context.Context
-	id  *int
+// RepositoryMockUpdateWebhookDeliveryParamPtrs contains pointers to parameters of the Repository.UpdateWebhookDelivery
+type RepositoryMockUpdateWebhookDeliveryParamPtrs struct {
+	ctx      *context.Context
+	delivery **domain.WebhookDelivery
 }
 
-// RepositoryMockGetPostResults contains results of the Repository.GetPost
-type RepositoryMockGetPostResults struct {
-	pp1 *domain.Post
+// RepositoryMockUpdateWebhookDeliveryResults contains results of the Repository.UpdateWebhookDelivery
+type RepositoryMockUpdateWebhookDeliveryResults struct {
 	err error
 }
 
-// Expect sets up expected params for Repository.GetPost
-func (mmGetPost *mRepositoryMockGetPost) Expect(ctx context.Context, id int) *mRepositoryMockGetPost {
-	if mmGetPost.mock.funcGetPost != nil {
-		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Set")
+// Expect sets up expected params for Repository.UpdateWebhookDelivery
+func (mmUpdateWebhookDelivery *mRepositoryMockUpdateWebhookDelivery) Expect(ctx context.Context, delivery *domain.WebhookDelivery) *mRepositoryMockUpdateWebhookDelivery {
+	if mmUpdateWebhookDelivery.mock.funcUpdateWebhookDelivery != nil {
+		mmUpdateWebhookDelivery.mock.t.Fatalf("RepositoryMock.UpdateWebhookDelivery mock is already set by Set")
 	}
 
-	if mmGetPost.defaultExpectation == nil {
-		mmGetPost.defaultExpectation = &RepositoryMockGetPostExpectation{}
+	if mmUpdateWebhookDelivery.defaultExpectation == nil {
+		mmUpdateWebhookDelivery.defaultExpectation = &RepositoryMockUpdateWebhookDeliveryExpectation{}
 	}
 
-	if mmGetPost.defaultExpectation.paramPtrs != nil {
-		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by ExpectParams functions")
+	if mmUpdateWebhookDelivery.defaultExpectation.paramPtrs != nil {
+		mmUpdateWebhookDelivery.mock.t.Fatalf("RepositoryMock.UpdateWebhookDelivery mock is already set by ExpectParams functions")
 	}
 
-	mmGetPost.defaultExpectation.params = &RepositoryMockGetPostParams{ctx, id}
-	for _, e := range mmGetPost.expectations {
-		if minimock.Equal(e.params, mmGetPost.defaultExpectation.params) {
-			mmGetPost.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetPost.defaultExpectation.params)
+	mmUpdateWebhookDelivery.defaultExpectation.params = &RepositoryMockUpdateWebhookDeliveryParams{ctx, delivery}
+	for _, e := range mmUpdateWebhookDelivery.expectations {
+		if minimock.Equal(e.params, mmUpdateWebhookDelivery.defaultExpectation.params) {
+			mmUpdateWebhookDelivery.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmUpdateWebhookDelivery.defaultExpectation.params)
 		}
 	}
 
-	return mmGetPost
+	return mmUpdateWebhookDelivery
 }
 
-// ExpectCtxParam1 sets up expected param ctx for Repository.GetPost
-func (mmGetPost *mRepositoryMockGetPost) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetPost {
-	if mmGetPost.mock.funcGetPost != nil {
-		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Repository.UpdateWebhookDelivery
+func (mmUpdateWebhookDelivery *mRepositoryMockUpdateWebhookDelivery) ExpectCtxParam1(ctx context.Context) *mRepositoryMockUpdateWebhookDelivery {
+	if mmUpdateWebhookDelivery.mock.funcUpdateWebhookDelivery != nil {
+		mmUpdateWebhookDelivery.mock.t.Fatalf("RepositoryMock.UpdateWebhookDelivery mock is already set by Set")
 	}
 
-	if mmGetPost.defaultExpectation == nil {
-		mmGetPost.defaultExpectation = &RepositoryMockGetPostExpectation{}
+	if mmUpdateWebhookDelivery.defaultExpectation == nil {
+		mmUpdateWebhookDelivery.defaultExpectation = &RepositoryMockUpdateWebhookDeliveryExpectation{}
 	}
 
-	if mmGetPost.defaultExpectation.params != nil {
-		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Expect")
+	if mmUpdateWebhookDelivery.defaultExpectation.params != nil {
+		mmUpdateWebhookDelivery.mock.t.Fatalf("RepositoryMock.UpdateWebhookDelivery mock is already set by Expect")
 	}
 
-	if mmGetPost.defaultExpectation.paramPtrs == nil {
-		mmGetPost.defaultExpectation.paramPtrs = &RepositoryMockGetPostParamPtrs{}
+	if mmUpdateWebhookDelivery.defaultExpectation.paramPtrs == nil {
+		mmUpdateWebhookDelivery.defaultExpectation.paramPtrs = &RepositoryMockUpdateWebhookDeliveryParamPtrs{}
 	}
-	mmGetPost.defaultExpectation.paramPtrs.ctx = &ctx
+	mmUpdateWebhookDelivery.defaultExpectation.paramPtrs.ctx = &ctx
 
-	return mmGetPost
+	return mmUpdateWebhookDelivery
 }
 
-// ExpectIdParam2 sets up expected param id for Repository.GetPost
-func (mmGetPost *mRepositoryMockGetPost) ExpectIdParam2(id int) *mRepositoryMockGetPost {
-	if mmGetPost.mock.funcGetPost != nil {
-		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Set")
+// ExpectDeliveryParam2 sets up expected param delivery for Repository.UpdateWebhookDelivery
+func (mmUpdateWebhookDelivery *mRepositoryMockUpdateWebhookDelivery) ExpectDeliveryParam2(delivery *domain.WebhookDelivery) *mRepositoryMockUpdateWebhookDelivery {
+	if mmUpdateWebhookDelivery.mock.funcUpdateWebhookDelivery != nil {
+		mmUpdateWebhookDelivery.mock.t.Fatalf("RepositoryMock.UpdateWebhookDelivery mock is already set by Set")
 	}
 
-	if mmGetPost.defaultExpectation == nil {
-		mmGetPost.defaultExpectation = &RepositoryMockGetPostExpectation{}
+	if mmUpdateWebhookDelivery.defaultExpectation == nil {
+		mmUpdateWebhookDelivery.defaultExpectation = &RepositoryMockUpdateWebhookDeliveryExpectation{}
 	}
 
-	if mmGetPost.defaultExpectation.params != nil {
-		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Expect")
+	if mmUpdateWebhookDelivery.defaultExpectation.params != nil {
+		mmUpdateWebhookDelivery.mock.t.Fatalf("RepositoryMock.UpdateWebhookDelivery mock is already set by Expect")
 	}
 
-	if mmGetPost.defaultExpectation.paramPtrs == nil {
-		mmGetPost.defaultExpectation.paramPtrs = &RepositoryMockGetPostParamPtrs{}
+	if mmUpdateWebhookDelivery.defaultExpectation.paramPtrs == nil {
+		mmUpdateWebhookDelivery.defaultExpectation.paramPtrs = &RepositoryMockUpdateWebhookDeliveryParamPtrs{}
 	}
-	mmGetPost.defaultExpectation.paramPtrs.id = &id
+	mmUpdateWebhookDelivery.defaultExpectation.paramPtrs.delivery = &delivery
 
-	return mmGetPost
+	return mmUpdateWebhookDelivery
 }
 
-// Inspect accepts an inspector function that has same arguments as the Repository.GetPost
-func (mmGetPost *mRepositoryMockGetPost) Inspect(f func(ctx context.Context, id int)) *mRepositoryMockGetPost {
-	if mmGetPost.mock.inspectFuncGetPost != nil {
-		mmGetPost.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetPost")
+// Inspect accepts an inspector function that has same arguments as the Repository.UpdateWebhookDelivery
+func (mmUpdateWebhookDelivery *mRepositoryMockUpdateWebhookDelivery) Inspect(f func(ctx context.Context, delivery *domain.WebhookDelivery)) *mRepositoryMockUpdateWebhookDelivery {
+	if mmUpdateWebhookDelivery.mock.inspectFuncUpdateWebhookDelivery != nil {
+		mmUpdateWebhookDelivery.mock.t.Fatalf("Inspect function is already set for RepositoryMock.UpdateWebhookDelivery")
 	}
 
-	mmGetPost.mock.inspectFuncGetPost = f
+	mmUpdateWebhookDelivery.mock.inspectFuncUpdateWebhookDelivery = f
 
-	return mmGetPost
+	return mmUpdateWebhookDelivery
 }
 
-// Return sets up results that will be returned by Repository.GetPost
-func (mmGetPost *mRepositoryMockGetPost) Return(pp1 *domain.Post, err error) *RepositoryMock {
-	if mmGetPost.mock.funcGetPost != nil {
-		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Set")
+// Return sets up results that will be returned by Repository.UpdateWebhookDelivery
+func (mmUpdateWebhookDelivery *mRepositoryMockUpdateWebhookDelivery) Return(err error) *RepositoryMock {
+	if mmUpdateWebhookDelivery.mock.funcUpdateWebhookDelivery != nil {
+		mmUpdateWebhookDelivery.mock.t.Fatalf("RepositoryMock.UpdateWebhookDelivery mock is already set by Set")
 	}
 
-	if mmGetPost.defaultExpectation == nil {
-		mmGetPost.defaultExpectation = &RepositoryMockGetPostExpectation{mock: mmGetPost.mock}
+	if mmUpdateWebhookDelivery.defaultExpectation == nil {
+		mmUpdateWebhookDelivery.defaultExpectation = &RepositoryMockUpdateWebhookDeliveryExpectation{mock: mmUpdateWebhookDelivery.mock}
 	}
-	mmGetPost.defaultExpectation.results = &RepositoryMockGetPostResults{pp1, err}
-	return mmGetPost.mock
+	mmUpdateWebhookDelivery.defaultExpectation.results = &RepositoryMockUpdateWebhookDeliveryResults{err}
+	return mmUpdateWebhookDelivery.mock
 }
 
-// Set uses given function f to mock the Repository.GetPost method
-func (mmGetPost *mRepositoryMockGetPost) Set(f func(ctx context.Context, id int) (pp1 *domain.Post, err error)) *RepositoryMock {
-	if mmGetPost.defaultExpectation != nil {
-		mmGetPost.mock.t.Fatalf("Default expectation is already set for the Repository.GetPost method")
+// Set uses given function f to mock the Repository.UpdateWebhookDelivery method
+func (mmUpdateWebhookDelivery *mRepositoryMockUpdateWebhookDelivery) Set(f func(ctx context.Context, delivery *domain.WebhookDelivery) (err error)) *RepositoryMock {
+	if mmUpdateWebhookDelivery.defaultExpectation != nil {
+		mmUpdateWebhookDelivery.mock.t.Fatalf("Default expectation is already set for the Repository.UpdateWebhookDelivery method")
 	}
 
-	if len(mmGetPost.expectations) > 0 {
-		mmGetPost.mock.t.Fatalf("Some expectations are already set for the Repository.GetPost method")
+	if len(mmUpdateWebhookDelivery.expectations) > 0 {
+		mmUpdateWebhookDelivery.mock.t.Fatalf("Some expectations are already set for the Repository.UpdateWebhookDelivery method")
 	}
 
-	mmGetPost.mock.funcGetPost = f
-	return mmGetPost.mock
+	mmUpdateWebhookDelivery.mock.funcUpdateWebhookDelivery = f
+	return mmUpdateWebhookDelivery.mock
 }
 
-// When sets expectation for the Repository.GetPost which will trigger the result defined by the following
+// When sets expectation for the Repository.UpdateWebhookDelivery which will trigger the result defined by the following
 // Then helper
-func (mmGetPost *mRepositoryMockGetPost) When(ctx context.Context, id int) *RepositoryMockGetPostExpectation {
-	if mmGetPost.mock.funcGetPost != nil {
-		mmGetPost.mock.t.Fatalf("RepositoryMock.GetPost mock is already set by Set")
+func (mmUpdateWebhookDelivery *mRepositoryMockUpdateWebhookDelivery) When(ctx context.Context, delivery *domain.WebhookDelivery) *RepositoryMockUpdateWebhookDeliveryExpectation {
+	if mmUpdateWebhookDelivery.mock.funcUpdateWebhookDelivery != nil {
+		mmUpdateWebhookDelivery.mock.t.Fatalf("RepositoryMock.UpdateWebhookDelivery mock is already set by Set")
 	}
 
-	expectation := &RepositoryMockGetPostExpectation{
-		mock:   mmGetPost.mock,
-		params: &RepositoryMockGetPostParams{ctx, id},
+	expectation := &RepositoryMockUpdateWebhookDeliveryExpectation{
+		mock:   mmUpdateWebhookDelivery.mock,
+		params: &RepositoryMockUpdateWebhookDeliveryParams{ctx, delivery},
 	}
-	mmGetPost.expectations = append(mmGetPost.expectations, expectation)
+	mmUpdateWebhookDelivery.expectations = append(mmUpdateWebhookDelivery.expectations, expectation)
 	return expectation
 }
 
-// Then sets up Repository.GetPost return parameters for the expectation previously defined by the When method
-func (e *RepositoryMockGetPostExpectation) Then(pp1 *domain.Post, err error) *RepositoryMock {
-	e.results = &RepositoryMockGetPostResults{pp1, err}
+// Then sets up Repository.UpdateWebhookDelivery return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockUpdateWebhookDeliveryExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockUpdateWebhookDeliveryResults{err}
 	return e.mock
 }
 
-// Times sets number of times Repository.GetPost should be invoked
-func (mmGetPost *mRepositoryMockGetPost) Times(n uint64) *mRepositoryMockGetPost {
+// Times sets number of times Repository.UpdateWebhookDelivery should be invoked
+func (mmUpdateWebhookDelivery *mRepositoryMockUpdateWebhookDelivery) Times(n uint64) *mRepositoryMockUpdateWebhookDelivery {
 	if n == 0 {
-		mmGetPost.mock.t.Fatalf("Times of RepositoryMock.GetPost mock can not be zero")
+		mmUpdateWebhookDelivery.mock.t.Fatalf("Times of RepositoryMock.UpdateWebhookDelivery mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmGetPost.expectedInvocations, n)
-	return mmGetPost
+	mm_atomic.StoreUint64(&mmUpdateWebhookDelivery.expectedInvocations, n)
+	return mmUpdateWebhookDelivery
 }
 
-func (mmGetPost *mRepositoryMockGetPost) invocationsDone() bool {
-	if len(mmGetPost.expectations) == 0 && mmGetPost.defaultExpectation == nil && mmGetPost.mock.funcGetPost == nil {
+func (mmUpdateWebhookDelivery *mRepositoryMockUpdateWebhookDelivery) invocationsDone() bool {
+	if len(mmUpdateWebhookDelivery.expectations) == 0 && mmUpdateWebhookDelivery.defaultExpectation == nil && mmUpdateWebhookDelivery.mock.funcUpdateWebhookDelivery == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmGetPost.mock.afterGetPostCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmGetPost.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmUpdateWebhookDelivery.mock.afterUpdateWebhookDeliveryCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmUpdateWebhookDelivery.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// GetPost implements repository.Repository
-func (mmGetPost *RepositoryMock) GetPost(ctx context.Context, id int) (pp1 *domain.Post, err error) {
-	mm_atomic.AddUint64(&mmGetPost.beforeGetPostCounter, 1)
-	defer mm_atomic.AddUint64(&mmGetPost.afterGetPostCounter, 1)
+// UpdateWebhookDelivery implements repository.Repository
+func (mmUpdateWebhookDelivery *RepositoryMock) UpdateWebhookDelivery(ctx context.Context, delivery *domain.WebhookDelivery) (err error) {
+	mm_atomic.AddUint64(&mmUpdateWebhookDelivery.beforeUpdateWebhookDeliveryCounter, 1)
+	defer mm_atomic.AddUint64(&mmUpdateWebhookDelivery.afterUpdateWebhookDeliveryCounter, 1)
 
-	if mmGetPost.inspectFuncGetPost != nil {
-		mmGetPost.inspectFuncGetPost(ctx, id)
+	if mmUpdateWebhookDelivery.inspectFuncUpdateWebhookDelivery != nil {
+		mmUpdateWebhookDelivery.inspectFuncUpdateWebhookDelivery(ctx, delivery)
 	}
 
-	mm_params := RepositoryMockGetPostParams{ctx, id}
+	mm_params := RepositoryMockUpdateWebhookDeliveryParams{ctx, delivery}
 
 	// Record call args
-	mmGetPost.GetPostMock.mutex.Lock()
-	mmGetPost.GetPostMock.callArgs = append(mmGetPost.GetPostMock.callArgs, &mm_params)
-	mmGetPost.GetPostMock.mutex.Unlock()
+	mmUpdateWebhookDelivery.UpdateWebhookDeliveryMock.mutex.Lock()
+	mmUpdateWebhookDelivery.UpdateWebhookDeliveryMock.callArgs = append(mmUpdateWebhookDelivery.UpdateWebhookDeliveryMock.callArgs, &mm_params)
+	mmUpdateWebhookDelivery.UpdateWebhookDeliveryMock.mutex.Unlock()
 
-	for _, e := range mmGetPost.GetPostMock.expectations {
+	for _, e := range mmUpdateWebhookDelivery.UpdateWebhookDeliveryMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.pp1, e.results.err
+			return e.results.err
 		}
 	}
 
-	if mmGetPost.GetPostMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmGetPost.GetPostMock.defaultExpectation.Counter, 1)
-		mm_want := mmGetPost.GetPostMock.defaultExpectation.params
-		mm_want_ptrs := mmGetPost.GetPostMock.defaultExpectation.paramPtrs
+	if mmUpdateWebhookDelivery.UpdateWebhookDeliveryMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUpdateWebhookDelivery.UpdateWebhookDeliveryMock.defaultExpectation.Counter, 1)
+		mm_want := mmUpdateWebhookDelivery.UpdateWebhookDeliveryMock.defaultExpectation.params
+		mm_want_ptrs := mmUpdateWebhookDelivery.UpdateWebhookDeliveryMock.defaultExpectation.paramPtrs
 
-		mm_got := RepositoryMockGetPostParams{ctx, id}
+		mm_got := RepositoryMockUpdateWebhookDeliveryParams{ctx, delivery}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmGetPost.t.Errorf("RepositoryMock.GetPost got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmUpdateWebhookDelivery.t.Errorf("RepositoryMock.UpdateWebhookDelivery got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.id != nil && !minimock.Equal(*mm_want_ptrs.id, mm_got.id) {
-				mmGetPost.t.Errorf("RepositoryMock.GetPost got unexpected parameter id, want: %#v, got: %#v%s\n", *mm_want_ptrs.id, mm_got.id, minimock.Diff(*mm_want_ptrs.id, mm_got.id))
+			if mm_want_ptrs.delivery != nil && !minimock.Equal(*mm_want_ptrs.delivery, mm_got.delivery) {
+				mmUpdateWebhookDelivery.t.Errorf("RepositoryMock.UpdateWebhookDelivery got unexpected parameter delivery, want: %#v, got: %#v%s\n", *mm_want_ptrs.delivery, mm_got.delivery, minimock.Diff(*mm_want_ptrs.delivery, mm_got.delivery))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmGetPost.t.Errorf("RepositoryMock.GetPost got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmUpdateWebhookDelivery.t.Errorf("RepositoryMock.UpdateWebhookDelivery got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmGetPost.GetPostMock.defaultExpectation.results
+		mm_results := mmUpdateWebhookDelivery.UpdateWebhookDeliveryMock.defaultExpectation.results
 		if mm_results == nil {
-			mmGetPost.t.Fatal("No results are set for the RepositoryMock.GetPost")
+			mmUpdateWebhookDelivery.t.Fatal("No results are set for the RepositoryMock.UpdateWebhookDelivery")
 		}
-		return (*mm_results).pp1, (*mm_results).err
+		return (*mm_results).err
 	}
-	if mmGetPost.funcGetPost != nil {
-		return mmGetPost.funcGetPost(ctx, id)
+	if mmUpdateWebhookDelivery.funcUpdateWebhookDelivery != nil {
+		return mmUpdateWebhookDelivery.funcUpdateWebhookDelivery(ctx, delivery)
 	}
-	mmGetPost.t.Fatalf("Unexpected call to RepositoryMock.GetPost. %v %v", ctx, id)
+	mmUpdateWebhookDelivery.t.Fatalf("Unexpected call to RepositoryMock.UpdateWebhookDelivery. %v %v", ctx, delivery)
 	return
 }
 
-// GetPostAfterCounter returns a count of finished RepositoryMock.GetPost invocations
-func (mmGetPost *RepositoryMock) GetPostAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetPost.afterGetPostCounter)
+// UpdateWebhookDeliveryAfterCounter returns a count of finished RepositoryMock.UpdateWebhookDelivery invocations
+func (mmUpdateWebhookDelivery *RepositoryMock) UpdateWebhookDeliveryAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateWebhookDelivery.afterUpdateWebhookDeliveryCounter)
 }
 
-// GetPostBeforeCounter returns a count of RepositoryMock.GetPost invocations
-func (mmGetPost *RepositoryMock) GetPostBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetPost.beforeGetPostCounter)
+// UpdateWebhookDeliveryBeforeCounter returns a count of RepositoryMock.UpdateWebhookDelivery invocations
+func (mmUpdateWebhookDelivery *RepositoryMock) UpdateWebhookDeliveryBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateWebhookDelivery.beforeUpdateWebhookDeliveryCounter)
 }
 
-// Calls returns a list of arguments used in each call to RepositoryMock.GetPost.
+// Calls returns a list of arguments used in each call to RepositoryMock.UpdateWebhookDelivery.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmGetPost *mRepositoryMockGetPost) Calls() []*RepositoryMockGetPostParams {
-	mmGetPost.mutex.RLock()
+func (mmUpdateWebhookDelivery *mRepositoryMockUpdateWebhookDelivery) Calls() []*RepositoryMockUpdateWebhookDeliveryParams {
+	mmUpdateWebhookDelivery.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockGetPostParams, len(mmGetPost.callArgs))
-	copy(argCopy, mmGetPost.callArgs)
+	argCopy := make([]*RepositoryMockUpdateWebhookDeliveryParams, len(mmUpdateWebhookDelivery.callArgs))
+	copy(argCopy, mmUpdateWebhookDelivery.callArgs)
 
-	mmGetPost.mutex.RUnlock()
+	mmUpdateWebhookDelivery.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockGetPostDone returns true if the count of the GetPost invocations corresponds
+// MinimockUpdateWebhookDeliveryDone returns true if the count of the UpdateWebhookDelivery invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockGetPostDone() bool {
-	for _, e := range m.GetPostMock.expectations {
+func (m *RepositoryMock) MinimockUpdateWebhookDeliveryDone() bool {
+	for _, e := range m.UpdateWebhookDeliveryMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.GetPostMock.invocationsDone()
+	return m.UpdateWebhookDeliveryMock.invocationsDone()
 }
 
-// MinimockGetPostInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockGetPostInspect() {
-	for _, e := range m.GetPostMock.expectations {
+// MinimockUpdateWebhookDeliveryInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockUpdateWebhookDeliveryInspect() {
+	for _, e := range m.UpdateWebhookDeliveryMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.GetPost with params: %#v", *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.UpdateWebhookDelivery with params: %#v", *e.params)
 		}
 	}
 
-	afterGetPostCounter := mm_atomic.LoadUint64(&m.afterGetPostCounter)
+	afterUpdateWebhookDeliveryCounter := mm_atomic.LoadUint64(&m.afterUpdateWebhookDeliveryCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.GetPostMock.defaultExpectation != nil && afterGetPostCounter < 1 {
-		if m.GetPostMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to RepositoryMock.GetPost")
+	if m.UpdateWebhookDeliveryMock.defaultExpectation != nil && afterUpdateWebhookDeliveryCounter < 1 {
+		if m.UpdateWebhookDeliveryMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.UpdateWebhookDelivery")
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.GetPost with params: %#v", *m.GetPostMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.UpdateWebhookDelivery with params: %#v", *m.UpdateWebhookDeliveryMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcGetPost != nil && afterGetPostCounter < 1 {
-		m.t.Error("Expected call to RepositoryMock.GetPost")
+	if m.funcUpdateWebhookDelivery != nil && afterUpdateWebhookDeliveryCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.UpdateWebhookDelivery")
 	}
 
-	if !m.GetPostMock.invocationsDone() && afterGetPostCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.GetPost but found %d calls",
-			mm_atomic.LoadUint64(&m.GetPostMock.expectedInvocations), afterGetPostCounter)
+	if !m.UpdateWebhookDeliveryMock.invocationsDone() && afterUpdateWebhookDeliveryCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.UpdateWebhookDelivery but found %d calls",
+			mm_atomic.LoadUint64(&m.UpdateWebhookDeliveryMock.expectedInvocations), afterUpdateWebhookDeliveryCounter)
 	}
 }
 
-type mRepositoryMockGetPosts struct {
+type mRepositoryMockWithTx struct {
 	mock               *RepositoryMock
-	defaultExpectation *RepositoryMockGetPostsExpectation
-	expectations       []*RepositoryMockGetPostsExpectation
+	defaultExpectation *RepositoryMockWithTxExpectation
+	expectations       []*RepositoryMockWithTxExpectation
 
-	callArgs []*RepositoryMockGetPostsParams
+	callArgs []*RepositoryMockWithTxParams
 	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// RepositoryMockGetPostsExpectation specifies expectation struct of the Repository.GetPosts
-type RepositoryMockGetPostsExpectation struct {
+// RepositoryMockWithTxExpectation specifies expectation struct of the Repository.WithTx
+type RepositoryMockWithTxExpectation struct {
 	mock      *RepositoryMock
-	params    *RepositoryMockGetPostsParams
-	paramPtrs *RepositoryMockGetPostsParamPtrs
-	results   *RepositoryMockGetPostsResults
+	params    *RepositoryMockWithTxParams
+	paramPtrs *RepositoryMockWithTxParamPtrs
+	results   *RepositoryMockWithTxResults
 	Counter   uint64
 }
 
-// RepositoryMockGetPostsParams contains parameters of the Repository.GetPosts
-type RepositoryMockGetPostsParams struct {
+// RepositoryMockWithTxParams contains parameters of the Repository.WithTx
+type RepositoryMockWithTxParams struct {
 	ctx context.Context
+	fn  func(repo mm_repository.Repository) error
 }
 
-// RepositoryMockGetPostsParamPtrs contains pointers to parameters of the Repository.GetPosts
-type RepositoryMockGetPostsParamPtrs struct {
+// RepositoryMockWithTxParamPtrs contains pointers to parameters of the Repository.WithTx
+type RepositoryMockWithTxParamPtrs struct {
 	ctx *context.Context
+	fn  *func(repo mm_repository.Repository) error
 }
 
-// RepositoryMockGetPostsResults contains results of the Repository.GetPosts
-type RepositoryMockGetPostsResults struct {
-	ppa1 []*domain.Post
-	err  error
+// RepositoryMockWithTxResults contains results of the Repository.WithTx
+type RepositoryMockWithTxResults struct {
+	err error
 }
 
-// Expect sets up expected params for Repository.GetPosts
-func (mmGetPosts *mRepositoryMockGetPosts) Expect(ctx context.Context) *mRepositoryMockGetPosts {
-	if mmGetPosts.mock.funcGetPosts != nil {
-		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by Set")
+// Expect sets up expected params for Repository.WithTx
+func (mmWithTx *mRepositoryMockWithTx) Expect(ctx context.Context, fn func(repo mm_repository.Repository) error) *mRepositoryMockWithTx {
+	if mmWithTx.mock.funcWithTx != nil {
+		mmWithTx.mock.t.Fatalf("RepositoryMock.WithTx mock is already set by Set")
 	}
 
-	if mmGetPosts.defaultExpectation == nil {
-		mmGetPosts.defaultExpectation = &RepositoryMockGetPostsExpectation{}
+	if mmWithTx.defaultExpectation == nil {
+		mmWithTx.defaultExpectation = &RepositoryMockWithTxExpectation{}
 	}
 
-	if mmGetPosts.defaultExpectation.paramPtrs != nil {
-		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by ExpectParams functions")
+	if mmWithTx.defaultExpectation.paramPtrs != nil {
+		mmWithTx.mock.t.Fatalf("RepositoryMock.WithTx mock is already set by ExpectParams functions")
 	}
 
-	mmGetPosts.defaultExpectation.params = &RepositoryMockGetPostsParams{ctx}
-	for _, e := range mmGetPosts.expectations {
-		if minimock.Equal(e.params, mmGetPosts.defaultExpectation.params) {
-			mmGetPosts.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetPosts.defaultExpectation.params)
+	mmWithTx.defaultExpectation.params = &RepositoryMockWithTxParams{ctx, fn}
+	for _, e := range mmWithTx.expectations {
+		if minimock.Equal(e.params, mmWithTx.defaultExpectation.params) {
+			mmWithTx.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmWithTx.defaultExpectation.params)
 		}
 	}
 
-	return mmGetPosts
+	return mmWithTx
 }
 
-// ExpectCtxParam1 sets up expected param ctx for Repository.GetPosts
-func (mmGetPosts *mRepositoryMockGetPosts) ExpectCtxParam1(ctx context.Context) *mRepositoryMockGetPosts {
-	if mmGetPosts.mock.funcGetPosts != nil {
-		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for Repository.WithTx
+func (mmWithTx *mRepositoryMockWithTx) ExpectCtxParam1(ctx context.Context) *mRepositoryMockWithTx {
+	if mmWithTx.mock.funcWithTx != nil {
+		mmWithTx.mock.t.Fatalf("RepositoryMock.WithTx mock is already set by Set")
 	}
 
-	if mmGetPosts.defaultExpectation == nil {
-		mmGetPosts.defaultExpectation = &RepositoryMockGetPostsExpectation{}
+	if mmWithTx.defaultExpectation == nil {
+		mmWithTx.defaultExpectation = &RepositoryMockWithTxExpectation{}
 	}
 
-	if mmGetPosts.defaultExpectation.params != nil {
-		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by Expect")
+	if mmWithTx.defaultExpectation.params != nil {
+		mmWithTx.mock.t.Fatalf("RepositoryMock.WithTx mock is already set by Expect")
 	}
 
-	if mmGetPosts.defaultExpectation.paramPtrs == nil {
-		mmGetPosts.defaultExpectation.paramPtrs = &RepositoryMockGetPostsParamPtrs{}
+	if mmWithTx.defaultExpectation.paramPtrs == nil {
+		mmWithTx.defaultExpectation.paramPtrs = &RepositoryMockWithTxParamPtrs{}
 	}
-	mmGetPosts.defaultExpectation.paramPtrs.ctx = &ctx
+	mmWithTx.defaultExpectation.paramPtrs.ctx = &ctx
 
-	return mmGetPosts
+	return mmWithTx
 }
 
-// Inspect accepts an inspector function that has same arguments as the Repository.GetPosts
-func (mmGetPosts *mRepositoryMockGetPosts) Inspect(f func(ctx context.Context)) *mRepositoryMockGetPosts {
-	if mmGetPosts.mock.inspectFuncGetPosts != nil {
-		mmGetPosts.mock.t.Fatalf("Inspect function is already set for RepositoryMock.GetPosts")
+// ExpectFnParam2 sets up expected param fn for Repository.WithTx
+func (mmWithTx *mRepositoryMockWithTx) ExpectFnParam2(fn func(repo mm_repository.Repository) error) *mRepositoryMockWithTx {
+	if mmWithTx.mock.funcWithTx != nil {
+		mmWithTx.mock.t.Fatalf("RepositoryMock.WithTx mock is already set by Set")
 	}
 
-	mmGetPosts.mock.inspectFuncGetPosts = f
+	if mmWithTx.defaultExpectation == nil {
+		mmWithTx.defaultExpectation = &RepositoryMockWithTxExpectation{}
+	}
 
-	return mmGetPosts
+	if mmWithTx.defaultExpectation.params != nil {
+		mmWithTx.mock.t.Fatalf("RepositoryMock.WithTx mock is already set by Expect")
+	}
+
+	if mmWithTx.defaultExpectation.paramPtrs == nil {
+		mmWithTx.defaultExpectation.paramPtrs = &RepositoryMockWithTxParamPtrs{}
+	}
+	mmWithTx.defaultExpectation.paramPtrs.fn = &fn
+
+	return mmWithTx
 }
 
-// Return sets up results that will be returned by Repository.GetPosts
-func (mmGetPosts *mRepositoryMockGetPosts) Return(ppa1 []*domain.Post, err error) *RepositoryMock {
-	if mmGetPosts.mock.funcGetPosts != nil {
-		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by Set")
+// Inspect accepts an inspector function that has same arguments as the Repository.WithTx
+func (mmWithTx *mRepositoryMockWithTx) Inspect(f func(ctx context.Context, fn func(repo mm_repository.Repository) error)) *mRepositoryMockWithTx {
+	if mmWithTx.mock.inspectFuncWithTx != nil {
+		mmWithTx.mock.t.Fatalf("Inspect function is already set for RepositoryMock.WithTx")
 	}
 
-	if mmGetPosts.defaultExpectation == nil {
-		mmGetPosts.defaultExpectation = &RepositoryMockGetPostsExpectation{mock: mmGetPosts.mock}
+	mmWithTx.mock.inspectFuncWithTx = f
+
+	return mmWithTx
+}
+
+// Return sets up results that will be returned by Repository.WithTx
+func (mmWithTx *mRepositoryMockWithTx) Return(err error) *RepositoryMock {
+	if mmWithTx.mock.funcWithTx != nil {
+		mmWithTx.mock.t.Fatalf("RepositoryMock.WithTx mock is already set by Set")
 	}
-	mmGetPosts.defaultExpectation.results = &RepositoryMockGetPostsResults{ppa1, err}
-	return mmGetPosts.mock
+
+	if mmWithTx.defaultExpectation == nil {
+		mmWithTx.defaultExpectation = &RepositoryMockWithTxExpectation{mock: mmWithTx.mock}
+	}
+	mmWithTx.defaultExpectation.results = &RepositoryMockWithTxResults{err}
+	return mmWithTx.mock
 }
 
-// Set uses given function f to mock the Repository.GetPosts method
-func (mmGetPosts *mRepositoryMockGetPosts) Set(f func(ctx context.Context) (ppa1 []*domain.Post, err error)) *RepositoryMock {
-	if mmGetPosts.defaultExpectation != nil {
-		mmGetPosts.mock.t.Fatalf("Default expectation is already set for the Repository.GetPosts method")
+// Set uses given function f to mock the Repository.WithTx method
+func (mmWithTx *mRepositoryMockWithTx) Set(f func(ctx context.Context, fn func(repo mm_repository.Repository) error) (err error)) *RepositoryMock {
+	if mmWithTx.defaultExpectation != nil {
+		mmWithTx.mock.t.Fatalf("Default expectation is already set for the Repository.WithTx method")
 	}
 
-	if len(mmGetPosts.expectations) > 0 {
-		mmGetPosts.mock.t.Fatalf("Some expectations are already set for the Repository.GetPosts method")
+	if len(mmWithTx.expectations) > 0 {
+		mmWithTx.mock.t.Fatalf("Some expectations are already set for the Repository.WithTx method")
 	}
 
-	mmGetPosts.mock.funcGetPosts = f
-	return mmGetPosts.mock
+	mmWithTx.mock.funcWithTx = f
+	return mmWithTx.mock
 }
 
-// When sets expectation for the Repository.GetPosts which will trigger the result defined by the following
+// When sets expectation for the Repository.WithTx which will trigger the result defined by the following
 // Then helper
-func (mmGetPosts *mRepositoryMockGetPosts) When(ctx context.Context) *RepositoryMockGetPostsExpectation {
-	if mmGetPosts.mock.funcGetPosts != nil {
-		mmGetPosts.mock.t.Fatalf("RepositoryMock.GetPosts mock is already set by Set")
+func (mmWithTx *mRepositoryMockWithTx) When(ctx context.Context, fn func(repo mm_repository.Repository) error) *RepositoryMockWithTxExpectation {
+	if mmWithTx.mock.funcWithTx != nil {
+		mmWithTx.mock.t.Fatalf("RepositoryMock.WithTx mock is already set by Set")
 	}
 
-	expectation := &RepositoryMockGetPostsExpectation{
-		mock:   mmGetPosts.mock,
-		params: &RepositoryMockGetPostsParams{ctx},
+	expectation := &RepositoryMockWithTxExpectation{
+		mock:   mmWithTx.mock,
+		params: &RepositoryMockWithTxParams{ctx, fn},
 	}
-	mmGetPosts.expectations = append(mmGetPosts.expectations, expectation)
+	mmWithTx.expectations = append(mmWithTx.expectations, expectation)
 	return expectation
 }
 
-// Then sets up Repository.GetPosts return parameters for the expectation previously defined by the When method
-func (e *RepositoryMockGetPostsExpectation) Then(ppa1 []*domain.Post, err error) *RepositoryMock {
-	e.results = &RepositoryMockGetPostsResults{ppa1, err}
+// Then sets up Repository.WithTx return parameters for the expectation previously defined by the When method
+func (e *RepositoryMockWithTxExpectation) Then(err error) *RepositoryMock {
+	e.results = &RepositoryMockWithTxResults{err}
 	return e.mock
 }
 
-// Times sets number of times Repository.GetPosts should be invoked
-func (mmGetPosts *mRepositoryMockGetPosts) Times(n uint64) *mRepositoryMockGetPosts {
+// Times sets number of times Repository.WithTx should be invoked
+func (mmWithTx *mRepositoryMockWithTx) Times(n uint64) *mRepositoryMockWithTx {
 	if n == 0 {
-		mmGetPosts.mock.t.Fatalf("Times of RepositoryMock.GetPosts mock can not be zero")
+		mmWithTx.mock.t.Fatalf("Times of RepositoryMock.WithTx mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmGetPosts.expectedInvocations, n)
-	return mmGetPosts
+	mm_atomic.StoreUint64(&mmWithTx.expectedInvocations, n)
+	return mmWithTx
 }
 
-func (mmGetPosts *mRepositoryMockGetPosts) invocationsDone() bool {
-	if len(mmGetPosts.expectations) == 0 && mmGetPosts.defaultExpectation == nil && mmGetPosts.mock.funcGetPosts == nil {
+func (mmWithTx *mRepositoryMockWithTx) invocationsDone() bool {
+	if len(mmWithTx.expectations) == 0 && mmWithTx.defaultExpectation == nil && mmWithTx.mock.funcWithTx == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmGetPosts.mock.afterGetPostsCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmGetPosts.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmWithTx.mock.afterWithTxCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmWithTx.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// GetPosts implements repository.Repository
-func (mmGetPosts *RepositoryMock) GetPosts(ctx context.Context) (ppa1 []*domain.Post, err error) {
-	mm_atomic.AddUint64(&mmGetPosts.beforeGetPostsCounter, 1)
-	defer mm_atomic.AddUint64(&mmGetPosts.afterGetPostsCounter, 1)
+// WithTx implements repository.Repository
+func (mmWithTx *RepositoryMock) WithTx(ctx context.Context, fn func(repo mm_repository.Repository) error) (err error) {
+	mm_atomic.AddUint64(&mmWithTx.beforeWithTxCounter, 1)
+	defer mm_atomic.AddUint64(&mmWithTx.afterWithTxCounter, 1)
 
-	if mmGetPosts.inspectFuncGetPosts != nil {
-		mmGetPosts.inspectFuncGetPosts(ctx)
+	if mmWithTx.inspectFuncWithTx != nil {
+		mmWithTx.inspectFuncWithTx(ctx, fn)
 	}
 
-	mm_params := RepositoryMockGetPostsParams{ctx}
+	mm_params := RepositoryMockWithTxParams{ctx, fn}
 
 	// Record call args
-	mmGetPosts.GetPostsMock.mutex.Lock()
-	mmGetPosts.GetPostsMock.callArgs = append(mmGetPosts.GetPostsMock.callArgs, &mm_params)
-	mmGetPosts.GetPostsMock.mutex.Unlock()
+	mmWithTx.WithTxMock.mutex.Lock()
+	mmWithTx.WithTxMock.callArgs = append(mmWithTx.WithTxMock.callArgs, &mm_params)
+	mmWithTx.WithTxMock.mutex.Unlock()
 
-	for _, e := range mmGetPosts.GetPostsMock.expectations {
+	for _, e := range mmWithTx.WithTxMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.ppa1, e.results.err
+			return e.results.err
 		}
 	}
 
-	if mmGetPosts.GetPostsMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmGetPosts.GetPostsMock.defaultExpectation.Counter, 1)
-		mm_want := mmGetPosts.GetPostsMock.defaultExpectation.params
-		mm_want_ptrs := mmGetPosts.GetPostsMock.defaultExpectation.paramPtrs
+	if mmWithTx.WithTxMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmWithTx.WithTxMock.defaultExpectation.Counter, 1)
+		mm_want := mmWithTx.WithTxMock.defaultExpectation.params
+		mm_want_ptrs := mmWithTx.WithTxMock.defaultExpectation.paramPtrs
 
-		mm_got := RepositoryMockGetPostsParams{ctx}
+		mm_got := RepositoryMockWithTxParams{ctx, fn}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmGetPosts.t.Errorf("RepositoryMock.GetPosts got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmWithTx.t.Errorf("RepositoryMock.WithTx got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.fn != nil && !minimock.Equal(*mm_want_ptrs.fn, mm_got.fn) {
+				mmWithTx.t.Errorf("RepositoryMock.WithTx got unexpected parameter fn, want: %#v, got: %#v%s\n", *mm_want_ptrs.fn, mm_got.fn, minimock.Diff(*mm_want_ptrs.fn, mm_got.fn))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmGetPosts.t.Errorf("RepositoryMock.GetPosts got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmWithTx.t.Errorf("RepositoryMock.WithTx got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmGetPosts.GetPostsMock.defaultExpectation.results
+		mm_results := mmWithTx.WithTxMock.defaultExpectation.results
 		if mm_results == nil {
-			mmGetPosts.t.Fatal("No results are set for the RepositoryMock.GetPosts")
+			mmWithTx.t.Fatal("No results are set for the RepositoryMock.WithTx")
 		}
-		return (*mm_results).ppa1, (*mm_results).err
+		return (*mm_results).err
 	}
-	if mmGetPosts.funcGetPosts != nil {
-		return mmGetPosts.funcGetPosts(ctx)
+	if mmWithTx.funcWithTx != nil {
+		return mmWithTx.funcWithTx(ctx, fn)
 	}
-	mmGetPosts.t.Fatalf("Unexpected call to RepositoryMock.GetPosts. %v", ctx)
+	mmWithTx.t.Fatalf("Unexpected call to RepositoryMock.WithTx. %v %v", ctx, fn)
 	return
 }
 
-// GetPostsAfterCounter returns a count of finished RepositoryMock.GetPosts invocations
-func (mmGetPosts *RepositoryMock) GetPostsAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetPosts.afterGetPostsCounter)
+// WithTxAfterCounter returns a count of finished RepositoryMock.WithTx invocations
+func (mmWithTx *RepositoryMock) WithTxAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmWithTx.afterWithTxCounter)
 }
 
-// GetPostsBeforeCounter returns a count of RepositoryMock.GetPosts invocations
-func (mmGetPosts *RepositoryMock) GetPostsBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetPosts.beforeGetPostsCounter)
+// WithTxBeforeCounter returns a count of RepositoryMock.WithTx invocations
+func (mmWithTx *RepositoryMock) WithTxBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmWithTx.beforeWithTxCounter)
 }
 
-// Calls returns a list of arguments used in each call to RepositoryMock.GetPosts.
+// Calls returns a list of arguments used in each call to RepositoryMock.WithTx.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmGetPosts *mRepositoryMockGetPosts) Calls() []*RepositoryMockGetPostsParams {
-	mmGetPosts.mutex.RLock()
+func (mmWithTx *mRepositoryMockWithTx) Calls() []*RepositoryMockWithTxParams {
+	mmWithTx.mutex.RLock()
 
-	argCopy := make([]*RepositoryMockGetPostsParams, len(mmGetPosts.callArgs))
-	copy(argCopy, mmGetPosts.callArgs)
+	argCopy := make([]*RepositoryMockWithTxParams, len(mmWithTx.callArgs))
+	copy(argCopy, mmWithTx.callArgs)
 
-	mmGetPosts.mutex.RUnlock()
+	mmWithTx.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockGetPostsDone returns true if the count of the GetPosts invocations corresponds
+// MinimockWithTxDone returns true if the count of the WithTx invocations corresponds
 // the number of defined expectations
-func (m *RepositoryMock) MinimockGetPostsDone() bool {
-	for _, e := range m.GetPostsMock.expectations {
+func (m *RepositoryMock) MinimockWithTxDone() bool {
+	for _, e := range m.WithTxMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.GetPostsMock.invocationsDone()
+	return m.WithTxMock.invocationsDone()
 }
 
-// MinimockGetPostsInspect logs each unmet expectation
-func (m *RepositoryMock) MinimockGetPostsInspect() {
-	for _, e := range m.GetPostsMock.expectations {
+// MinimockWithTxInspect logs each unmet expectation
+func (m *RepositoryMock) MinimockWithTxInspect() {
+	for _, e := range m.WithTxMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to RepositoryMock.GetPosts with params: %#v", *e.params)
+			m.t.Errorf("Expected call to RepositoryMock.WithTx with params: %#v", *e.params)
 		}
 	}
 
-	afterGetPostsCounter := mm_atomic.LoadUint64(&m.afterGetPostsCounter)
+	afterWithTxCounter := mm_atomic.LoadUint64(&m.afterWithTxCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.GetPostsMock.defaultExpectation != nil && afterGetPostsCounter < 1 {
-		if m.GetPostsMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to RepositoryMock.GetPosts")
+	if m.WithTxMock.defaultExpectation != nil && afterWithTxCounter < 1 {
+		if m.WithTxMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to RepositoryMock.WithTx")
 		} else {
-			m.t.Errorf("Expected call to RepositoryMock.GetPosts with params: %#v", *m.GetPostsMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to RepositoryMock.WithTx with params: %#v", *m.WithTxMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcGetPosts != nil && afterGetPostsCounter < 1 {
-		m.t.Error("Expected call to RepositoryMock.GetPosts")
+	if m.funcWithTx != nil && afterWithTxCounter < 1 {
+		m.t.Error("Expected call to RepositoryMock.WithTx")
 	}
 
-	if !m.GetPostsMock.invocationsDone() && afterGetPostsCounter > 0 {
-		m.t.Errorf("Expected %d calls to RepositoryMock.GetPosts but found %d calls",
-			mm_atomic.LoadUint64(&m.GetPostsMock.expectedInvocations), afterGetPostsCounter)
+	if !m.WithTxMock.invocationsDone() && afterWithTxCounter > 0 {
+		m.t.Errorf("Expected %d calls to RepositoryMock.WithTx but found %d calls",
+			mm_atomic.LoadUint64(&m.WithTxMock.expectedInvocations), afterWithTxCounter)
 	}
 }
 
@@ -2946,23 +11834,77 @@ func (m *RepositoryMock) MinimockGetPostsInspect() {
 func (m *RepositoryMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
 		if !m.minimockDone() {
-			m.MinimockContainsCommentInspect()
+			m.MinimockBanAuthorInspect()
+
+			m.MinimockClaimEventsInspect()
 
-			m.MinimockContainsPostInspect()
+			m.MinimockClaimWebhookDeliveriesInspect()
 
 			m.MinimockCreateCommentInspect()
 
+			m.MinimockCreateEventInspect()
+
 			m.MinimockCreatePostInspect()
 
+			m.MinimockCreateReportInspect()
+
+			m.MinimockCreateWebhookInspect()
+
+			m.MinimockCreateWebhookDeliveriesInspect()
+
+			m.MinimockDeleteCommentInspect()
+
+			m.MinimockDeletePostInspect()
+
+			m.MinimockDeletePublishedEventsInspect()
+
+			m.MinimockDeleteWebhookInspect()
+
 			m.MinimockDisableCommentsInspect()
 
+			m.MinimockEnableCommentsInspect()
+
+			m.MinimockGetCommentInspect()
+
 			m.MinimockGetCommentsByParentInspect()
 
 			m.MinimockGetCommentsByPostInspect()
 
+			m.MinimockGetModerationRecordsInspect()
+
 			m.MinimockGetPostInspect()
 
 			m.MinimockGetPostsInspect()
+
+			m.MinimockGetPostsAfterInspect()
+
+			m.MinimockGetReportInspect()
+
+			m.MinimockGetReportsInspect()
+
+			m.MinimockGetWebhookInspect()
+
+			m.MinimockGetWebhookDeliveriesInspect()
+
+			m.MinimockGetWebhooksInspect()
+
+			m.MinimockGetWebhooksForEventInspect()
+
+			m.MinimockHideCommentInspect()
+
+			m.MinimockHidePostInspect()
+
+			m.MinimockImportPostsInspect()
+
+			m.MinimockIsBannedInspect()
+
+			m.MinimockMarkEventsPublishedInspect()
+
+			m.MinimockResolveReportInspect()
+
+			m.MinimockUpdateWebhookDeliveryInspect()
+
+			m.MinimockWithTxInspect()
 			m.t.FailNow()
 		}
 	})
@@ -2987,13 +11929,40 @@ func (m *RepositoryMock) MinimockWait(timeout mm_time.Duration) {
 func (m *RepositoryMock) minimockDone() bool {
 	done := true
 	return done &&
-		m.MinimockContainsCommentDone() &&
-		m.MinimockContainsPostDone() &&
+		m.MinimockBanAuthorDone() &&
+		m.MinimockClaimEventsDone() &&
+		m.MinimockClaimWebhookDeliveriesDone() &&
 		m.MinimockCreateCommentDone() &&
+		m.MinimockCreateEventDone() &&
 		m.MinimockCreatePostDone() &&
+		m.MinimockCreateReportDone() &&
+		m.MinimockCreateWebhookDone() &&
+		m.MinimockCreateWebhookDeliveriesDone() &&
+		m.MinimockDeleteCommentDone() &&
+		m.MinimockDeletePostDone() &&
+		m.MinimockDeletePublishedEventsDone() &&
+		m.MinimockDeleteWebhookDone() &&
 		m.MinimockDisableCommentsDone() &&
+		m.MinimockEnableCommentsDone() &&
+		m.MinimockGetCommentDone() &&
 		m.MinimockGetCommentsByParentDone() &&
 		m.MinimockGetCommentsByPostDone() &&
+		m.MinimockGetModerationRecordsDone() &&
 		m.MinimockGetPostDone() &&
-		m.MinimockGetPostsDone()
+		m.MinimockGetPostsDone() &&
+		m.MinimockGetPostsAfterDone() &&
+		m.MinimockGetReportDone() &&
+		m.MinimockGetReportsDone() &&
+		m.MinimockGetWebhookDone() &&
+		m.MinimockGetWebhookDeliveriesDone() &&
+		m.MinimockGetWebhooksDone() &&
+		m.MinimockGetWebhooksForEventDone() &&
+		m.MinimockHideCommentDone() &&
+		m.MinimockHidePostDone() &&
+		m.MinimockImportPostsDone() &&
+		m.MinimockIsBannedDone() &&
+		m.MinimockMarkEventsPublishedDone() &&
+		m.MinimockResolveReportDone() &&
+		m.MinimockUpdateWebhookDeliveryDone() &&
+		m.MinimockWithTxDone()
 }
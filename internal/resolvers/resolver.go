@@ -2,11 +2,18 @@ package resolvers
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"sync"
 	"time"
 
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/filter"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/logging"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/metrics"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/ratelimit"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
 )
 
@@ -15,46 +22,140 @@ var (
 	ErrCommentNotFound  = fmt.Errorf("comment not found")
 	ErrCommentsDisabled = fmt.Errorf("comments are disabled")
 	ErrNotAuthor        = fmt.Errorf("only author can disable comments")
+	ErrAuthorBanned     = fmt.Errorf("author is banned")
 )
 
 // Resolver is a GraphQL resolver that implements business logic
 type Resolver struct {
-	repo         repository.Repository
-	postChannels map[int][]chan *domain.Comment // postID
+	repo         store
+	postChannels map[int][]*postSubscriber // postID
 	mu           sync.Mutex
+	closed       bool             // no new subscriptions once closed
+	closing      chan struct{}    // closed by Close to end all subscriptions
+	subscribers  sync.WaitGroup   // goroutines started by Subscribe
+	moderators   map[int]struct{} // ids of users allowed to moderate content
+	filters      *filter.Chain    // content filters for new posts and comments, nil disables filtering
+	limiter      *ratelimit.Limiter
+	metrics      *metrics.Metrics // nil disables metrics
+	logger       *slog.Logger
+	notify       func()                                      // called after events are added to the outbox, nil disables it
+	checkURL     func(ctx context.Context, url string) error // checks where webhook urls point, nil accepts any
 }
 
-func NewResolver(repo repository.Repository) *Resolver {
-	return &Resolver{repo: repo, postChannels: make(map[int][]chan *domain.Comment)}
+// Option configures optional Resolver dependencies
+type Option func(*Resolver)
+
+// WithModerators grants moderator rights to the given user ids
+func WithModerators(ids ...int) Option {
+	return func(r *Resolver) {
+		for _, id := range ids {
+			r.moderators[id] = struct{}{}
+		}
+	}
+}
+
+// WithFilters runs new posts and comments through the filter chain before saving them
+func WithFilters(chain *filter.Chain) Option {
+	return func(r *Resolver) {
+		r.filters = chain
+	}
+}
+
+// WithRateLimiter limits how often users can call mutations
+func WithRateLimiter(limiter *ratelimit.Limiter) Option {
+	return func(r *Resolver) {
+		r.limiter = limiter
+	}
+}
+
+// WithMetrics records the comment fan-out to subscribers
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(r *Resolver) {
+		r.metrics = m
+	}
+}
+
+// WithLogger sets the logger of the resolver, slog.Default() is used otherwise
+func WithLogger(logger *slog.Logger) Option {
+	return func(r *Resolver) {
+		r.logger = logger
+	}
+}
+
+// WithEventNotify calls notify after a mutation added events to the outbox,
+// so the outbox relay can publish them without waiting for its next poll
+func WithEventNotify(notify func()) Option {
+	return func(r *Resolver) {
+		r.notify = notify
+	}
+}
+
+// WithWebhookURLCheck rejects webhooks whose url fails the check, e.g. webhook.CheckURL
+func WithWebhookURLCheck(check func(ctx context.Context, url string) error) Option {
+	return func(r *Resolver) {
+		r.checkURL = check
+	}
+}
+
+func NewResolver(repo repository.Repository, opts ...Option) *Resolver {
+	r := &Resolver{
+		repo:         store{repo},
+		postChannels: make(map[int][]*postSubscriber),
+		closing:      make(chan struct{}),
+		moderators:   make(map[int]struct{}),
+		logger:       slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
 }
 
-func (r *Resolver) GetPosts(ctx context.Context) (any, error) {
+func (r *Resolver) GetPosts(ctx context.Context, args PostsArgs) (any, error) {
+	logging.SetUser(ctx, args.ViewerID)
+
 	posts, err := r.repo.GetPosts(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get posts: %w", err)
 	}
 
-	return posts, nil
+	if r.isModerator(args.ViewerID) {
+		return posts, nil
+	}
+
+	visible := make([]*domain.Post, 0, len(posts))
+	for _, post := range posts {
+		if !post.Hidden {
+			visible = append(visible, post)
+		}
+	}
+
+	return visible, nil
 }
 
 func (r *Resolver) GetPost(ctx context.Context, args PostArgs) (any, error) {
+	logging.SetUser(ctx, args.ViewerID)
+
 	if err := validateID(args.ID); err != nil {
 		return nil, err
 	}
 
-	if err := r.postExists(ctx, args.ID); err != nil {
+	post, err := r.repo.getPost(ctx, args.ID)
+	if err != nil {
 		return nil, err
 	}
 
-	post, err := r.repo.GetPost(ctx, args.ID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get post: %w", err)
+	if post.Hidden && !r.isModerator(args.ViewerID) {
+		return nil, fmt.Errorf("%w: %d", ErrPostNotFound, args.ID)
 	}
 
 	return post, nil
 }
 
 func (r *Resolver) GetCommentsByPost(ctx context.Context, args GetCommentsArgs) (any, error) {
+	logging.SetUser(ctx, args.ViewerID)
+
 	if err := validateID(args.PostID); err != nil {
 		return nil, err
 	}
@@ -63,11 +164,10 @@ func (r *Resolver) GetCommentsByPost(ctx context.Context, args GetCommentsArgs)
 		return nil, err
 	}
 
-	if err := r.postExists(ctx, args.PostID); err != nil {
-		return nil, err
+	comments, err := r.repo.GetCommentsByPost(ctx, args.PostID, args.Limit, args.Offset, r.isModerator(args.ViewerID))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %d", ErrPostNotFound, args.PostID)
 	}
-
-	comments, err := r.repo.GetCommentsByPost(ctx, args.PostID, args.Limit, args.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments: %w", err)
 	}
@@ -76,6 +176,8 @@ func (r *Resolver) GetCommentsByPost(ctx context.Context, args GetCommentsArgs)
 }
 
 func (r *Resolver) GetCommentsByParent(ctx context.Context, args GetCommentsArgs) (any, error) {
+	logging.SetUser(ctx, args.ViewerID)
+
 	if err := validateID(args.PostID, *args.ParentID); err != nil {
 		return nil, err
 	}
@@ -84,11 +186,10 @@ func (r *Resolver) GetCommentsByParent(ctx context.Context, args GetCommentsArgs
 		return nil, err
 	}
 
-	if err := r.commentExists(ctx, *args.ParentID); err != nil {
-		return nil, err
+	comments, err := r.repo.GetCommentsByParent(ctx, *args.ParentID, args.Limit, args.Offset, r.isModerator(args.ViewerID))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %d", ErrCommentNotFound, *args.ParentID)
 	}
-
-	comments, err := r.repo.GetCommentsByParent(ctx, *args.ParentID, args.Limit, args.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get comments: %w", err)
 	}
@@ -97,26 +198,63 @@ func (r *Resolver) GetCommentsByParent(ctx context.Context, args GetCommentsArgs
 }
 
 func (r *Resolver) CreatePost(ctx context.Context, args CreatePostArgs) (any, error) {
+	logging.SetUser(ctx, args.AuthorID)
+
 	if err := validateID(args.AuthorID); err != nil {
 		return nil, err
 	}
 
-	post := &domain.Post{
-		Title:     args.Title,
-		Content:   args.Content,
-		AuthorID:  args.AuthorID,
-		CreatedAt: time.Now().UTC(),
+	if err := validatePost(args.Title, args.Content); err != nil {
+		return nil, err
 	}
 
-	savedPost, err := r.repo.CreatePost(ctx, post)
+	if err := r.limiter.Allow(ctx, ratelimit.OpCreatePost, args.AuthorID); err != nil {
+		return nil, err
+	}
+
+	content := &filter.Content{Kind: domain.TargetPost, AuthorID: args.AuthorID, Title: args.Title, Body: args.Content}
+	flags, err := r.filters.Run(ctx, content)
+	if err != nil {
+		return nil, err
+	}
+
+	var savedPost *domain.Post
+	err = r.repo.withTx(ctx, func(tx store) error {
+		if err := tx.notBanned(ctx, args.AuthorID); err != nil {
+			return err
+		}
+
+		post := &domain.Post{
+			Title:     content.Title,
+			Content:   content.Body,
+			AuthorID:  args.AuthorID,
+			CreatedAt: time.Now().UTC(),
+		}
+
+		savedPost, err = tx.CreatePost(ctx, post)
+		if err != nil {
+			return fmt.Errorf("failed to create post: %w", err)
+		}
+
+		if err := tx.flagForReview(ctx, domain.TargetPost, savedPost.ID, flags); err != nil {
+			return err
+		}
+
+		return tx.addEvent(ctx, domain.EventPostCreated, savedPost.ID, savedPost.ID, savedPost)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to create post: %w", err)
+		return nil, err
 	}
+	content.Saved()
+	r.logFlags(ctx, domain.TargetPost, savedPost.ID, flags)
+	r.notifyEvents()
 
 	return savedPost, nil
 }
 
 func (r *Resolver) CreateComment(ctx context.Context, args CreateCommentArgs) (any, error) {
+	logging.SetUser(ctx, args.AuthorID)
+
 	if err := validateID(args.PostID, args.AuthorID); err != nil {
 		return nil, err
 	}
@@ -125,131 +263,265 @@ func (r *Resolver) CreateComment(ctx context.Context, args CreateCommentArgs) (a
 		return nil, err
 	}
 
-	comment := &domain.Comment{
-		PostID:    args.PostID,
-		ParentID:  args.ParentID,
-		AuthorID:  args.AuthorID,
-		Content:   args.Content,
-		CreatedAt: time.Now().UTC(),
+	if err := r.limiter.Allow(ctx, ratelimit.OpCreateComment, args.AuthorID); err != nil {
+		return nil, err
 	}
 
-	if err := r.postExists(ctx, args.PostID); err != nil {
-		return nil, err
+	content := &filter.Content{Kind: domain.TargetComment, AuthorID: args.AuthorID, PostID: args.PostID, Body: args.Content}
+	if args.ParentID != nil {
+		content.ParentID = *args.ParentID
 	}
-	if err := r.commentsDisabled(ctx, args.PostID); err != nil {
+	flags, err := r.filters.Run(ctx, content)
+	if err != nil {
 		return nil, err
 	}
 
-	savedComment, err := r.repo.CreateComment(ctx, comment)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create comment: %w", err)
-	}
+	// the checks and the insert run in one unit of work,
+	// so comments can't be disabled or the author banned in between
+	var savedComment *domain.Comment
+	err = r.repo.withTx(ctx, func(tx store) error {
+		post, err := tx.getPost(ctx, args.PostID)
+		if err != nil {
+			return err
+		}
+		if err := commentsDisabled(post); err != nil {
+			return err
+		}
+		if err := tx.notBanned(ctx, args.AuthorID); err != nil {
+			return err
+		}
 
-	// Send the new comment to all subscribers
-	r.mu.Lock()
-	if postChans, ok := r.postChannels[args.PostID]; ok {
-		for _, postChan := range postChans {
-			postChan <- savedComment
+		comment := &domain.Comment{
+			PostID:    args.PostID,
+			ParentID:  args.ParentID,
+			AuthorID:  args.AuthorID,
+			Content:   content.Body,
+			CreatedAt: time.Now().UTC(),
+		}
+
+		savedComment, err = tx.CreateComment(ctx, comment)
+		// the post was found in this unit of work, so the missing reference is the parent
+		if errors.Is(err, repository.ErrNotFound) && args.ParentID != nil {
+			return fmt.Errorf("%w: %d", ErrCommentNotFound, *args.ParentID)
 		}
+		if err != nil {
+			return fmt.Errorf("failed to create comment: %w", err)
+		}
+
+		if err := tx.flagForReview(ctx, domain.TargetComment, savedComment.ID, flags); err != nil {
+			return err
+		}
+
+		// subscribers get the comment from the outbox once the unit of work is committed
+		return tx.addEvent(ctx, domain.EventCommentCreated, savedComment.PostID, savedComment.ID, savedComment)
+	})
+	if err != nil {
+		return nil, err
 	}
-	r.mu.Unlock()
+	content.Saved()
+	r.logFlags(ctx, domain.TargetComment, savedComment.ID, flags)
+
+	r.notifyEvents()
 
 	return savedComment, nil
 }
 
 func (r *Resolver) DisableComments(ctx context.Context, args DisableCommentsArgs) (any, error) {
+	logging.SetUser(ctx, args.AuthorId)
+
 	if err := validateID(args.PostID, args.AuthorId); err != nil {
 		return nil, err
 	}
 
-	if err := r.postExists(ctx, args.PostID); err != nil {
+	err := r.repo.withTx(ctx, func(tx store) error {
+		post, err := tx.getPost(ctx, args.PostID)
+		if err != nil {
+			return err
+		}
+
+		// check that the author is the same as the author of the post
+		if err := authorizeAuthor(post, args.AuthorId); err != nil {
+			return err
+		}
+
+		if err := tx.DisableComments(ctx, args.PostID); err != nil {
+			return fmt.Errorf("failed to disable comments: %w", err)
+		}
+
+		updated := *post
+		updated.CommentsDisabled = true
+		return tx.addEvent(ctx, domain.EventPostUpdated, updated.ID, updated.ID, &updated)
+	})
+	if err != nil {
 		return nil, err
 	}
+	r.notifyEvents()
 
-	// check that the author is the same as the author of the post
-	if err := r.authorizeAuthor(ctx, args.PostID, args.AuthorId); err != nil {
-		return nil, err
+	return true, nil
+}
+
+// Publish sends the comment of a COMMENT_CREATED event to the subscribers of its post,
+// it makes the resolver the in-process event bus sink of the outbox relay
+func (r *Resolver) Publish(ctx context.Context, event *domain.Event) error {
+	if event.Type != domain.EventCommentCreated {
+		return nil
 	}
 
-	err := r.repo.DisableComments(ctx, args.PostID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to disable comments: %w", err)
+	var comment domain.Comment
+	if err := json.Unmarshal(event.Payload, &comment); err != nil {
+		return fmt.Errorf("failed to decode comment of event %s: %w", event.DedupID, err)
 	}
 
-	return true, nil
+	r.mu.Lock()
+	subs := append([]*postSubscriber(nil), r.postChannels[event.PostID]...)
+	r.mu.Unlock()
+
+	// the relay must not wait for slow subscribers: a subscriber whose buffer is full is dropped,
+	// its client is disconnected and can subscribe again
+	r.metrics.FanoutQueued(len(subs))
+	for _, sub := range subs {
+		select {
+		case sub.comments <- &comment:
+		case <-sub.done: // the subscription ended meanwhile
+		default:
+			r.logger.WarnContext(ctx, "dropped a subscriber that fell behind", "post_id", event.PostID)
+			r.metrics.SubscriberDropped()
+			sub.drop()
+		}
+		r.metrics.FanoutDelivered()
+	}
+
+	return nil
 }
 
-// Subscribe sends new comments to the channel linked to the posts
+func (r *Resolver) notifyEvents() {
+	if r.notify != nil {
+		r.notify()
+	}
+}
+
+// subscriberBuffer is how many new comments a subscriber can fall behind before it is dropped,
+// more than the outbox relay publishes at once, so a burst doesn't drop subscribers that keep up
+const subscriberBuffer = 256
+
+// postSubscriber receives the new comments of a post until its subscription is done
+type postSubscriber struct {
+	comments chan *domain.Comment // buffered, so publishing doesn't wait for the subscriber
+	done     <-chan struct{}
+	drop     context.CancelFunc // ends the whole subscription
+}
+
+// Subscribe sends new comments of the posts to the channel until the context is canceled
+// or the resolver is closed, then it closes the channel
 func (r *Resolver) Subscribe(ctx context.Context, c chan any, posts []int) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		close(c)
+		return
+	}
+	r.subscribers.Add(1)
+	r.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-r.closing:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var forwarders sync.WaitGroup
 	for _, postID := range posts {
-		if r.postExists(ctx, postID) == nil {
-			postChan := make(chan *domain.Comment)
-			r.mu.Lock()
-			r.postChannels[postID] = append(r.postChannels[postID], postChan)
-			r.mu.Unlock()
-
-			// Start a goroutine that listens for new comments on the channel
-			go func() {
-				for {
+		if _, err := r.repo.getPost(ctx, postID); err != nil {
+			continue
+		}
+
+		sub := &postSubscriber{comments: make(chan *domain.Comment, subscriberBuffer), done: ctx.Done(), drop: cancel}
+		r.mu.Lock()
+		r.postChannels[postID] = append(r.postChannels[postID], sub)
+		r.mu.Unlock()
+
+		// Start a goroutine that forwards new comments of the post
+		forwarders.Add(1)
+		go func(postID int) {
+			defer forwarders.Done()
+			defer r.removeSubscriber(postID, sub)
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case comment := <-sub.comments:
 					select {
-					case <-ctx.Done(): // context canceled, clean up
-						r.mu.Lock()
-						delete(r.postChannels, postID)
-						r.mu.Unlock()
-						close(postChan)
+					case c <- comment:
+					case <-ctx.Done():
 						return
-					case comment := <-postChan:
-						c <- comment
 					}
 				}
-			}()
-		}
+			}
+		}(postID)
 	}
-}
 
-func (r *Resolver) postExists(ctx context.Context, postID int) error {
-	ok, err := r.repo.ContainsPost(ctx, postID)
-	if err != nil {
-		return fmt.Errorf("failed to check post existence: %w", err)
-	}
-	if !ok {
-		return fmt.Errorf("%w: %d", ErrPostNotFound, postID)
-	}
-	return nil
+	go func() {
+		defer r.subscribers.Done()
+		<-ctx.Done()
+		forwarders.Wait()
+		cancel()
+		close(c)
+	}()
 }
 
-func (r *Resolver) commentExists(ctx context.Context, commentID int) error {
-	ok, err := r.repo.ContainsComment(ctx, commentID)
-	if err != nil {
-		return fmt.Errorf("failed to check comment existence: %w", err)
+func (r *Resolver) removeSubscriber(postID int, sub *postSubscriber) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subs := r.postChannels[postID]
+	for i, s := range subs {
+		if s == sub {
+			subs = append(subs[:i], subs[i+1:]...)
+			break
+		}
 	}
-	if !ok {
-		return fmt.Errorf("%w: %d", ErrCommentNotFound, commentID)
+	if len(subs) == 0 {
+		delete(r.postChannels, postID)
+	} else {
+		r.postChannels[postID] = subs
 	}
-	return nil
 }
 
-func (r *Resolver) commentsDisabled(ctx context.Context, postID int) error {
-	post, err := r.repo.GetPost(ctx, postID)
-	if err != nil {
-		return fmt.Errorf("failed to get post: %w", err)
+// Close ends all subscriptions and waits for their goroutines until the context is done
+func (r *Resolver) Close(ctx context.Context) error {
+	r.mu.Lock()
+	if !r.closed {
+		r.closed = true
+		close(r.closing)
 	}
+	r.mu.Unlock()
 
-	if post.CommentsDisabled {
-		return fmt.Errorf("%w: %d", ErrCommentsDisabled, postID)
-	}
+	done := make(chan struct{})
+	go func() {
+		r.subscribers.Wait()
+		close(done)
+	}()
 
-	return nil
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("subscriptions are still running: %w", ctx.Err())
+	}
 }
 
-func (r *Resolver) authorizeAuthor(ctx context.Context, postID, authorID int) error {
-	post, err := r.repo.GetPost(ctx, postID)
-	if err != nil {
-		return fmt.Errorf("failed to get post: %w", err)
-	}
-	if post.AuthorID != authorID {
-		return ErrNotAuthor
+// logFlags logs the filters that flagged the saved content for review
+func (r *Resolver) logFlags(ctx context.Context, targetType domain.TargetType, targetID int, flags []filter.Flagged) {
+	for _, flag := range flags {
+		r.logger.InfoContext(ctx, "content flagged for review",
+			"target_type", targetType, "target_id", targetID, "filter", flag.Filter, "reason", flag.Reason)
 	}
+}
 
-	return nil
+// Logger returns the logger of the resolver
+func (r *Resolver) Logger() *slog.Logger {
+	return r.logger
 }
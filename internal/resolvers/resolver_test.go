@@ -2,11 +2,20 @@ package resolvers
 
 import (
 	"context"
+	"fmt"
+	"log/slog"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/filter"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/outbox"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/ratelimit"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/in_memory"
 	"github.com/gojuno/minimock/v3"
 	"github.com/stretchr/testify/assert"
 )
@@ -27,7 +36,7 @@ func TestResolver_GetPosts(t *testing.T) {
 
 	resolver := NewResolver(mockRepo)
 
-	res, err := resolver.GetPosts(context.Background())
+	res, err := resolver.GetPosts(context.Background(), PostsArgs{})
 	assert.NoError(t, err)
 
 	posts, ok := res.([]*domain.Post)
@@ -49,7 +58,6 @@ func TestResolver_GetPost(t *testing.T) {
 		CommentsDisabled: false,
 	}
 
-	mockRepo.ContainsPostMock.Expect(minimock.AnyContext, 1).Return(true, nil)
 	mockRepo.GetPostMock.Expect(minimock.AnyContext, 1).Return(post, nil)
 
 	resolver := NewResolver(mockRepo)
@@ -66,7 +74,7 @@ func TestResolver_GetPost(t *testing.T) {
 func TestResolver_GetPost_NoSuchPost(t *testing.T) {
 	mockRepo := NewRepositoryMock(minimock.NewController(t))
 
-	mockRepo.ContainsPostMock.Expect(minimock.AnyContext, 1).Return(false, nil)
+	mockRepo.GetPostMock.Expect(minimock.AnyContext, 1).Return(nil, repository.ErrNotFound)
 
 	resolver := NewResolver(mockRepo)
 
@@ -87,8 +95,7 @@ func TestResolver_GetCommentsByPost(t *testing.T) {
 		CreatedAt: time.Now(),
 	}
 
-	mockRepo.ContainsPostMock.Expect(minimock.AnyContext, 1).Return(true, nil)
-	mockRepo.GetCommentsByPostMock.Expect(minimock.AnyContext, 1, 10, 0).Return([]*domain.Comment{comment}, nil)
+	mockRepo.GetCommentsByPostMock.Expect(minimock.AnyContext, 1, 10, 0, false).Return([]*domain.Comment{comment}, nil)
 
 	resolver := NewResolver(mockRepo)
 
@@ -105,7 +112,7 @@ func TestResolver_GetCommentsByPost(t *testing.T) {
 func TestResolver_GetCommentsByPost_NoSuchPost(t *testing.T) {
 	mockRepo := NewRepositoryMock(minimock.NewController(t))
 
-	mockRepo.ContainsPostMock.Expect(minimock.AnyContext, 1).Return(false, nil)
+	mockRepo.GetCommentsByPostMock.Expect(minimock.AnyContext, 1, 10, 0, false).Return(nil, repository.ErrNotFound)
 
 	resolver := NewResolver(mockRepo)
 
@@ -126,8 +133,7 @@ func TestResolver_GetCommentsByParent(t *testing.T) {
 		CreatedAt: time.Now(),
 	}
 
-	mockRepo.ContainsCommentMock.Expect(minimock.AnyContext, 1).Return(true, nil)
-	mockRepo.GetCommentsByParentMock.Expect(minimock.AnyContext, 1, 10, 0).Return([]*domain.Comment{comment}, nil)
+	mockRepo.GetCommentsByParentMock.Expect(minimock.AnyContext, 1, 10, 0, false).Return([]*domain.Comment{comment}, nil)
 
 	resolver := NewResolver(mockRepo)
 
@@ -145,7 +151,7 @@ func TestResolver_GetCommentsByParent(t *testing.T) {
 func TestResolver_GetCommentsByParent_NoSuchComment(t *testing.T) {
 	mockRepo := NewRepositoryMock(minimock.NewController(t))
 
-	mockRepo.ContainsCommentMock.Expect(minimock.AnyContext, 1).Return(false, nil)
+	mockRepo.GetCommentsByParentMock.Expect(minimock.AnyContext, 1, 10, 0, false).Return(nil, repository.ErrNotFound)
 
 	resolver := NewResolver(mockRepo)
 
@@ -157,6 +163,8 @@ func TestResolver_GetCommentsByParent_NoSuchComment(t *testing.T) {
 
 func TestResolver_CreatePost(t *testing.T) {
 	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
+	mockRepo.CreateEventMock.Return(&domain.Event{}, nil)
 
 	post := &domain.Post{
 		Title:    "Title",
@@ -171,6 +179,7 @@ func TestResolver_CreatePost(t *testing.T) {
 		AuthorID: post.AuthorID,
 	}
 
+	mockRepo.IsBannedMock.Expect(minimock.AnyContext, 1).Return(false, nil)
 	mockRepo.CreatePostMock.Set(func(ctx context.Context, p *domain.Post) (*domain.Post, error) {
 		p.ID = 1
 		p.CreatedAt = time.Now().UTC()
@@ -203,8 +212,9 @@ func TestResolver_CreatePost(t *testing.T) {
 
 func TestResolver_DisableComments(t *testing.T) {
 	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
+	mockRepo.CreateEventMock.Return(&domain.Event{}, nil)
 
-	mockRepo.ContainsPostMock.Expect(minimock.AnyContext, 1).Return(true, nil)
 	mockRepo.GetPostMock.Expect(minimock.AnyContext, 1).Return(&domain.Post{ID: 1, AuthorID: 1}, nil)
 	mockRepo.DisableCommentsMock.Expect(minimock.AnyContext, 1).Return(nil)
 
@@ -219,8 +229,9 @@ func TestResolver_DisableComments(t *testing.T) {
 
 func TestResolver_DisableComments_NoSuchPost(t *testing.T) {
 	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
 
-	mockRepo.ContainsPostMock.Expect(minimock.AnyContext, 1).Return(false, nil)
+	mockRepo.GetPostMock.Expect(minimock.AnyContext, 1).Return(nil, repository.ErrNotFound)
 
 	resolver := NewResolver(mockRepo)
 
@@ -231,8 +242,8 @@ func TestResolver_DisableComments_NoSuchPost(t *testing.T) {
 
 func TestResolver_DisableComments_WrongAuthor(t *testing.T) {
 	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
 
-	mockRepo.ContainsPostMock.Expect(minimock.AnyContext, 1).Return(true, nil)
 	mockRepo.GetPostMock.Expect(minimock.AnyContext, 1).Return(&domain.Post{ID: 1, AuthorID: 1}, nil)
 
 	resolver := NewResolver(mockRepo)
@@ -244,6 +255,7 @@ func TestResolver_DisableComments_WrongAuthor(t *testing.T) {
 
 func TestResolver_CreateComment(t *testing.T) {
 	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
 
 	comment := &domain.Comment{
 		PostID:    1,
@@ -261,14 +273,20 @@ func TestResolver_CreateComment(t *testing.T) {
 		Content:   comment.Content,
 		CreatedAt: comment.CreatedAt,
 	}
-	mockRepo.ContainsPostMock.Expect(minimock.AnyContext, 1).Return(true, nil)
 	mockRepo.GetPostMock.Expect(minimock.AnyContext, 1).Return(&domain.Post{ID: 1, CommentsDisabled: false}, nil)
+	mockRepo.IsBannedMock.Expect(minimock.AnyContext, 1).Return(false, nil)
 
 	mockRepo.CreateCommentMock.Set(func(ctx context.Context, c *domain.Comment) (*domain.Comment, error) {
 		c.ID = 1
 		c.CreatedAt = time.Now().UTC()
 		return c, nil
 	})
+	mockRepo.CreateEventMock.Set(func(ctx context.Context, e *domain.Event) (*domain.Event, error) {
+		assert.Equal(t, domain.EventCommentCreated, e.Type)
+		assert.True(t, strings.HasPrefix(e.DedupID, "COMMENT_CREATED:1:"))
+		assert.Equal(t, 1, e.PostID)
+		return e, nil
+	})
 
 	resolver := NewResolver(mockRepo)
 
@@ -296,10 +314,51 @@ func TestResolver_CreateComment(t *testing.T) {
 	assert.True(t, after.After(resultComment.CreatedAt) || after.Equal(resultComment.CreatedAt))
 }
 
+func TestResolver_CreateComment_RateLimitedByIP(t *testing.T) {
+	limiter := ratelimit.New(ratelimit.NewMemoryStore(), map[ratelimit.Operation]ratelimit.Limit{
+		ratelimit.OpCreateComment: {Burst: 10, Period: time.Minute},
+	}, map[ratelimit.Operation]ratelimit.Limit{
+		ratelimit.OpCreateComment: {Burst: 3, Period: time.Minute},
+	})
+	resolver := NewResolver(in_memory.New(), WithRateLimiter(limiter))
+	ctx := ratelimit.WithClientIP(context.Background(), "10.0.0.1")
+
+	_, err := resolver.CreatePost(ctx, CreatePostArgs{Title: "Title", Content: "Content", AuthorID: 1})
+	assert.NoError(t, err)
+
+	// a new author id on every call doesn't get around the budget of the client IP
+	for authorID := 2; authorID < 5; authorID++ {
+		_, err := resolver.CreateComment(ctx, CreateCommentArgs{PostID: 1, AuthorID: authorID, Content: "Comment"})
+		assert.NoError(t, err)
+	}
+	_, err = resolver.CreateComment(ctx, CreateCommentArgs{PostID: 1, AuthorID: 5, Content: "Comment"})
+
+	var limited *ratelimit.LimitedError
+	assert.ErrorAs(t, err, &limited)
+	assert.Equal(t, "RATE_LIMITED", limited.Extensions()["code"])
+	assert.Equal(t, 20, limited.Extensions()["retryAfter"])
+}
+
+func TestResolver_CreateComment_RetryAfterFailure(t *testing.T) {
+	resolver := NewResolver(in_memory.New(), WithFilters(filter.NewChain(filter.NewDuplicate(time.Minute, filter.Reject))))
+	ctx := context.Background()
+
+	// the comment on a missing post is not saved, so sending it again is not a duplicate
+	_, err := resolver.CreateComment(ctx, CreateCommentArgs{PostID: 1, AuthorID: 2, Content: "Comment"})
+	assert.ErrorIs(t, err, ErrPostNotFound)
+	_, err = resolver.CreatePost(ctx, CreatePostArgs{Title: "Title", Content: "Content", AuthorID: 1})
+	assert.NoError(t, err)
+	_, err = resolver.CreateComment(ctx, CreateCommentArgs{PostID: 1, AuthorID: 2, Content: "Comment"})
+	assert.NoError(t, err)
+
+	_, err = resolver.CreateComment(ctx, CreateCommentArgs{PostID: 1, AuthorID: 2, Content: "Comment"})
+	assert.ErrorIs(t, err, filter.ErrRejected)
+}
+
 func TestResolver_CreateComment_CommentsForbidden(t *testing.T) {
 	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
 
-	mockRepo.ContainsPostMock.Expect(minimock.AnyContext, 1).Return(true, nil)
 	mockRepo.GetPostMock.Expect(minimock.AnyContext, 1).Return(&domain.Post{ID: 1, CommentsDisabled: true}, nil)
 
 	resolver := NewResolver(mockRepo)
@@ -316,8 +375,9 @@ func TestResolver_CreateComment_CommentsForbidden(t *testing.T) {
 
 func TestResolver_CreateComment_NoSuchPost(t *testing.T) {
 	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
 
-	mockRepo.ContainsPostMock.Expect(minimock.AnyContext, 1).Return(false, nil)
+	mockRepo.GetPostMock.Expect(minimock.AnyContext, 1).Return(nil, repository.ErrNotFound)
 
 	resolver := NewResolver(mockRepo)
 
@@ -369,3 +429,150 @@ func TestResolver_BadID(t *testing.T) {
 	assert.ErrorIs(t, err, ErrNotPositiveID)
 	assert.Nil(t, res)
 }
+
+func TestResolver_Subscribe(t *testing.T) {
+	repo := in_memory.New()
+	relay := outbox.NewRelay(repo, slog.Default(), outbox.WithInterval(time.Hour))
+	resolver := NewResolver(repo, WithEventNotify(relay.Notify))
+	relay.AddSink("subscriptions", resolver)
+	relay.Start()
+	defer relay.Stop(context.Background())
+	ctx := context.Background()
+
+	_, err := resolver.CreatePost(ctx, CreatePostArgs{Title: "Title", Content: "Content", AuthorID: 1})
+	assert.NoError(t, err)
+
+	firstCtx, cancelFirst := context.WithCancel(ctx)
+	first := make(chan any)
+	resolver.Subscribe(firstCtx, first, []int{1})
+	second := make(chan any)
+	resolver.Subscribe(ctx, second, []int{1})
+
+	// the first subscriber leaves, the second one still gets new comments
+	cancelFirst()
+	_, open := <-first
+	assert.False(t, open)
+
+	go func() {
+		_, err := resolver.CreateComment(ctx, CreateCommentArgs{PostID: 1, AuthorID: 2, Content: "Comment"})
+		assert.NoError(t, err)
+	}()
+	select {
+	case comment := <-second:
+		assert.Equal(t, "Comment", comment.(*domain.Comment).Content)
+	case <-time.After(time.Second):
+		t.Fatal("comment was not delivered")
+	}
+
+	closeCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	assert.NoError(t, resolver.Close(closeCtx))
+	_, open = <-second
+	assert.False(t, open)
+
+	// no new subscriptions after close
+	third := make(chan any)
+	resolver.Subscribe(ctx, third, []int{1})
+	_, open = <-third
+	assert.False(t, open)
+}
+
+func TestResolver_Publish_SlowSubscriber(t *testing.T) {
+	resolver := NewResolver(in_memory.New())
+	ctx := context.Background()
+	_, err := resolver.CreatePost(ctx, CreatePostArgs{Title: "Title", Content: "Content", AuthorID: 1})
+	assert.NoError(t, err)
+
+	stuck := make(chan any) // never read
+	resolver.Subscribe(ctx, stuck, []int{1})
+	reader := make(chan any)
+	resolver.Subscribe(ctx, reader, []int{1})
+
+	var received atomic.Int64
+	go func() {
+		for range reader {
+			received.Add(1)
+		}
+	}()
+
+	publish := func(n int) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := range n {
+				event := &domain.Event{DedupID: fmt.Sprint(i), Type: domain.EventCommentCreated, PostID: 1, Payload: []byte(`{"id":1,"post_id":1}`)}
+				assert.NoError(t, resolver.Publish(ctx, event))
+			}
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("publishing is blocked by the slow subscriber")
+		}
+	}
+
+	// a burst fits in the buffers
+	publish(subscriberBuffer)
+	assert.Eventually(t, func() bool { return received.Load() == subscriberBuffer }, time.Second, time.Millisecond)
+
+	// the subscriber that never reads is dropped once its buffer is full, the other one gets every comment
+	publish(10)
+	select {
+	case _, open := <-stuck:
+		for open {
+			_, open = <-stuck
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the slow subscriber was not dropped")
+	}
+	assert.Eventually(t, func() bool { return received.Load() == subscriberBuffer+10 }, time.Second, time.Millisecond)
+
+	closeCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	assert.NoError(t, resolver.Close(closeCtx))
+}
+
+func TestResolver_CreateComment_RacesDisableComments(t *testing.T) {
+	resolver := NewResolver(in_memory.New())
+	ctx := context.Background()
+
+	_, err := resolver.CreatePost(ctx, CreatePostArgs{Title: "Title", Content: "Content", AuthorID: 1})
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	created := make(chan *domain.Comment, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := resolver.CreateComment(ctx, CreateCommentArgs{PostID: 1, AuthorID: 2, Content: "Comment"})
+			if err != nil {
+				assert.ErrorIs(t, err, ErrCommentsDisabled)
+				return
+			}
+			created <- res.(*domain.Comment)
+		}()
+	}
+	var disabledAt time.Time
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, err := resolver.DisableComments(ctx, DisableCommentsArgs{PostID: 1, AuthorId: 1})
+		assert.NoError(t, err)
+		disabledAt = time.Now().UTC()
+	}()
+	wg.Wait()
+	close(created)
+
+	// every comment that got in was created before comments were disabled
+	for comment := range created {
+		assert.True(t, comment.CreatedAt.Before(disabledAt))
+	}
+}
+
+// inTx makes the mock run units of work on itself
+func inTx(mockRepo *RepositoryMock) {
+	mockRepo.WithTxMock.Set(func(ctx context.Context, fn func(repo repository.Repository) error) error {
+		return fn(mockRepo)
+	})
+}
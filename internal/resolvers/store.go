@@ -0,0 +1,230 @@
+package resolvers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/filter"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+)
+
+// store adds the checks shared by resolvers to a repository,
+// it wraps either the resolver repository or the one given to a unit of work
+type store struct {
+	repository.Repository
+}
+
+// withTx runs fn in a unit of work of the repository
+func (s store) withTx(ctx context.Context, fn func(tx store) error) error {
+	return s.WithTx(ctx, func(repo repository.Repository) error {
+		return fn(store{repo})
+	})
+}
+
+// getPost returns the post or ErrPostNotFound
+func (s store) getPost(ctx context.Context, postID int) (*domain.Post, error) {
+	post, err := s.GetPost(ctx, postID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %d", ErrPostNotFound, postID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get post: %w", err)
+	}
+	return post, nil
+}
+
+// getComment returns the comment or ErrCommentNotFound
+func (s store) getComment(ctx context.Context, commentID int) (*domain.Comment, error) {
+	comment, err := s.GetComment(ctx, commentID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %d", ErrCommentNotFound, commentID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+	return comment, nil
+}
+
+// getReport returns the report or ErrReportNotFound
+func (s store) getReport(ctx context.Context, reportID int) (*domain.Report, error) {
+	report, err := s.GetReport(ctx, reportID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %d", ErrReportNotFound, reportID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	return report, nil
+}
+
+func commentsDisabled(post *domain.Post) error {
+	if post.CommentsDisabled {
+		return fmt.Errorf("%w: %d", ErrCommentsDisabled, post.ID)
+	}
+
+	return nil
+}
+
+func authorizeAuthor(post *domain.Post, authorID int) error {
+	if post.AuthorID != authorID {
+		return ErrNotAuthor
+	}
+
+	return nil
+}
+
+func (s store) notBanned(ctx context.Context, authorID int) error {
+	banned, err := s.IsBanned(ctx, authorID)
+	if err != nil {
+		return fmt.Errorf("failed to check if author is banned: %w", err)
+	}
+	if banned {
+		return fmt.Errorf("%w: %d", ErrAuthorBanned, authorID)
+	}
+
+	return nil
+}
+
+// flagForReview files a system report for every filter that flagged the saved content
+func (s store) flagForReview(ctx context.Context, targetType domain.TargetType, targetID int, flags []filter.Flagged) error {
+	for _, flag := range flags {
+		report := &domain.Report{
+			TargetType: targetType,
+			TargetID:   targetID,
+			ReporterID: domain.SystemReporterID,
+			Reason:     fmt.Sprintf("%s: %s", flag.Filter, flag.Reason),
+			Status:     domain.ReportOpen,
+			CreatedAt:  time.Now().UTC(),
+		}
+		if _, err := s.CreateReport(ctx, report); err != nil {
+			return fmt.Errorf("failed to flag content for review: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// addEvent records the change of the entity in the outbox
+func (s store) addEvent(ctx context.Context, eventType domain.EventType, postID, entityID int, entity any) error {
+	event, err := domain.NewEvent(eventType, postID, entityID, entity)
+	if err != nil {
+		return err
+	}
+	if _, err := s.CreateEvent(ctx, event); err != nil {
+		return fmt.Errorf("failed to add event to the outbox: %w", err)
+	}
+
+	return nil
+}
+
+func (s store) applyAction(ctx context.Context, action domain.ModerationAction, targetType domain.TargetType, targetID, authorID int) error {
+	var err error
+	switch action {
+	case domain.ActionHide:
+		err = s.hide(ctx, targetType, targetID)
+	case domain.ActionDelete:
+		err = s.delete(ctx, targetType, targetID)
+	case domain.ActionBanAuthor:
+		if err = s.BanAuthor(ctx, authorID); err == nil {
+			err = s.hide(ctx, targetType, targetID)
+		}
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownAction, action)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply moderation action %s: %w", action, err)
+	}
+
+	return nil
+}
+
+// hide hides the post or the comment and records the update in the outbox
+func (s store) hide(ctx context.Context, targetType domain.TargetType, targetID int) error {
+	if targetType == domain.TargetPost {
+		if err := s.HidePost(ctx, targetID); err != nil {
+			return err
+		}
+		return s.postUpdated(ctx, targetID)
+	}
+
+	if err := s.HideComment(ctx, targetID); err != nil {
+		return err
+	}
+	comment, err := s.GetComment(ctx, targetID)
+	if err != nil {
+		return err
+	}
+	return s.addEvent(ctx, domain.EventCommentUpdated, comment.PostID, comment.ID, comment)
+}
+
+// delete deletes the post or the comment thread and records the deletion in the outbox,
+// the comments deleted with it get no events of their own
+func (s store) delete(ctx context.Context, targetType domain.TargetType, targetID int) error {
+	if targetType == domain.TargetPost {
+		post, err := s.GetPost(ctx, targetID)
+		if err != nil {
+			return err
+		}
+		if err := s.DeletePost(ctx, targetID); err != nil {
+			return err
+		}
+		return s.addEvent(ctx, domain.EventPostDeleted, post.ID, post.ID, post)
+	}
+
+	comment, err := s.GetComment(ctx, targetID)
+	if err != nil {
+		return err
+	}
+	if err := s.DeleteComment(ctx, targetID); err != nil {
+		return err
+	}
+	return s.addEvent(ctx, domain.EventCommentDeleted, comment.PostID, comment.ID, comment)
+}
+
+// postUpdated records the current state of the post in the outbox
+func (s store) postUpdated(ctx context.Context, postID int) error {
+	post, err := s.GetPost(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	return s.addEvent(ctx, domain.EventPostUpdated, post.ID, post.ID, post)
+}
+
+// targetAuthor returns the author of the post or the comment, ErrPostNotFound or ErrCommentNotFound if it doesn't exist
+func (s store) targetAuthor(ctx context.Context, targetType domain.TargetType, targetID int) (int, error) {
+	switch targetType {
+	case domain.TargetPost:
+		post, err := s.getPost(ctx, targetID)
+		if err != nil {
+			return 0, err
+		}
+		return post.AuthorID, nil
+	case domain.TargetComment:
+		comment, err := s.getComment(ctx, targetID)
+		if err != nil {
+			return 0, err
+		}
+		return comment.AuthorID, nil
+	default:
+		return 0, fmt.Errorf("%w: %s", ErrUnknownTarget, targetType)
+	}
+}
+
+// ownWebhook checks that the webhook exists and belongs to the owner,
+// a webhook of another owner is reported as missing
+func (s store) ownWebhook(ctx context.Context, webhookID, ownerID int) error {
+	webhook, err := s.GetWebhook(ctx, webhookID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("%w: %d", ErrWebhookNotFound, webhookID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get webhook: %w", err)
+	}
+	if webhook.OwnerID != ownerID {
+		return fmt.Errorf("%w: %d", ErrWebhookNotFound, webhookID)
+	}
+	return nil
+}
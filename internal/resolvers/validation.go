@@ -1,13 +1,33 @@
 package resolvers
 
-import "fmt"
+import (
+	"fmt"
+	"net/url"
+	"strings"
 
-const maxLength = 2000
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+)
+
+const (
+	maxLength       = 2000
+	maxTitleLength  = 200
+	maxPostLength   = 20000
+	maxReasonLength = 500
+
+	minSecretLength = 16
+	maxURLLength    = 2000
+)
 
 var (
 	ErrInvalidComment        = fmt.Errorf("comment is too long")
+	ErrInvalidTitle          = fmt.Errorf("post title must be non-empty and shorter than %d bytes", maxTitleLength)
+	ErrInvalidPost           = fmt.Errorf("post content must be non-empty and shorter than %d bytes", maxPostLength)
 	ErrNotPositiveID         = fmt.Errorf("ID must be positive")
 	ErrInvalidPaginationArgs = fmt.Errorf("invalid pagination args")
+	ErrInvalidReason         = fmt.Errorf("report reason must be non-empty and shorter than %d bytes", maxReasonLength)
+	ErrInvalidWebhookURL     = fmt.Errorf("webhook url must be an absolute http or https url")
+	ErrInvalidWebhookSecret  = fmt.Errorf("webhook secret must be at least %d bytes", minSecretLength)
+	ErrInvalidWebhookEvents  = fmt.Errorf("webhook must subscribe to at least one known event type")
 )
 
 func validateComment(comment string) error {
@@ -18,6 +38,17 @@ func validateComment(comment string) error {
 	return nil
 }
 
+func validatePost(title, content string) error {
+	if strings.TrimSpace(title) == "" || len(title) > maxTitleLength {
+		return ErrInvalidTitle
+	}
+	if strings.TrimSpace(content) == "" || len(content) > maxPostLength {
+		return ErrInvalidPost
+	}
+
+	return nil
+}
+
 func validateID(IDs ...int) error {
 	for _, id := range IDs {
 		if id < 1 {
@@ -33,3 +64,33 @@ func validatePaginationArgs(limit, offset int) error {
 	}
 	return nil
 }
+
+func validateReason(reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" || len(reason) > maxReasonLength {
+		return ErrInvalidReason
+	}
+	return nil
+}
+
+func validateWebhook(rawURL, secret string, events []domain.EventType) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || len(rawURL) > maxURLLength || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ErrInvalidWebhookURL
+	}
+	if len(secret) < minSecretLength {
+		return ErrInvalidWebhookSecret
+	}
+	if len(events) == 0 {
+		return ErrInvalidWebhookEvents
+	}
+	for _, e := range events {
+		switch e {
+		case domain.EventPostCreated, domain.EventPostUpdated, domain.EventPostDeleted,
+			domain.EventCommentCreated, domain.EventCommentUpdated, domain.EventCommentDeleted:
+		default:
+			return ErrInvalidWebhookEvents
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package resolvers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/logging"
+)
+
+var (
+	ErrWebhookNotFound   = fmt.Errorf("webhook not found")
+	ErrWebhookNotAllowed = fmt.Errorf("webhook url must point to a public address")
+)
+
+// RegisterWebhook registers an HTTP callback for the events on the watched posts, or on every post
+func (r *Resolver) RegisterWebhook(ctx context.Context, args RegisterWebhookArgs) (any, error) {
+	logging.SetUser(ctx, args.OwnerID)
+
+	if err := validateID(append([]int{args.OwnerID}, args.PostIDs...)...); err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhook(args.URL, args.Secret, args.Events); err != nil {
+		return nil, err
+	}
+
+	if r.checkURL != nil {
+		if err := r.checkURL(ctx, args.URL); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrWebhookNotAllowed, err)
+		}
+	}
+
+	var savedWebhook *domain.Webhook
+	err := r.repo.withTx(ctx, func(tx store) error {
+		for _, postID := range args.PostIDs {
+			if _, err := tx.getPost(ctx, postID); err != nil {
+				return err
+			}
+		}
+
+		webhook := &domain.Webhook{
+			OwnerID:   args.OwnerID,
+			URL:       args.URL,
+			Events:    args.Events,
+			PostIDs:   args.PostIDs,
+			Secret:    args.Secret,
+			CreatedAt: time.Now().UTC(),
+		}
+
+		var err error
+		savedWebhook, err = tx.CreateWebhook(ctx, webhook)
+		if err != nil {
+			return fmt.Errorf("failed to create webhook: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	r.logger.InfoContext(ctx, "webhook registered", "webhook_id", savedWebhook.ID, "events", savedWebhook.Events)
+
+	return savedWebhook, nil
+}
+
+// DeleteWebhook deletes the webhook of the owner with its deliveries
+func (r *Resolver) DeleteWebhook(ctx context.Context, args DeleteWebhookArgs) (any, error) {
+	logging.SetUser(ctx, args.OwnerID)
+
+	if err := validateID(args.ID, args.OwnerID); err != nil {
+		return nil, err
+	}
+
+	err := r.repo.withTx(ctx, func(tx store) error {
+		if err := tx.ownWebhook(ctx, args.ID, args.OwnerID); err != nil {
+			return err
+		}
+
+		if err := tx.DeleteWebhook(ctx, args.ID); err != nil {
+			return fmt.Errorf("failed to delete webhook: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return true, nil
+}
+
+// Webhooks returns the webhooks of the owner
+func (r *Resolver) Webhooks(ctx context.Context, args WebhooksArgs) (any, error) {
+	logging.SetUser(ctx, args.OwnerID)
+
+	if err := validateID(args.OwnerID); err != nil {
+		return nil, err
+	}
+
+	webhooks, err := r.repo.GetWebhooks(ctx, args.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+// WebhookDeliveries returns the deliveries of the webhook, newest first, to its owner or a moderator
+func (r *Resolver) WebhookDeliveries(ctx context.Context, args WebhookDeliveriesArgs) (any, error) {
+	logging.SetUser(ctx, args.OwnerID)
+
+	if err := validateID(args.WebhookID, args.OwnerID); err != nil {
+		return nil, err
+	}
+
+	if err := validatePaginationArgs(args.Limit, args.Offset); err != nil {
+		return nil, err
+	}
+
+	if !r.isModerator(args.OwnerID) {
+		if err := r.repo.ownWebhook(ctx, args.WebhookID, args.OwnerID); err != nil {
+			return nil, err
+		}
+	}
+
+	deliveries, err := r.repo.GetWebhookDeliveries(ctx, args.WebhookID, args.Limit, args.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
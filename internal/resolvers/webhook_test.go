@@ -0,0 +1,126 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/webhook"
+	"github.com/gojuno/minimock/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolver_RegisterWebhook(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
+
+	mockRepo.GetPostMock.Expect(minimock.AnyContext, 1).Return(&domain.Post{ID: 1}, nil)
+	mockRepo.CreateWebhookMock.Set(func(ctx context.Context, w *domain.Webhook) (*domain.Webhook, error) {
+		w.ID = 1
+		return w, nil
+	})
+
+	resolver := NewResolver(mockRepo)
+
+	res, err := resolver.RegisterWebhook(context.Background(), RegisterWebhookArgs{
+		OwnerID: 2,
+		URL:     "https://example.com/hook",
+		Events:  []domain.EventType{domain.EventCommentCreated},
+		PostIDs: []int{1},
+		Secret:  "0123456789abcdef",
+	})
+	assert.NoError(t, err)
+
+	webhook, ok := res.(*domain.Webhook)
+	assert.True(t, ok)
+	assert.Equal(t, 1, webhook.ID)
+	assert.Equal(t, 2, webhook.OwnerID)
+	assert.Equal(t, []int{1}, webhook.PostIDs)
+}
+
+func TestResolver_RegisterWebhook_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args RegisterWebhookArgs
+		err  error
+	}{
+		{
+			name: "relative url",
+			args: RegisterWebhookArgs{OwnerID: 1, URL: "/hook", Events: []domain.EventType{domain.EventPostCreated}, Secret: "0123456789abcdef"},
+			err:  ErrInvalidWebhookURL,
+		},
+		{
+			name: "not http",
+			args: RegisterWebhookArgs{OwnerID: 1, URL: "ftp://example.com", Events: []domain.EventType{domain.EventPostCreated}, Secret: "0123456789abcdef"},
+			err:  ErrInvalidWebhookURL,
+		},
+		{
+			name: "short secret",
+			args: RegisterWebhookArgs{OwnerID: 1, URL: "https://example.com", Events: []domain.EventType{domain.EventPostCreated}, Secret: "secret"},
+			err:  ErrInvalidWebhookSecret,
+		},
+		{
+			name: "no events",
+			args: RegisterWebhookArgs{OwnerID: 1, URL: "https://example.com", Secret: "0123456789abcdef"},
+			err:  ErrInvalidWebhookEvents,
+		},
+		{
+			name: "unknown event",
+			args: RegisterWebhookArgs{OwnerID: 1, URL: "https://example.com", Events: []domain.EventType{"POST_LIKED"}, Secret: "0123456789abcdef"},
+			err:  ErrInvalidWebhookEvents,
+		},
+		{
+			name: "invalid post id",
+			args: RegisterWebhookArgs{OwnerID: 1, URL: "https://example.com", Events: []domain.EventType{domain.EventPostCreated}, PostIDs: []int{0}, Secret: "0123456789abcdef"},
+			err:  ErrNotPositiveID,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewResolver(NewRepositoryMock(minimock.NewController(t)))
+
+			_, err := resolver.RegisterWebhook(context.Background(), tt.args)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestResolver_RegisterWebhook_NotPublic(t *testing.T) {
+	resolver := NewResolver(NewRepositoryMock(minimock.NewController(t)), WithWebhookURLCheck(webhook.CheckURL))
+
+	for _, url := range []string{"http://169.254.169.254/latest/meta-data", "http://localhost:8080/hook", "http://10.0.0.1/hook"} {
+		_, err := resolver.RegisterWebhook(context.Background(), RegisterWebhookArgs{
+			OwnerID: 1,
+			URL:     url,
+			Events:  []domain.EventType{domain.EventPostCreated},
+			Secret:  "0123456789abcdef",
+		})
+		assert.ErrorIs(t, err, ErrWebhookNotAllowed, url)
+		assert.ErrorIs(t, err, webhook.ErrNotPublic, url)
+	}
+}
+
+func TestResolver_DeleteWebhook_NotOwner(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+	inTx(mockRepo)
+
+	mockRepo.GetWebhookMock.Expect(minimock.AnyContext, 1).Return(&domain.Webhook{ID: 1, OwnerID: 3}, nil)
+
+	resolver := NewResolver(mockRepo)
+
+	_, err := resolver.DeleteWebhook(context.Background(), DeleteWebhookArgs{ID: 1, OwnerID: 2})
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+}
+
+func TestResolver_WebhookDeliveries_Moderator(t *testing.T) {
+	mockRepo := NewRepositoryMock(minimock.NewController(t))
+
+	mockRepo.GetWebhookDeliveriesMock.Expect(minimock.AnyContext, 1, 10, 0).Return([]*domain.WebhookDelivery{{ID: 1, WebhookID: 1}}, nil)
+
+	resolver := NewResolver(mockRepo, WithModerators(9))
+
+	res, err := resolver.WebhookDeliveries(context.Background(), WebhookDeliveriesArgs{WebhookID: 1, OwnerID: 9, Limit: 10})
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+}
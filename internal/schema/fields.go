@@ -1,8 +1,10 @@
 package schema
 
 import (
-	"log"
+	"context"
+	"log/slog"
 
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/resolvers"
 	"github.com/graphql-go/graphql"
 )
@@ -11,9 +13,13 @@ func postsField(postType *graphql.Object, resolver *resolvers.Resolver) *graphql
 	return &graphql.Field{
 		Type:        graphql.NewList(postType),
 		Description: "Get all posts",
+		Args: graphql.FieldConfigArgument{
+			"viewerId": &graphql.ArgumentConfig{Type: graphql.Int},
+		},
 		Resolve: func(p graphql.ResolveParams) (any, error) {
-			res, err := resolver.GetPosts(p.Context)
-			logIfNotNil(err)
+			viewerId, _ := p.Args["viewerId"].(int)
+			res, err := resolver.GetPosts(p.Context, resolvers.PostsArgs{ViewerID: viewerId})
+			logIfNotNil(p.Context, resolver.Logger(), err)
 			return res, err
 		},
 	}
@@ -27,11 +33,15 @@ func postField(postType *graphql.Object, resolver *resolvers.Resolver) *graphql.
 			"id": &graphql.ArgumentConfig{
 				Type: graphql.Int,
 			},
+			"viewerId": &graphql.ArgumentConfig{
+				Type: graphql.Int,
+			},
 		},
 		Resolve: func(p graphql.ResolveParams) (any, error) {
 			id, _ := p.Args["id"].(int)
-			res, err := resolver.GetPost(p.Context, resolvers.PostArgs{ID: id})
-			logIfNotNil(err)
+			viewerId, _ := p.Args["viewerId"].(int)
+			res, err := resolver.GetPost(p.Context, resolvers.PostArgs{ID: id, ViewerID: viewerId})
+			logIfNotNil(p.Context, resolver.Logger(), err)
 			return res, err
 		},
 	}
@@ -42,20 +52,23 @@ func commentsByPostField(commentType *graphql.Object, resolver *resolvers.Resolv
 		Type:        graphql.NewList(commentType),
 		Description: "Get comments by post id",
 		Args: graphql.FieldConfigArgument{
-			"postId": &graphql.ArgumentConfig{Type: graphql.Int},
-			"limit":  &graphql.ArgumentConfig{Type: graphql.Int},
-			"offset": &graphql.ArgumentConfig{Type: graphql.Int},
+			"postId":   &graphql.ArgumentConfig{Type: graphql.Int},
+			"limit":    &graphql.ArgumentConfig{Type: graphql.Int},
+			"offset":   &graphql.ArgumentConfig{Type: graphql.Int},
+			"viewerId": &graphql.ArgumentConfig{Type: graphql.Int},
 		},
 		Resolve: func(p graphql.ResolveParams) (any, error) {
 			postId, _ := p.Args["postId"].(int)
 			limit, _ := p.Args["limit"].(int)
 			offset, _ := p.Args["offset"].(int)
+			viewerId, _ := p.Args["viewerId"].(int)
 			res, err := resolver.GetCommentsByPost(p.Context, resolvers.GetCommentsArgs{
-				PostID: postId,
-				Limit:  limit,
-				Offset: offset,
+				PostID:   postId,
+				Limit:    limit,
+				Offset:   offset,
+				ViewerID: viewerId,
 			})
-			logIfNotNil(err)
+			logIfNotNil(p.Context, resolver.Logger(), err)
 			return res, err
 		},
 	}
@@ -69,11 +82,13 @@ func commentsByParentField(commentType *graphql.Object, resolver *resolvers.Reso
 			"parentId": &graphql.ArgumentConfig{Type: graphql.Int},
 			"limit":    &graphql.ArgumentConfig{Type: graphql.Int},
 			"offset":   &graphql.ArgumentConfig{Type: graphql.Int},
+			"viewerId": &graphql.ArgumentConfig{Type: graphql.Int},
 		},
 		Resolve: func(p graphql.ResolveParams) (any, error) {
 			parentId, _ := p.Args["parentId"].(int)
 			limit, _ := p.Args["limit"].(int)
 			offset, _ := p.Args["offset"].(int)
+			viewerId, _ := p.Args["viewerId"].(int)
 			// making parentId nil if it's 0
 			var pointerToParent *int
 			if parentId != 0 {
@@ -83,8 +98,9 @@ func commentsByParentField(commentType *graphql.Object, resolver *resolvers.Reso
 				ParentID: pointerToParent,
 				Limit:    limit,
 				Offset:   offset,
+				ViewerID: viewerId,
 			})
-			logIfNotNil(err)
+			logIfNotNil(p.Context, resolver.Logger(), err)
 			return res, err
 		},
 	}
@@ -108,7 +124,7 @@ func createPostField(postType *graphql.Object, resolver *resolvers.Resolver) *gr
 				Content:  content,
 				AuthorID: authorId,
 			})
-			logIfNotNil(err)
+			logIfNotNil(p.Context, resolver.Logger(), err)
 			return res, err
 		},
 	}
@@ -140,7 +156,7 @@ func createCommentField(commentType *graphql.Object, resolver *resolvers.Resolve
 				AuthorID: authorId,
 				Content:  content,
 			})
-			logIfNotNil(err)
+			logIfNotNil(p.Context, resolver.Logger(), err)
 			return res, err
 		},
 	}
@@ -158,7 +174,7 @@ func disableCommentsField(resolver *resolvers.Resolver) *graphql.Field {
 			postId, _ := p.Args["postId"].(int)
 			authorId, _ := p.Args["authorId"].(int)
 			res, err := resolver.DisableComments(p.Context, resolvers.DisableCommentsArgs{PostID: postId, AuthorId: authorId})
-			logIfNotNil(err)
+			logIfNotNil(p.Context, resolver.Logger(), err)
 			return res, err
 		},
 	}
@@ -174,19 +190,220 @@ func subscribeField(commentType *graphql.Object, resolver *resolvers.Resolver) *
 			posts, _ := p.Context.Value("posts").([]int)
 
 			c := make(chan any)
-			go resolver.Subscribe(p.Context, c, posts)
-			go func() {
-				<-p.Context.Done()
-				close(c)
-			}()
+			resolver.Subscribe(p.Context, c, posts) // closes c when the subscription ends
 
 			return c, nil
 		},
 	}
 }
 
-func logIfNotNil(err error) {
+func reportField(reportType *graphql.Object, targetType *graphql.Enum, resolver *resolvers.Resolver) *graphql.Field {
+	return &graphql.Field{
+		Type:        reportType,
+		Description: "Report a post or a comment to moderators",
+		Args: graphql.FieldConfigArgument{
+			"targetType": &graphql.ArgumentConfig{Type: targetType},
+			"targetId":   &graphql.ArgumentConfig{Type: graphql.Int},
+			"reporterId": &graphql.ArgumentConfig{Type: graphql.Int},
+			"reason":     &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			target, _ := p.Args["targetType"].(domain.TargetType)
+			targetId, _ := p.Args["targetId"].(int)
+			reporterId, _ := p.Args["reporterId"].(int)
+			reason, _ := p.Args["reason"].(string)
+			res, err := resolver.Report(p.Context, resolvers.ReportArgs{
+				TargetType: target,
+				TargetID:   targetId,
+				ReporterID: reporterId,
+				Reason:     reason,
+			})
+			logIfNotNil(p.Context, resolver.Logger(), err)
+			return res, err
+		},
+	}
+}
+
+func moderationQueueField(reportPageType *graphql.Object, status *graphql.Enum, resolver *resolvers.Resolver) *graphql.Field {
+	return &graphql.Field{
+		Type:        reportPageType,
+		Description: "Get reports waiting for moderation, moderators only",
+		Args: graphql.FieldConfigArgument{
+			"moderatorId": &graphql.ArgumentConfig{Type: graphql.Int},
+			"status":      &graphql.ArgumentConfig{Type: status},
+			"first":       &graphql.ArgumentConfig{Type: graphql.Int},
+			"after":       &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			moderatorId, _ := p.Args["moderatorId"].(int)
+			reportStatus, _ := p.Args["status"].(domain.ReportStatus)
+			first, _ := p.Args["first"].(int)
+			after, _ := p.Args["after"].(string)
+			res, err := resolver.ModerationQueue(p.Context, resolvers.ModerationQueueArgs{
+				ModeratorID: moderatorId,
+				Status:      reportStatus,
+				First:       first,
+				After:       after,
+			})
+			logIfNotNil(p.Context, resolver.Logger(), err)
+			return res, err
+		},
+	}
+}
+
+func moderationLogField(recordType *graphql.Object, resolver *resolvers.Resolver) *graphql.Field {
+	return &graphql.Field{
+		Type:        graphql.NewList(recordType),
+		Description: "Get moderation audit trail, moderators only",
+		Args: graphql.FieldConfigArgument{
+			"moderatorId": &graphql.ArgumentConfig{Type: graphql.Int},
+			"limit":       &graphql.ArgumentConfig{Type: graphql.Int},
+			"offset":      &graphql.ArgumentConfig{Type: graphql.Int},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			moderatorId, _ := p.Args["moderatorId"].(int)
+			limit, _ := p.Args["limit"].(int)
+			offset, _ := p.Args["offset"].(int)
+			res, err := resolver.ModerationLog(p.Context, resolvers.ModerationLogArgs{
+				ModeratorID: moderatorId,
+				Limit:       limit,
+				Offset:      offset,
+			})
+			logIfNotNil(p.Context, resolver.Logger(), err)
+			return res, err
+		},
+	}
+}
+
+func resolveReportField(recordType *graphql.Object, action *graphql.Enum, resolver *resolvers.Resolver) *graphql.Field {
+	return &graphql.Field{
+		Type:        recordType,
+		Description: "Resolve a report, moderators only",
+		Args: graphql.FieldConfigArgument{
+			"reportId":    &graphql.ArgumentConfig{Type: graphql.Int},
+			"moderatorId": &graphql.ArgumentConfig{Type: graphql.Int},
+			"action":      &graphql.ArgumentConfig{Type: action},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			reportId, _ := p.Args["reportId"].(int)
+			moderatorId, _ := p.Args["moderatorId"].(int)
+			moderationAction, _ := p.Args["action"].(domain.ModerationAction)
+			res, err := resolver.ResolveReport(p.Context, resolvers.ResolveReportArgs{
+				ReportID:    reportId,
+				ModeratorID: moderatorId,
+				Action:      moderationAction,
+			})
+			logIfNotNil(p.Context, resolver.Logger(), err)
+			return res, err
+		},
+	}
+}
+
+func registerWebhookField(webhookType *graphql.Object, eventType *graphql.Enum, resolver *resolvers.Resolver) *graphql.Field {
+	return &graphql.Field{
+		Type:        webhookType,
+		Description: "Register an HTTP callback for events on the watched posts, or on every post if postIds is empty",
+		Args: graphql.FieldConfigArgument{
+			"ownerId": &graphql.ArgumentConfig{Type: graphql.Int},
+			"url":     &graphql.ArgumentConfig{Type: graphql.String},
+			"events":  &graphql.ArgumentConfig{Type: graphql.NewList(eventType)},
+			"postIds": &graphql.ArgumentConfig{Type: graphql.NewList(graphql.Int)},
+			"secret":  &graphql.ArgumentConfig{Type: graphql.String},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			ownerId, _ := p.Args["ownerId"].(int)
+			url, _ := p.Args["url"].(string)
+			secret, _ := p.Args["secret"].(string)
+			eventList, _ := p.Args["events"].([]any)
+			events := make([]domain.EventType, 0, len(eventList))
+			for _, e := range eventList {
+				if event, ok := e.(domain.EventType); ok {
+					events = append(events, event)
+				}
+			}
+			postIdList, _ := p.Args["postIds"].([]any)
+			postIds := make([]int, 0, len(postIdList))
+			for _, id := range postIdList {
+				if postId, ok := id.(int); ok {
+					postIds = append(postIds, postId)
+				}
+			}
+			res, err := resolver.RegisterWebhook(p.Context, resolvers.RegisterWebhookArgs{
+				OwnerID: ownerId,
+				URL:     url,
+				Events:  events,
+				PostIDs: postIds,
+				Secret:  secret,
+			})
+			logIfNotNil(p.Context, resolver.Logger(), err)
+			return res, err
+		},
+	}
+}
+
+func deleteWebhookField(resolver *resolvers.Resolver) *graphql.Field {
+	return &graphql.Field{
+		Type:        graphql.Boolean,
+		Description: "Delete a webhook with its deliveries",
+		Args: graphql.FieldConfigArgument{
+			"id":      &graphql.ArgumentConfig{Type: graphql.Int},
+			"ownerId": &graphql.ArgumentConfig{Type: graphql.Int},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			id, _ := p.Args["id"].(int)
+			ownerId, _ := p.Args["ownerId"].(int)
+			res, err := resolver.DeleteWebhook(p.Context, resolvers.DeleteWebhookArgs{ID: id, OwnerID: ownerId})
+			logIfNotNil(p.Context, resolver.Logger(), err)
+			return res, err
+		},
+	}
+}
+
+func webhooksField(webhookType *graphql.Object, resolver *resolvers.Resolver) *graphql.Field {
+	return &graphql.Field{
+		Type:        graphql.NewList(webhookType),
+		Description: "Get webhooks of the owner",
+		Args: graphql.FieldConfigArgument{
+			"ownerId": &graphql.ArgumentConfig{Type: graphql.Int},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			ownerId, _ := p.Args["ownerId"].(int)
+			res, err := resolver.Webhooks(p.Context, resolvers.WebhooksArgs{OwnerID: ownerId})
+			logIfNotNil(p.Context, resolver.Logger(), err)
+			return res, err
+		},
+	}
+}
+
+func webhookDeliveriesField(deliveryType *graphql.Object, resolver *resolvers.Resolver) *graphql.Field {
+	return &graphql.Field{
+		Type:        graphql.NewList(deliveryType),
+		Description: "Get deliveries of a webhook with their attempts and errors, newest first",
+		Args: graphql.FieldConfigArgument{
+			"webhookId": &graphql.ArgumentConfig{Type: graphql.Int},
+			"ownerId":   &graphql.ArgumentConfig{Type: graphql.Int},
+			"limit":     &graphql.ArgumentConfig{Type: graphql.Int},
+			"offset":    &graphql.ArgumentConfig{Type: graphql.Int},
+		},
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			webhookId, _ := p.Args["webhookId"].(int)
+			ownerId, _ := p.Args["ownerId"].(int)
+			limit, _ := p.Args["limit"].(int)
+			offset, _ := p.Args["offset"].(int)
+			res, err := resolver.WebhookDeliveries(p.Context, resolvers.WebhookDeliveriesArgs{
+				WebhookID: webhookId,
+				OwnerID:   ownerId,
+				Limit:     limit,
+				Offset:    offset,
+			})
+			logIfNotNil(p.Context, resolver.Logger(), err)
+			return res, err
+		},
+	}
+}
+
+func logIfNotNil(ctx context.Context, logger *slog.Logger, err error) {
 	if err != nil {
-		log.Println("Error response:", err)
+		logger.InfoContext(ctx, "error response", "error", err)
 	}
 }
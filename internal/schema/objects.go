@@ -1,6 +1,9 @@
 package schema
 
-import "github.com/graphql-go/graphql"
+import (
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/graphql-go/graphql"
+)
 
 // postObject is a GraphQL object for domain.Post
 func postObject() *graphql.Object {
@@ -25,6 +28,9 @@ func postObject() *graphql.Object {
 			"commentsDisabled": &graphql.Field{
 				Type: graphql.Boolean,
 			},
+			"hidden": &graphql.Field{
+				Type: graphql.Boolean,
+			},
 		},
 	})
 }
@@ -52,6 +58,236 @@ func commentObject() *graphql.Object {
 			"createdAt": &graphql.Field{
 				Type: graphql.DateTime,
 			},
+			"hidden": &graphql.Field{
+				Type: graphql.Boolean,
+			},
+		},
+	})
+}
+
+// targetTypeEnum is a GraphQL enum for domain.TargetType
+func targetTypeEnum() *graphql.Enum {
+	return graphql.NewEnum(graphql.EnumConfig{
+		Name: "TargetType",
+		Values: graphql.EnumValueConfigMap{
+			"POST":    &graphql.EnumValueConfig{Value: domain.TargetPost},
+			"COMMENT": &graphql.EnumValueConfig{Value: domain.TargetComment},
+		},
+	})
+}
+
+// reportStatusEnum is a GraphQL enum for domain.ReportStatus
+func reportStatusEnum() *graphql.Enum {
+	return graphql.NewEnum(graphql.EnumConfig{
+		Name: "ReportStatus",
+		Values: graphql.EnumValueConfigMap{
+			"OPEN":      &graphql.EnumValueConfig{Value: domain.ReportOpen},
+			"DISMISSED": &graphql.EnumValueConfig{Value: domain.ReportDismissed},
+			"ACTIONED":  &graphql.EnumValueConfig{Value: domain.ReportActioned},
+		},
+	})
+}
+
+// moderationActionEnum is a GraphQL enum for domain.ModerationAction
+func moderationActionEnum() *graphql.Enum {
+	return graphql.NewEnum(graphql.EnumConfig{
+		Name: "ModerationAction",
+		Values: graphql.EnumValueConfigMap{
+			"DISMISS":    &graphql.EnumValueConfig{Value: domain.ActionDismiss},
+			"HIDE":       &graphql.EnumValueConfig{Value: domain.ActionHide},
+			"DELETE":     &graphql.EnumValueConfig{Value: domain.ActionDelete},
+			"BAN_AUTHOR": &graphql.EnumValueConfig{Value: domain.ActionBanAuthor},
+		},
+	})
+}
+
+// reportObject is a GraphQL object for domain.Report
+func reportObject(targetType, status *graphql.Enum) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Report",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"targetType": &graphql.Field{
+				Type: targetType,
+			},
+			"targetId": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"reporterId": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"reason": &graphql.Field{
+				Type: graphql.String,
+			},
+			"status": &graphql.Field{
+				Type: status,
+			},
+			"createdAt": &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			"resolvedAt": &graphql.Field{
+				Type: graphql.DateTime,
+			},
+		},
+	})
+}
+
+// reportPageObject is a GraphQL object for resolvers.ReportPage
+func reportPageObject(reportType *graphql.Object) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "ReportPage",
+		Fields: graphql.Fields{
+			"reports": &graphql.Field{
+				Type: graphql.NewList(reportType),
+			},
+			"endCursor": &graphql.Field{
+				Type: graphql.String,
+			},
+			"hasNextPage": &graphql.Field{
+				Type: graphql.Boolean,
+			},
+		},
+	})
+}
+
+// moderationRecordObject is a GraphQL object for domain.ModerationRecord
+func moderationRecordObject(targetType, action *graphql.Enum) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "ModerationRecord",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"reportId": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"moderatorId": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"action": &graphql.Field{
+				Type: action,
+			},
+			"targetType": &graphql.Field{
+				Type: targetType,
+			},
+			"targetId": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"authorId": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"createdAt": &graphql.Field{
+				Type: graphql.DateTime,
+			},
+		},
+	})
+}
+
+// eventTypeEnum is a GraphQL enum for domain.EventType
+func eventTypeEnum() *graphql.Enum {
+	return graphql.NewEnum(graphql.EnumConfig{
+		Name: "EventType",
+		Values: graphql.EnumValueConfigMap{
+			"POST_CREATED":    &graphql.EnumValueConfig{Value: domain.EventPostCreated},
+			"POST_UPDATED":    &graphql.EnumValueConfig{Value: domain.EventPostUpdated},
+			"POST_DELETED":    &graphql.EnumValueConfig{Value: domain.EventPostDeleted},
+			"COMMENT_CREATED": &graphql.EnumValueConfig{Value: domain.EventCommentCreated},
+			"COMMENT_UPDATED": &graphql.EnumValueConfig{Value: domain.EventCommentUpdated},
+			"COMMENT_DELETED": &graphql.EnumValueConfig{Value: domain.EventCommentDeleted},
+		},
+	})
+}
+
+// deliveryStatusEnum is a GraphQL enum for domain.DeliveryStatus
+func deliveryStatusEnum() *graphql.Enum {
+	return graphql.NewEnum(graphql.EnumConfig{
+		Name: "DeliveryStatus",
+		Values: graphql.EnumValueConfigMap{
+			"PENDING":   &graphql.EnumValueConfig{Value: domain.DeliveryPending},
+			"DELIVERED": &graphql.EnumValueConfig{Value: domain.DeliveryDelivered},
+			"DEAD":      &graphql.EnumValueConfig{Value: domain.DeliveryDead},
+		},
+	})
+}
+
+// webhookObject is a GraphQL object for domain.Webhook, the secret is never returned
+func webhookObject(eventType *graphql.Enum) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Webhook",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"ownerId": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"url": &graphql.Field{
+				Type: graphql.String,
+			},
+			"events": &graphql.Field{
+				Type: graphql.NewList(eventType),
+			},
+			"postIds": &graphql.Field{
+				Type: graphql.NewList(graphql.Int),
+			},
+			"createdAt": &graphql.Field{
+				Type: graphql.DateTime,
+			},
+		},
+	})
+}
+
+// webhookDeliveryObject is a GraphQL object for domain.WebhookDelivery
+func webhookDeliveryObject(eventType, status *graphql.Enum) *graphql.Object {
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "WebhookDelivery",
+		Fields: graphql.Fields{
+			"id": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"webhookId": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"eventId": &graphql.Field{
+				Type: graphql.String,
+			},
+			"eventType": &graphql.Field{
+				Type: eventType,
+			},
+			"payload": &graphql.Field{
+				Type:        graphql.String,
+				Description: "Request body as sent",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					delivery, ok := p.Source.(*domain.WebhookDelivery)
+					if !ok {
+						return nil, nil
+					}
+					return string(delivery.Payload), nil
+				},
+			},
+			"status": &graphql.Field{
+				Type: status,
+			},
+			"attempts": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"responseStatus": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"lastError": &graphql.Field{
+				Type: graphql.String,
+			},
+			"nextAttemptAt": &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			"createdAt": &graphql.Field{
+				Type: graphql.DateTime,
+			},
+			"deliveredAt": &graphql.Field{
+				Type: graphql.DateTime,
+			},
 		},
 	})
 }
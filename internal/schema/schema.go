@@ -10,8 +10,28 @@ func NewSchema(resolver *resolvers.Resolver) (graphql.Schema, error) {
 	post := postObject()
 	comment := commentObject()
 
-	rootQuery := query(post, comment, resolver)
-	rootMutation := mutation(post, comment, resolver)
+	targetType := targetTypeEnum()
+	reportStatus := reportStatusEnum()
+	action := moderationActionEnum()
+	report := reportObject(targetType, reportStatus)
+	mod := moderation{
+		targetType: targetType,
+		status:     reportStatus,
+		action:     action,
+		report:     report,
+		reportPage: reportPageObject(report),
+		record:     moderationRecordObject(targetType, action),
+	}
+
+	eventType := eventTypeEnum()
+	hooks := webhooks{
+		eventType: eventType,
+		webhook:   webhookObject(eventType),
+		delivery:  webhookDeliveryObject(eventType, deliveryStatusEnum()),
+	}
+
+	rootQuery := query(post, comment, mod, hooks, resolver)
+	rootMutation := mutation(post, comment, mod, hooks, resolver)
 	rootSubscribtion := subscription(comment, resolver)
 
 	schemaConfig := graphql.SchemaConfig{
@@ -22,27 +42,52 @@ func NewSchema(resolver *resolvers.Resolver) (graphql.Schema, error) {
 	return graphql.NewSchema(schemaConfig)
 }
 
+// moderation holds GraphQL types used by the moderation fields
+type moderation struct {
+	targetType *graphql.Enum
+	status     *graphql.Enum
+	action     *graphql.Enum
+	report     *graphql.Object
+	reportPage *graphql.Object
+	record     *graphql.Object
+}
+
+// webhooks holds GraphQL types used by the webhook fields
+type webhooks struct {
+	eventType *graphql.Enum
+	webhook   *graphql.Object
+	delivery  *graphql.Object
+}
+
 // query creates a root query object
-func query(postType, commentType *graphql.Object, resolver *resolvers.Resolver) *graphql.Object {
+func query(postType, commentType *graphql.Object, mod moderation, hooks webhooks, resolver *resolvers.Resolver) *graphql.Object {
 	return graphql.NewObject(graphql.ObjectConfig{
 		Name: "RootQuery",
 		Fields: graphql.Fields{
-			"posts":            postsField(postType, resolver),
-			"post":             postField(postType, resolver),
-			"commentsByPost":   commentsByPostField(commentType, resolver),
-			"commentsByParent": commentsByParentField(commentType, resolver),
+			"posts":             postsField(postType, resolver),
+			"post":              postField(postType, resolver),
+			"commentsByPost":    commentsByPostField(commentType, resolver),
+			"commentsByParent":  commentsByParentField(commentType, resolver),
+			"moderationQueue":   moderationQueueField(mod.reportPage, mod.status, resolver),
+			"moderationLog":     moderationLogField(mod.record, resolver),
+			"webhooks":          webhooksField(hooks.webhook, resolver),
+			"webhookDeliveries": webhookDeliveriesField(hooks.delivery, resolver),
 		},
 	})
 }
 
 // mutation creates a root mutation object
-func mutation(postType, commentType *graphql.Object, resolver *resolvers.Resolver) *graphql.Object {
+func mutation(postType, commentType *graphql.Object, mod moderation, hooks webhooks, resolver *resolvers.Resolver) *graphql.Object {
 	return graphql.NewObject(graphql.ObjectConfig{
 		Name: "RootMutation",
 		Fields: graphql.Fields{
 			"createPost":      createPostField(postType, resolver),
 			"createComment":   createCommentField(commentType, resolver),
 			"disableComments": disableCommentsField(resolver),
+			"report":          reportField(mod.report, mod.targetType, resolver),
+			"resolveReport":   resolveReportField(mod.record, mod.action, resolver),
+			"registerWebhook": registerWebhookField(hooks.webhook, hooks.eventType, resolver),
+			"deleteWebhook":   deleteWebhookField(resolver),
 		},
 	})
 }
@@ -0,0 +1,380 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/complexity"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/logging"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/metrics"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/persisted"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/tracing"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/graphql-go/handler"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// maxRequestSize limits the size of a GraphQL request body
+const maxRequestSize = 1 << 20
+
+// wroteAtCookie carries the time of the client's last mutation in Unix milliseconds
+const wroteAtCookie = "posts_wrote_at"
+
+// error codes reported in metrics for errors without their own code
+const (
+	codeBadRequest       = "BAD_REQUEST"
+	codeParseFailed      = "GRAPHQL_PARSE_FAILED"
+	codeValidationFailed = "GRAPHQL_VALIDATION_FAILED"
+	codeResolverError    = "RESOLVER_ERROR"
+)
+
+// graphqlRequest is a GraphQL request sent over HTTP
+type graphqlRequest struct {
+	Query         string         `json:"query"`
+	Variables     map[string]any `json:"variables"`
+	OperationName string         `json:"operationName"`
+	Extensions    map[string]any `json:"extensions"`
+}
+
+// graphqlHandler executes GraphQL requests.
+// Unlike handler.Handler it analyzes every document before execution
+// and reports the analysis in the response extensions.
+// The GraphiQL and Playground pages are still served by handler.Handler.
+type graphqlHandler struct {
+	schema    *graphql.Schema
+	ui        http.Handler
+	analyzer  *complexity.Analyzer
+	persisted *persisted.Store // nil disables persisted queries
+	metrics   *metrics.Metrics // nil disables metrics
+	maxAge    time.Duration    // Cache-Control max-age of GET queries, zero makes clients revalidate every time
+	// reads of a client go to the primary this long after its last mutation, zero disables it
+	primaryWindow time.Duration
+}
+
+func newGraphqlHandler(s *graphql.Schema, analyzer *complexity.Analyzer, persistedQueries *persisted.Store, m *metrics.Metrics) *graphqlHandler {
+	return &graphqlHandler{
+		schema: s,
+		ui: handler.New(&handler.Config{
+			Schema:     s,
+			Pretty:     true,
+			GraphiQL:   true,
+			Playground: true,
+		}),
+		analyzer:  analyzer,
+		persisted: persistedQueries,
+		metrics:   m,
+	}
+}
+
+func (h *graphqlHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if wantsUI(r) {
+		h.ui.ServeHTTP(w, r)
+		return
+	}
+
+	req, err := parseRequest(r)
+	if err != nil {
+		result := errorResult(err)
+		h.observeErrors(result.Errors, codeBadRequest)
+		writeResult(w, http.StatusBadRequest, result)
+		return
+	}
+
+	query, err := h.persisted.Resolve(req.Query, req.Extensions)
+	if err != nil {
+		result := errorResult(err)
+		h.observeErrors(result.Errors, codeBadRequest)
+		writeResult(w, http.StatusOK, result)
+		return
+	}
+	req.Query = query
+
+	// the client wrote recently, a replica or a cache may not have its writes yet
+	ctx := r.Context()
+	recent := h.wroteRecently(r)
+	if recent {
+		ctx = repository.WithPrimaryReads(ctx)
+	}
+
+	result, operation := h.execute(ctx, req)
+	if operation == ast.OperationTypeMutation {
+		h.setWroteAt(w)
+	}
+	if r.Method == http.MethodGet && operation == ast.OperationTypeQuery && len(result.Errors) == 0 && !recent {
+		writeCacheable(w, r, result, h.maxAge)
+		return
+	}
+	writeResult(w, http.StatusOK, result)
+}
+
+// execute parses, validates, analyzes and executes the request,
+// it returns the type of the executed operation, empty if nothing was executed
+func (h *graphqlHandler) execute(ctx context.Context, req *graphqlRequest) (*graphql.Result, string) {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{
+			Body: []byte(req.Query),
+			Name: "GraphQL request",
+		}),
+	})
+	if err != nil {
+		result := &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+		h.observeErrors(result.Errors, codeParseFailed)
+		return result, ""
+	}
+
+	validation := graphql.ValidateDocument(h.schema, doc, nil)
+	if !validation.IsValid {
+		h.observeErrors(validation.Errors, codeValidationFailed)
+		return &graphql.Result{Errors: validation.Errors}, ""
+	}
+
+	analysis, err := h.analyzer.Check(doc, req.OperationName, req.Variables)
+	if err != nil {
+		result := errorResult(err)
+		result.Extensions = map[string]any{"cost": analysis}
+		h.observeErrors(result.Errors, codeValidationFailed)
+		return result, ""
+	}
+
+	// the fields of a mutation read what it wrote, a replica or a cache may not have it yet;
+	// the next requests of the client read from the primary too, see wroteRecently
+	op := findOperation(doc, req.OperationName)
+	if op != nil && op.Operation == ast.OperationTypeMutation {
+		ctx = repository.WithPrimaryReads(ctx)
+	}
+
+	ctx, span := tracing.Start(ctx, "graphql.execute", attribute.String("graphql.operation.name", req.OperationName))
+	start := time.Now()
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        *h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+	span.SetAttributes(attribute.Int("graphql.errors", len(result.Errors)))
+	span.End()
+	if result.Extensions == nil {
+		result.Extensions = make(map[string]any)
+	}
+	result.Extensions["cost"] = analysis
+
+	h.observeOperation(doc, req.OperationName, time.Since(start))
+	if op != nil {
+		logging.AddFields(ctx, slog.String("operation_type", op.Operation))
+		if op.Name != nil {
+			logging.AddFields(ctx, slog.String("operation_name", op.Name.Value))
+		}
+	}
+	logging.AddFields(ctx, slog.Int("errors", len(result.Errors)))
+	h.observeErrors(result.Errors, codeResolverError)
+
+	if op == nil {
+		return result, ""
+	}
+	return result, op.Operation
+}
+
+// wroteRecently reports if the request carries the time of a mutation made within the primary window
+func (h *graphqlHandler) wroteRecently(r *http.Request) bool {
+	if h.primaryWindow <= 0 {
+		return false
+	}
+	cookie, err := r.Cookie(wroteAtCookie)
+	if err != nil {
+		return false
+	}
+	millis, err := strconv.ParseInt(cookie.Value, 10, 64)
+	if err != nil {
+		return false
+	}
+	// a second of clock skew between instances is tolerated, later times are ignored
+	since := time.Since(time.UnixMilli(millis))
+	return since >= -time.Second && since < h.primaryWindow
+}
+
+// setWroteAt lets the client's next requests read from the primary until the window ends
+func (h *graphqlHandler) setWroteAt(w http.ResponseWriter) {
+	if h.primaryWindow <= 0 {
+		return
+	}
+	http.SetCookie(w, &http.Cookie{
+		Name:     wroteAtCookie,
+		Value:    strconv.FormatInt(time.Now().UnixMilli(), 10),
+		Path:     "/",
+		MaxAge:   int(h.primaryWindow.Round(time.Second).Seconds()) + 1,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// observeOperation records every root field of the executed operation
+func (h *graphqlHandler) observeOperation(doc *ast.Document, operationName string, d time.Duration) {
+	if h.metrics == nil {
+		return
+	}
+	op := findOperation(doc, operationName)
+	if op == nil || op.SelectionSet == nil {
+		return
+	}
+	for _, selection := range op.SelectionSet.Selections {
+		if field, ok := selection.(*ast.Field); ok && field.Name != nil {
+			h.metrics.ObserveOperation(op.Operation, field.Name.Value, d)
+		}
+	}
+}
+
+// observeErrors records the errors by their extension code, or by the given code if they have none
+func (h *graphqlHandler) observeErrors(errs []gqlerrors.FormattedError, code string) {
+	for _, err := range errs {
+		if c, ok := err.Extensions["code"].(string); ok && c != "" {
+			h.metrics.ObserveError(c)
+			continue
+		}
+		h.metrics.ObserveError(code)
+	}
+}
+
+// findOperation returns the operation with the given name, or the only operation if the name is empty
+func findOperation(doc *ast.Document, operationName string) *ast.OperationDefinition {
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" || (op.Name != nil && op.Name.Value == operationName) {
+			return op
+		}
+	}
+	return nil
+}
+
+// parseRequest reads a GraphQL request from the query string or the body
+func parseRequest(r *http.Request) (*graphqlRequest, error) {
+	if query := r.URL.Query(); query.Get("query") != "" || query.Get("extensions") != "" {
+		return requestFromValues(query.Get)
+	}
+
+	if r.Method != http.MethodPost {
+		return nil, fmt.Errorf("query is missing")
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(body) > maxRequestSize {
+		return nil, fmt.Errorf("request body is larger than %d bytes", maxRequestSize)
+	}
+
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch contentType {
+	case handler.ContentTypeGraphQL:
+		return &graphqlRequest{Query: string(body)}, nil
+	case handler.ContentTypeFormURLEncoded:
+		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		if err := r.ParseForm(); err != nil {
+			return nil, fmt.Errorf("failed to parse form: %w", err)
+		}
+		return requestFromValues(r.PostForm.Get)
+	default:
+		var req graphqlRequest
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, fmt.Errorf("failed to parse request body: %w", err)
+		}
+		return &req, nil
+	}
+}
+
+// requestFromValues builds a request from URL or form values, where variables and extensions are JSON strings
+func requestFromValues(get func(string) string) (*graphqlRequest, error) {
+	req := &graphqlRequest{
+		Query:         get("query"),
+		OperationName: get("operationName"),
+	}
+	if v := get("variables"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Variables); err != nil {
+			return nil, fmt.Errorf("failed to parse variables: %w", err)
+		}
+	}
+	if v := get("extensions"); v != "" {
+		if err := json.Unmarshal([]byte(v), &req.Extensions); err != nil {
+			return nil, fmt.Errorf("failed to parse extensions: %w", err)
+		}
+	}
+	return req, nil
+}
+
+// wantsUI reports if a browser asks for the GraphiQL or Playground page
+func wantsUI(r *http.Request) bool {
+	accept := r.Header.Get("Accept")
+	_, raw := r.URL.Query()["raw"]
+	return r.Method == http.MethodGet && !raw &&
+		!strings.Contains(accept, "application/json") && strings.Contains(accept, "text/html")
+}
+
+// errorResult wraps the error so that its extensions, if any, are kept in the response
+func errorResult(err error) *graphql.Result {
+	return &graphql.Result{
+		Errors: []gqlerrors.FormattedError{
+			gqlerrors.FormatError(gqlerrors.NewError(err.Error(), nil, "", nil, []int{}, err)),
+		},
+	}
+}
+
+// writeCacheable writes the result of a query sent with GET, which caches and clients may keep for maxAge.
+// The ETag is a hash of the response, a client that has it gets 304 Not Modified without a body.
+func writeCacheable(w http.ResponseWriter, r *http.Request, result *graphql.Result, maxAge time.Duration) {
+	buff, _ := json.MarshalIndent(result, "", "\t")
+	sum := sha256.Sum256(buff)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("ETag", etag)
+	if maxAge > 0 {
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(buff)
+}
+
+// etagMatches reports if the If-None-Match header lists the ETag, weak ones compare by their value
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+func writeResult(w http.ResponseWriter, status int, result *graphql.Result) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	buff, _ := json.MarshalIndent(result, "", "\t")
+	w.Write(buff)
+}
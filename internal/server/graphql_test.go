@@ -0,0 +1,234 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/complexity"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/metrics"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/in_memory"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/resolvers"
+	gqlschema "github.com/DimaGitHahahab/ozon-fintech-posts/internal/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type response struct {
+	Data   map[string]any `json:"data"`
+	Errors []struct {
+		Message    string         `json:"message"`
+		Extensions map[string]any `json:"extensions"`
+	} `json:"errors"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+func newTestHandler(t *testing.T, limits complexity.Limits) http.Handler {
+	return newMeasuredTestHandler(t, limits, nil)
+}
+
+func newMeasuredTestHandler(t *testing.T, limits complexity.Limits, m *metrics.Metrics) http.Handler {
+	s, err := gqlschema.NewSchema(resolvers.NewResolver(in_memory.New()))
+	require.NoError(t, err)
+
+	return newGraphqlHandler(&s, &complexity.Analyzer{Schema: &s, DefaultListSize: 100, Limits: limits}, nil, m)
+}
+
+func do(t *testing.T, h http.Handler, r *http.Request) response {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+
+	var res response
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	return res
+}
+
+func TestGraphqlHandler_ReportsCost(t *testing.T) {
+	h := newTestHandler(t, complexity.Limits{})
+
+	body := `{"query": "mutation { createPost(title: \"Title\", content: \"Content\", authorId: 1) { id } }"}`
+	r := httptest.NewRequest(http.MethodPost, "/root", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	res := do(t, h, r)
+	assert.Empty(t, res.Errors)
+	assert.Equal(t, map[string]any{"id": float64(1)}, res.Data["createPost"])
+	assert.Equal(t, map[string]any{"depth": float64(2), "aliases": float64(0), "cost": float64(2)}, res.Extensions["cost"])
+
+	r = httptest.NewRequest(http.MethodGet, "/root?query={posts{id}}", nil)
+	res = do(t, h, r)
+	assert.Empty(t, res.Errors)
+	assert.Equal(t, float64(101), res.Extensions["cost"].(map[string]any)["cost"])
+}
+
+func TestGraphqlHandler_RejectsComplexQuery(t *testing.T) {
+	h := newTestHandler(t, complexity.Limits{MaxCost: 50})
+
+	r := httptest.NewRequest(http.MethodPost, "/root", strings.NewReader(`{ posts { id title } }`))
+	r.Header.Set("Content-Type", "application/graphql")
+
+	res := do(t, h, r)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, "QUERY_TOO_COMPLEX", res.Errors[0].Extensions["code"])
+	assert.Nil(t, res.Data)
+}
+
+func TestGraphqlHandler_RecordsMetrics(t *testing.T) {
+	m := metrics.New()
+	h := newMeasuredTestHandler(t, complexity.Limits{MaxCost: 150}, m)
+
+	do(t, h, httptest.NewRequest(http.MethodGet, "/root?query={posts{id}}", nil))
+	do(t, h, httptest.NewRequest(http.MethodGet, "/root?query={posts{id", nil))
+	do(t, h, httptest.NewRequest(http.MethodGet, "/root?query={posts{id,title}}", nil))
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := w.Body.String()
+
+	assert.Contains(t, body, `posts_graphql_operations_total{field="posts",type="query"} 1`)
+	assert.Contains(t, body, `posts_graphql_operation_duration_seconds_count{field="posts",type="query"} 1`)
+	assert.Contains(t, body, `posts_graphql_errors_total{code="GRAPHQL_PARSE_FAILED"} 1`)
+	assert.Contains(t, body, `posts_graphql_errors_total{code="QUERY_TOO_COMPLEX"} 1`)
+}
+
+// primaryRecorder records if the repository calls ask for the primary database
+type primaryRecorder struct {
+	repository.Repository
+	primary []bool
+}
+
+func (r *primaryRecorder) GetPosts(ctx context.Context) ([]*domain.Post, error) {
+	r.primary = append(r.primary, repository.PrimaryReads(ctx))
+	return r.Repository.GetPosts(ctx)
+}
+
+func (r *primaryRecorder) WithTx(ctx context.Context, fn func(repo repository.Repository) error) error {
+	r.primary = append(r.primary, repository.PrimaryReads(ctx))
+	return r.Repository.WithTx(ctx, fn)
+}
+
+func TestGraphqlHandler_MutationsReadPrimary(t *testing.T) {
+	repo := &primaryRecorder{Repository: in_memory.New()}
+	s, err := gqlschema.NewSchema(resolvers.NewResolver(repo))
+	require.NoError(t, err)
+	h := newGraphqlHandler(&s, &complexity.Analyzer{Schema: &s, DefaultListSize: 100}, nil, nil)
+
+	res := do(t, h, httptest.NewRequest(http.MethodGet, "/root?query={posts{id}}", nil))
+	require.Empty(t, res.Errors)
+	assert.Equal(t, []bool{false}, repo.primary)
+
+	repo.primary = nil
+	body := `{"query": "mutation { createPost(title: \"Title\", content: \"Content\", authorId: 1) { id } }"}`
+	r := httptest.NewRequest(http.MethodPost, "/root", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	res = do(t, h, r)
+	require.Empty(t, res.Errors)
+	assert.NotEmpty(t, repo.primary)
+	assert.NotContains(t, repo.primary, false)
+}
+
+func TestGraphqlHandler_ReadYourWrites(t *testing.T) {
+	repo := &primaryRecorder{Repository: in_memory.New()}
+	s, err := gqlschema.NewSchema(resolvers.NewResolver(repo))
+	require.NoError(t, err)
+	h := newGraphqlHandler(&s, &complexity.Analyzer{Schema: &s, DefaultListSize: 100}, nil, nil)
+	h.maxAge = time.Minute
+	h.primaryWindow = time.Minute
+
+	body := `{"query": "mutation { createPost(title: \"Title\", content: \"Content\", authorId: 1) { id } }"}`
+	r := httptest.NewRequest(http.MethodPost, "/root", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, wroteAtCookie, cookies[0].Name)
+
+	get := func(cookie *http.Cookie) *httptest.ResponseRecorder {
+		repo.primary = nil
+		r := httptest.NewRequest(http.MethodGet, "/root?query={posts{id}}", nil)
+		if cookie != nil {
+			r.AddCookie(cookie)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w = get(cookies[0])
+	assert.Equal(t, []bool{true}, repo.primary, "the writer reads from the primary")
+	assert.Empty(t, w.Header().Get("Cache-Control"), "primary reads are not cacheable")
+	assert.Empty(t, w.Result().Cookies(), "queries don't extend the window")
+
+	w = get(nil)
+	assert.Equal(t, []bool{false}, repo.primary, "other clients read from the replicas")
+	assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+
+	expired := &http.Cookie{Name: wroteAtCookie, Value: strconv.FormatInt(time.Now().Add(-2*time.Minute).UnixMilli(), 10)}
+	get(expired)
+	assert.Equal(t, []bool{false}, repo.primary, "the window is over")
+
+	future := &http.Cookie{Name: wroteAtCookie, Value: strconv.FormatInt(time.Now().Add(time.Hour).UnixMilli(), 10)}
+	get(future)
+	assert.Equal(t, []bool{false}, repo.primary, "times in the future are ignored")
+
+	h.primaryWindow = 0
+	get(cookies[0])
+	assert.Equal(t, []bool{false}, repo.primary, "the window is disabled")
+}
+
+func TestGraphqlHandler_CacheHeaders(t *testing.T) {
+	s, err := gqlschema.NewSchema(resolvers.NewResolver(in_memory.New()))
+	require.NoError(t, err)
+	h := newGraphqlHandler(&s, &complexity.Analyzer{Schema: &s, DefaultListSize: 100}, nil, nil)
+	h.maxAge = time.Minute
+
+	get := func(query, etag string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/root?query="+url.QueryEscape(query), nil)
+		if etag != "" {
+			r.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("{ posts { id } }", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "public, max-age=60", w.Header().Get("Cache-Control"))
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	w = get("{ posts { id } }", `"other", W/`+etag)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	// another result gets another tag
+	body := `{"query": "mutation { createPost(title: \"Title\", content: \"Content\", authorId: 1) { id } }"}`
+	r := httptest.NewRequest(http.MethodPost, "/root", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Empty(t, w.Header().Get("ETag"), "only GET queries are cacheable")
+
+	w = get("{ posts { id } }", etag)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	// errors are not cached
+	w = get("{ post(id: 100) { id } }", "")
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+
+	h.maxAge = 0
+	w = get("{ posts { id } }", "")
+	assert.Equal(t, "no-cache", w.Header().Get("Cache-Control"))
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/logging"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/ratelimit"
+)
+
+// requestIDHeader carries the request id, the client's id is kept if it looks sane
+const requestIDHeader = "X-Request-ID"
+
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// unloggedPaths are scraped and probed too often to be logged or traced
+var unloggedPaths = map[string]bool{
+	"/metrics": true,
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+// withClientIP puts the client IP into the request context. Behind trustedProxies proxies,
+// each appending the address it got the request from to X-Forwarded-For, the client IP is the entry
+// the outermost trusted proxy appended, trustedProxies from the right: the entries left of it are written
+// by the client and can't be trusted. Zero ignores proxy headers.
+func withClientIP(next http.Handler, trustedProxies int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := remoteIP(r.RemoteAddr)
+		if trustedProxies > 0 {
+			if forwarded := forwardedFor(r.Header.Values("X-Forwarded-For"), trustedProxies); forwarded != "" {
+				ip = forwarded
+			} else if realIP := strings.TrimSpace(r.Header.Get("X-Real-IP")); net.ParseIP(realIP) != nil {
+				ip = realIP
+			}
+		}
+
+		next.ServeHTTP(w, r.WithContext(ratelimit.WithClientIP(r.Context(), ip)))
+	})
+}
+
+// forwardedFor returns the X-Forwarded-For entry hops from the right,
+// or the leftmost one when the request passed fewer proxies
+func forwardedFor(headers []string, hops int) string {
+	var entries []string
+	for _, header := range headers {
+		for _, entry := range strings.Split(header, ",") {
+			if entry = strings.TrimSpace(entry); entry != "" {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	if len(entries) == 0 {
+		return ""
+	}
+
+	ip := entries[max(len(entries)-hops, 0)]
+	if net.ParseIP(ip) == nil {
+		return ""
+	}
+	return ip
+}
+
+func remoteIP(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// withRequestLog gives every request an id and logs it with the fields collected while it was handled.
+// WebSocket requests are logged when the connection is closed.
+func withRequestLog(next http.Handler, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID.MatchString(id) {
+			id = logging.NewRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+
+		ctx := logging.WithFields(logging.WithRequestID(r.Context(), id))
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r.WithContext(ctx))
+
+		if unloggedPaths[r.URL.Path] {
+			return
+		}
+		attrs := []slog.Attr{
+			slog.String("method", r.Method),
+			slog.String("path", r.URL.Path),
+			slog.Int("status", rec.status),
+			slog.Duration("latency", time.Since(start)),
+		}
+		if ip, ok := ratelimit.ClientIP(ctx); ok {
+			attrs = append(attrs, slog.String("client_ip", ip))
+		}
+		attrs = append(attrs, logging.Fields(ctx)...)
+		logger.LogAttrs(ctx, slog.LevelInfo, "request", attrs...)
+	})
+}
+
+// statusRecorder remembers the response status, it keeps the writer hijackable for WebSocket upgrades
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := r.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, fmt.Errorf("response writer does not support hijacking")
+	}
+	r.status = http.StatusSwitchingProtocols
+	return hijacker.Hijack()
+}
+
+func (r *statusRecorder) Flush() {
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
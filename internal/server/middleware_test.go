@@ -0,0 +1,88 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/complexity"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/logging"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/ratelimit"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRequestLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(config.LogConfig{Level: "INFO", Format: "JSON"}, &buf)
+	require.NoError(t, err)
+	h := withRequestLog(newTestHandler(t, complexity.Limits{}), logger)
+
+	body := `{"query": "mutation Create { createPost(title: \"Title\", content: \"Content\", authorId: 5) { id } }"}`
+	r := httptest.NewRequest(http.MethodPost, "/root", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Request-ID", "client-id")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Equal(t, "client-id", w.Header().Get("X-Request-ID"))
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "request", record["msg"])
+	assert.Equal(t, "client-id", record["request_id"])
+	assert.Equal(t, float64(5), record["user_id"])
+	assert.Equal(t, "mutation", record["operation_type"])
+	assert.Equal(t, "Create", record["operation_name"])
+	assert.Equal(t, float64(http.StatusOK), record["status"])
+	assert.Contains(t, record, "latency")
+
+	r = httptest.NewRequest(http.MethodGet, "/root?query={posts{id}}", nil)
+	r.Header.Set("X-Request-ID", "not a valid id")
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	assert.Len(t, w.Header().Get("X-Request-ID"), 16)
+}
+
+func TestWithClientIP(t *testing.T) {
+	tests := []struct {
+		name      string
+		proxies   int
+		forwarded []string
+		realIP    string
+		want      string
+	}{
+		{name: "no proxy", proxies: 0, forwarded: []string{"1.1.1.1"}, want: "10.0.0.1"},
+		{name: "one proxy", proxies: 1, forwarded: []string{"203.0.113.7"}, want: "203.0.113.7"},
+		{name: "spoofed entries", proxies: 1, forwarded: []string{"1.1.1.1, 2.2.2.2, 203.0.113.7"}, want: "203.0.113.7"},
+		{name: "spoofed header", proxies: 1, forwarded: []string{"1.1.1.1", "203.0.113.7"}, want: "203.0.113.7"},
+		{name: "two proxies", proxies: 2, forwarded: []string{"1.1.1.1, 203.0.113.7, 10.0.0.2"}, want: "203.0.113.7"},
+		{name: "fewer entries than proxies", proxies: 2, forwarded: []string{"203.0.113.7"}, want: "203.0.113.7"},
+		{name: "not an ip", proxies: 1, forwarded: []string{"1.1.1.1, junk"}, want: "10.0.0.1"},
+		{name: "real ip", proxies: 1, realIP: "203.0.113.7", want: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+			h := withClientIP(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+				got, _ = ratelimit.ClientIP(r.Context())
+			}), tt.proxies)
+
+			r := httptest.NewRequest(http.MethodPost, "/root", nil)
+			r.RemoteAddr = "10.0.0.1:4321"
+			for _, forwarded := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", forwarded)
+			}
+			if tt.realIP != "" {
+				r.Header.Set("X-Real-IP", tt.realIP)
+			}
+			h.ServeHTTP(httptest.NewRecorder(), r)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,44 +2,167 @@ package server
 
 import (
 	"context"
+	"log/slog"
 	"net"
 	"net/http"
+	"time"
 
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/complexity"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/health"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/metrics"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/persisted"
 	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/subscription"
 	"github.com/graphql-go/graphql"
-	"github.com/graphql-go/handler"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 // Server is a server that handles GraphQL schema
 type Server struct {
-	server *http.Server
+	server     *http.Server
+	health     *health.Health
+	subManager *subscription.Manager
+
+	trustedProxies  int // proxies in front of the server, the client IP is taken from their headers
+	limits          complexity.Limits
+	defaultListSize int // assumed size of unbounded lists in the query cost
+	persisted       *persisted.Store
+	metrics         *metrics.Metrics
+	tracing         bool          // start a span for every request
+	cacheMaxAge     time.Duration // Cache-Control max-age of GET queries
+	readYourWrites  time.Duration // reads go to the primary this long after a client's mutation
+	logger          *slog.Logger
+	checks          []health.Check
+}
+
+// defaultListSize is the list size assumed by the query cost when WithQueryLimits is not used
+const defaultListSize = 100
+
+// Option configures optional Server behaviour
+type Option func(*Server)
+
+// WithTrustedProxies makes the server take client IPs from X-Forwarded-For and X-Real-IP headers
+// set by the given number of proxies in front of it
+func WithTrustedProxies(proxies int) Option {
+	return func(s *Server) {
+		s.trustedProxies = proxies
+	}
+}
+
+// WithQueryLimits rejects queries over the limits before execution
+func WithQueryLimits(limits complexity.Limits, defaultListSize int) Option {
+	return func(s *Server) {
+		s.limits = limits
+		s.defaultListSize = defaultListSize
+	}
+}
+
+// WithPersistedQueries enables automatic persisted queries and the query allowlist
+func WithPersistedQueries(store *persisted.Store) Option {
+	return func(s *Server) {
+		s.persisted = store
+	}
+}
+
+// WithMetrics records request metrics and serves them on /metrics
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(s *Server) {
+		s.metrics = m
+	}
+}
+
+// WithTracing starts a span for every HTTP request, continuing the W3C trace context sent by the client
+func WithTracing() Option {
+	return func(s *Server) {
+		s.tracing = true
+	}
+}
+
+// WithCacheControl lets caches and clients keep the results of queries sent with GET for maxAge.
+// The results always get an ETag, so clients can revalidate them.
+func WithCacheControl(maxAge time.Duration) Option {
+	return func(s *Server) {
+		s.cacheMaxAge = maxAge
+	}
+}
+
+// WithReadYourWrites sends the reads of a client to the primary for the window after its last mutation,
+// so that it sees its writes even if the replicas and the caches of other instances don't have them yet.
+// The time of the mutation is kept in a cookie, clients that drop cookies read their writes only in the mutation response.
+func WithReadYourWrites(window time.Duration) Option {
+	return func(s *Server) {
+		s.readYourWrites = window
+	}
+}
+
+// WithLogger sets the logger of the server, slog.Default() is used otherwise
+func WithLogger(logger *slog.Logger) Option {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// WithHealthChecks adds readiness checks to /readyz, the subscription manager is always checked
+func WithHealthChecks(checks ...health.Check) Option {
+	return func(s *Server) {
+		s.checks = append(s.checks, checks...)
+	}
 }
 
 var schema graphql.Schema
 
 // NewServer creates a new GraphQL server
-func NewServer(s *graphql.Schema) *Server {
+func NewServer(s *graphql.Schema, opts ...Option) *Server {
+	srv := &Server{
+		defaultListSize: defaultListSize,
+		logger:          slog.Default(),
+	}
+	for _, opt := range opts {
+		opt(srv)
+	}
+
 	schema = *s
-	h := handler.New(&handler.Config{
-		Schema:     s,
-		Pretty:     true,
-		GraphiQL:   true,
-		Playground: true,
-	})
+	analyzer := &complexity.Analyzer{
+		Schema:          s,
+		DefaultListSize: srv.defaultListSize,
+		Limits:          srv.limits,
+	}
+	h := newGraphqlHandler(s, analyzer, srv.persisted, srv.metrics)
+	h.maxAge = srv.cacheMaxAge
+	h.primaryWindow = srv.readYourWrites
 
 	mux := http.NewServeMux()
 	mux.Handle("/root", h)
 
-	subManager := subscription.New(schema)
-	mux.HandleFunc("/subscriptions", subManager.SubscriptionsHandler)
+	srv.subManager = subscription.New(schema, analyzer, srv.persisted, srv.metrics, srv.logger)
+	mux.HandleFunc("/subscriptions", srv.subManager.SubscriptionsHandler)
+
+	srv.health = health.New(srv.checks...)
+	srv.health.Add(health.Check{Name: "subscriptions", Check: srv.subManager.Check})
+	mux.Handle("/healthz", srv.health.LivenessHandler())
+	mux.Handle("/readyz", srv.health.ReadinessHandler())
 
-	server := &http.Server{
-		Handler: mux,
+	if srv.metrics != nil {
+		mux.Handle("/metrics", srv.metrics.Handler())
 	}
 
-	return &Server{
-		server: server,
+	var handler http.Handler = mux
+	if srv.tracing {
+		handler = otelhttp.NewHandler(mux, "http.server",
+			otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+				return r.Method + " " + r.URL.Path
+			}),
+			otelhttp.WithFilter(func(r *http.Request) bool {
+				return !unloggedPaths[r.URL.Path]
+			}),
+		)
 	}
+
+	srv.server = &http.Server{
+		Handler:  withClientIP(withRequestLog(handler, srv.logger), srv.trustedProxies),
+		ErrorLog: slog.NewLogLogger(srv.logger.Handler(), slog.LevelError),
+	}
+
+	return srv
 }
 
 // Run starts HTTP server on the given port
@@ -49,7 +172,21 @@ func (s *Server) Run(port string) error {
 	return s.server.ListenAndServe()
 }
 
-// Shutdown stops HTTP server
+// MarkNotReady makes /readyz report the server as not ready and refuses new subscriptions
+func (s *Server) MarkNotReady() {
+	s.health.Shutdown()
+	s.subManager.Stop()
+}
+
+// Shutdown stops accepting connections and waits for the in-flight requests until the context is done,
+// WebSocket connections are not waited for, see CloseSubscriptions
 func (s *Server) Shutdown(ctx context.Context) error {
+	s.MarkNotReady()
+
 	return s.server.Shutdown(ctx)
 }
+
+// CloseSubscriptions sends a close frame to every WebSocket client and ends their subscriptions
+func (s *Server) CloseSubscriptions(ctx context.Context) error {
+	return s.subManager.Close(ctx)
+}
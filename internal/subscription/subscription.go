@@ -4,12 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"fmt"
+	"log/slog"
 	"net/http"
 	"sync"
+	"time"
 
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/complexity"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/metrics"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/persisted"
 	"github.com/gorilla/websocket"
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/parser"
 )
 
 // Manager handles websocket subscriptions
@@ -17,56 +24,202 @@ import (
 type Manager struct {
 	schema      graphql.Schema
 	subscribers sync.Map
+	analyzer    *complexity.Analyzer // rejects subscriptions over the query limits
+	persisted   *persisted.Store     // resolves persisted queries, nil disables them
+	metrics     *metrics.Metrics     // nil disables metrics
+	logger      *slog.Logger
+
+	mu          sync.Mutex
+	stopped     bool                                   // new subscriptions are refused once stopped
+	connections map[*websocket.Conn]context.CancelFunc // open connections and their subscription cancel funcs
+	handlers    sync.WaitGroup                         // running connection handlers
 }
 
-func New(schema graphql.Schema) *Manager {
+// closeFrameTimeout bounds writing the close frame to a client on shutdown
+const closeFrameTimeout = time.Second
+
+// writeTimeout bounds writing a message, a client that doesn't read is disconnected after it
+const writeTimeout = 10 * time.Second
+
+// ErrStopped is returned by Check once the manager is stopped
+var ErrStopped = errors.New("subscription manager is stopped")
+
+func New(
+	schema graphql.Schema, analyzer *complexity.Analyzer, persistedQueries *persisted.Store,
+	m *metrics.Metrics, logger *slog.Logger,
+) *Manager {
 	return &Manager{
-		schema: schema,
+		schema:      schema,
+		analyzer:    analyzer,
+		persisted:   persistedQueries,
+		metrics:     m,
+		logger:      logger,
+		connections: make(map[*websocket.Conn]context.CancelFunc),
 	}
 }
 
 // SubscribeMessage is the message sent by the client to subscribe to posts
 type SubscribeMessage struct {
-	Posts []int  `json:"posts"`
-	Query string `json:"query"`
+	Posts      []int          `json:"posts"`
+	Query      string         `json:"query"`
+	Extensions map[string]any `json:"extensions"`
+}
+
+// Stop makes the manager refuse new subscriptions
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	m.stopped = true
+	m.mu.Unlock()
+}
+
+// Check reports if the manager accepts new subscriptions
+func (m *Manager) Check(context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.stopped {
+		return ErrStopped
+	}
+	return nil
+}
+
+// Close stops the manager, sends a close frame to every client, ends their subscriptions
+// and waits for the connection handlers until the context is done
+func (m *Manager) Close(ctx context.Context) error {
+	m.mu.Lock()
+	m.stopped = true
+	connections := make(map[*websocket.Conn]context.CancelFunc, len(m.connections))
+	for conn, cancel := range m.connections {
+		connections[conn] = cancel
+	}
+	m.mu.Unlock()
+
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down")
+	for conn, cancel := range connections {
+		err := conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(closeFrameTimeout))
+		if err != nil && !errors.Is(err, websocket.ErrCloseSent) {
+			m.logger.DebugContext(ctx, "failed to send close frame", "error", err)
+		}
+		cancel()
+		conn.Close()
+	}
+	m.logger.InfoContext(ctx, "closed websocket connections", "count", len(connections))
+
+	done := make(chan struct{})
+	go func() {
+		m.handlers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("websocket handlers are still running: %w", ctx.Err())
+	}
 }
 
 // SubscriptionsHandler upgrades the connection to a websocket connection
 func (m *Manager) SubscriptionsHandler(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	if m.stopped {
+		m.mu.Unlock()
+		http.Error(w, ErrStopped.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	m.handlers.Add(1)
+	m.mu.Unlock()
+	defer m.handlers.Done()
+
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
-		log.Println("failed to upgrade connection:", err)
+		m.logger.WarnContext(r.Context(), "failed to upgrade connection", "error", err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	m.handleSubscription(conn)
+	// the subscriptions outlive the request, only its log fields are kept
+	m.handleSubscription(context.WithoutCancel(r.Context()), conn)
 }
 
 // handleSubscription reads the message from the websocket connection
-func (m *Manager) handleSubscription(conn *websocket.Conn) {
-	subscriptionCtx, subscriptionCancelFn := context.WithCancel(context.Background())
+func (m *Manager) handleSubscription(ctx context.Context, wsConn *websocket.Conn) {
+	subscriptionCtx, subscriptionCancelFn := context.WithCancel(ctx)
 	defer subscriptionCancelFn()
 
+	m.mu.Lock()
+	m.connections[wsConn] = subscriptionCancelFn
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		delete(m.connections, wsConn)
+		m.mu.Unlock()
+		wsConn.Close()
+	}()
+
+	conn := &connection{Conn: wsConn}
+
 	for {
 		_, p, err := conn.ReadMessage()
 		if err != nil {
-			log.Printf("failed to read websocket message: %v", err)
+			if websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) || m.Check(ctx) != nil {
+				m.logger.DebugContext(ctx, "websocket connection closed")
+				return
+			}
+			m.logger.WarnContext(ctx, "failed to read websocket message", "error", err)
 			return
 		}
 
 		var msg SubscribeMessage
 		if err := json.Unmarshal(p, &msg); err != nil {
-			log.Printf("failed to unmarshal websocket message: %v", err)
+			m.logger.WarnContext(ctx, "failed to unmarshal websocket message", "error", err)
 			continue
 		}
+		query, err := m.persisted.Resolve(msg.Query, msg.Extensions)
+		if err != nil {
+			m.reject(ctx, conn, err)
+			continue
+		}
+		msg.Query = query
+
+		if err := m.checkLimits(msg.Query); err != nil {
+			m.reject(ctx, conn, err)
+			continue
+		}
+		m.logger.InfoContext(ctx, "subscribed", "posts", msg.Posts)
 		m.subscribe(subscriptionCtx, subscriptionCancelFn, conn, msg)
 	}
 }
 
+// reject sends the reason the subscription was rejected to the client
+func (m *Manager) reject(ctx context.Context, conn *connection, err error) {
+	m.logger.InfoContext(ctx, "rejected subscription", "error", err)
+	if err := sendError(conn, err); err != nil {
+		m.logger.WarnContext(ctx, "failed to send message", "error", err)
+	}
+}
+
+// connection is a websocket connection shared by the read loop and the subscriptions on it.
+// Websocket connections support one concurrent writer, so every message is written under the lock.
+// Control frames are written with WriteControl, which is safe to call concurrently.
+type connection struct {
+	*websocket.Conn
+	writeMu sync.Mutex
+}
+
+// writeMessage writes a text message to the client within writeTimeout
+func (c *connection) writeMessage(message []byte) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	if err := c.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		return err
+	}
+	return c.WriteMessage(websocket.TextMessage, message)
+}
+
 // subscriber represents a websocket connection with a query
 type subscriber struct {
-	conn          *websocket.Conn
+	conn          *connection
 	requestString string
 }
 
@@ -80,12 +233,12 @@ func (m *Manager) unsubscribe(subscriptionCancelFn context.CancelFunc, subscribe
 }
 
 // subscribe creates a new subscriber and starts a goroutine to manage the subscription
-func (m *Manager) subscribe(ctx context.Context, subscriptionCancelFn context.CancelFunc, conn *websocket.Conn, msg SubscribeMessage) *subscriber {
+func (m *Manager) subscribe(ctx context.Context, subscriptionCancelFn context.CancelFunc, conn *connection, msg SubscribeMessage) *subscriber {
 	sub := &subscriber{
 		conn:          conn,
 		requestString: msg.Query,
 	}
-	m.subscribers.Store(&sub, struct{}{})
+	m.subscribers.Store(sub, struct{}{})
 
 	ctx = context.WithValue(ctx, "posts", msg.Posts)
 	go func() {
@@ -97,6 +250,8 @@ func (m *Manager) subscribe(ctx context.Context, subscriptionCancelFn context.Ca
 
 		subscribeChannel := graphql.Subscribe(subscribeParams)
 
+		m.metrics.SubscriptionStarted()
+		defer m.metrics.SubscriptionEnded()
 		m.manageUnsub(ctx, subscribeChannel, subscriptionCancelFn, sub)
 	}()
 
@@ -118,15 +273,43 @@ func (m *Manager) manageUnsub(
 				return
 			}
 			if err := sendMessage(r, *sub); err != nil {
-				if errors.Is(err, websocket.ErrCloseSent) {
-					m.unsubscribe(subscriptionCancelFn, sub)
+				// a failed or timed out write leaves the connection unusable
+				m.unsubscribe(subscriptionCancelFn, sub)
+				if !errors.Is(err, websocket.ErrCloseSent) {
+					m.logger.WarnContext(ctx, "failed to send message", "error", err)
 				}
-				log.Printf("failed to send message: %v", err)
+				return
 			}
 		}
 	}
 }
 
+// checkLimits rejects subscription queries over the limits, invalid queries are left to graphql.Subscribe
+func (m *Manager) checkLimits(query string) error {
+	doc, err := parser.Parse(parser.ParseParams{Source: query})
+	if err != nil {
+		return nil
+	}
+	if !graphql.ValidateDocument(&m.schema, doc, nil).IsValid {
+		return nil
+	}
+
+	_, err = m.analyzer.Check(doc, "", nil)
+	return err
+}
+
+// sendError sends the error to the client in the GraphQL error format
+func sendError(conn *connection, queryErr error) error {
+	message, err := json.Marshal(map[string]any{
+		"errors": gqlerrors.FormatErrors(gqlerrors.NewError(queryErr.Error(), nil, "", nil, []int{}, queryErr)),
+	})
+	if err != nil {
+		return err
+	}
+
+	return conn.writeMessage(message)
+}
+
 // sendMessage sends the result of the subscription to the client
 func sendMessage(r *graphql.Result, sub subscriber) error {
 	message, err := json.Marshal(map[string]any{
@@ -136,7 +319,7 @@ func sendMessage(r *graphql.Result, sub subscriber) error {
 		return err
 	}
 
-	if err := sub.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+	if err := sub.conn.writeMessage(message); err != nil {
 		return err
 	}
 
@@ -0,0 +1,175 @@
+package subscription
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/complexity"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/outbox"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/persisted"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/in_memory"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/resolvers"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/schema"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Close(t *testing.T) {
+	resolver := resolvers.NewResolver(in_memory.New())
+	_, err := resolver.CreatePost(context.Background(), resolvers.CreatePostArgs{Title: "Title", Content: "Content", AuthorID: 1})
+	require.NoError(t, err)
+
+	s, err := schema.NewSchema(resolver)
+	require.NoError(t, err)
+	m := New(s, &complexity.Analyzer{Schema: &s}, nil, nil, slog.Default())
+
+	srv := httptest.NewServer(http.HandlerFunc(m.SubscriptionsHandler))
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.WriteJSON(SubscribeMessage{Posts: []int{1}, Query: "subscription { comment { id } }"}))
+
+	// wait until the connection is registered
+	require.Eventually(t, func() bool {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		return len(m.connections) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, m.Close(ctx))
+	require.NoError(t, resolver.Close(ctx))
+
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "unexpected error: %v", err)
+	assert.ErrorIs(t, m.Check(ctx), ErrStopped)
+
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestManager_AllowlistOnly(t *testing.T) {
+	allowed := "subscription { comment { id } }"
+	s, err := schema.NewSchema(resolvers.NewResolver(in_memory.New()))
+	require.NoError(t, err)
+	store := persisted.New(0, persisted.WithManifest(persisted.Manifest{persisted.Hash(allowed): allowed}), persisted.AllowlistOnly())
+	m := New(s, &complexity.Analyzer{Schema: &s}, store, nil, slog.Default())
+
+	srv := httptest.NewServer(http.HandlerFunc(m.SubscriptionsHandler))
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	for _, msg := range []SubscribeMessage{
+		{Posts: []int{1}, Query: "subscription { comment { id content } }"},
+		{Posts: []int{1}, Extensions: map[string]any{
+			"persistedQuery": map[string]any{"version": 1, "sha256Hash": persisted.Hash("subscription { comment { content } }")},
+		}},
+	} {
+		require.NoError(t, conn.WriteJSON(msg))
+
+		var resp struct {
+			Errors []struct {
+				Extensions map[string]any `json:"extensions"`
+			} `json:"errors"`
+		}
+		require.NoError(t, conn.ReadJSON(&resp))
+		require.Len(t, resp.Errors, 1)
+		assert.Equal(t, "PERSISTED_QUERY_NOT_ALLOWED", resp.Errors[0].Extensions["code"])
+	}
+	assert.Zero(t, countSubscribers(m))
+
+	// queries from the manifest are subscribed by text or by hash
+	require.NoError(t, conn.WriteJSON(SubscribeMessage{Posts: []int{1}, Query: allowed}))
+	require.NoError(t, conn.WriteJSON(SubscribeMessage{Posts: []int{1}, Extensions: map[string]any{
+		"persistedQuery": map[string]any{"version": 1, "sha256Hash": persisted.Hash(allowed)},
+	}}))
+	require.Eventually(t, func() bool { return countSubscribers(m) == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestManager_RejectWhileSending(t *testing.T) {
+	repo := in_memory.New()
+	relay := outbox.NewRelay(repo, slog.Default(), outbox.WithInterval(time.Hour))
+	resolver := resolvers.NewResolver(repo, resolvers.WithEventNotify(relay.Notify))
+	relay.AddSink("subscriptions", resolver)
+	relay.Start()
+	defer relay.Stop(context.Background())
+
+	ctx := context.Background()
+	_, err := resolver.CreatePost(ctx, resolvers.CreatePostArgs{Title: "Title", Content: "Content", AuthorID: 1})
+	require.NoError(t, err)
+
+	s, err := schema.NewSchema(resolver)
+	require.NoError(t, err)
+	m := New(s, &complexity.Analyzer{Schema: &s, Limits: complexity.Limits{MaxAliases: 1}}, nil, nil, slog.Default())
+
+	srv := httptest.NewServer(http.HandlerFunc(m.SubscriptionsHandler))
+	defer srv.Close()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.WriteJSON(SubscribeMessage{Posts: []int{1}, Query: "subscription { comment { id } }"}))
+	require.Eventually(t, func() bool { return countSubscribers(m) == 1 }, time.Second, 10*time.Millisecond)
+
+	const n = 50
+	go func() {
+		for i := 0; i < n; i++ {
+			_, err := resolver.CreateComment(ctx, resolvers.CreateCommentArgs{PostID: 1, AuthorID: 2, Content: "Comment"})
+			assert.NoError(t, err)
+		}
+	}()
+	// rejections are written by the read loop while the subscription writes events
+	go func() {
+		for i := 0; i < n; i++ {
+			err := conn.WriteJSON(SubscribeMessage{Posts: []int{1}, Query: "subscription { comment { a: id b: id } }"})
+			assert.NoError(t, err)
+		}
+	}()
+
+	var events, rejections int
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	for events < n || rejections < n {
+		var resp struct {
+			Payload map[string]any `json:"payload"`
+			Errors  []any          `json:"errors"`
+		}
+		require.NoError(t, conn.ReadJSON(&resp))
+		if resp.Errors != nil {
+			rejections++
+		} else {
+			events++
+		}
+	}
+	assert.Equal(t, n, events)
+	assert.Equal(t, n, rejections)
+
+	closeCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, m.Close(closeCtx))
+	require.NoError(t, resolver.Close(closeCtx))
+}
+
+func countSubscribers(m *Manager) int {
+	count := 0
+	m.subscribers.Range(func(_, _ any) bool {
+		count++
+		return true
+	})
+	return count
+}
@@ -0,0 +1,19 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RepositoryObserver starts a span for every repository call, it is meant for the observed repository decorator
+func RepositoryObserver(ctx context.Context, method string) (context.Context, func(error)) {
+	ctx, span := tracer().Start(ctx, "repository."+method,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attribute.String("repository.method", method)),
+	)
+	return ctx, func(err error) {
+		end(span, err)
+	}
+}
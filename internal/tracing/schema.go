@@ -0,0 +1,42 @@
+package tracing
+
+import (
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentSchema wraps the resolver of every field that has one in a span.
+// Fields resolved by the default resolver are plain struct reads and are not traced.
+// The span context is passed to the resolver, so repository and pgx spans are nested in the field span.
+func InstrumentSchema(s *graphql.Schema) {
+	for name, t := range s.TypeMap() {
+		object, ok := t.(*graphql.Object)
+		if !ok || strings.HasPrefix(name, "__") {
+			continue
+		}
+		for fieldName, field := range object.Fields() {
+			if field.Resolve == nil {
+				continue
+			}
+			field.Resolve = traceResolve(object.Name(), fieldName, field.Resolve)
+		}
+	}
+}
+
+func traceResolve(typeName, fieldName string, resolve graphql.FieldResolveFn) graphql.FieldResolveFn {
+	spanName := "graphql.resolve " + typeName + "." + fieldName
+	return func(p graphql.ResolveParams) (any, error) {
+		ctx, span := tracer().Start(p.Context, spanName, trace.WithAttributes(
+			attribute.String("graphql.type", typeName),
+			attribute.String("graphql.field", fieldName),
+		))
+		p.Context = ctx
+
+		result, err := resolve(p)
+		end(span, err)
+		return result, err
+	}
+}
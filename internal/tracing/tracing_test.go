@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/in_memory"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/observed"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/resolvers"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/schema"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestInstrumentSchema_NestsRepositorySpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(sdktrace.NewTracerProvider()) })
+
+	repo := observed.New(in_memory.New(), RepositoryObserver)
+	s, err := schema.NewSchema(resolvers.NewResolver(repo))
+	require.NoError(t, err)
+	InstrumentSchema(&s)
+
+	ctx, root := Start(context.Background(), "request")
+	result := graphql.Do(graphql.Params{
+		Schema:        s,
+		RequestString: `mutation { createPost(title: "Title", content: "Content", authorId: 1) { id title } }`,
+		Context:       ctx,
+	})
+	root.End()
+	require.Empty(t, result.Errors)
+
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	field, ok := spans["graphql.resolve RootMutation.createPost"]
+	require.True(t, ok)
+	assert.Equal(t, root.SpanContext().SpanID(), field.Parent().SpanID())
+
+	create, ok := spans["repository.CreatePost"]
+	require.True(t, ok)
+	assert.Equal(t, field.SpanContext().SpanID(), create.Parent().SpanID())
+
+	_, ok = spans["graphql.resolve Post.title"]
+	assert.False(t, ok, "default resolvers are not traced")
+}
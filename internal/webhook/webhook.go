@@ -0,0 +1,287 @@
+// Package webhook delivers outbox events to the registered webhooks
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/worker"
+)
+
+// Headers of a webhook request
+const (
+	SignatureHeader = "X-Webhook-Signature-256" // "sha256=" and the hex HMAC-SHA256 of the body
+	EventHeader     = "X-Webhook-Event"         // event type
+	DeliveryHeader  = "X-Webhook-Delivery"      // event dedup id, the same on every attempt
+)
+
+// Payload is the body of a webhook request
+type Payload struct {
+	ID        string           `json:"id"` // event dedup id
+	Type      domain.EventType `json:"type"`
+	PostID    int              `json:"post_id"`
+	CreatedAt time.Time        `json:"created_at"`
+	Data      json.RawMessage  `json:"data"` // the changed post or comment
+}
+
+// Dispatcher turns outbox events into deliveries to the matching webhooks and sends them,
+// retrying failed deliveries with exponential backoff until they run out of attempts and are dead
+type Dispatcher struct {
+	repo        repository.Repository
+	client      *http.Client
+	timeout     time.Duration
+	private     bool // deliveries to non-public addresses are allowed
+	logger      *slog.Logger
+	interval    time.Duration // poll interval when not notified
+	batchSize   int
+	lease       time.Duration // how long claimed deliveries are hidden from other dispatchers
+	maxAttempts int
+	minBackoff  time.Duration // delay after the first failed attempt, doubled after every next one
+	maxBackoff  time.Duration
+	now         func() time.Time
+
+	*worker.Loop // Start, Stop and Notify
+}
+
+// Option configures the dispatcher
+type Option func(*Dispatcher)
+
+// WithInterval sets how often due deliveries are polled, 1s by default
+func WithInterval(interval time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.interval = interval
+	}
+}
+
+// WithBatchSize sets how many deliveries are sent at once, 20 by default
+func WithBatchSize(size int) Option {
+	return func(d *Dispatcher) {
+		d.batchSize = size
+	}
+}
+
+// WithTimeout sets the request timeout, 10s by default
+func WithTimeout(timeout time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.timeout = timeout
+	}
+}
+
+// WithPrivateAddresses allows deliveries to loopback, private and other non-public addresses,
+// for receivers on the local network. By default they fail with ErrNotPublic.
+func WithPrivateAddresses() Option {
+	return func(d *Dispatcher) {
+		d.private = true
+	}
+}
+
+// WithLease sets how long claimed deliveries are reserved for this dispatcher, 1m by default.
+// It must be longer than the request timeout.
+func WithLease(lease time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.lease = lease
+	}
+}
+
+// WithRetries sets the number of attempts and the backoff between them, by default
+// a delivery is dead after 8 attempts and the backoff grows from 10s up to 1h
+func WithRetries(maxAttempts int, minBackoff, maxBackoff time.Duration) Option {
+	return func(d *Dispatcher) {
+		d.maxAttempts = maxAttempts
+		d.minBackoff = minBackoff
+		d.maxBackoff = maxBackoff
+	}
+}
+
+func NewDispatcher(repo repository.Repository, logger *slog.Logger, opts ...Option) *Dispatcher {
+	d := &Dispatcher{
+		repo:        repo,
+		timeout:     10 * time.Second,
+		logger:      logger,
+		interval:    time.Second,
+		batchSize:   20,
+		lease:       time.Minute,
+		maxAttempts: 8,
+		minBackoff:  10 * time.Second,
+		maxBackoff:  time.Hour,
+		now:         time.Now,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	d.client = newClient(d.timeout, d.private)
+	d.Loop = worker.New("webhook dispatcher", d.interval, d.poll)
+
+	return d
+}
+
+// Publish creates a delivery of the event for every matching webhook, it makes the dispatcher an outbox sink
+func (d *Dispatcher) Publish(ctx context.Context, event *domain.Event) error {
+	webhooks, err := d.repo.GetWebhooksForEvent(ctx, event.Type, event.PostID)
+	if err != nil {
+		return fmt.Errorf("failed to get webhooks: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(Payload{
+		ID:        event.DedupID,
+		Type:      event.Type,
+		PostID:    event.PostID,
+		CreatedAt: event.CreatedAt,
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	now := d.now().UTC()
+	deliveries := make([]*domain.WebhookDelivery, len(webhooks))
+	for i, w := range webhooks {
+		deliveries[i] = &domain.WebhookDelivery{
+			WebhookID:     w.ID,
+			EventID:       event.DedupID,
+			EventType:     event.Type,
+			Payload:       payload,
+			Status:        domain.DeliveryPending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+	}
+	if err := d.repo.CreateWebhookDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("failed to create webhook deliveries: %w", err)
+	}
+
+	d.Notify()
+	return nil
+}
+
+// poll sends due deliveries until there are no more
+func (d *Dispatcher) poll(ctx context.Context) {
+	for {
+		n, err := d.DeliverPending(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				d.logger.WarnContext(ctx, "failed to deliver webhooks", "error", err)
+			}
+			return
+		}
+		if n < d.batchSize {
+			return
+		}
+	}
+}
+
+// DeliverPending claims one batch of due deliveries, sends them concurrently
+// and returns how many were attempted
+func (d *Dispatcher) DeliverPending(ctx context.Context) (int, error) {
+	deliveries, err := d.repo.ClaimWebhookDeliveries(ctx, d.batchSize, d.lease)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery *domain.WebhookDelivery) {
+			defer wg.Done()
+			d.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// deliver makes one attempt and saves its result
+func (d *Dispatcher) deliver(ctx context.Context, delivery *domain.WebhookDelivery) {
+	status, err := d.send(ctx, delivery)
+	if ctx.Err() != nil {
+		return // stopped, the delivery is claimed again once the lease ends
+	}
+
+	now := d.now().UTC()
+	delivery.Attempts++
+	delivery.ResponseStatus = status
+	switch {
+	case err == nil:
+		delivery.Status = domain.DeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= d.maxAttempts:
+		delivery.Status = domain.DeliveryDead
+		delivery.LastError = err.Error()
+		d.logger.WarnContext(ctx, "webhook delivery is dead", "webhook_id", delivery.WebhookID,
+			"event_id", delivery.EventID, "attempts", delivery.Attempts, "error", err)
+	default:
+		delivery.LastError = err.Error()
+		delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+	}
+
+	if err := d.repo.UpdateWebhookDelivery(ctx, delivery); err != nil {
+		d.logger.WarnContext(ctx, "failed to save webhook delivery", "delivery_id", delivery.ID, "error", err)
+	}
+}
+
+// send posts the signed payload to the webhook and returns the response status
+func (d *Dispatcher) send(ctx context.Context, delivery *domain.WebhookDelivery) (int, error) {
+	webhook, err := d.repo.GetWebhook(ctx, delivery.WebhookID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, delivery.Payload))
+	req.Header.Set(EventHeader, string(delivery.EventType))
+	req.Header.Set(DeliveryHeader, delivery.EventID)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // lets the connection be reused
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay after the given number of failed attempts
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.minBackoff
+	for i := 1; i < attempts && delay < d.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.maxBackoff)
+}
+
+// Sign returns the signature header value of the body: "sha256=" and the hex HMAC-SHA256 with the secret
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports if the signature header value matches the body, for receivers
+func Verify(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, body)), []byte(signature))
+}
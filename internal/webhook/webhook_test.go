@@ -0,0 +1,270 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/domain"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository"
+	"github.com/DimaGitHahahab/ozon-fintech-posts/internal/repository/in_memory"
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "0123456789abcdef"
+
+// receiver is a webhook endpoint that records the requests and answers with the statuses in order, then 200
+type receiver struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	statuses []int
+}
+
+func (rc *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.requests = append(rc.requests, req)
+	rc.bodies = append(rc.bodies, body)
+	status := http.StatusOK
+	if len(rc.statuses) > 0 {
+		status, rc.statuses = rc.statuses[0], rc.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func registerWebhook(t *testing.T, repo repository.Repository, url string, postIDs ...int) *domain.Webhook {
+	webhook, err := repo.CreateWebhook(context.Background(), &domain.Webhook{
+		OwnerID:   1,
+		URL:       url,
+		Events:    []domain.EventType{domain.EventCommentCreated},
+		PostIDs:   postIDs,
+		Secret:    secret,
+		CreatedAt: time.Now().UTC(),
+	})
+	assert.NoError(t, err)
+	return webhook
+}
+
+func commentCreated(dedupID string, postID int) *domain.Event {
+	return &domain.Event{
+		DedupID:   dedupID,
+		Type:      domain.EventCommentCreated,
+		PostID:    postID,
+		Payload:   json.RawMessage(`{"id":1}`),
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+func deliveries(t *testing.T, repo repository.Repository, webhookID int) []*domain.WebhookDelivery {
+	got, err := repo.GetWebhookDeliveries(context.Background(), webhookID, 10, 0)
+	assert.NoError(t, err)
+	return got
+}
+
+func TestDispatcher_Deliver(t *testing.T) {
+	rc := &receiver{}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	repo := in_memory.New()
+	webhook := registerWebhook(t, repo, srv.URL, 1)
+	other := registerWebhook(t, repo, srv.URL, 2)
+	d := NewDispatcher(repo, slog.Default(), WithPrivateAddresses())
+
+	assert.NoError(t, d.Publish(context.Background(), commentCreated("COMMENT_CREATED:1:a", 1)))
+	n, err := d.DeliverPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	assert.Len(t, rc.requests, 1)
+	req := rc.requests[0]
+	assert.Equal(t, string(domain.EventCommentCreated), req.Header.Get(EventHeader))
+	assert.Equal(t, "COMMENT_CREATED:1:a", req.Header.Get(DeliveryHeader))
+	assert.True(t, Verify(secret, rc.bodies[0], req.Header.Get(SignatureHeader)))
+	assert.False(t, Verify("another secret!!", rc.bodies[0], req.Header.Get(SignatureHeader)))
+
+	var payload Payload
+	assert.NoError(t, json.Unmarshal(rc.bodies[0], &payload))
+	assert.Equal(t, "COMMENT_CREATED:1:a", payload.ID)
+	assert.Equal(t, 1, payload.PostID)
+	assert.JSONEq(t, `{"id":1}`, string(payload.Data))
+
+	got := deliveries(t, repo, webhook.ID)
+	assert.Len(t, got, 1)
+	assert.Equal(t, domain.DeliveryDelivered, got[0].Status)
+	assert.Equal(t, 1, got[0].Attempts)
+	assert.Equal(t, http.StatusOK, got[0].ResponseStatus)
+	assert.NotNil(t, got[0].DeliveredAt)
+
+	assert.Empty(t, deliveries(t, repo, other.ID))
+}
+
+func TestDispatcher_Publish_Deduplicates(t *testing.T) {
+	repo := in_memory.New()
+	webhook := registerWebhook(t, repo, "http://localhost")
+	d := NewDispatcher(repo, slog.Default())
+
+	event := commentCreated("COMMENT_CREATED:1:a", 1)
+	assert.NoError(t, d.Publish(context.Background(), event))
+	assert.NoError(t, d.Publish(context.Background(), event))
+
+	assert.Len(t, deliveries(t, repo, webhook.ID), 1)
+}
+
+func TestDispatcher_Retry(t *testing.T) {
+	rc := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusBadGateway}}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	repo := in_memory.New()
+	webhook := registerWebhook(t, repo, srv.URL)
+	d := NewDispatcher(repo, slog.Default(), WithRetries(5, 0, 0), WithPrivateAddresses())
+
+	assert.NoError(t, d.Publish(context.Background(), commentCreated("COMMENT_CREATED:1:a", 1)))
+	for i := 0; i < 2; i++ {
+		_, err := d.DeliverPending(context.Background())
+		assert.NoError(t, err)
+
+		got := deliveries(t, repo, webhook.ID)[0]
+		assert.Equal(t, domain.DeliveryPending, got.Status)
+		assert.Equal(t, i+1, got.Attempts)
+		assert.Equal(t, "unexpected response status "+[]string{"500", "502"}[i], got.LastError)
+	}
+
+	_, err := d.DeliverPending(context.Background())
+	assert.NoError(t, err)
+
+	got := deliveries(t, repo, webhook.ID)[0]
+	assert.Equal(t, domain.DeliveryDelivered, got.Status)
+	assert.Equal(t, 3, got.Attempts)
+	assert.Empty(t, got.LastError)
+	assert.Len(t, rc.requests, 3)
+	assert.Equal(t, rc.requests[0].Header.Get(DeliveryHeader), rc.requests[2].Header.Get(DeliveryHeader))
+}
+
+func TestDispatcher_Dead(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	repo := in_memory.New()
+	webhook := registerWebhook(t, repo, srv.URL)
+	d := NewDispatcher(repo, slog.Default(), WithRetries(2, 0, 0), WithPrivateAddresses())
+
+	assert.NoError(t, d.Publish(context.Background(), commentCreated("COMMENT_CREATED:1:a", 1)))
+	for i := 0; i < 3; i++ {
+		_, err := d.DeliverPending(context.Background())
+		assert.NoError(t, err)
+	}
+
+	got := deliveries(t, repo, webhook.ID)[0]
+	assert.Equal(t, domain.DeliveryDead, got.Status)
+	assert.Equal(t, 2, got.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, got.ResponseStatus)
+}
+
+func TestDispatcher_Backoff(t *testing.T) {
+	d := NewDispatcher(in_memory.New(), slog.Default(), WithRetries(10, 10*time.Second, time.Minute))
+
+	assert.Equal(t, 10*time.Second, d.backoff(1))
+	assert.Equal(t, 20*time.Second, d.backoff(2))
+	assert.Equal(t, 40*time.Second, d.backoff(3))
+	assert.Equal(t, time.Minute, d.backoff(4))
+	assert.Equal(t, time.Minute, d.backoff(9))
+}
+
+func TestDispatcher_BackoffDelaysRetry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	repo := in_memory.New()
+	webhook := registerWebhook(t, repo, srv.URL)
+	d := NewDispatcher(repo, slog.Default(), WithRetries(5, time.Hour, time.Hour), WithPrivateAddresses())
+
+	assert.NoError(t, d.Publish(context.Background(), commentCreated("COMMENT_CREATED:1:a", 1)))
+	n, err := d.DeliverPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	n, err = d.DeliverPending(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	got := deliveries(t, repo, webhook.ID)[0]
+	assert.WithinDuration(t, time.Now().Add(time.Hour), got.NextAttemptAt, time.Minute)
+}
+
+func TestDispatcher_NotPublic(t *testing.T) {
+	rc := &receiver{}
+	srv := httptest.NewServer(rc)
+	defer srv.Close()
+
+	repo := in_memory.New()
+	webhook := registerWebhook(t, repo, srv.URL)
+	d := NewDispatcher(repo, slog.Default(), WithRetries(1, 0, 0))
+
+	assert.NoError(t, d.Publish(context.Background(), commentCreated("COMMENT_CREATED:1:a", 1)))
+	_, err := d.DeliverPending(context.Background())
+	assert.NoError(t, err)
+
+	// the connection is refused before anything is sent
+	assert.Empty(t, rc.requests)
+	got := deliveries(t, repo, webhook.ID)
+	assert.Len(t, got, 1)
+	assert.Equal(t, domain.DeliveryDead, got[0].Status)
+	assert.Contains(t, got[0].LastError, ErrNotPublic.Error())
+}
+
+func TestDispatcher_NoRedirects(t *testing.T) {
+	target := &receiver{}
+	targetSrv := httptest.NewServer(target)
+	defer targetSrv.Close()
+	srv := httptest.NewServer(http.RedirectHandler(targetSrv.URL, http.StatusTemporaryRedirect))
+	defer srv.Close()
+
+	repo := in_memory.New()
+	webhook := registerWebhook(t, repo, srv.URL)
+	d := NewDispatcher(repo, slog.Default(), WithRetries(1, 0, 0), WithPrivateAddresses())
+
+	assert.NoError(t, d.Publish(context.Background(), commentCreated("COMMENT_CREATED:1:a", 1)))
+	_, err := d.DeliverPending(context.Background())
+	assert.NoError(t, err)
+
+	assert.Empty(t, target.requests)
+	got := deliveries(t, repo, webhook.ID)
+	assert.Len(t, got, 1)
+	assert.Equal(t, domain.DeliveryDead, got[0].Status)
+	assert.Equal(t, http.StatusTemporaryRedirect, got[0].ResponseStatus)
+}
+
+func TestCheckURL(t *testing.T) {
+	for _, rawURL := range []string{
+		"http://127.0.0.1/hook",
+		"http://localhost:8080/hook",
+		"http://10.1.2.3/hook",
+		"http://169.254.169.254/latest/meta-data",
+		"http://[::1]/hook",
+		"http://[::ffff:192.168.0.1]/hook",
+		"http://100.64.0.1/hook",
+		"http://0.0.0.0/hook",
+		"http://[fd00::1]/hook",
+	} {
+		assert.ErrorIs(t, CheckURL(context.Background(), rawURL), ErrNotPublic, rawURL)
+	}
+
+	for _, rawURL := range []string{"https://1.1.1.1/hook", "http://[2606:4700:4700::1111]:8080/hook"} {
+		assert.NoError(t, CheckURL(context.Background(), rawURL), rawURL)
+	}
+}
@@ -0,0 +1,87 @@
+// Package worker runs a function in the background on a ticker or when notified
+package worker
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Loop calls its function every interval, and right away after Notify, until it is stopped
+type Loop struct {
+	name     string // used in errors
+	interval time.Duration
+	fn       func(ctx context.Context)
+
+	wake   chan struct{}
+	mu     sync.Mutex
+	cancel context.CancelFunc // stops the running loop, nil when not started
+	done   chan struct{}      // closed when the loop returns
+}
+
+func New(name string, interval time.Duration, fn func(ctx context.Context)) *Loop {
+	return &Loop{
+		name:     name,
+		interval: interval,
+		fn:       fn,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Notify makes the loop call its function now instead of waiting for the next tick
+func (l *Loop) Notify() {
+	select {
+	case l.wake <- struct{}{}:
+	default: // a call is already pending
+	}
+}
+
+// Start runs the loop in the background until Stop
+func (l *Loop) Start() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	l.cancel = cancel
+	l.done = make(chan struct{})
+	go l.run(ctx, l.done)
+}
+
+// Stop cancels the context of the function and waits for it to return until the context is done
+func (l *Loop) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	cancel, done := l.cancel, l.done
+	l.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%s is still running: %w", l.name, ctx.Err())
+	}
+}
+
+func (l *Loop) run(ctx context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-l.wake:
+		}
+		l.fn(ctx)
+	}
+}
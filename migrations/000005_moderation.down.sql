@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS banned_authors;
+DROP TABLE IF EXISTS moderation_records;
+DROP TABLE IF EXISTS reports;
+
+ALTER TABLE comments DROP COLUMN IF EXISTS hidden;
+ALTER TABLE posts DROP COLUMN IF EXISTS hidden;
@@ -0,0 +1,35 @@
+ALTER TABLE posts ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE comments ADD COLUMN hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE TABLE reports
+(
+    id          SERIAL PRIMARY KEY,
+    target_type TEXT      NOT NULL,
+    target_id   INT       NOT NULL,
+    reporter_id INT       NOT NULL,
+    reason      TEXT      NOT NULL,
+    status      TEXT      NOT NULL DEFAULT 'OPEN',
+    created_at  TIMESTAMP NOT NULL,
+    resolved_at TIMESTAMP
+);
+
+CREATE TABLE moderation_records
+(
+    id           SERIAL PRIMARY KEY,
+    report_id    INT       NOT NULL,
+    moderator_id INT       NOT NULL,
+    action       TEXT      NOT NULL,
+    target_type  TEXT      NOT NULL,
+    target_id    INT       NOT NULL,
+    author_id    INT       NOT NULL,
+    created_at   TIMESTAMP NOT NULL,
+    FOREIGN KEY (report_id) REFERENCES reports (id)
+);
+
+CREATE TABLE banned_authors
+(
+    author_id  INT PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_reports_status ON reports(status, id);
@@ -0,0 +1,9 @@
+CREATE UNLOGGED TABLE rate_limit_buckets
+(
+    key        TEXT PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ      NOT NULL,
+    expires_at TIMESTAMPTZ      NOT NULL -- when the bucket is full again
+);
+
+CREATE INDEX idx_rate_limit_buckets_expires_at ON rate_limit_buckets(expires_at);
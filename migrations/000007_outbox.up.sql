@@ -0,0 +1,13 @@
+CREATE TABLE outbox
+(
+    id           SERIAL PRIMARY KEY,
+    dedup_id     TEXT      NOT NULL UNIQUE,
+    type         TEXT      NOT NULL,
+    post_id      INT       NOT NULL,
+    payload      JSONB     NOT NULL,
+    created_at   TIMESTAMP NOT NULL,
+    locked_until TIMESTAMPTZ, -- claimed by a relay until then
+    published_at TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_pending ON outbox(id) WHERE published_at IS NULL;
@@ -0,0 +1,32 @@
+CREATE TABLE webhooks
+(
+    id         SERIAL PRIMARY KEY,
+    owner_id   INT       NOT NULL,
+    url        TEXT      NOT NULL,
+    events     TEXT[]    NOT NULL,
+    post_ids   INT[]     NOT NULL DEFAULT '{}', -- empty watches every post
+    secret     TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_webhooks_owner_id ON webhooks(owner_id);
+
+CREATE TABLE webhook_deliveries
+(
+    id              SERIAL PRIMARY KEY,
+    webhook_id      INT         NOT NULL,
+    event_id        TEXT        NOT NULL,
+    event_type      TEXT        NOT NULL,
+    payload         JSONB       NOT NULL,
+    status          TEXT        NOT NULL DEFAULT 'PENDING',
+    attempts        INT         NOT NULL DEFAULT 0,
+    response_status INT         NOT NULL DEFAULT 0,
+    last_error      TEXT        NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMP   NOT NULL,
+    delivered_at    TIMESTAMP,
+    UNIQUE (webhook_id, event_id),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
@@ -0,0 +1,27 @@
+CREATE TABLE posts
+(
+    id                INTEGER PRIMARY KEY AUTOINCREMENT,
+    title             TEXT      NOT NULL,
+    content           TEXT      NOT NULL,
+    author_id         INTEGER   NOT NULL,
+    created_at        TIMESTAMP NOT NULL,
+    comments_disabled BOOLEAN   NOT NULL DEFAULT FALSE,
+    hidden            BOOLEAN   NOT NULL DEFAULT FALSE
+);
+
+CREATE TABLE comments
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    post_id    INTEGER   NOT NULL,
+    parent_id  INTEGER,
+    author_id  INTEGER   NOT NULL,
+    content    TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    hidden     BOOLEAN   NOT NULL DEFAULT FALSE,
+    FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE,
+    FOREIGN KEY (parent_id) REFERENCES comments (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_post_id ON comments(post_id);
+CREATE INDEX idx_parent_id ON comments(parent_id);
+CREATE INDEX idx_created_at ON comments(created_at);
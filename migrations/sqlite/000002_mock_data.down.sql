@@ -0,0 +1,2 @@
+DELETE FROM comments;
+DELETE FROM posts;
@@ -0,0 +1,22 @@
+INSERT INTO posts (title, content, author_id, created_at, comments_disabled)
+VALUES ('Breaking news!', 'No way! Something happened!', 2, CURRENT_TIMESTAMP, FALSE),
+       ('Hello Reddit', 'This is my first post here', 1, CURRENT_TIMESTAMP, FALSE),
+       ('News', 'Something is happening again!', 2, CURRENT_TIMESTAMP, FALSE);
+
+-- comments to posts
+INSERT INTO comments (post_id, parent_id, author_id, content, created_at)
+VALUES (1, NULL, 1, 'Wow, this is some breaking news indeed!', CURRENT_TIMESTAMP),
+       (1, NULL, 2, 'I cannot believe this is happening!', CURRENT_TIMESTAMP),
+       (2, NULL, 3, 'Welcome to Reddit! Looking forward to your posts.', CURRENT_TIMESTAMP),
+       (2, NULL, 1, 'Nice to see new faces around here.', CURRENT_TIMESTAMP),
+       (3, NULL, 2, 'Again? This is getting interesting.', CURRENT_TIMESTAMP),
+       (3, NULL, 1, 'I wonder what will happen next.', CURRENT_TIMESTAMP);
+
+-- comments to comments
+INSERT INTO comments (post_id, parent_id, author_id, content, created_at)
+VALUES (1, 1, 2, 'I agree, this is indeed breaking news!', CURRENT_TIMESTAMP),
+       (1, 2, 3, 'Me too', CURRENT_TIMESTAMP),
+       (2, 3, 1, 'Thank you for the warm welcome!', CURRENT_TIMESTAMP),
+       (2, 4, 2, 'It is always nice to see new people!', CURRENT_TIMESTAMP),
+       (3, 5, 3, 'It is indeed getting interesting.', CURRENT_TIMESTAMP),
+       (3, 6, 1, 'I am also curious about that', CURRENT_TIMESTAMP);
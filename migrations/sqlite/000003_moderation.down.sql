@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS banned_authors;
+DROP TABLE IF EXISTS moderation_records;
+DROP TABLE IF EXISTS reports;
@@ -0,0 +1,32 @@
+CREATE TABLE reports
+(
+    id          INTEGER PRIMARY KEY AUTOINCREMENT,
+    target_type TEXT      NOT NULL,
+    target_id   INTEGER   NOT NULL,
+    reporter_id INTEGER   NOT NULL,
+    reason      TEXT      NOT NULL,
+    status      TEXT      NOT NULL DEFAULT 'OPEN',
+    created_at  TIMESTAMP NOT NULL,
+    resolved_at TIMESTAMP
+);
+
+CREATE TABLE moderation_records
+(
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    report_id    INTEGER   NOT NULL,
+    moderator_id INTEGER   NOT NULL,
+    action       TEXT      NOT NULL,
+    target_type  TEXT      NOT NULL,
+    target_id    INTEGER   NOT NULL,
+    author_id    INTEGER   NOT NULL,
+    created_at   TIMESTAMP NOT NULL,
+    FOREIGN KEY (report_id) REFERENCES reports (id)
+);
+
+CREATE TABLE banned_authors
+(
+    author_id  INTEGER PRIMARY KEY,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_reports_status ON reports(status, id);
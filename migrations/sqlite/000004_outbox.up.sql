@@ -0,0 +1,13 @@
+CREATE TABLE outbox
+(
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    dedup_id     TEXT      NOT NULL UNIQUE,
+    type         TEXT      NOT NULL,
+    post_id      INTEGER   NOT NULL,
+    payload      TEXT      NOT NULL, -- JSON
+    created_at   TIMESTAMP NOT NULL,
+    locked_until TIMESTAMP, -- claimed by a relay until then
+    published_at TIMESTAMP
+);
+
+CREATE INDEX idx_outbox_pending ON outbox(id) WHERE published_at IS NULL;
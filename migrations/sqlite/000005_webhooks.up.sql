@@ -0,0 +1,32 @@
+CREATE TABLE webhooks
+(
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner_id   INTEGER   NOT NULL,
+    url        TEXT      NOT NULL,
+    events     TEXT      NOT NULL,              -- JSON array of event types
+    post_ids   TEXT      NOT NULL DEFAULT '[]', -- JSON array, empty watches every post
+    secret     TEXT      NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_webhooks_owner_id ON webhooks(owner_id);
+
+CREATE TABLE webhook_deliveries
+(
+    id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id      INTEGER   NOT NULL,
+    event_id        TEXT      NOT NULL,
+    event_type      TEXT      NOT NULL,
+    payload         TEXT      NOT NULL, -- JSON
+    status          TEXT      NOT NULL DEFAULT 'PENDING',
+    attempts        INTEGER   NOT NULL DEFAULT 0,
+    response_status INTEGER   NOT NULL DEFAULT 0,
+    last_error      TEXT      NOT NULL DEFAULT '',
+    next_attempt_at TIMESTAMP NOT NULL,
+    created_at      TIMESTAMP NOT NULL,
+    delivered_at    TIMESTAMP,
+    UNIQUE (webhook_id, event_id),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks (id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_webhook_deliveries_pending ON webhook_deliveries(next_attempt_at) WHERE status = 'PENDING';
@@ -1,7 +1,9 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
@@ -11,7 +13,187 @@ type Config struct {
 	HTTPPort      string `envconfig:"HTTP_PORT" required:"true"`
 	DbURL         string `envconfig:"DB_URL"`
 	MigrationPath string `envconfig:"MIGRATION_PATH"`
-	Repository    string `envconfig:"REPOSITORY" default:"IN_MEMORY" required:"true"` // IN_MEMORY, POSTGRES
+	AutoMigrate   bool   `envconfig:"AUTO_MIGRATE" default:"true"`                    // migrate up at startup, off when migrations run as a separate job
+	TxIsolation   string `envconfig:"DB_TX_ISOLATION" default:"SERIALIZABLE"`         // READ_COMMITTED, REPEATABLE_READ, SERIALIZABLE
+	Repository    string `envconfig:"REPOSITORY" default:"IN_MEMORY" required:"true"` // IN_MEMORY, POSTGRES, SQLITE
+	ModeratorIDs  []int  `envconfig:"MODERATOR_IDS"`                                  // user ids allowed to moderate content
+	Metrics       bool   `envconfig:"METRICS" default:"true"`                         // serve Prometheus metrics on /metrics
+
+	Pool      PoolConfig      `envconfig:"DB_POOL"`
+	Replica   ReplicaConfig   `envconfig:"DB_REPLICA"`
+	Memory    MemoryConfig    `envconfig:"MEMORY"`
+	SQLite    SQLiteConfig    `envconfig:"SQLITE"`
+	Filter    FilterConfig    `envconfig:"FILTER"`
+	RateLimit RateLimitConfig `envconfig:"RATE_LIMIT"`
+	Query     QueryConfig     `envconfig:"QUERY"`
+	Tracing   TracingConfig   `envconfig:"TRACING"`
+	Log       LogConfig       `envconfig:"LOG"`
+	Shutdown  ShutdownConfig  `envconfig:"SHUTDOWN"`
+	Outbox    OutboxConfig    `envconfig:"OUTBOX"`
+	Cache     CacheConfig     `envconfig:"CACHE"`
+	Webhook   WebhookConfig   `envconfig:"WEBHOOK"`
+
+	PersistedQueries PersistedQueriesConfig `envconfig:"PERSISTED_QUERIES"`
+}
+
+// PoolConfig configures the Postgres connection pools, the replicas get the same settings.
+// They override the pool_* parameters of the URLs.
+type PoolConfig struct {
+	MaxConns          int32         `envconfig:"MAX_CONNS" default:"10"`
+	MinConns          int32         `envconfig:"MIN_CONNS" default:"0"`            // kept open even when idle
+	MaxConnLifetime   time.Duration `envconfig:"MAX_CONN_LIFETIME" default:"1h"`   // connections are replaced after it
+	MaxConnIdleTime   time.Duration `envconfig:"MAX_CONN_IDLE_TIME" default:"30m"` // idle connections over MinConns are closed after it
+	HealthCheckPeriod time.Duration `envconfig:"HEALTH_CHECK_PERIOD" default:"1m"` // how often idle connections are checked
+	StatementTimeout  time.Duration `envconfig:"STATEMENT_TIMEOUT" default:"30s"`  // the server cancels longer statements, 0 disables it
+	QueryTimeout      time.Duration `envconfig:"QUERY_TIMEOUT" default:"10s"`      // the client cancels longer queries, 0 disables it
+	ApplicationName   string        `envconfig:"APPLICATION_NAME" default:"posts"`
+}
+
+// Validate checks that the pool settings make sense
+func (c PoolConfig) Validate() error {
+	var errs []error
+	if c.MaxConns < 1 {
+		errs = append(errs, fmt.Errorf("DB_POOL_MAX_CONNS must be positive, got %d", c.MaxConns))
+	}
+	if c.MinConns < 0 || c.MinConns > c.MaxConns {
+		errs = append(errs, fmt.Errorf("DB_POOL_MIN_CONNS must be from 0 to DB_POOL_MAX_CONNS, got %d", c.MinConns))
+	}
+	if c.MaxConnLifetime <= 0 || c.MaxConnIdleTime <= 0 || c.HealthCheckPeriod <= 0 {
+		errs = append(errs, errors.New("DB_POOL_MAX_CONN_LIFETIME, DB_POOL_MAX_CONN_IDLE_TIME and DB_POOL_HEALTH_CHECK_PERIOD must be positive"))
+	}
+	if c.StatementTimeout < 0 || c.QueryTimeout < 0 {
+		errs = append(errs, errors.New("DB_POOL_STATEMENT_TIMEOUT and DB_POOL_QUERY_TIMEOUT must not be negative"))
+	}
+	if c.StatementTimeout%time.Millisecond != 0 {
+		errs = append(errs, fmt.Errorf("DB_POOL_STATEMENT_TIMEOUT must be whole milliseconds, got %s", c.StatementTimeout))
+	}
+	return errors.Join(errs...)
+}
+
+// ReplicaConfig configures the read replicas of the Postgres repository
+type ReplicaConfig struct {
+	URLs          []string      `envconfig:"URLS"` // comma separated, every read goes to the primary if empty
+	CheckInterval time.Duration `envconfig:"CHECK_INTERVAL" default:"5s"`
+	MaxLag        time.Duration `envconfig:"MAX_LAG" default:"10s"` // replicas further behind are skipped, 0 disables the check
+	// reads of a client go to the primary this long after its last mutation, 0 disables it
+	ReadYourWrites time.Duration `envconfig:"READ_YOUR_WRITES" default:"15s"`
+}
+
+// MemoryConfig configures persistence of the in-memory repository
+type MemoryConfig struct {
+	Dir              string        `envconfig:"DIR"` // snapshot and write-ahead log directory, nothing is persisted if empty
+	SnapshotInterval time.Duration `envconfig:"SNAPSHOT_INTERVAL" default:"5m"`
+	SnapshotAfter    int           `envconfig:"SNAPSHOT_AFTER" default:"10000"` // logged writes that trigger a snapshot early
+}
+
+// SQLiteConfig configures the SQLite repository
+type SQLiteConfig struct {
+	Path          string `envconfig:"PATH" default:"posts.db"` // database file, created if missing
+	MigrationPath string `envconfig:"MIGRATION_PATH" default:"file://migrations/sqlite"`
+}
+
+// FilterConfig configures the content filter chain for new posts and comments.
+// Actions are REJECT, FLAG or REWRITE.
+type FilterConfig struct {
+	Chain               []string      `envconfig:"CHAIN" default:"banned_words,link_limit,repeated_chars,duplicate"` // filters in order
+	BannedWords         []string      `envconfig:"BANNED_WORDS"`
+	BannedWordsAction   string        `envconfig:"BANNED_WORDS_ACTION" default:"REWRITE"`
+	MaxLinks            int           `envconfig:"MAX_LINKS" default:"3"`
+	MaxLinksAction      string        `envconfig:"MAX_LINKS_ACTION" default:"FLAG"`
+	MaxRepeatedChars    int           `envconfig:"MAX_REPEATED_CHARS" default:"10"`
+	RepeatedCharsAction string        `envconfig:"REPEATED_CHARS_ACTION" default:"REWRITE"`
+	DuplicateWindow     time.Duration `envconfig:"DUPLICATE_WINDOW" default:"10m"`
+	DuplicateAction     string        `envconfig:"DUPLICATE_ACTION" default:"REJECT"`
+}
+
+// RateLimitConfig configures mutation budgets, zero disables the limit
+type RateLimitConfig struct {
+	Store             string `envconfig:"STORE" default:"MEMORY"` // MEMORY, POSTGRES
+	PostsPerHour      int    `envconfig:"POSTS_PER_HOUR" default:"20"`
+	CommentsPerMinute int    `envconfig:"COMMENTS_PER_MINUTE" default:"10"`
+	ReportsPerHour    int    `envconfig:"REPORTS_PER_HOUR" default:"30"`
+	// per client IP, shared by the users behind a NAT or a proxy
+	PostsPerHourIP      int  `envconfig:"POSTS_PER_HOUR_IP" default:"100"`
+	CommentsPerMinuteIP int  `envconfig:"COMMENTS_PER_MINUTE_IP" default:"60"`
+	ReportsPerHourIP    int  `envconfig:"REPORTS_PER_HOUR_IP" default:"150"`
+	TrustProxyHeaders   bool `envconfig:"TRUST_PROXY_HEADERS"`         // take client IP from X-Forwarded-For
+	TrustedProxies      int  `envconfig:"TRUSTED_PROXIES" default:"1"` // proxies appending to X-Forwarded-For in front of the server
+}
+
+// Validate checks that the rate limit settings make sense
+func (c RateLimitConfig) Validate() error {
+	if c.TrustProxyHeaders && c.TrustedProxies < 1 {
+		return fmt.Errorf("RATE_LIMIT_TRUSTED_PROXIES must be positive with RATE_LIMIT_TRUST_PROXY_HEADERS, got %d", c.TrustedProxies)
+	}
+	return nil
+}
+
+// QueryConfig limits GraphQL documents before execution, zero disables a limit
+type QueryConfig struct {
+	MaxDepth        int `envconfig:"MAX_DEPTH" default:"10"`
+	MaxAliases      int `envconfig:"MAX_ALIASES" default:"20"`
+	MaxCost         int `envconfig:"MAX_COST" default:"10000"`
+	DefaultListSize int `envconfig:"DEFAULT_LIST_SIZE" default:"100"` // cost multiplier of lists without first/limit
+}
+
+// PersistedQueriesConfig configures automatic persisted queries and the query allowlist
+type PersistedQueriesConfig struct {
+	CacheSize     int    `envconfig:"CACHE_SIZE" default:"1000"` // registered queries kept in memory, 0 disables registration
+	Manifest      string `envconfig:"MANIFEST"`                  // path to a manifest of known queries
+	AllowlistOnly bool   `envconfig:"ALLOWLIST_ONLY"`            // execute only queries from the manifest
+}
+
+// TracingConfig configures the OpenTelemetry trace exporter
+type TracingConfig struct {
+	Exporter    string  `envconfig:"EXPORTER" default:"NONE"` // NONE, OTLP, STDOUT, FILE
+	Endpoint    string  `envconfig:"ENDPOINT"`                // OTLP/HTTP endpoint URL, OTEL_EXPORTER_OTLP_* variables are used if empty
+	File        string  `envconfig:"FILE"`                    // output of the FILE exporter
+	ServiceName string  `envconfig:"SERVICE_NAME" default:"posts"`
+	SampleRatio float64 `envconfig:"SAMPLE_RATIO" default:"1"` // share of traces started here that are recorded
+}
+
+// LogConfig configures the service logger
+type LogConfig struct {
+	Level  string `envconfig:"LEVEL" default:"INFO"`  // DEBUG, INFO, WARN, ERROR
+	Format string `envconfig:"FORMAT" default:"TEXT"` // TEXT, JSON
+}
+
+// ShutdownConfig sets the timeouts of the graceful shutdown stages
+type ShutdownConfig struct {
+	ReadinessDelay       time.Duration `envconfig:"READINESS_DELAY" default:"0s"` // time for load balancers to notice the server is not ready
+	HTTPTimeout          time.Duration `envconfig:"HTTP_TIMEOUT" default:"15s"`
+	SubscriptionsTimeout time.Duration `envconfig:"SUBSCRIPTIONS_TIMEOUT" default:"5s"`
+	BackgroundTimeout    time.Duration `envconfig:"BACKGROUND_TIMEOUT" default:"5s"`
+	DatabaseTimeout      time.Duration `envconfig:"DATABASE_TIMEOUT" default:"5s"`
+}
+
+// OutboxConfig configures the relay publishing outbox events to the subscriptions and the extra sinks
+type OutboxConfig struct {
+	PollInterval time.Duration `envconfig:"POLL_INTERVAL" default:"1s"` // mutations wake the relay too
+	BatchSize    int           `envconfig:"BATCH_SIZE" default:"100"`
+	Lease        time.Duration `envconfig:"LEASE" default:"30s"`         // events not published by then are claimed again
+	Retention    time.Duration `envconfig:"RETENTION" default:"168h"`    // published events are deleted after it, 0 keeps them
+	Sinks        []string      `envconfig:"SINKS"`                       // LOG, FILE
+	File         string        `envconfig:"FILE" default:"events.jsonl"` // output of the FILE sink
+}
+
+// CacheConfig configures the cache of hot reads and the HTTP caching of GET queries
+type CacheConfig struct {
+	Size   int           `envconfig:"SIZE" default:"10000"` // posts and comment pages kept, 0 disables the cache
+	TTL    time.Duration `envconfig:"TTL" default:"30s"`    // other instances' writes show up after it at the latest
+	MaxAge time.Duration `envconfig:"MAX_AGE" default:"5s"` // Cache-Control max-age of GET query responses, 0 makes clients revalidate
+}
+
+// WebhookConfig configures the dispatcher sending outbox events to the registered webhooks
+type WebhookConfig struct {
+	PollInterval time.Duration `envconfig:"POLL_INTERVAL" default:"1s"` // new deliveries wake the dispatcher too
+	BatchSize    int           `envconfig:"BATCH_SIZE" default:"20"`    // deliveries sent concurrently
+	Timeout      time.Duration `envconfig:"TIMEOUT" default:"10s"`
+	Lease        time.Duration `envconfig:"LEASE" default:"1m"` // must be longer than the timeout
+	MaxAttempts  int           `envconfig:"MAX_ATTEMPTS" default:"8"`
+	MinBackoff   time.Duration `envconfig:"MIN_BACKOFF" default:"10s"` // doubled after every failed attempt
+	MaxBackoff   time.Duration `envconfig:"MAX_BACKOFF" default:"1h"`
+	AllowPrivate bool          `envconfig:"ALLOW_PRIVATE_ADDRESSES"` // deliver to loopback and private addresses, for local receivers
 }
 
 func LoadConfig() (*Config, error) {
@@ -25,5 +207,12 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to process env variables: %w", err)
 	}
 
+	if err := cfg.Pool.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid database pool config: %w", err)
+	}
+	if err := cfg.RateLimit.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid rate limit config: %w", err)
+	}
+
 	return &cfg, nil
 }